@@ -7,63 +7,130 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/redis/go-redis/v9"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
 	"github.com/ucchy108/whiskey/backend/domain/service"
 	"github.com/ucchy108/whiskey/backend/infrastructure/auth"
+	"github.com/ucchy108/whiskey/backend/infrastructure/csvimport"
 	"github.com/ucchy108/whiskey/backend/infrastructure/database"
 	"github.com/ucchy108/whiskey/backend/infrastructure/email"
+	"github.com/ucchy108/whiskey/backend/infrastructure/oidc"
+	"github.com/ucchy108/whiskey/backend/infrastructure/ratelimit"
 	"github.com/ucchy108/whiskey/backend/infrastructure/router"
 	"github.com/ucchy108/whiskey/backend/infrastructure/storage"
 	"github.com/ucchy108/whiskey/backend/interfaces/handler"
 	"github.com/ucchy108/whiskey/backend/usecase"
 )
 
+// BackgroundJobs はサーバーと並行して定期実行するジョブが使用するUsecaseを表す。
+type BackgroundJobs struct {
+	// AccountUsecase は削除予定日時を過ぎたアカウントの削除に使用する
+	AccountUsecase usecase.AccountUsecaseInterface
+	// DailyScoreUsecase はデイリースコアの計算方法を変更したユーザーのスコアの再計算に使用する
+	DailyScoreUsecase usecase.DailyScoreUsecaseInterface
+}
+
 // BuildRouterConfig はDB接続とRedisクライアントからルーター設定を構築する。
 //
 // Clean Architectureの各レイヤーを内側から外側へ順に初期化し、
 // 依存関係を注入した router.RouterConfig を返す。
+// あわせて、定期実行するジョブが使用するUsecaseを BackgroundJobs として返す。
 //
 // パラメータ:
 //   - db: PostgreSQLデータベース接続
 //   - redisClient: Redisクライアント
+//   - oidcProviders: 外部IDプロバイダ（OpenID Connect）の設定。空の場合は外部IDプロバイダでのログインは無効
 //
 // 戻り値:
 //   - router.RouterConfig: 全ハンドラーとセッションリポジトリを含むルーター設定
-func BuildRouterConfig(db *sql.DB, redisClient *redis.Client, s3Client *s3.Client, s3Bucket string, s3Endpoint string, s3ExternalEndpoint string, smtpHost string, smtpPort string, frontendURL string) router.RouterConfig {
+//   - BackgroundJobs: 定期実行するジョブが使用するUsecase
+func BuildRouterConfig(db *sql.DB, redisClient *redis.Client, s3Client *s3.Client, s3Bucket string, s3Endpoint string, s3ExternalEndpoint string, smtpHost string, smtpPort string, frontendURL string, oidcProviders []oidc.ProviderConfig) (router.RouterConfig, BackgroundJobs) {
 	// Infrastructure層
 	userRepo := database.NewUserRepository(db)
 	sessionStore := auth.NewSessionStore(redisClient)
+	twoFactorChallengeStore := auth.NewTwoFactorChallengeStore(redisClient)
+	oidcStateStore := auth.NewOIDCStateStore(redisClient)
+	rateLimiter := ratelimit.NewRedisRateLimiter(redisClient)
 	workoutRepo := database.NewWorkoutRepository(db)
 	workoutSetRepo := database.NewWorkoutSetRepository(db)
 	exerciseRepo := database.NewExerciseRepository(db)
+	profileRepo := database.NewProfileRepository(db)
+	personalRecordRepo := database.NewPersonalRecordRepository(db)
+	routineRepo := database.NewRoutineRepository(db)
+	programRepo := database.NewProgramRepository(db)
+	userIdentityRepo := database.NewUserIdentityRepository(db)
+	analyticsRepo := database.NewAnalyticsRepository(db)
+	txManager := database.NewTransactionManager(db)
+
+	providers := make(map[string]repository.OIDCProvider, len(oidcProviders))
+	for _, config := range oidcProviders {
+		provider := oidc.NewProvider(config, nil)
+		providers[provider.Name()] = provider
+	}
 
 	// Domain層
 	userService := service.NewUserService(userRepo)
 	workoutService := service.NewWorkoutService(workoutRepo)
 	exerciseService := service.NewExerciseService(exerciseRepo)
+	streakService := service.NewStreakService(workoutRepo)
+	dailyScoreService := service.NewDailyScoreService(workoutRepo, workoutSetRepo)
 
 	// Usecase層
-	sessionTTL := 24 * time.Hour
+	// セッションは無操作で24時間（ログインしたままにする場合は30日）経過すると失効し、
+	// アクセスがあっても最長30日で再ログインが必要になる
+	sessionPolicy := usecase.SessionPolicy{
+		TTL:           24 * time.Hour,
+		RememberMeTTL: 30 * 24 * time.Hour,
+		MaxLifetime:   30 * 24 * time.Hour,
+	}
 	emailSender := email.NewSmtpSender(smtpHost, smtpPort, frontendURL)
-	userUsecase := usecase.NewUserUsecase(userRepo, userService, sessionStore, emailSender, sessionTTL)
-	workoutUsecase := usecase.NewWorkoutUsecase(workoutRepo, workoutSetRepo, exerciseRepo, workoutService)
+	userUsecase := usecase.NewUserUsecase(userRepo, userService, sessionStore, twoFactorChallengeStore, emailSender, rateLimiter, sessionPolicy)
+	oidcUsecase := usecase.NewOIDCUsecase(providers, oidcStateStore, userRepo, userIdentityRepo, userUsecase, txManager)
+	workoutUsecase := usecase.NewWorkoutUsecase(workoutRepo, workoutSetRepo, exerciseRepo, profileRepo, personalRecordRepo, programRepo, workoutService, dailyScoreService, txManager)
 	exerciseUsecase := usecase.NewExerciseUsecase(exerciseRepo, exerciseService)
+	personalRecordUsecase := usecase.NewPersonalRecordUsecase(personalRecordRepo, exerciseRepo)
+	routineUsecase := usecase.NewRoutineUsecase(routineRepo, exerciseRepo, workoutUsecase, txManager)
+	programUsecase := usecase.NewProgramUsecase(programRepo, exerciseRepo, workoutSetRepo, txManager)
+	importUsecase := usecase.NewImportUsecase(csvimport.Parsers(), exerciseRepo, workoutUsecase)
+	analyticsUsecase := usecase.NewAnalyticsUsecase(analyticsRepo)
+	statsUsecase := usecase.NewStatsUsecase(profileRepo, streakService)
+	dailyScoreUsecase := usecase.NewDailyScoreUsecase(profileRepo, workoutRepo, workoutSetRepo, dailyScoreService, txManager)
 
 	// Profile + ObjectStorage
-	profileRepo := database.NewProfileRepository(db)
 	objectStorage := storage.NewS3ObjectStorage(s3Client, s3Bucket, s3Endpoint, s3ExternalEndpoint)
-	profileUsecase := usecase.NewProfileUsecase(profileRepo, objectStorage)
+	profileUsecase := usecase.NewProfileUsecase(profileRepo, workoutRepo, workoutSetRepo, personalRecordRepo, objectStorage, txManager)
+	accountUsecase := usecase.NewAccountUsecase(userRepo, profileRepo, workoutRepo, workoutSetRepo, exerciseRepo, routineRepo, programRepo, sessionStore, objectStorage, txManager)
 
 	// Interface層
 	userHandler := handler.NewUserHandler(userUsecase)
-	workoutHandler := handler.NewWorkoutHandler(workoutUsecase)
+	accountHandler := handler.NewAccountHandler(accountUsecase)
+	oidcHandler := handler.NewOIDCHandler(oidcUsecase, frontendURL)
+	workoutHandler := handler.NewWorkoutHandler(workoutUsecase, profileUsecase, csvimport.NewExportWriter)
 	exerciseHandler := handler.NewExerciseHandler(exerciseUsecase)
 	profileHandler := handler.NewProfileHandler(profileUsecase)
+	personalRecordHandler := handler.NewPersonalRecordHandler(personalRecordUsecase)
+	routineHandler := handler.NewRoutineHandler(routineUsecase, profileUsecase)
+	programHandler := handler.NewProgramHandler(programUsecase)
+	importHandler := handler.NewImportHandler(importUsecase, profileUsecase)
+	analyticsHandler := handler.NewAnalyticsHandler(analyticsUsecase, profileUsecase)
+	statsHandler := handler.NewStatsHandler(statsUsecase)
 
 	return router.RouterConfig{
-		UserHandler:     userHandler,
-		WorkoutHandler:  workoutHandler,
-		ExerciseHandler: exerciseHandler,
-		ProfileHandler:  profileHandler,
-		SessionRepo:     sessionStore,
+		UserHandler:           userHandler,
+		AccountHandler:        accountHandler,
+		OIDCHandler:           oidcHandler,
+		WorkoutHandler:        workoutHandler,
+		ExerciseHandler:       exerciseHandler,
+		ProfileHandler:        profileHandler,
+		PersonalRecordHandler: personalRecordHandler,
+		RoutineHandler:        routineHandler,
+		ProgramHandler:        programHandler,
+		ImportHandler:         importHandler,
+		AnalyticsHandler:      analyticsHandler,
+		StatsHandler:          statsHandler,
+		SessionRepo:           sessionStore,
+		RateLimiter:           rateLimiter,
+	}, BackgroundJobs{
+		AccountUsecase:    accountUsecase,
+		DailyScoreUsecase: dailyScoreUsecase,
 	}
 }
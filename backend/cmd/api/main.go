@@ -7,6 +7,8 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/credentials"
@@ -14,8 +16,10 @@ import (
 	_ "github.com/lib/pq"
 	"github.com/redis/go-redis/v9"
 	"github.com/ucchy108/whiskey/backend/cmd/api/di"
+	"github.com/ucchy108/whiskey/backend/infrastructure/oidc"
 	"github.com/ucchy108/whiskey/backend/infrastructure/router"
 	"github.com/ucchy108/whiskey/backend/pkg/logger"
+	"github.com/ucchy108/whiskey/backend/usecase"
 )
 
 func main() {
@@ -95,10 +99,23 @@ func main() {
 	smtpPort := getEnv("SMTP_PORT", "1025")
 	frontendURL := getEnv("FRONTEND_URL", "http://localhost:3000")
 
+	// 外部IDプロバイダ（OpenID Connect）設定
+	oidcProviders := loadOIDCProviders(getEnv("OIDC_REDIRECT_BASE_URL", "http://localhost:8080"))
+
 	// 依存関係の注入（DI）
-	routerConfig := di.BuildRouterConfig(db, redisClient, s3Client, s3Bucket, s3Endpoint, s3ExternalEndpoint, smtpHost, smtpPort, frontendURL)
+	routerConfig, jobs := di.BuildRouterConfig(db, redisClient, s3Client, s3Bucket, s3Endpoint, s3ExternalEndpoint, smtpHost, smtpPort, frontendURL, oidcProviders)
 	r := router.NewRouter(routerConfig)
 
+	// 削除予定日時を過ぎたアカウントの定期削除
+	go runPeriodically(ctx, 1*time.Hour, func(ctx context.Context) {
+		purgeDueAccounts(ctx, jobs.AccountUsecase)
+	})
+
+	// デイリースコアの計算方法を変更したユーザーのスコアの再計算
+	go runPeriodically(ctx, 1*time.Minute, func(ctx context.Context) {
+		backfillDailyScores(ctx, jobs.DailyScoreUsecase)
+	})
+
 	// サーバー起動
 	addr := fmt.Sprintf(":%s", port)
 	logger.Info("Server starting",
@@ -112,6 +129,77 @@ func main() {
 	}
 }
 
+// runPeriodically はfnをinterval間隔で実行する。
+// 起動時にも1回実行し、ctxがキャンセルされるまで繰り返す。
+func runPeriodically(ctx context.Context, interval time.Duration, fn func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		fn(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeDueAccounts は削除予定日時を過ぎたアカウントを削除し、結果をログに記録する。
+func purgeDueAccounts(ctx context.Context, accountUsecase usecase.AccountUsecaseInterface) {
+	purged, err := accountUsecase.PurgeDueAccounts(ctx)
+	if err != nil {
+		logger.Error("Failed to purge accounts due for deletion", "error", err, "purged", purged)
+	} else if purged > 0 {
+		logger.Info("Purged accounts due for deletion", "purged", purged)
+	}
+}
+
+// backfillDailyScores はデイリースコアの計算方法を変更したユーザーのスコアを再計算し、結果をログに記録する。
+func backfillDailyScores(ctx context.Context, dailyScoreUsecase usecase.DailyScoreUsecaseInterface) {
+	backfilled, err := dailyScoreUsecase.BackfillDailyScores(ctx)
+	if err != nil {
+		logger.Error("Failed to backfill daily scores", "error", err, "backfilled", backfilled)
+	} else if backfilled > 0 {
+		logger.Info("Backfilled daily scores", "backfilled", backfilled)
+	}
+}
+
+// loadOIDCProviders は環境変数から外部IDプロバイダの設定を読み込む。
+// OIDC_PROVIDERSにカンマ区切りでプロバイダ名を指定し、プロバイダごとに
+// OIDC_{NAME}_ISSUER, _CLIENT_ID, _CLIENT_SECRET, _AUTH_URL, _TOKEN_URL, _JWKS_URL を設定する。
+// コールバックURLは {redirectBaseURL}/api/auth/oidc/{name}/callback とする。
+func loadOIDCProviders(redirectBaseURL string) []oidc.ProviderConfig {
+	var configs []oidc.ProviderConfig
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		config := oidc.ProviderConfig{
+			Name:                  name,
+			Issuer:                os.Getenv(prefix + "ISSUER"),
+			ClientID:              os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret:          os.Getenv(prefix + "CLIENT_SECRET"),
+			AuthorizationEndpoint: os.Getenv(prefix + "AUTH_URL"),
+			TokenEndpoint:         os.Getenv(prefix + "TOKEN_URL"),
+			JWKSEndpoint:          os.Getenv(prefix + "JWKS_URL"),
+			RedirectURL:           fmt.Sprintf("%s/api/auth/oidc/%s/callback", redirectBaseURL, name),
+		}
+		if config.Issuer == "" || config.ClientID == "" || config.AuthorizationEndpoint == "" || config.TokenEndpoint == "" || config.JWKSEndpoint == "" {
+			logger.Error("Incomplete OIDC provider configuration, skipping", "provider", name)
+			continue
+		}
+
+		logger.Info("OIDC provider configured", "provider", name, "issuer", config.Issuer)
+		configs = append(configs, config)
+	}
+	return configs
+}
+
 // getEnv は環境変数を取得し、存在しない場合はデフォルト値を返す。
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
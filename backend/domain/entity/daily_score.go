@@ -0,0 +1,190 @@
+package entity
+
+import "math"
+
+// DailyScoreStrategyType はデイリースコアの計算方法を表す値オブジェクト
+type DailyScoreStrategyType string
+
+const (
+	// DailyScoreStrategyAbsolute は総負荷量の絶対値でスコアを計算する: score = 100 × √(volume / 20000)
+	DailyScoreStrategyAbsolute DailyScoreStrategyType = "absolute"
+	// DailyScoreStrategyBodyweightRelative は体重あたりの負荷量でスコアを計算する: score = 100 × √((workload / bodyWeight) / 250)
+	DailyScoreStrategyBodyweightRelative DailyScoreStrategyType = "bodyweight_relative"
+	// DailyScoreStrategyRollingAverage は直近4週間の平均負荷量との比でスコアを計算する: score = 50 × workload / average
+	DailyScoreStrategyRollingAverage DailyScoreStrategyType = "rolling_average"
+	// DailyScoreStrategyRPEWeighted はセットごとのRPEで重み付けした総負荷量でスコアを計算する: score = 100 × √(Σ(volume × RPE / 8) / 20000)
+	DailyScoreStrategyRPEWeighted DailyScoreStrategyType = "rpe_weighted"
+)
+
+// DefaultDailyScoreStrategy はユーザーが計算方法を指定していない場合に使用するデイリースコアの計算方法
+const DefaultDailyScoreStrategy = DailyScoreStrategyAbsolute
+
+// ValidDailyScoreStrategies は有効なデイリースコアの計算方法の一覧
+var ValidDailyScoreStrategies = []DailyScoreStrategyType{
+	DailyScoreStrategyAbsolute,
+	DailyScoreStrategyBodyweightRelative,
+	DailyScoreStrategyRollingAverage,
+	DailyScoreStrategyRPEWeighted,
+}
+
+// IsValid は計算方法が有効かどうかを検証する
+func (t DailyScoreStrategyType) IsValid() bool {
+	switch t {
+	case DailyScoreStrategyAbsolute, DailyScoreStrategyBodyweightRelative, DailyScoreStrategyRollingAverage, DailyScoreStrategyRPEWeighted:
+		return true
+	default:
+		return false
+	}
+}
+
+// UsesBodyWeight は計算方法がプロフィールの体重を使用するかどうかを返す
+// 体重比での計算に加え、平均負荷量との比での計算も自重種目の負荷量に体重を使用する
+func (t DailyScoreStrategyType) UsesBodyWeight() bool {
+	return t == DailyScoreStrategyBodyweightRelative || t == DailyScoreStrategyRollingAverage
+}
+
+const (
+	// absoluteReferenceVolume は絶対値でのスコア計算の基準最大ボリューム（kg）
+	absoluteReferenceVolume = 20000.0
+	// bodyweightReferenceRatio は体重比でのスコア計算の基準最大値（体重80kgで20,000kgに相当）
+	bodyweightReferenceRatio = 250.0
+	// rollingAverageScore は直近の平均と同じ負荷量のセッションのスコア
+	rollingAverageScore = 50.0
+	// referenceRPE はRPEでの重み付けで重みが1となるRPE（RPE未記録のセットも重み1とする）
+	referenceRPE = 8.0
+)
+
+// RollingAverageWeeks は平均負荷量との比でスコアを計算する際に平均を取る週数
+const RollingAverageWeeks = 4
+
+// DailyScoreInput はデイリースコアの計算に使用するデータを表す
+type DailyScoreInput struct {
+	// Sets はワークアウトのセット。ウォームアップセットは計算に含めない
+	Sets []*WorkoutSet
+	// BodyWeight はプロフィールの体重（未設定の場合はnil）
+	BodyWeight *float64
+	// RecentAverageWorkload は直近 RollingAverageWeeks 週間のワークアウト1回あたりの平均負荷量
+	// （記録がない場合はnil）
+	RecentAverageWorkload *float64
+}
+
+// Workload はウォームアップセットを除いたセットの負荷量（体重・継続時間を考慮）の合計を返す
+func (in DailyScoreInput) Workload() float64 {
+	total := 0.0
+	for _, set := range ExcludeWarmUpSets(in.Sets) {
+		total += set.CalculateWorkload(in.BodyWeight)
+	}
+	return total
+}
+
+// DailyScoreStrategy はワークアウトのセットからデイリースコア（0〜100）を計算する
+type DailyScoreStrategy interface {
+	// Type は計算方法を返す
+	Type() DailyScoreStrategyType
+	// CalculateDailyScore はデイリースコアを計算する
+	CalculateDailyScore(input DailyScoreInput) int32
+}
+
+// NewDailyScoreStrategy は計算方法に対応するDailyScoreStrategyを返す
+// 無効な計算方法が指定された場合は絶対値での計算にフォールバックする
+func NewDailyScoreStrategy(t DailyScoreStrategyType) DailyScoreStrategy {
+	switch t {
+	case DailyScoreStrategyBodyweightRelative:
+		return BodyweightRelativeStrategy{}
+	case DailyScoreStrategyRollingAverage:
+		return RollingAverageStrategy{}
+	case DailyScoreStrategyRPEWeighted:
+		return RPEWeightedStrategy{}
+	default:
+		return AbsoluteVolumeStrategy{}
+	}
+}
+
+// AbsoluteVolumeStrategy は総負荷量（重量 × レップ数のウォームアップ以外の全セット合計）の絶対値でスコアを計算する
+// 体重・継続時間は考慮しない
+type AbsoluteVolumeStrategy struct{}
+
+// Type は計算方法を返す
+func (AbsoluteVolumeStrategy) Type() DailyScoreStrategyType {
+	return DailyScoreStrategyAbsolute
+}
+
+// CalculateDailyScore は score = 100 × √(totalVolume / 20000) でスコアを計算する
+func (AbsoluteVolumeStrategy) CalculateDailyScore(input DailyScoreInput) int32 {
+	totalVolume := 0.0
+	for _, set := range ExcludeWarmUpSets(input.Sets) {
+		totalVolume += set.CalculateVolume()
+	}
+	return scaleDailyScore(100.0 * math.Sqrt(math.Max(totalVolume, 0)/absoluteReferenceVolume))
+}
+
+// BodyweightRelativeStrategy は体重あたりの負荷量でスコアを計算する
+// 自重種目は体重を負荷とし、継続時間で記録したセットも負荷量に含める
+// 体重が未設定の場合は絶対値での計算にフォールバックする
+type BodyweightRelativeStrategy struct{}
+
+// Type は計算方法を返す
+func (BodyweightRelativeStrategy) Type() DailyScoreStrategyType {
+	return DailyScoreStrategyBodyweightRelative
+}
+
+// CalculateDailyScore は score = 100 × √((workload / bodyWeight) / 250) でスコアを計算する
+func (BodyweightRelativeStrategy) CalculateDailyScore(input DailyScoreInput) int32 {
+	if input.BodyWeight == nil || *input.BodyWeight <= 0 {
+		return AbsoluteVolumeStrategy{}.CalculateDailyScore(input)
+	}
+	ratio := input.Workload() / *input.BodyWeight
+	return scaleDailyScore(100.0 * math.Sqrt(ratio/bodyweightReferenceRatio))
+}
+
+// RollingAverageStrategy は直近の平均負荷量との比でスコアを計算する
+// 平均と同じ負荷量で50、平均の2倍以上で100となる
+// 直近の記録がない場合は絶対値での計算にフォールバックする
+type RollingAverageStrategy struct{}
+
+// Type は計算方法を返す
+func (RollingAverageStrategy) Type() DailyScoreStrategyType {
+	return DailyScoreStrategyRollingAverage
+}
+
+// CalculateDailyScore は score = 50 × workload / average でスコアを計算する
+func (RollingAverageStrategy) CalculateDailyScore(input DailyScoreInput) int32 {
+	if input.RecentAverageWorkload == nil || *input.RecentAverageWorkload <= 0 {
+		return AbsoluteVolumeStrategy{}.CalculateDailyScore(input)
+	}
+	return scaleDailyScore(rollingAverageScore * input.Workload() / *input.RecentAverageWorkload)
+}
+
+// RPEWeightedStrategy はセットごとのRPEで重み付けした総負荷量でスコアを計算する
+// RIRで記録したセットはRPEに換算し、RPE・RIRが未記録のセットはRPE 8相当として扱うため、
+// 記録がない場合は絶対値での計算と同じスコアになる
+type RPEWeightedStrategy struct{}
+
+// Type は計算方法を返す
+func (RPEWeightedStrategy) Type() DailyScoreStrategyType {
+	return DailyScoreStrategyRPEWeighted
+}
+
+// CalculateDailyScore は score = 100 × √(Σ(volume × RPE / 8) / 20000) でスコアを計算する
+func (RPEWeightedStrategy) CalculateDailyScore(input DailyScoreInput) int32 {
+	weightedVolume := 0.0
+	for _, set := range ExcludeWarmUpSets(input.Sets) {
+		weight := 1.0
+		if rpe := set.EffectiveRPE(); rpe != nil {
+			weight = *rpe / referenceRPE
+		}
+		weightedVolume += set.CalculateVolume() * weight
+	}
+	return scaleDailyScore(100.0 * math.Sqrt(math.Max(weightedVolume, 0)/absoluteReferenceVolume))
+}
+
+// scaleDailyScore はスコアを四捨五入し、0〜MaxDailyScoreの範囲に収める
+func scaleDailyScore(score float64) int32 {
+	if score <= 0 || math.IsNaN(score) {
+		return 0
+	}
+	if score > float64(MaxDailyScore) {
+		return MaxDailyScore
+	}
+	return int32(math.Round(score))
+}
@@ -0,0 +1,228 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestDailyScoreStrategyType_IsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy DailyScoreStrategyType
+		want     bool
+	}{
+		{name: "正常系: absolute", strategy: DailyScoreStrategyAbsolute, want: true},
+		{name: "正常系: bodyweight_relative", strategy: DailyScoreStrategyBodyweightRelative, want: true},
+		{name: "正常系: rolling_average", strategy: DailyScoreStrategyRollingAverage, want: true},
+		{name: "正常系: rpe_weighted", strategy: DailyScoreStrategyRPEWeighted, want: true},
+		{name: "異常系: 空文字", strategy: "", want: false},
+		{name: "異常系: 未知の計算方法", strategy: "unknown", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.strategy.IsValid(); got != tt.want {
+				t.Errorf("IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDailyScoreStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy DailyScoreStrategyType
+		want     DailyScoreStrategyType
+	}{
+		{name: "正常系: absolute", strategy: DailyScoreStrategyAbsolute, want: DailyScoreStrategyAbsolute},
+		{name: "正常系: bodyweight_relative", strategy: DailyScoreStrategyBodyweightRelative, want: DailyScoreStrategyBodyweightRelative},
+		{name: "正常系: rolling_average", strategy: DailyScoreStrategyRollingAverage, want: DailyScoreStrategyRollingAverage},
+		{name: "正常系: rpe_weighted", strategy: DailyScoreStrategyRPEWeighted, want: DailyScoreStrategyRPEWeighted},
+		{name: "正常系: 無効な計算方法はabsoluteにフォールバック", strategy: "unknown", want: DailyScoreStrategyAbsolute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewDailyScoreStrategy(tt.strategy).Type(); got != tt.want {
+				t.Errorf("NewDailyScoreStrategy().Type() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDailyScoreStrategy_CalculateDailyScore(t *testing.T) {
+	workoutID := uuid.New()
+	exerciseID := uuid.New()
+
+	// 100kg × 10レップ × 5セット = 5,000kg
+	weightedSets := make([]*WorkoutSet, 5)
+	for i := range weightedSets {
+		weightedSets[i], _ = NewWorkoutSet(workoutID, exerciseID, int32(i+1), 10, 100.0)
+	}
+	// 10kg × 10レップ = 100kg
+	lightSet, _ := NewWorkoutSet(workoutID, exerciseID, 1, 10, 10.0)
+	// 250kg × 100レップ = 25,000kg
+	heavySet, _ := NewWorkoutSet(workoutID, exerciseID, 1, 100, 250.0)
+	// 自重 × 10レップ
+	bodyweightSet, _ := NewWorkoutSet(workoutID, exerciseID, 1, 10, 0)
+	// ウォームアップ 60kg × 10レップ
+	warmUpSet, _ := NewWorkoutSet(workoutID, exerciseID, 1, 10, 60.0)
+	_ = warmUpSet.UpdateSetType(SetTypeWarmUp)
+	withWarmUp := append([]*WorkoutSet{warmUpSet}, weightedSets...)
+	// 100kg × 10レップ × 5セット（RPE 10 / RIR 4）
+	rpe10Sets := make([]*WorkoutSet, 5)
+	rir4Sets := make([]*WorkoutSet, 5)
+	for i := range weightedSets {
+		rpe10Sets[i], _ = NewWorkoutSet(workoutID, exerciseID, int32(i+1), 10, 100.0)
+		_ = rpe10Sets[i].UpdateEffort(float64Ptr(10), nil)
+		rir4Sets[i], _ = NewWorkoutSet(workoutID, exerciseID, int32(i+1), 10, 100.0)
+		_ = rir4Sets[i].UpdateEffort(nil, int32Ptr(4))
+	}
+
+	tests := []struct {
+		name     string
+		strategy DailyScoreStrategyType
+		input    DailyScoreInput
+		want     int32
+	}{
+		{
+			name:     "正常系: absolute（5,000kg）",
+			strategy: DailyScoreStrategyAbsolute,
+			input:    DailyScoreInput{Sets: weightedSets},
+			want:     50, // 100 × √(5000 / 20000)
+		},
+		{
+			name:     "正常系: absolute（100kg）",
+			strategy: DailyScoreStrategyAbsolute,
+			input:    DailyScoreInput{Sets: []*WorkoutSet{lightSet}},
+			want:     7, // 100 × √(100 / 20000) ≈ 7.07
+		},
+		{
+			name:     "正常系: absolute（基準値超過は100）",
+			strategy: DailyScoreStrategyAbsolute,
+			input:    DailyScoreInput{Sets: []*WorkoutSet{heavySet}},
+			want:     MaxDailyScore,
+		},
+		{
+			name:     "正常系: absoluteはウォームアップセットを除外",
+			strategy: DailyScoreStrategyAbsolute,
+			input:    DailyScoreInput{Sets: withWarmUp},
+			want:     50,
+		},
+		{
+			name:     "正常系: absolute（セットなし）",
+			strategy: DailyScoreStrategyAbsolute,
+			input:    DailyScoreInput{},
+			want:     0,
+		},
+		{
+			name:     "正常系: absoluteは自重種目を考慮しない",
+			strategy: DailyScoreStrategyAbsolute,
+			input:    DailyScoreInput{Sets: []*WorkoutSet{bodyweightSet}, BodyWeight: float64Ptr(80)},
+			want:     0,
+		},
+		{
+			name:     "正常系: bodyweight_relative（体重80kg、5,000kg）",
+			strategy: DailyScoreStrategyBodyweightRelative,
+			input:    DailyScoreInput{Sets: weightedSets, BodyWeight: float64Ptr(80)},
+			want:     50, // 100 × √((5000 / 80) / 250)
+		},
+		{
+			name:     "正常系: bodyweight_relativeは自重種目を体重で計算",
+			strategy: DailyScoreStrategyBodyweightRelative,
+			input:    DailyScoreInput{Sets: []*WorkoutSet{bodyweightSet}, BodyWeight: float64Ptr(80)},
+			want:     20, // 100 × √((800 / 80) / 250)
+		},
+		{
+			name:     "正常系: bodyweight_relativeは体重未設定でabsoluteにフォールバック",
+			strategy: DailyScoreStrategyBodyweightRelative,
+			input:    DailyScoreInput{Sets: weightedSets},
+			want:     50,
+		},
+		{
+			name:     "正常系: rolling_average（平均と同じ負荷量）",
+			strategy: DailyScoreStrategyRollingAverage,
+			input:    DailyScoreInput{Sets: weightedSets, RecentAverageWorkload: float64Ptr(5000)},
+			want:     50,
+		},
+		{
+			name:     "正常系: rolling_average（平均の半分の負荷量）",
+			strategy: DailyScoreStrategyRollingAverage,
+			input:    DailyScoreInput{Sets: weightedSets, RecentAverageWorkload: float64Ptr(10000)},
+			want:     25,
+		},
+		{
+			name:     "正常系: rolling_average（平均の2倍以上は100）",
+			strategy: DailyScoreStrategyRollingAverage,
+			input:    DailyScoreInput{Sets: weightedSets, RecentAverageWorkload: float64Ptr(1000)},
+			want:     MaxDailyScore,
+		},
+		{
+			name:     "正常系: rolling_averageは直近の記録なしでabsoluteにフォールバック",
+			strategy: DailyScoreStrategyRollingAverage,
+			input:    DailyScoreInput{Sets: weightedSets},
+			want:     50,
+		},
+		{
+			name:     "正常系: rpe_weighted（RPE未記録はabsoluteと同じ）",
+			strategy: DailyScoreStrategyRPEWeighted,
+			input:    DailyScoreInput{Sets: withWarmUp},
+			want:     50,
+		},
+		{
+			name:     "正常系: rpe_weighted（RPE 10）",
+			strategy: DailyScoreStrategyRPEWeighted,
+			input:    DailyScoreInput{Sets: rpe10Sets},
+			want:     56, // 100 × √((5000 × 10 / 8) / 20000)
+		},
+		{
+			name:     "正常系: rpe_weightedはRIRをRPEに換算（RIR 4 = RPE 6）",
+			strategy: DailyScoreStrategyRPEWeighted,
+			input:    DailyScoreInput{Sets: rir4Sets},
+			want:     43, // 100 × √((5000 × 6 / 8) / 20000)
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewDailyScoreStrategy(tt.strategy).CalculateDailyScore(tt.input)
+			if got != tt.want {
+				t.Errorf("CalculateDailyScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkoutSet_CalculateWorkload(t *testing.T) {
+	workoutID := uuid.New()
+	exerciseID := uuid.New()
+
+	tests := []struct {
+		name       string
+		reps       int32
+		weight     float64
+		duration   *int32
+		bodyWeight *float64
+		want       float64
+	}{
+		{name: "正常系: 重量 × レップ数", reps: 10, weight: 100, want: 1000},
+		{name: "正常系: 自重種目は体重を負荷とする", reps: 10, weight: 0, bodyWeight: float64Ptr(80), want: 800},
+		{name: "正常系: 自重種目で体重未設定", reps: 10, weight: 0, want: 0},
+		{name: "正常系: 継続時間をレップ数に換算", reps: 1, weight: 0, duration: int32Ptr(60), bodyWeight: float64Ptr(80), want: 1600},
+		{name: "正常系: 換算値よりレップ数が多い場合はレップ数", reps: 30, weight: 20, duration: int32Ptr(30), want: 600},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws, _ := NewWorkoutSet(workoutID, exerciseID, 1, tt.reps, tt.weight)
+			if err := ws.UpdateDuration(tt.duration); err != nil {
+				t.Fatalf("UpdateDuration() unexpected error = %v", err)
+			}
+
+			if got := ws.CalculateWorkload(tt.bodyWeight); got != tt.want {
+				t.Errorf("CalculateWorkload() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
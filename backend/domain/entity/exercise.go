@@ -38,9 +38,11 @@ var ValidBodyParts = map[BodyPart]bool{
 	BodyPartOther:     true,
 }
 
-// Exercise はシステム内のエクササイズを表す
+// Exercise はシステム内のエクササイズを表す。
+// UserIDがnilの場合は全ユーザー共通のシステム種目、それ以外は該当ユーザーのカスタム種目となる。
 type Exercise struct {
 	ID          uuid.UUID
+	UserID      *uuid.UUID
 	Name        string
 	Description *string
 	BodyPart    *BodyPart
@@ -71,10 +73,21 @@ func NewExercise(name string, description *string, bodyPart *BodyPart) (*Exercis
 	}, nil
 }
 
+// NewUserExercise はバリデーション付きで指定ユーザーが所有するカスタムExerciseエンティティを作成する
+func NewUserExercise(userID uuid.UUID, name string, description *string, bodyPart *BodyPart) (*Exercise, error) {
+	exercise, err := NewExercise(name, description, bodyPart)
+	if err != nil {
+		return nil, err
+	}
+	exercise.UserID = &userID
+	return exercise, nil
+}
+
 // ReconstructExercise は保存されたデータからExerciseエンティティを再構築する
-func ReconstructExercise(id uuid.UUID, name string, description *string, bodyPart *BodyPart, createdAt, updatedAt time.Time) *Exercise {
+func ReconstructExercise(id uuid.UUID, userID *uuid.UUID, name string, description *string, bodyPart *BodyPart, createdAt, updatedAt time.Time) *Exercise {
 	return &Exercise{
 		ID:          id,
+		UserID:      userID,
 		Name:        name,
 		Description: description,
 		BodyPart:    bodyPart,
@@ -83,6 +96,22 @@ func ReconstructExercise(id uuid.UUID, name string, description *string, bodyPar
 	}
 }
 
+// IsGlobal はシステム所有（全ユーザー共通）のエクササイズかどうかを返す
+func (e *Exercise) IsGlobal() bool {
+	return e.UserID == nil
+}
+
+// IsOwnedBy は指定ユーザーが所有するカスタムエクササイズかどうかを返す
+func (e *Exercise) IsOwnedBy(userID uuid.UUID) bool {
+	return e.UserID != nil && *e.UserID == userID
+}
+
+// IsVisibleTo は指定ユーザーが参照・使用できるエクササイズかどうかを返す。
+// システム種目は全ユーザーから、カスタム種目は所有者からのみ参照できる。
+func (e *Exercise) IsVisibleTo(userID uuid.UUID) bool {
+	return e.IsGlobal() || e.IsOwnedBy(userID)
+}
+
 // UpdateName はエクササイズの名前を更新する
 func (e *Exercise) UpdateName(name string) error {
 	if err := ValidateExerciseName(name); err != nil {
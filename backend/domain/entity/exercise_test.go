@@ -293,3 +293,76 @@ func TestExercise_UpdateDescription(t *testing.T) {
 		t.Error("Description should be nil after setting to nil")
 	}
 }
+
+func TestNewUserExercise(t *testing.T) {
+	userID := uuid.New()
+
+	exercise, err := NewUserExercise(userID, "Custom Press", nil, nil)
+	if err != nil {
+		t.Fatalf("NewUserExercise() unexpected error = %v", err)
+	}
+
+	if exercise.UserID == nil || *exercise.UserID != userID {
+		t.Errorf("UserID = %v, want %v", exercise.UserID, userID)
+	}
+
+	if _, err := NewUserExercise(userID, "", nil, nil); err != ErrInvalidExerciseName {
+		t.Errorf("NewUserExercise() error = %v, want %v", err, ErrInvalidExerciseName)
+	}
+}
+
+func TestExercise_Ownership(t *testing.T) {
+	ownerID := uuid.New()
+	otherID := uuid.New()
+
+	global, _ := NewExercise("Bench Press", nil, nil)
+	custom, _ := NewUserExercise(ownerID, "Custom Press", nil, nil)
+
+	tests := []struct {
+		name        string
+		exercise    *Exercise
+		userID      uuid.UUID
+		wantGlobal  bool
+		wantOwned   bool
+		wantVisible bool
+	}{
+		{
+			name:        "正常系: システム種目は全ユーザーから参照可能",
+			exercise:    global,
+			userID:      otherID,
+			wantGlobal:  true,
+			wantOwned:   false,
+			wantVisible: true,
+		},
+		{
+			name:        "正常系: カスタム種目は所有者から参照可能",
+			exercise:    custom,
+			userID:      ownerID,
+			wantGlobal:  false,
+			wantOwned:   true,
+			wantVisible: true,
+		},
+		{
+			name:        "異常系: カスタム種目は他ユーザーから参照不可",
+			exercise:    custom,
+			userID:      otherID,
+			wantGlobal:  false,
+			wantOwned:   false,
+			wantVisible: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.exercise.IsGlobal(); got != tt.wantGlobal {
+				t.Errorf("IsGlobal() = %v, want %v", got, tt.wantGlobal)
+			}
+			if got := tt.exercise.IsOwnedBy(tt.userID); got != tt.wantOwned {
+				t.Errorf("IsOwnedBy() = %v, want %v", got, tt.wantOwned)
+			}
+			if got := tt.exercise.IsVisibleTo(tt.userID); got != tt.wantVisible {
+				t.Errorf("IsVisibleTo() = %v, want %v", got, tt.wantVisible)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PersonalRecordType は自己ベスト（PR）の種類を表す値オブジェクト
+type PersonalRecordType string
+
+const (
+	// PersonalRecordTypeEstimated1RM は推定1RMの自己ベスト
+	PersonalRecordTypeEstimated1RM PersonalRecordType = "estimated_1rm"
+	// PersonalRecordTypeRepMax は指定レップ数以上で挙上した最大重量（nRM）の自己ベスト
+	PersonalRecordTypeRepMax PersonalRecordType = "rep_max"
+	// PersonalRecordTypeSessionVolume は1回のワークアウトにおける種目ボリュームの自己ベスト
+	PersonalRecordTypeSessionVolume PersonalRecordType = "session_volume"
+)
+
+// PersonalRecordRepTargets はnRMを追跡するレップ数の一覧（1RM, 3RM, 5RM, 10RM）
+var PersonalRecordRepTargets = []int32{1, 3, 5, 10}
+
+// PersonalRecord は種目ごとの自己ベスト更新イベントを表す
+type PersonalRecord struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	ExerciseID    uuid.UUID
+	WorkoutID     uuid.UUID
+	WorkoutSetID  *uuid.UUID // ボリュームPRの場合はnil
+	RecordType    PersonalRecordType
+	Reps          *int32 // nRMの場合のレップ数、それ以外はnil
+	Value         float64
+	PreviousValue *float64 // 初回記録の場合はnil
+	AchievedDate  time.Time
+	CreatedAt     time.Time
+}
+
+// NewPersonalRecord は新しいPersonalRecordエンティティを作成する
+func NewPersonalRecord(userID, exerciseID, workoutID uuid.UUID, workoutSetID *uuid.UUID, recordType PersonalRecordType, reps *int32, value float64, previousValue *float64, achievedDate time.Time) *PersonalRecord {
+	return &PersonalRecord{
+		ID:            uuid.New(),
+		UserID:        userID,
+		ExerciseID:    exerciseID,
+		WorkoutID:     workoutID,
+		WorkoutSetID:  workoutSetID,
+		RecordType:    recordType,
+		Reps:          reps,
+		Value:         value,
+		PreviousValue: previousValue,
+		AchievedDate:  achievedDate,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// ReconstructPersonalRecord は保存されたデータからPersonalRecordエンティティを再構築する
+func ReconstructPersonalRecord(id, userID, exerciseID, workoutID uuid.UUID, workoutSetID *uuid.UUID, recordType PersonalRecordType, reps *int32, value float64, previousValue *float64, achievedDate, createdAt time.Time) *PersonalRecord {
+	return &PersonalRecord{
+		ID:            id,
+		UserID:        userID,
+		ExerciseID:    exerciseID,
+		WorkoutID:     workoutID,
+		WorkoutSetID:  workoutSetID,
+		RecordType:    recordType,
+		Reps:          reps,
+		Value:         value,
+		PreviousValue: previousValue,
+		AchievedDate:  achievedDate,
+		CreatedAt:     createdAt,
+	}
+}
+
+// DetectPersonalRecords は新しく記録されたセットが自己ベストを更新したかを判定する。
+//
+// 判定対象（いずれも値が0より大きい場合のみ）:
+//   - 推定1RM: 指定された公式で生の重量・レップ数から計算した値の最大値
+//   - nRM: PersonalRecordRepTargets の各レップ数について、そのレップ数以上で挙上した最大重量
+//   - セッションボリューム: 同一ワークアウト内の種目ボリューム（レップ数 × 重量）の合計
+//
+// history には同じユーザー・種目の既存セットのうち、workout より前のワークアウトのセットと
+// workout 自身の既存セットを渡す（newSets は含まない）。
+// ウォームアップセットは history・newSets のいずれも判定に含めない。
+// 既存の記録がない場合も初回記録として自己ベストとみなす（PreviousValue は nil）。
+func DetectPersonalRecords(workout *Workout, exerciseID uuid.UUID, history, newSets []*WorkoutSet, formula OneRMFormula) []*PersonalRecord {
+	history = ExcludeWarmUpSets(history)
+	newSets = ExcludeWarmUpSets(newSets)
+	if len(newSets) == 0 {
+		return nil
+	}
+
+	var records []*PersonalRecord
+	newRecord := func(set *WorkoutSet, recordType PersonalRecordType, reps *int32, value float64, previous *float64) {
+		var setID *uuid.UUID
+		if set != nil {
+			id := set.ID
+			setID = &id
+		}
+		records = append(records, NewPersonalRecord(workout.UserID, exerciseID, workout.ID, setID, recordType, reps, value, previous, workout.Date))
+	}
+
+	// 推定1RM
+	estimated1RM := func(s *WorkoutSet) (float64, bool) {
+		return CalculateEstimated1RMWithFormula(s.Weight, s.Reps, formula), true
+	}
+	prev1RM := bestOf(history, estimated1RM)
+	if set, value := bestSetOf(newSets, estimated1RM); set != nil && beats(value, prev1RM) {
+		newRecord(set, PersonalRecordTypeEstimated1RM, nil, value, prev1RM)
+	}
+
+	// nRM
+	for _, target := range PersonalRecordRepTargets {
+		reps := target
+		atLeast := func(s *WorkoutSet) (float64, bool) {
+			return s.Weight, s.Reps >= reps
+		}
+		prev := bestOf(history, atLeast)
+		if set, value := bestSetOf(newSets, atLeast); set != nil && beats(value, prev) {
+			newRecord(set, PersonalRecordTypeRepMax, &reps, value, prev)
+		}
+	}
+
+	// セッションボリューム
+	volumes := make(map[uuid.UUID]float64)
+	for _, s := range history {
+		volumes[s.WorkoutID] += s.CalculateVolume()
+	}
+	var prevVolume *float64
+	for _, v := range volumes {
+		v := v
+		if prevVolume == nil || v > *prevVolume {
+			prevVolume = &v
+		}
+	}
+	sessionVolume := volumes[workout.ID]
+	for _, s := range newSets {
+		sessionVolume += s.CalculateVolume()
+	}
+	if beats(sessionVolume, prevVolume) {
+		newRecord(nil, PersonalRecordTypeSessionVolume, nil, sessionVolume, prevVolume)
+	}
+
+	return records
+}
+
+// RebuildPersonalRecords は種目の全セットをワークアウト単位で古い順に判定し直し、自己ベスト更新イベントを再構築する。
+// セットの更新・削除や過去日付の記録によって既存のイベントが実態と合わなくなった場合に使用する。
+//
+// workouts には対象のワークアウトを時系列順に、setsByWorkout にはワークアウトIDごとの種目のセットを渡す。
+// 各ワークアウトのセットは、それより前のワークアウトのセットのみを履歴として判定される。
+func RebuildPersonalRecords(exerciseID uuid.UUID, workouts []*Workout, setsByWorkout map[uuid.UUID][]*WorkoutSet, formula OneRMFormula) []*PersonalRecord {
+	var records []*PersonalRecord
+	var history []*WorkoutSet
+	for _, workout := range workouts {
+		sets := setsByWorkout[workout.ID]
+		records = append(records, DetectPersonalRecords(workout, exerciseID, history, sets, formula)...)
+		history = append(history, sets...)
+	}
+	return records
+}
+
+// bestOf は対象となるセットの値の最大値を返す。対象がない場合はnilを返す。
+func bestOf(sets []*WorkoutSet, valueOf func(*WorkoutSet) (float64, bool)) *float64 {
+	best, value := bestSetOf(sets, valueOf)
+	if best == nil {
+		return nil
+	}
+	return &value
+}
+
+// bestSetOf は対象となるセットのうち値が最大のセットとその値を返す。対象がない場合はnilを返す。
+func bestSetOf(sets []*WorkoutSet, valueOf func(*WorkoutSet) (float64, bool)) (*WorkoutSet, float64) {
+	var best *WorkoutSet
+	var bestValue float64
+	for _, s := range sets {
+		v, ok := valueOf(s)
+		if !ok {
+			continue
+		}
+		if best == nil || v > bestValue {
+			best = s
+			bestValue = v
+		}
+	}
+	return best, bestValue
+}
+
+// beats は値が0より大きく、既存の自己ベストを上回るかを判定する
+func beats(value float64, previous *float64) bool {
+	if value <= 0 {
+		return false
+	}
+	return previous == nil || value > *previous
+}
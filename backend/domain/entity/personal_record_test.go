@@ -0,0 +1,257 @@
+package entity
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestDetectPersonalRecords(t *testing.T) {
+	userID := uuid.New()
+	exerciseID := uuid.New()
+	date := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
+	pastWorkoutID := uuid.New()
+
+	newSet := func(workoutID uuid.UUID, setNumber, reps int32, weight float64) *WorkoutSet {
+		set, err := NewWorkoutSet(workoutID, exerciseID, setNumber, reps, weight)
+		if err != nil {
+			t.Fatalf("NewWorkoutSet() error = %v", err)
+		}
+		return set
+	}
+	newWarmUpSet := func(workoutID uuid.UUID, setNumber, reps int32, weight float64) *WorkoutSet {
+		set := newSet(workoutID, setNumber, reps, weight)
+		if err := set.UpdateSetType(SetTypeWarmUp); err != nil {
+			t.Fatalf("UpdateSetType() error = %v", err)
+		}
+		return set
+	}
+
+	type recordKey struct {
+		recordType PersonalRecordType
+		reps       int32
+		value      float64
+		previous   *float64
+	}
+	f := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name    string
+		history func(workout *Workout) []*WorkoutSet
+		newSets func(workout *Workout) []*WorkoutSet
+		want    []recordKey
+	}{
+		{
+			name:    "正常系: 履歴がない場合は全種類が初回記録",
+			history: func(w *Workout) []*WorkoutSet { return nil },
+			newSets: func(w *Workout) []*WorkoutSet {
+				return []*WorkoutSet{newSet(w.ID, 1, 10, 60.0)}
+			},
+			want: []recordKey{
+				{PersonalRecordTypeEstimated1RM, 0, 80.0, nil}, // 60 * (1 + 10/30) = 80
+				{PersonalRecordTypeRepMax, 1, 60.0, nil},
+				{PersonalRecordTypeRepMax, 3, 60.0, nil},
+				{PersonalRecordTypeRepMax, 5, 60.0, nil},
+				{PersonalRecordTypeRepMax, 10, 60.0, nil},
+				{PersonalRecordTypeSessionVolume, 0, 600.0, nil},
+			},
+		},
+		{
+			name: "正常系: 重量のみ更新した場合は低レップ側のnRMと推定1RMを更新",
+			history: func(w *Workout) []*WorkoutSet {
+				return []*WorkoutSet{
+					newSet(pastWorkoutID, 1, 10, 60.0),
+					newSet(pastWorkoutID, 2, 10, 60.0),
+				}
+			},
+			newSets: func(w *Workout) []*WorkoutSet {
+				return []*WorkoutSet{newSet(w.ID, 1, 5, 80.0)}
+			},
+			want: []recordKey{
+				{PersonalRecordTypeEstimated1RM, 0, 80.0 * (1 + 5.0/30.0), f(80.0)},
+				{PersonalRecordTypeRepMax, 1, 80.0, f(60.0)},
+				{PersonalRecordTypeRepMax, 3, 80.0, f(60.0)},
+				{PersonalRecordTypeRepMax, 5, 80.0, f(60.0)},
+			},
+		},
+		{
+			name: "正常系: 同じワークアウトの既存セットを含めてボリュームを判定",
+			history: func(w *Workout) []*WorkoutSet {
+				return []*WorkoutSet{
+					newSet(pastWorkoutID, 1, 10, 60.0),
+					newSet(w.ID, 1, 10, 50.0),
+				}
+			},
+			newSets: func(w *Workout) []*WorkoutSet {
+				return []*WorkoutSet{newSet(w.ID, 2, 2, 50.0)}
+			},
+			// 500 + 100 = 600 は過去の600と同じためボリュームは更新しない（重量・推定1RMも過去以下）
+			want: nil,
+		},
+		{
+			name: "正常系: 過去を上回るボリュームのみ更新",
+			history: func(w *Workout) []*WorkoutSet {
+				return []*WorkoutSet{newSet(pastWorkoutID, 1, 10, 60.0)}
+			},
+			newSets: func(w *Workout) []*WorkoutSet {
+				return []*WorkoutSet{
+					newSet(w.ID, 1, 10, 40.0),
+					newSet(w.ID, 2, 10, 40.0),
+				}
+			},
+			want: []recordKey{
+				{PersonalRecordTypeSessionVolume, 0, 800.0, f(600.0)},
+			},
+		},
+		{
+			name: "正常系: ウォームアップセットは自己ベストの判定に含めない",
+			history: func(w *Workout) []*WorkoutSet {
+				return []*WorkoutSet{
+					newSet(pastWorkoutID, 1, 10, 60.0),
+					newWarmUpSet(pastWorkoutID, 2, 10, 100.0),
+				}
+			},
+			newSets: func(w *Workout) []*WorkoutSet {
+				return []*WorkoutSet{
+					newWarmUpSet(w.ID, 1, 10, 40.0),
+					newSet(w.ID, 2, 10, 65.0),
+				}
+			},
+			want: []recordKey{
+				{PersonalRecordTypeEstimated1RM, 0, 65.0 * (1 + 10.0/30.0), f(80.0)},
+				{PersonalRecordTypeRepMax, 1, 65.0, f(60.0)},
+				{PersonalRecordTypeRepMax, 3, 65.0, f(60.0)},
+				{PersonalRecordTypeRepMax, 5, 65.0, f(60.0)},
+				{PersonalRecordTypeRepMax, 10, 65.0, f(60.0)},
+				{PersonalRecordTypeSessionVolume, 0, 650.0, f(600.0)},
+			},
+		},
+		{
+			name:    "正常系: 自重（重量0）は自己ベストにならない",
+			history: func(w *Workout) []*WorkoutSet { return nil },
+			newSets: func(w *Workout) []*WorkoutSet {
+				return []*WorkoutSet{newSet(w.ID, 1, 20, 0.0)}
+			},
+			want: nil,
+		},
+		{
+			name:    "正常系: 新しいセットがない場合は何も返さない",
+			history: func(w *Workout) []*WorkoutSet { return nil },
+			newSets: func(w *Workout) []*WorkoutSet { return nil },
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workout := NewWorkout(userID, date)
+			newSets := tt.newSets(workout)
+
+			got := DetectPersonalRecords(workout, exerciseID, tt.history(workout), newSets, OneRMFormulaEpley)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("DetectPersonalRecords() returned %d records, want %d", len(got), len(tt.want))
+			}
+			for i, want := range tt.want {
+				record := got[i]
+				var reps int32
+				if record.Reps != nil {
+					reps = *record.Reps
+				}
+				if record.RecordType != want.recordType || reps != want.reps {
+					t.Errorf("records[%d] = (%v, %d), want (%v, %d)", i, record.RecordType, reps, want.recordType, want.reps)
+				}
+				if math.Abs(record.Value-want.value) > 0.01 {
+					t.Errorf("records[%d].Value = %v, want %v", i, record.Value, want.value)
+				}
+				if (record.PreviousValue == nil) != (want.previous == nil) ||
+					(want.previous != nil && math.Abs(*record.PreviousValue-*want.previous) > 0.01) {
+					t.Errorf("records[%d].PreviousValue = %v, want %v", i, record.PreviousValue, want.previous)
+				}
+				if record.UserID != userID || record.ExerciseID != exerciseID || record.WorkoutID != workout.ID {
+					t.Errorf("records[%d] has wrong user/exercise/workout", i)
+				}
+				if !record.AchievedDate.Equal(date) {
+					t.Errorf("records[%d].AchievedDate = %v, want %v", i, record.AchievedDate, date)
+				}
+				if want.recordType == PersonalRecordTypeSessionVolume {
+					if record.WorkoutSetID != nil {
+						t.Errorf("records[%d].WorkoutSetID = %v, want nil for session volume", i, record.WorkoutSetID)
+					}
+				} else if record.WorkoutSetID == nil {
+					t.Errorf("records[%d].WorkoutSetID = nil, want set ID", i)
+				}
+			}
+		})
+	}
+}
+
+func TestDetectPersonalRecords_Formula(t *testing.T) {
+	exerciseID := uuid.New()
+	workout := NewWorkout(uuid.New(), time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC))
+	pastWorkoutID := uuid.New()
+
+	// Epley: 100*(1+5/30)≈116.67 → 90*(1+10/30)=120
+	// Brzycki: 100*36/32=112.5 → 90*36/27=120
+	// いずれの公式でも更新となり、値と前回値が指定した公式で計算されることを確認する
+	past, _ := NewWorkoutSet(pastWorkoutID, exerciseID, 1, 5, 100.0)
+	current, _ := NewWorkoutSet(workout.ID, exerciseID, 1, 10, 90.0)
+
+	for _, formula := range []OneRMFormula{OneRMFormulaEpley, OneRMFormulaBrzycki} {
+		records := DetectPersonalRecords(workout, exerciseID, []*WorkoutSet{past}, []*WorkoutSet{current}, formula)
+		if len(records) == 0 || records[0].RecordType != PersonalRecordTypeEstimated1RM {
+			t.Fatalf("formula %s: first record = %v, want estimated_1rm", formula, records)
+		}
+		want := CalculateEstimated1RMWithFormula(90.0, 10, formula)
+		if records[0].Value != want {
+			t.Errorf("formula %s: Value = %v, want %v", formula, records[0].Value, want)
+		}
+		wantPrev := CalculateEstimated1RMWithFormula(100.0, 5, formula)
+		if records[0].PreviousValue == nil || *records[0].PreviousValue != wantPrev {
+			t.Errorf("formula %s: PreviousValue = %v, want %v", formula, records[0].PreviousValue, wantPrev)
+		}
+	}
+}
+
+func TestRebuildPersonalRecords(t *testing.T) {
+	userID := uuid.New()
+	exerciseID := uuid.New()
+	first := NewWorkout(userID, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+	second := NewWorkout(userID, time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC))
+	third := NewWorkout(userID, time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC))
+
+	newSet := func(workoutID uuid.UUID, reps int32, weight float64) *WorkoutSet {
+		set, err := NewWorkoutSet(workoutID, exerciseID, 1, reps, weight)
+		if err != nil {
+			t.Fatalf("NewWorkoutSet() error = %v", err)
+		}
+		return set
+	}
+	setsByWorkout := map[uuid.UUID][]*WorkoutSet{
+		first.ID:  {newSet(first.ID, 1, 100.0)},
+		second.ID: {newSet(second.ID, 1, 90.0)},
+		third.ID:  {newSet(third.ID, 1, 110.0)},
+	}
+
+	records := RebuildPersonalRecords(exerciseID, []*Workout{first, second, third}, setsByWorkout, OneRMFormulaEpley)
+
+	// 1回目は初回記録（推定1RM + 1RM + ボリューム）、2回目は更新なし、3回目は1回目を上回る
+	if len(records) != 6 {
+		t.Fatalf("RebuildPersonalRecords() count = %d, want 6", len(records))
+	}
+	for _, record := range records[:3] {
+		if record.WorkoutID != first.ID || record.PreviousValue != nil {
+			t.Errorf("record = (%v, previous %v), want first record of first workout", record.RecordType, record.PreviousValue)
+		}
+	}
+	for _, record := range records[3:] {
+		if record.WorkoutID != third.ID || record.PreviousValue == nil || *record.PreviousValue != 100.0 {
+			t.Errorf("record = (%v, previous %v), want third workout beating 100", record.RecordType, record.PreviousValue)
+		}
+		if !record.AchievedDate.Equal(third.Date) {
+			t.Errorf("AchievedDate = %v, want %v", record.AchievedDate, third.Date)
+		}
+	}
+}
@@ -3,27 +3,53 @@ package entity
 import (
 	"errors"
 	"time"
+	// タイムゾーンの検証を実行環境のtzdataに依存させないため埋め込む
+	_ "time/tzdata"
 
 	"github.com/google/uuid"
 )
 
 var (
-	ErrInvalidDisplayName = errors.New("display name must be between 1 and 100 characters")
-	ErrInvalidAge         = errors.New("age must be between 0 and 150")
-	ErrInvalidWeight      = errors.New("weight must be greater than 0")
-	ErrInvalidHeight      = errors.New("height must be between 1 and 300 cm")
+	ErrInvalidDisplayName        = errors.New("display name must be between 1 and 100 characters")
+	ErrInvalidAge                = errors.New("age must be between 0 and 150")
+	ErrInvalidWeight             = errors.New("weight must be greater than 0")
+	ErrInvalidHeight             = errors.New("height must be between 1 and 300 cm")
+	ErrInvalidTimezone           = errors.New("invalid timezone: must be an IANA time zone name")
+	ErrInvalidWeeklyWorkoutGoal  = errors.New("weekly workout goal must be between 1 and 7")
+	ErrInvalidRestDayTolerance   = errors.New("rest day tolerance must be between 0 and 6")
+	ErrInvalidDailyScoreStrategy = errors.New("invalid daily score strategy: must be absolute, bodyweight_relative, rolling_average or rpe_weighted")
+)
+
+// DefaultTimezone はタイムゾーン未設定時に使用するタイムゾーン
+const DefaultTimezone = "UTC"
+
+const (
+	// DefaultWeeklyWorkoutGoal は週あたりの目標トレーニング日数の初期値
+	DefaultWeeklyWorkoutGoal int32 = 3
+	// DefaultRestDayTolerance は連続記録を途切れさせない休息日数の初期値
+	DefaultRestDayTolerance int32 = 1
 )
 
 // Profile はユーザーのプロフィール情報を表す
 type Profile struct {
-	ID          uuid.UUID
-	UserID      uuid.UUID
-	DisplayName string
-	Age         *int32
-	Weight      *float64
-	Height      *float64
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID           uuid.UUID
+	UserID       uuid.UUID
+	DisplayName  string
+	Age          *int32
+	Weight       *float64
+	Height       *float64
+	OneRMFormula OneRMFormula
+	Timezone     string
+	// WeeklyWorkoutGoal は週あたりの目標トレーニング日数（1〜7）
+	WeeklyWorkoutGoal int32
+	// RestDayTolerance は日単位の連続記録を途切れさせずに休める連続日数（0〜6）
+	RestDayTolerance int32
+	// DailyScoreStrategy はデイリースコアの計算方法
+	DailyScoreStrategy DailyScoreStrategyType
+	// DailyScoreBackfillPending は計算方法の変更後、保存済みのデイリースコアの再計算が未完了かどうか
+	DailyScoreBackfillPending bool
+	CreatedAt                 time.Time
+	UpdatedAt                 time.Time
 }
 
 // NewProfile はバリデーション付きで新しいProfileエンティティを作成する
@@ -34,25 +60,36 @@ func NewProfile(userID uuid.UUID, displayName string) (*Profile, error) {
 
 	now := time.Now()
 	return &Profile{
-		ID:          uuid.New(),
-		UserID:      userID,
-		DisplayName: displayName,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		ID:                 uuid.New(),
+		UserID:             userID,
+		DisplayName:        displayName,
+		OneRMFormula:       DefaultOneRMFormula,
+		Timezone:           DefaultTimezone,
+		WeeklyWorkoutGoal:  DefaultWeeklyWorkoutGoal,
+		RestDayTolerance:   DefaultRestDayTolerance,
+		DailyScoreStrategy: DefaultDailyScoreStrategy,
+		CreatedAt:          now,
+		UpdatedAt:          now,
 	}, nil
 }
 
 // ReconstructProfile は保存されたデータからProfileエンティティを再構築する
-func ReconstructProfile(id, userID uuid.UUID, displayName string, age *int32, weight, height *float64, createdAt, updatedAt time.Time) *Profile {
+func ReconstructProfile(id, userID uuid.UUID, displayName string, age *int32, weight, height *float64, oneRMFormula OneRMFormula, timezone string, weeklyWorkoutGoal, restDayTolerance int32, dailyScoreStrategy DailyScoreStrategyType, dailyScoreBackfillPending bool, createdAt, updatedAt time.Time) *Profile {
 	return &Profile{
-		ID:          id,
-		UserID:      userID,
-		DisplayName: displayName,
-		Age:         age,
-		Weight:      weight,
-		Height:      height,
-		CreatedAt:   createdAt,
-		UpdatedAt:   updatedAt,
+		ID:                        id,
+		UserID:                    userID,
+		DisplayName:               displayName,
+		Age:                       age,
+		Weight:                    weight,
+		Height:                    height,
+		OneRMFormula:              oneRMFormula,
+		Timezone:                  timezone,
+		WeeklyWorkoutGoal:         weeklyWorkoutGoal,
+		RestDayTolerance:          restDayTolerance,
+		DailyScoreStrategy:        dailyScoreStrategy,
+		DailyScoreBackfillPending: dailyScoreBackfillPending,
+		CreatedAt:                 createdAt,
+		UpdatedAt:                 updatedAt,
 	}
 }
 
@@ -79,12 +116,17 @@ func (p *Profile) UpdateAge(age *int32) error {
 }
 
 // UpdateWeight はプロフィールの体重を更新する
+// 体重を使用するデイリースコアの計算方法を選択している場合、体重が変わると保存済みのスコアを再計算待ちにする
 func (p *Profile) UpdateWeight(weight *float64) error {
 	if weight != nil {
 		if err := ValidateWeight(*weight); err != nil {
 			return err
 		}
 	}
+	changed := (weight == nil) != (p.Weight == nil) || (weight != nil && p.Weight != nil && *weight != *p.Weight)
+	if changed && p.DailyScoreStrategy.UsesBodyWeight() {
+		p.DailyScoreBackfillPending = true
+	}
 	p.Weight = weight
 	p.UpdatedAt = time.Now()
 	return nil
@@ -102,6 +144,74 @@ func (p *Profile) UpdateHeight(height *float64) error {
 	return nil
 }
 
+// UpdateOneRMFormula は推定1RMの計算に使用する公式を更新する
+func (p *Profile) UpdateOneRMFormula(formula OneRMFormula) error {
+	if !formula.IsValid() {
+		return ErrInvalidOneRMFormula
+	}
+	p.OneRMFormula = formula
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// UpdateTimezone は日付の解釈に使用するタイムゾーンを更新する
+// タイムゾーンはIANAタイムゾーン名（例: Asia/Tokyo）で指定する
+func (p *Profile) UpdateTimezone(timezone string) error {
+	if err := ValidateTimezone(timezone); err != nil {
+		return err
+	}
+	p.Timezone = timezone
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// UpdateWeeklyWorkoutGoal は週あたりの目標トレーニング日数を更新する
+func (p *Profile) UpdateWeeklyWorkoutGoal(goal int32) error {
+	if err := ValidateWeeklyWorkoutGoal(goal); err != nil {
+		return err
+	}
+	p.WeeklyWorkoutGoal = goal
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// UpdateRestDayTolerance は日単位の連続記録を途切れさせずに休める連続日数を更新する
+func (p *Profile) UpdateRestDayTolerance(tolerance int32) error {
+	if err := ValidateRestDayTolerance(tolerance); err != nil {
+		return err
+	}
+	p.RestDayTolerance = tolerance
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// UpdateDailyScoreStrategy はデイリースコアの計算方法を更新する
+// 計算方法が変わった場合は、保存済みのデイリースコアを再計算するため再計算待ちとする
+func (p *Profile) UpdateDailyScoreStrategy(strategy DailyScoreStrategyType) error {
+	if !strategy.IsValid() {
+		return ErrInvalidDailyScoreStrategy
+	}
+	if strategy != p.DailyScoreStrategy {
+		p.DailyScoreBackfillPending = true
+	}
+	p.DailyScoreStrategy = strategy
+	p.UpdatedAt = time.Now()
+	return nil
+}
+
+// Location はプロフィールのタイムゾーンを*time.Locationとして返す
+// タイムゾーンが未設定または読み込めない場合はUTCを返す
+func (p *Profile) Location() *time.Location {
+	if p.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
 // CalculateBMI はBMI（Body Mass Index）を計算する
 // 体重または身長が設定されていない場合はnilを返す
 func (p *Profile) CalculateBMI() *float64 {
@@ -146,3 +256,31 @@ func ValidateHeight(height float64) error {
 	}
 	return nil
 }
+
+// ValidateTimezone はタイムゾーンがIANAタイムゾーン名として有効かを検証する
+// 空文字とサーバーのローカルタイムゾーンを表す"Local"は無効とする
+func ValidateTimezone(timezone string) error {
+	if timezone == "" || timezone == "Local" {
+		return ErrInvalidTimezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return ErrInvalidTimezone
+	}
+	return nil
+}
+
+// ValidateWeeklyWorkoutGoal は週あたりの目標トレーニング日数を検証する
+func ValidateWeeklyWorkoutGoal(goal int32) error {
+	if goal < 1 || goal > 7 {
+		return ErrInvalidWeeklyWorkoutGoal
+	}
+	return nil
+}
+
+// ValidateRestDayTolerance は日単位の連続記録を途切れさせずに休める連続日数を検証する
+func ValidateRestDayTolerance(tolerance int32) error {
+	if tolerance < 0 || tolerance > 6 {
+		return ErrInvalidRestDayTolerance
+	}
+	return nil
+}
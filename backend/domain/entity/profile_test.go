@@ -87,6 +87,10 @@ func TestNewProfile(t *testing.T) {
 			if profile.ID == uuid.Nil {
 				t.Error("profile.ID should not be nil UUID")
 			}
+
+			if profile.OneRMFormula != DefaultOneRMFormula {
+				t.Errorf("profile.OneRMFormula = %v, want %v", profile.OneRMFormula, DefaultOneRMFormula)
+			}
 		})
 	}
 }
@@ -301,6 +305,36 @@ func TestProfile_UpdateWeight(t *testing.T) {
 	}
 }
 
+func TestProfile_UpdateWeight_DailyScoreBackfill(t *testing.T) {
+	tests := []struct {
+		name        string
+		strategy    DailyScoreStrategyType
+		weight      *float64
+		wantPending bool
+	}{
+		{name: "正常系: 体重比で計算する場合は体重の変更で再計算待ちになる", strategy: DailyScoreStrategyBodyweightRelative, weight: float64Ptr(75.0), wantPending: true},
+		{name: "正常系: 平均負荷量との比で計算する場合は体重の変更で再計算待ちになる", strategy: DailyScoreStrategyRollingAverage, weight: float64Ptr(75.0), wantPending: true},
+		{name: "正常系: 体重の削除でも再計算待ちになる", strategy: DailyScoreStrategyBodyweightRelative, weight: nil, wantPending: true},
+		{name: "正常系: 同じ体重では再計算待ちにならない", strategy: DailyScoreStrategyBodyweightRelative, weight: float64Ptr(70.0), wantPending: false},
+		{name: "正常系: 体重を使用しない計算方法では再計算待ちにならない", strategy: DailyScoreStrategyAbsolute, weight: float64Ptr(75.0), wantPending: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, _ := NewProfile(uuid.New(), "Test User")
+			profile.Weight = float64Ptr(70.0)
+			profile.DailyScoreStrategy = tt.strategy
+
+			if err := profile.UpdateWeight(tt.weight); err != nil {
+				t.Fatalf("UpdateWeight() unexpected error = %v", err)
+			}
+			if profile.DailyScoreBackfillPending != tt.wantPending {
+				t.Errorf("profile.DailyScoreBackfillPending = %v, want %v", profile.DailyScoreBackfillPending, tt.wantPending)
+			}
+		})
+	}
+}
+
 func TestProfile_UpdateHeight(t *testing.T) {
 	userID := uuid.New()
 	profile, _ := NewProfile(userID, "Test User")
@@ -373,6 +407,312 @@ func TestProfile_UpdateHeight(t *testing.T) {
 	}
 }
 
+func TestProfile_UpdateOneRMFormula(t *testing.T) {
+	userID := uuid.New()
+	profile, _ := NewProfile(userID, "Test User")
+
+	tests := []struct {
+		name        string
+		formula     OneRMFormula
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name:    "正常系: Brzycki式",
+			formula: OneRMFormulaBrzycki,
+			wantErr: false,
+		},
+		{
+			name:    "正常系: Wathan式",
+			formula: OneRMFormulaWathan,
+			wantErr: false,
+		},
+		{
+			name:        "異常系: 未知の公式",
+			formula:     OneRMFormula("unknown"),
+			wantErr:     true,
+			expectedErr: ErrInvalidOneRMFormula,
+		},
+		{
+			name:        "異常系: 空文字列",
+			formula:     OneRMFormula(""),
+			wantErr:     true,
+			expectedErr: ErrInvalidOneRMFormula,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := profile.OneRMFormula
+			err := profile.UpdateOneRMFormula(tt.formula)
+
+			if tt.wantErr {
+				if err != tt.expectedErr {
+					t.Errorf("UpdateOneRMFormula() error = %v, want %v", err, tt.expectedErr)
+				}
+				if profile.OneRMFormula != before {
+					t.Errorf("profile.OneRMFormula = %v, want unchanged %v", profile.OneRMFormula, before)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("UpdateOneRMFormula() unexpected error = %v", err)
+				return
+			}
+
+			if profile.OneRMFormula != tt.formula {
+				t.Errorf("profile.OneRMFormula = %v, want %v", profile.OneRMFormula, tt.formula)
+			}
+		})
+	}
+}
+
+func TestProfile_UpdateTimezone(t *testing.T) {
+	userID := uuid.New()
+	profile, _ := NewProfile(userID, "Test User")
+
+	if profile.Timezone != DefaultTimezone {
+		t.Errorf("NewProfile() Timezone = %v, want %v", profile.Timezone, DefaultTimezone)
+	}
+
+	tests := []struct {
+		name        string
+		timezone    string
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name:     "正常系: Asia/Tokyo",
+			timezone: "Asia/Tokyo",
+			wantErr:  false,
+		},
+		{
+			name:     "正常系: America/New_York",
+			timezone: "America/New_York",
+			wantErr:  false,
+		},
+		{
+			name:        "異常系: 存在しないタイムゾーン",
+			timezone:    "Mars/Olympus",
+			wantErr:     true,
+			expectedErr: ErrInvalidTimezone,
+		},
+		{
+			name:        "異常系: Local",
+			timezone:    "Local",
+			wantErr:     true,
+			expectedErr: ErrInvalidTimezone,
+		},
+		{
+			name:        "異常系: 空文字列",
+			timezone:    "",
+			wantErr:     true,
+			expectedErr: ErrInvalidTimezone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := profile.Timezone
+			err := profile.UpdateTimezone(tt.timezone)
+
+			if tt.wantErr {
+				if err != tt.expectedErr {
+					t.Errorf("UpdateTimezone() error = %v, want %v", err, tt.expectedErr)
+				}
+				if profile.Timezone != before {
+					t.Errorf("profile.Timezone = %v, want unchanged %v", profile.Timezone, before)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("UpdateTimezone() unexpected error = %v", err)
+				return
+			}
+
+			if profile.Timezone != tt.timezone {
+				t.Errorf("profile.Timezone = %v, want %v", profile.Timezone, tt.timezone)
+			}
+			if profile.Location().String() != tt.timezone {
+				t.Errorf("profile.Location() = %v, want %v", profile.Location(), tt.timezone)
+			}
+		})
+	}
+}
+
+func TestProfile_UpdateWeeklyWorkoutGoal(t *testing.T) {
+	userID := uuid.New()
+	profile, _ := NewProfile(userID, "Test User")
+
+	if profile.WeeklyWorkoutGoal != DefaultWeeklyWorkoutGoal {
+		t.Errorf("NewProfile() WeeklyWorkoutGoal = %v, want %v", profile.WeeklyWorkoutGoal, DefaultWeeklyWorkoutGoal)
+	}
+
+	tests := []struct {
+		name        string
+		goal        int32
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "正常系: 週1日", goal: 1, wantErr: false},
+		{name: "正常系: 週7日", goal: 7, wantErr: false},
+		{name: "異常系: 0日", goal: 0, wantErr: true, expectedErr: ErrInvalidWeeklyWorkoutGoal},
+		{name: "異常系: 8日", goal: 8, wantErr: true, expectedErr: ErrInvalidWeeklyWorkoutGoal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := profile.WeeklyWorkoutGoal
+			err := profile.UpdateWeeklyWorkoutGoal(tt.goal)
+
+			if tt.wantErr {
+				if err != tt.expectedErr {
+					t.Errorf("UpdateWeeklyWorkoutGoal() error = %v, want %v", err, tt.expectedErr)
+				}
+				if profile.WeeklyWorkoutGoal != before {
+					t.Errorf("profile.WeeklyWorkoutGoal = %v, want unchanged %v", profile.WeeklyWorkoutGoal, before)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("UpdateWeeklyWorkoutGoal() unexpected error = %v", err)
+				return
+			}
+			if profile.WeeklyWorkoutGoal != tt.goal {
+				t.Errorf("profile.WeeklyWorkoutGoal = %v, want %v", profile.WeeklyWorkoutGoal, tt.goal)
+			}
+		})
+	}
+}
+
+func TestProfile_UpdateRestDayTolerance(t *testing.T) {
+	userID := uuid.New()
+	profile, _ := NewProfile(userID, "Test User")
+
+	if profile.RestDayTolerance != DefaultRestDayTolerance {
+		t.Errorf("NewProfile() RestDayTolerance = %v, want %v", profile.RestDayTolerance, DefaultRestDayTolerance)
+	}
+
+	tests := []struct {
+		name        string
+		tolerance   int32
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "正常系: 休息日を許容しない", tolerance: 0, wantErr: false},
+		{name: "正常系: 6日", tolerance: 6, wantErr: false},
+		{name: "異常系: 負の値", tolerance: -1, wantErr: true, expectedErr: ErrInvalidRestDayTolerance},
+		{name: "異常系: 7日", tolerance: 7, wantErr: true, expectedErr: ErrInvalidRestDayTolerance},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := profile.RestDayTolerance
+			err := profile.UpdateRestDayTolerance(tt.tolerance)
+
+			if tt.wantErr {
+				if err != tt.expectedErr {
+					t.Errorf("UpdateRestDayTolerance() error = %v, want %v", err, tt.expectedErr)
+				}
+				if profile.RestDayTolerance != before {
+					t.Errorf("profile.RestDayTolerance = %v, want unchanged %v", profile.RestDayTolerance, before)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("UpdateRestDayTolerance() unexpected error = %v", err)
+				return
+			}
+			if profile.RestDayTolerance != tt.tolerance {
+				t.Errorf("profile.RestDayTolerance = %v, want %v", profile.RestDayTolerance, tt.tolerance)
+			}
+		})
+	}
+}
+
+func TestProfile_UpdateDailyScoreStrategy(t *testing.T) {
+	tests := []struct {
+		name        string
+		strategy    DailyScoreStrategyType
+		wantPending bool
+		wantErr     bool
+		expectedErr error
+	}{
+		{name: "正常系: 計算方法を変更すると再計算待ちになる", strategy: DailyScoreStrategyRollingAverage, wantPending: true},
+		{name: "正常系: 同じ計算方法では再計算待ちにならない", strategy: DefaultDailyScoreStrategy, wantPending: false},
+		{name: "異常系: 無効な計算方法", strategy: "unknown", wantErr: true, expectedErr: ErrInvalidDailyScoreStrategy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, _ := NewProfile(uuid.New(), "Test User")
+			if profile.DailyScoreStrategy != DefaultDailyScoreStrategy {
+				t.Errorf("NewProfile() DailyScoreStrategy = %v, want %v", profile.DailyScoreStrategy, DefaultDailyScoreStrategy)
+			}
+
+			err := profile.UpdateDailyScoreStrategy(tt.strategy)
+
+			if tt.wantErr {
+				if err != tt.expectedErr {
+					t.Errorf("UpdateDailyScoreStrategy() error = %v, want %v", err, tt.expectedErr)
+				}
+				if profile.DailyScoreStrategy != DefaultDailyScoreStrategy || profile.DailyScoreBackfillPending {
+					t.Errorf("profile changed on error: %v, %v", profile.DailyScoreStrategy, profile.DailyScoreBackfillPending)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("UpdateDailyScoreStrategy() unexpected error = %v", err)
+				return
+			}
+			if profile.DailyScoreStrategy != tt.strategy {
+				t.Errorf("profile.DailyScoreStrategy = %v, want %v", profile.DailyScoreStrategy, tt.strategy)
+			}
+			if profile.DailyScoreBackfillPending != tt.wantPending {
+				t.Errorf("profile.DailyScoreBackfillPending = %v, want %v", profile.DailyScoreBackfillPending, tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestProfile_Location(t *testing.T) {
+	tests := []struct {
+		name     string
+		timezone string
+		want     string
+	}{
+		{
+			name:     "正常系: 設定されたタイムゾーン",
+			timezone: "Asia/Tokyo",
+			want:     "Asia/Tokyo",
+		},
+		{
+			name:     "正常系: 未設定の場合はUTC",
+			timezone: "",
+			want:     "UTC",
+		},
+		{
+			name:     "正常系: 読み込めない場合はUTC",
+			timezone: "Invalid/Zone",
+			want:     "UTC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := &Profile{Timezone: tt.timezone}
+			if got := profile.Location().String(); got != tt.want {
+				t.Errorf("Location() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 // NOTE: ヘルパー関数：float64のポインタを生成
 func float64Ptr(f float64) *float64 {
 	return &f
@@ -0,0 +1,210 @@
+package entity
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidProgramName     = errors.New("program name must be between 1 and 100 characters")
+	ErrEmptyProgramDays       = errors.New("program must have at least one day")
+	ErrEmptyProgramDaySets    = errors.New("program day must have at least one set")
+	ErrInvalidProgramWeek     = errors.New("program week must be greater than 0")
+	ErrInvalidProgramDay      = errors.New("program day must be greater than 0")
+	ErrProgramDaysOutOfOrder  = errors.New("program days must be in ascending order of week and day")
+	ErrInvalidPrescribedReps  = errors.New("prescribed reps must be greater than 0")
+	ErrInvalidPercentageOf1RM = errors.New("percentage of 1RM must be greater than 0 and less than or equal to 150")
+)
+
+// LoadRoundingIncrement は処方重量を丸める単位（kg）。一般的なプレートの組み合わせで作れる2.5kg刻みとする。
+const LoadRoundingIncrement = 2.5
+
+// ProgramSet はプログラムの1日に含まれる1セット分の処方（種目・レップ数・推定1RMに対する割合）を表す
+type ProgramSet struct {
+	ExerciseID      uuid.UUID
+	Reps            int32
+	PercentageOf1RM float64
+}
+
+// ProgramDay はプログラムの1日分（第何週の何日目か）の処方を表す。
+// Sets の並び順がその日に行うセットの並び順となる。
+type ProgramDay struct {
+	Week int32
+	Day  int32
+	Sets []ProgramSet
+}
+
+// Program は複数週にわたるトレーニングプログラムを表す。
+// 各日の重量は推定1RMに対する割合で処方され、実際の重量はユーザーの現在の最大推定1RMから計算する。
+// CurrentDayIndex は次に行う日（Days のインデックス）を指し、全日程を終えると len(Days) になる。
+type Program struct {
+	ID              uuid.UUID
+	UserID          uuid.UUID
+	Name            string
+	Description     *string
+	Days            []ProgramDay
+	CurrentDayIndex int32
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// ProgramWorkout はワークアウトをプログラムのどの日として記録したかの紐付けを表す。
+// DayIndex は記録時点のプログラムの CurrentDayIndex。
+type ProgramWorkout struct {
+	WorkoutID uuid.UUID
+	ProgramID uuid.UUID
+	DayIndex  int32
+}
+
+// NewProgramSet はバリデーション付きで新しいProgramSetを作成する
+func NewProgramSet(exerciseID uuid.UUID, reps int32, percentageOf1RM float64) (ProgramSet, error) {
+	if reps <= 0 {
+		return ProgramSet{}, ErrInvalidPrescribedReps
+	}
+	if percentageOf1RM <= 0 || percentageOf1RM > 150 {
+		return ProgramSet{}, ErrInvalidPercentageOf1RM
+	}
+
+	return ProgramSet{
+		ExerciseID:      exerciseID,
+		Reps:            reps,
+		PercentageOf1RM: percentageOf1RM,
+	}, nil
+}
+
+// PrescribedWeight は推定1RMから処方重量を計算し、LoadRoundingIncrement 刻みに丸めて返す
+func (s ProgramSet) PrescribedWeight(estimated1RM float64) float64 {
+	weight := estimated1RM * s.PercentageOf1RM / 100
+	return math.Round(weight/LoadRoundingIncrement) * LoadRoundingIncrement
+}
+
+// NewProgramDay はバリデーション付きで新しいProgramDayを作成する
+func NewProgramDay(week, day int32, sets []ProgramSet) (ProgramDay, error) {
+	if week <= 0 {
+		return ProgramDay{}, ErrInvalidProgramWeek
+	}
+	if day <= 0 {
+		return ProgramDay{}, ErrInvalidProgramDay
+	}
+	if len(sets) == 0 {
+		return ProgramDay{}, ErrEmptyProgramDaySets
+	}
+
+	return ProgramDay{
+		Week: week,
+		Day:  day,
+		Sets: sets,
+	}, nil
+}
+
+// ExerciseIDs はその日に行う種目のIDを重複なく、初出順で返す
+func (d ProgramDay) ExerciseIDs() []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(d.Sets))
+	var ids []uuid.UUID
+	for _, s := range d.Sets {
+		if !seen[s.ExerciseID] {
+			seen[s.ExerciseID] = true
+			ids = append(ids, s.ExerciseID)
+		}
+	}
+	return ids
+}
+
+// IsSatisfiedBy は記録されたワークアウトの種目がその日の全種目を含むかどうかを返す
+func (d ProgramDay) IsSatisfiedBy(exerciseIDs []uuid.UUID) bool {
+	recorded := make(map[uuid.UUID]bool, len(exerciseIDs))
+	for _, id := range exerciseIDs {
+		recorded[id] = true
+	}
+	for _, id := range d.ExerciseIDs() {
+		if !recorded[id] {
+			return false
+		}
+	}
+	return true
+}
+
+// NewProgram はバリデーション付きで新しいProgramエンティティを作成する。
+// Days は週・日の昇順で並んでいる必要がある。
+func NewProgram(userID uuid.UUID, name string, description *string, days []ProgramDay) (*Program, error) {
+	if err := ValidateProgramName(name); err != nil {
+		return nil, err
+	}
+	if len(days) == 0 {
+		return nil, ErrEmptyProgramDays
+	}
+	for i := 1; i < len(days); i++ {
+		prev, cur := days[i-1], days[i]
+		if cur.Week < prev.Week || (cur.Week == prev.Week && cur.Day <= prev.Day) {
+			return nil, ErrProgramDaysOutOfOrder
+		}
+	}
+
+	now := time.Now()
+	return &Program{
+		ID:              uuid.New(),
+		UserID:          userID,
+		Name:            name,
+		Description:     description,
+		Days:            days,
+		CurrentDayIndex: 0,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}, nil
+}
+
+// ReconstructProgram は保存されたデータからProgramエンティティを再構築する
+func ReconstructProgram(id, userID uuid.UUID, name string, description *string, days []ProgramDay, currentDayIndex int32, createdAt, updatedAt time.Time) *Program {
+	return &Program{
+		ID:              id,
+		UserID:          userID,
+		Name:            name,
+		Description:     description,
+		Days:            days,
+		CurrentDayIndex: currentDayIndex,
+		CreatedAt:       createdAt,
+		UpdatedAt:       updatedAt,
+	}
+}
+
+// IsOwnedBy は指定ユーザーが所有するプログラムかどうかを返す
+func (p *Program) IsOwnedBy(userID uuid.UUID) bool {
+	return p.UserID == userID
+}
+
+// IsCompleted は全日程を終えたかどうかを返す
+func (p *Program) IsCompleted() bool {
+	return int(p.CurrentDayIndex) >= len(p.Days)
+}
+
+// CurrentDay は次に行う日を返す。全日程を終えている場合はnilを返す。
+func (p *Program) CurrentDay() *ProgramDay {
+	if p.IsCompleted() {
+		return nil
+	}
+	return &p.Days[p.CurrentDayIndex]
+}
+
+// AdvanceIfSatisfied は dayIndex の日として記録されたワークアウトの種目がその日を満たす場合に次の日へ進める。
+// 進めた場合はtrueを返す。dayIndex が現在の日でない場合（既に進めた日への追記など）や
+// 全日程を終えている場合は何もしない。
+func (p *Program) AdvanceIfSatisfied(dayIndex int32, exerciseIDs []uuid.UUID) bool {
+	day := p.CurrentDay()
+	if day == nil || p.CurrentDayIndex != dayIndex || !day.IsSatisfiedBy(exerciseIDs) {
+		return false
+	}
+	p.CurrentDayIndex++
+	p.UpdatedAt = time.Now()
+	return true
+}
+
+// ValidateProgramName はプログラム名を検証する
+func ValidateProgramName(name string) error {
+	if len(name) < 1 || len(name) > 100 {
+		return ErrInvalidProgramName
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewProgramSet(t *testing.T) {
+	exerciseID := uuid.New()
+
+	tests := []struct {
+		name        string
+		reps        int32
+		percentage  float64
+		expectedErr error
+	}{
+		{
+			name:       "正常系: 有効な処方",
+			reps:       5,
+			percentage: 85,
+		},
+		{
+			name:       "正常系: 割合の上限",
+			reps:       1,
+			percentage: 150,
+		},
+		{
+			name:        "異常系: レップ数0",
+			reps:        0,
+			percentage:  85,
+			expectedErr: ErrInvalidPrescribedReps,
+		},
+		{
+			name:        "異常系: 割合0",
+			reps:        5,
+			percentage:  0,
+			expectedErr: ErrInvalidPercentageOf1RM,
+		},
+		{
+			name:        "異常系: 割合が上限超過",
+			reps:        5,
+			percentage:  150.5,
+			expectedErr: ErrInvalidPercentageOf1RM,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewProgramSet(exerciseID, tt.reps, tt.percentage)
+
+			if tt.expectedErr != nil {
+				if err != tt.expectedErr {
+					t.Errorf("NewProgramSet() error = %v, want %v", err, tt.expectedErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewProgramSet() unexpected error = %v", err)
+			}
+			if s.ExerciseID != exerciseID || s.Reps != tt.reps || s.PercentageOf1RM != tt.percentage {
+				t.Errorf("NewProgramSet() = %+v, unexpected fields", s)
+			}
+		})
+	}
+}
+
+func TestProgramSet_PrescribedWeight(t *testing.T) {
+	tests := []struct {
+		name         string
+		percentage   float64
+		estimated1RM float64
+		want         float64
+	}{
+		{name: "正常系: 割り切れる重量", percentage: 80, estimated1RM: 100, want: 80},
+		{name: "正常系: 2.5kg刻みに切り上げ", percentage: 85, estimated1RM: 105, want: 90},
+		{name: "正常系: 2.5kg刻みに切り捨て", percentage: 65, estimated1RM: 101, want: 65},
+		{name: "正常系: 推定1RMが0", percentage: 75, estimated1RM: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := ProgramSet{ExerciseID: uuid.New(), Reps: 5, PercentageOf1RM: tt.percentage}
+			if got := s.PrescribedWeight(tt.estimated1RM); got != tt.want {
+				t.Errorf("PrescribedWeight(%v) = %v, want %v", tt.estimated1RM, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewProgramDay(t *testing.T) {
+	sets := []ProgramSet{{ExerciseID: uuid.New(), Reps: 5, PercentageOf1RM: 65}}
+
+	tests := []struct {
+		name        string
+		week        int32
+		day         int32
+		sets        []ProgramSet
+		expectedErr error
+	}{
+		{name: "正常系: 有効な日", week: 1, day: 1, sets: sets},
+		{name: "異常系: 週が0", week: 0, day: 1, sets: sets, expectedErr: ErrInvalidProgramWeek},
+		{name: "異常系: 日が0", week: 1, day: 0, sets: sets, expectedErr: ErrInvalidProgramDay},
+		{name: "異常系: セットが空", week: 1, day: 1, sets: nil, expectedErr: ErrEmptyProgramDaySets},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewProgramDay(tt.week, tt.day, tt.sets)
+
+			if tt.expectedErr != nil {
+				if err != tt.expectedErr {
+					t.Errorf("NewProgramDay() error = %v, want %v", err, tt.expectedErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewProgramDay() unexpected error = %v", err)
+			}
+			if d.Week != tt.week || d.Day != tt.day || len(d.Sets) != len(tt.sets) {
+				t.Errorf("NewProgramDay() = %+v, unexpected fields", d)
+			}
+		})
+	}
+}
+
+func TestNewProgram(t *testing.T) {
+	userID := uuid.New()
+	sets := []ProgramSet{{ExerciseID: uuid.New(), Reps: 5, PercentageOf1RM: 65}}
+	day := func(week, d int32) ProgramDay {
+		return ProgramDay{Week: week, Day: d, Sets: sets}
+	}
+
+	tests := []struct {
+		name        string
+		programName string
+		days        []ProgramDay
+		expectedErr error
+	}{
+		{
+			name:        "正常系: 複数週のプログラム",
+			programName: "5/3/1",
+			days:        []ProgramDay{day(1, 1), day(1, 2), day(2, 1)},
+		},
+		{
+			name:        "異常系: 名前が空",
+			programName: "",
+			days:        []ProgramDay{day(1, 1)},
+			expectedErr: ErrInvalidProgramName,
+		},
+		{
+			name:        "異常系: 日が空",
+			programName: "5/3/1",
+			days:        nil,
+			expectedErr: ErrEmptyProgramDays,
+		},
+		{
+			name:        "異常系: 週が逆順",
+			programName: "5/3/1",
+			days:        []ProgramDay{day(2, 1), day(1, 1)},
+			expectedErr: ErrProgramDaysOutOfOrder,
+		},
+		{
+			name:        "異常系: 同じ週・日が重複",
+			programName: "5/3/1",
+			days:        []ProgramDay{day(1, 1), day(1, 1)},
+			expectedErr: ErrProgramDaysOutOfOrder,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewProgram(userID, tt.programName, nil, tt.days)
+
+			if tt.expectedErr != nil {
+				if err != tt.expectedErr {
+					t.Errorf("NewProgram() error = %v, want %v", err, tt.expectedErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewProgram() unexpected error = %v", err)
+			}
+			if p.UserID != userID || p.Name != tt.programName || len(p.Days) != len(tt.days) {
+				t.Errorf("NewProgram() = %+v, unexpected fields", p)
+			}
+			if p.CurrentDayIndex != 0 || p.IsCompleted() {
+				t.Errorf("NewProgram() CurrentDayIndex = %d, want 0 and not completed", p.CurrentDayIndex)
+			}
+		})
+	}
+}
+
+func TestProgram_AdvanceIfSatisfied(t *testing.T) {
+	squat := uuid.New()
+	bench := uuid.New()
+	row := uuid.New()
+
+	program, err := NewProgram(uuid.New(), "Linear", nil, []ProgramDay{
+		{Week: 1, Day: 1, Sets: []ProgramSet{
+			{ExerciseID: squat, Reps: 5, PercentageOf1RM: 75},
+			{ExerciseID: bench, Reps: 5, PercentageOf1RM: 75},
+		}},
+		{Week: 1, Day: 2, Sets: []ProgramSet{
+			{ExerciseID: row, Reps: 8, PercentageOf1RM: 70},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("NewProgram() error = %v", err)
+	}
+
+	// 一部の種目のみでは進まない
+	if program.AdvanceIfSatisfied(0, []uuid.UUID{squat}) {
+		t.Error("AdvanceIfSatisfied() with partial exercises = true, want false")
+	}
+	if program.CurrentDayIndex != 0 {
+		t.Errorf("CurrentDayIndex = %d, want 0", program.CurrentDayIndex)
+	}
+
+	// 全種目を含めば進む（他の種目が含まれていてもよい）
+	if !program.AdvanceIfSatisfied(0, []uuid.UUID{bench, squat, row}) {
+		t.Error("AdvanceIfSatisfied() with all exercises = false, want true")
+	}
+
+	// 既に進めた日として記録されたワークアウトでは再度進まない
+	if program.AdvanceIfSatisfied(0, []uuid.UUID{bench, squat, row}) {
+		t.Error("AdvanceIfSatisfied() for a past day = true, want false")
+	}
+	if day := program.CurrentDay(); day == nil || day.Day != 2 {
+		t.Errorf("CurrentDay() = %+v, want week 1 day 2", day)
+	}
+
+	if !program.AdvanceIfSatisfied(1, []uuid.UUID{row}) {
+		t.Error("AdvanceIfSatisfied() on last day = false, want true")
+	}
+	if !program.IsCompleted() || program.CurrentDay() != nil {
+		t.Errorf("program should be completed, CurrentDayIndex = %d", program.CurrentDayIndex)
+	}
+
+	// 完了後は進まない
+	if program.AdvanceIfSatisfied(2, []uuid.UUID{squat, bench, row}) {
+		t.Error("AdvanceIfSatisfied() after completion = true, want false")
+	}
+}
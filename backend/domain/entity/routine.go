@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidRoutineName    = errors.New("routine name must be between 1 and 100 characters")
+	ErrEmptyRoutineExercises = errors.New("routine must have at least one exercise")
+	ErrInvalidTargetSets     = errors.New("target sets must be greater than 0")
+	ErrInvalidTargetReps     = errors.New("target reps must be greater than 0")
+	ErrInvalidTargetWeight   = errors.New("target weight must be greater than or equal to 0")
+)
+
+// RoutineExercise はルーティンに含まれる1種目分の目標（セット数・レップ数・重量）を表す
+type RoutineExercise struct {
+	ExerciseID   uuid.UUID
+	TargetSets   int32
+	TargetReps   int32
+	TargetWeight float64
+}
+
+// Routine はユーザーが繰り返し行うトレーニングのテンプレートを表す。
+// Exercises の並び順がワークアウト開始時のセットの並び順となる。
+type Routine struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Name        string
+	Description *string
+	Exercises   []RoutineExercise
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// NewRoutineExercise はバリデーション付きで新しいRoutineExerciseを作成する
+func NewRoutineExercise(exerciseID uuid.UUID, targetSets, targetReps int32, targetWeight float64) (RoutineExercise, error) {
+	if targetSets <= 0 {
+		return RoutineExercise{}, ErrInvalidTargetSets
+	}
+	if targetReps <= 0 {
+		return RoutineExercise{}, ErrInvalidTargetReps
+	}
+	if targetWeight < 0 {
+		return RoutineExercise{}, ErrInvalidTargetWeight
+	}
+
+	return RoutineExercise{
+		ExerciseID:   exerciseID,
+		TargetSets:   targetSets,
+		TargetReps:   targetReps,
+		TargetWeight: targetWeight,
+	}, nil
+}
+
+// NewRoutine はバリデーション付きで新しいRoutineエンティティを作成する
+func NewRoutine(userID uuid.UUID, name string, description *string, exercises []RoutineExercise) (*Routine, error) {
+	if err := ValidateRoutineName(name); err != nil {
+		return nil, err
+	}
+	if len(exercises) == 0 {
+		return nil, ErrEmptyRoutineExercises
+	}
+
+	now := time.Now()
+	return &Routine{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Name:        name,
+		Description: description,
+		Exercises:   exercises,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// ReconstructRoutine は保存されたデータからRoutineエンティティを再構築する
+func ReconstructRoutine(id, userID uuid.UUID, name string, description *string, exercises []RoutineExercise, createdAt, updatedAt time.Time) *Routine {
+	return &Routine{
+		ID:          id,
+		UserID:      userID,
+		Name:        name,
+		Description: description,
+		Exercises:   exercises,
+		CreatedAt:   createdAt,
+		UpdatedAt:   updatedAt,
+	}
+}
+
+// IsOwnedBy は指定ユーザーが所有するルーティンかどうかを返す
+func (r *Routine) IsOwnedBy(userID uuid.UUID) bool {
+	return r.UserID == userID
+}
+
+// UpdateName はルーティンの名前を更新する
+func (r *Routine) UpdateName(name string) error {
+	if err := ValidateRoutineName(name); err != nil {
+		return err
+	}
+	r.Name = name
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// UpdateDescription はルーティンの説明を更新する
+func (r *Routine) UpdateDescription(description *string) {
+	r.Description = description
+	r.UpdatedAt = time.Now()
+}
+
+// ReplaceExercises はルーティンの種目一覧を置き換える
+func (r *Routine) ReplaceExercises(exercises []RoutineExercise) error {
+	if len(exercises) == 0 {
+		return ErrEmptyRoutineExercises
+	}
+	r.Exercises = exercises
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// ValidateRoutineName はルーティン名を検証する
+func ValidateRoutineName(name string) error {
+	if len(name) < 1 || len(name) > 100 {
+		return ErrInvalidRoutineName
+	}
+	return nil
+}
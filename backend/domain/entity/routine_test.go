@@ -0,0 +1,174 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewRoutineExercise(t *testing.T) {
+	exerciseID := uuid.New()
+
+	tests := []struct {
+		name         string
+		targetSets   int32
+		targetReps   int32
+		targetWeight float64
+		expectedErr  error
+	}{
+		{
+			name:         "正常系: 有効な目標",
+			targetSets:   3,
+			targetReps:   10,
+			targetWeight: 60.0,
+		},
+		{
+			name:         "正常系: 重量0（自重種目）",
+			targetSets:   3,
+			targetReps:   15,
+			targetWeight: 0,
+		},
+		{
+			name:        "異常系: セット数0",
+			targetSets:  0,
+			targetReps:  10,
+			expectedErr: ErrInvalidTargetSets,
+		},
+		{
+			name:        "異常系: レップ数0",
+			targetSets:  3,
+			targetReps:  0,
+			expectedErr: ErrInvalidTargetReps,
+		},
+		{
+			name:         "異常系: 負の重量",
+			targetSets:   3,
+			targetReps:   10,
+			targetWeight: -1,
+			expectedErr:  ErrInvalidTargetWeight,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re, err := NewRoutineExercise(exerciseID, tt.targetSets, tt.targetReps, tt.targetWeight)
+
+			if tt.expectedErr != nil {
+				if err != tt.expectedErr {
+					t.Errorf("NewRoutineExercise() error = %v, want %v", err, tt.expectedErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewRoutineExercise() unexpected error = %v", err)
+			}
+			if re.ExerciseID != exerciseID || re.TargetSets != tt.targetSets || re.TargetReps != tt.targetReps || re.TargetWeight != tt.targetWeight {
+				t.Errorf("NewRoutineExercise() = %+v, unexpected fields", re)
+			}
+		})
+	}
+}
+
+func TestNewRoutine(t *testing.T) {
+	userID := uuid.New()
+	description := "Push day"
+	exercises := []RoutineExercise{
+		{ExerciseID: uuid.New(), TargetSets: 3, TargetReps: 10, TargetWeight: 60.0},
+	}
+
+	tests := []struct {
+		name        string
+		routineName string
+		exercises   []RoutineExercise
+		expectedErr error
+	}{
+		{
+			name:        "正常系: 有効なルーティン",
+			routineName: "Push",
+			exercises:   exercises,
+		},
+		{
+			name:        "異常系: 空の名前",
+			routineName: "",
+			exercises:   exercises,
+			expectedErr: ErrInvalidRoutineName,
+		},
+		{
+			name:        "異常系: 101文字の名前",
+			routineName: "12345678901234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901",
+			exercises:   exercises,
+			expectedErr: ErrInvalidRoutineName,
+		},
+		{
+			name:        "異常系: 種目が空",
+			routineName: "Push",
+			exercises:   nil,
+			expectedErr: ErrEmptyRoutineExercises,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routine, err := NewRoutine(userID, tt.routineName, &description, tt.exercises)
+
+			if tt.expectedErr != nil {
+				if err != tt.expectedErr {
+					t.Errorf("NewRoutine() error = %v, want %v", err, tt.expectedErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewRoutine() unexpected error = %v", err)
+			}
+			if routine.ID == uuid.Nil {
+				t.Error("NewRoutine() ID should not be nil")
+			}
+			if routine.UserID != userID {
+				t.Errorf("NewRoutine() UserID = %v, want %v", routine.UserID, userID)
+			}
+			if routine.Name != tt.routineName {
+				t.Errorf("NewRoutine() Name = %v, want %v", routine.Name, tt.routineName)
+			}
+			if len(routine.Exercises) != len(tt.exercises) {
+				t.Errorf("NewRoutine() Exercises length = %v, want %v", len(routine.Exercises), len(tt.exercises))
+			}
+		})
+	}
+}
+
+func TestRoutine_Update(t *testing.T) {
+	userID := uuid.New()
+	routine, err := NewRoutine(userID, "Push", nil, []RoutineExercise{
+		{ExerciseID: uuid.New(), TargetSets: 3, TargetReps: 10, TargetWeight: 60.0},
+	})
+	if err != nil {
+		t.Fatalf("NewRoutine() unexpected error = %v", err)
+	}
+
+	if err := routine.UpdateName(""); err != ErrInvalidRoutineName {
+		t.Errorf("UpdateName() error = %v, want %v", err, ErrInvalidRoutineName)
+	}
+	if err := routine.UpdateName("Push A"); err != nil || routine.Name != "Push A" {
+		t.Errorf("UpdateName() = %v, Name = %v", err, routine.Name)
+	}
+
+	if err := routine.ReplaceExercises(nil); err != ErrEmptyRoutineExercises {
+		t.Errorf("ReplaceExercises() error = %v, want %v", err, ErrEmptyRoutineExercises)
+	}
+	replaced := []RoutineExercise{
+		{ExerciseID: uuid.New(), TargetSets: 5, TargetReps: 5, TargetWeight: 100.0},
+		{ExerciseID: uuid.New(), TargetSets: 3, TargetReps: 12, TargetWeight: 20.0},
+	}
+	if err := routine.ReplaceExercises(replaced); err != nil || len(routine.Exercises) != 2 {
+		t.Errorf("ReplaceExercises() = %v, Exercises = %v", err, routine.Exercises)
+	}
+
+	if !routine.IsOwnedBy(userID) {
+		t.Error("IsOwnedBy() should be true for the owner")
+	}
+	if routine.IsOwnedBy(uuid.New()) {
+		t.Error("IsOwnedBy() should be false for another user")
+	}
+}
@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"crypto/subtle"
+	"errors"
 	"time"
 
 	"github.com/ucchy108/whiskey/backend/domain/value"
@@ -8,15 +10,39 @@ import (
 	"github.com/google/uuid"
 )
 
+var (
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnrolled    = errors.New("two-factor authentication enrollment has not been started")
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor authentication code")
+	ErrSameEmail               = errors.New("new email is the same as the current email")
+	ErrEmailChangeNotRequested = errors.New("email change has not been requested")
+	ErrEmailChangeTokenExpired = errors.New("email change token has expired")
+)
+
 // User はシステム内のユーザーを表す
 type User struct {
-	ID                uuid.UUID
-	Email             value.Email
-	PasswordHash      value.HashedPassword
-	EmailVerified     bool
-	VerificationToken *value.VerificationToken
-	CreatedAt         time.Time
-	UpdatedAt         time.Time
+	ID                 uuid.UUID
+	Email              value.Email
+	PasswordHash       value.HashedPassword
+	EmailVerified      bool
+	VerificationToken  *value.VerificationToken
+	PasswordResetToken *value.PasswordResetToken
+	// PendingEmail は変更後のメールアドレス。確認トークン（EmailChangeToken）が確認されるまでEmailは切り替えない。
+	PendingEmail     *value.Email
+	EmailChangeToken *value.EmailChangeToken
+	// TOTPSecret は二要素認証の秘密鍵。登録を開始してから確認されるまではTwoFactorEnabledがfalseのまま保持される。
+	TOTPSecret       *value.TOTPSecret
+	TwoFactorEnabled bool
+	// TOTPLastUsedStep は最後に受け付けたTOTPのタイムステップ。同じコードの再利用を防ぐために使用する。
+	TOTPLastUsedStep int64
+	// RecoveryCodeHashes は未使用のリカバリーコードのハッシュ
+	RecoveryCodeHashes []string
+	// DeletionScheduledAt はアカウントの削除予定日時。削除が要求されていない場合はnil。
+	// 猶予期間が過ぎるまではデータを残し、ログインすると削除は取り消される。
+	DeletionScheduledAt *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
 }
 
 // NewUser はバリデーション付きで新しいUserエンティティを作成する
@@ -53,22 +79,71 @@ func NewUser(email, password string) (*User, error) {
 	}, nil
 }
 
+// NewOIDCUser は外部IDプロバイダでメールアドレスの所有が確認されたユーザーを作成する。
+// メール検証は完了済みとし、パスワードは設定しない（パスワードでのログインはできず、
+// 必要に応じてパスワードリセットで設定する）。
+func NewOIDCUser(email string) (*User, error) {
+	emailVO, err := value.NewEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &User{
+		ID:            uuid.New(),
+		Email:         emailVO,
+		PasswordHash:  value.ReconstructHashedPassword(""),
+		EmailVerified: true,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}, nil
+}
+
 // ReconstructUser は保存されたデータからUserエンティティを再構築する
 // データベースからロードする際に使用される
-func ReconstructUser(id uuid.UUID, email, passwordHash string, emailVerified bool, verificationToken string, verificationTokenExpiresAt time.Time, createdAt, updatedAt time.Time) *User {
+func ReconstructUser(id uuid.UUID, email, passwordHash string, emailVerified bool, verificationToken string, verificationTokenExpiresAt time.Time, passwordResetTokenHash string, passwordResetTokenExpiresAt time.Time, pendingEmail, emailChangeTokenHash string, emailChangeTokenExpiresAt time.Time, totpSecret string, twoFactorEnabled bool, totpLastUsedStep int64, recoveryCodeHashes []string, deletionScheduledAt *time.Time, createdAt, updatedAt time.Time) *User {
 	var token *value.VerificationToken
 	if verificationToken != "" {
 		token = value.ReconstructVerificationToken(verificationToken, verificationTokenExpiresAt)
 	}
 
+	var resetToken *value.PasswordResetToken
+	if passwordResetTokenHash != "" {
+		resetToken = value.ReconstructPasswordResetToken(passwordResetTokenHash, passwordResetTokenExpiresAt)
+	}
+
+	var pending *value.Email
+	if pendingEmail != "" {
+		pendingEmailVO := value.ReconstructEmail(pendingEmail)
+		pending = &pendingEmailVO
+	}
+
+	var changeToken *value.EmailChangeToken
+	if emailChangeTokenHash != "" {
+		changeToken = value.ReconstructEmailChangeToken(emailChangeTokenHash, emailChangeTokenExpiresAt)
+	}
+
+	var secret *value.TOTPSecret
+	if totpSecret != "" {
+		secret = value.ReconstructTOTPSecret(totpSecret)
+	}
+
 	return &User{
-		ID:                id,
-		Email:             value.ReconstructEmail(email),
-		PasswordHash:      value.ReconstructHashedPassword(passwordHash),
-		EmailVerified:     emailVerified,
-		VerificationToken: token,
-		CreatedAt:         createdAt,
-		UpdatedAt:         updatedAt,
+		ID:                  id,
+		Email:               value.ReconstructEmail(email),
+		PasswordHash:        value.ReconstructHashedPassword(passwordHash),
+		EmailVerified:       emailVerified,
+		VerificationToken:   token,
+		PasswordResetToken:  resetToken,
+		PendingEmail:        pending,
+		EmailChangeToken:    changeToken,
+		TOTPSecret:          secret,
+		TwoFactorEnabled:    twoFactorEnabled,
+		TOTPLastUsedStep:    totpLastUsedStep,
+		RecoveryCodeHashes:  recoveryCodeHashes,
+		DeletionScheduledAt: deletionScheduledAt,
+		CreatedAt:           createdAt,
+		UpdatedAt:           updatedAt,
 	}
 }
 
@@ -79,6 +154,25 @@ func (u *User) VerifyEmail() {
 	u.UpdatedAt = time.Now()
 }
 
+// ClaimByVerifiedIdentity は外部IDプロバイダでメールアドレスの所有が確認されたことを受けて、メール検証を完了する。
+// 未検証の間に設定されたパスワードは第三者が事前に登録したものである可能性があるため、
+// メールが未検証だった場合はパスワードとリセットトークンを無効化する。
+func (u *User) ClaimByVerifiedIdentity() {
+	if u.EmailVerified {
+		return
+	}
+
+	u.VerifyEmail()
+	u.PasswordHash = value.ReconstructHashedPassword("")
+	u.PasswordResetToken = nil
+}
+
+// HasPassword はパスワードが設定されているかどうかを返す。
+// 外部IDプロバイダ経由で作成されたユーザーはパスワードを持たない。
+func (u *User) HasPassword() bool {
+	return u.PasswordHash.String() != ""
+}
+
 // RegenerateVerificationToken は新しい検証トークンを生成する
 func (u *User) RegenerateVerificationToken() error {
 	token, err := value.NewVerificationToken()
@@ -101,6 +195,49 @@ func (u *User) UpdateEmail(email string) error {
 	return nil
 }
 
+// RequestEmailChange はメールアドレスの変更を受け付け、変更後のメールアドレスの確認トークンを発行する。
+// 確認されるまでEmailは変更せず、変更後のメールアドレスはPendingEmailとして保持する。
+// 既存の変更要求は上書きされ無効になる。戻り値は変更後のメールアドレスに送信する平文トークン。
+func (u *User) RequestEmailChange(email string) (string, error) {
+	emailVO, err := value.NewEmail(email)
+	if err != nil {
+		return "", err
+	}
+	if emailVO.Equals(u.Email) {
+		return "", ErrSameEmail
+	}
+
+	token, raw, err := value.NewEmailChangeToken()
+	if err != nil {
+		return "", err
+	}
+
+	u.PendingEmail = &emailVO
+	u.EmailChangeToken = token
+	u.UpdatedAt = time.Now()
+	return raw, nil
+}
+
+// ConfirmEmailChange は確認トークンが確認されたことを受けて、メールアドレスをPendingEmailに切り替える。
+// 変更後のメールアドレスは所有が確認済みのため、メール検証も完了したものとして扱う。
+// トークンは一度きりの使用とするため、切り替えた時点で破棄される。
+func (u *User) ConfirmEmailChange() error {
+	if u.PendingEmail == nil || u.EmailChangeToken == nil {
+		return ErrEmailChangeNotRequested
+	}
+	if u.EmailChangeToken.IsExpired() {
+		return ErrEmailChangeTokenExpired
+	}
+
+	if err := u.UpdateEmail(u.PendingEmail.String()); err != nil {
+		return err
+	}
+	u.PendingEmail = nil
+	u.EmailChangeToken = nil
+	u.VerifyEmail()
+	return nil
+}
+
 // UpdatePassword はユーザーのパスワードを更新する
 func (u *User) UpdatePassword(password string) error {
 	passwordVO, err := value.NewPassword(password)
@@ -118,6 +255,28 @@ func (u *User) UpdatePassword(password string) error {
 	return nil
 }
 
+// IssuePasswordResetToken は新しいパスワードリセットトークンを発行する。
+// 既存のトークンは上書きされ無効になる。戻り値はメール送信用の平文トークン。
+func (u *User) IssuePasswordResetToken() (string, error) {
+	token, raw, err := value.NewPasswordResetToken()
+	if err != nil {
+		return "", err
+	}
+	u.PasswordResetToken = token
+	u.UpdatedAt = time.Now()
+	return raw, nil
+}
+
+// ResetPassword はパスワードリセットトークンを消費してパスワードを更新する。
+// トークンは一度きりの使用とするため、更新に成功した時点で破棄される。
+func (u *User) ResetPassword(password string) error {
+	if err := u.UpdatePassword(password); err != nil {
+		return err
+	}
+	u.PasswordResetToken = nil
+	return nil
+}
+
 // VerifyPassword は提供されたパスワードがユーザーのパスワードと一致するか検証する
 func (u *User) VerifyPassword(password string) error {
 	passwordVO, err := value.NewPassword(password)
@@ -126,3 +285,127 @@ func (u *User) VerifyPassword(password string) error {
 	}
 	return u.PasswordHash.Verify(passwordVO)
 }
+
+// BeginTwoFactorEnrollment は二要素認証の登録を開始し、新しい秘密鍵を生成する。
+// 確認されるまで二要素認証は有効にならず、再度呼び出すと秘密鍵は作り直される。
+func (u *User) BeginTwoFactorEnrollment() (*value.TOTPSecret, error) {
+	if u.TwoFactorEnabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := value.NewTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	u.TOTPSecret = secret
+	u.TOTPLastUsedStep = 0
+	u.UpdatedAt = time.Now()
+	return secret, nil
+}
+
+// ConfirmTwoFactor は認証アプリが生成したコードで登録を確認し、二要素認証を有効にする。
+// 有効化と同時にリカバリーコードを発行する。戻り値はユーザーに一度だけ表示する平文のリカバリーコード。
+func (u *User) ConfirmTwoFactor(code string, at time.Time) ([]string, error) {
+	if u.TwoFactorEnabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+	if u.TOTPSecret == nil {
+		return nil, ErrTwoFactorNotEnrolled
+	}
+
+	step, ok := u.TOTPSecret.MatchStep(code, at)
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	codes, hashes, err := value.NewRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	u.TwoFactorEnabled = true
+	u.TOTPLastUsedStep = step
+	u.RecoveryCodeHashes = hashes
+	u.UpdatedAt = at
+	return codes, nil
+}
+
+// VerifyTwoFactor はログイン時の二要素認証のコードを検証する。
+// TOTPのコードに加えてリカバリーコードも受け付ける。
+// 同じTOTPのコードの再利用を防ぐため、受け付けたタイムステップ以前のコードは拒否する。
+// リカバリーコードは一度きりの使用で、使用した時点で破棄される。
+func (u *User) VerifyTwoFactor(code string, at time.Time) error {
+	if !u.TwoFactorEnabled || u.TOTPSecret == nil {
+		return ErrTwoFactorNotEnabled
+	}
+
+	if step, ok := u.TOTPSecret.MatchStep(code, at); ok {
+		if step <= u.TOTPLastUsedStep {
+			return ErrInvalidTwoFactorCode
+		}
+		u.TOTPLastUsedStep = step
+		u.UpdatedAt = at
+		return nil
+	}
+
+	hash := value.HashRecoveryCode(code)
+	for i, h := range u.RecoveryCodeHashes {
+		if subtle.ConstantTimeCompare([]byte(h), []byte(hash)) == 1 {
+			u.RecoveryCodeHashes = append(u.RecoveryCodeHashes[:i:i], u.RecoveryCodeHashes[i+1:]...)
+			u.UpdatedAt = at
+			return nil
+		}
+	}
+
+	return ErrInvalidTwoFactorCode
+}
+
+// DisableTwoFactor は現在の二要素認証のコードを確認した上で、二要素認証を無効にする。
+// コードにはTOTPのコードとリカバリーコードのいずれも使用でき、秘密鍵とリカバリーコードは破棄される。
+func (u *User) DisableTwoFactor(code string, at time.Time) error {
+	if err := u.VerifyTwoFactor(code, at); err != nil {
+		return err
+	}
+
+	u.TwoFactorEnabled = false
+	u.TOTPSecret = nil
+	u.TOTPLastUsedStep = 0
+	u.RecoveryCodeHashes = nil
+	u.UpdatedAt = at
+	return nil
+}
+
+// RegenerateRecoveryCodes は現在の二要素認証のコードを確認した上で、リカバリーコードを発行し直す。
+// 未使用のリカバリーコードは全て無効になる。戻り値はユーザーに一度だけ表示する平文のリカバリーコード。
+func (u *User) RegenerateRecoveryCodes(code string, at time.Time) ([]string, error) {
+	if err := u.VerifyTwoFactor(code, at); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := value.NewRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	u.RecoveryCodeHashes = hashes
+	u.UpdatedAt = at
+	return codes, nil
+}
+
+// ScheduleDeletion はアカウントの削除をat以降に予定する
+func (u *User) ScheduleDeletion(at time.Time) {
+	u.DeletionScheduledAt = &at
+	u.UpdatedAt = time.Now()
+}
+
+// CancelDeletion は予定されているアカウントの削除を取り消す
+func (u *User) CancelDeletion() {
+	u.DeletionScheduledAt = nil
+	u.UpdatedAt = time.Now()
+}
+
+// IsDeletionDue はアカウントの削除予定日時をnowの時点で過ぎているかを返す
+func (u *User) IsDeletionDue(now time.Time) bool {
+	return u.DeletionScheduledAt != nil && !now.Before(*u.DeletionScheduledAt)
+}
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidIdentityProvider = errors.New("identity provider must not be empty")
+	ErrInvalidIdentitySubject  = errors.New("identity subject must not be empty")
+)
+
+// UserIdentity は外部IDプロバイダ（OIDC）のアカウントとユーザーの紐付けを表す。
+// プロバイダ内でアカウントを一意に識別するsub（Subject）で照合し、メールアドレスは参考情報として保持する。
+type UserIdentity struct {
+	ID       uuid.UUID
+	UserID   uuid.UUID
+	Provider string
+	Subject  string
+	// Email は紐付け時にプロバイダから取得したメールアドレス
+	Email     string
+	CreatedAt time.Time
+}
+
+// NewUserIdentity はバリデーション付きで新しいUserIdentityエンティティを作成する
+func NewUserIdentity(userID uuid.UUID, provider, subject, email string) (*UserIdentity, error) {
+	if provider == "" {
+		return nil, ErrInvalidIdentityProvider
+	}
+	if subject == "" {
+		return nil, ErrInvalidIdentitySubject
+	}
+
+	return &UserIdentity{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Provider:  provider,
+		Subject:   subject,
+		Email:     email,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// ReconstructUserIdentity は保存されたデータからUserIdentityエンティティを再構築する
+func ReconstructUserIdentity(id, userID uuid.UUID, provider, subject, email string, createdAt time.Time) *UserIdentity {
+	return &UserIdentity{
+		ID:        id,
+		UserID:    userID,
+		Provider:  provider,
+		Subject:   subject,
+		Email:     email,
+		CreatedAt: createdAt,
+	}
+}
@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNewUserIdentity(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name     string
+		provider string
+		subject  string
+		wantErr  error
+	}{
+		{
+			name:     "正常系: プロバイダとsubjectを指定",
+			provider: "google",
+			subject:  "1234567890",
+		},
+		{
+			name:     "異常系: プロバイダが空",
+			provider: "",
+			subject:  "1234567890",
+			wantErr:  ErrInvalidIdentityProvider,
+		},
+		{
+			name:     "異常系: subjectが空",
+			provider: "google",
+			subject:  "",
+			wantErr:  ErrInvalidIdentitySubject,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := NewUserIdentity(userID, tt.provider, tt.subject, "test@example.com")
+
+			if tt.wantErr != nil {
+				if err != tt.wantErr {
+					t.Errorf("NewUserIdentity() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NewUserIdentity() unexpected error = %v", err)
+			}
+			if identity.ID == uuid.Nil {
+				t.Error("NewUserIdentity() should generate an ID")
+			}
+			if identity.UserID != userID || identity.Provider != tt.provider || identity.Subject != tt.subject {
+				t.Errorf("NewUserIdentity() = %+v, want user %v, provider %v, subject %v", identity, userID, tt.provider, tt.subject)
+			}
+		})
+	}
+}
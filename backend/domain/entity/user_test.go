@@ -304,6 +304,567 @@ func TestUser_UpdatePassword(t *testing.T) {
 	}
 }
 
+func TestUser_IssuePasswordResetToken(t *testing.T) {
+	user, err := NewUser("test@example.com", "password123")
+	if err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+
+	first, err := user.IssuePasswordResetToken()
+	if err != nil {
+		t.Fatalf("IssuePasswordResetToken() unexpected error = %v", err)
+	}
+	if user.PasswordResetToken == nil {
+		t.Fatal("PasswordResetToken should be set after IssuePasswordResetToken")
+	}
+	if user.PasswordResetToken.Hash() != value.HashPasswordResetToken(first) {
+		t.Error("PasswordResetToken should hold the hash of the returned token")
+	}
+
+	second, err := user.IssuePasswordResetToken()
+	if err != nil {
+		t.Fatalf("IssuePasswordResetToken() unexpected error = %v", err)
+	}
+	if user.PasswordResetToken.Hash() == value.HashPasswordResetToken(first) {
+		t.Errorf("reissuing should replace the previous token (first=%v, second=%v)", first, second)
+	}
+}
+
+func TestUser_ResetPassword(t *testing.T) {
+	tests := []struct {
+		name        string
+		newPassword string
+		wantErr     error
+	}{
+		{
+			name:        "正常系: パスワードを更新しトークンを破棄",
+			newPassword: "newpassword123",
+		},
+		{
+			name:        "異常系: パスワードが短すぎる場合はトークンを残す",
+			newPassword: "short",
+			wantErr:     value.ErrPasswordTooShort,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := NewUser("test@example.com", "password123")
+			if err != nil {
+				t.Fatalf("Failed to create test user: %v", err)
+			}
+			if _, err := user.IssuePasswordResetToken(); err != nil {
+				t.Fatalf("IssuePasswordResetToken() unexpected error = %v", err)
+			}
+
+			err = user.ResetPassword(tt.newPassword)
+
+			if tt.wantErr != nil {
+				if err != tt.wantErr {
+					t.Errorf("ResetPassword() error = %v, want %v", err, tt.wantErr)
+				}
+				if user.PasswordResetToken == nil {
+					t.Error("PasswordResetToken should remain when ResetPassword fails")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ResetPassword() unexpected error = %v", err)
+			}
+			if user.PasswordResetToken != nil {
+				t.Error("PasswordResetToken should be cleared after ResetPassword")
+			}
+			if err := user.VerifyPassword(tt.newPassword); err != nil {
+				t.Errorf("VerifyPassword() failed with new password: %v", err)
+			}
+		})
+	}
+}
+
+func TestUser_RequestEmailChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		newEmail string
+		wantErr  error
+	}{
+		{
+			name:     "正常系: 変更後のメールアドレスを保留し確認トークンを発行",
+			newEmail: "New@Example.com",
+		},
+		{
+			name:     "異常系: 現在と同じメールアドレス",
+			newEmail: "TEST@example.com",
+			wantErr:  ErrSameEmail,
+		},
+		{
+			name:     "異常系: 不正なメールアドレス",
+			newEmail: "invalid-email",
+			wantErr:  value.ErrInvalidEmail,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := NewUser("test@example.com", "password123")
+			if err != nil {
+				t.Fatalf("Failed to create test user: %v", err)
+			}
+
+			raw, err := user.RequestEmailChange(tt.newEmail)
+
+			if tt.wantErr != nil {
+				if err != tt.wantErr {
+					t.Errorf("RequestEmailChange() error = %v, want %v", err, tt.wantErr)
+				}
+				if user.PendingEmail != nil || user.EmailChangeToken != nil {
+					t.Error("PendingEmail and EmailChangeToken should not be set on failure")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("RequestEmailChange() unexpected error = %v", err)
+			}
+			if user.Email.String() != "test@example.com" {
+				t.Errorf("Email = %v, should not change until confirmed", user.Email.String())
+			}
+			if user.PendingEmail == nil || user.PendingEmail.String() != "new@example.com" {
+				t.Errorf("PendingEmail = %v, want new@example.com", user.PendingEmail)
+			}
+			if user.EmailChangeToken == nil || user.EmailChangeToken.Hash() != value.HashEmailChangeToken(raw) {
+				t.Error("EmailChangeToken should hold the hash of the returned token")
+			}
+		})
+	}
+}
+
+func TestUser_ConfirmEmailChange(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(user *User)
+		wantErr error
+	}{
+		{
+			name: "正常系: メールアドレスを切り替え検証済みにする",
+			setup: func(user *User) {
+				user.RequestEmailChange("new@example.com")
+			},
+		},
+		{
+			name:    "異常系: 変更要求がない",
+			setup:   func(user *User) {},
+			wantErr: ErrEmailChangeNotRequested,
+		},
+		{
+			name: "異常系: トークンの有効期限切れ",
+			setup: func(user *User) {
+				user.RequestEmailChange("new@example.com")
+				user.EmailChangeToken = value.ReconstructEmailChangeToken(user.EmailChangeToken.Hash(), time.Now().Add(-time.Minute))
+			},
+			wantErr: ErrEmailChangeTokenExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := NewUser("test@example.com", "password123")
+			if err != nil {
+				t.Fatalf("Failed to create test user: %v", err)
+			}
+			tt.setup(user)
+
+			err = user.ConfirmEmailChange()
+
+			if tt.wantErr != nil {
+				if err != tt.wantErr {
+					t.Errorf("ConfirmEmailChange() error = %v, want %v", err, tt.wantErr)
+				}
+				if user.Email.String() != "test@example.com" {
+					t.Errorf("Email = %v, should not change on failure", user.Email.String())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ConfirmEmailChange() unexpected error = %v", err)
+			}
+			if user.Email.String() != "new@example.com" {
+				t.Errorf("Email = %v, want new@example.com", user.Email.String())
+			}
+			if !user.EmailVerified {
+				t.Error("EmailVerified should be true after ConfirmEmailChange")
+			}
+			if user.PendingEmail != nil || user.EmailChangeToken != nil {
+				t.Error("PendingEmail and EmailChangeToken should be cleared after ConfirmEmailChange")
+			}
+		})
+	}
+}
+
+func TestNewOIDCUser(t *testing.T) {
+	user, err := NewOIDCUser("Test@Example.com")
+	if err != nil {
+		t.Fatalf("NewOIDCUser() unexpected error = %v", err)
+	}
+
+	if !user.EmailVerified {
+		t.Error("NewOIDCUser() should create a verified user")
+	}
+	if user.VerificationToken != nil {
+		t.Error("NewOIDCUser() should not issue a verification token")
+	}
+	if user.HasPassword() {
+		t.Error("NewOIDCUser() should not set a password")
+	}
+	if err := user.VerifyPassword("password123"); err == nil {
+		t.Error("VerifyPassword() should fail for a user without password")
+	}
+
+	if _, err := NewOIDCUser("invalid"); err != value.ErrInvalidEmail {
+		t.Errorf("NewOIDCUser() error = %v, want %v", err, value.ErrInvalidEmail)
+	}
+}
+
+func TestUser_ClaimByVerifiedIdentity(t *testing.T) {
+	tests := []struct {
+		name          string
+		emailVerified bool
+		wantPassword  bool
+	}{
+		{
+			name:          "正常系: 検証済みのユーザーはパスワードを維持",
+			emailVerified: true,
+			wantPassword:  true,
+		},
+		{
+			name:          "正常系: 未検証のユーザーは検証済みにしてパスワードを無効化",
+			emailVerified: false,
+			wantPassword:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := NewUser("test@example.com", "password123")
+			if err != nil {
+				t.Fatalf("Failed to create test user: %v", err)
+			}
+			user.EmailVerified = tt.emailVerified
+
+			user.ClaimByVerifiedIdentity()
+
+			if !user.EmailVerified {
+				t.Error("EmailVerified should be true after ClaimByVerifiedIdentity")
+			}
+			if user.HasPassword() != tt.wantPassword {
+				t.Errorf("HasPassword() = %v, want %v", user.HasPassword(), tt.wantPassword)
+			}
+		})
+	}
+}
+
+// newTwoFactorUser は二要素認証を有効にしたテスト用ユーザーと、有効化時に発行されたリカバリーコードを返す
+func newTwoFactorUser(t *testing.T, at time.Time) (*User, []string) {
+	t.Helper()
+
+	user, err := NewUser("test@example.com", "password123")
+	if err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	secret, err := user.BeginTwoFactorEnrollment()
+	if err != nil {
+		t.Fatalf("BeginTwoFactorEnrollment() unexpected error = %v", err)
+	}
+	code, _ := secret.Code(at)
+	recoveryCodes, err := user.ConfirmTwoFactor(code, at)
+	if err != nil {
+		t.Fatalf("ConfirmTwoFactor() unexpected error = %v", err)
+	}
+	return user, recoveryCodes
+}
+
+func TestUser_ConfirmTwoFactor(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		setup   func(user *User) string
+		wantErr error
+	}{
+		{
+			name: "正常系: 認証アプリのコードで有効化",
+			setup: func(user *User) string {
+				secret, _ := user.BeginTwoFactorEnrollment()
+				code, _ := secret.Code(now)
+				return code
+			},
+		},
+		{
+			name: "異常系: コードが一致しない",
+			setup: func(user *User) string {
+				user.BeginTwoFactorEnrollment()
+				return "000000"
+			},
+			wantErr: ErrInvalidTwoFactorCode,
+		},
+		{
+			name: "異常系: 登録を開始していない",
+			setup: func(user *User) string {
+				return "123456"
+			},
+			wantErr: ErrTwoFactorNotEnrolled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := NewUser("test@example.com", "password123")
+			if err != nil {
+				t.Fatalf("Failed to create test user: %v", err)
+			}
+			code := tt.setup(user)
+
+			recoveryCodes, err := user.ConfirmTwoFactor(code, now)
+
+			if tt.wantErr != nil {
+				if err != tt.wantErr {
+					t.Errorf("ConfirmTwoFactor() error = %v, want %v", err, tt.wantErr)
+				}
+				if user.TwoFactorEnabled {
+					t.Error("TwoFactorEnabled should remain false when ConfirmTwoFactor fails")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ConfirmTwoFactor() unexpected error = %v", err)
+			}
+			if !user.TwoFactorEnabled {
+				t.Error("TwoFactorEnabled should be true after ConfirmTwoFactor")
+			}
+			if len(recoveryCodes) != value.RecoveryCodeCount || len(user.RecoveryCodeHashes) != value.RecoveryCodeCount {
+				t.Errorf("ConfirmTwoFactor() issued %d codes and stored %d hashes, want %d", len(recoveryCodes), len(user.RecoveryCodeHashes), value.RecoveryCodeCount)
+			}
+			if _, err := user.BeginTwoFactorEnrollment(); err != ErrTwoFactorAlreadyEnabled {
+				t.Errorf("BeginTwoFactorEnrollment() error = %v, want %v", err, ErrTwoFactorAlreadyEnabled)
+			}
+		})
+	}
+}
+
+func TestUser_VerifyTwoFactor(t *testing.T) {
+	enrolledAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := enrolledAt.Add(5 * time.Minute)
+
+	tests := []struct {
+		name          string
+		code          func(user *User, recoveryCodes []string) string
+		wantErr       error
+		wantRemaining int
+	}{
+		{
+			name: "正常系: TOTPのコード",
+			code: func(user *User, _ []string) string {
+				code, _ := user.TOTPSecret.Code(later)
+				return code
+			},
+			wantRemaining: value.RecoveryCodeCount,
+		},
+		{
+			name: "正常系: リカバリーコードは消費される",
+			code: func(_ *User, recoveryCodes []string) string {
+				return recoveryCodes[3]
+			},
+			wantRemaining: value.RecoveryCodeCount - 1,
+		},
+		{
+			name: "異常系: 有効化時に使用したコードの再利用",
+			code: func(user *User, _ []string) string {
+				code, _ := user.TOTPSecret.Code(later)
+				user.TOTPLastUsedStep = value.TOTPTimeStep(later)
+				return code
+			},
+			wantErr:       ErrInvalidTwoFactorCode,
+			wantRemaining: value.RecoveryCodeCount,
+		},
+		{
+			name: "異常系: コードが一致しない",
+			code: func(_ *User, _ []string) string {
+				return "000000"
+			},
+			wantErr:       ErrInvalidTwoFactorCode,
+			wantRemaining: value.RecoveryCodeCount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, recoveryCodes := newTwoFactorUser(t, enrolledAt)
+			code := tt.code(user, recoveryCodes)
+
+			err := user.VerifyTwoFactor(code, later)
+
+			if err != tt.wantErr {
+				t.Errorf("VerifyTwoFactor() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(user.RecoveryCodeHashes) != tt.wantRemaining {
+				t.Errorf("len(RecoveryCodeHashes) = %d, want %d", len(user.RecoveryCodeHashes), tt.wantRemaining)
+			}
+
+			// 同じコードは二度使えない
+			if err == nil {
+				if err := user.VerifyTwoFactor(code, later); err != ErrInvalidTwoFactorCode {
+					t.Errorf("VerifyTwoFactor() with reused code error = %v, want %v", err, ErrInvalidTwoFactorCode)
+				}
+			}
+		})
+	}
+}
+
+func TestUser_VerifyTwoFactor_NotEnabled(t *testing.T) {
+	user, err := NewUser("test@example.com", "password123")
+	if err != nil {
+		t.Fatalf("Failed to create test user: %v", err)
+	}
+	user.BeginTwoFactorEnrollment()
+
+	if err := user.VerifyTwoFactor("123456", time.Now()); err != ErrTwoFactorNotEnabled {
+		t.Errorf("VerifyTwoFactor() error = %v, want %v", err, ErrTwoFactorNotEnabled)
+	}
+}
+
+func TestUser_DisableTwoFactor(t *testing.T) {
+	enrolledAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := enrolledAt.Add(5 * time.Minute)
+
+	t.Run("正常系: 現在のコードで無効化すると秘密鍵とリカバリーコードが破棄される", func(t *testing.T) {
+		user, _ := newTwoFactorUser(t, enrolledAt)
+		code, _ := user.TOTPSecret.Code(later)
+
+		if err := user.DisableTwoFactor(code, later); err != nil {
+			t.Fatalf("DisableTwoFactor() unexpected error = %v", err)
+		}
+		if user.TwoFactorEnabled || user.TOTPSecret != nil || user.RecoveryCodeHashes != nil {
+			t.Errorf("DisableTwoFactor() left enabled=%v secret=%v recovery=%v", user.TwoFactorEnabled, user.TOTPSecret, user.RecoveryCodeHashes)
+		}
+		if _, err := user.BeginTwoFactorEnrollment(); err != nil {
+			t.Errorf("BeginTwoFactorEnrollment() after disable error = %v", err)
+		}
+	})
+
+	t.Run("異常系: コードが一致しない場合は有効なまま", func(t *testing.T) {
+		user, _ := newTwoFactorUser(t, enrolledAt)
+
+		if err := user.DisableTwoFactor("000000", later); err != ErrInvalidTwoFactorCode {
+			t.Errorf("DisableTwoFactor() error = %v, want %v", err, ErrInvalidTwoFactorCode)
+		}
+		if !user.TwoFactorEnabled || user.TOTPSecret == nil {
+			t.Error("TwoFactorEnabled should remain true when DisableTwoFactor fails")
+		}
+	})
+
+	t.Run("異常系: 二要素認証が有効でない", func(t *testing.T) {
+		user, err := NewUser("test@example.com", "password123")
+		if err != nil {
+			t.Fatalf("Failed to create test user: %v", err)
+		}
+
+		if err := user.DisableTwoFactor("123456", later); err != ErrTwoFactorNotEnabled {
+			t.Errorf("DisableTwoFactor() error = %v, want %v", err, ErrTwoFactorNotEnabled)
+		}
+	})
+}
+
+func TestUser_RegenerateRecoveryCodes(t *testing.T) {
+	enrolledAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := enrolledAt.Add(5 * time.Minute)
+
+	t.Run("正常系: 発行し直すと以前のリカバリーコードは使えない", func(t *testing.T) {
+		user, oldCodes := newTwoFactorUser(t, enrolledAt)
+
+		newCodes, err := user.RegenerateRecoveryCodes(oldCodes[0], later)
+		if err != nil {
+			t.Fatalf("RegenerateRecoveryCodes() unexpected error = %v", err)
+		}
+		if len(newCodes) != value.RecoveryCodeCount || len(user.RecoveryCodeHashes) != value.RecoveryCodeCount {
+			t.Errorf("RegenerateRecoveryCodes() issued %d codes and stored %d hashes, want %d", len(newCodes), len(user.RecoveryCodeHashes), value.RecoveryCodeCount)
+		}
+		if err := user.VerifyTwoFactor(oldCodes[1], later); err != ErrInvalidTwoFactorCode {
+			t.Errorf("VerifyTwoFactor() with old recovery code error = %v, want %v", err, ErrInvalidTwoFactorCode)
+		}
+		if err := user.VerifyTwoFactor(newCodes[0], later); err != nil {
+			t.Errorf("VerifyTwoFactor() with new recovery code error = %v", err)
+		}
+	})
+
+	t.Run("異常系: コードが一致しない場合は発行し直さない", func(t *testing.T) {
+		user, oldCodes := newTwoFactorUser(t, enrolledAt)
+
+		if _, err := user.RegenerateRecoveryCodes("000000", later); err != ErrInvalidTwoFactorCode {
+			t.Errorf("RegenerateRecoveryCodes() error = %v, want %v", err, ErrInvalidTwoFactorCode)
+		}
+		if err := user.VerifyTwoFactor(oldCodes[0], later); err != nil {
+			t.Errorf("VerifyTwoFactor() with existing recovery code error = %v", err)
+		}
+	})
+}
+
+func TestUser_ScheduleDeletion(t *testing.T) {
+	scheduledAt := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		cancel  bool
+		now     time.Time
+		wantDue bool
+	}{
+		{
+			name:    "正常系: 削除予定日時より前は削除対象外",
+			now:     scheduledAt.Add(-time.Second),
+			wantDue: false,
+		},
+		{
+			name:    "正常系: 削除予定日時を過ぎると削除対象",
+			now:     scheduledAt,
+			wantDue: true,
+		},
+		{
+			name:    "正常系: 取り消すと削除対象外",
+			cancel:  true,
+			now:     scheduledAt.Add(time.Hour),
+			wantDue: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := NewUser("test@example.com", "password123")
+			if err != nil {
+				t.Fatalf("Failed to create test user: %v", err)
+			}
+			if user.IsDeletionDue(tt.now) {
+				t.Fatal("IsDeletionDue() = true before ScheduleDeletion()")
+			}
+
+			user.ScheduleDeletion(scheduledAt)
+			if user.DeletionScheduledAt == nil || !user.DeletionScheduledAt.Equal(scheduledAt) {
+				t.Fatalf("DeletionScheduledAt = %v, want %v", user.DeletionScheduledAt, scheduledAt)
+			}
+			if tt.cancel {
+				user.CancelDeletion()
+				if user.DeletionScheduledAt != nil {
+					t.Errorf("DeletionScheduledAt = %v, want nil", user.DeletionScheduledAt)
+				}
+			}
+
+			if got := user.IsDeletionDue(tt.now); got != tt.wantDue {
+				t.Errorf("IsDeletionDue() = %v, want %v", got, tt.wantDue)
+			}
+		})
+	}
+}
+
 func TestReconstructUser(t *testing.T) {
 	id := uuid.New()
 	email := "test@example.com"
@@ -311,7 +872,7 @@ func TestReconstructUser(t *testing.T) {
 	createdAt := time.Now().Add(-24 * time.Hour)
 	updatedAt := time.Now()
 
-	user := ReconstructUser(id, email, passwordHash, false, "", time.Time{}, createdAt, updatedAt)
+	user := ReconstructUser(id, email, passwordHash, false, "", time.Time{}, "", time.Time{}, "", "", time.Time{}, "", false, 0, nil, nil, createdAt, updatedAt)
 
 	if user == nil {
 		t.Fatal("ReconstructUser() returned nil")
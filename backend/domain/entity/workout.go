@@ -2,21 +2,27 @@ package entity
 
 import (
 	"errors"
-	"math"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 var (
-	ErrInvalidDailyScore = errors.New("daily score must be between 0 and 100")
+	ErrInvalidDailyScore     = errors.New("daily score must be between 0 and 100")
+	ErrInvalidWorkoutEndTime = errors.New("workout end time must not be before start time")
 )
 
-// Workout は特定の日のトレーニングセッションを表す
+// MaxDailyScore は日次スコアの上限
+const MaxDailyScore int32 = 100
+
+// Workout はトレーニングセッションを表す
+// 同じ日に複数のセッションを記録でき、Dateはセッションが属する日を表す
 type Workout struct {
 	ID         uuid.UUID
 	UserID     uuid.UUID
 	Date       time.Time
+	StartedAt  time.Time
+	EndedAt    *time.Time
 	DailyScore int32
 	Memo       *string
 	CreatedAt  time.Time
@@ -24,12 +30,14 @@ type Workout struct {
 }
 
 // NewWorkout はバリデーション付きで新しいWorkoutエンティティを作成する
+// Dateはdateのタイムゾーンでの暦日となり、開始時刻はdateで初期化される
 func NewWorkout(userID uuid.UUID, date time.Time) *Workout {
 	now := time.Now()
 	return &Workout{
 		ID:         uuid.New(),
 		UserID:     userID,
-		Date:       date,
+		Date:       CalendarDate(date),
+		StartedAt:  date,
 		DailyScore: 0, // 初期スコアは0
 		CreatedAt:  now,
 		UpdatedAt:  now,
@@ -37,11 +45,13 @@ func NewWorkout(userID uuid.UUID, date time.Time) *Workout {
 }
 
 // ReconstructWorkout は保存されたデータからWorkoutエンティティを再構築する
-func ReconstructWorkout(id, userID uuid.UUID, date time.Time, dailyScore int32, memo *string, createdAt, updatedAt time.Time) *Workout {
+func ReconstructWorkout(id, userID uuid.UUID, date, startedAt time.Time, endedAt *time.Time, dailyScore int32, memo *string, createdAt, updatedAt time.Time) *Workout {
 	return &Workout{
 		ID:         id,
 		UserID:     userID,
 		Date:       date,
+		StartedAt:  startedAt,
+		EndedAt:    endedAt,
 		DailyScore: dailyScore,
 		Memo:       memo,
 		CreatedAt:  createdAt,
@@ -49,35 +59,36 @@ func ReconstructWorkout(id, userID uuid.UUID, date time.Time, dailyScore int32,
 	}
 }
 
-// UpdateDailyScore はワークアウトの日次スコアを更新する
-func (w *Workout) UpdateDailyScore(score int32) error {
-	if err := ValidateDailyScore(score); err != nil {
-		return err
+// UpdateSessionTime はセッションの開始時刻と終了時刻を更新する
+// 終了時刻がnilの場合は終了時刻なしとして扱う
+func (w *Workout) UpdateSessionTime(startedAt time.Time, endedAt *time.Time) error {
+	if endedAt != nil && endedAt.Before(startedAt) {
+		return ErrInvalidWorkoutEndTime
 	}
-	w.DailyScore = score
+	w.StartedAt = startedAt
+	w.EndedAt = endedAt
 	w.UpdatedAt = time.Now()
 	return nil
 }
 
-// CalculateDailyScore はトレーニングボリュームに基づいて日次スコアを計算する
-// スコア計算: score = 100 × √(totalVolume / maxVolume)
-// - totalVolume: 総負荷量（重量 × レップ数の全セット合計）
-// - maxVolume: 基準最大ボリューム（20,000kg）
-// - 範囲: 0〜100
-func (w *Workout) CalculateDailyScore(totalVolume float64) int32 {
-	const maxVolume = 20000.0
-
-	if totalVolume <= 0 {
-		return 0
+// Duration はセッションの所要時間を返す
+// 終了時刻が記録されていない場合はnilを返す
+func (w *Workout) Duration() *time.Duration {
+	if w.EndedAt == nil {
+		return nil
 	}
+	d := w.EndedAt.Sub(w.StartedAt)
+	return &d
+}
 
-	score := 100.0 * math.Sqrt(totalVolume/maxVolume)
-
-	if score > 100 {
-		score = 100
+// UpdateDailyScore はワークアウトの日次スコアを更新する
+func (w *Workout) UpdateDailyScore(score int32) error {
+	if err := ValidateDailyScore(score); err != nil {
+		return err
 	}
-
-	return int32(math.Round(score))
+	w.DailyScore = score
+	w.UpdatedAt = time.Now()
+	return nil
 }
 
 // UpdateMemo はワークアウトのメモを更新する
@@ -88,8 +99,27 @@ func (w *Workout) UpdateMemo(memo *string) {
 
 // ValidateDailyScore は日次スコアを検証する
 func ValidateDailyScore(score int32) error {
-	if score < 0 || score > 100 {
+	if score < 0 || score > MaxDailyScore {
 		return ErrInvalidDailyScore
 	}
 	return nil
 }
+
+// SumDailyScores は同じ日の複数セッションの日次スコアを合算する
+// 合算値はMaxDailyScoreを上限とする
+func SumDailyScores(workouts []*Workout) int32 {
+	var total int32
+	for _, w := range workouts {
+		total += w.DailyScore
+	}
+	if total > MaxDailyScore {
+		return MaxDailyScore
+	}
+	return total
+}
+
+// CalendarDate はtのタイムゾーンでの暦日（年月日）をUTCの0時として返す
+// 例: 2026-02-07T00:30:00+09:00 は 2026-02-07T00:00:00Z になる
+func CalendarDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
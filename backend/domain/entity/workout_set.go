@@ -2,6 +2,7 @@ package entity
 
 import (
 	"errors"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,6 +15,10 @@ var (
 	ErrInvalidDuration       = errors.New("duration must be greater than or equal to 0")
 	ErrInvalidOneRMFormula   = errors.New("invalid 1RM formula")
 	ErrBrzyckiRepsOutOfRange = errors.New("Brzycki formula requires reps < 37")
+	ErrInvalidSetType        = errors.New("invalid set type: must be warmup, working, drop or failure")
+	ErrInvalidRPE            = errors.New("rpe must be between 6 and 10 in steps of 0.5")
+	ErrInvalidRIR            = errors.New("rir must be between 0 and 10")
+	ErrRPEAndRIRBothSet      = errors.New("rpe and rir cannot both be set")
 )
 
 // OneRMFormula は推定1RM計算に使用する公式を表す値オブジェクト
@@ -24,21 +29,84 @@ const (
 	OneRMFormulaEpley OneRMFormula = "epley"
 	// OneRMFormulaBrzycki はBrzycki式: 1RM = weight × (36 / (37 - reps))
 	OneRMFormulaBrzycki OneRMFormula = "brzycki"
+	// OneRMFormulaLombardi はLombardi式: 1RM = weight × reps^0.10
+	OneRMFormulaLombardi OneRMFormula = "lombardi"
+	// OneRMFormulaMayhew はMayhew式: 1RM = 100 × weight / (52.2 + 41.9 × e^(-0.055 × reps))
+	OneRMFormulaMayhew OneRMFormula = "mayhew"
+	// OneRMFormulaOConner はO'Conner式: 1RM = weight × (1 + reps / 40)
+	OneRMFormulaOConner OneRMFormula = "oconner"
+	// OneRMFormulaWathan はWathan式: 1RM = 100 × weight / (48.8 + 53.8 × e^(-0.075 × reps))
+	OneRMFormulaWathan OneRMFormula = "wathan"
 )
 
+// DefaultOneRMFormula はユーザーが公式を指定していない場合に使用する1RM計算式
+const DefaultOneRMFormula = OneRMFormulaEpley
+
 // ValidOneRMFormulas は有効な1RM計算式の一覧
-var ValidOneRMFormulas = []OneRMFormula{OneRMFormulaEpley, OneRMFormulaBrzycki}
+var ValidOneRMFormulas = []OneRMFormula{
+	OneRMFormulaEpley,
+	OneRMFormulaBrzycki,
+	OneRMFormulaLombardi,
+	OneRMFormulaMayhew,
+	OneRMFormulaOConner,
+	OneRMFormulaWathan,
+}
 
 // IsValid は公式名が有効かどうかを検証する
 func (f OneRMFormula) IsValid() bool {
 	switch f {
-	case OneRMFormulaEpley, OneRMFormulaBrzycki:
+	case OneRMFormulaEpley, OneRMFormulaBrzycki, OneRMFormulaLombardi,
+		OneRMFormulaMayhew, OneRMFormulaOConner, OneRMFormulaWathan:
+		return true
+	default:
+		return false
+	}
+}
+
+// SetType はセットの種類を表す値オブジェクト
+type SetType string
+
+const (
+	// SetTypeWarmUp はウォームアップセット。デイリースコアのボリュームや自己ベスト・推定1RMの推移には含めない
+	SetTypeWarmUp SetType = "warmup"
+	// SetTypeWorking は通常のセット
+	SetTypeWorking SetType = "working"
+	// SetTypeDrop はドロップセット
+	SetTypeDrop SetType = "drop"
+	// SetTypeFailure は限界まで行ったセット
+	SetTypeFailure SetType = "failure"
+)
+
+// DefaultSetType はセットの種類が指定されていない場合に使用する種類
+const DefaultSetType = SetTypeWorking
+
+// ValidSetTypes は有効なセットの種類の一覧
+var ValidSetTypes = []SetType{
+	SetTypeWarmUp,
+	SetTypeWorking,
+	SetTypeDrop,
+	SetTypeFailure,
+}
+
+// IsValid はセットの種類が有効かどうかを検証する
+func (t SetType) IsValid() bool {
+	switch t {
+	case SetTypeWarmUp, SetTypeWorking, SetTypeDrop, SetTypeFailure:
 		return true
 	default:
 		return false
 	}
 }
 
+const (
+	// MinRPE はRPE（主観的運動強度）の最小値
+	MinRPE = 6.0
+	// MaxRPE はRPE（主観的運動強度）の最大値
+	MaxRPE = 10.0
+	// MaxRIR はRIR（余力として残したレップ数）の最大値
+	MaxRIR int32 = 10
+)
+
 // WorkoutSet はワークアウト内の1セットを表す
 type WorkoutSet struct {
 	ID              uuid.UUID
@@ -50,11 +118,24 @@ type WorkoutSet struct {
 	Estimated1RM    float64
 	DurationSeconds *int32
 	Notes           *string
-	CreatedAt       time.Time
+	// SetType はセットの種類
+	SetType SetType
+	// RPE は主観的運動強度（6〜10、0.5刻み）。RIRとはどちらか一方のみ記録する
+	RPE *float64
+	// RIR は余力として残したレップ数（0〜10）。RPEとはどちらか一方のみ記録する
+	RIR       *int32
+	CreatedAt time.Time
 }
 
-// NewWorkoutSet はバリデーション付きで新しいWorkoutSetエンティティを作成する
+// NewWorkoutSet はバリデーション付きで新しいWorkoutSetエンティティを作成する。
+// 推定1RMはデフォルトの公式（Epley式）で計算する。
 func NewWorkoutSet(workoutID, exerciseID uuid.UUID, setNumber, reps int32, weight float64) (*WorkoutSet, error) {
+	return NewWorkoutSetWithFormula(workoutID, exerciseID, setNumber, reps, weight, DefaultOneRMFormula)
+}
+
+// NewWorkoutSetWithFormula はバリデーション付きで新しいWorkoutSetエンティティを作成し、
+// 指定された公式で推定1RMを計算する。
+func NewWorkoutSetWithFormula(workoutID, exerciseID uuid.UUID, setNumber, reps int32, weight float64, formula OneRMFormula) (*WorkoutSet, error) {
 	if err := ValidateSetNumber(setNumber); err != nil {
 		return nil, err
 	}
@@ -65,7 +146,7 @@ func NewWorkoutSet(workoutID, exerciseID uuid.UUID, setNumber, reps int32, weigh
 		return nil, err
 	}
 
-	estimated1RM := CalculateEstimated1RM(weight, reps)
+	estimated1RM := CalculateEstimated1RMWithFormula(weight, reps, formula)
 
 	return &WorkoutSet{
 		ID:           uuid.New(),
@@ -75,12 +156,13 @@ func NewWorkoutSet(workoutID, exerciseID uuid.UUID, setNumber, reps int32, weigh
 		Reps:         reps,
 		Weight:       weight,
 		Estimated1RM: estimated1RM,
+		SetType:      DefaultSetType,
 		CreatedAt:    time.Now(),
 	}, nil
 }
 
 // ReconstructWorkoutSet は保存されたデータからWorkoutSetエンティティを再構築する
-func ReconstructWorkoutSet(id, workoutID, exerciseID uuid.UUID, setNumber, reps int32, weight, estimated1RM float64, durationSeconds *int32, notes *string, createdAt time.Time) *WorkoutSet {
+func ReconstructWorkoutSet(id, workoutID, exerciseID uuid.UUID, setNumber, reps int32, weight, estimated1RM float64, durationSeconds *int32, notes *string, setType SetType, rpe *float64, rir *int32, createdAt time.Time) *WorkoutSet {
 	return &WorkoutSet{
 		ID:              id,
 		WorkoutID:       workoutID,
@@ -91,12 +173,20 @@ func ReconstructWorkoutSet(id, workoutID, exerciseID uuid.UUID, setNumber, reps
 		Estimated1RM:    estimated1RM,
 		DurationSeconds: durationSeconds,
 		Notes:           notes,
+		SetType:         setType,
+		RPE:             rpe,
+		RIR:             rir,
 		CreatedAt:       createdAt,
 	}
 }
 
-// UpdateRepsAndWeight はレップ数と重量を更新し、推定1RMを再計算する
+// UpdateRepsAndWeight はレップ数と重量を更新し、デフォルトの公式（Epley式）で推定1RMを再計算する
 func (ws *WorkoutSet) UpdateRepsAndWeight(reps int32, weight float64) error {
+	return ws.UpdateRepsAndWeightWithFormula(reps, weight, DefaultOneRMFormula)
+}
+
+// UpdateRepsAndWeightWithFormula はレップ数と重量を更新し、指定された公式で推定1RMを再計算する
+func (ws *WorkoutSet) UpdateRepsAndWeightWithFormula(reps int32, weight float64, formula OneRMFormula) error {
 	if err := ValidateReps(reps); err != nil {
 		return err
 	}
@@ -106,10 +196,15 @@ func (ws *WorkoutSet) UpdateRepsAndWeight(reps int32, weight float64) error {
 
 	ws.Reps = reps
 	ws.Weight = weight
-	ws.Estimated1RM = CalculateEstimated1RM(weight, reps)
+	ws.Estimated1RM = CalculateEstimated1RMWithFormula(weight, reps, formula)
 	return nil
 }
 
+// RecalculateEstimated1RM は現在のレップ数と重量から、指定された計算式で推定1RMを再計算する
+func (ws *WorkoutSet) RecalculateEstimated1RM(formula OneRMFormula) {
+	ws.Estimated1RM = CalculateEstimated1RMWithFormula(ws.Weight, ws.Reps, formula)
+}
+
 // UpdateDuration はセットの継続時間を更新する
 func (ws *WorkoutSet) UpdateDuration(durationSeconds *int32) error {
 	if durationSeconds != nil {
@@ -126,10 +221,69 @@ func (ws *WorkoutSet) UpdateNotes(notes *string) {
 	ws.Notes = notes
 }
 
+// UpdateSetType はセットの種類を更新する
+func (ws *WorkoutSet) UpdateSetType(setType SetType) error {
+	if !setType.IsValid() {
+		return ErrInvalidSetType
+	}
+	ws.SetType = setType
+	return nil
+}
+
+// UpdateEffort はセットのRPEとRIRを更新する。どちらか一方のみ指定でき、両方nilの場合は記録を消去する
+func (ws *WorkoutSet) UpdateEffort(rpe *float64, rir *int32) error {
+	if rpe != nil && rir != nil {
+		return ErrRPEAndRIRBothSet
+	}
+	if rpe != nil {
+		if err := ValidateRPE(*rpe); err != nil {
+			return err
+		}
+	}
+	if rir != nil {
+		if err := ValidateRIR(*rir); err != nil {
+			return err
+		}
+	}
+	ws.RPE = rpe
+	ws.RIR = rir
+	return nil
+}
+
+// IsWarmUp はウォームアップセットかどうかを返す
+func (ws *WorkoutSet) IsWarmUp() bool {
+	return ws.SetType == SetTypeWarmUp
+}
+
+// EffectiveRPE はセットの主観的運動強度を返す。
+// RIRで記録されたセットは RPE = 10 − RIR に換算し（MinRPE未満はMinRPEとする）、どちらも未記録の場合はnilを返す。
+func (ws *WorkoutSet) EffectiveRPE() *float64 {
+	if ws.RPE != nil {
+		rpe := *ws.RPE
+		return &rpe
+	}
+	if ws.RIR != nil {
+		rpe := math.Max(MaxRPE-float64(*ws.RIR), MinRPE)
+		return &rpe
+	}
+	return nil
+}
+
+// ExcludeWarmUpSets はウォームアップセットを除いたセットを返す
+func ExcludeWarmUpSets(sets []*WorkoutSet) []*WorkoutSet {
+	result := make([]*WorkoutSet, 0, len(sets))
+	for _, set := range sets {
+		if !set.IsWarmUp() {
+			result = append(result, set)
+		}
+	}
+	return result
+}
+
 // CalculateEstimated1RM はデフォルト（Epley式）で推定1RMを計算する。
 // 既存コードとの後方互換性を維持するラッパー関数。
 func CalculateEstimated1RM(weight float64, reps int32) float64 {
-	return CalculateEstimated1RMWithFormula(weight, reps, OneRMFormulaEpley)
+	return CalculateEstimated1RMWithFormula(weight, reps, DefaultOneRMFormula)
 }
 
 // CalculateEstimated1RMWithFormula は指定された公式で推定1RMを計算する。
@@ -137,6 +291,10 @@ func CalculateEstimated1RM(weight float64, reps int32) float64 {
 // サポートする公式:
 //   - Epley式: 1RM = weight × (1 + reps / 30)
 //   - Brzycki式: 1RM = weight × (36 / (37 - reps))
+//   - Lombardi式: 1RM = weight × reps^0.10
+//   - Mayhew式: 1RM = 100 × weight / (52.2 + 41.9 × e^(-0.055 × reps))
+//   - O'Conner式: 1RM = weight × (1 + reps / 40)
+//   - Wathan式: 1RM = 100 × weight / (48.8 + 53.8 × e^(-0.075 × reps))
 //
 // 共通ルール:
 //   - reps <= 0 の場合は 0 を返す
@@ -156,6 +314,14 @@ func CalculateEstimated1RMWithFormula(weight float64, reps int32, formula OneRMF
 			return 0
 		}
 		return weight * (36.0 / (37.0 - float64(reps)))
+	case OneRMFormulaLombardi:
+		return weight * math.Pow(float64(reps), 0.10)
+	case OneRMFormulaMayhew:
+		return 100.0 * weight / (52.2 + 41.9*math.Exp(-0.055*float64(reps)))
+	case OneRMFormulaOConner:
+		return weight * (1 + float64(reps)/40.0)
+	case OneRMFormulaWathan:
+		return 100.0 * weight / (48.8 + 53.8*math.Exp(-0.075*float64(reps)))
 	case OneRMFormulaEpley:
 		return weight * (1 + float64(reps)/30.0)
 	default:
@@ -169,6 +335,26 @@ func (ws *WorkoutSet) CalculateVolume() float64 {
 	return float64(ws.Reps) * ws.Weight
 }
 
+// SecondsPerRepEquivalent は継続時間で記録したセットをレップ数に換算する際の1レップあたりの秒数
+const SecondsPerRepEquivalent = 3
+
+// CalculateWorkload は体重と継続時間を考慮したセットの負荷量を計算する。
+// 重量が0のセット（自重種目）は体重を負荷とし、体重がnilの場合は0となる。
+// 継続時間が記録されたセットは SecondsPerRepEquivalent 秒を1レップに換算し、レップ数と大きい方を使用する。
+func (ws *WorkoutSet) CalculateWorkload(bodyWeight *float64) float64 {
+	load := ws.Weight
+	if load == 0 && bodyWeight != nil {
+		load = *bodyWeight
+	}
+
+	reps := float64(ws.Reps)
+	if ws.DurationSeconds != nil {
+		reps = math.Max(reps, float64(*ws.DurationSeconds)/SecondsPerRepEquivalent)
+	}
+
+	return reps * load
+}
+
 // ValidateSetNumber はセット番号を検証する
 func ValidateSetNumber(setNumber int32) error {
 	if setNumber <= 0 {
@@ -193,6 +379,22 @@ func ValidateExerciseWeight(weight float64) error {
 	return nil
 }
 
+// ValidateRPE はRPEが MinRPE〜MaxRPE の0.5刻みの値であることを検証する
+func ValidateRPE(rpe float64) error {
+	if rpe < MinRPE || rpe > MaxRPE || rpe*2 != math.Trunc(rpe*2) {
+		return ErrInvalidRPE
+	}
+	return nil
+}
+
+// ValidateRIR はRIRが0〜MaxRIRであることを検証する
+func ValidateRIR(rir int32) error {
+	if rir < 0 || rir > MaxRIR {
+		return ErrInvalidRIR
+	}
+	return nil
+}
+
 // ValidateDuration は継続時間を検証する
 func ValidateDuration(durationSeconds int32) error {
 	if durationSeconds < 0 {
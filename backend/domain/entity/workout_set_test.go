@@ -448,6 +448,93 @@ func TestCalculateEstimated1RMWithFormula_Brzycki(t *testing.T) {
 	}
 }
 
+func TestCalculateEstimated1RMWithFormula_OtherFormulas(t *testing.T) {
+	tests := []struct {
+		name        string
+		formula     OneRMFormula
+		weight      float64
+		reps        int32
+		expected1RM float64
+	}{
+		{
+			name:        "Lombardi式: 10レップ、100kg",
+			formula:     OneRMFormulaLombardi,
+			weight:      100.0,
+			reps:        10,
+			expected1RM: 125.89, // 100 * 10^0.10 ≈ 125.89
+		},
+		{
+			name:        "Mayhew式: 10レップ、100kg",
+			formula:     OneRMFormulaMayhew,
+			weight:      100.0,
+			reps:        10,
+			expected1RM: 130.93, // 100 * 100 / (52.2 + 41.9 * e^(-0.55)) ≈ 130.93
+		},
+		{
+			name:        "O'Conner式: 10レップ、100kg",
+			formula:     OneRMFormulaOConner,
+			weight:      100.0,
+			reps:        10,
+			expected1RM: 125.0, // 100 * (1 + 10/40) = 125
+		},
+		{
+			name:        "Wathan式: 10レップ、100kg",
+			formula:     OneRMFormulaWathan,
+			weight:      100.0,
+			reps:        10,
+			expected1RM: 134.75, // 100 * 100 / (48.8 + 53.8 * e^(-0.75)) ≈ 134.75
+		},
+		{
+			name:        "Mayhew式: 1レップ（重量そのもの）",
+			formula:     OneRMFormulaMayhew,
+			weight:      100.0,
+			reps:        1,
+			expected1RM: 100.0,
+		},
+		{
+			name:        "Wathan式: 0レップ（0を返す）",
+			formula:     OneRMFormulaWathan,
+			weight:      100.0,
+			reps:        0,
+			expected1RM: 0.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CalculateEstimated1RMWithFormula(tt.weight, tt.reps, tt.formula)
+			diff := result - tt.expected1RM
+			if diff < 0 {
+				diff = -diff
+			}
+			if diff > 0.01 {
+				t.Errorf("CalculateEstimated1RMWithFormula(%s) = %v, want %v", tt.formula, result, tt.expected1RM)
+			}
+		})
+	}
+}
+
+func TestNewWorkoutSetWithFormula(t *testing.T) {
+	ws, err := NewWorkoutSetWithFormula(uuid.New(), uuid.New(), 1, 5, 100.0, OneRMFormulaBrzycki)
+	if err != nil {
+		t.Fatalf("NewWorkoutSetWithFormula() unexpected error = %v", err)
+	}
+
+	expected := CalculateEstimated1RMWithFormula(100.0, 5, OneRMFormulaBrzycki)
+	if ws.Estimated1RM != expected {
+		t.Errorf("NewWorkoutSetWithFormula() Estimated1RM = %v, want %v", ws.Estimated1RM, expected)
+	}
+
+	if err := ws.UpdateRepsAndWeightWithFormula(8, 80.0, OneRMFormulaOConner); err != nil {
+		t.Fatalf("UpdateRepsAndWeightWithFormula() unexpected error = %v", err)
+	}
+
+	expected = CalculateEstimated1RMWithFormula(80.0, 8, OneRMFormulaOConner)
+	if ws.Estimated1RM != expected {
+		t.Errorf("UpdateRepsAndWeightWithFormula() Estimated1RM = %v, want %v", ws.Estimated1RM, expected)
+	}
+}
+
 func TestCalculateEstimated1RMWithFormula_InvalidFormula(t *testing.T) {
 	// 無効な公式はEpley式にフォールバック
 	result := CalculateEstimated1RMWithFormula(100.0, 10, OneRMFormula("unknown"))
@@ -464,6 +551,10 @@ func TestOneRMFormula_IsValid(t *testing.T) {
 	}{
 		{OneRMFormulaEpley, true},
 		{OneRMFormulaBrzycki, true},
+		{OneRMFormulaLombardi, true},
+		{OneRMFormulaMayhew, true},
+		{OneRMFormulaOConner, true},
+		{OneRMFormulaWathan, true},
 		{OneRMFormula("unknown"), false},
 		{OneRMFormula(""), false},
 	}
@@ -556,3 +647,198 @@ func TestWorkoutSet_UpdateDuration(t *testing.T) {
 		})
 	}
 }
+
+func TestSetType_IsValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		setType SetType
+		want    bool
+	}{
+		{name: "正常系: warmup", setType: SetTypeWarmUp, want: true},
+		{name: "正常系: working", setType: SetTypeWorking, want: true},
+		{name: "正常系: drop", setType: SetTypeDrop, want: true},
+		{name: "正常系: failure", setType: SetTypeFailure, want: true},
+		{name: "異常系: 空文字", setType: "", want: false},
+		{name: "異常系: 未知の種類", setType: "cooldown", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.setType.IsValid(); got != tt.want {
+				t.Errorf("IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkoutSet_UpdateSetType(t *testing.T) {
+	ws, _ := NewWorkoutSet(uuid.New(), uuid.New(), 1, 10, 100.0)
+	if ws.SetType != SetTypeWorking {
+		t.Fatalf("NewWorkoutSet() SetType = %v, want %v", ws.SetType, SetTypeWorking)
+	}
+
+	if err := ws.UpdateSetType(SetTypeWarmUp); err != nil {
+		t.Fatalf("UpdateSetType() unexpected error = %v", err)
+	}
+	if !ws.IsWarmUp() {
+		t.Errorf("IsWarmUp() = false, want true")
+	}
+
+	if err := ws.UpdateSetType("cooldown"); err != ErrInvalidSetType {
+		t.Errorf("UpdateSetType() error = %v, want %v", err, ErrInvalidSetType)
+	}
+	if ws.SetType != SetTypeWarmUp {
+		t.Errorf("SetType = %v, want unchanged %v", ws.SetType, SetTypeWarmUp)
+	}
+}
+
+func TestWorkoutSet_UpdateEffort(t *testing.T) {
+	tests := []struct {
+		name        string
+		rpe         *float64
+		rir         *int32
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "正常系: RPE 8.5",
+			rpe:  float64Ptr(8.5),
+		},
+		{
+			name: "正常系: RPE 下限（6）",
+			rpe:  float64Ptr(MinRPE),
+		},
+		{
+			name: "正常系: RPE 上限（10）",
+			rpe:  float64Ptr(MaxRPE),
+		},
+		{
+			name: "正常系: RIR 0",
+			rir:  int32Ptr(0),
+		},
+		{
+			name: "正常系: RIR 上限（10）",
+			rir:  int32Ptr(MaxRIR),
+		},
+		{
+			name: "正常系: 両方nilで記録を消去",
+		},
+		{
+			name:        "異常系: RPE 下限未満",
+			rpe:         float64Ptr(5.5),
+			wantErr:     true,
+			expectedErr: ErrInvalidRPE,
+		},
+		{
+			name:        "異常系: RPE 上限超過",
+			rpe:         float64Ptr(10.5),
+			wantErr:     true,
+			expectedErr: ErrInvalidRPE,
+		},
+		{
+			name:        "異常系: RPE 0.5刻みでない",
+			rpe:         float64Ptr(7.25),
+			wantErr:     true,
+			expectedErr: ErrInvalidRPE,
+		},
+		{
+			name:        "異常系: RIR 負の値",
+			rir:         int32Ptr(-1),
+			wantErr:     true,
+			expectedErr: ErrInvalidRIR,
+		},
+		{
+			name:        "異常系: RIR 上限超過",
+			rir:         int32Ptr(11),
+			wantErr:     true,
+			expectedErr: ErrInvalidRIR,
+		},
+		{
+			name:        "異常系: RPEとRIRの両方を指定",
+			rpe:         float64Ptr(8),
+			rir:         int32Ptr(2),
+			wantErr:     true,
+			expectedErr: ErrRPEAndRIRBothSet,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws, _ := NewWorkoutSet(uuid.New(), uuid.New(), 1, 10, 100.0)
+			_ = ws.UpdateEffort(float64Ptr(7), nil)
+
+			err := ws.UpdateEffort(tt.rpe, tt.rir)
+
+			if tt.wantErr {
+				if err != tt.expectedErr {
+					t.Errorf("UpdateEffort() error = %v, want %v", err, tt.expectedErr)
+				}
+				if ws.RPE == nil || *ws.RPE != 7 || ws.RIR != nil {
+					t.Errorf("UpdateEffort() should not change RPE/RIR on error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("UpdateEffort() unexpected error = %v", err)
+			}
+			if ws.RPE != tt.rpe || ws.RIR != tt.rir {
+				t.Errorf("RPE = %v, RIR = %v, want %v, %v", ws.RPE, ws.RIR, tt.rpe, tt.rir)
+			}
+		})
+	}
+}
+
+func TestWorkoutSet_EffectiveRPE(t *testing.T) {
+	tests := []struct {
+		name string
+		rpe  *float64
+		rir  *int32
+		want *float64
+	}{
+		{name: "正常系: RPEをそのまま返す", rpe: float64Ptr(8.5), want: float64Ptr(8.5)},
+		{name: "正常系: RIR 2はRPE 8", rir: int32Ptr(2), want: float64Ptr(8)},
+		{name: "正常系: RIR 0はRPE 10", rir: int32Ptr(0), want: float64Ptr(10)},
+		{name: "正常系: RIR 5以上はRPE 6", rir: int32Ptr(8), want: float64Ptr(MinRPE)},
+		{name: "正常系: 未記録はnil", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws, _ := NewWorkoutSet(uuid.New(), uuid.New(), 1, 10, 100.0)
+			if err := ws.UpdateEffort(tt.rpe, tt.rir); err != nil {
+				t.Fatalf("UpdateEffort() unexpected error = %v", err)
+			}
+
+			got := ws.EffectiveRPE()
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("EffectiveRPE() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkoutSet_RecalculateEstimated1RM(t *testing.T) {
+	tests := []struct {
+		name    string
+		formula OneRMFormula
+	}{
+		{name: "正常系: Brzycki式で再計算", formula: OneRMFormulaBrzycki},
+		{name: "正常系: Epley式で再計算", formula: OneRMFormulaEpley},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws, _ := NewWorkoutSet(uuid.New(), uuid.New(), 1, 5, 100.0)
+			ws.RecalculateEstimated1RM(tt.formula)
+
+			want := CalculateEstimated1RMWithFormula(100.0, 5, tt.formula)
+			if ws.Estimated1RM != want {
+				t.Errorf("Estimated1RM = %v, want %v", ws.Estimated1RM, want)
+			}
+			if ws.Reps != 5 || ws.Weight != 100.0 {
+				t.Errorf("Reps, Weight = %d, %v, want 5, 100", ws.Reps, ws.Weight)
+			}
+		})
+	}
+}
@@ -21,8 +21,12 @@ func TestNewWorkout(t *testing.T) {
 		t.Errorf("UserID = %v, want %v", workout.UserID, userID)
 	}
 
-	if workout.Date != date {
-		t.Errorf("Date = %v, want %v", workout.Date, date)
+	if want := CalendarDate(date); !workout.Date.Equal(want) {
+		t.Errorf("Date = %v, want %v", workout.Date, want)
+	}
+
+	if workout.StartedAt != date || workout.EndedAt != nil {
+		t.Errorf("StartedAt = %v, EndedAt = %v, want %v and nil", workout.StartedAt, workout.EndedAt, date)
 	}
 
 	if workout.DailyScore != 0 {
@@ -34,82 +38,36 @@ func TestNewWorkout(t *testing.T) {
 	}
 }
 
-func TestWorkout_CalculateDailyScore(t *testing.T) {
-	userID := uuid.New()
-	date := time.Now()
-	workout := NewWorkout(userID, date)
+func TestCalendarDate(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
 
-	// 新ロジック: score = 100 × √(totalVolume / 20000)、上限100
-	// totalVolume（総負荷量 = 重量 × レップ数の全セット合計）のみでスコアを決定
 	tests := []struct {
-		name          string
-		totalVolume   float64
-		expectedScore int32
+		name string
+		t    time.Time
+		want time.Time
 	}{
 		{
-			name:          "ボリューム0: トレーニングなし",
-			totalVolume:   0.0,
-			expectedScore: 0,
-		},
-		{
-			name:          "軽いウォームアップ: 100kg",
-			totalVolume:   100.0,
-			expectedScore: 7, // 100 * √(100/20000) ≈ 7.07
-		},
-		{
-			name:          "軽いセッション: 500kg",
-			totalVolume:   500.0,
-			expectedScore: 16, // 100 * √(500/20000) ≈ 15.81
-		},
-		{
-			name:          "軽めのトレーニング: 1000kg",
-			totalVolume:   1000.0,
-			expectedScore: 22, // 100 * √(1000/20000) ≈ 22.36
-		},
-		{
-			name:          "中程度のトレーニング: 2000kg",
-			totalVolume:   2000.0,
-			expectedScore: 32, // 100 * √(2000/20000) ≈ 31.62
-		},
-		{
-			name:          "標準的なトレーニング: 5000kg",
-			totalVolume:   5000.0,
-			expectedScore: 50, // 100 * √(5000/20000) = 50.0
-		},
-		{
-			name:          "ハードなトレーニング: 10000kg",
-			totalVolume:   10000.0,
-			expectedScore: 71, // 100 * √(10000/20000) ≈ 70.71
+			name: "正常系: UTCの時刻",
+			t:    time.Date(2026, 2, 6, 15, 30, 0, 0, time.UTC),
+			want: time.Date(2026, 2, 6, 0, 0, 0, 0, time.UTC),
 		},
 		{
-			name:          "非常にハードなトレーニング: 15000kg",
-			totalVolume:   15000.0,
-			expectedScore: 87, // 100 * √(15000/20000) ≈ 86.60
+			name: "正常系: JSTの深夜はJSTの暦日になる",
+			t:    time.Date(2026, 2, 7, 0, 30, 0, 0, jst),
+			want: time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC),
 		},
 		{
-			name:          "最大基準値: 20000kg",
-			totalVolume:   20000.0,
-			expectedScore: 100, // 100 * √(20000/20000) = 100
-		},
-		{
-			name:          "基準値超過: 25000kg（上限100）",
-			totalVolume:   25000.0,
-			expectedScore: 100, // 上限100に制限
-		},
-		{
-			name:          "負の値: -100kg（0として扱う）",
-			totalVolume:   -100.0,
-			expectedScore: 0,
+			name: "正常系: UTC時刻をJSTに変換すると翌日になる",
+			t:    time.Date(2026, 2, 6, 15, 30, 0, 0, time.UTC).In(jst),
+			want: time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			score := workout.CalculateDailyScore(tt.totalVolume)
-
-			if score != tt.expectedScore {
-				t.Errorf("CalculateDailyScore(%v) = %v, want %v",
-					tt.totalVolume, score, tt.expectedScore)
+			got := CalendarDate(tt.t)
+			if !got.Equal(tt.want) || got.Location() != time.UTC {
+				t.Errorf("CalendarDate() = %v, want %v", got, tt.want)
 			}
 		})
 	}
@@ -222,12 +180,14 @@ func TestReconstructWorkout(t *testing.T) {
 	id := uuid.New()
 	userID := uuid.New()
 	date := time.Now()
+	startedAt := date.Add(18 * time.Hour)
+	endedAt := startedAt.Add(time.Hour)
 	dailyScore := int32(75)
 	memo := "Test memo"
 	createdAt := time.Now().Add(-24 * time.Hour)
 	updatedAt := time.Now()
 
-	workout := ReconstructWorkout(id, userID, date, dailyScore, &memo, createdAt, updatedAt)
+	workout := ReconstructWorkout(id, userID, date, startedAt, &endedAt, dailyScore, &memo, createdAt, updatedAt)
 
 	if workout == nil {
 		t.Fatal("ReconstructWorkout() returned nil")
@@ -245,6 +205,14 @@ func TestReconstructWorkout(t *testing.T) {
 		t.Errorf("Date = %v, want %v", workout.Date, date)
 	}
 
+	if workout.StartedAt != startedAt {
+		t.Errorf("StartedAt = %v, want %v", workout.StartedAt, startedAt)
+	}
+
+	if workout.EndedAt == nil || *workout.EndedAt != endedAt {
+		t.Errorf("EndedAt = %v, want %v", workout.EndedAt, endedAt)
+	}
+
 	if workout.DailyScore != dailyScore {
 		t.Errorf("DailyScore = %v, want %v", workout.DailyScore, dailyScore)
 	}
@@ -261,3 +229,92 @@ func TestReconstructWorkout(t *testing.T) {
 		t.Errorf("UpdatedAt = %v, want %v", workout.UpdatedAt, updatedAt)
 	}
 }
+
+func TestWorkout_UpdateSessionTime(t *testing.T) {
+	startedAt := time.Date(2026, 2, 7, 18, 0, 0, 0, time.UTC)
+	endedAt := startedAt.Add(90 * time.Minute)
+	beforeStart := startedAt.Add(-time.Minute)
+
+	tests := []struct {
+		name         string
+		endedAt      *time.Time
+		wantDuration *time.Duration
+		expectedErr  error
+	}{
+		{
+			name:         "正常系: 終了時刻あり",
+			endedAt:      &endedAt,
+			wantDuration: durationPtr(90 * time.Minute),
+		},
+		{
+			name:    "正常系: 終了時刻なし",
+			endedAt: nil,
+		},
+		{
+			name:         "正常系: 開始時刻と終了時刻が同じ",
+			endedAt:      &startedAt,
+			wantDuration: durationPtr(0),
+		},
+		{
+			name:        "異常系: 終了時刻が開始時刻より前",
+			endedAt:     &beforeStart,
+			expectedErr: ErrInvalidWorkoutEndTime,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workout := NewWorkout(uuid.New(), time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC))
+
+			err := workout.UpdateSessionTime(startedAt, tt.endedAt)
+
+			if tt.expectedErr != nil {
+				if err != tt.expectedErr {
+					t.Errorf("UpdateSessionTime() error = %v, want %v", err, tt.expectedErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("UpdateSessionTime() unexpected error = %v", err)
+			}
+			if workout.StartedAt != startedAt {
+				t.Errorf("StartedAt = %v, want %v", workout.StartedAt, startedAt)
+			}
+
+			got := workout.Duration()
+			if (got == nil) != (tt.wantDuration == nil) || (got != nil && *got != *tt.wantDuration) {
+				t.Errorf("Duration() = %v, want %v", got, tt.wantDuration)
+			}
+		})
+	}
+}
+
+func TestSumDailyScores(t *testing.T) {
+	session := func(score int32) *Workout {
+		return &Workout{DailyScore: score}
+	}
+
+	tests := []struct {
+		name     string
+		workouts []*Workout
+		want     int32
+	}{
+		{name: "正常系: セッションなし", workouts: nil, want: 0},
+		{name: "正常系: 1セッション", workouts: []*Workout{session(40)}, want: 40},
+		{name: "正常系: 複数セッションを合算", workouts: []*Workout{session(40), session(35)}, want: 75},
+		{name: "正常系: 合算値は上限100", workouts: []*Workout{session(70), session(60)}, want: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SumDailyScores(tt.workouts); got != tt.want {
+				t.Errorf("SumDailyScores() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func durationPtr(d time.Duration) *time.Duration {
+	return &d
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+)
+
+// VolumeGroupBy はトレーニング量を集計する期間の単位を表す。
+type VolumeGroupBy string
+
+const (
+	// VolumeGroupByWeek は週（月曜始まり）ごとに集計する
+	VolumeGroupByWeek VolumeGroupBy = "week"
+	// VolumeGroupByMonth は月ごとに集計する
+	VolumeGroupByMonth VolumeGroupBy = "month"
+)
+
+// VolumeDimension はトレーニング量を集計する軸を表す。
+type VolumeDimension string
+
+const (
+	// VolumeDimensionExercise は種目ごとに集計する
+	VolumeDimensionExercise VolumeDimension = "exercise"
+	// VolumeDimensionBodyPart は身体部位ごとに集計する
+	VolumeDimensionBodyPart VolumeDimension = "body_part"
+)
+
+// VolumeQuery はトレーニング量の集計条件を表す。
+// StartDate・EndDateがnilの場合は期間を限定しない。
+type VolumeQuery struct {
+	UserID    uuid.UUID
+	GroupBy   VolumeGroupBy
+	Dimension VolumeDimension
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
+// VolumeAggregate は1期間・1グループ分のトレーニング量を表す。
+// 種目ごとの集計ではExerciseID・ExerciseNameが設定され、身体部位ごとの集計ではnilとなる。
+// BodyPartは身体部位が設定されていない種目の場合nilとなる。
+type VolumeAggregate struct {
+	PeriodStart  time.Time
+	ExerciseID   *uuid.UUID
+	ExerciseName *string
+	BodyPart     *entity.BodyPart
+	// Tonnage は総負荷量（重量 × レップ数の合計）
+	Tonnage  float64
+	SetCount int
+	RepCount int
+	// AverageIntensity は1レップあたりの平均重量（総負荷量 / 総レップ数）
+	AverageIntensity float64
+}
+
+// AnalyticsRepository defines the interface for aggregated training analytics
+type AnalyticsRepository interface {
+	// GetVolume aggregates tonnage, set count, rep count and average intensity per period and dimension, oldest period first
+	GetVolume(ctx context.Context, query VolumeQuery) ([]VolumeAggregate, error)
+}
@@ -6,4 +6,13 @@ import "context"
 type EmailSender interface {
 	// SendVerificationEmail は検証メールを送信する
 	SendVerificationEmail(ctx context.Context, toEmail string, token string) error
+
+	// SendPasswordResetEmail はパスワードリセットメールを送信する
+	SendPasswordResetEmail(ctx context.Context, toEmail string, token string) error
+
+	// SendEmailChangeVerificationEmail は変更後のメールアドレスに確認メールを送信する
+	SendEmailChangeVerificationEmail(ctx context.Context, toEmail string, token string) error
+
+	// SendEmailChangeNotice は変更前のメールアドレスにメールアドレス変更の通知を送信する
+	SendEmailChangeNotice(ctx context.Context, toEmail string, newEmail string) error
 }
@@ -2,12 +2,19 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 	"github.com/ucchy108/whiskey/backend/domain/entity"
 )
 
-// ExerciseRepository defines the interface for exercise data persistence
+// ErrExerciseInUse is returned by ExerciseRepository.Delete when the exercise
+// is still referenced by workout sets, routines or programs.
+var ErrExerciseInUse = errors.New("exercise is in use")
+
+// ExerciseRepository defines the interface for exercise data persistence.
+// Exercises are either system-owned (UserID is nil, visible to everyone)
+// or owned by a single user (visible only to that user).
 type ExerciseRepository interface {
 	// Create creates a new exercise
 	Create(ctx context.Context, exercise *entity.Exercise) error
@@ -15,21 +22,22 @@ type ExerciseRepository interface {
 	// FindByID retrieves an exercise by ID
 	FindByID(ctx context.Context, id uuid.UUID) (*entity.Exercise, error)
 
-	// FindByName retrieves an exercise by name
-	FindByName(ctx context.Context, name string) (*entity.Exercise, error)
+	// FindByName retrieves an exercise by name among the exercises visible to the user
+	FindByName(ctx context.Context, userID uuid.UUID, name string) (*entity.Exercise, error)
 
-	// FindAll retrieves all exercises
-	FindAll(ctx context.Context) ([]*entity.Exercise, error)
+	// FindAll retrieves the system exercises and the user's own exercises
+	FindAll(ctx context.Context, userID uuid.UUID) ([]*entity.Exercise, error)
 
-	// FindByBodyPart retrieves exercises by body part
-	FindByBodyPart(ctx context.Context, bodyPart entity.BodyPart) ([]*entity.Exercise, error)
+	// FindByBodyPart retrieves exercises visible to the user by body part
+	FindByBodyPart(ctx context.Context, userID uuid.UUID, bodyPart entity.BodyPart) ([]*entity.Exercise, error)
 
 	// Update updates an existing exercise
 	Update(ctx context.Context, exercise *entity.Exercise) error
 
-	// Delete deletes an exercise by ID
+	// Delete deletes an exercise by ID.
+	// Returns ErrExerciseInUse if the exercise is still referenced by workout sets, routines or programs.
 	Delete(ctx context.Context, id uuid.UUID) error
 
-	// ExistsByName checks if an exercise with the given name exists
-	ExistsByName(ctx context.Context, name string) (bool, error)
+	// ExistsByName checks if an exercise with the given name is visible to the user
+	ExistsByName(ctx context.Context, userID uuid.UUID, name string) (bool, error)
 }
@@ -0,0 +1,26 @@
+package repository
+
+import "context"
+
+// OIDCIdentity は外部IDプロバイダ（OpenID Connect）で認証されたアカウントの情報。
+// 検証済みのIDトークンのクレームから取得する。
+type OIDCIdentity struct {
+	// Subject はプロバイダ内でアカウントを一意に識別するsubクレーム
+	Subject string
+	Email   string
+	// EmailVerified はプロバイダがメールアドレスの所有を確認済みかどうか
+	EmailVerified bool
+}
+
+// OIDCProvider は外部IDプロバイダとの認可コードフロー（PKCE）のインターフェース。
+// プロバイダごとにInfrastructure層で実装され、Usecase層で使用される。
+type OIDCProvider interface {
+	// AuthorizationURL returns the URL to redirect the user to for authentication.
+	// codeChallenge is the S256 PKCE challenge derived from the code verifier.
+	AuthorizationURL(state, nonce, codeChallenge string) string
+
+	// Exchange exchanges the authorization code for tokens and verifies the ID token
+	// (signature, issuer, audience, expiry and nonce).
+	// Returns the authenticated identity and an error if the exchange or verification fails.
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*OIDCIdentity, error)
+}
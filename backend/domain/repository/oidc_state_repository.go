@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// OIDCAuthState は外部IDプロバイダへのリダイレクトからコールバックまでの間に保持する認可リクエストの状態。
+// stateパラメータをキーとして保存し、コールバック時に一度だけ取り出す。
+type OIDCAuthState struct {
+	Provider string
+	// Nonce はIDトークンの再利用を防ぐためにIDトークンに含めさせる値
+	Nonce string
+	// CodeVerifier はトークン交換時に送るPKCEのcode_verifier
+	CodeVerifier string
+	// RememberMe はセッション作成時に引き継ぐ「ログインしたままにする」の指定
+	RememberMe bool
+}
+
+// OIDCStateRepository は外部IDプロバイダの認可リクエストの状態を管理するインターフェース。
+// Infrastructure層で実装され、Usecase層で使用される。
+type OIDCStateRepository interface {
+	// Create stores the authorization request state that expires after ttl.
+	// Returns the generated state parameter and an error if the operation fails.
+	Create(ctx context.Context, authState OIDCAuthState, ttl time.Duration) (string, error)
+
+	// Consume retrieves and deletes the authorization request state atomically, so that it can be used only once.
+	// Returns nil and no error if the state does not exist or has expired.
+	Consume(ctx context.Context, state string) (*OIDCAuthState, error)
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+)
+
+// PersonalRecordRepository defines the interface for personal record data persistence
+type PersonalRecordRepository interface {
+	// Create creates a new personal record event
+	Create(ctx context.Context, record *entity.PersonalRecord) error
+
+	// FindByUserID retrieves all personal records for a user, newest first
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.PersonalRecord, error)
+
+	// FindByUserIDAndExerciseID retrieves personal records for a specific exercise of a user, newest first
+	FindByUserIDAndExerciseID(ctx context.Context, userID, exerciseID uuid.UUID) ([]*entity.PersonalRecord, error)
+
+	// DeleteByUserIDAndExerciseID deletes all personal records for a specific exercise of a user
+	DeleteByUserIDAndExerciseID(ctx context.Context, userID, exerciseID uuid.UUID) error
+}
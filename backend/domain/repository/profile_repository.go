@@ -26,4 +26,11 @@ type ProfileRepository interface {
 
 	// ExistsByUserID checks if a profile for the given user ID exists
 	ExistsByUserID(ctx context.Context, userID uuid.UUID) (bool, error)
+
+	// FindPendingDailyScoreBackfill retrieves profiles whose stored daily scores must be recalculated after a strategy change
+	FindPendingDailyScoreBackfill(ctx context.Context) ([]*entity.Profile, error)
+
+	// CompleteDailyScoreBackfill clears the pending recalculation flag of a user's profile
+	// The flag is kept if the strategy has been changed again since the given strategy was used for the recalculation
+	CompleteDailyScoreBackfill(ctx context.Context, userID uuid.UUID, strategy entity.DailyScoreStrategyType) error
 }
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+)
+
+// ProgramRepository defines the interface for training program data persistence.
+// A program is persisted together with its days and prescribed sets,
+// so Create should be called inside a transaction.
+type ProgramRepository interface {
+	// Create creates a new program with its days and sets
+	Create(ctx context.Context, program *entity.Program) error
+
+	// FindByID retrieves a program with its days and sets by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Program, error)
+
+	// FindByUserID retrieves all programs with their days and sets for a user
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Program, error)
+
+	// UpdateProgress updates the current day of a program
+	UpdateProgress(ctx context.Context, program *entity.Program) error
+
+	// Delete deletes a program with its days and sets by ID
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// LinkWorkout records which program day a workout was performed as
+	LinkWorkout(ctx context.Context, link *entity.ProgramWorkout) error
+
+	// FindWorkoutLink retrieves the program day link of a workout.
+	// Returns nil if the workout is not linked to any program.
+	FindWorkoutLink(ctx context.Context, workoutID uuid.UUID) (*entity.ProgramWorkout, error)
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// RateLimitResult はレート制限の判定結果
+type RateLimitResult struct {
+	// Allowed は試行が許可されたかどうか
+	Allowed bool
+	// RetryAfter は許可されなかった場合に、次の試行が許可されるまでの時間
+	RetryAfter time.Duration
+}
+
+// RateLimiter はスライディングウィンドウ方式のレート制限のインターフェース。
+// キーごとに直近window内の試行回数を数え、limit回を超える試行を拒否する。
+type RateLimiter interface {
+	// Allow は試行がlimit以内であれば記録して許可し、超えていれば記録せずに拒否する
+	Allow(ctx context.Context, key string, limit int, window time.Duration) (RateLimitResult, error)
+
+	// Reset はキーに記録された試行を全て削除する
+	Reset(ctx context.Context, key string) error
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+)
+
+// RoutineRepository defines the interface for routine data persistence.
+// A routine is persisted together with its ordered exercise targets,
+// so Create and Update should be called inside a transaction.
+type RoutineRepository interface {
+	// Create creates a new routine with its exercises
+	Create(ctx context.Context, routine *entity.Routine) error
+
+	// FindByID retrieves a routine with its exercises by ID
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Routine, error)
+
+	// FindByUserID retrieves all routines with their exercises for a user
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Routine, error)
+
+	// Update updates an existing routine and replaces its exercises
+	Update(ctx context.Context, routine *entity.Routine) error
+
+	// Delete deletes a routine and its exercises by ID
+	Delete(ctx context.Context, id uuid.UUID) error
+}
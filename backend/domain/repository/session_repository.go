@@ -7,22 +7,65 @@ import (
 	"github.com/google/uuid"
 )
 
+// SessionMetadata はセッション作成時に記録するクライアント情報。
+type SessionMetadata struct {
+	UserAgent string
+	IPAddress string
+}
+
+// Session はセッションとそのメタデータを表す。
+// 一覧表示や「他の端末からログアウト」、有効期限の延長のために使用する。
+type Session struct {
+	ID         string
+	UserID     uuid.UUID
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	UserAgent  string
+	IPAddress  string
+	// TTL はログイン時に決定した無操作タイムアウト。アクセスがあるたびにこの期間だけ延長する。
+	TTL time.Duration
+	// AbsoluteExpiresAt はアクセスの有無に関わらずセッションが失効する日時。
+	AbsoluteExpiresAt time.Time
+}
+
 // SessionRepository はセッション管理のインターフェース。
 // Infrastructure層で実装され、Usecase層で使用される。
 type SessionRepository interface {
-	// Create creates a new session for the given user ID.
+	// Create creates a new session for the given user ID with the given client metadata.
+	// The session expires after ttl of inactivity, and never outlives absoluteExpiresAt.
 	// Returns the session ID and an error if the operation fails.
-	Create(ctx context.Context, userID uuid.UUID, ttl time.Duration) (string, error)
+	Create(ctx context.Context, userID uuid.UUID, ttl time.Duration, absoluteExpiresAt time.Time, metadata SessionMetadata) (string, error)
 
 	// Get retrieves the user ID associated with the given session ID.
 	// Returns uuid.Nil and an error if the session does not exist or has expired.
 	Get(ctx context.Context, sessionID string) (uuid.UUID, error)
 
+	// FindByID retrieves the session with its metadata.
+	// Returns nil and no error if the session does not exist or has expired.
+	FindByID(ctx context.Context, sessionID string) (*Session, error)
+
+	// ListByUser retrieves all active sessions of the given user ID, most recently used first.
+	// Returns an empty slice if the user has no sessions.
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*Session, error)
+
+	// Touch records that the session was used at the given time.
+	// Returns an error if the session does not exist or the operation fails.
+	Touch(ctx context.Context, sessionID string, at time.Time) error
+
 	// Delete deletes the session with the given session ID.
 	// Returns an error if the operation fails.
 	Delete(ctx context.Context, sessionID string) error
 
-	// Extend extends the TTL of the session with the given session ID.
+	// DeleteAllForUser deletes all sessions belonging to the given user ID.
+	// Returns an error if the operation fails.
+	DeleteAllForUser(ctx context.Context, userID uuid.UUID) error
+
+	// DeleteOthers deletes all sessions belonging to the given user ID except keepSessionID.
 	// Returns an error if the operation fails.
+	DeleteOthers(ctx context.Context, userID uuid.UUID, keepSessionID string) error
+
+	// Extend extends the TTL of the session with the given session ID.
+	// The TTL is capped so that the session never outlives its absolute expiry.
+	// Returns an error if the session does not exist or the operation fails.
 	Extend(ctx context.Context, sessionID string, ttl time.Duration) error
 }
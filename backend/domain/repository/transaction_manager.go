@@ -0,0 +1,13 @@
+package repository
+
+import "context"
+
+// TransactionManager は複数のリポジトリ操作を1つの作業単位（Unit of Work）として扱うインターフェース。
+// Infrastructure層で実装され、Usecase層で使用される。
+type TransactionManager interface {
+	// RunInTx executes fn within a single transaction.
+	// Repository calls made with the context passed to fn participate in the transaction.
+	// The transaction is committed if fn returns nil, and rolled back otherwise.
+	// If ctx already carries a transaction, fn joins it instead of starting a new one.
+	RunInTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
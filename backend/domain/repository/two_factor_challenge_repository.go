@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TwoFactorChallenge はパスワード認証に成功し、二要素認証のコード入力を待っているログインを表す。
+// コードの検証に成功するまでセッションは作成しない。
+type TwoFactorChallenge struct {
+	ID     string
+	UserID uuid.UUID
+	// RememberMe はセッション作成時に引き継ぐ「ログインしたままにする」の指定
+	RememberMe bool
+}
+
+// TwoFactorChallengeRepository は二要素認証の保留中のログインを管理するインターフェース。
+// Infrastructure層で実装され、Usecase層で使用される。
+type TwoFactorChallengeRepository interface {
+	// Create creates a new pending two-factor challenge for the given user ID that expires after ttl.
+	// Returns the challenge ID and an error if the operation fails.
+	Create(ctx context.Context, userID uuid.UUID, rememberMe bool, ttl time.Duration) (string, error)
+
+	// FindByID retrieves the challenge with the given challenge ID.
+	// Returns nil and no error if the challenge does not exist or has expired.
+	FindByID(ctx context.Context, challengeID string) (*TwoFactorChallenge, error)
+
+	// Delete deletes the challenge with the given challenge ID.
+	// Returns an error if the operation fails.
+	Delete(ctx context.Context, challengeID string) error
+}
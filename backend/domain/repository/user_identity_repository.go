@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+)
+
+// UserIdentityRepository defines the interface for external identity link persistence
+type UserIdentityRepository interface {
+	// Create links an external identity to a user
+	Create(ctx context.Context, identity *entity.UserIdentity) error
+
+	// FindByProviderSubject retrieves the identity for the given provider and subject
+	// Returns nil and no error if the identity is not linked to any user
+	FindByProviderSubject(ctx context.Context, provider, subject string) (*entity.UserIdentity, error)
+
+	// FindByUserID retrieves all identities linked to a user, oldest first
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.UserIdentity, error)
+}
@@ -2,9 +2,11 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/value"
 )
 
 // UserRepository defines the interface for user data persistence
@@ -22,6 +24,7 @@ type UserRepository interface {
 	FindAll(ctx context.Context) ([]*entity.User, error)
 
 	// Update updates an existing user
+	// Returns value.ErrEmailAlreadyExists if the email is already registered to another user.
 	Update(ctx context.Context, user *entity.User) error
 
 	// Delete deletes a user by ID
@@ -32,4 +35,14 @@ type UserRepository interface {
 
 	// FindByVerificationToken retrieves a user by verification token
 	FindByVerificationToken(ctx context.Context, token string) (*entity.User, error)
+
+	// ResetPasswordByTokenHash atomically sets a new password and consumes the password reset token,
+	// only if the token matches and has not expired. Returns nil if no user matched.
+	ResetPasswordByTokenHash(ctx context.Context, tokenHash string, passwordHash value.HashedPassword) (*entity.User, error)
+
+	// FindByEmailChangeTokenHash retrieves a user by the hash of an email change token
+	FindByEmailChangeTokenHash(ctx context.Context, tokenHash string) (*entity.User, error)
+
+	// FindDueForDeletion retrieves users whose scheduled deletion time is at or before the given time
+	FindDueForDeletion(ctx context.Context, before time.Time) ([]*entity.User, error)
 }
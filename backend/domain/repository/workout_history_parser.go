@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"io"
+	"time"
+
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+)
+
+// WeightUnit はインポート元のファイルに記録された重量の単位
+type WeightUnit string
+
+const (
+	WeightUnitKg WeightUnit = "kg"
+	WeightUnitLb WeightUnit = "lb"
+)
+
+// ImportedSetRow はインポート元のファイルから読み取ったセット1件分のデータ。
+// 日時はファイルに記録された壁時計の時刻をUTCとして保持し、Usecase層でユーザーのタイムゾーンの時刻として解釈し直す。
+type ImportedSetRow struct {
+	// Line はファイル内の行番号（ヘッダーを1行目とする）
+	Line int
+	// WorkoutKey は同じワークアウトのセットを識別するキー（開始日時やワークアウトIDなど、ファイル形式ごとに決まる）
+	WorkoutKey string
+	Date       time.Time
+	// StartedAt はワークアウトの開始時刻。ファイルに記録されていない場合はnil
+	StartedAt *time.Time
+	EndedAt   *time.Time
+	// Memo はワークアウト全体のメモ
+	Memo         *string
+	ExerciseName string
+	// BodyPart は種目を新しく作成する場合に使用する身体部位。ファイルに記録されていない場合はnil
+	BodyPart *entity.BodyPart
+	// SetNumber は種目内のセット番号。ファイルに記録されていない場合はnil（記録順に採番する）
+	SetNumber *int32
+	Reps      int32
+	Weight    float64
+	// WeightUnit はファイルに記録された重量の単位。ファイルから判別できない場合は空
+	WeightUnit      WeightUnit
+	DurationSeconds *int32
+	Notes           *string
+	// SetType はセットの種類。ファイルに記録されていない場合はnil（通常のセットとして記録する）
+	SetType *entity.SetType
+	// RPE・RIR はファイルに記録されていない場合はnil
+	RPE *float64
+	RIR *int32
+	// WorkoutOnly はセットのないワークアウトを表す行かどうか。trueの場合はワークアウトの列（日時・メモ）のみを使用する
+	WorkoutOnly bool
+}
+
+// ImportRowError はインポート元のファイルの行ごとのエラー
+type ImportRowError struct {
+	Line    int
+	Message string
+}
+
+// WorkoutHistoryParser は他のアプリからエクスポートされたワークアウト履歴のファイルを読み取るインターフェース。
+// ファイル形式ごとにInfrastructure層で実装され、Usecase層で使用される。
+type WorkoutHistoryParser interface {
+	// Parse reads all set rows from the file.
+	// Rows that cannot be read are skipped and reported as row errors.
+	// Returns an error only if the file itself cannot be read (e.g. a required column is missing).
+	Parse(r io.Reader) ([]ImportedSetRow, []ImportRowError, error)
+}
+
+// WorkoutExportWriter はエクスポートするワークアウトとセットをファイルとして書き出すインターフェース。
+// ファイル形式ごとにInfrastructure層で実装され、Interface層で使用される。
+type WorkoutExportWriter interface {
+	// Write writes a single row. Rows of the same workout are passed consecutively.
+	Write(row WorkoutExportRow) error
+	// Flush writes any buffered data. It is called once after the last row, even if there were no rows.
+	Flush() error
+}
+
+// WorkoutExportWriterFactory はwに書き出すWorkoutExportWriterを生成する関数。
+// 日時はlocの時刻として書き出す。
+type WorkoutExportWriterFactory func(w io.Writer, loc *time.Location) WorkoutExportWriter
@@ -2,12 +2,62 @@ package repository
 
 import (
 	"context"
+	"iter"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/ucchy108/whiskey/backend/domain/entity"
 )
 
+// WorkoutSortOrder はワークアウト一覧の並び順を表す。
+type WorkoutSortOrder string
+
+const (
+	// WorkoutSortDateDesc は日付・開始時刻の新しい順
+	WorkoutSortDateDesc WorkoutSortOrder = "date_desc"
+	// WorkoutSortDateAsc は日付・開始時刻の古い順
+	WorkoutSortDateAsc WorkoutSortOrder = "date_asc"
+)
+
+// WorkoutCursor はキーセットページネーションの位置を表す。
+// 直前のページの最後のワークアウトの並び替えキー（日付・開始時刻・ID）を保持する。
+type WorkoutCursor struct {
+	Date      time.Time
+	StartedAt time.Time
+	ID        uuid.UUID
+}
+
+// WorkoutFilter はワークアウト一覧の絞り込み条件を表す。
+// nilのフィールドは条件に含めない。
+type WorkoutFilter struct {
+	StartDate  *time.Time
+	EndDate    *time.Time
+	ExerciseID *uuid.UUID
+	BodyPart   *entity.BodyPart
+	MinScore   *int32
+	MemoQuery  *string
+}
+
+// WorkoutPageQuery はワークアウト一覧のページ取得条件を表す。
+// Afterがnilの場合は先頭ページを取得する。
+type WorkoutPageQuery struct {
+	UserID uuid.UUID
+	Filter WorkoutFilter
+	Sort   WorkoutSortOrder
+	After  *WorkoutCursor
+	Limit  int32
+}
+
+// WorkoutExportRow はエクスポート用の、ワークアウトとセット1件・その種目名・身体部位の組を表す。
+// 同じワークアウトの行は連続し、Workoutは同じインスタンスを指す。
+// セットのないワークアウトは、Setがnilの1行となる。
+type WorkoutExportRow struct {
+	Workout      *entity.Workout
+	Set          *entity.WorkoutSet
+	ExerciseName string
+	BodyPart     *entity.BodyPart
+}
+
 // WorkoutRepository defines the interface for workout data persistence
 type WorkoutRepository interface {
 	// Create creates a new workout
@@ -19,18 +69,31 @@ type WorkoutRepository interface {
 	// FindByUserID retrieves all workouts for a user
 	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Workout, error)
 
-	// FindByUserIDAndDateRange retrieves workouts for a user within a date range
+	// FindPage retrieves one page of a user's workouts matching the filter,
+	// ordered by (date, started_at, id) and starting after the cursor
+	FindPage(ctx context.Context, query WorkoutPageQuery) ([]*entity.Workout, error)
+
+	// FindByUserIDAndDateRange retrieves workouts for a user within a date range.
+	// Dates are interpreted as calendar days in the location of the given times.
 	FindByUserIDAndDateRange(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]*entity.Workout, error)
 
-	// FindByUserIDAndDate retrieves a workout for a user on a specific date
-	FindByUserIDAndDate(ctx context.Context, userID uuid.UUID, date time.Time) (*entity.Workout, error)
+	// FindByUserIDAndDate retrieves all workouts (sessions) for a user on a specific date
+	FindByUserIDAndDate(ctx context.Context, userID uuid.UUID, date time.Time) ([]*entity.Workout, error)
+
+	// FindDatesByUserID retrieves the distinct calendar dates on which a user has workouts, oldest first
+	FindDatesByUserID(ctx context.Context, userID uuid.UUID) ([]time.Time, error)
+
+	// StreamForExport iterates over all sets of a user's workouts within the optional date range
+	// (nil bounds are ignored), together with their workouts and exercise names,
+	// ordered by (date, started_at, workout id, exercise id, set number).
+	// Workouts are read from the database a page at a time rather than loaded into memory all at once.
+	// A workout without sets is yielded once with a nil Set.
+	// Iteration stops after yielding the first error.
+	StreamForExport(ctx context.Context, userID uuid.UUID, startDate, endDate *time.Time) iter.Seq2[WorkoutExportRow, error]
 
 	// Update updates an existing workout
 	Update(ctx context.Context, workout *entity.Workout) error
 
 	// Delete deletes a workout by ID
 	Delete(ctx context.Context, id uuid.UUID) error
-
-	// ExistsByUserIDAndDate checks if a workout exists for a user on a specific date
-	ExistsByUserIDAndDate(ctx context.Context, userID uuid.UUID, date time.Time) (bool, error)
 }
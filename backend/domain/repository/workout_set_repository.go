@@ -15,6 +15,14 @@ type WeightProgressionPoint struct {
 	Max1RM float64
 }
 
+// WorkoutSetDetail はセットと、そのセットの種目名・身体部位の組を表す。
+// ワークアウト一覧で種目を個別に引き直さずに表示するために使用される。
+type WorkoutSetDetail struct {
+	Set          *entity.WorkoutSet
+	ExerciseName string
+	BodyPart     *entity.BodyPart
+}
+
 // WorkoutSetRepository defines the interface for workout set data persistence
 type WorkoutSetRepository interface {
 	// Create creates a new workout set
@@ -29,12 +37,27 @@ type WorkoutSetRepository interface {
 	// FindByWorkoutIDAndExerciseID retrieves all sets for a specific exercise in a workout
 	FindByWorkoutIDAndExerciseID(ctx context.Context, workoutID, exerciseID uuid.UUID) ([]*entity.WorkoutSet, error)
 
+	// FindDetailsByWorkoutIDs retrieves all sets of the given workouts with their exercise names and body parts in a single query
+	// Within each workout, exercises are ordered by their first recorded set and sets by set number
+	FindDetailsByWorkoutIDs(ctx context.Context, workoutIDs []uuid.UUID) ([]WorkoutSetDetail, error)
+
 	// FindByExerciseID retrieves all sets for a specific exercise (across all workouts)
 	FindByExerciseID(ctx context.Context, exerciseID uuid.UUID) ([]*entity.WorkoutSet, error)
 
+	// FindByUserIDAndExerciseID retrieves all sets of a user for a specific exercise (across all workouts)
+	// Results are sorted chronologically by workout date, workout start time and set number
+	FindByUserIDAndExerciseID(ctx context.Context, userID, exerciseID uuid.UUID) ([]*entity.WorkoutSet, error)
+
+	// FindByUserID retrieves all sets of a user (across all workouts and exercises)
+	// Results are sorted by workout date and set number
+	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.WorkoutSet, error)
+
 	// Update updates an existing workout set
 	Update(ctx context.Context, workoutSet *entity.WorkoutSet) error
 
+	// UpdateEstimated1RMs overwrites the stored estimated 1RM of the given sets in a single statement
+	UpdateEstimated1RMs(ctx context.Context, workoutSets []*entity.WorkoutSet) error
+
 	// Delete deletes a workout set by ID
 	Delete(ctx context.Context, id uuid.UUID) error
 
@@ -46,6 +69,7 @@ type WorkoutSetRepository interface {
 	GetMaxEstimated1RMByExerciseAndUser(ctx context.Context, userID, exerciseID uuid.UUID) (float64, error)
 
 	// GetWeightProgression retrieves daily max estimated 1RM progression for an exercise
+	// The 1RM is recomputed from raw weight/reps with the given formula, not read from the stored column
 	// Returns data points sorted by date ascending
-	GetWeightProgression(ctx context.Context, userID, exerciseID uuid.UUID) ([]WeightProgressionPoint, error)
+	GetWeightProgression(ctx context.Context, userID, exerciseID uuid.UUID, formula entity.OneRMFormula) ([]WeightProgressionPoint, error)
 }
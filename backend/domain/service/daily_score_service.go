@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+// DailyScoreService はワークアウトのデイリースコアの計算に関するドメインサービス。
+// 直近のワークアウトとの比較が必要な計算方法のために、過去のワークアウトを参照する。
+type DailyScoreService struct {
+	workoutRepo    repository.WorkoutRepository
+	workoutSetRepo repository.WorkoutSetRepository
+}
+
+// NewDailyScoreService はDailyScoreServiceを生成する。
+//
+// パラメータ:
+//   - workoutRepo: 直近のワークアウトの取得を担当するリポジトリ
+//   - workoutSetRepo: 直近のワークアウトのセットの取得を担当するリポジトリ
+//
+// 戻り値:
+//   - *DailyScoreService: 生成されたDailyScoreServiceインスタンス
+func NewDailyScoreService(workoutRepo repository.WorkoutRepository, workoutSetRepo repository.WorkoutSetRepository) *DailyScoreService {
+	return &DailyScoreService{
+		workoutRepo:    workoutRepo,
+		workoutSetRepo: workoutSetRepo,
+	}
+}
+
+// CalculateDailyScore はプロフィールで選択された計算方法でワークアウトのデイリースコアを計算する。
+// プロフィールがnilの場合はデフォルトの計算方法（絶対値）を使用する。
+// 平均負荷量との比で計算する場合は、ワークアウトの日の前日までの entity.RollingAverageWeeks 週間の
+// ワークアウト（ウォームアップ以外のセットのないものを除く）の平均負荷量を基準とする。
+//
+// パラメータ:
+//   - ctx: リクエストのコンテキスト
+//   - workout: スコアを計算するワークアウト
+//   - sets: ワークアウトのセット
+//   - profile: 計算方法と体重を参照するプロフィール（nil可）
+//
+// 戻り値:
+//   - int32: デイリースコア（0〜100）
+//   - error: リポジトリエラー
+func (s *DailyScoreService) CalculateDailyScore(ctx context.Context, workout *entity.Workout, sets []*entity.WorkoutSet, profile *entity.Profile) (int32, error) {
+	strategyType := entity.DefaultDailyScoreStrategy
+	var bodyWeight *float64
+	if profile != nil {
+		strategyType = profile.DailyScoreStrategy
+		bodyWeight = profile.Weight
+	}
+
+	input := entity.DailyScoreInput{
+		Sets:       sets,
+		BodyWeight: bodyWeight,
+	}
+	if strategyType == entity.DailyScoreStrategyRollingAverage {
+		average, err := s.recentAverageWorkload(ctx, workout, bodyWeight)
+		if err != nil {
+			return 0, err
+		}
+		input.RecentAverageWorkload = average
+	}
+
+	return entity.NewDailyScoreStrategy(strategyType).CalculateDailyScore(input), nil
+}
+
+// recentAverageWorkload はワークアウトの日の前日までの直近のワークアウト1回あたりの平均負荷量を返す。
+// 対象のワークアウトがない場合はnilを返す。
+func (s *DailyScoreService) recentAverageWorkload(ctx context.Context, workout *entity.Workout, bodyWeight *float64) (*float64, error) {
+	endDate := workout.Date.AddDate(0, 0, -1)
+	startDate := workout.Date.AddDate(0, 0, -7*entity.RollingAverageWeeks)
+	workouts, err := s.workoutRepo.FindByUserIDAndDateRange(ctx, workout.UserID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	if len(workouts) == 0 {
+		return nil, nil
+	}
+
+	workoutIDs := make([]uuid.UUID, len(workouts))
+	for i, w := range workouts {
+		workoutIDs[i] = w.ID
+	}
+	details, err := s.workoutSetRepo.FindDetailsByWorkoutIDs(ctx, workoutIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	workloads := make(map[uuid.UUID]float64)
+	for _, detail := range details {
+		if detail.Set.IsWarmUp() {
+			continue
+		}
+		workloads[detail.Set.WorkoutID] += detail.Set.CalculateWorkload(bodyWeight)
+	}
+	if len(workloads) == 0 {
+		return nil, nil
+	}
+
+	total := 0.0
+	for _, workload := range workloads {
+		total += workload
+	}
+	average := total / float64(len(workloads))
+	return &average, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/google/uuid"
 	"github.com/ucchy108/whiskey/backend/domain/repository"
 )
 
@@ -31,17 +32,19 @@ func NewExerciseService(exerciseRepo repository.ExerciseRepository) *ExerciseSer
 	}
 }
 
-// CheckNameUniqueness はエクササイズ名のユニーク性を検証する。
-// 既に同じ名前が登録されている場合はErrExerciseNameAlreadyExistsを返す。
+// CheckNameUniqueness はユーザーにとってのエクササイズ名のユニーク性を検証する。
+// システム種目またはユーザー自身のカスタム種目に同じ名前が登録されている場合は
+// ErrExerciseNameAlreadyExistsを返す。他ユーザーのカスタム種目とは重複してもよい。
 //
 // パラメータ:
 //   - ctx: リクエストのコンテキスト
+//   - userID: エクササイズを所有するユーザーのID
 //   - name: 検証するエクササイズ名
 //
 // 戻り値:
 //   - error: 名前が重複している場合はErrExerciseNameAlreadyExists
-func (s *ExerciseService) CheckNameUniqueness(ctx context.Context, name string) error {
-	exists, err := s.exerciseRepo.ExistsByName(ctx, name)
+func (s *ExerciseService) CheckNameUniqueness(ctx context.Context, userID uuid.UUID, name string) error {
+	exists, err := s.exerciseRepo.ExistsByName(ctx, userID, name)
 	if err != nil {
 		return err
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/ucchy108/whiskey/backend/domain/entity"
 	"github.com/ucchy108/whiskey/backend/infrastructure/database"
 )
@@ -13,35 +14,60 @@ func TestExerciseService_CheckNameUniqueness(t *testing.T) {
 	defer database.CleanupTestDB(t, db)
 
 	repo := database.NewExerciseRepository(db)
+	userRepo := database.NewUserRepository(db)
 	exerciseService := NewExerciseService(repo)
 	ctx := context.Background()
 
-	// テスト用エクササイズを作成して保存
+	// テスト用ユーザーを作成して保存
+	owner, _ := entity.NewUser("owner@example.com", "password123")
+	userRepo.Create(ctx, owner)
+	other, _ := entity.NewUser("other@example.com", "password123")
+	userRepo.Create(ctx, other)
+
+	// テスト用エクササイズ（システム種目とカスタム種目）を作成して保存
 	existing, _ := entity.NewExercise("ベンチプレス", nil, nil)
 	repo.Create(ctx, existing)
+	custom, _ := entity.NewUserExercise(owner.ID, "マイ種目", nil, nil)
+	repo.Create(ctx, custom)
 
 	tests := []struct {
-		name        string
+		name         string
+		userID       uuid.UUID
 		exerciseName string
-		wantErr     bool
-		expectedErr error
+		wantErr      bool
+		expectedErr  error
 	}{
 		{
 			name:         "正常系: 未使用のエクササイズ名",
+			userID:       owner.ID,
 			exerciseName: "スクワット",
 			wantErr:      false,
 		},
 		{
-			name:         "異常系: 既に使用されているエクササイズ名",
+			name:         "正常系: 他ユーザーのカスタム種目と同じ名前",
+			userID:       other.ID,
+			exerciseName: "マイ種目",
+			wantErr:      false,
+		},
+		{
+			name:         "異常系: システム種目と同じ名前",
+			userID:       owner.ID,
 			exerciseName: "ベンチプレス",
 			wantErr:      true,
 			expectedErr:  ErrExerciseNameAlreadyExists,
 		},
+		{
+			name:         "異常系: 自身のカスタム種目と同じ名前",
+			userID:       owner.ID,
+			exerciseName: "マイ種目",
+			wantErr:      true,
+			expectedErr:  ErrExerciseNameAlreadyExists,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := exerciseService.CheckNameUniqueness(ctx, tt.exerciseName)
+			err := exerciseService.CheckNameUniqueness(ctx, tt.userID, tt.exerciseName)
 
 			if tt.wantErr {
 				if err == nil {
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+// WeeklyGoalProgress は今週の目標トレーニング日数に対する進捗を表す。
+type WeeklyGoalProgress struct {
+	// WeekStart は今週の開始日（月曜日）
+	WeekStart time.Time
+	// WorkoutDays は今週ワークアウトを記録した日数
+	WorkoutDays int
+	// Goal は週あたりの目標トレーニング日数
+	Goal int
+	// Remaining は目標達成までに必要な残りの日数（達成済みの場合は0）
+	Remaining int
+	// Achieved は今週の目標を達成済みかどうか
+	Achieved bool
+}
+
+// StreakSummary はワークアウトの連続記録と今週の目標の進捗をまとめたもの。
+type StreakSummary struct {
+	// CurrentDailyStreak は現在継続中の日単位の連続記録（ワークアウトを記録した日数）
+	CurrentDailyStreak int
+	// LongestDailyStreak はこれまでで最長の日単位の連続記録
+	LongestDailyStreak int
+	// CurrentWeeklyStreak は現在継続中の週単位の連続記録（目標を達成した連続週数）
+	CurrentWeeklyStreak int
+	// LongestWeeklyStreak はこれまでで最長の週単位の連続記録
+	LongestWeeklyStreak int
+	// ThisWeek は今週の目標の進捗
+	ThisWeek WeeklyGoalProgress
+	// LastWorkoutDate は最後にワークアウトを記録した日（記録がない場合はnil）
+	LastWorkoutDate *time.Time
+}
+
+// StreakService はワークアウトの連続記録（ストリーク）に関するドメインサービス。
+type StreakService struct {
+	workoutRepo repository.WorkoutRepository
+}
+
+// NewStreakService はStreakServiceを生成する。
+//
+// パラメータ:
+//   - workoutRepo: ワークアウトを記録した日付の取得を担当するリポジトリ
+//
+// 戻り値:
+//   - *StreakService: 生成されたStreakServiceインスタンス
+func NewStreakService(workoutRepo repository.WorkoutRepository) *StreakService {
+	return &StreakService{
+		workoutRepo: workoutRepo,
+	}
+}
+
+// CalculateStreaks はユーザーの日単位・週単位の連続記録と今週の目標の進捗を計算する。
+//
+// 日単位の連続記録は、ワークアウトを記録した日の間隔が restDayTolerance 日以下の休息であれば
+// 途切れないものとして数える。今日はまだ記録できるため、最後の記録からの休息が
+// restDayTolerance 日以下であれば現在の連続記録は継続中とみなす。
+// 週単位の連続記録は、月曜始まりの週で weeklyGoal 日以上記録した週が連続した数とする。
+// 今週が未達成の場合は前週までの連続記録を継続中とみなす。
+// today より後の日付のワークアウトは計算に含めない。
+//
+// パラメータ:
+//   - ctx: リクエストのコンテキスト
+//   - userID: ユーザーID
+//   - today: ユーザーのタイムゾーンにおける今日の日付（UTCの0時）
+//   - weeklyGoal: 週あたりの目標トレーニング日数
+//   - restDayTolerance: 連続記録を途切れさせずに休める連続日数
+//
+// 戻り値:
+//   - *StreakSummary: 連続記録と今週の目標の進捗
+//   - error: リポジトリエラー
+func (s *StreakService) CalculateStreaks(ctx context.Context, userID uuid.UUID, today time.Time, weeklyGoal, restDayTolerance int32) (*StreakSummary, error) {
+	dates, err := s.workoutRepo.FindDatesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return calculateStreaks(dates, today, int(weeklyGoal), int(restDayTolerance)), nil
+}
+
+// calculateStreaks は古い順に並んだ重複のない日付から連続記録を計算する。
+func calculateStreaks(dates []time.Time, today time.Time, weeklyGoal, restDayTolerance int) *StreakSummary {
+	today = entity.CalendarDate(today)
+	// 目標0日では全ての週が達成扱いとなり週の遡りが終わらないため、最低1日とする
+	weeklyGoal = max(weeklyGoal, 1)
+	restDayTolerance = max(restDayTolerance, 0)
+
+	// 未来の日付は除外する
+	var past []time.Time
+	for _, date := range dates {
+		date = entity.CalendarDate(date)
+		if !date.After(today) {
+			past = append(past, date)
+		}
+	}
+
+	summary := &StreakSummary{}
+
+	// 日単位の連続記録
+	run := 0
+	for i, date := range past {
+		if i > 0 && daysBetween(past[i-1], date) <= restDayTolerance+1 {
+			run++
+		} else {
+			run = 1
+		}
+		summary.LongestDailyStreak = max(summary.LongestDailyStreak, run)
+	}
+	if len(past) > 0 {
+		last := past[len(past)-1]
+		summary.LastWorkoutDate = &last
+		if daysBetween(last, today) <= restDayTolerance+1 {
+			summary.CurrentDailyStreak = run
+		}
+	}
+
+	// 週ごとのワークアウト日数
+	workoutDaysByWeek := make(map[time.Time]int)
+	for _, date := range past {
+		workoutDaysByWeek[weekStart(date)]++
+	}
+	achieved := func(week time.Time) bool {
+		return workoutDaysByWeek[week] >= weeklyGoal
+	}
+
+	// 週単位の連続記録
+	thisWeek := weekStart(today)
+	if len(past) > 0 {
+		run = 0
+		for week := weekStart(past[0]); !week.After(thisWeek); week = week.AddDate(0, 0, 7) {
+			if achieved(week) {
+				run++
+				summary.LongestWeeklyStreak = max(summary.LongestWeeklyStreak, run)
+			} else {
+				run = 0
+			}
+		}
+
+		week := thisWeek
+		if !achieved(week) {
+			week = week.AddDate(0, 0, -7)
+		}
+		for ; achieved(week); week = week.AddDate(0, 0, -7) {
+			summary.CurrentWeeklyStreak++
+		}
+	}
+
+	// 今週の目標の進捗
+	workoutDays := workoutDaysByWeek[thisWeek]
+	summary.ThisWeek = WeeklyGoalProgress{
+		WeekStart:   thisWeek,
+		WorkoutDays: workoutDays,
+		Goal:        weeklyGoal,
+		Remaining:   max(weeklyGoal-workoutDays, 0),
+		Achieved:    workoutDays >= weeklyGoal,
+	}
+
+	return summary
+}
+
+// daysBetween は2つの暦日（UTCの0時）の間の日数を返す。
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// weekStart は暦日が属する週の開始日（月曜日）を返す。
+func weekStart(date time.Time) time.Time {
+	offset := (int(date.Weekday()) + 6) % 7
+	return date.AddDate(0, 0, -offset)
+}
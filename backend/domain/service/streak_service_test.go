@@ -0,0 +1,134 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalculateStreaks(t *testing.T) {
+	// 2026-02-11 は水曜日（今週は 2026-02-09 の月曜日から）
+	today := time.Date(2026, 2, 11, 0, 0, 0, 0, time.UTC)
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2026, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name             string
+		dates            []time.Time
+		weeklyGoal       int
+		restDayTolerance int
+		want             StreakSummary
+	}{
+		{
+			name:             "正常系: ワークアウトなし",
+			dates:            nil,
+			weeklyGoal:       3,
+			restDayTolerance: 1,
+			want: StreakSummary{
+				ThisWeek: WeeklyGoalProgress{WeekStart: day(2, 9), Goal: 3, Remaining: 3},
+			},
+		},
+		{
+			name:             "正常系: 休息日の許容範囲内なら連続記録が続く",
+			dates:            []time.Time{day(2, 5), day(2, 7), day(2, 9), day(2, 10)},
+			weeklyGoal:       3,
+			restDayTolerance: 1,
+			want: StreakSummary{
+				CurrentDailyStreak: 4,
+				LongestDailyStreak: 4,
+				ThisWeek:           WeeklyGoalProgress{WeekStart: day(2, 9), WorkoutDays: 2, Goal: 3, Remaining: 1},
+			},
+		},
+		{
+			name:             "正常系: 休息日の許容範囲を超えると連続記録が途切れる",
+			dates:            []time.Time{day(2, 1), day(2, 2), day(2, 3), day(2, 6), day(2, 9)},
+			weeklyGoal:       3,
+			restDayTolerance: 0,
+			want: StreakSummary{
+				CurrentDailyStreak:  0,
+				LongestDailyStreak:  3,
+				CurrentWeeklyStreak: 1,
+				LongestWeeklyStreak: 1,
+				ThisWeek:            WeeklyGoalProgress{WeekStart: day(2, 9), WorkoutDays: 1, Goal: 3, Remaining: 2},
+			},
+		},
+		{
+			name:             "正常系: 今日まだ記録していなくても許容範囲内なら継続中",
+			dates:            []time.Time{day(2, 8), day(2, 9)},
+			weeklyGoal:       1,
+			restDayTolerance: 1,
+			want: StreakSummary{
+				CurrentDailyStreak:  2,
+				LongestDailyStreak:  2,
+				CurrentWeeklyStreak: 2,
+				LongestWeeklyStreak: 2,
+				ThisWeek:            WeeklyGoalProgress{WeekStart: day(2, 9), WorkoutDays: 1, Goal: 1, Remaining: 0, Achieved: true},
+			},
+		},
+		{
+			name: "正常系: 今週が未達成なら前週までの週単位の連続記録が継続中",
+			dates: []time.Time{
+				day(1, 12), day(1, 13), // 1/12の週: 達成
+				day(1, 26), day(1, 27), // 1/26の週: 達成
+				day(2, 2), day(2, 4), // 2/2の週: 達成
+				day(2, 10), // 今週: 未達成
+			},
+			weeklyGoal:       2,
+			restDayTolerance: 6,
+			want: StreakSummary{
+				CurrentDailyStreak:  5,
+				LongestDailyStreak:  5,
+				CurrentWeeklyStreak: 2,
+				LongestWeeklyStreak: 2,
+				ThisWeek:            WeeklyGoalProgress{WeekStart: day(2, 9), WorkoutDays: 1, Goal: 2, Remaining: 1},
+			},
+		},
+		{
+			name:             "正常系: 同じ週に複数の記録",
+			dates:            []time.Time{day(2, 9), day(2, 10), day(2, 11)},
+			weeklyGoal:       2,
+			restDayTolerance: 0,
+			want: StreakSummary{
+				CurrentDailyStreak:  3,
+				LongestDailyStreak:  3,
+				CurrentWeeklyStreak: 1,
+				LongestWeeklyStreak: 1,
+				ThisWeek:            WeeklyGoalProgress{WeekStart: day(2, 9), WorkoutDays: 3, Goal: 2, Remaining: 0, Achieved: true},
+			},
+		},
+		{
+			name:             "正常系: 未来の日付は含めない",
+			dates:            []time.Time{day(2, 11), day(2, 12)},
+			weeklyGoal:       3,
+			restDayTolerance: 1,
+			want: StreakSummary{
+				CurrentDailyStreak: 1,
+				LongestDailyStreak: 1,
+				ThisWeek:           WeeklyGoalProgress{WeekStart: day(2, 9), WorkoutDays: 1, Goal: 3, Remaining: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculateStreaks(tt.dates, today, tt.weeklyGoal, tt.restDayTolerance)
+
+			if got.CurrentDailyStreak != tt.want.CurrentDailyStreak || got.LongestDailyStreak != tt.want.LongestDailyStreak {
+				t.Errorf("daily streak = (current %d, longest %d), want (current %d, longest %d)",
+					got.CurrentDailyStreak, got.LongestDailyStreak, tt.want.CurrentDailyStreak, tt.want.LongestDailyStreak)
+			}
+			if got.CurrentWeeklyStreak != tt.want.CurrentWeeklyStreak || got.LongestWeeklyStreak != tt.want.LongestWeeklyStreak {
+				t.Errorf("weekly streak = (current %d, longest %d), want (current %d, longest %d)",
+					got.CurrentWeeklyStreak, got.LongestWeeklyStreak, tt.want.CurrentWeeklyStreak, tt.want.LongestWeeklyStreak)
+			}
+			if !got.ThisWeek.WeekStart.Equal(tt.want.ThisWeek.WeekStart) || got.ThisWeek.WorkoutDays != tt.want.ThisWeek.WorkoutDays ||
+				got.ThisWeek.Goal != tt.want.ThisWeek.Goal || got.ThisWeek.Remaining != tt.want.ThisWeek.Remaining ||
+				got.ThisWeek.Achieved != tt.want.ThisWeek.Achieved {
+				t.Errorf("ThisWeek = %+v, want %+v", got.ThisWeek, tt.want.ThisWeek)
+			}
+			if len(tt.dates) == 0 && got.LastWorkoutDate != nil {
+				t.Errorf("LastWorkoutDate = %v, want nil", got.LastWorkoutDate)
+			}
+		})
+	}
+}
@@ -2,17 +2,20 @@ package service
 
 import (
 	"context"
-	"errors"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
 	"github.com/ucchy108/whiskey/backend/domain/repository"
 )
 
-var (
-	// ErrDuplicateWorkoutDate は同日に既にワークアウトが存在する場合のエラー
-	ErrDuplicateWorkoutDate = errors.New("workout already exists for this date")
-)
+// DailyScore は1日分のワークアウトの集計結果を表す。
+type DailyScore struct {
+	Date         time.Time
+	DailyScore   int32
+	SessionCount int32
+}
 
 // WorkoutService はワークアウトに関するドメインサービス。
 // エンティティ単体では実現できないドメインロジックを提供する。
@@ -33,25 +36,46 @@ func NewWorkoutService(workoutRepo repository.WorkoutRepository) *WorkoutService
 	}
 }
 
-// CheckDateUniqueness は特定ユーザーの特定日にワークアウトが存在しないことを検証する。
-// 既に同日のワークアウトが存在する場合はErrDuplicateWorkoutDateを返す。
+// AggregateDailyScores は指定期間のワークアウトを日付ごとに集計する。
+// 同じ日に複数のセッションがある場合は各セッションのデイリースコアを合算する（上限100）。
+// 結果は日付の降順で返す。
 //
 // パラメータ:
 //   - ctx: リクエストのコンテキスト
 //   - userID: ユーザーID
-//   - date: 検証する日付
+//   - startDate: 開始日
+//   - endDate: 終了日
 //
 // 戻り値:
-//   - error: 日付が重複している場合はErrDuplicateWorkoutDate
-func (s *WorkoutService) CheckDateUniqueness(ctx context.Context, userID uuid.UUID, date time.Time) error {
-	exists, err := s.workoutRepo.ExistsByUserIDAndDate(ctx, userID, date)
+//   - []DailyScore: 日付ごとの集計結果
+//   - error: リポジトリエラー
+func (s *WorkoutService) AggregateDailyScores(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]DailyScore, error) {
+	workouts, err := s.workoutRepo.FindByUserIDAndDateRange(ctx, userID, startDate, endDate)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// 同じ日のセッションをまとめる
+	var dates []time.Time
+	sessions := make(map[string][]*entity.Workout)
+	for _, workout := range workouts {
+		key := workout.Date.Format(time.DateOnly)
+		if _, ok := sessions[key]; !ok {
+			dates = append(dates, workout.Date)
+		}
+		sessions[key] = append(sessions[key], workout)
 	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].After(dates[j]) })
 
-	if exists {
-		return ErrDuplicateWorkoutDate
+	scores := make([]DailyScore, 0, len(dates))
+	for _, date := range dates {
+		daySessions := sessions[date.Format(time.DateOnly)]
+		scores = append(scores, DailyScore{
+			Date:         date,
+			DailyScore:   entity.SumDailyScores(daySessions),
+			SessionCount: int32(len(daySessions)),
+		})
 	}
 
-	return nil
+	return scores, nil
 }
@@ -9,7 +9,7 @@ import (
 	"github.com/ucchy108/whiskey/backend/infrastructure/database"
 )
 
-func TestWorkoutService_CheckDateUniqueness(t *testing.T) {
+func TestWorkoutService_AggregateDailyScores(t *testing.T) {
 	db := database.SetupTestDB(t)
 	defer database.CleanupTestDB(t, db)
 
@@ -22,47 +22,64 @@ func TestWorkoutService_CheckDateUniqueness(t *testing.T) {
 	user, _ := entity.NewUser("workout-test@example.com", "password123")
 	userRepo.Create(ctx, user)
 
-	// テスト用ワークアウトを作成して保存
-	existingDate := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
-	existing := entity.NewWorkout(user.ID, existingDate)
-	workoutRepo.Create(ctx, existing)
+	day1 := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2026, 2, 8, 0, 0, 0, 0, time.UTC)
+
+	// 2/7は2セッション（合算で上限超過）、2/8は1セッション
+	for _, w := range []struct {
+		date      time.Time
+		startedAt time.Time
+		score     int32
+	}{
+		{date: day1, startedAt: day1.Add(7 * time.Hour), score: 60},
+		{date: day1, startedAt: day1.Add(18 * time.Hour), score: 55},
+		{date: day2, startedAt: day2.Add(18 * time.Hour), score: 30},
+	} {
+		workout := entity.NewWorkout(user.ID, w.date)
+		workout.UpdateSessionTime(w.startedAt, nil)
+		workout.UpdateDailyScore(w.score)
+		if err := workoutRepo.Create(ctx, workout); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
 
 	tests := []struct {
-		name        string
-		date        time.Time
-		wantErr     bool
-		expectedErr error
+		name      string
+		startDate time.Time
+		endDate   time.Time
+		want      []DailyScore
 	}{
 		{
-			name:    "正常系: 未使用の日付",
-			date:    time.Date(2026, 2, 8, 0, 0, 0, 0, time.UTC),
-			wantErr: false,
+			name:      "正常系: 同日の複数セッションを合算",
+			startDate: day1,
+			endDate:   day2,
+			want: []DailyScore{
+				{Date: day2, DailyScore: 30, SessionCount: 1},
+				{Date: day1, DailyScore: 100, SessionCount: 2},
+			},
 		},
 		{
-			name:        "異常系: 既にワークアウトが存在する日付",
-			date:        existingDate,
-			wantErr:     true,
-			expectedErr: ErrDuplicateWorkoutDate,
+			name:      "正常系: 期間内にワークアウトなし",
+			startDate: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			endDate:   time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+			want:      []DailyScore{},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := workoutService.CheckDateUniqueness(ctx, user.ID, tt.date)
-
-			if tt.wantErr {
-				if err == nil {
-					t.Errorf("CheckDateUniqueness() error = nil, wantErr %v", tt.expectedErr)
-					return
-				}
-				if err != tt.expectedErr {
-					t.Errorf("CheckDateUniqueness() error = %v, want %v", err, tt.expectedErr)
-				}
-				return
+			got, err := workoutService.AggregateDailyScores(ctx, user.ID, tt.startDate, tt.endDate)
+			if err != nil {
+				t.Fatalf("AggregateDailyScores() error = %v", err)
 			}
 
-			if err != nil {
-				t.Errorf("CheckDateUniqueness() unexpected error = %v", err)
+			if len(got) != len(tt.want) {
+				t.Fatalf("AggregateDailyScores() returned %d days, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if !got[i].Date.Equal(tt.want[i].Date) || got[i].DailyScore != tt.want[i].DailyScore || got[i].SessionCount != tt.want[i].SessionCount {
+					t.Errorf("AggregateDailyScores()[%d] = %+v, want %+v", i, got[i], tt.want[i])
+				}
 			}
 		})
 	}
@@ -0,0 +1,67 @@
+package value
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+const (
+	// EmailChangeTokenLength はトークンのバイト長（hex文字列は倍の64文字）
+	EmailChangeTokenLength = 32
+	// EmailChangeTokenExpiry はトークンの有効期限
+	EmailChangeTokenExpiry = 24 * time.Hour
+)
+
+// EmailChangeToken はメールアドレス変更の確認トークンを表す値オブジェクト。
+// 変更後のメールアドレス宛に送信し、所有が確認できた時点でメールアドレスを切り替える。
+// パスワードリセットトークンと同様に、保存・照合にはSHA-256ハッシュのみを使用する。
+type EmailChangeToken struct {
+	hash      string
+	expiresAt time.Time
+}
+
+// NewEmailChangeToken は新しいメールアドレス変更の確認トークンを生成する。
+// 戻り値の文字列はメール送信用の平文トークンで、値オブジェクトにはハッシュのみが保持される。
+func NewEmailChangeToken() (*EmailChangeToken, string, error) {
+	bytes := make([]byte, EmailChangeTokenLength)
+	if _, err := rand.Read(bytes); err != nil {
+		return nil, "", err
+	}
+
+	raw := hex.EncodeToString(bytes)
+	return &EmailChangeToken{
+		hash:      HashEmailChangeToken(raw),
+		expiresAt: time.Now().Add(EmailChangeTokenExpiry),
+	}, raw, nil
+}
+
+// ReconstructEmailChangeToken は保存されたハッシュと有効期限からトークンを再構築する
+func ReconstructEmailChangeToken(hash string, expiresAt time.Time) *EmailChangeToken {
+	return &EmailChangeToken{
+		hash:      hash,
+		expiresAt: expiresAt,
+	}
+}
+
+// HashEmailChangeToken は平文のトークンを保存・検索用のハッシュに変換する
+func HashEmailChangeToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// Hash はトークンのハッシュを返す
+func (t *EmailChangeToken) Hash() string {
+	return t.hash
+}
+
+// ExpiresAt はトークンの有効期限を返す
+func (t *EmailChangeToken) ExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+// IsExpired はトークンが期限切れかどうかを返す
+func (t *EmailChangeToken) IsExpired() bool {
+	return time.Now().After(t.expiresAt)
+}
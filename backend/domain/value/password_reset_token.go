@@ -0,0 +1,67 @@
+package value
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+const (
+	// PasswordResetTokenLength はトークンのバイト長（hex文字列は倍の64文字）
+	PasswordResetTokenLength = 32
+	// PasswordResetTokenExpiry はトークンの有効期限
+	PasswordResetTokenExpiry = 1 * time.Hour
+)
+
+// PasswordResetToken はパスワードリセットトークンを表す値オブジェクト。
+// 平文のトークンはメールで送信するためだけに生成時に一度だけ返し、
+// 保存・照合にはSHA-256ハッシュのみを使用する。
+type PasswordResetToken struct {
+	hash      string
+	expiresAt time.Time
+}
+
+// NewPasswordResetToken は新しいパスワードリセットトークンを生成する。
+// 戻り値の文字列はメール送信用の平文トークンで、値オブジェクトにはハッシュのみが保持される。
+func NewPasswordResetToken() (*PasswordResetToken, string, error) {
+	bytes := make([]byte, PasswordResetTokenLength)
+	if _, err := rand.Read(bytes); err != nil {
+		return nil, "", err
+	}
+
+	raw := hex.EncodeToString(bytes)
+	return &PasswordResetToken{
+		hash:      HashPasswordResetToken(raw),
+		expiresAt: time.Now().Add(PasswordResetTokenExpiry),
+	}, raw, nil
+}
+
+// ReconstructPasswordResetToken は保存されたハッシュと有効期限からトークンを再構築する
+func ReconstructPasswordResetToken(hash string, expiresAt time.Time) *PasswordResetToken {
+	return &PasswordResetToken{
+		hash:      hash,
+		expiresAt: expiresAt,
+	}
+}
+
+// HashPasswordResetToken は平文のトークンを保存・検索用のハッシュに変換する
+func HashPasswordResetToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// Hash はトークンのハッシュを返す
+func (t *PasswordResetToken) Hash() string {
+	return t.hash
+}
+
+// ExpiresAt はトークンの有効期限を返す
+func (t *PasswordResetToken) ExpiresAt() time.Time {
+	return t.expiresAt
+}
+
+// IsExpired はトークンが期限切れかどうかを返す
+func (t *PasswordResetToken) IsExpired() bool {
+	return time.Now().After(t.expiresAt)
+}
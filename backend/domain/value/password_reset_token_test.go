@@ -0,0 +1,59 @@
+package value
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewPasswordResetToken(t *testing.T) {
+	token, raw, err := NewPasswordResetToken()
+	if err != nil {
+		t.Fatalf("NewPasswordResetToken() unexpected error = %v", err)
+	}
+
+	if len(raw) != PasswordResetTokenLength*2 {
+		t.Errorf("len(raw) = %d, want %d", len(raw), PasswordResetTokenLength*2)
+	}
+	if token.Hash() == raw {
+		t.Error("Hash() should not equal the raw token")
+	}
+	if token.Hash() != HashPasswordResetToken(raw) {
+		t.Errorf("Hash() = %v, want %v", token.Hash(), HashPasswordResetToken(raw))
+	}
+	if token.IsExpired() {
+		t.Error("IsExpired() = true for a freshly issued token")
+	}
+
+	_, other, _ := NewPasswordResetToken()
+	if raw == other {
+		t.Error("NewPasswordResetToken() returned the same token twice")
+	}
+}
+
+func TestPasswordResetToken_IsExpired(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		want      bool
+	}{
+		{
+			name:      "正常系: 有効期限内",
+			expiresAt: time.Now().Add(time.Minute),
+			want:      false,
+		},
+		{
+			name:      "正常系: 有効期限切れ",
+			expiresAt: time.Now().Add(-time.Minute),
+			want:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := ReconstructPasswordResetToken(HashPasswordResetToken("raw"), tt.expiresAt)
+			if got := token.IsExpired(); got != tt.want {
+				t.Errorf("IsExpired() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package value
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// PKCECodeVerifierLength はPKCEのcode_verifierの元になるランダムなバイト長（base64url化すると43文字）
+const PKCECodeVerifierLength = 32
+
+// NewPKCECodeVerifier はPKCE（RFC 7636）のcode_verifierを生成する。
+// 認可リクエスト時にはPKCECodeChallengeで変換した値のみを送り、トークン交換時に平文を送る。
+func NewPKCECodeVerifier() (string, error) {
+	bytes := make([]byte, PKCECodeVerifierLength)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// PKCECodeChallenge はcode_verifierからS256方式のcode_challengeを計算する
+func PKCECodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,30 @@
+package value
+
+import "testing"
+
+func TestNewPKCECodeVerifier(t *testing.T) {
+	verifier, err := NewPKCECodeVerifier()
+	if err != nil {
+		t.Fatalf("NewPKCECodeVerifier() unexpected error = %v", err)
+	}
+
+	// RFC 7636: code_verifierは43〜128文字
+	if len(verifier) != 43 {
+		t.Errorf("len(verifier) = %d, want 43", len(verifier))
+	}
+
+	other, _ := NewPKCECodeVerifier()
+	if verifier == other {
+		t.Error("NewPKCECodeVerifier() returned the same verifier twice")
+	}
+}
+
+func TestPKCECodeChallenge(t *testing.T) {
+	// RFC 7636付録Bの例
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	want := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+	if got := PKCECodeChallenge(verifier); got != want {
+		t.Errorf("PKCECodeChallenge() = %v, want %v", got, want)
+	}
+}
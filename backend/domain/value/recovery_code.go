@@ -0,0 +1,51 @@
+package value
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+)
+
+const (
+	// RecoveryCodeCount は二要素認証の有効化時に発行するリカバリーコードの数
+	RecoveryCodeCount = 10
+	// recoveryCodeHalfLength はリカバリーコードをハイフンで区切る前後それぞれの文字数
+	recoveryCodeHalfLength = 5
+)
+
+// recoveryCodeEncoding は読み間違えにくいよう小文字のBase32を使用する
+var recoveryCodeEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// NewRecoveryCodes は認証アプリを使えなくなった場合に使用する使い捨てのリカバリーコードを生成する。
+// 平文のコードはユーザーに表示するためだけに一度だけ返し、保存・照合にはSHA-256ハッシュのみを使用する。
+// 戻り値は平文のコードと、それぞれに対応するハッシュ。
+func NewRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, RecoveryCodeCount)
+	hashes := make([]string, RecoveryCodeCount)
+
+	for i := range codes {
+		// 10文字のBase32（50ビット）を得るために7バイトを生成する
+		bytes := make([]byte, 7)
+		if _, err := rand.Read(bytes); err != nil {
+			return nil, nil, err
+		}
+
+		encoded := recoveryCodeEncoding.EncodeToString(bytes)[:recoveryCodeHalfLength*2]
+		codes[i] = encoded[:recoveryCodeHalfLength] + "-" + encoded[recoveryCodeHalfLength:]
+		hashes[i] = HashRecoveryCode(codes[i])
+	}
+
+	return codes, hashes, nil
+}
+
+// HashRecoveryCode は平文のリカバリーコードを保存・照合用のハッシュに変換する。
+// 入力の揺れを吸収するため、大文字・ハイフン・空白は正規化してからハッシュ化する。
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(code)
+	normalized = strings.NewReplacer("-", "", " ", "").Replace(normalized)
+
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,48 @@
+package value
+
+import "testing"
+
+func TestNewRecoveryCodes(t *testing.T) {
+	codes, hashes, err := NewRecoveryCodes()
+	if err != nil {
+		t.Fatalf("NewRecoveryCodes() unexpected error = %v", err)
+	}
+
+	if len(codes) != RecoveryCodeCount || len(hashes) != RecoveryCodeCount {
+		t.Fatalf("NewRecoveryCodes() returned %d codes and %d hashes, want %d", len(codes), len(hashes), RecoveryCodeCount)
+	}
+
+	seen := make(map[string]bool)
+	for i, code := range codes {
+		if len(code) != 11 || code[5] != '-' {
+			t.Errorf("code = %v, want xxxxx-xxxxx format", code)
+		}
+		if hashes[i] != HashRecoveryCode(code) {
+			t.Errorf("hashes[%d] = %v, want %v", i, hashes[i], HashRecoveryCode(code))
+		}
+		if seen[code] {
+			t.Errorf("NewRecoveryCodes() returned duplicate code %v", code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "正常系: 大文字", input: "ABCDE-FGHIJ"},
+		{name: "正常系: ハイフンなし", input: "abcdefghij"},
+		{name: "正常系: 空白区切り", input: "abcde fghij"},
+	}
+
+	want := HashRecoveryCode("abcde-fghij")
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HashRecoveryCode(tt.input); got != want {
+				t.Errorf("HashRecoveryCode(%q) = %v, want %v", tt.input, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package value
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const (
+	// TOTPSecretLength は秘密鍵のバイト長（RFC 4226で推奨される160ビット）
+	TOTPSecretLength = 20
+	// TOTPDigits はワンタイムコードの桁数
+	TOTPDigits = 6
+	// TOTPPeriod はワンタイムコードが切り替わる間隔
+	TOTPPeriod = 30 * time.Second
+	// TOTPSkew は端末の時刻ずれを許容するため、前後何ステップまでのコードを受け付けるか
+	TOTPSkew = 1
+)
+
+// ErrInvalidTOTPSecret は秘密鍵がBase32として不正な場合のエラー
+var ErrInvalidTOTPSecret = errors.New("invalid TOTP secret")
+
+// totpEncoding は認証アプリとの互換性のため、パディングなしのBase32を使用する
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTPSecret は二要素認証（RFC 6238 TOTP）の秘密鍵を表す値オブジェクト。
+// 認証アプリに登録するためBase32文字列として保持する。
+// コードの生成・検証は現在時刻を引数で受け取り、テストで時刻を固定できるようにする。
+type TOTPSecret struct {
+	secret string
+}
+
+// NewTOTPSecret はランダムな秘密鍵を生成する
+func NewTOTPSecret() (*TOTPSecret, error) {
+	bytes := make([]byte, TOTPSecretLength)
+	if _, err := rand.Read(bytes); err != nil {
+		return nil, err
+	}
+
+	return &TOTPSecret{secret: totpEncoding.EncodeToString(bytes)}, nil
+}
+
+// ReconstructTOTPSecret は保存されたBase32文字列から秘密鍵を再構築する
+func ReconstructTOTPSecret(secret string) *TOTPSecret {
+	return &TOTPSecret{secret: secret}
+}
+
+// String は秘密鍵のBase32文字列を返す
+func (s *TOTPSecret) String() string {
+	return s.secret
+}
+
+// ProvisioningURI は認証アプリに登録するためのotpauth URIを返す。
+// QRコードに変換して読み取らせることを想定している。
+func (s *TOTPSecret) ProvisioningURI(issuer, account string) string {
+	query := url.Values{}
+	query.Set("secret", s.secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(TOTPDigits))
+	query.Set("period", fmt.Sprint(int(TOTPPeriod.Seconds())))
+
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// Code は指定時刻におけるワンタイムコードを返す
+func (s *TOTPSecret) Code(at time.Time) (string, error) {
+	key, err := s.key()
+	if err != nil {
+		return "", err
+	}
+	return hotp(key, TOTPTimeStep(at)), nil
+}
+
+// MatchStep はコードが指定時刻の前後TOTPSkewステップ以内のいずれかと一致するか検証し、
+// 一致したタイムステップを返す。呼び出し側はタイムステップを記録して同じコードの再利用を防ぐ。
+func (s *TOTPSecret) MatchStep(code string, at time.Time) (int64, bool) {
+	if len(code) != TOTPDigits {
+		return 0, false
+	}
+
+	key, err := s.key()
+	if err != nil {
+		return 0, false
+	}
+
+	current := TOTPTimeStep(at)
+	for step := current - TOTPSkew; step <= current+TOTPSkew; step++ {
+		if subtle.ConstantTimeCompare([]byte(hotp(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// key はBase32文字列をデコードした秘密鍵を返す
+func (s *TOTPSecret) key() ([]byte, error) {
+	key, err := totpEncoding.DecodeString(s.secret)
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidTOTPSecret
+	}
+	return key, nil
+}
+
+// TOTPTimeStep は指定時刻が属するTOTPのタイムステップ（Unix時刻をTOTPPeriodで割った値）を返す
+func TOTPTimeStep(at time.Time) int64 {
+	return at.Unix() / int64(TOTPPeriod.Seconds())
+}
+
+// hotp はRFC 4226に従い、カウンターに対するHMAC-SHA1ベースのワンタイムコードを計算する
+func hotp(key []byte, counter int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	truncated := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < TOTPDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", TOTPDigits, truncated%mod)
+}
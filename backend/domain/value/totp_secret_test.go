@@ -0,0 +1,140 @@
+package value
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret はRFC 6238付録Bのテストベクターで使用される秘密鍵（"12345678901234567890"）のBase32表現
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestNewTOTPSecret(t *testing.T) {
+	secret, err := NewTOTPSecret()
+	if err != nil {
+		t.Fatalf("NewTOTPSecret() unexpected error = %v", err)
+	}
+
+	if len(secret.String()) != 32 {
+		t.Errorf("len(String()) = %d, want 32", len(secret.String()))
+	}
+
+	other, _ := NewTOTPSecret()
+	if secret.String() == other.String() {
+		t.Error("NewTOTPSecret() returned the same secret twice")
+	}
+}
+
+func TestTOTPSecret_Code(t *testing.T) {
+	// RFC 6238付録BのSHA-1のテストベクター（8桁）の下6桁
+	tests := []struct {
+		name string
+		at   time.Time
+		want string
+	}{
+		{name: "正常系: T=59", at: time.Unix(59, 0), want: "287082"},
+		{name: "正常系: T=1111111109", at: time.Unix(1111111109, 0), want: "081804"},
+		{name: "正常系: T=1234567890", at: time.Unix(1234567890, 0), want: "005924"},
+		{name: "正常系: T=2000000000", at: time.Unix(2000000000, 0), want: "279037"},
+	}
+
+	secret := ReconstructTOTPSecret(rfc6238Secret)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := secret.Code(tt.at)
+			if err != nil {
+				t.Fatalf("Code() unexpected error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Code() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTOTPSecret_MatchStep(t *testing.T) {
+	secret := ReconstructTOTPSecret(rfc6238Secret)
+	now := time.Unix(1234567890, 0)
+	code, _ := secret.Code(now)
+
+	tests := []struct {
+		name     string
+		secret   *TOTPSecret
+		code     string
+		at       time.Time
+		wantStep int64
+		wantOK   bool
+	}{
+		{
+			name:     "正常系: 現在のステップのコード",
+			secret:   secret,
+			code:     code,
+			at:       now,
+			wantStep: TOTPTimeStep(now),
+			wantOK:   true,
+		},
+		{
+			name:     "正常系: 1ステップ前のコードは時刻ずれとして許容",
+			secret:   secret,
+			code:     code,
+			at:       now.Add(TOTPPeriod),
+			wantStep: TOTPTimeStep(now),
+			wantOK:   true,
+		},
+		{
+			name:   "異常系: 2ステップ前のコード",
+			secret: secret,
+			code:   code,
+			at:     now.Add(2 * TOTPPeriod),
+			wantOK: false,
+		},
+		{
+			name:   "異常系: コードが一致しない",
+			secret: secret,
+			code:   "000000",
+			at:     now,
+			wantOK: false,
+		},
+		{
+			name:   "異常系: 桁数が不正",
+			secret: secret,
+			code:   code[:5],
+			at:     now,
+			wantOK: false,
+		},
+		{
+			name:   "異常系: 秘密鍵が不正",
+			secret: ReconstructTOTPSecret("not base32!"),
+			code:   code,
+			at:     now,
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := tt.secret.MatchStep(tt.code, tt.at)
+			if ok != tt.wantOK {
+				t.Fatalf("MatchStep() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && step != tt.wantStep {
+				t.Errorf("MatchStep() step = %v, want %v", step, tt.wantStep)
+			}
+		})
+	}
+}
+
+func TestTOTPSecret_ProvisioningURI(t *testing.T) {
+	secret := ReconstructTOTPSecret(rfc6238Secret)
+
+	uri := secret.ProvisioningURI("Whiskey", "user@example.com")
+
+	if !strings.HasPrefix(uri, "otpauth://totp/Whiskey:user@example.com?") {
+		t.Errorf("ProvisioningURI() = %v, want otpauth://totp/Whiskey:user@example.com?...", uri)
+	}
+	for _, param := range []string{"secret=" + rfc6238Secret, "issuer=Whiskey", "digits=6", "period=30"} {
+		if !strings.Contains(uri, param) {
+			t.Errorf("ProvisioningURI() = %v, want it to contain %v", uri, param)
+		}
+	}
+}
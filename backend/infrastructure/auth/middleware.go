@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ucchy108/whiskey/backend/domain/repository"
@@ -15,13 +16,23 @@ const (
 	// UserIDContextKey は認証済みユーザーのIDを格納するためのコンテキストキー。
 	UserIDContextKey contextKey = "userID"
 
+	// SessionIDContextKey は認証に使用したセッションIDを格納するためのコンテキストキー。
+	SessionIDContextKey contextKey = "sessionID"
+
 	// SessionCookieName はセッションIDを格納するHTTPクッキーの名前。
 	SessionCookieName = "session_id"
+
+	// SessionRefreshInterval はセッションの有効期限の延長と最終アクセス日時の更新を行う最小間隔。
+	// リクエストごとにRedisへ書き込まないよう、前回の更新からこの時間が経過した場合のみ更新する。
+	SessionRefreshInterval = 1 * time.Minute
 )
 
 // AuthMiddleware はセッションベース認証を検証するHTTPミドルウェアを返す。
 // 有効なセッションクッキーの存在を確認し、提供されたSessionRepositoryを使用してセッションを検証し、
-// 認証済みユーザーIDをリクエストコンテキストに追加する。
+// 認証済みユーザーIDとセッションIDをリクエストコンテキストに追加する。
+// アクセスがあるとSessionRefreshIntervalごとにセッションの有効期限を延長し（スライディング方式）、
+// クッキーのMax-Ageも延長後の有効期限に合わせて更新する。
+// 延長はログイン時に決まった絶対的な有効期限を超えない。
 // 認証が失敗した場合はHTTP 401 Unauthorizedを返す。
 func AuthMiddleware(sessionRepo repository.SessionRepository) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
@@ -34,19 +45,72 @@ func AuthMiddleware(sessionRepo repository.SessionRepository) func(http.Handler)
 			}
 
 			// Validate session
-			userID, err := sessionRepo.Get(r.Context(), cookie.Value)
-			if err != nil {
+			session, err := sessionRepo.FindByID(r.Context(), cookie.Value)
+			if err != nil || session == nil {
 				http.Error(w, "Unauthorized: invalid session", http.StatusUnauthorized)
 				return
 			}
 
-			// Add user ID to context
-			ctx := context.WithValue(r.Context(), UserIDContextKey, userID)
+			// Slide expiry and record activity (best effort; a failure must not reject the request)
+			now := time.Now()
+			if now.Sub(session.LastSeenAt) >= SessionRefreshInterval {
+				refreshSession(w, r, sessionRepo, session, now)
+			}
+
+			// Add user ID and session ID to context
+			ctx := context.WithValue(r.Context(), UserIDContextKey, session.UserID)
+			ctx = context.WithValue(ctx, SessionIDContextKey, session.ID)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// refreshSession はセッションの有効期限を延長し、最終アクセス日時を記録する。
+// 延長に成功した場合はクッキーのMax-Ageを新しい有効期限に合わせる。
+func refreshSession(w http.ResponseWriter, r *http.Request, sessionRepo repository.SessionRepository, session *repository.Session, now time.Time) {
+	ttl := session.TTL
+	if !session.AbsoluteExpiresAt.IsZero() {
+		if remaining := session.AbsoluteExpiresAt.Sub(now); remaining < ttl {
+			ttl = remaining
+		}
+	}
+
+	if ttl > 0 {
+		if err := sessionRepo.Extend(r.Context(), session.ID, ttl); err == nil {
+			SetSessionCookie(w, session.ID, ttl)
+		}
+	}
+
+	_ = sessionRepo.Touch(r.Context(), session.ID, now)
+}
+
+// SetSessionCookie はセッションIDを格納するクッキーを設定する。
+// Max-Ageはセッションの有効期限（ttl）に合わせる。
+func SetSessionCookie(w http.ResponseWriter, sessionID string, ttl time.Duration) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    sessionID,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   int(ttl.Seconds()),
+	})
+}
+
+// ClearSessionCookie はセッションIDを格納するクッキーを削除する。
+func ClearSessionCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   -1,
+	})
+}
+
 // GetUserIDFromContext はリクエストコンテキストから認証済みユーザーのIDを取得する。
 // AuthMiddlewareで保護されたハンドラー内で使用する必要がある。
 // コンテキストにユーザーIDが見つからない場合（AuthMiddlewareが適用されていない場合など）はuuid.Nilを返す。
@@ -57,3 +121,14 @@ func GetUserIDFromContext(ctx context.Context) uuid.UUID {
 	}
 	return userID
 }
+
+// GetSessionIDFromContext はリクエストコンテキストから認証に使用したセッションIDを取得する。
+// AuthMiddlewareで保護されたハンドラー内で使用する必要がある。
+// コンテキストにセッションIDが見つからない場合は空文字列を返す。
+func GetSessionIDFromContext(ctx context.Context) string {
+	sessionID, ok := ctx.Value(SessionIDContextKey).(string)
+	if !ok {
+		return ""
+	}
+	return sessionID
+}
@@ -21,7 +21,7 @@ func TestAuthMiddleware_ValidSession(t *testing.T) {
 
 	// Create a session
 	userID := uuid.New()
-	sessionID, err := store.Create(ctx, userID, 1*time.Hour)
+	sessionID, err := store.Create(ctx, userID, 1*time.Hour, testAbsoluteExpiresAt(), testSessionMetadata)
 	require.NoError(t, err)
 
 	// Create a test handler that verifies the user ID is in context
@@ -30,6 +30,7 @@ func TestAuthMiddleware_ValidSession(t *testing.T) {
 		handlerCalled = true
 		retrievedUserID := GetUserIDFromContext(r.Context())
 		assert.Equal(t, userID, retrievedUserID)
+		assert.Equal(t, sessionID, GetSessionIDFromContext(r.Context()))
 		w.WriteHeader(http.StatusOK)
 	})
 
@@ -120,7 +121,7 @@ func TestAuthMiddleware_ExpiredSession(t *testing.T) {
 
 	// Create a session with very short TTL
 	userID := uuid.New()
-	sessionID, err := store.Create(ctx, userID, 1*time.Millisecond)
+	sessionID, err := store.Create(ctx, userID, 1*time.Millisecond, testAbsoluteExpiresAt(), testSessionMetadata)
 	require.NoError(t, err)
 
 	// Wait for expiration
@@ -152,6 +153,91 @@ func TestAuthMiddleware_ExpiredSession(t *testing.T) {
 	assert.Contains(t, rec.Body.String(), "invalid session")
 }
 
+func TestAuthMiddleware_SlidingExpiry(t *testing.T) {
+	client := setupTestRedis(t)
+	defer client.Close()
+
+	store := NewSessionStore(client)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	sessionID, err := store.Create(ctx, userID, 1*time.Hour, testAbsoluteExpiresAt(), testSessionMetadata)
+	require.NoError(t, err)
+
+	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := AuthMiddleware(store)(testHandler)
+
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.AddCookie(&http.Cookie{Name: SessionCookieName, Value: sessionID})
+		return req
+	}
+
+	// Recently used session: no refresh, no cookie
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Result().Cookies())
+
+	// Simulate inactivity longer than the refresh interval
+	require.NoError(t, client.Expire(ctx, sessionKey(sessionID), 10*time.Minute).Err())
+	require.NoError(t, store.Touch(ctx, sessionID, time.Now().Add(-2*SessionRefreshInterval)))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// TTL should be slid back to the session's TTL and the cookie kept in sync
+	ttl, err := client.TTL(ctx, sessionKey(sessionID)).Result()
+	require.NoError(t, err)
+	assert.Greater(t, ttl, 50*time.Minute)
+
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, SessionCookieName, cookies[0].Name)
+	assert.Equal(t, sessionID, cookies[0].Value)
+	assert.Equal(t, int((1 * time.Hour).Seconds()), cookies[0].MaxAge)
+
+	session, err := store.FindByID(ctx, sessionID)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), session.LastSeenAt, 5*time.Second)
+}
+
+func TestAuthMiddleware_SlidingExpiry_CappedByAbsoluteExpiry(t *testing.T) {
+	client := setupTestRedis(t)
+	defer client.Close()
+
+	store := NewSessionStore(client)
+	ctx := context.Background()
+
+	// Absolute expiry is closer than the TTL
+	sessionID, err := store.Create(ctx, uuid.New(), 1*time.Hour, time.Now().Add(5*time.Minute), testSessionMetadata)
+	require.NoError(t, err)
+	require.NoError(t, store.Touch(ctx, sessionID, time.Now().Add(-2*SessionRefreshInterval)))
+
+	testHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := AuthMiddleware(store)(testHandler)
+
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.AddCookie(&http.Cookie{Name: SessionCookieName, Value: sessionID})
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	ttl, err := client.TTL(ctx, sessionKey(sessionID)).Result()
+	require.NoError(t, err)
+	assert.LessOrEqual(t, ttl, 5*time.Minute)
+
+	cookies := rec.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.LessOrEqual(t, cookies[0].MaxAge, int((5 * time.Minute).Seconds()))
+}
+
 func TestGetUserIDFromContext_Valid(t *testing.T) {
 	userID := uuid.New()
 	ctx := context.WithValue(context.Background(), UserIDContextKey, userID)
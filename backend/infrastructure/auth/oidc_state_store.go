@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+// OIDC認可リクエストの状態のハッシュのフィールド名
+const (
+	oidcStateFieldProvider     = "provider"
+	oidcStateFieldNonce        = "nonce"
+	oidcStateFieldCodeVerifier = "code_verifier"
+	oidcStateFieldRememberMe   = "remember_me"
+)
+
+// OIDCStateStore はRedisを使用して外部IDプロバイダの認可リクエストの状態を管理する。
+// repository.OIDCStateRepositoryインターフェースを実装する。
+// 状態（oidc_state:{state}）はプロバイダ名・nonce・PKCEのcode_verifierを持つハッシュとして保存し、
+// 有効期限はRedisのキーの有効期限で管理する。
+type OIDCStateStore struct {
+	client *redis.Client
+}
+
+// OIDCStateStoreがrepository.OIDCStateRepositoryを実装していることをコンパイル時にチェック
+var _ repository.OIDCStateRepository = (*OIDCStateStore)(nil)
+
+// NewOIDCStateStore は指定されたRedisクライアントを使用して新しいOIDCStateStoreインスタンスを生成する。
+func NewOIDCStateStore(client *redis.Client) *OIDCStateStore {
+	return &OIDCStateStore{
+		client: client,
+	}
+}
+
+// Create は認可リクエストの状態をttlで失効するように保存し、stateパラメータを返す。
+func (s *OIDCStateStore) Create(ctx context.Context, authState repository.OIDCAuthState, ttl time.Duration) (string, error) {
+	state := uuid.New().String()
+	key := oidcStateKey(state)
+
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key,
+			oidcStateFieldProvider, authState.Provider,
+			oidcStateFieldNonce, authState.Nonce,
+			oidcStateFieldCodeVerifier, authState.CodeVerifier,
+			oidcStateFieldRememberMe, strconv.FormatBool(authState.RememberMe),
+		)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create OIDC state: %w", err)
+	}
+
+	return state, nil
+}
+
+// Consume は指定されたstateの認可リクエストの状態を取得すると同時に削除する。
+// 取得と削除はトランザクションで行い、同じstateでのコールバックを一度だけ受け付ける。
+// 状態が存在しない、または期限切れの場合はnilを返す。
+func (s *OIDCStateStore) Consume(ctx context.Context, state string) (*repository.OIDCAuthState, error) {
+	key := oidcStateKey(state)
+
+	var getCmd *redis.MapStringStringCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		getCmd = pipe.HGetAll(ctx, key)
+		pipe.Del(ctx, key)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume OIDC state: %w", err)
+	}
+
+	fields := getCmd.Val()
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	rememberMe, err := strconv.ParseBool(fields[oidcStateFieldRememberMe])
+	if err != nil {
+		return nil, fmt.Errorf("invalid remember_me in OIDC state: %w", err)
+	}
+
+	return &repository.OIDCAuthState{
+		Provider:     fields[oidcStateFieldProvider],
+		Nonce:        fields[oidcStateFieldNonce],
+		CodeVerifier: fields[oidcStateFieldCodeVerifier],
+		RememberMe:   rememberMe,
+	}, nil
+}
+
+// oidcStateKey は認可リクエストの状態を保存するキーを返す
+func oidcStateKey(state string) string {
+	return fmt.Sprintf("oidc_state:%s", state)
+}
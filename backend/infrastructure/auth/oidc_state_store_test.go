@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+func TestOIDCStateStore_CreateAndConsume(t *testing.T) {
+	client := setupTestRedis(t)
+	defer client.Close()
+
+	store := NewOIDCStateStore(client)
+	ctx := context.Background()
+	authState := repository.OIDCAuthState{
+		Provider:     "google",
+		Nonce:        "test-nonce",
+		CodeVerifier: "test-code-verifier",
+		RememberMe:   true,
+	}
+
+	state, err := store.Create(ctx, authState, 10*time.Minute)
+	require.NoError(t, err)
+	assert.NotEmpty(t, state)
+
+	// Verify the key expires
+	ttl, err := client.TTL(ctx, oidcStateKey(state)).Result()
+	require.NoError(t, err)
+	assert.Greater(t, ttl, 9*time.Minute)
+	assert.LessOrEqual(t, ttl, 10*time.Minute)
+
+	consumed, err := store.Consume(ctx, state)
+	require.NoError(t, err)
+	require.NotNil(t, consumed)
+	assert.Equal(t, authState, *consumed)
+
+	// The state can be consumed only once
+	consumed, err = store.Consume(ctx, state)
+	require.NoError(t, err)
+	assert.Nil(t, consumed)
+}
+
+func TestOIDCStateStore_Consume_NotFound(t *testing.T) {
+	client := setupTestRedis(t)
+	defer client.Close()
+
+	store := NewOIDCStateStore(client)
+
+	consumed, err := store.Consume(context.Background(), uuid.New().String())
+	require.NoError(t, err)
+	assert.Nil(t, consumed)
+}
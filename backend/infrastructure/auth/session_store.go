@@ -4,6 +4,7 @@ package auth
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,8 +12,33 @@ import (
 	"github.com/ucchy108/whiskey/backend/domain/repository"
 )
 
+// セッションハッシュのフィールド名
+const (
+	sessionFieldUserID            = "user_id"
+	sessionFieldCreatedAt         = "created_at"
+	sessionFieldLastSeenAt        = "last_seen_at"
+	sessionFieldUserAgent         = "user_agent"
+	sessionFieldIPAddress         = "ip_address"
+	sessionFieldTTL               = "ttl"
+	sessionFieldAbsoluteExpiresAt = "absolute_expires_at"
+)
+
+// touchScript はセッションが存在する場合のみ最終アクセス日時を更新する。
+// 存在確認と更新の間にセッションが失効した場合に、TTLのないハッシュが作られるのを防ぐ。
+var touchScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+redis.call("HSET", KEYS[1], ARGV[1], ARGV[2])
+return 1
+`)
+
 // SessionStore はRedisを使用してユーザーセッションを管理する。
 // repository.SessionRepositoryインターフェースを実装する。
+// セッション本体（session:{id}）はユーザーIDとメタデータ（作成日時・最終アクセス日時・
+// User-Agent・IPアドレス）を持つハッシュとして保存する。
+// 加えて、ユーザーごとのセッションIDの集合（user_sessions:{userID}）を保持し、
+// ユーザー単位での一覧取得・一括削除に使用する。
 type SessionStore struct {
 	client *redis.Client
 }
@@ -29,13 +55,37 @@ func NewSessionStore(client *redis.Client) *SessionStore {
 }
 
 // Create は指定されたユーザーIDに対して指定されたTTLで新しいセッションを作成する。
-// 一意なセッションIDを生成し、Redis内にマッピングを保存する。
+// 一意なセッションIDを生成し、ユーザーIDとクライアント情報、有効期限の設定をRedis内に保存する。
+// キーの有効期限はttlとabsoluteExpiresAtまでの残り時間の短い方になる。
 // 生成されたセッションIDを返し、操作が失敗した場合はエラーを返す。
-func (s *SessionStore) Create(ctx context.Context, userID uuid.UUID, ttl time.Duration) (string, error) {
+func (s *SessionStore) Create(ctx context.Context, userID uuid.UUID, ttl time.Duration, absoluteExpiresAt time.Time, metadata repository.SessionMetadata) (string, error) {
 	sessionID := uuid.New().String()
-	key := fmt.Sprintf("session:%s", sessionID)
+	key := sessionKey(sessionID)
+	userKey := userSessionsKey(userID)
+	now := time.Now().UTC().Format(time.RFC3339Nano)
 
-	err := s.client.Set(ctx, key, userID.String(), ttl).Err()
+	keyTTL := capTTL(ttl, absoluteExpiresAt)
+	if keyTTL <= 0 {
+		return "", fmt.Errorf("failed to create session: already expired")
+	}
+
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key,
+			sessionFieldUserID, userID.String(),
+			sessionFieldCreatedAt, now,
+			sessionFieldLastSeenAt, now,
+			sessionFieldUserAgent, metadata.UserAgent,
+			sessionFieldIPAddress, metadata.IPAddress,
+			sessionFieldTTL, ttl.String(),
+			sessionFieldAbsoluteExpiresAt, absoluteExpiresAt.UTC().Format(time.RFC3339Nano),
+		)
+		pipe.Expire(ctx, key, keyTTL)
+		pipe.SAdd(ctx, userKey, sessionID)
+		// 集合は最も長く残るセッションに合わせて期限を延ばす（NXは期限未設定時のみ、GTは延長時のみ適用）
+		pipe.ExpireNX(ctx, userKey, keyTTL)
+		pipe.ExpireGT(ctx, userKey, keyTTL)
+		return nil
+	})
 	if err != nil {
 		return "", fmt.Errorf("failed to create session: %w", err)
 	}
@@ -47,9 +97,9 @@ func (s *SessionStore) Create(ctx context.Context, userID uuid.UUID, ttl time.Du
 // セッションが有効で期限切れでない場合にユーザーIDを返す。
 // セッションが存在しない、期限切れ、または保存されたデータが不正な場合はuuid.Nilとエラーを返す。
 func (s *SessionStore) Get(ctx context.Context, sessionID string) (uuid.UUID, error) {
-	key := fmt.Sprintf("session:%s", sessionID)
+	key := sessionKey(sessionID)
 
-	userIDStr, err := s.client.Get(ctx, key).Result()
+	userIDStr, err := s.client.HGet(ctx, key, sessionFieldUserID).Result()
 	if err == redis.Nil {
 		return uuid.Nil, fmt.Errorf("session not found")
 	}
@@ -65,13 +115,108 @@ func (s *SessionStore) Get(ctx context.Context, sessionID string) (uuid.UUID, er
 	return userID, nil
 }
 
+// FindByID は指定されたセッションIDのセッションとメタデータをRedisから取得する。
+// セッションが存在しない、または期限切れの場合はnilを返す。
+func (s *SessionStore) FindByID(ctx context.Context, sessionID string) (*repository.Session, error) {
+	fields, err := s.client.HGetAll(ctx, sessionKey(sessionID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	return sessionFromFields(sessionID, fields)
+}
+
+// ListByUser は指定されたユーザーの有効なセッションを最終アクセス日時の新しい順に取得する。
+// 期限切れで集合に残っているセッションIDは、この時点で集合から取り除く。
+func (s *SessionStore) ListByUser(ctx context.Context, userID uuid.UUID) ([]*repository.Session, error) {
+	userKey := userSessionsKey(userID)
+
+	sessionIDs, err := s.client.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user sessions: %w", err)
+	}
+
+	sessions := make([]*repository.Session, 0, len(sessionIDs))
+	if len(sessionIDs) == 0 {
+		return sessions, nil
+	}
+
+	cmds := make([]*redis.MapStringStringCmd, len(sessionIDs))
+	_, err = s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, sessionID := range sessionIDs {
+			cmds[i] = pipe.HGetAll(ctx, sessionKey(sessionID))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list user sessions: %w", err)
+	}
+
+	var stale []interface{}
+	for i, cmd := range cmds {
+		fields := cmd.Val()
+		if len(fields) == 0 {
+			stale = append(stale, sessionIDs[i])
+			continue
+		}
+		session, err := sessionFromFields(sessionIDs[i], fields)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	if len(stale) > 0 {
+		if err := s.client.SRem(ctx, userKey, stale...).Err(); err != nil {
+			return nil, fmt.Errorf("failed to clean up user sessions: %w", err)
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+// Touch は指定されたセッションの最終アクセス日時を更新する。
+// セッションが存在しない場合、またはRedis操作が失敗した場合はエラーを返す。
+func (s *SessionStore) Touch(ctx context.Context, sessionID string, at time.Time) error {
+	updated, err := touchScript.Run(ctx, s.client,
+		[]string{sessionKey(sessionID)},
+		sessionFieldLastSeenAt, at.UTC().Format(time.RFC3339Nano),
+	).Int()
+	if err != nil {
+		return fmt.Errorf("failed to touch session: %w", err)
+	}
+	if updated == 0 {
+		return fmt.Errorf("session not found")
+	}
+
+	return nil
+}
+
 // Delete は指定されたセッションIDのセッションをRedisから削除する。
 // この操作は冪等であり、存在しないセッションを削除してもエラーを返さない。
 // Redis操作自体が失敗した場合のみエラーを返す。
 func (s *SessionStore) Delete(ctx context.Context, sessionID string) error {
-	key := fmt.Sprintf("session:%s", sessionID)
+	key := sessionKey(sessionID)
 
-	err := s.client.Del(ctx, key).Err()
+	userIDStr, err := s.client.HGet(ctx, key, sessionFieldUserID).Result()
+	if err != nil && err != redis.Nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		if userID, parseErr := uuid.Parse(userIDStr); parseErr == nil {
+			pipe.SRem(ctx, userSessionsKey(userID), sessionID)
+		}
+		return nil
+	})
 	if err != nil {
 		return fmt.Errorf("failed to delete session: %w", err)
 	}
@@ -79,24 +224,154 @@ func (s *SessionStore) Delete(ctx context.Context, sessionID string) error {
 	return nil
 }
 
+// DeleteAllForUser は指定されたユーザーの全セッションをRedisから削除する。
+// ユーザーごとのセッションIDの集合を元に削除するため、他のユーザーのセッションには影響しない。
+// セッションが1件もない場合もエラーを返さない。
+func (s *SessionStore) DeleteAllForUser(ctx context.Context, userID uuid.UUID) error {
+	userKey := userSessionsKey(userID)
+
+	sessionIDs, err := s.client.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to list user sessions: %w", err)
+	}
+
+	keys := make([]string, 0, len(sessionIDs)+1)
+	for _, sessionID := range sessionIDs {
+		keys = append(keys, sessionKey(sessionID))
+	}
+	keys = append(keys, userKey)
+
+	if err := s.client.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("failed to delete user sessions: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteOthers は指定されたユーザーのセッションのうち、keepSessionID以外を全てRedisから削除する。
+// 「他の端末からログアウト」やパスワード変更時に、現在のセッションを残して使用する。
+func (s *SessionStore) DeleteOthers(ctx context.Context, userID uuid.UUID, keepSessionID string) error {
+	userKey := userSessionsKey(userID)
+
+	sessionIDs, err := s.client.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to list user sessions: %w", err)
+	}
+
+	keys := make([]string, 0, len(sessionIDs))
+	members := make([]interface{}, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		if sessionID == keepSessionID {
+			continue
+		}
+		keys = append(keys, sessionKey(sessionID))
+		members = append(members, sessionID)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, keys...)
+		pipe.SRem(ctx, userKey, members...)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete user sessions: %w", err)
+	}
+
+	return nil
+}
+
 // Extend は指定されたセッションIDのセッションのTTL（有効期限）を延長する。
-// まずセッションの存在を確認し、その後有効期限を更新する。
+// 延長後の有効期限はセッションの絶対的な有効期限を超えないよう切り詰める。
 // セッションが存在しない場合、またはRedis操作が失敗した場合はエラーを返す。
 func (s *SessionStore) Extend(ctx context.Context, sessionID string, ttl time.Duration) error {
-	key := fmt.Sprintf("session:%s", sessionID)
+	key := sessionKey(sessionID)
 
-	exists, err := s.client.Exists(ctx, key).Result()
+	session, err := s.FindByID(ctx, sessionID)
 	if err != nil {
-		return fmt.Errorf("failed to check session existence: %w", err)
+		return fmt.Errorf("failed to extend session: %w", err)
 	}
-	if exists == 0 {
+	if session == nil {
 		return fmt.Errorf("session not found")
 	}
 
-	err = s.client.Expire(ctx, key, ttl).Err()
+	ttl = capTTL(ttl, session.AbsoluteExpiresAt)
+	if ttl <= 0 {
+		return fmt.Errorf("session not found")
+	}
+
+	// Expireは存在しないキーに対してfalseを返すため、取得後に失効した場合も検出できる
+	extended, err := s.client.Expire(ctx, key, ttl).Result()
 	if err != nil {
 		return fmt.Errorf("failed to extend session: %w", err)
 	}
+	if !extended {
+		return fmt.Errorf("session not found")
+	}
+
+	// ユーザーごとの集合がセッションより先に失効しないよう期限を揃える
+	if err := s.client.ExpireGT(ctx, userSessionsKey(session.UserID), ttl).Err(); err != nil {
+		return fmt.Errorf("failed to extend session: %w", err)
+	}
 
 	return nil
 }
+
+// capTTL はttlを絶対的な有効期限までの残り時間で切り詰める。
+// absoluteExpiresAtがゼロ値の場合は上限なしとして扱う。
+func capTTL(ttl time.Duration, absoluteExpiresAt time.Time) time.Duration {
+	if absoluteExpiresAt.IsZero() {
+		return ttl
+	}
+	if remaining := time.Until(absoluteExpiresAt); remaining < ttl {
+		return remaining
+	}
+	return ttl
+}
+
+// sessionKey はセッション本体のキーを返す
+func sessionKey(sessionID string) string {
+	return fmt.Sprintf("session:%s", sessionID)
+}
+
+// userSessionsKey はユーザーごとのセッションIDの集合のキーを返す
+func userSessionsKey(userID uuid.UUID) string {
+	return fmt.Sprintf("user_sessions:%s", userID.String())
+}
+
+// sessionFromFields はRedisのハッシュフィールドからセッションを組み立てる
+func sessionFromFields(sessionID string, fields map[string]string) (*repository.Session, error) {
+	userID, err := uuid.Parse(fields[sessionFieldUserID])
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID in session: %w", err)
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, fields[sessionFieldCreatedAt])
+	if err != nil {
+		return nil, fmt.Errorf("invalid created_at in session: %w", err)
+	}
+	lastSeenAt, err := time.Parse(time.RFC3339Nano, fields[sessionFieldLastSeenAt])
+	if err != nil {
+		return nil, fmt.Errorf("invalid last_seen_at in session: %w", err)
+	}
+	ttl, err := time.ParseDuration(fields[sessionFieldTTL])
+	if err != nil {
+		return nil, fmt.Errorf("invalid ttl in session: %w", err)
+	}
+	absoluteExpiresAt, err := time.Parse(time.RFC3339Nano, fields[sessionFieldAbsoluteExpiresAt])
+	if err != nil {
+		return nil, fmt.Errorf("invalid absolute_expires_at in session: %w", err)
+	}
+
+	return &repository.Session{
+		ID:                sessionID,
+		UserID:            userID,
+		CreatedAt:         createdAt,
+		LastSeenAt:        lastSeenAt,
+		UserAgent:         fields[sessionFieldUserAgent],
+		IPAddress:         fields[sessionFieldIPAddress],
+		TTL:               ttl,
+		AbsoluteExpiresAt: absoluteExpiresAt,
+	}, nil
+}
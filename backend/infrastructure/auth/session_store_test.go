@@ -10,8 +10,20 @@ import (
 	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
 )
 
+// testSessionMetadata はテストで使用するセッションのクライアント情報
+var testSessionMetadata = repository.SessionMetadata{
+	UserAgent: "Mozilla/5.0 (test)",
+	IPAddress: "192.0.2.1",
+}
+
+// testAbsoluteExpiresAt はテストで使用するセッションの絶対的な有効期限を返す
+func testAbsoluteExpiresAt() time.Time {
+	return time.Now().Add(24 * time.Hour)
+}
+
 // setupTestRedis creates a test Redis client.
 // Redis のアドレスは環境変数 REDIS_ADDR で指定可能（デフォルト: localhost:6379）。
 func setupTestRedis(t *testing.T) *redis.Client {
@@ -49,7 +61,7 @@ func TestSessionStore_Create(t *testing.T) {
 	userID := uuid.New()
 	ttl := 1 * time.Hour
 
-	sessionID, err := store.Create(ctx, userID, ttl)
+	sessionID, err := store.Create(ctx, userID, ttl, testAbsoluteExpiresAt(), testSessionMetadata)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, sessionID)
@@ -78,7 +90,7 @@ func TestSessionStore_Get(t *testing.T) {
 			name: "valid session",
 			setupFunc: func() string {
 				userID := uuid.New()
-				sessionID, _ := store.Create(ctx, userID, 1*time.Hour)
+				sessionID, _ := store.Create(ctx, userID, 1*time.Hour, testAbsoluteExpiresAt(), testSessionMetadata)
 				return sessionID
 			},
 			wantErr: false,
@@ -95,7 +107,7 @@ func TestSessionStore_Get(t *testing.T) {
 			name: "expired session",
 			setupFunc: func() string {
 				userID := uuid.New()
-				sessionID, _ := store.Create(ctx, userID, 1*time.Millisecond)
+				sessionID, _ := store.Create(ctx, userID, 1*time.Millisecond, testAbsoluteExpiresAt(), testSessionMetadata)
 				time.Sleep(10 * time.Millisecond) // Wait for expiration
 				return sessionID
 			},
@@ -133,7 +145,7 @@ func TestSessionStore_Delete(t *testing.T) {
 
 	// Create a session
 	userID := uuid.New()
-	sessionID, err := store.Create(ctx, userID, 1*time.Hour)
+	sessionID, err := store.Create(ctx, userID, 1*time.Hour, testAbsoluteExpiresAt(), testSessionMetadata)
 	require.NoError(t, err)
 
 	// Verify it exists
@@ -172,7 +184,7 @@ func TestSessionStore_Extend(t *testing.T) {
 
 	// Create a session with short TTL
 	userID := uuid.New()
-	sessionID, err := store.Create(ctx, userID, 100*time.Millisecond)
+	sessionID, err := store.Create(ctx, userID, 100*time.Millisecond, testAbsoluteExpiresAt(), testSessionMetadata)
 	require.NoError(t, err)
 
 	// Extend the session
@@ -201,3 +213,239 @@ func TestSessionStore_Extend_NonExistent(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "session not found")
 }
+
+func TestSessionStore_DeleteAllForUser(t *testing.T) {
+	client := setupTestRedis(t)
+	defer client.Close()
+
+	store := NewSessionStore(client)
+	ctx := context.Background()
+
+	// Create two sessions for the target user and one for another user
+	userID := uuid.New()
+	otherUserID := uuid.New()
+	sessionID1, err := store.Create(ctx, userID, 1*time.Hour, testAbsoluteExpiresAt(), testSessionMetadata)
+	require.NoError(t, err)
+	sessionID2, err := store.Create(ctx, userID, 1*time.Hour, testAbsoluteExpiresAt(), testSessionMetadata)
+	require.NoError(t, err)
+	otherSessionID, err := store.Create(ctx, otherUserID, 1*time.Hour, testAbsoluteExpiresAt(), testSessionMetadata)
+	require.NoError(t, err)
+
+	err = store.DeleteAllForUser(ctx, userID)
+	assert.NoError(t, err)
+
+	// All sessions of the target user should be gone
+	_, err = store.Get(ctx, sessionID1)
+	assert.Error(t, err)
+	_, err = store.Get(ctx, sessionID2)
+	assert.Error(t, err)
+
+	// Other user's session should remain
+	retrievedUserID, err := store.Get(ctx, otherSessionID)
+	assert.NoError(t, err)
+	assert.Equal(t, otherUserID, retrievedUserID)
+}
+
+func TestSessionStore_DeleteAllForUser_NoSessions(t *testing.T) {
+	client := setupTestRedis(t)
+	defer client.Close()
+
+	store := NewSessionStore(client)
+	ctx := context.Background()
+
+	// Deleting sessions of a user without sessions should not error
+	err := store.DeleteAllForUser(ctx, uuid.New())
+	assert.NoError(t, err)
+}
+
+func TestSessionStore_FindByID(t *testing.T) {
+	client := setupTestRedis(t)
+	defer client.Close()
+
+	store := NewSessionStore(client)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	sessionID, err := store.Create(ctx, userID, 1*time.Hour, testAbsoluteExpiresAt(), testSessionMetadata)
+	require.NoError(t, err)
+
+	session, err := store.FindByID(ctx, sessionID)
+	require.NoError(t, err)
+	require.NotNil(t, session)
+	assert.Equal(t, sessionID, session.ID)
+	assert.Equal(t, userID, session.UserID)
+	assert.Equal(t, testSessionMetadata.UserAgent, session.UserAgent)
+	assert.Equal(t, testSessionMetadata.IPAddress, session.IPAddress)
+	assert.WithinDuration(t, time.Now(), session.CreatedAt, 5*time.Second)
+	assert.Equal(t, session.CreatedAt, session.LastSeenAt)
+
+	// Non-existent session returns nil without error
+	session, err = store.FindByID(ctx, uuid.New().String())
+	assert.NoError(t, err)
+	assert.Nil(t, session)
+}
+
+func TestSessionStore_ListByUser(t *testing.T) {
+	client := setupTestRedis(t)
+	defer client.Close()
+
+	store := NewSessionStore(client)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	olderID, err := store.Create(ctx, userID, 1*time.Hour, testAbsoluteExpiresAt(), testSessionMetadata)
+	require.NoError(t, err)
+	newerID, err := store.Create(ctx, userID, 1*time.Hour, testAbsoluteExpiresAt(), testSessionMetadata)
+	require.NoError(t, err)
+	expiredID, err := store.Create(ctx, userID, 1*time.Millisecond, testAbsoluteExpiresAt(), testSessionMetadata)
+	require.NoError(t, err)
+	_, err = store.Create(ctx, uuid.New(), 1*time.Hour, testAbsoluteExpiresAt(), testSessionMetadata)
+	require.NoError(t, err)
+
+	// Make the older session the most recently used one
+	require.NoError(t, store.Touch(ctx, olderID, time.Now().Add(1*time.Minute)))
+	time.Sleep(10 * time.Millisecond)
+
+	sessions, err := store.ListByUser(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, olderID, sessions[0].ID)
+	assert.Equal(t, newerID, sessions[1].ID)
+
+	// Expired session ID should be removed from the user's set
+	isMember, err := client.SIsMember(ctx, userSessionsKey(userID), expiredID).Result()
+	require.NoError(t, err)
+	assert.False(t, isMember)
+
+	// User without sessions gets an empty slice
+	sessions, err = store.ListByUser(ctx, uuid.New())
+	assert.NoError(t, err)
+	assert.Empty(t, sessions)
+}
+
+func TestSessionStore_Touch(t *testing.T) {
+	client := setupTestRedis(t)
+	defer client.Close()
+
+	store := NewSessionStore(client)
+	ctx := context.Background()
+
+	sessionID, err := store.Create(ctx, uuid.New(), 1*time.Hour, testAbsoluteExpiresAt(), testSessionMetadata)
+	require.NoError(t, err)
+
+	at := time.Now().Add(5 * time.Minute)
+	require.NoError(t, store.Touch(ctx, sessionID, at))
+
+	session, err := store.FindByID(ctx, sessionID)
+	require.NoError(t, err)
+	assert.True(t, at.Equal(session.LastSeenAt))
+
+	// TTL must be preserved
+	ttl, err := client.TTL(ctx, sessionKey(sessionID)).Result()
+	require.NoError(t, err)
+	assert.Greater(t, ttl, time.Duration(0))
+}
+
+func TestSessionStore_Touch_NonExistent(t *testing.T) {
+	client := setupTestRedis(t)
+	defer client.Close()
+
+	store := NewSessionStore(client)
+	ctx := context.Background()
+
+	sessionID := uuid.New().String()
+	err := store.Touch(ctx, sessionID, time.Now())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "session not found")
+
+	// Touching must not create a session
+	exists, err := client.Exists(ctx, sessionKey(sessionID)).Result()
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), exists)
+}
+
+func TestSessionStore_DeleteOthers(t *testing.T) {
+	client := setupTestRedis(t)
+	defer client.Close()
+
+	store := NewSessionStore(client)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	otherUserID := uuid.New()
+	currentID, err := store.Create(ctx, userID, 1*time.Hour, testAbsoluteExpiresAt(), testSessionMetadata)
+	require.NoError(t, err)
+	staleID, err := store.Create(ctx, userID, 1*time.Hour, testAbsoluteExpiresAt(), testSessionMetadata)
+	require.NoError(t, err)
+	otherSessionID, err := store.Create(ctx, otherUserID, 1*time.Hour, testAbsoluteExpiresAt(), testSessionMetadata)
+	require.NoError(t, err)
+
+	err = store.DeleteOthers(ctx, userID, currentID)
+	assert.NoError(t, err)
+
+	// Current session should remain
+	retrievedUserID, err := store.Get(ctx, currentID)
+	assert.NoError(t, err)
+	assert.Equal(t, userID, retrievedUserID)
+
+	// Other sessions of the user should be gone
+	_, err = store.Get(ctx, staleID)
+	assert.Error(t, err)
+	sessions, err := store.ListByUser(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, currentID, sessions[0].ID)
+
+	// Other user's session should remain
+	_, err = store.Get(ctx, otherSessionID)
+	assert.NoError(t, err)
+
+	// Only the current session left: no-op
+	assert.NoError(t, store.DeleteOthers(ctx, userID, currentID))
+}
+
+func TestSessionStore_Create_CappedByAbsoluteExpiry(t *testing.T) {
+	client := setupTestRedis(t)
+	defer client.Close()
+
+	store := NewSessionStore(client)
+	ctx := context.Background()
+
+	absoluteExpiresAt := time.Now().Add(10 * time.Minute)
+	sessionID, err := store.Create(ctx, uuid.New(), 1*time.Hour, absoluteExpiresAt, testSessionMetadata)
+	require.NoError(t, err)
+
+	// Key TTL never exceeds the absolute expiry
+	ttl, err := client.TTL(ctx, sessionKey(sessionID)).Result()
+	require.NoError(t, err)
+	assert.LessOrEqual(t, ttl, 10*time.Minute)
+
+	// The requested TTL and absolute expiry are kept for later extension
+	session, err := store.FindByID(ctx, sessionID)
+	require.NoError(t, err)
+	assert.Equal(t, 1*time.Hour, session.TTL)
+	assert.WithinDuration(t, absoluteExpiresAt, session.AbsoluteExpiresAt, time.Millisecond)
+
+	// Already expired absolute expiry is rejected
+	_, err = store.Create(ctx, uuid.New(), 1*time.Hour, time.Now().Add(-1*time.Second), testSessionMetadata)
+	assert.Error(t, err)
+}
+
+func TestSessionStore_Extend_CappedByAbsoluteExpiry(t *testing.T) {
+	client := setupTestRedis(t)
+	defer client.Close()
+
+	store := NewSessionStore(client)
+	ctx := context.Background()
+
+	sessionID, err := store.Create(ctx, uuid.New(), 1*time.Minute, time.Now().Add(10*time.Minute), testSessionMetadata)
+	require.NoError(t, err)
+
+	err = store.Extend(ctx, sessionID, 1*time.Hour)
+	assert.NoError(t, err)
+
+	ttl, err := client.TTL(ctx, sessionKey(sessionID)).Result()
+	require.NoError(t, err)
+	assert.Greater(t, ttl, 1*time.Minute)
+	assert.LessOrEqual(t, ttl, 10*time.Minute)
+}
@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+// 二要素認証チャレンジのハッシュのフィールド名
+const (
+	challengeFieldUserID     = "user_id"
+	challengeFieldRememberMe = "remember_me"
+)
+
+// TwoFactorChallengeStore はRedisを使用して二要素認証の保留中のログインを管理する。
+// repository.TwoFactorChallengeRepositoryインターフェースを実装する。
+// チャレンジ（two_factor_challenge:{id}）はユーザーIDと「ログインしたままにする」の指定を持つハッシュとして保存し、
+// 有効期限はRedisのキーの有効期限で管理する。
+type TwoFactorChallengeStore struct {
+	client *redis.Client
+}
+
+// TwoFactorChallengeStoreがrepository.TwoFactorChallengeRepositoryを実装していることをコンパイル時にチェック
+var _ repository.TwoFactorChallengeRepository = (*TwoFactorChallengeStore)(nil)
+
+// NewTwoFactorChallengeStore は指定されたRedisクライアントを使用して新しいTwoFactorChallengeStoreインスタンスを生成する。
+func NewTwoFactorChallengeStore(client *redis.Client) *TwoFactorChallengeStore {
+	return &TwoFactorChallengeStore{
+		client: client,
+	}
+}
+
+// Create は指定されたユーザーIDに対してttlで失効するチャレンジを作成し、チャレンジIDを返す。
+func (s *TwoFactorChallengeStore) Create(ctx context.Context, userID uuid.UUID, rememberMe bool, ttl time.Duration) (string, error) {
+	challengeID := uuid.New().String()
+	key := twoFactorChallengeKey(challengeID)
+
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key,
+			challengeFieldUserID, userID.String(),
+			challengeFieldRememberMe, strconv.FormatBool(rememberMe),
+		)
+		pipe.Expire(ctx, key, ttl)
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create two-factor challenge: %w", err)
+	}
+
+	return challengeID, nil
+}
+
+// FindByID は指定されたチャレンジIDのチャレンジをRedisから取得する。
+// チャレンジが存在しない、または期限切れの場合はnilを返す。
+func (s *TwoFactorChallengeStore) FindByID(ctx context.Context, challengeID string) (*repository.TwoFactorChallenge, error) {
+	fields, err := s.client.HGetAll(ctx, twoFactorChallengeKey(challengeID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get two-factor challenge: %w", err)
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	userID, err := uuid.Parse(fields[challengeFieldUserID])
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID in two-factor challenge: %w", err)
+	}
+
+	rememberMe, err := strconv.ParseBool(fields[challengeFieldRememberMe])
+	if err != nil {
+		return nil, fmt.Errorf("invalid remember_me in two-factor challenge: %w", err)
+	}
+
+	return &repository.TwoFactorChallenge{
+		ID:         challengeID,
+		UserID:     userID,
+		RememberMe: rememberMe,
+	}, nil
+}
+
+// Delete は指定されたチャレンジIDのチャレンジをRedisから削除する。
+// チャレンジが存在しない場合もエラーは返さない。
+func (s *TwoFactorChallengeStore) Delete(ctx context.Context, challengeID string) error {
+	if err := s.client.Del(ctx, twoFactorChallengeKey(challengeID)).Err(); err != nil {
+		return fmt.Errorf("failed to delete two-factor challenge: %w", err)
+	}
+	return nil
+}
+
+// twoFactorChallengeKey はチャレンジを保存するキーを返す
+func twoFactorChallengeKey(challengeID string) string {
+	return fmt.Sprintf("two_factor_challenge:%s", challengeID)
+}
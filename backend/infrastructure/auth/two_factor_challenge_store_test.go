@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTwoFactorChallengeStore_Create(t *testing.T) {
+	client := setupTestRedis(t)
+	defer client.Close()
+
+	store := NewTwoFactorChallengeStore(client)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	challengeID, err := store.Create(ctx, userID, true, 5*time.Minute)
+	require.NoError(t, err)
+	assert.NotEmpty(t, challengeID)
+
+	// Verify the key expires
+	ttl, err := client.TTL(ctx, twoFactorChallengeKey(challengeID)).Result()
+	require.NoError(t, err)
+	assert.Greater(t, ttl, 4*time.Minute)
+	assert.LessOrEqual(t, ttl, 5*time.Minute)
+
+	challenge, err := store.FindByID(ctx, challengeID)
+	require.NoError(t, err)
+	require.NotNil(t, challenge)
+	assert.Equal(t, challengeID, challenge.ID)
+	assert.Equal(t, userID, challenge.UserID)
+	assert.True(t, challenge.RememberMe)
+}
+
+func TestTwoFactorChallengeStore_FindByID_NotFound(t *testing.T) {
+	client := setupTestRedis(t)
+	defer client.Close()
+
+	store := NewTwoFactorChallengeStore(client)
+
+	challenge, err := store.FindByID(context.Background(), uuid.New().String())
+	require.NoError(t, err)
+	assert.Nil(t, challenge)
+}
+
+func TestTwoFactorChallengeStore_Delete(t *testing.T) {
+	client := setupTestRedis(t)
+	defer client.Close()
+
+	store := NewTwoFactorChallengeStore(client)
+	ctx := context.Background()
+
+	challengeID, err := store.Create(ctx, uuid.New(), false, 5*time.Minute)
+	require.NoError(t, err)
+
+	err = store.Delete(ctx, challengeID)
+	require.NoError(t, err)
+
+	challenge, err := store.FindByID(ctx, challengeID)
+	require.NoError(t, err)
+	assert.Nil(t, challenge)
+
+	// Deleting a missing challenge is not an error
+	err = store.Delete(ctx, challengeID)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,272 @@
+// Package csvimport は他のアプリからエクスポートされたワークアウト履歴のCSVファイルを読み取る実装と、
+// 読み取り可能なwhiskey形式のCSVを書き出す実装を提供する。
+package csvimport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+// 対応しているファイル形式
+const (
+	FormatStrong  = "strong"
+	FormatHevy    = "hevy"
+	FormatWhiskey = "whiskey"
+)
+
+// errSkipRow は行をエラーとせずに読み飛ばす場合に parseRow が返すエラー
+var errSkipRow = errors.New("skip row")
+
+// Parsers は対応している全てのファイル形式のパーサーをファイル形式名をキーとして返す。
+func Parsers() map[string]repository.WorkoutHistoryParser {
+	return map[string]repository.WorkoutHistoryParser{
+		FormatStrong:  NewStrongParser(),
+		FormatHevy:    NewHevyParser(),
+		FormatWhiskey: NewWhiskeyParser(),
+	}
+}
+
+// NewExportWriter はwhiskey形式のCSVを書き出すWorkoutExportWriterを生成する。
+// repository.WorkoutExportWriterFactory としてInterface層に渡す。
+func NewExportWriter(w io.Writer, loc *time.Location) repository.WorkoutExportWriter {
+	return NewWhiskeyWriter(w, loc)
+}
+
+// record はCSVの1行を列名で参照できるようにしたもの
+type record struct {
+	fields  []string
+	columns map[string]int
+}
+
+// get は列の値を前後の空白を除いて返す。列が存在しない場合は空文字を返す。
+func (r record) get(column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(r.fields) {
+		return ""
+	}
+	return strings.TrimSpace(r.fields[i])
+}
+
+// has はヘッダーに列が存在するかどうかを返す。
+func (r record) has(column string) bool {
+	_, ok := r.columns[column]
+	return ok
+}
+
+// readRows はヘッダー付きのCSVを読み取り、各行をparseRowで変換する。
+// requiredは必須の列名で、"a|b" のように指定した場合はいずれかの列があればよい。
+// 列名は大文字小文字を区別しない。区切り文字はヘッダー行から判別する（カンマまたはセミコロン）。
+// 読み取れない行は行ごとのエラーとして報告し、残りの行の読み取りを続ける。
+func readRows(r io.Reader, required []string, parseRow func(record) (repository.ImportedSetRow, error)) ([]repository.ImportedSetRow, []repository.ImportRowError, error) {
+	br := bufio.NewReader(r)
+	reader := csv.NewReader(br)
+	reader.Comma = detectDelimiter(br)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, errors.New("file is empty")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			// Excelなどが付与するBOMを取り除く
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, column := range required {
+		if !hasAnyColumn(columns, column) {
+			return nil, nil, fmt.Errorf("missing required column %s", strings.Join(quoteAll(strings.Split(column, "|")), " or "))
+		}
+	}
+
+	var rows []repository.ImportedSetRow
+	var rowErrors []repository.ImportRowError
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rowErrors = append(rowErrors, repository.ImportRowError{Line: parseErr.StartLine, Message: parseErr.Err.Error()})
+				continue
+			}
+			return nil, nil, fmt.Errorf("failed to read file: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		if isBlank(fields) {
+			continue
+		}
+
+		row, err := parseRow(record{fields: fields, columns: columns})
+		if errors.Is(err, errSkipRow) {
+			continue
+		}
+		if err != nil {
+			rowErrors = append(rowErrors, repository.ImportRowError{Line: line, Message: err.Error()})
+			continue
+		}
+		row.Line = line
+		rows = append(rows, row)
+	}
+
+	return rows, rowErrors, nil
+}
+
+// hasAnyColumn は "|" 区切りで指定した列のいずれかがヘッダーに存在するかどうかを返す。
+func hasAnyColumn(columns map[string]int, column string) bool {
+	for _, name := range strings.Split(column, "|") {
+		if _, ok := columns[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// quoteAll は各値を引用符で囲む。
+func quoteAll(values []string) []string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = strconv.Quote(v)
+	}
+	return quoted
+}
+
+// detectDelimiter はヘッダー行に含まれる区切り文字を判別する。
+// セミコロンのみを含む場合はセミコロン、それ以外はカンマとする。
+func detectDelimiter(br *bufio.Reader) rune {
+	peeked, _ := br.Peek(br.Size())
+	if i := bytes.IndexByte(peeked, '\n'); i >= 0 {
+		peeked = peeked[:i]
+	}
+	if bytes.IndexByte(peeked, ';') >= 0 && bytes.IndexByte(peeked, ',') < 0 {
+		return ';'
+	}
+	return ','
+}
+
+// isBlank は全ての列が空の行かどうかを返す。
+func isBlank(fields []string) bool {
+	for _, f := range fields {
+		if strings.TrimSpace(f) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// parseReps はレップ数を読み取る。空の場合は0とする。
+func parseReps(s string) (int32, error) {
+	if s == "" {
+		return 0, nil
+	}
+	// 小数で記録されている場合（例: "10.0"）も受け付ける
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f != float64(int32(f)) {
+		return 0, fmt.Errorf("invalid reps %q", s)
+	}
+	return int32(f), nil
+}
+
+// parseWeight は重量を読み取る。空の場合は0（自重）とする。
+func parseWeight(s string) (float64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid weight %q", s)
+	}
+	return f, nil
+}
+
+// parseOptionalSeconds は秒数を読み取る。空または0の場合はnilを返す。
+func parseOptionalSeconds(s string) (*int32, error) {
+	if s == "" {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid duration %q", s)
+	}
+	if f == 0 {
+		return nil, nil
+	}
+	seconds := int32(f)
+	return &seconds, nil
+}
+
+// parseOptionalRPE はRPEを読み取る。空または0の場合はnilを返す。
+func parseOptionalRPE(s string) (*float64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rpe %q", s)
+	}
+	if f == 0 {
+		return nil, nil
+	}
+	return &f, nil
+}
+
+// parseOptionalRIR はRIRを読み取る。空の場合はnilを返す。
+func parseOptionalRIR(s string) (*int32, error) {
+	if s == "" {
+		return nil, nil
+	}
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rir %q", s)
+	}
+	rir := int32(n)
+	return &rir, nil
+}
+
+// optionalString は空文字の場合にnilを返す。
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// joinMemo は空でない値を改行で連結する。全て空の場合はnilを返す。
+func joinMemo(values ...string) *string {
+	var parts []string
+	for _, v := range values {
+		if v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return optionalString(strings.Join(parts, "\n"))
+}
+
+// wallClock は時刻の壁時計の値をそのままUTCの時刻として返す。
+// ファイルの日時はユーザーのタイムゾーンの時刻として記録されているため、Usecase層で解釈し直す。
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+}
+
+// dateOf は時刻の日付部分（0時0分）を返す。
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
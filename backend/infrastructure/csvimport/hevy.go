@@ -0,0 +1,101 @@
+package csvimport
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+// hevyTimeLayout はHevyのCSVのstart_time・end_time列の形式
+const hevyTimeLayout = "2 Jan 2006, 15:04"
+
+// hevySetTypes はHevyのCSVのset_type列の値とセットの種類の対応
+var hevySetTypes = map[string]entity.SetType{
+	"normal":  entity.SetTypeWorking,
+	"warmup":  entity.SetTypeWarmUp,
+	"dropset": entity.SetTypeDrop,
+	"failure": entity.SetTypeFailure,
+}
+
+// HevyParser はHevyアプリからエクスポートしたCSVを読み取る。
+// repository.WorkoutHistoryParserインターフェースを実装する。
+//
+// 列: title, start_time, end_time, description, exercise_title, superset_id, exercise_notes,
+// set_index, set_type, weight_kg（またはweight_lbs）, reps, distance_km, duration_seconds, rpe
+type HevyParser struct{}
+
+// NewHevyParser はHevyParserの新しいインスタンスを生成する。
+func NewHevyParser() *HevyParser {
+	return &HevyParser{}
+}
+
+// Parse はHevyのCSVを読み取る。
+// 同じstart_timeの行を1つのワークアウトとする。重量の単位は重量の列名（weight_kg / weight_lbs）から判別する。
+// 種目ごとのメモ（exercise_notes）は各ワークアウトのその種目の最初のセットのメモとする。
+// set_typeが未知の値の場合は通常のセットとする。
+func (p *HevyParser) Parse(r io.Reader) ([]repository.ImportedSetRow, []repository.ImportRowError, error) {
+	// 直前に読み取った行のワークアウトと種目（種目ごとのメモを最初のセットにのみ付けるため）
+	var lastWorkout, lastExercise string
+
+	required := []string{"start_time", "exercise_title", "reps", "weight_kg|weight_lbs"}
+	return readRows(r, required, func(rec record) (repository.ImportedSetRow, error) {
+		weightColumn, unit := "weight_kg", repository.WeightUnitKg
+		if !rec.has(weightColumn) {
+			weightColumn, unit = "weight_lbs", repository.WeightUnitLb
+		}
+
+		startedAt, err := time.Parse(hevyTimeLayout, rec.get("start_time"))
+		if err != nil {
+			return repository.ImportedSetRow{}, fmt.Errorf("invalid start_time %q", rec.get("start_time"))
+		}
+
+		exerciseName := rec.get("exercise_title")
+		if exerciseName == "" {
+			return repository.ImportedSetRow{}, fmt.Errorf("exercise_title is empty")
+		}
+
+		reps, err := parseReps(rec.get("reps"))
+		if err != nil {
+			return repository.ImportedSetRow{}, err
+		}
+		weight, err := parseWeight(rec.get(weightColumn))
+		if err != nil {
+			return repository.ImportedSetRow{}, err
+		}
+		seconds, err := parseOptionalSeconds(rec.get("duration_seconds"))
+		if err != nil {
+			return repository.ImportedSetRow{}, err
+		}
+		rpe, err := parseOptionalRPE(rec.get("rpe"))
+		if err != nil {
+			return repository.ImportedSetRow{}, err
+		}
+
+		row := repository.ImportedSetRow{
+			WorkoutKey:      rec.get("start_time"),
+			Date:            dateOf(startedAt),
+			StartedAt:       &startedAt,
+			Memo:            joinMemo(rec.get("title"), rec.get("description")),
+			ExerciseName:    exerciseName,
+			Reps:            reps,
+			Weight:          weight,
+			WeightUnit:      unit,
+			DurationSeconds: seconds,
+			RPE:             rpe,
+		}
+		if setType, ok := hevySetTypes[rec.get("set_type")]; ok {
+			row.SetType = &setType
+		}
+		if endedAt, err := time.Parse(hevyTimeLayout, rec.get("end_time")); err == nil {
+			row.EndedAt = &endedAt
+		}
+		if row.WorkoutKey != lastWorkout || exerciseName != lastExercise {
+			row.Notes = optionalString(rec.get("exercise_notes"))
+		}
+		lastWorkout, lastExercise = row.WorkoutKey, exerciseName
+		return row, nil
+	})
+}
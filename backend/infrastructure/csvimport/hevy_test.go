@@ -0,0 +1,104 @@
+package csvimport
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+func TestHevyParser_Parse(t *testing.T) {
+	input := strings.Join([]string{
+		`"title","start_time","end_time","description","exercise_title","superset_id","exercise_notes","set_index","set_type","weight_kg","reps","distance_km","duration_seconds","rpe"`,
+		`"Upper","15 Jan 2023, 08:30","15 Jan 2023, 09:40","","Bench Press (Barbell)","","Pause reps","0","warmup","40","10","","",""`,
+		`"Upper","15 Jan 2023, 08:30","15 Jan 2023, 09:40","","Bench Press (Barbell)","","Pause reps","1","normal","80","5","","","8.5"`,
+		`"Upper","15 Jan 2023, 08:30","15 Jan 2023, 09:40","","Pull Up","","","0","normal","","8","","",""`,
+		`"Upper","yesterday","","","Pull Up","","","1","normal","","8","","",""`,
+	}, "\n")
+
+	rows, rowErrors, err := NewHevyParser().Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("len(rows) = %d, want 3", len(rows))
+	}
+	if len(rowErrors) != 1 || rowErrors[0].Line != 5 {
+		t.Errorf("rowErrors = %v, want one error on line 5", rowErrors)
+	}
+
+	first := rows[0]
+	wantStart := time.Date(2023, 1, 15, 8, 30, 0, 0, time.UTC)
+	if first.StartedAt == nil || !first.StartedAt.Equal(wantStart) {
+		t.Errorf("StartedAt = %v, want %v", first.StartedAt, wantStart)
+	}
+	if first.EndedAt == nil || !first.EndedAt.Equal(wantStart.Add(70*time.Minute)) {
+		t.Errorf("EndedAt = %v, want %v", first.EndedAt, wantStart.Add(70*time.Minute))
+	}
+	if first.WeightUnit != repository.WeightUnitKg || first.Weight != 40 {
+		t.Errorf("weight = %v %s, want 40 kg", first.Weight, first.WeightUnit)
+	}
+	if first.Memo == nil || *first.Memo != "Upper" {
+		t.Errorf("Memo = %v, want Upper", first.Memo)
+	}
+
+	// 種目ごとのメモは最初のセットにのみ付ける
+	if first.Notes == nil || *first.Notes != "Pause reps" {
+		t.Errorf("Notes = %v, want Pause reps", first.Notes)
+	}
+	if rows[1].Notes != nil {
+		t.Errorf("Notes of the second set = %v, want nil", *rows[1].Notes)
+	}
+
+	// set_typeとrpeを読み取る
+	if first.SetType == nil || *first.SetType != entity.SetTypeWarmUp {
+		t.Errorf("SetType = %v, want warmup", first.SetType)
+	}
+	if first.RPE != nil {
+		t.Errorf("RPE = %v, want nil", *first.RPE)
+	}
+	if rows[1].SetType == nil || *rows[1].SetType != entity.SetTypeWorking {
+		t.Errorf("SetType = %v, want working", rows[1].SetType)
+	}
+	if rows[1].RPE == nil || *rows[1].RPE != 8.5 {
+		t.Errorf("RPE = %v, want 8.5", rows[1].RPE)
+	}
+
+	// 自重種目は重量0
+	if rows[2].Weight != 0 || rows[2].Reps != 8 {
+		t.Errorf("row = %+v, want bodyweight x8", rows[2])
+	}
+}
+
+func TestHevyParser_Parse_Pounds(t *testing.T) {
+	input := strings.Join([]string{
+		"title,start_time,end_time,exercise_title,set_index,weight_lbs,reps",
+		"Legs,3 Feb 2024, 18:05,,Squat (Barbell),0,225,5",
+	}, "\n")
+	// 日時にカンマを含むため引用符で囲む
+	input = strings.Replace(input, "3 Feb 2024, 18:05", `"3 Feb 2024, 18:05"`, 1)
+
+	rows, rowErrors, err := NewHevyParser().Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(rows) != 1 || len(rowErrors) != 0 {
+		t.Fatalf("rows = %v, rowErrors = %v, want 1 row", rows, rowErrors)
+	}
+	if rows[0].WeightUnit != repository.WeightUnitLb || rows[0].Weight != 225 {
+		t.Errorf("weight = %v %s, want 225 lb", rows[0].Weight, rows[0].WeightUnit)
+	}
+	if rows[0].EndedAt != nil {
+		t.Errorf("EndedAt = %v, want nil", rows[0].EndedAt)
+	}
+}
+
+func TestHevyParser_Parse_MissingWeightColumn(t *testing.T) {
+	input := "title,start_time,exercise_title,reps\nLegs,\"3 Feb 2024, 18:05\",Squat,5"
+
+	if _, _, err := NewHevyParser().Parse(strings.NewReader(input)); err == nil {
+		t.Error("Parse() error = nil, want error")
+	}
+}
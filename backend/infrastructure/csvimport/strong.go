@@ -0,0 +1,118 @@
+package csvimport
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+// strongDateLayout はStrongのCSVのDate列（ワークアウトの開始日時）の形式
+const strongDateLayout = "2006-01-02 15:04:05"
+
+// strongSetTypes はStrongのCSVのSet Order列で通常のセット以外を表す値とセットの種類の対応
+var strongSetTypes = map[string]entity.SetType{
+	"W": entity.SetTypeWarmUp,
+	"D": entity.SetTypeDrop,
+	"F": entity.SetTypeFailure,
+}
+
+// StrongParser はStrongアプリからエクスポートしたCSVを読み取る。
+// repository.WorkoutHistoryParserインターフェースを実装する。
+//
+// 列: Date, Workout Name, Duration, Exercise Name, Set Order, Weight, Reps, Distance, Seconds, Notes, Workout Notes, RPE
+// （古いバージョンのWeight Unit列があれば重量の単位として使用する。ない場合は単位を判別できない）
+type StrongParser struct{}
+
+// NewStrongParser はStrongParserの新しいインスタンスを生成する。
+func NewStrongParser() *StrongParser {
+	return &StrongParser{}
+}
+
+// Parse はStrongのCSVを読み取る。
+// 同じDate（開始日時）の行を1つのワークアウトとし、休憩タイマーの行は読み飛ばす。
+// Set OrderがW・D・Fの行はそれぞれウォームアップ・ドロップ・限界までのセットとする。
+func (p *StrongParser) Parse(r io.Reader) ([]repository.ImportedSetRow, []repository.ImportRowError, error) {
+	required := []string{"date", "exercise name", "reps", "weight"}
+	return readRows(r, required, func(rec record) (repository.ImportedSetRow, error) {
+		if strings.EqualFold(rec.get("set order"), "rest timer") {
+			return repository.ImportedSetRow{}, errSkipRow
+		}
+
+		startedAt, err := time.Parse(strongDateLayout, rec.get("date"))
+		if err != nil {
+			return repository.ImportedSetRow{}, fmt.Errorf("invalid date %q", rec.get("date"))
+		}
+		startedAt = wallClock(startedAt)
+
+		exerciseName := rec.get("exercise name")
+		if exerciseName == "" {
+			return repository.ImportedSetRow{}, fmt.Errorf("exercise name is empty")
+		}
+
+		reps, err := parseReps(rec.get("reps"))
+		if err != nil {
+			return repository.ImportedSetRow{}, err
+		}
+		weight, err := parseWeight(rec.get("weight"))
+		if err != nil {
+			return repository.ImportedSetRow{}, err
+		}
+		seconds, err := parseOptionalSeconds(rec.get("seconds"))
+		if err != nil {
+			return repository.ImportedSetRow{}, err
+		}
+		rpe, err := parseOptionalRPE(rec.get("rpe"))
+		if err != nil {
+			return repository.ImportedSetRow{}, err
+		}
+
+		var unit repository.WeightUnit
+		if rec.has("weight unit") {
+			switch strings.ToLower(rec.get("weight unit")) {
+			case "kg", "kgs":
+				unit = repository.WeightUnitKg
+			case "lb", "lbs":
+				unit = repository.WeightUnitLb
+			}
+		}
+
+		row := repository.ImportedSetRow{
+			WorkoutKey:      rec.get("date"),
+			Date:            dateOf(startedAt),
+			StartedAt:       &startedAt,
+			Memo:            joinMemo(rec.get("workout name"), rec.get("workout notes")),
+			ExerciseName:    exerciseName,
+			Reps:            reps,
+			Weight:          weight,
+			WeightUnit:      unit,
+			DurationSeconds: seconds,
+			Notes:           optionalString(rec.get("notes")),
+			RPE:             rpe,
+		}
+		if setType, ok := strongSetTypes[strings.ToUpper(rec.get("set order"))]; ok {
+			row.SetType = &setType
+		}
+		if d, ok := parseStrongDuration(rec.get("duration")); ok {
+			endedAt := startedAt.Add(d)
+			row.EndedAt = &endedAt
+		}
+		return row, nil
+	})
+}
+
+// parseStrongDuration はStrongのDuration列（例: "1h 5m", "45m"）を読み取る。
+// 空または読み取れない場合はfalseを返す。
+func parseStrongDuration(s string) (time.Duration, bool) {
+	if s == "" {
+		return 0, false
+	}
+	d, err := time.ParseDuration(strings.ReplaceAll(s, " ", ""))
+	if err != nil || d <= 0 {
+		return 0, false
+	}
+	return d, true
+}
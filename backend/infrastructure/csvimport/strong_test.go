@@ -0,0 +1,121 @@
+package csvimport
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+func TestStrongParser_Parse(t *testing.T) {
+	input := strings.Join([]string{
+		"Date,Workout Name,Duration,Exercise Name,Set Order,Weight,Reps,Distance,Seconds,Notes,Workout Notes,RPE",
+		`2023-01-15 08:30:00,Push Day,1h 5m,Bench Press (Barbell),W,60,10,0,0,"warm, easy",,`,
+		"2023-01-15 08:30:00,Push Day,1h 5m,Bench Press (Barbell),2,80,5,0,0,,,8",
+		"2023-01-15 08:30:00,Push Day,1h 5m,Bench Press (Barbell),Rest Timer,0,0,0,90,,,",
+		"2023-01-15 08:30:00,Push Day,1h 5m,Plank,1,0,1,0,60,,,",
+		"2023-01-15 08:30:00,Push Day,1h 5m,Dips,1,0,ten,0,0,,,",
+		"2023-01-17 18:00:00,Legs,45m,Squat (Barbell),1,100,5,0,0,,Felt good,",
+	}, "\n")
+
+	rows, rowErrors, err := NewStrongParser().Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	// 休憩タイマーの行は読み飛ばし、読み取れない行は行番号付きで報告する
+	if len(rows) != 4 {
+		t.Fatalf("len(rows) = %d, want 4", len(rows))
+	}
+	if len(rowErrors) != 1 || rowErrors[0].Line != 6 {
+		t.Errorf("rowErrors = %v, want one error on line 6", rowErrors)
+	}
+
+	first := rows[0]
+	wantStart := time.Date(2023, 1, 15, 8, 30, 0, 0, time.UTC)
+	if first.Line != 2 {
+		t.Errorf("Line = %d, want 2", first.Line)
+	}
+	if first.ExerciseName != "Bench Press (Barbell)" || first.Reps != 10 || first.Weight != 60 {
+		t.Errorf("row = %+v, want Bench Press (Barbell) 60x10", first)
+	}
+	if first.StartedAt == nil || !first.StartedAt.Equal(wantStart) {
+		t.Errorf("StartedAt = %v, want %v", first.StartedAt, wantStart)
+	}
+	if first.EndedAt == nil || !first.EndedAt.Equal(wantStart.Add(65*time.Minute)) {
+		t.Errorf("EndedAt = %v, want %v", first.EndedAt, wantStart.Add(65*time.Minute))
+	}
+	if !first.Date.Equal(time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Date = %v, want 2023-01-15", first.Date)
+	}
+	if first.Notes == nil || *first.Notes != "warm, easy" {
+		t.Errorf("Notes = %v, want warm, easy", first.Notes)
+	}
+	if first.WeightUnit != "" {
+		t.Errorf("WeightUnit = %q, want empty (unknown)", first.WeightUnit)
+	}
+	if first.SetType == nil || *first.SetType != entity.SetTypeWarmUp {
+		t.Errorf("SetType = %v, want warmup", first.SetType)
+	}
+	if rows[1].SetType != nil {
+		t.Errorf("SetType = %v, want nil", *rows[1].SetType)
+	}
+	if rows[1].RPE == nil || *rows[1].RPE != 8 {
+		t.Errorf("RPE = %v, want 8", rows[1].RPE)
+	}
+	if first.WorkoutKey != rows[1].WorkoutKey || first.WorkoutKey == rows[3].WorkoutKey {
+		t.Errorf("rows of the same workout should share the WorkoutKey")
+	}
+
+	if rows[2].DurationSeconds == nil || *rows[2].DurationSeconds != 60 {
+		t.Errorf("DurationSeconds = %v, want 60", rows[2].DurationSeconds)
+	}
+	if rows[3].Memo == nil || *rows[3].Memo != "Legs\nFelt good" {
+		t.Errorf("Memo = %v, want workout name and notes", rows[3].Memo)
+	}
+}
+
+func TestStrongParser_Parse_WeightUnitAndDelimiter(t *testing.T) {
+	// 古いバージョンはセミコロン区切りで、Weight Unit列を含む
+	input := strings.Join([]string{
+		"Date;Workout Name;Exercise Name;Set Order;Weight;Weight Unit;Reps",
+		"2020-05-01 07:00:00;Morning;Deadlift (Barbell);1;225;lbs;5",
+	}, "\n")
+
+	rows, rowErrors, err := NewStrongParser().Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(rows) != 1 || len(rowErrors) != 0 {
+		t.Fatalf("rows = %v, rowErrors = %v, want 1 row", rows, rowErrors)
+	}
+	if rows[0].WeightUnit != repository.WeightUnitLb || rows[0].Weight != 225 {
+		t.Errorf("weight = %v %s, want 225 lb", rows[0].Weight, rows[0].WeightUnit)
+	}
+}
+
+func TestStrongParser_Parse_InvalidFile(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "異常系: 空のファイル",
+			input: "",
+		},
+		{
+			name:  "異常系: 必須の列がない",
+			input: "Date,Workout Name,Weight,Reps\n2023-01-15 08:30:00,Push,60,10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := NewStrongParser().Parse(strings.NewReader(tt.input)); err == nil {
+				t.Error("Parse() error = nil, want error")
+			}
+		})
+	}
+}
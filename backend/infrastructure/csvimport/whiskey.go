@@ -0,0 +1,258 @@
+package csvimport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+// WhiskeyColumns はwhiskey形式のCSVの列。
+// 必須の列はdate, exercise_name, reps, weightで、それ以外は省略できる。
+// exercise_name・reps・weightがすべて空の行は、セットのないワークアウトを表す。
+// set_typeを省略した場合は通常のセット（working）とする。
+// estimated_1rmは記録時に再計算するため、読み取りでは使用しない。
+var WhiskeyColumns = []string{
+	"workout_id",
+	"date",
+	"started_at",
+	"ended_at",
+	"memo",
+	"exercise_name",
+	"body_part",
+	"set_number",
+	"reps",
+	"weight",
+	"estimated_1rm",
+	"duration_seconds",
+	"notes",
+	"set_type",
+	"rpe",
+	"rir",
+}
+
+// WhiskeyParser はwhiskey形式のCSVを読み取る。
+// repository.WorkoutHistoryParserインターフェースを実装する。
+//
+// dateはYYYY-MM-DD形式、started_at・ended_atはRFC3339形式、weightはkg単位とする。
+type WhiskeyParser struct{}
+
+// NewWhiskeyParser はWhiskeyParserの新しいインスタンスを生成する。
+func NewWhiskeyParser() *WhiskeyParser {
+	return &WhiskeyParser{}
+}
+
+// Parse はwhiskey形式のCSVを読み取る。
+// 同じworkout_idの行を1つのワークアウトとし、workout_idがない場合は同じdateとstarted_atの行を1つのワークアウトとする。
+func (p *WhiskeyParser) Parse(r io.Reader) ([]repository.ImportedSetRow, []repository.ImportRowError, error) {
+	required := []string{"date", "exercise_name", "reps", "weight"}
+	return readRows(r, required, func(rec record) (repository.ImportedSetRow, error) {
+		date, err := time.Parse(time.DateOnly, rec.get("date"))
+		if err != nil {
+			return repository.ImportedSetRow{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", rec.get("date"))
+		}
+
+		startedAt, err := parseOptionalRFC3339(rec.get("started_at"))
+		if err != nil {
+			return repository.ImportedSetRow{}, fmt.Errorf("invalid started_at %q, expected RFC3339", rec.get("started_at"))
+		}
+		endedAt, err := parseOptionalRFC3339(rec.get("ended_at"))
+		if err != nil {
+			return repository.ImportedSetRow{}, fmt.Errorf("invalid ended_at %q, expected RFC3339", rec.get("ended_at"))
+		}
+
+		workoutKey := rec.get("workout_id")
+		if workoutKey == "" {
+			workoutKey = rec.get("date") + " " + rec.get("started_at")
+		}
+
+		exerciseName := rec.get("exercise_name")
+		if exerciseName == "" && rec.get("reps") == "" && rec.get("weight") == "" {
+			return repository.ImportedSetRow{
+				WorkoutKey:  workoutKey,
+				Date:        date,
+				StartedAt:   startedAt,
+				EndedAt:     endedAt,
+				Memo:        optionalString(rec.get("memo")),
+				WorkoutOnly: true,
+			}, nil
+		}
+		if exerciseName == "" {
+			return repository.ImportedSetRow{}, fmt.Errorf("exercise_name is empty")
+		}
+
+		var bodyPart *entity.BodyPart
+		if s := rec.get("body_part"); s != "" {
+			bp := entity.BodyPart(s)
+			if err := entity.ValidateBodyPart(bp); err != nil {
+				return repository.ImportedSetRow{}, fmt.Errorf("invalid body_part %q", s)
+			}
+			bodyPart = &bp
+		}
+
+		var setNumber *int32
+		if s := rec.get("set_number"); s != "" {
+			n, err := strconv.ParseInt(s, 10, 32)
+			if err != nil {
+				return repository.ImportedSetRow{}, fmt.Errorf("invalid set_number %q", s)
+			}
+			number := int32(n)
+			setNumber = &number
+		}
+
+		reps, err := parseReps(rec.get("reps"))
+		if err != nil {
+			return repository.ImportedSetRow{}, err
+		}
+		weight, err := parseWeight(rec.get("weight"))
+		if err != nil {
+			return repository.ImportedSetRow{}, err
+		}
+		seconds, err := parseOptionalSeconds(rec.get("duration_seconds"))
+		if err != nil {
+			return repository.ImportedSetRow{}, err
+		}
+
+		var setType *entity.SetType
+		if s := rec.get("set_type"); s != "" {
+			t := entity.SetType(s)
+			if !t.IsValid() {
+				return repository.ImportedSetRow{}, fmt.Errorf("invalid set_type %q", s)
+			}
+			setType = &t
+		}
+		rpe, err := parseOptionalRPE(rec.get("rpe"))
+		if err != nil {
+			return repository.ImportedSetRow{}, err
+		}
+		rir, err := parseOptionalRIR(rec.get("rir"))
+		if err != nil {
+			return repository.ImportedSetRow{}, err
+		}
+
+		return repository.ImportedSetRow{
+			WorkoutKey:      workoutKey,
+			Date:            date,
+			StartedAt:       startedAt,
+			EndedAt:         endedAt,
+			Memo:            optionalString(rec.get("memo")),
+			ExerciseName:    exerciseName,
+			BodyPart:        bodyPart,
+			SetNumber:       setNumber,
+			Reps:            reps,
+			Weight:          weight,
+			WeightUnit:      repository.WeightUnitKg,
+			DurationSeconds: seconds,
+			Notes:           optionalString(rec.get("notes")),
+			SetType:         setType,
+			RPE:             rpe,
+			RIR:             rir,
+		}, nil
+	})
+}
+
+// WhiskeyWriter はワークアウトとセットをwhiskey形式のCSVとして書き出す。
+// 書き出したCSVはWhiskeyParserでそのまま読み取れるため、バックアップと復元に使用できる。
+// CSVは1行1セットとし、セットのないワークアウトはセットの列を空にした1行として書き出す。
+type WhiskeyWriter struct {
+	w             *csv.Writer
+	loc           *time.Location
+	headerWritten bool
+}
+
+// NewWhiskeyWriter はWhiskeyWriterの新しいインスタンスを生成する。
+// started_at・ended_atはlocでのRFC3339形式で書き出す。
+func NewWhiskeyWriter(w io.Writer, loc *time.Location) *WhiskeyWriter {
+	return &WhiskeyWriter{
+		w:   csv.NewWriter(w),
+		loc: loc,
+	}
+}
+
+// Write はセット1件を1行として書き出す。最初の呼び出しではヘッダー行も書き出す。
+// row.Setがnilの場合は、ワークアウトの列のみの行を書き出す。
+func (w *WhiskeyWriter) Write(row repository.WorkoutExportRow) error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+
+	workout := row.Workout
+	record := make([]string, len(WhiskeyColumns))
+	record[0] = workout.ID.String()
+	record[1] = workout.Date.Format(time.DateOnly)
+	record[2] = workout.StartedAt.In(w.loc).Format(time.RFC3339)
+	if workout.EndedAt != nil {
+		record[3] = workout.EndedAt.In(w.loc).Format(time.RFC3339)
+	}
+	record[4] = derefString(workout.Memo)
+
+	set := row.Set
+	if set == nil {
+		return w.w.Write(record)
+	}
+
+	record[5] = row.ExerciseName
+	if row.BodyPart != nil {
+		record[6] = string(*row.BodyPart)
+	}
+	record[7] = strconv.FormatInt(int64(set.SetNumber), 10)
+	record[8] = strconv.FormatInt(int64(set.Reps), 10)
+	record[9] = strconv.FormatFloat(set.Weight, 'f', -1, 64)
+	record[10] = strconv.FormatFloat(set.Estimated1RM, 'f', -1, 64)
+	record[12] = derefString(set.Notes)
+	record[13] = string(set.SetType)
+	if set.DurationSeconds != nil {
+		record[11] = strconv.FormatInt(int64(*set.DurationSeconds), 10)
+	}
+	if set.RPE != nil {
+		record[14] = strconv.FormatFloat(*set.RPE, 'f', -1, 64)
+	}
+	if set.RIR != nil {
+		record[15] = strconv.FormatInt(int64(*set.RIR), 10)
+	}
+	return w.w.Write(record)
+}
+
+// Flush はバッファに残っている行を書き出す。行が1件もない場合もヘッダー行は書き出す。
+func (w *WhiskeyWriter) Flush() error {
+	if err := w.writeHeader(); err != nil {
+		return err
+	}
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// writeHeader はまだ書き出していなければヘッダー行を書き出す。
+func (w *WhiskeyWriter) writeHeader() error {
+	if w.headerWritten {
+		return nil
+	}
+	w.headerWritten = true
+	return w.w.Write(WhiskeyColumns)
+}
+
+// derefString は文字列のポインタを値に変換する。nilの場合は空文字列を返す。
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// parseOptionalRFC3339 はRFC3339形式の時刻を読み取り、記録されたオフセットでの壁時計の時刻を返す。
+// 空の場合はnilを返す。
+func parseOptionalRFC3339(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, err
+	}
+	t = wallClock(t)
+	return &t, nil
+}
@@ -0,0 +1,225 @@
+package csvimport
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+func TestWhiskeyParser_Parse(t *testing.T) {
+	input := strings.Join([]string{
+		strings.Join(WhiskeyColumns, ","),
+		`w1,2026-01-14,2026-01-14T10:00:00+09:00,2026-01-14T11:00:00+09:00,"朝トレ
+2行目",ベンチプレス,chest,1,10,60,80,,`,
+		"w1,2026-01-14,2026-01-14T10:00:00+09:00,2026-01-14T11:00:00+09:00,,プランク,core,1,1,0,0,60,キープ,failure,,2",
+		"w2,2026-01-14,,,,ベンチプレス,chest,1,5,80,93.33,,",
+	}, "\n")
+
+	rows, rowErrors, err := NewWhiskeyParser().Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(rows) != 3 || len(rowErrors) != 0 {
+		t.Fatalf("rows = %v, rowErrors = %v, want 3 rows", rows, rowErrors)
+	}
+
+	first := rows[0]
+	// 開始時刻は記録されたオフセットでの壁時計の時刻として読み取る
+	wantStart := time.Date(2026, 1, 14, 10, 0, 0, 0, time.UTC)
+	if first.StartedAt == nil || !first.StartedAt.Equal(wantStart) {
+		t.Errorf("StartedAt = %v, want %v", first.StartedAt, wantStart)
+	}
+	if first.Memo == nil || *first.Memo != "朝トレ\n2行目" {
+		t.Errorf("Memo = %v, want multi-line memo", first.Memo)
+	}
+	if first.BodyPart == nil || *first.BodyPart != entity.BodyPartChest {
+		t.Errorf("BodyPart = %v, want chest", first.BodyPart)
+	}
+	if first.SetNumber == nil || *first.SetNumber != 1 {
+		t.Errorf("SetNumber = %v, want 1", first.SetNumber)
+	}
+
+	// 3行目の開始行番号は、メモの改行を含めて4行目
+	if rows[1].Line != 4 {
+		t.Errorf("Line = %d, want 4", rows[1].Line)
+	}
+	if rows[1].Notes == nil || *rows[1].Notes != "キープ" {
+		t.Errorf("Notes = %v, want キープ", rows[1].Notes)
+	}
+	if rows[1].SetType == nil || *rows[1].SetType != entity.SetTypeFailure {
+		t.Errorf("SetType = %v, want failure", rows[1].SetType)
+	}
+	if rows[1].RPE != nil || rows[1].RIR == nil || *rows[1].RIR != 2 {
+		t.Errorf("RPE = %v, RIR = %v, want RIR 2", rows[1].RPE, rows[1].RIR)
+	}
+	// set_typeの列がない場合はnil
+	if first.SetType != nil {
+		t.Errorf("SetType = %v, want nil", *first.SetType)
+	}
+
+	if first.WorkoutKey != rows[1].WorkoutKey || first.WorkoutKey == rows[2].WorkoutKey {
+		t.Error("rows should be grouped by workout_id")
+	}
+	if rows[2].StartedAt != nil {
+		t.Errorf("StartedAt = %v, want nil", rows[2].StartedAt)
+	}
+}
+
+func TestWhiskeyParser_Parse_InvalidRows(t *testing.T) {
+	tests := []struct {
+		name string
+		row  string
+	}{
+		{
+			name: "異常系: 日付の形式が不正",
+			row:  "2026/01/14,ベンチプレス,10,60,",
+		},
+		{
+			name: "異常系: 身体部位が不正",
+			row:  "2026-01-14,ベンチプレス,10,60,胸",
+		},
+		{
+			name: "異常系: 重量が数値でない",
+			row:  "2026-01-14,ベンチプレス,10,heavy,",
+		},
+		{
+			name: "異常系: 種目名が空",
+			row:  "2026-01-14,,10,60,",
+		},
+		{
+			name: "異常系: セットの種類が不正",
+			row:  "2026-01-14,ベンチプレス,10,60,,cooldown,",
+		},
+		{
+			name: "異常系: RPEが数値でない",
+			row:  "2026-01-14,ベンチプレス,10,60,,,hard",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := "date,exercise_name,reps,weight,body_part,set_type,rpe\n" + tt.row
+
+			rows, rowErrors, err := NewWhiskeyParser().Parse(strings.NewReader(input))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if len(rows) != 0 {
+				t.Errorf("rows = %v, want none", rows)
+			}
+			if len(rowErrors) != 1 || rowErrors[0].Line != 2 {
+				t.Errorf("rowErrors = %v, want one error on line 2", rowErrors)
+			}
+		})
+	}
+}
+
+func TestWhiskeyWriter_RoundTrip(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	chest := entity.BodyPartChest
+	memo := "朝トレ, 胸の日\n2行目"
+	notes := `"キープ"`
+	seconds := int32(60)
+
+	workout := entity.NewWorkout(uuid.New(), time.Date(2026, 1, 14, 0, 0, 0, 0, time.UTC))
+	endedAt := time.Date(2026, 1, 14, 2, 0, 0, 0, time.UTC)
+	workout.UpdateSessionTime(time.Date(2026, 1, 14, 1, 0, 0, 0, time.UTC), &endedAt)
+	workout.UpdateMemo(&memo)
+	bench, _ := entity.NewWorkoutSet(workout.ID, uuid.New(), 1, 10, 62.5)
+	plank, _ := entity.NewWorkoutSet(workout.ID, uuid.New(), 1, 1, 0)
+	plank.UpdateDuration(&seconds)
+	plank.UpdateNotes(&notes)
+	bench.UpdateSetType(entity.SetTypeWarmUp)
+	rpe := 7.5
+	bench.UpdateEffort(&rpe, nil)
+	rir := int32(1)
+	plank.UpdateEffort(nil, &rir)
+	empty := entity.NewWorkout(workout.UserID, time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC))
+	restMemo := "休養"
+	empty.UpdateMemo(&restMemo)
+
+	var buf bytes.Buffer
+	w := NewWhiskeyWriter(&buf, tokyo)
+	for _, row := range []repository.WorkoutExportRow{
+		{Workout: workout, Set: bench, ExerciseName: "ベンチプレス", BodyPart: &chest},
+		{Workout: workout, Set: plank, ExerciseName: "プランク"},
+		{Workout: empty},
+	} {
+		if err := w.Write(row); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	rows, rowErrors, err := NewWhiskeyParser().Parse(&buf)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(rows) != 3 || len(rowErrors) != 0 {
+		t.Fatalf("rows = %v, rowErrors = %v, want 3 rows", rows, rowErrors)
+	}
+
+	// セットのないワークアウトはワークアウトの列のみの行として読み戻せる
+	if last := rows[2]; !last.WorkoutOnly || last.WorkoutKey != empty.ID.String() || last.Memo == nil || *last.Memo != restMemo {
+		t.Errorf("last row = %+v, want the workout without sets", last)
+	}
+	if rows[0].WorkoutOnly {
+		t.Error("rows with a set should not be workout only")
+	}
+
+	first := rows[0]
+	if first.WorkoutKey != workout.ID.String() || rows[1].WorkoutKey != first.WorkoutKey {
+		t.Errorf("WorkoutKey = %q, want %q", first.WorkoutKey, workout.ID.String())
+	}
+	if !first.Date.Equal(workout.Date) {
+		t.Errorf("Date = %v, want %v", first.Date, workout.Date)
+	}
+	// 壁時計の時刻はユーザーのタイムゾーンでの時刻
+	if first.StartedAt == nil || !first.StartedAt.Equal(time.Date(2026, 1, 14, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("StartedAt = %v, want 10:00 wall clock", first.StartedAt)
+	}
+	if first.EndedAt == nil || !first.EndedAt.Equal(time.Date(2026, 1, 14, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("EndedAt = %v, want 11:00 wall clock", first.EndedAt)
+	}
+	if first.Memo == nil || *first.Memo != memo {
+		t.Errorf("Memo = %v, want %q", first.Memo, memo)
+	}
+	if first.ExerciseName != "ベンチプレス" || first.BodyPart == nil || *first.BodyPart != chest {
+		t.Errorf("exercise = %s %v, want ベンチプレス chest", first.ExerciseName, first.BodyPart)
+	}
+	if first.SetNumber == nil || *first.SetNumber != 1 || first.Reps != 10 || first.Weight != 62.5 {
+		t.Errorf("set = %+v, want set 1 62.5kg x10", first)
+	}
+	if rows[1].BodyPart != nil {
+		t.Errorf("BodyPart = %v, want nil", rows[1].BodyPart)
+	}
+	if rows[1].DurationSeconds == nil || *rows[1].DurationSeconds != 60 {
+		t.Errorf("DurationSeconds = %v, want 60", rows[1].DurationSeconds)
+	}
+	if rows[1].Notes == nil || *rows[1].Notes != notes {
+		t.Errorf("Notes = %v, want %q", rows[1].Notes, notes)
+	}
+	if first.SetType == nil || *first.SetType != entity.SetTypeWarmUp || first.RPE == nil || *first.RPE != rpe {
+		t.Errorf("SetType = %v, RPE = %v, want warmup @7.5", first.SetType, first.RPE)
+	}
+	if rows[1].SetType == nil || *rows[1].SetType != entity.SetTypeWorking || rows[1].RIR == nil || *rows[1].RIR != rir {
+		t.Errorf("SetType = %v, RIR = %v, want working RIR 1", rows[1].SetType, rows[1].RIR)
+	}
+}
+
+func TestWhiskeyWriter_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewWhiskeyWriter(&buf, time.UTC).Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got, want := buf.String(), strings.Join(WhiskeyColumns, ",")+"\n"; got != want {
+		t.Errorf("output = %q, want header only %q", got, want)
+	}
+}
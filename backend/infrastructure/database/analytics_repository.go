@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+	db "github.com/ucchy108/whiskey/backend/sqlc/db"
+)
+
+// analyticsRepository はAnalyticsRepositoryインターフェースの実装
+type analyticsRepository struct {
+	queries *db.Queries
+}
+
+// NewAnalyticsRepository はAnalyticsRepositoryの実装を生成する
+func NewAnalyticsRepository(conn *sql.DB) repository.AnalyticsRepository {
+	return &analyticsRepository{
+		queries: db.New(conn),
+	}
+}
+
+// GetVolume は期間（週・月）と種目または身体部位ごとのトレーニング量を、古い期間から順に集計する。
+// 集計はworkouts・workout_sets・exercisesを結合したSQLで行う。
+// 日付はそれぞれのタイムゾーンでの暦日として解釈される。
+func (r *analyticsRepository) GetVolume(ctx context.Context, query repository.VolumeQuery) ([]repository.VolumeAggregate, error) {
+	var startDate, endDate sql.NullTime
+	if query.StartDate != nil {
+		startDate = sql.NullTime{Time: entity.CalendarDate(*query.StartDate), Valid: true}
+	}
+	if query.EndDate != nil {
+		endDate = sql.NullTime{Time: entity.CalendarDate(*query.EndDate), Valid: true}
+	}
+
+	switch query.Dimension {
+	case repository.VolumeDimensionExercise:
+		rows, err := queriesFromContext(ctx, r.queries).ListVolumeByExercise(ctx, db.ListVolumeByExerciseParams{
+			Period:    string(query.GroupBy),
+			UserID:    query.UserID,
+			StartDate: startDate,
+			EndDate:   endDate,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		aggregates := make([]repository.VolumeAggregate, len(rows))
+		for i, row := range rows {
+			aggregate, err := toVolumeAggregate(row.PeriodStart, row.ExerciseBodyPart, row.Tonnage, row.SetCount, row.RepCount, row.AverageIntensity)
+			if err != nil {
+				return nil, err
+			}
+			exerciseID, exerciseName := row.ExerciseID, row.ExerciseName
+			aggregate.ExerciseID = &exerciseID
+			aggregate.ExerciseName = &exerciseName
+			aggregates[i] = aggregate
+		}
+		return aggregates, nil
+
+	case repository.VolumeDimensionBodyPart:
+		rows, err := queriesFromContext(ctx, r.queries).ListVolumeByBodyPart(ctx, db.ListVolumeByBodyPartParams{
+			Period:    string(query.GroupBy),
+			UserID:    query.UserID,
+			StartDate: startDate,
+			EndDate:   endDate,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		aggregates := make([]repository.VolumeAggregate, len(rows))
+		for i, row := range rows {
+			aggregate, err := toVolumeAggregate(row.PeriodStart, row.ExerciseBodyPart, row.Tonnage, row.SetCount, row.RepCount, row.AverageIntensity)
+			if err != nil {
+				return nil, err
+			}
+			aggregates[i] = aggregate
+		}
+		return aggregates, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported volume dimension: %q", query.Dimension)
+	}
+}
+
+// toVolumeAggregate は集計クエリの共通の列をドメイン層のVolumeAggregateに変換する
+func toVolumeAggregate(periodStart time.Time, bodyPart sql.NullString, tonnage string, setCount, repCount int64, averageIntensity string) (repository.VolumeAggregate, error) {
+	t, err := parseFloat(tonnage)
+	if err != nil {
+		return repository.VolumeAggregate{}, err
+	}
+	intensity, err := parseFloat(averageIntensity)
+	if err != nil {
+		return repository.VolumeAggregate{}, err
+	}
+
+	return repository.VolumeAggregate{
+		PeriodStart:      periodStart,
+		BodyPart:         nullStringToBodyPart(bodyPart),
+		Tonnage:          t,
+		SetCount:         int(setCount),
+		RepCount:         int(repCount),
+		AverageIntensity: intensity,
+	}, nil
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+func TestAnalyticsRepository_GetVolume(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	otherUser := CreateUser(t, ctx, repos.User)
+	suffix := uuid.NewString()[:8]
+	bench := CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Analytics Bench "+suffix), WithBodyPart(entity.BodyPartChest))
+	squat := CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Analytics Squat "+suffix), WithBodyPart(entity.BodyPartLegs))
+
+	jan5 := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	jan7 := time.Date(2026, 1, 7, 0, 0, 0, 0, time.UTC)
+	jan14 := time.Date(2026, 1, 14, 0, 0, 0, 0, time.UTC)
+
+	workout1 := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(jan5))
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout1.ID, bench.ID, WithSetNumber(1), WithReps(10), WithWeight(60))
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout1.ID, bench.ID, WithSetNumber(2), WithReps(5), WithWeight(70))
+	// ウォームアップのセットは集計に含まれない
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout1.ID, bench.ID, WithSetNumber(3), WithReps(10), WithWeight(20), WithSetType(entity.SetTypeWarmUp))
+	workout2 := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(jan7))
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout2.ID, bench.ID, WithSetNumber(1), WithReps(5), WithWeight(80))
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout2.ID, squat.ID, WithSetNumber(1), WithReps(5), WithWeight(100))
+	workout3 := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(jan14))
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout3.ID, squat.ID, WithSetNumber(1), WithReps(8), WithWeight(100))
+
+	// 他ユーザーのセットは集計に含まれない
+	otherWorkout := CreateWorkout(t, ctx, repos.Workout, otherUser.ID, WithDate(jan5))
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, otherWorkout.ID, bench.ID, WithSetNumber(1), WithReps(10), WithWeight(100))
+
+	chest, legs := entity.BodyPartChest, entity.BodyPartLegs
+	jan12 := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	jan1 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		query repository.VolumeQuery
+		want  []repository.VolumeAggregate
+	}{
+		{
+			name:  "正常系: 週・種目ごと",
+			query: repository.VolumeQuery{UserID: user.ID, GroupBy: repository.VolumeGroupByWeek, Dimension: repository.VolumeDimensionExercise},
+			want: []repository.VolumeAggregate{
+				{PeriodStart: jan5, ExerciseID: &bench.ID, BodyPart: &chest, Tonnage: 1350, SetCount: 3, RepCount: 20, AverageIntensity: 67.5},
+				{PeriodStart: jan5, ExerciseID: &squat.ID, BodyPart: &legs, Tonnage: 500, SetCount: 1, RepCount: 5, AverageIntensity: 100},
+				{PeriodStart: jan12, ExerciseID: &squat.ID, BodyPart: &legs, Tonnage: 800, SetCount: 1, RepCount: 8, AverageIntensity: 100},
+			},
+		},
+		{
+			name:  "正常系: 月・身体部位ごと",
+			query: repository.VolumeQuery{UserID: user.ID, GroupBy: repository.VolumeGroupByMonth, Dimension: repository.VolumeDimensionBodyPart},
+			want: []repository.VolumeAggregate{
+				{PeriodStart: jan1, BodyPart: &chest, Tonnage: 1350, SetCount: 3, RepCount: 20, AverageIntensity: 67.5},
+				{PeriodStart: jan1, BodyPart: &legs, Tonnage: 1300, SetCount: 2, RepCount: 13, AverageIntensity: 100},
+			},
+		},
+		{
+			name:  "正常系: 期間を指定",
+			query: repository.VolumeQuery{UserID: user.ID, GroupBy: repository.VolumeGroupByWeek, Dimension: repository.VolumeDimensionExercise, StartDate: &jan7, EndDate: &end},
+			want: []repository.VolumeAggregate{
+				{PeriodStart: jan5, ExerciseID: &bench.ID, BodyPart: &chest, Tonnage: 400, SetCount: 1, RepCount: 5, AverageIntensity: 80},
+				{PeriodStart: jan5, ExerciseID: &squat.ID, BodyPart: &legs, Tonnage: 500, SetCount: 1, RepCount: 5, AverageIntensity: 100},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repos.Analytics.GetVolume(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("GetVolume() error = %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("GetVolume() returned %d aggregates, want %d: %+v", len(got), len(tt.want), got)
+			}
+
+			for i, want := range tt.want {
+				g := got[i]
+				if !g.PeriodStart.Equal(want.PeriodStart) {
+					t.Errorf("[%d] PeriodStart = %v, want %v", i, g.PeriodStart, want.PeriodStart)
+				}
+				if (want.ExerciseID == nil) != (g.ExerciseID == nil) || (want.ExerciseID != nil && *g.ExerciseID != *want.ExerciseID) {
+					t.Errorf("[%d] ExerciseID = %v, want %v", i, g.ExerciseID, want.ExerciseID)
+				}
+				if g.BodyPart == nil || *g.BodyPart != *want.BodyPart {
+					t.Errorf("[%d] BodyPart = %v, want %v", i, g.BodyPart, *want.BodyPart)
+				}
+				if g.Tonnage != want.Tonnage || g.SetCount != want.SetCount || g.RepCount != want.RepCount || g.AverageIntensity != want.AverageIntensity {
+					t.Errorf("[%d] = %+v, want %+v", i, g, want)
+				}
+			}
+		})
+	}
+}
@@ -2,9 +2,33 @@ package database
 
 import (
 	"database/sql"
+	"errors"
 	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
+// foreignKeyViolation はPostgreSQLの外部キー制約違反のエラーコード
+const foreignKeyViolation = "23503"
+
+// uniqueViolation はPostgreSQLの一意制約違反のエラーコード
+const uniqueViolation = "23505"
+
+// isForeignKeyViolation は外部キー制約違反のエラーかどうかを判定する
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation
+}
+
+// isUniqueViolation は一意制約違反のエラーかどうかを判定する
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
+
 // toNullString は*stringをsql.NullStringに変換する
 func toNullString(s *string) sql.NullString {
 	if s == nil {
@@ -21,6 +45,22 @@ func fromNullString(ns sql.NullString) *string {
 	return &ns.String
 }
 
+// toNullUUID は*uuid.UUIDをuuid.NullUUIDに変換する
+func toNullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{Valid: false}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+// fromNullUUID はuuid.NullUUIDを*uuid.UUIDに変換する
+func fromNullUUID(nu uuid.NullUUID) *uuid.UUID {
+	if !nu.Valid {
+		return nil
+	}
+	return &nu.UUID
+}
+
 // toNullInt32 は*int32をsql.NullInt32に変換する
 func toNullInt32(i *int32) sql.NullInt32 {
 	if i == nil {
@@ -37,6 +77,22 @@ func fromNullInt32(ni sql.NullInt32) *int32 {
 	return &ni.Int32
 }
 
+// toNullTime は*time.Timeをsql.NullTimeに変換する
+func toNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{Valid: false}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+// fromNullTime はsql.NullTimeを*time.Timeに変換する
+func fromNullTime(nt sql.NullTime) *time.Time {
+	if !nt.Valid {
+		return nil
+	}
+	return &nt.Time
+}
+
 // float64ToNullString は*float64をsql.NullString（DECIMAL用）に変換する
 func float64ToNullString(v *float64) sql.NullString {
 	if v == nil {
@@ -56,3 +112,11 @@ func nullStringToFloat64(v sql.NullString) *float64 {
 	}
 	return &f
 }
+
+// likePatternEscaper はLIKE/ILIKEのパターンの特殊文字（\, %, _）をエスケープする
+var likePatternEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// escapeLikePattern はLIKE/ILIKEの部分一致検索に使う文字列をエスケープする
+func escapeLikePattern(s string) string {
+	return likePatternEscaper.Replace(s)
+}
@@ -34,12 +34,13 @@ func NewExerciseRepository(conn *sql.DB) repository.ExerciseRepository {
 // DB生成のID、CreatedAt、UpdatedAtが元のエンティティに反映される。
 func (r *exerciseRepository) Create(ctx context.Context, exercise *entity.Exercise) error {
 	params := db.CreateExerciseParams{
+		UserID:      toNullUUID(exercise.UserID),
 		Name:        exercise.Name,
 		Description: toNullString(exercise.Description),
 		BodyPart:    bodyPartToNullString(exercise.BodyPart),
 	}
 
-	created, err := r.queries.CreateExercise(ctx, params)
+	created, err := queriesFromContext(ctx, r.queries).CreateExercise(ctx, params)
 	if err != nil {
 		return err
 	}
@@ -54,7 +55,7 @@ func (r *exerciseRepository) Create(ctx context.Context, exercise *entity.Exerci
 // FindByID はIDでエクササイズを取得する。
 // 該当するエクササイズが存在しない場合はnilを返す。
 func (r *exerciseRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Exercise, error) {
-	dbExercise, err := r.queries.GetExercise(ctx, id)
+	dbExercise, err := queriesFromContext(ctx, r.queries).GetExercise(ctx, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -65,10 +66,16 @@ func (r *exerciseRepository) FindByID(ctx context.Context, id uuid.UUID) (*entit
 	return toExerciseEntity(dbExercise), nil
 }
 
-// FindByName は名前でエクササイズを取得する。
+// FindByName はユーザーが参照可能なエクササイズ（システム種目と自身のカスタム種目）から名前で取得する。
+// 同名の種目が両方に存在する場合はシステム種目を優先する。
 // 該当するエクササイズが存在しない場合はnilを返す。
-func (r *exerciseRepository) FindByName(ctx context.Context, name string) (*entity.Exercise, error) {
-	dbExercise, err := r.queries.GetExerciseByName(ctx, name)
+func (r *exerciseRepository) FindByName(ctx context.Context, userID uuid.UUID, name string) (*entity.Exercise, error) {
+	params := db.GetExerciseByNameForUserParams{
+		Name:   name,
+		UserID: uuid.NullUUID{UUID: userID, Valid: true},
+	}
+
+	dbExercise, err := queriesFromContext(ctx, r.queries).GetExerciseByNameForUser(ctx, params)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -79,10 +86,10 @@ func (r *exerciseRepository) FindByName(ctx context.Context, name string) (*enti
 	return toExerciseEntity(dbExercise), nil
 }
 
-// FindAll は全エクササイズを取得する。
+// FindAll はシステム種目とユーザー自身のカスタム種目を取得する。
 // 結果は名前順でソートされる。
-func (r *exerciseRepository) FindAll(ctx context.Context) ([]*entity.Exercise, error) {
-	dbExercises, err := r.queries.ListExercises(ctx)
+func (r *exerciseRepository) FindAll(ctx context.Context, userID uuid.UUID) ([]*entity.Exercise, error) {
+	dbExercises, err := queriesFromContext(ctx, r.queries).ListExercisesForUser(ctx, uuid.NullUUID{UUID: userID, Valid: true})
 	if err != nil {
 		return nil, err
 	}
@@ -90,12 +97,15 @@ func (r *exerciseRepository) FindAll(ctx context.Context) ([]*entity.Exercise, e
 	return toExerciseEntities(dbExercises), nil
 }
 
-// FindByBodyPart は身体部位でエクササイズを取得する。
+// FindByBodyPart はユーザーが参照可能なエクササイズを身体部位で取得する。
 // 結果は名前順でソートされる。
-func (r *exerciseRepository) FindByBodyPart(ctx context.Context, bodyPart entity.BodyPart) ([]*entity.Exercise, error) {
-	ns := sql.NullString{String: string(bodyPart), Valid: true}
+func (r *exerciseRepository) FindByBodyPart(ctx context.Context, userID uuid.UUID, bodyPart entity.BodyPart) ([]*entity.Exercise, error) {
+	params := db.ListExercisesByBodyPartForUserParams{
+		BodyPart: sql.NullString{String: string(bodyPart), Valid: true},
+		UserID:   uuid.NullUUID{UUID: userID, Valid: true},
+	}
 
-	dbExercises, err := r.queries.ListExercisesByBodyPart(ctx, ns)
+	dbExercises, err := queriesFromContext(ctx, r.queries).ListExercisesByBodyPartForUser(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -114,7 +124,7 @@ func (r *exerciseRepository) Update(ctx context.Context, exercise *entity.Exerci
 		BodyPart:    bodyPartToNullString(exercise.BodyPart),
 	}
 
-	updated, err := r.queries.UpdateExercise(ctx, params)
+	updated, err := queriesFromContext(ctx, r.queries).UpdateExercise(ctx, params)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil
@@ -127,14 +137,19 @@ func (r *exerciseRepository) Update(ctx context.Context, exercise *entity.Exerci
 	return nil
 }
 
-// Delete はエクササイズを削除する
+// Delete はエクササイズを削除する。
+// ワークアウトのセット・ルーティン・プログラムから参照されている場合はrepository.ErrExerciseInUseを返す。
 func (r *exerciseRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.queries.DeleteExercise(ctx, id)
+	err := queriesFromContext(ctx, r.queries).DeleteExercise(ctx, id)
+	if isForeignKeyViolation(err) {
+		return repository.ErrExerciseInUse
+	}
+	return err
 }
 
-// ExistsByName は名前でエクササイズが存在するか確認する
-func (r *exerciseRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
-	exercise, err := r.FindByName(ctx, name)
+// ExistsByName はユーザーが参照可能なエクササイズに同名のものが存在するか確認する
+func (r *exerciseRepository) ExistsByName(ctx context.Context, userID uuid.UUID, name string) (bool, error) {
+	exercise, err := r.FindByName(ctx, userID, name)
 	if err != nil {
 		return false, err
 	}
@@ -162,6 +177,7 @@ func nullStringToBodyPart(ns sql.NullString) *entity.BodyPart {
 func toExerciseEntity(e db.Exercise) *entity.Exercise {
 	return entity.ReconstructExercise(
 		e.ID,
+		fromNullUUID(e.UserID),
 		e.Name,
 		fromNullString(e.Description),
 		nullStringToBodyPart(e.BodyPart),
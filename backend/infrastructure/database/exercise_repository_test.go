@@ -2,10 +2,12 @@ package database
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/google/uuid"
 	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
 )
 
 func TestExerciseRepository_Create(t *testing.T) {
@@ -46,6 +48,34 @@ func TestExerciseRepository_Create_DuplicateName(t *testing.T) {
 	}
 }
 
+func TestExerciseRepository_Create_SameNameDifferentOwners(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user1 := CreateUser(t, ctx, repos.User)
+	user2 := CreateUser(t, ctx, repos.User)
+
+	CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Bench Press"))
+	custom := CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Bench Press"), WithExerciseOwner(user1.ID))
+	CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Bench Press"), WithExerciseOwner(user2.ID))
+
+	found, err := repos.Exercise.FindByID(ctx, custom.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found.UserID == nil || *found.UserID != user1.ID {
+		t.Errorf("FindByID() UserID = %v, want %v", found.UserID, user1.ID)
+	}
+
+	duplicate, _ := entity.NewUserExercise(user1.ID, "Bench Press", nil, nil)
+	if err := repos.Exercise.Create(ctx, duplicate); err == nil {
+		t.Error("Create() expected unique constraint error for duplicate name of same owner, got nil")
+	}
+}
+
 func TestExerciseRepository_FindByID(t *testing.T) {
 	conn := SetupTestDB(t)
 	defer CleanupTestDB(t, conn)
@@ -106,7 +136,12 @@ func TestExerciseRepository_FindByName(t *testing.T) {
 	repos := SetupRepos(conn)
 	ctx := context.Background()
 
+	user := CreateUser(t, ctx, repos.User)
+	other := CreateUser(t, ctx, repos.User)
+
 	CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Squat"))
+	CreateExercise(t, ctx, repos.Exercise, WithExerciseName("My Press"), WithExerciseOwner(user.ID))
+	CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Other Press"), WithExerciseOwner(other.ID))
 
 	tests := []struct {
 		name    string
@@ -114,10 +149,20 @@ func TestExerciseRepository_FindByName(t *testing.T) {
 		wantNil bool
 	}{
 		{
-			name:    "正常系: 存在する名前",
+			name:    "正常系: システム種目の名前",
 			exName:  "Squat",
 			wantNil: false,
 		},
+		{
+			name:    "正常系: 自身のカスタム種目の名前",
+			exName:  "My Press",
+			wantNil: false,
+		},
+		{
+			name:    "正常系: 他ユーザーのカスタム種目の名前",
+			exName:  "Other Press",
+			wantNil: true,
+		},
 		{
 			name:    "正常系: 存在しない名前",
 			exName:  "Deadlift",
@@ -127,7 +172,7 @@ func TestExerciseRepository_FindByName(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			found, err := repos.Exercise.FindByName(ctx, tt.exName)
+			found, err := repos.Exercise.FindByName(ctx, user.ID, tt.exName)
 			if err != nil {
 				t.Fatalf("FindByName() error = %v", err)
 			}
@@ -146,15 +191,20 @@ func TestExerciseRepository_FindAll(t *testing.T) {
 	repos := SetupRepos(conn)
 	ctx := context.Background()
 
+	user := CreateUser(t, ctx, repos.User)
+	other := CreateUser(t, ctx, repos.User)
+
 	CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Bench Press"))
 	CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Squat"))
-	CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Deadlift"))
+	CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Deadlift"), WithExerciseOwner(user.ID))
+	CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Other Press"), WithExerciseOwner(other.ID))
 
-	exercises, err := repos.Exercise.FindAll(ctx)
+	exercises, err := repos.Exercise.FindAll(ctx, user.ID)
 	if err != nil {
 		t.Fatalf("FindAll() error = %v", err)
 	}
 
+	// システム種目2件 + 自身のカスタム種目1件（他ユーザーの種目は含まない）
 	if len(exercises) != 3 {
 		t.Errorf("FindAll() returned %d exercises, want 3", len(exercises))
 	}
@@ -174,11 +224,15 @@ func TestExerciseRepository_FindByBodyPart(t *testing.T) {
 	repos := SetupRepos(conn)
 	ctx := context.Background()
 
+	user := CreateUser(t, ctx, repos.User)
+	other := CreateUser(t, ctx, repos.User)
+
 	CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Bench Press"), WithBodyPart(entity.BodyPartChest))
-	CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Incline Press"), WithBodyPart(entity.BodyPartChest))
+	CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Incline Press"), WithBodyPart(entity.BodyPartChest), WithExerciseOwner(user.ID))
+	CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Decline Press"), WithBodyPart(entity.BodyPartChest), WithExerciseOwner(other.ID))
 	CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Pull Up"), WithBodyPart(entity.BodyPartBack))
 
-	chestExercises, err := repos.Exercise.FindByBodyPart(ctx, entity.BodyPartChest)
+	chestExercises, err := repos.Exercise.FindByBodyPart(ctx, user.ID, entity.BodyPartChest)
 	if err != nil {
 		t.Fatalf("FindByBodyPart() error = %v", err)
 	}
@@ -187,7 +241,7 @@ func TestExerciseRepository_FindByBodyPart(t *testing.T) {
 		t.Errorf("FindByBodyPart(chest) returned %d exercises, want 2", len(chestExercises))
 	}
 
-	backExercises, err := repos.Exercise.FindByBodyPart(ctx, entity.BodyPartBack)
+	backExercises, err := repos.Exercise.FindByBodyPart(ctx, user.ID, entity.BodyPartBack)
 	if err != nil {
 		t.Fatalf("FindByBodyPart() error = %v", err)
 	}
@@ -255,6 +309,29 @@ func TestExerciseRepository_Delete(t *testing.T) {
 	}
 }
 
+func TestExerciseRepository_Delete_InUse(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	exercise := CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Bench Press"))
+	workout := CreateWorkout(t, ctx, repos.Workout, user.ID)
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout.ID, exercise.ID)
+
+	err := repos.Exercise.Delete(ctx, exercise.ID)
+	if !errors.Is(err, repository.ErrExerciseInUse) {
+		t.Fatalf("Delete() error = %v, want %v", err, repository.ErrExerciseInUse)
+	}
+
+	found, _ := repos.Exercise.FindByID(ctx, exercise.ID)
+	if found == nil {
+		t.Error("Delete() deleted an exercise in use")
+	}
+}
+
 func TestExerciseRepository_ExistsByName(t *testing.T) {
 	conn := SetupTestDB(t)
 	defer CleanupTestDB(t, conn)
@@ -262,7 +339,11 @@ func TestExerciseRepository_ExistsByName(t *testing.T) {
 	repos := SetupRepos(conn)
 	ctx := context.Background()
 
+	user := CreateUser(t, ctx, repos.User)
+	other := CreateUser(t, ctx, repos.User)
+
 	CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Bench Press"))
+	CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Other Press"), WithExerciseOwner(other.ID))
 
 	tests := []struct {
 		name   string
@@ -274,6 +355,11 @@ func TestExerciseRepository_ExistsByName(t *testing.T) {
 			exName: "Bench Press",
 			want:   true,
 		},
+		{
+			name:   "正常系: 他ユーザーのカスタム種目の名前",
+			exName: "Other Press",
+			want:   false,
+		},
 		{
 			name:   "正常系: 存在しない名前",
 			exName: "Squat",
@@ -283,7 +369,7 @@ func TestExerciseRepository_ExistsByName(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			exists, err := repos.Exercise.ExistsByName(ctx, tt.exName)
+			exists, err := repos.Exercise.ExistsByName(ctx, user.ID, tt.exName)
 			if err != nil {
 				t.Fatalf("ExistsByName() error = %v", err)
 			}
@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+	db "github.com/ucchy108/whiskey/backend/sqlc/db"
+)
+
+// personalRecordRepository はPersonalRecordRepositoryインターフェースの実装
+type personalRecordRepository struct {
+	queries *db.Queries
+}
+
+// NewPersonalRecordRepository はPersonalRecordRepositoryの実装を生成する
+func NewPersonalRecordRepository(conn *sql.DB) repository.PersonalRecordRepository {
+	return &personalRecordRepository{
+		queries: db.New(conn),
+	}
+}
+
+// Create は自己ベスト更新イベントを作成する
+func (r *personalRecordRepository) Create(ctx context.Context, record *entity.PersonalRecord) error {
+	params := db.CreatePersonalRecordParams{
+		ID:            record.ID,
+		UserID:        record.UserID,
+		ExerciseID:    record.ExerciseID,
+		WorkoutID:     record.WorkoutID,
+		WorkoutSetID:  toNullUUID(record.WorkoutSetID),
+		RecordType:    string(record.RecordType),
+		Reps:          toNullInt32(record.Reps),
+		Value:         formatFloat(record.Value),
+		PreviousValue: float64ToNullString(record.PreviousValue),
+		AchievedDate:  record.AchievedDate,
+		CreatedAt:     record.CreatedAt,
+	}
+
+	created, err := queriesFromContext(ctx, r.queries).CreatePersonalRecord(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	record.CreatedAt = created.CreatedAt
+
+	return nil
+}
+
+// FindByUserID はユーザーの全種目の自己ベスト履歴を新しい順に取得する
+func (r *personalRecordRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.PersonalRecord, error) {
+	dbRecords, err := queriesFromContext(ctx, r.queries).ListPersonalRecordsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPersonalRecordEntities(dbRecords)
+}
+
+// FindByUserIDAndExerciseID はユーザーの特定種目の自己ベスト履歴を新しい順に取得する
+func (r *personalRecordRepository) FindByUserIDAndExerciseID(ctx context.Context, userID, exerciseID uuid.UUID) ([]*entity.PersonalRecord, error) {
+	dbRecords, err := queriesFromContext(ctx, r.queries).ListPersonalRecordsByUserAndExercise(ctx, db.ListPersonalRecordsByUserAndExerciseParams{
+		UserID:     userID,
+		ExerciseID: exerciseID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toPersonalRecordEntities(dbRecords)
+}
+
+// DeleteByUserIDAndExerciseID はユーザーの特定種目の自己ベスト履歴をすべて削除する
+func (r *personalRecordRepository) DeleteByUserIDAndExerciseID(ctx context.Context, userID, exerciseID uuid.UUID) error {
+	return queriesFromContext(ctx, r.queries).DeletePersonalRecordsByUserAndExercise(ctx, db.DeletePersonalRecordsByUserAndExerciseParams{
+		UserID:     userID,
+		ExerciseID: exerciseID,
+	})
+}
+
+// toPersonalRecordEntity はDB層のPersonalRecordをドメイン層のPersonalRecordエンティティに変換する
+func toPersonalRecordEntity(pr db.PersonalRecord) (*entity.PersonalRecord, error) {
+	value, err := parseFloat(pr.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.ReconstructPersonalRecord(
+		pr.ID,
+		pr.UserID,
+		pr.ExerciseID,
+		pr.WorkoutID,
+		fromNullUUID(pr.WorkoutSetID),
+		entity.PersonalRecordType(pr.RecordType),
+		fromNullInt32(pr.Reps),
+		value,
+		nullStringToFloat64(pr.PreviousValue),
+		pr.AchievedDate,
+		pr.CreatedAt,
+	), nil
+}
+
+// toPersonalRecordEntities はDB層のPersonalRecordスライスをドメイン層のスライスに変換する
+func toPersonalRecordEntities(dbRecords []db.PersonalRecord) ([]*entity.PersonalRecord, error) {
+	records := make([]*entity.PersonalRecord, len(dbRecords))
+	for i, pr := range dbRecords {
+		record, err := toPersonalRecordEntity(pr)
+		if err != nil {
+			return nil, err
+		}
+		records[i] = record
+	}
+	return records, nil
+}
@@ -0,0 +1,198 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+)
+
+func TestPersonalRecordRepository_Create(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	exercise := CreateExercise(t, ctx, repos.Exercise)
+	date := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	workout := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(date))
+	set := CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout.ID, exercise.ID, WithSetNumber(1), WithReps(5), WithWeight(100.0))
+
+	reps := int32(5)
+	previous := 95.0
+	record := entity.NewPersonalRecord(user.ID, exercise.ID, workout.ID, &set.ID, entity.PersonalRecordTypeRepMax, &reps, 100.0, &previous, date)
+
+	if err := repos.PersonalRecord.Create(ctx, record); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	records, err := repos.PersonalRecord.FindByUserIDAndExerciseID(ctx, user.ID, exercise.ID)
+	if err != nil {
+		t.Fatalf("FindByUserIDAndExerciseID() error = %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("FindByUserIDAndExerciseID() returned %d records, want 1", len(records))
+	}
+
+	found := records[0]
+	if found.ID != record.ID {
+		t.Errorf("ID = %v, want %v", found.ID, record.ID)
+	}
+	if found.RecordType != entity.PersonalRecordTypeRepMax {
+		t.Errorf("RecordType = %v, want %v", found.RecordType, entity.PersonalRecordTypeRepMax)
+	}
+	if found.Reps == nil || *found.Reps != 5 {
+		t.Errorf("Reps = %v, want 5", found.Reps)
+	}
+	if found.Value != 100.0 {
+		t.Errorf("Value = %v, want 100", found.Value)
+	}
+	if found.PreviousValue == nil || *found.PreviousValue != 95.0 {
+		t.Errorf("PreviousValue = %v, want 95", found.PreviousValue)
+	}
+	if found.WorkoutSetID == nil || *found.WorkoutSetID != set.ID {
+		t.Errorf("WorkoutSetID = %v, want %v", found.WorkoutSetID, set.ID)
+	}
+	if !found.AchievedDate.Equal(date) {
+		t.Errorf("AchievedDate = %v, want %v", found.AchievedDate, date)
+	}
+}
+
+func TestPersonalRecordRepository_Create_Nullable(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	exercise := CreateExercise(t, ctx, repos.Exercise)
+	workout := CreateWorkout(t, ctx, repos.Workout, user.ID)
+
+	// セッションボリュームの初回記録（セットID・レップ数・前回値なし）
+	record := entity.NewPersonalRecord(user.ID, exercise.ID, workout.ID, nil, entity.PersonalRecordTypeSessionVolume, nil, 1540.0, nil, workout.Date)
+	if err := repos.PersonalRecord.Create(ctx, record); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	records, err := repos.PersonalRecord.FindByUserID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("FindByUserID() error = %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("FindByUserID() returned %d records, want 1", len(records))
+	}
+
+	found := records[0]
+	if found.WorkoutSetID != nil {
+		t.Errorf("WorkoutSetID = %v, want nil", found.WorkoutSetID)
+	}
+	if found.Reps != nil {
+		t.Errorf("Reps = %v, want nil", found.Reps)
+	}
+	if found.PreviousValue != nil {
+		t.Errorf("PreviousValue = %v, want nil", found.PreviousValue)
+	}
+	if found.Value != 1540.0 {
+		t.Errorf("Value = %v, want 1540", found.Value)
+	}
+}
+
+func TestPersonalRecordRepository_FindByUserID(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	other := CreateUser(t, ctx, repos.User)
+	bench := CreateExercise(t, ctx, repos.Exercise)
+	squat := CreateExercise(t, ctx, repos.Exercise)
+
+	older := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)))
+	newer := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)))
+	otherWorkout := CreateWorkout(t, ctx, repos.Workout, other.ID)
+
+	for _, r := range []*entity.PersonalRecord{
+		entity.NewPersonalRecord(user.ID, bench.ID, older.ID, nil, entity.PersonalRecordTypeSessionVolume, nil, 600.0, nil, older.Date),
+		entity.NewPersonalRecord(user.ID, squat.ID, newer.ID, nil, entity.PersonalRecordTypeSessionVolume, nil, 800.0, nil, newer.Date),
+		entity.NewPersonalRecord(other.ID, bench.ID, otherWorkout.ID, nil, entity.PersonalRecordTypeSessionVolume, nil, 900.0, nil, otherWorkout.Date),
+	} {
+		if err := repos.PersonalRecord.Create(ctx, r); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	records, err := repos.PersonalRecord.FindByUserID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("FindByUserID() error = %v", err)
+	}
+
+	// 他ユーザーの記録は含まない
+	if len(records) != 2 {
+		t.Fatalf("FindByUserID() returned %d records, want 2", len(records))
+	}
+
+	// 達成日の降順
+	if records[0].WorkoutID != newer.ID || records[1].WorkoutID != older.ID {
+		t.Error("FindByUserID() records are not sorted by achieved date descending")
+	}
+
+	benchRecords, err := repos.PersonalRecord.FindByUserIDAndExerciseID(ctx, user.ID, bench.ID)
+	if err != nil {
+		t.Fatalf("FindByUserIDAndExerciseID() error = %v", err)
+	}
+	if len(benchRecords) != 1 {
+		t.Errorf("FindByUserIDAndExerciseID() returned %d records, want 1", len(benchRecords))
+	}
+}
+
+func TestPersonalRecordRepository_DeleteByUserIDAndExerciseID(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	other := CreateUser(t, ctx, repos.User)
+	exercise := CreateExercise(t, ctx, repos.Exercise)
+	otherExercise := CreateExercise(t, ctx, repos.Exercise)
+	date := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	workout := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(date))
+	otherWorkout := CreateWorkout(t, ctx, repos.Workout, other.ID, WithDate(date))
+
+	for _, record := range []*entity.PersonalRecord{
+		entity.NewPersonalRecord(user.ID, exercise.ID, workout.ID, nil, entity.PersonalRecordTypeSessionVolume, nil, 1000.0, nil, date),
+		entity.NewPersonalRecord(user.ID, otherExercise.ID, workout.ID, nil, entity.PersonalRecordTypeSessionVolume, nil, 500.0, nil, date),
+		entity.NewPersonalRecord(other.ID, exercise.ID, otherWorkout.ID, nil, entity.PersonalRecordTypeSessionVolume, nil, 800.0, nil, date),
+	} {
+		if err := repos.PersonalRecord.Create(ctx, record); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	if err := repos.PersonalRecord.DeleteByUserIDAndExerciseID(ctx, user.ID, exercise.ID); err != nil {
+		t.Fatalf("DeleteByUserIDAndExerciseID() error = %v", err)
+	}
+
+	// 対象のユーザー・種目の記録のみ削除される
+	records, err := repos.PersonalRecord.FindByUserID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("FindByUserID() error = %v", err)
+	}
+	if len(records) != 1 || records[0].ExerciseID != otherExercise.ID {
+		t.Errorf("FindByUserID() returned %d records, want only the other exercise", len(records))
+	}
+	otherRecords, err := repos.PersonalRecord.FindByUserID(ctx, other.ID)
+	if err != nil {
+		t.Fatalf("FindByUserID() error = %v", err)
+	}
+	if len(otherRecords) != 1 {
+		t.Errorf("other user's records = %d, want 1", len(otherRecords))
+	}
+}
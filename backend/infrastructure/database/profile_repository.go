@@ -26,17 +26,23 @@ func NewProfileRepository(conn *sql.DB) repository.ProfileRepository {
 // Create はプロフィールを作成する
 func (r *profileRepository) Create(ctx context.Context, profile *entity.Profile) error {
 	params := db.CreateProfileParams{
-		ID:          profile.ID,
-		UserID:      profile.UserID,
-		DisplayName: profile.DisplayName,
-		Age:         toNullInt32(profile.Age),
-		Weight:      float64ToNullString(profile.Weight),
-		Height:      float64ToNullString(profile.Height),
-		CreatedAt:   profile.CreatedAt,
-		UpdatedAt:   profile.UpdatedAt,
+		ID:                        profile.ID,
+		UserID:                    profile.UserID,
+		DisplayName:               profile.DisplayName,
+		Age:                       toNullInt32(profile.Age),
+		Weight:                    float64ToNullString(profile.Weight),
+		Height:                    float64ToNullString(profile.Height),
+		OneRmFormula:              string(profile.OneRMFormula),
+		Timezone:                  profile.Timezone,
+		WeeklyWorkoutGoal:         profile.WeeklyWorkoutGoal,
+		RestDayTolerance:          profile.RestDayTolerance,
+		DailyScoreStrategy:        string(profile.DailyScoreStrategy),
+		DailyScoreBackfillPending: profile.DailyScoreBackfillPending,
+		CreatedAt:                 profile.CreatedAt,
+		UpdatedAt:                 profile.UpdatedAt,
 	}
 
-	created, err := r.queries.CreateProfile(ctx, params)
+	created, err := queriesFromContext(ctx, r.queries).CreateProfile(ctx, params)
 	if err != nil {
 		return err
 	}
@@ -49,7 +55,7 @@ func (r *profileRepository) Create(ctx context.Context, profile *entity.Profile)
 
 // FindByID はIDでプロフィールを取得する
 func (r *profileRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Profile, error) {
-	dbProfile, err := r.queries.GetProfile(ctx, id)
+	dbProfile, err := queriesFromContext(ctx, r.queries).GetProfile(ctx, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -62,7 +68,7 @@ func (r *profileRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity
 
 // FindByUserID はユーザーIDでプロフィールを取得する
 func (r *profileRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*entity.Profile, error) {
-	dbProfile, err := r.queries.GetProfileByUserID(ctx, userID)
+	dbProfile, err := queriesFromContext(ctx, r.queries).GetProfileByUserID(ctx, userID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -76,14 +82,20 @@ func (r *profileRepository) FindByUserID(ctx context.Context, userID uuid.UUID)
 // Update はプロフィールを更新する
 func (r *profileRepository) Update(ctx context.Context, profile *entity.Profile) error {
 	params := db.UpdateProfileParams{
-		ID:          profile.ID,
-		DisplayName: profile.DisplayName,
-		Age:         toNullInt32(profile.Age),
-		Weight:      float64ToNullString(profile.Weight),
-		Height:      float64ToNullString(profile.Height),
+		ID:                        profile.ID,
+		DisplayName:               profile.DisplayName,
+		Age:                       toNullInt32(profile.Age),
+		Weight:                    float64ToNullString(profile.Weight),
+		Height:                    float64ToNullString(profile.Height),
+		OneRmFormula:              string(profile.OneRMFormula),
+		Timezone:                  profile.Timezone,
+		WeeklyWorkoutGoal:         profile.WeeklyWorkoutGoal,
+		RestDayTolerance:          profile.RestDayTolerance,
+		DailyScoreStrategy:        string(profile.DailyScoreStrategy),
+		DailyScoreBackfillPending: profile.DailyScoreBackfillPending,
 	}
 
-	updated, err := r.queries.UpdateProfile(ctx, params)
+	updated, err := queriesFromContext(ctx, r.queries).UpdateProfile(ctx, params)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil
@@ -98,12 +110,35 @@ func (r *profileRepository) Update(ctx context.Context, profile *entity.Profile)
 
 // Delete はプロフィールを削除する
 func (r *profileRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.queries.DeleteProfile(ctx, id)
+	return queriesFromContext(ctx, r.queries).DeleteProfile(ctx, id)
 }
 
 // ExistsByUserID はユーザーIDでプロフィールの存在を確認する
 func (r *profileRepository) ExistsByUserID(ctx context.Context, userID uuid.UUID) (bool, error) {
-	return r.queries.ExistsProfileByUserID(ctx, userID)
+	return queriesFromContext(ctx, r.queries).ExistsProfileByUserID(ctx, userID)
+}
+
+// FindPendingDailyScoreBackfill はデイリースコアの再計算が必要なプロフィールを取得する
+func (r *profileRepository) FindPendingDailyScoreBackfill(ctx context.Context) ([]*entity.Profile, error) {
+	dbProfiles, err := queriesFromContext(ctx, r.queries).ListProfilesPendingDailyScoreBackfill(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make([]*entity.Profile, len(dbProfiles))
+	for i, p := range dbProfiles {
+		profiles[i] = toProfileEntity(p)
+	}
+	return profiles, nil
+}
+
+// CompleteDailyScoreBackfill はデイリースコアの再計算待ちを解除する。
+// 再計算中に計算方法が再度変更された場合は解除せず、次回の再計算の対象とする。
+func (r *profileRepository) CompleteDailyScoreBackfill(ctx context.Context, userID uuid.UUID, strategy entity.DailyScoreStrategyType) error {
+	return queriesFromContext(ctx, r.queries).CompleteDailyScoreBackfill(ctx, db.CompleteDailyScoreBackfillParams{
+		UserID:             userID,
+		DailyScoreStrategy: string(strategy),
+	})
 }
 
 // toProfileEntity はDB層のProfileをドメイン層のProfileエンティティに変換する
@@ -115,6 +150,12 @@ func toProfileEntity(p db.Profile) *entity.Profile {
 		fromNullInt32(p.Age),
 		nullStringToFloat64(p.Weight),
 		nullStringToFloat64(p.Height),
+		entity.OneRMFormula(p.OneRmFormula),
+		p.Timezone,
+		p.WeeklyWorkoutGoal,
+		p.RestDayTolerance,
+		entity.DailyScoreStrategyType(p.DailyScoreStrategy),
+		p.DailyScoreBackfillPending,
 		p.CreatedAt,
 		p.UpdatedAt,
 	)
@@ -167,6 +167,19 @@ func TestProfileRepository_Update(t *testing.T) {
 	age := int32(30)
 	profile.Age = &age
 
+	if err := profile.UpdateOneRMFormula(entity.OneRMFormulaMayhew); err != nil {
+		t.Fatalf("UpdateOneRMFormula() error = %v", err)
+	}
+	if err := profile.UpdateTimezone("Asia/Tokyo"); err != nil {
+		t.Fatalf("UpdateTimezone() error = %v", err)
+	}
+	if err := profile.UpdateWeeklyWorkoutGoal(5); err != nil {
+		t.Fatalf("UpdateWeeklyWorkoutGoal() error = %v", err)
+	}
+	if err := profile.UpdateRestDayTolerance(0); err != nil {
+		t.Fatalf("UpdateRestDayTolerance() error = %v", err)
+	}
+
 	err = repos.Profile.Update(ctx, profile)
 	if err != nil {
 		t.Fatalf("Update() error = %v", err)
@@ -180,6 +193,15 @@ func TestProfileRepository_Update(t *testing.T) {
 	if found.Age == nil || *found.Age != 30 {
 		t.Errorf("Update() Age = %v, want 30", found.Age)
 	}
+	if found.OneRMFormula != entity.OneRMFormulaMayhew {
+		t.Errorf("Update() OneRMFormula = %v, want mayhew", found.OneRMFormula)
+	}
+	if found.Timezone != "Asia/Tokyo" {
+		t.Errorf("Update() Timezone = %v, want Asia/Tokyo", found.Timezone)
+	}
+	if found.WeeklyWorkoutGoal != 5 || found.RestDayTolerance != 0 {
+		t.Errorf("Update() WeeklyWorkoutGoal = %v, RestDayTolerance = %v, want 5, 0", found.WeeklyWorkoutGoal, found.RestDayTolerance)
+	}
 }
 
 func TestProfileRepository_Delete(t *testing.T) {
@@ -243,3 +265,57 @@ func TestProfileRepository_ExistsByUserID(t *testing.T) {
 		})
 	}
 }
+
+func TestProfileRepository_DailyScoreBackfill(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repos := SetupRepos(db)
+	ctx := context.Background()
+
+	pendingUser := CreateUser(t, ctx, repos.User)
+	pending := CreateProfile(t, ctx, repos.Profile, pendingUser.ID)
+	if err := pending.UpdateDailyScoreStrategy(entity.DailyScoreStrategyRollingAverage); err != nil {
+		t.Fatalf("UpdateDailyScoreStrategy() error = %v", err)
+	}
+	if err := repos.Profile.Update(ctx, pending); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	otherUser := CreateUser(t, ctx, repos.User)
+	CreateProfile(t, ctx, repos.Profile, otherUser.ID)
+
+	profiles, err := repos.Profile.FindPendingDailyScoreBackfill(ctx)
+	if err != nil {
+		t.Fatalf("FindPendingDailyScoreBackfill() error = %v", err)
+	}
+	if len(profiles) != 1 || profiles[0].ID != pending.ID {
+		t.Fatalf("FindPendingDailyScoreBackfill() = %v, want only profile %v", profiles, pending.ID)
+	}
+	if profiles[0].DailyScoreStrategy != entity.DailyScoreStrategyRollingAverage {
+		t.Errorf("DailyScoreStrategy = %v, want %v", profiles[0].DailyScoreStrategy, entity.DailyScoreStrategyRollingAverage)
+	}
+
+	// 再計算中に計算方法が変更された場合は再計算待ちを解除しない
+	if err := repos.Profile.CompleteDailyScoreBackfill(ctx, pendingUser.ID, entity.DailyScoreStrategyBodyweightRelative); err != nil {
+		t.Fatalf("CompleteDailyScoreBackfill() error = %v", err)
+	}
+	profiles, err = repos.Profile.FindPendingDailyScoreBackfill(ctx)
+	if err != nil {
+		t.Fatalf("FindPendingDailyScoreBackfill() error = %v", err)
+	}
+	if len(profiles) != 1 {
+		t.Fatalf("FindPendingDailyScoreBackfill() returned %d profiles, want 1", len(profiles))
+	}
+
+	if err := repos.Profile.CompleteDailyScoreBackfill(ctx, pendingUser.ID, entity.DailyScoreStrategyRollingAverage); err != nil {
+		t.Fatalf("CompleteDailyScoreBackfill() error = %v", err)
+	}
+	profiles, err = repos.Profile.FindPendingDailyScoreBackfill(ctx)
+	if err != nil {
+		t.Fatalf("FindPendingDailyScoreBackfill() error = %v", err)
+	}
+	if len(profiles) != 0 {
+		t.Errorf("FindPendingDailyScoreBackfill() returned %d profiles, want 0", len(profiles))
+	}
+}
@@ -0,0 +1,241 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+	"github.com/ucchy108/whiskey/backend/sqlc/db"
+)
+
+// programRepository はProgramRepositoryインターフェースのPostgreSQL実装。
+// プログラム本体（programs）、日程（program_days）、セット処方（program_sets）をまとめて扱う。
+type programRepository struct {
+	queries *db.Queries
+}
+
+// NewProgramRepository はProgramRepositoryの実装を生成する。
+//
+// パラメータ:
+//   - conn: PostgreSQLデータベース接続
+//
+// 戻り値:
+//   - repository.ProgramRepository: プログラムリポジトリの実装
+func NewProgramRepository(conn *sql.DB) repository.ProgramRepository {
+	return &programRepository{
+		queries: db.New(conn),
+	}
+}
+
+// Create はプログラムとその日程・セット処方を作成する。
+// DB生成のID、CreatedAt、UpdatedAtが元のエンティティに反映される。
+// 日程はDaysの並び順（day_index）、セットは各日のSetsの並び順（position）で保存される。
+func (r *programRepository) Create(ctx context.Context, program *entity.Program) error {
+	q := queriesFromContext(ctx, r.queries)
+
+	created, err := q.CreateProgram(ctx, db.CreateProgramParams{
+		UserID:      program.UserID,
+		Name:        program.Name,
+		Description: toNullString(program.Description),
+	})
+	if err != nil {
+		return err
+	}
+
+	program.ID = created.ID
+	program.CurrentDayIndex = created.CurrentDayIndex
+	program.CreatedAt = created.CreatedAt
+	program.UpdatedAt = created.UpdatedAt
+
+	for dayIndex, day := range program.Days {
+		if err := q.CreateProgramDay(ctx, db.CreateProgramDayParams{
+			ProgramID:  program.ID,
+			DayIndex:   int32(dayIndex),
+			WeekNumber: day.Week,
+			DayNumber:  day.Day,
+		}); err != nil {
+			return err
+		}
+
+		for position, set := range day.Sets {
+			if err := q.CreateProgramSet(ctx, db.CreateProgramSetParams{
+				ProgramID:     program.ID,
+				DayIndex:      int32(dayIndex),
+				Position:      int32(position),
+				ExerciseID:    set.ExerciseID,
+				Reps:          set.Reps,
+				Percentage1rm: formatFloat(set.PercentageOf1RM),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// FindByID はIDでプログラムとその日程・セット処方を取得する。
+// 該当するプログラムが存在しない場合はnilを返す。
+func (r *programRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Program, error) {
+	q := queriesFromContext(ctx, r.queries)
+
+	dbProgram, err := q.GetProgram(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	dbDays, err := q.ListProgramDaysByProgram(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	dbSets, err := q.ListProgramSetsByProgram(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	days, err := toProgramDays(dbDays, dbSets)
+	if err != nil {
+		return nil, err
+	}
+
+	return toProgramEntity(dbProgram, days), nil
+}
+
+// FindByUserID はユーザーの全プログラムとその日程・セット処方を取得する。
+// 結果は作成日時の降順でソートされる。
+func (r *programRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Program, error) {
+	q := queriesFromContext(ctx, r.queries)
+
+	dbPrograms, err := q.ListProgramsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dbDays, err := q.ListProgramDaysByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dbSets, err := q.ListProgramSetsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	daysByProgram := make(map[uuid.UUID][]db.ProgramDay)
+	for _, d := range dbDays {
+		daysByProgram[d.ProgramID] = append(daysByProgram[d.ProgramID], d)
+	}
+	setsByProgram := make(map[uuid.UUID][]db.ProgramSet)
+	for _, s := range dbSets {
+		setsByProgram[s.ProgramID] = append(setsByProgram[s.ProgramID], s)
+	}
+
+	programs := make([]*entity.Program, len(dbPrograms))
+	for i, dbProgram := range dbPrograms {
+		days, err := toProgramDays(daysByProgram[dbProgram.ID], setsByProgram[dbProgram.ID])
+		if err != nil {
+			return nil, err
+		}
+		programs[i] = toProgramEntity(dbProgram, days)
+	}
+
+	return programs, nil
+}
+
+// UpdateProgress はプログラムの現在の日（CurrentDayIndex）を更新する。
+// UpdatedAtが元のエンティティに反映される。
+// 該当するプログラムが存在しない場合はnilを返す。
+func (r *programRepository) UpdateProgress(ctx context.Context, program *entity.Program) error {
+	updated, err := queriesFromContext(ctx, r.queries).UpdateProgramProgress(ctx, db.UpdateProgramProgressParams{
+		ID:              program.ID,
+		CurrentDayIndex: program.CurrentDayIndex,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	program.UpdatedAt = updated.UpdatedAt
+	return nil
+}
+
+// Delete はプログラムを削除する（日程・セット処方はON DELETE CASCADEで削除される）
+func (r *programRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return queriesFromContext(ctx, r.queries).DeleteProgram(ctx, id)
+}
+
+// LinkWorkout はワークアウトをプログラムの日として記録したことを保存する
+func (r *programRepository) LinkWorkout(ctx context.Context, link *entity.ProgramWorkout) error {
+	return queriesFromContext(ctx, r.queries).CreateProgramWorkout(ctx, db.CreateProgramWorkoutParams{
+		WorkoutID: link.WorkoutID,
+		ProgramID: link.ProgramID,
+		DayIndex:  link.DayIndex,
+	})
+}
+
+// FindWorkoutLink はワークアウトのプログラムへの紐付けを取得する。
+// プログラムに紐付いていないワークアウトの場合はnilを返す。
+func (r *programRepository) FindWorkoutLink(ctx context.Context, workoutID uuid.UUID) (*entity.ProgramWorkout, error) {
+	link, err := queriesFromContext(ctx, r.queries).GetProgramWorkout(ctx, workoutID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &entity.ProgramWorkout{
+		WorkoutID: link.WorkoutID,
+		ProgramID: link.ProgramID,
+		DayIndex:  link.DayIndex,
+	}, nil
+}
+
+// toProgramEntity はDB層のProgramをDomain層のProgramに変換する
+func toProgramEntity(p db.Program, days []entity.ProgramDay) *entity.Program {
+	return entity.ReconstructProgram(
+		p.ID,
+		p.UserID,
+		p.Name,
+		fromNullString(p.Description),
+		days,
+		p.CurrentDayIndex,
+		p.CreatedAt,
+		p.UpdatedAt,
+	)
+}
+
+// toProgramDays はday_index順の日程とセット処方を、Domain層のProgramDayスライスに組み立てる
+func toProgramDays(dbDays []db.ProgramDay, dbSets []db.ProgramSet) ([]entity.ProgramDay, error) {
+	setsByDay := make(map[int32][]entity.ProgramSet, len(dbDays))
+	for _, s := range dbSets {
+		percentage, err := parseFloat(s.Percentage1rm)
+		if err != nil {
+			return nil, err
+		}
+		setsByDay[s.DayIndex] = append(setsByDay[s.DayIndex], entity.ProgramSet{
+			ExerciseID:      s.ExerciseID,
+			Reps:            s.Reps,
+			PercentageOf1RM: percentage,
+		})
+	}
+
+	days := make([]entity.ProgramDay, len(dbDays))
+	for i, d := range dbDays {
+		days[i] = entity.ProgramDay{
+			Week: d.WeekNumber,
+			Day:  d.DayNumber,
+			Sets: setsByDay[d.DayIndex],
+		}
+	}
+	return days, nil
+}
@@ -0,0 +1,204 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+)
+
+func TestProgramRepository_CreateAndFindByID(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	squat := CreateExercise(t, ctx, repos.Exercise)
+	bench := CreateExercise(t, ctx, repos.Exercise)
+
+	program, err := entity.NewProgram(user.ID, "5/3/1", nil, []entity.ProgramDay{
+		{Week: 1, Day: 1, Sets: []entity.ProgramSet{
+			{ExerciseID: squat.ID, Reps: 5, PercentageOf1RM: 65},
+			{ExerciseID: squat.ID, Reps: 5, PercentageOf1RM: 75},
+			{ExerciseID: squat.ID, Reps: 5, PercentageOf1RM: 85},
+		}},
+		{Week: 1, Day: 2, Sets: []entity.ProgramSet{
+			{ExerciseID: bench.ID, Reps: 5, PercentageOf1RM: 65},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("NewProgram() error = %v", err)
+	}
+
+	if err := repos.Program.Create(ctx, program); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	found, err := repos.Program.FindByID(ctx, program.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found == nil {
+		t.Fatal("FindByID() returned nil")
+	}
+	if found.Name != "5/3/1" || found.CurrentDayIndex != 0 {
+		t.Errorf("FindByID() = %+v, unexpected name/current day", found)
+	}
+	if len(found.Days) != 2 {
+		t.Fatalf("Days length = %d, want 2", len(found.Days))
+	}
+	// 日程とセットの並び順が保存されていること
+	if found.Days[0].Week != 1 || found.Days[0].Day != 1 || len(found.Days[0].Sets) != 3 {
+		t.Errorf("Days[0] = %+v, want week 1 day 1 with 3 sets", found.Days[0])
+	}
+	if found.Days[0].Sets[2].PercentageOf1RM != 85 {
+		t.Errorf("Days[0].Sets[2].PercentageOf1RM = %v, want 85", found.Days[0].Sets[2].PercentageOf1RM)
+	}
+	if found.Days[1].Sets[0].ExerciseID != bench.ID {
+		t.Errorf("Days[1].Sets[0].ExerciseID = %v, want %v", found.Days[1].Sets[0].ExerciseID, bench.ID)
+	}
+}
+
+func TestProgramRepository_FindByUserID(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	otherUser := CreateUser(t, ctx, repos.User)
+	exercise := CreateExercise(t, ctx, repos.Exercise)
+
+	create := func(name string, owner *entity.User, weeks int32) {
+		var days []entity.ProgramDay
+		for w := int32(1); w <= weeks; w++ {
+			days = append(days, entity.ProgramDay{Week: w, Day: 1, Sets: []entity.ProgramSet{
+				{ExerciseID: exercise.ID, Reps: 5, PercentageOf1RM: 70},
+			}})
+		}
+		program, err := entity.NewProgram(owner.ID, name, nil, days)
+		if err != nil {
+			t.Fatalf("NewProgram() error = %v", err)
+		}
+		if err := repos.Program.Create(ctx, program); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	create("Linear A", user, 2)
+	create("Linear B", user, 3)
+	create("Other", otherUser, 1)
+
+	programs, err := repos.Program.FindByUserID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("FindByUserID() error = %v", err)
+	}
+	if len(programs) != 2 {
+		t.Fatalf("FindByUserID() returned %d programs, want 2", len(programs))
+	}
+	for _, p := range programs {
+		if p.UserID != user.ID {
+			t.Errorf("program %s belongs to %v, want %v", p.Name, p.UserID, user.ID)
+		}
+		want := map[string]int{"Linear A": 2, "Linear B": 3}[p.Name]
+		if len(p.Days) != want {
+			t.Errorf("program %s has %d days, want %d", p.Name, len(p.Days), want)
+		}
+	}
+}
+
+func TestProgramRepository_UpdateProgressAndDelete(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	exercise := CreateExercise(t, ctx, repos.Exercise)
+
+	program, err := entity.NewProgram(user.ID, "Linear", nil, []entity.ProgramDay{
+		{Week: 1, Day: 1, Sets: []entity.ProgramSet{{ExerciseID: exercise.ID, Reps: 5, PercentageOf1RM: 70}}},
+		{Week: 2, Day: 1, Sets: []entity.ProgramSet{{ExerciseID: exercise.ID, Reps: 5, PercentageOf1RM: 75}}},
+	})
+	if err != nil {
+		t.Fatalf("NewProgram() error = %v", err)
+	}
+	if err := repos.Program.Create(ctx, program); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	program.AdvanceIfSatisfied(0, []uuid.UUID{exercise.ID})
+	if err := repos.Program.UpdateProgress(ctx, program); err != nil {
+		t.Fatalf("UpdateProgress() error = %v", err)
+	}
+
+	found, err := repos.Program.FindByID(ctx, program.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found.CurrentDayIndex != 1 {
+		t.Errorf("CurrentDayIndex = %d, want 1", found.CurrentDayIndex)
+	}
+
+	if err := repos.Program.Delete(ctx, program.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	deleted, err := repos.Program.FindByID(ctx, program.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if deleted != nil {
+		t.Errorf("FindByID() after delete = %+v, want nil", deleted)
+	}
+}
+
+func TestProgramRepository_LinkWorkout(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	exercise := CreateExercise(t, ctx, repos.Exercise)
+	workout := CreateWorkout(t, ctx, repos.Workout, user.ID)
+	unlinked := CreateWorkout(t, ctx, repos.Workout, user.ID)
+
+	program, err := entity.NewProgram(user.ID, "Linear", nil, []entity.ProgramDay{
+		{Week: 1, Day: 1, Sets: []entity.ProgramSet{{ExerciseID: exercise.ID, Reps: 5, PercentageOf1RM: 70}}},
+	})
+	if err != nil {
+		t.Fatalf("NewProgram() error = %v", err)
+	}
+	if err := repos.Program.Create(ctx, program); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if err := repos.Program.LinkWorkout(ctx, &entity.ProgramWorkout{
+		WorkoutID: workout.ID,
+		ProgramID: program.ID,
+		DayIndex:  0,
+	}); err != nil {
+		t.Fatalf("LinkWorkout() error = %v", err)
+	}
+
+	link, err := repos.Program.FindWorkoutLink(ctx, workout.ID)
+	if err != nil {
+		t.Fatalf("FindWorkoutLink() error = %v", err)
+	}
+	if link == nil || link.ProgramID != program.ID || link.DayIndex != 0 {
+		t.Errorf("FindWorkoutLink() = %+v, want program %v day 0", link, program.ID)
+	}
+
+	none, err := repos.Program.FindWorkoutLink(ctx, unlinked.ID)
+	if err != nil {
+		t.Fatalf("FindWorkoutLink() error = %v", err)
+	}
+	if none != nil {
+		t.Errorf("FindWorkoutLink() for unlinked workout = %+v, want nil", none)
+	}
+}
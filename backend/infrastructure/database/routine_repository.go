@@ -0,0 +1,192 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+	"github.com/ucchy108/whiskey/backend/sqlc/db"
+)
+
+// routineRepository はRoutineRepositoryインターフェースのPostgreSQL実装。
+// ルーティン本体（routines）と種目の目標（routine_exercises）をまとめて扱う。
+type routineRepository struct {
+	queries *db.Queries
+}
+
+// NewRoutineRepository はRoutineRepositoryの実装を生成する。
+//
+// パラメータ:
+//   - conn: PostgreSQLデータベース接続
+//
+// 戻り値:
+//   - repository.RoutineRepository: ルーティンリポジトリの実装
+func NewRoutineRepository(conn *sql.DB) repository.RoutineRepository {
+	return &routineRepository{
+		queries: db.New(conn),
+	}
+}
+
+// Create はルーティンとその種目を作成する。
+// DB生成のID、CreatedAt、UpdatedAtが元のエンティティに反映される。
+// 種目はExercisesの並び順で保存される。
+func (r *routineRepository) Create(ctx context.Context, routine *entity.Routine) error {
+	q := queriesFromContext(ctx, r.queries)
+
+	created, err := q.CreateRoutine(ctx, db.CreateRoutineParams{
+		UserID:      routine.UserID,
+		Name:        routine.Name,
+		Description: toNullString(routine.Description),
+	})
+	if err != nil {
+		return err
+	}
+
+	routine.ID = created.ID
+	routine.CreatedAt = created.CreatedAt
+	routine.UpdatedAt = created.UpdatedAt
+
+	return createRoutineExercises(ctx, q, routine.ID, routine.Exercises)
+}
+
+// FindByID はIDでルーティンとその種目を取得する。
+// 該当するルーティンが存在しない場合はnilを返す。
+func (r *routineRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Routine, error) {
+	q := queriesFromContext(ctx, r.queries)
+
+	dbRoutine, err := q.GetRoutine(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	dbExercises, err := q.ListRoutineExercisesByRoutine(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	exercises, err := toRoutineExercises(dbExercises)
+	if err != nil {
+		return nil, err
+	}
+
+	return toRoutineEntity(dbRoutine, exercises), nil
+}
+
+// FindByUserID はユーザーの全ルーティンとその種目を取得する。
+// 結果は作成日時の降順でソートされる。
+func (r *routineRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Routine, error) {
+	q := queriesFromContext(ctx, r.queries)
+
+	dbRoutines, err := q.ListRoutinesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dbExercises, err := q.ListRoutineExercisesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	exercisesByRoutine := make(map[uuid.UUID][]db.RoutineExercise)
+	for _, re := range dbExercises {
+		exercisesByRoutine[re.RoutineID] = append(exercisesByRoutine[re.RoutineID], re)
+	}
+
+	routines := make([]*entity.Routine, len(dbRoutines))
+	for i, dbRoutine := range dbRoutines {
+		exercises, err := toRoutineExercises(exercisesByRoutine[dbRoutine.ID])
+		if err != nil {
+			return nil, err
+		}
+		routines[i] = toRoutineEntity(dbRoutine, exercises)
+	}
+
+	return routines, nil
+}
+
+// Update はルーティンの名前・説明を更新し、種目を置き換える。
+// UpdatedAtが元のエンティティに反映される。
+// 該当するルーティンが存在しない場合はnilを返す。
+func (r *routineRepository) Update(ctx context.Context, routine *entity.Routine) error {
+	q := queriesFromContext(ctx, r.queries)
+
+	updated, err := q.UpdateRoutine(ctx, db.UpdateRoutineParams{
+		ID:          routine.ID,
+		Name:        routine.Name,
+		Description: toNullString(routine.Description),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+
+	routine.UpdatedAt = updated.UpdatedAt
+
+	if err := q.DeleteRoutineExercisesByRoutine(ctx, routine.ID); err != nil {
+		return err
+	}
+
+	return createRoutineExercises(ctx, q, routine.ID, routine.Exercises)
+}
+
+// Delete はルーティンを削除する（種目はON DELETE CASCADEで削除される）
+func (r *routineRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return queriesFromContext(ctx, r.queries).DeleteRoutine(ctx, id)
+}
+
+// createRoutineExercises はルーティンの種目を並び順（position）付きで保存する
+func createRoutineExercises(ctx context.Context, q *db.Queries, routineID uuid.UUID, exercises []entity.RoutineExercise) error {
+	for i, re := range exercises {
+		params := db.CreateRoutineExerciseParams{
+			RoutineID:    routineID,
+			Position:     int32(i),
+			ExerciseID:   re.ExerciseID,
+			TargetSets:   re.TargetSets,
+			TargetReps:   re.TargetReps,
+			TargetWeight: formatFloat(re.TargetWeight),
+		}
+		if err := q.CreateRoutineExercise(ctx, params); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toRoutineEntity はDB層のRoutineをDomain層のRoutineに変換する
+func toRoutineEntity(r db.Routine, exercises []entity.RoutineExercise) *entity.Routine {
+	return entity.ReconstructRoutine(
+		r.ID,
+		r.UserID,
+		r.Name,
+		fromNullString(r.Description),
+		exercises,
+		r.CreatedAt,
+		r.UpdatedAt,
+	)
+}
+
+// toRoutineExercises はDB層のRoutineExerciseスライスをDomain層のRoutineExerciseスライスに変換する
+func toRoutineExercises(dbExercises []db.RoutineExercise) ([]entity.RoutineExercise, error) {
+	exercises := make([]entity.RoutineExercise, len(dbExercises))
+	for i, re := range dbExercises {
+		weight, err := parseFloat(re.TargetWeight)
+		if err != nil {
+			return nil, err
+		}
+		exercises[i] = entity.RoutineExercise{
+			ExerciseID:   re.ExerciseID,
+			TargetSets:   re.TargetSets,
+			TargetReps:   re.TargetReps,
+			TargetWeight: weight,
+		}
+	}
+	return exercises, nil
+}
@@ -0,0 +1,172 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+)
+
+func TestRoutineRepository_CreateAndFindByID(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	bench := CreateExercise(t, ctx, repos.Exercise)
+	dips := CreateExercise(t, ctx, repos.Exercise)
+
+	description := "Chest and triceps"
+	routine, err := entity.NewRoutine(user.ID, "Push", &description, []entity.RoutineExercise{
+		{ExerciseID: bench.ID, TargetSets: 5, TargetReps: 5, TargetWeight: 80.0},
+		{ExerciseID: dips.ID, TargetSets: 3, TargetReps: 12, TargetWeight: 0},
+	})
+	if err != nil {
+		t.Fatalf("NewRoutine() error = %v", err)
+	}
+
+	if err := repos.Routine.Create(ctx, routine); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	found, err := repos.Routine.FindByID(ctx, routine.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found == nil {
+		t.Fatal("FindByID() returned nil")
+	}
+	if found.Name != "Push" || found.Description == nil || *found.Description != description {
+		t.Errorf("FindByID() = %+v, unexpected name/description", found)
+	}
+	if len(found.Exercises) != 2 {
+		t.Fatalf("Exercises length = %d, want 2", len(found.Exercises))
+	}
+	// 並び順が保存されていること
+	if found.Exercises[0].ExerciseID != bench.ID || found.Exercises[1].ExerciseID != dips.ID {
+		t.Errorf("Exercises order = %+v, want bench then dips", found.Exercises)
+	}
+	if found.Exercises[0].TargetSets != 5 || found.Exercises[0].TargetReps != 5 || found.Exercises[0].TargetWeight != 80.0 {
+		t.Errorf("Exercises[0] = %+v, unexpected targets", found.Exercises[0])
+	}
+}
+
+func TestRoutineRepository_FindByID_NotFound(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+
+	found, err := repos.Routine.FindByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found != nil {
+		t.Errorf("FindByID() = %+v, want nil", found)
+	}
+}
+
+func TestRoutineRepository_FindByUserID(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	otherUser := CreateUser(t, ctx, repos.User)
+	exercise := CreateExercise(t, ctx, repos.Exercise)
+
+	create := func(name string, owner *entity.User, sets int32) {
+		routine, err := entity.NewRoutine(owner.ID, name, nil, []entity.RoutineExercise{
+			{ExerciseID: exercise.ID, TargetSets: sets, TargetReps: 10, TargetWeight: 50.0},
+		})
+		if err != nil {
+			t.Fatalf("NewRoutine() error = %v", err)
+		}
+		if err := repos.Routine.Create(ctx, routine); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	create("Push", user, 3)
+	create("Pull", user, 4)
+	create("Legs", otherUser, 5)
+
+	routines, err := repos.Routine.FindByUserID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("FindByUserID() error = %v", err)
+	}
+	if len(routines) != 2 {
+		t.Fatalf("FindByUserID() returned %d routines, want 2", len(routines))
+	}
+	for _, r := range routines {
+		if r.UserID != user.ID {
+			t.Errorf("routine %s belongs to %v, want %v", r.Name, r.UserID, user.ID)
+		}
+		if len(r.Exercises) != 1 {
+			t.Errorf("routine %s has %d exercises, want 1", r.Name, len(r.Exercises))
+		}
+	}
+}
+
+func TestRoutineRepository_UpdateAndDelete(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	squat := CreateExercise(t, ctx, repos.Exercise)
+	lunge := CreateExercise(t, ctx, repos.Exercise)
+
+	routine, err := entity.NewRoutine(user.ID, "Legs", nil, []entity.RoutineExercise{
+		{ExerciseID: squat.ID, TargetSets: 5, TargetReps: 5, TargetWeight: 100.0},
+	})
+	if err != nil {
+		t.Fatalf("NewRoutine() error = %v", err)
+	}
+	if err := repos.Routine.Create(ctx, routine); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if err := routine.UpdateName("Legs A"); err != nil {
+		t.Fatalf("UpdateName() error = %v", err)
+	}
+	if err := routine.ReplaceExercises([]entity.RoutineExercise{
+		{ExerciseID: lunge.ID, TargetSets: 3, TargetReps: 12, TargetWeight: 20.0},
+		{ExerciseID: squat.ID, TargetSets: 3, TargetReps: 8, TargetWeight: 90.0},
+	}); err != nil {
+		t.Fatalf("ReplaceExercises() error = %v", err)
+	}
+	if err := repos.Routine.Update(ctx, routine); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	found, err := repos.Routine.FindByID(ctx, routine.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found.Name != "Legs A" {
+		t.Errorf("Name = %v, want Legs A", found.Name)
+	}
+	if len(found.Exercises) != 2 || found.Exercises[0].ExerciseID != lunge.ID {
+		t.Errorf("Exercises = %+v, want lunge then squat", found.Exercises)
+	}
+
+	if err := repos.Routine.Delete(ctx, routine.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	deleted, err := repos.Routine.FindByID(ctx, routine.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if deleted != nil {
+		t.Errorf("FindByID() after delete = %+v, want nil", deleted)
+	}
+}
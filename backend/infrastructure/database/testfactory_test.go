@@ -16,21 +16,31 @@ import (
 
 // Repos はテスト用リポジトリ群をまとめた構造体
 type Repos struct {
-	User       repository.UserRepository
-	Exercise   repository.ExerciseRepository
-	Workout    repository.WorkoutRepository
-	WorkoutSet repository.WorkoutSetRepository
-	Profile    repository.ProfileRepository
+	User           repository.UserRepository
+	Exercise       repository.ExerciseRepository
+	Workout        repository.WorkoutRepository
+	WorkoutSet     repository.WorkoutSetRepository
+	Profile        repository.ProfileRepository
+	PersonalRecord repository.PersonalRecordRepository
+	Routine        repository.RoutineRepository
+	Program        repository.ProgramRepository
+	UserIdentity   repository.UserIdentityRepository
+	Analytics      repository.AnalyticsRepository
 }
 
 // SetupRepos はテスト用の全リポジトリを生成する
 func SetupRepos(conn *sql.DB) *Repos {
 	return &Repos{
-		User:       NewUserRepository(conn),
-		Exercise:   NewExerciseRepository(conn),
-		Workout:    NewWorkoutRepository(conn),
-		WorkoutSet: NewWorkoutSetRepository(conn),
-		Profile:    NewProfileRepository(conn),
+		User:           NewUserRepository(conn),
+		Exercise:       NewExerciseRepository(conn),
+		Workout:        NewWorkoutRepository(conn),
+		WorkoutSet:     NewWorkoutSetRepository(conn),
+		Profile:        NewProfileRepository(conn),
+		PersonalRecord: NewPersonalRecordRepository(conn),
+		Routine:        NewRoutineRepository(conn),
+		Program:        NewProgramRepository(conn),
+		UserIdentity:   NewUserIdentityRepository(conn),
+		Analytics:      NewAnalyticsRepository(conn),
 	}
 }
 
@@ -110,6 +120,13 @@ func WithBodyPart(bp entity.BodyPart) ExerciseOption {
 	}
 }
 
+// WithExerciseOwner はエクササイズを所有するユーザーを指定する（未指定の場合はシステム種目）
+func WithExerciseOwner(userID uuid.UUID) ExerciseOption {
+	return func(e *entity.Exercise) {
+		e.UserID = &userID
+	}
+}
+
 // CreateExercise はテスト用エクササイズを作成しDBに保存する。
 // オプションを指定しない場合、ユニークな名前が自動生成される。
 func CreateExercise(t *testing.T, ctx context.Context, repo repository.ExerciseRepository, opts ...ExerciseOption) *entity.Exercise {
@@ -139,10 +156,18 @@ func CreateExercise(t *testing.T, ctx context.Context, repo repository.ExerciseR
 // WorkoutOption はWorkoutファクトリのオプション関数
 type WorkoutOption func(w *entity.Workout)
 
-// WithDate はワークアウトの日付を指定する
+// WithDate はワークアウトの日付を指定する（開始時刻も同じ時刻になる）
 func WithDate(date time.Time) WorkoutOption {
 	return func(w *entity.Workout) {
 		w.Date = date
+		w.StartedAt = date
+	}
+}
+
+// WithStartedAt はワークアウトの開始時刻を指定する
+func WithStartedAt(startedAt time.Time) WorkoutOption {
+	return func(w *entity.Workout) {
+		w.StartedAt = startedAt
 	}
 }
 
@@ -277,6 +302,13 @@ func WithNotes(notes string) WorkoutSetOption {
 	}
 }
 
+// WithSetType はセットの種類を指定する
+func WithSetType(setType entity.SetType) WorkoutSetOption {
+	return func(ws *entity.WorkoutSet) {
+		ws.SetType = setType
+	}
+}
+
 // workoutSetSeq はユニークなセット番号生成用のシーケンス
 var workoutSetSeq int32
 
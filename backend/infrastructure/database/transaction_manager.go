@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+	"github.com/ucchy108/whiskey/backend/sqlc/db"
+)
+
+// txContextKey はコンテキストにトランザクションを格納するためのキー
+type txContextKey struct{}
+
+// transactionManager はTransactionManagerインターフェースのPostgreSQL実装。
+// 開始したトランザクションをコンテキストに格納し、各リポジトリは
+// queriesFromContext を通じてsqlcの WithTx で同じトランザクションを使用する。
+type transactionManager struct {
+	conn *sql.DB
+}
+
+// NewTransactionManager はTransactionManagerの実装を生成する。
+//
+// パラメータ:
+//   - conn: PostgreSQLデータベース接続
+//
+// 戻り値:
+//   - repository.TransactionManager: トランザクションマネージャーの実装
+func NewTransactionManager(conn *sql.DB) repository.TransactionManager {
+	return &transactionManager{
+		conn: conn,
+	}
+}
+
+// RunInTx はfnを1つのトランザクション内で実行する。
+// fnがエラーを返した場合、またはpanicした場合はロールバックし、それ以外はコミットする。
+// コンテキストに既にトランザクションが存在する場合は、そのトランザクションに参加する。
+func (m *transactionManager) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := txFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txContextKey{}, tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// txFromContext はコンテキストからトランザクションを取り出す
+func txFromContext(ctx context.Context) (*sql.Tx, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(*sql.Tx)
+	return tx, ok
+}
+
+// queriesFromContext はコンテキストにトランザクションがあればそれに紐づくQueriesを返し、
+// なければ渡されたQueriesをそのまま返す
+func queriesFromContext(ctx context.Context, q *db.Queries) *db.Queries {
+	if tx, ok := txFromContext(ctx); ok {
+		return q.WithTx(tx)
+	}
+	return q
+}
@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+)
+
+func TestTransactionManager_RunInTx_Commit(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	txManager := NewTransactionManager(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	exercise := CreateExercise(t, ctx, repos.Exercise)
+
+	var workout *entity.Workout
+	err := txManager.RunInTx(ctx, func(ctx context.Context) error {
+		workout = entity.NewWorkout(user.ID, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+		if err := repos.Workout.Create(ctx, workout); err != nil {
+			return err
+		}
+		set, err := entity.NewWorkoutSet(workout.ID, exercise.ID, 1, 10, 60.0)
+		if err != nil {
+			return err
+		}
+		return repos.WorkoutSet.Create(ctx, set)
+	})
+	if err != nil {
+		t.Fatalf("RunInTx() error = %v", err)
+	}
+
+	found, err := repos.Workout.FindByID(ctx, workout.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found == nil {
+		t.Fatal("FindByID() = nil, want committed workout")
+	}
+
+	sets, err := repos.WorkoutSet.FindByWorkoutID(ctx, workout.ID)
+	if err != nil {
+		t.Fatalf("FindByWorkoutID() error = %v", err)
+	}
+	if len(sets) != 1 {
+		t.Errorf("FindByWorkoutID() count = %d, want 1", len(sets))
+	}
+}
+
+func TestTransactionManager_RunInTx_RollbackOnError(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	txManager := NewTransactionManager(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	exercise := CreateExercise(t, ctx, repos.Exercise)
+	wantErr := errors.New("set #3 failed")
+
+	var workout *entity.Workout
+	err := txManager.RunInTx(ctx, func(ctx context.Context) error {
+		workout = entity.NewWorkout(user.ID, time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC))
+		if err := repos.Workout.Create(ctx, workout); err != nil {
+			return err
+		}
+		for i := int32(1); i <= 2; i++ {
+			set, err := entity.NewWorkoutSet(workout.ID, exercise.ID, i, 10, 60.0)
+			if err != nil {
+				return err
+			}
+			if err := repos.WorkoutSet.Create(ctx, set); err != nil {
+				return err
+			}
+		}
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("RunInTx() error = %v, want %v", err, wantErr)
+	}
+
+	found, err := repos.Workout.FindByID(ctx, workout.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found != nil {
+		t.Error("FindByID() returned workout, want nil after rollback")
+	}
+
+	sets, err := repos.WorkoutSet.FindByWorkoutID(ctx, workout.ID)
+	if err != nil {
+		t.Fatalf("FindByWorkoutID() error = %v", err)
+	}
+	if len(sets) != 0 {
+		t.Errorf("FindByWorkoutID() count = %d, want 0 after rollback", len(sets))
+	}
+}
+
+func TestTransactionManager_RunInTx_Nested(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	txManager := NewTransactionManager(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	wantErr := errors.New("outer failed")
+
+	var workout *entity.Workout
+	err := txManager.RunInTx(ctx, func(ctx context.Context) error {
+		// 内側のRunInTxは外側のトランザクションに参加するため、内側の成功だけではコミットされない
+		if err := txManager.RunInTx(ctx, func(ctx context.Context) error {
+			workout = entity.NewWorkout(user.ID, time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC))
+			return repos.Workout.Create(ctx, workout)
+		}); err != nil {
+			return err
+		}
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("RunInTx() error = %v, want %v", err, wantErr)
+	}
+
+	found, err := repos.Workout.FindByID(ctx, workout.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if found != nil {
+		t.Error("FindByID() returned workout, want nil after outer rollback")
+	}
+}
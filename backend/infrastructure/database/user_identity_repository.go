@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+	db "github.com/ucchy108/whiskey/backend/sqlc/db"
+)
+
+// userIdentityRepository はUserIdentityRepositoryインターフェースの実装
+type userIdentityRepository struct {
+	queries *db.Queries
+}
+
+// NewUserIdentityRepository はUserIdentityRepositoryの実装を生成する
+func NewUserIdentityRepository(conn *sql.DB) repository.UserIdentityRepository {
+	return &userIdentityRepository{
+		queries: db.New(conn),
+	}
+}
+
+// Create は外部IDプロバイダのアカウントをユーザーに紐付ける
+func (r *userIdentityRepository) Create(ctx context.Context, identity *entity.UserIdentity) error {
+	created, err := queriesFromContext(ctx, r.queries).CreateUserIdentity(ctx, db.CreateUserIdentityParams{
+		ID:        identity.ID,
+		UserID:    identity.UserID,
+		Provider:  identity.Provider,
+		Subject:   identity.Subject,
+		Email:     identity.Email,
+		CreatedAt: identity.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	identity.CreatedAt = created.CreatedAt
+
+	return nil
+}
+
+// FindByProviderSubject はプロバイダとsubjectで紐付けを取得する
+func (r *userIdentityRepository) FindByProviderSubject(ctx context.Context, provider, subject string) (*entity.UserIdentity, error) {
+	dbIdentity, err := queriesFromContext(ctx, r.queries).GetUserIdentityByProviderSubject(ctx, db.GetUserIdentityByProviderSubjectParams{
+		Provider: provider,
+		Subject:  subject,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return toUserIdentityEntity(dbIdentity), nil
+}
+
+// FindByUserID はユーザーに紐付いた外部IDプロバイダのアカウントを紐付けた順に取得する
+func (r *userIdentityRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.UserIdentity, error) {
+	dbIdentities, err := queriesFromContext(ctx, r.queries).ListUserIdentitiesByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	identities := make([]*entity.UserIdentity, len(dbIdentities))
+	for i, dbIdentity := range dbIdentities {
+		identities[i] = toUserIdentityEntity(dbIdentity)
+	}
+
+	return identities, nil
+}
+
+// toUserIdentityEntity はDB層のUserIdentityをドメイン層のUserIdentityエンティティに変換する
+func toUserIdentityEntity(i db.UserIdentity) *entity.UserIdentity {
+	return entity.ReconstructUserIdentity(i.ID, i.UserID, i.Provider, i.Subject, i.Email, i.CreatedAt)
+}
@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+)
+
+func TestUserIdentityRepository_Create(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+
+	identity, err := entity.NewUserIdentity(user.ID, "google", "subject-1", user.Email.String())
+	if err != nil {
+		t.Fatalf("NewUserIdentity() error = %v", err)
+	}
+	if err := repos.UserIdentity.Create(ctx, identity); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	found, err := repos.UserIdentity.FindByProviderSubject(ctx, "google", "subject-1")
+	if err != nil {
+		t.Fatalf("FindByProviderSubject() error = %v", err)
+	}
+	if found == nil {
+		t.Fatal("FindByProviderSubject() returned nil")
+	}
+	if found.ID != identity.ID || found.UserID != user.ID {
+		t.Errorf("FindByProviderSubject() = %+v, want identity %v of user %v", found, identity.ID, user.ID)
+	}
+	if found.Email != user.Email.String() {
+		t.Errorf("Email = %v, want %v", found.Email, user.Email.String())
+	}
+
+	// 同じプロバイダ・subjectは重複して紐付けられない
+	duplicate, _ := entity.NewUserIdentity(user.ID, "google", "subject-1", user.Email.String())
+	if err := repos.UserIdentity.Create(ctx, duplicate); err == nil {
+		t.Error("Create() should fail for a duplicate provider and subject")
+	}
+}
+
+func TestUserIdentityRepository_FindByProviderSubject_NotFound(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	found, err := repos.UserIdentity.FindByProviderSubject(ctx, "google", "unknown")
+	if err != nil {
+		t.Fatalf("FindByProviderSubject() error = %v", err)
+	}
+	if found != nil {
+		t.Errorf("FindByProviderSubject() = %+v, want nil", found)
+	}
+}
+
+func TestUserIdentityRepository_FindByUserID(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	other := CreateUser(t, ctx, repos.User)
+
+	for _, provider := range []string{"google", "github"} {
+		identity, _ := entity.NewUserIdentity(user.ID, provider, "subject-"+provider, user.Email.String())
+		if err := repos.UserIdentity.Create(ctx, identity); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	otherIdentity, _ := entity.NewUserIdentity(other.ID, "google", "subject-other", other.Email.String())
+	if err := repos.UserIdentity.Create(ctx, otherIdentity); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	identities, err := repos.UserIdentity.FindByUserID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("FindByUserID() error = %v", err)
+	}
+	if len(identities) != 2 {
+		t.Fatalf("FindByUserID() returned %d identities, want 2", len(identities))
+	}
+	for _, identity := range identities {
+		if identity.UserID != user.ID {
+			t.Errorf("FindByUserID() returned identity of user %v, want %v", identity.UserID, user.ID)
+		}
+	}
+}
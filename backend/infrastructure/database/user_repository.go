@@ -4,10 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ucchy108/whiskey/backend/domain/entity"
 	"github.com/ucchy108/whiskey/backend/domain/repository"
+	"github.com/ucchy108/whiskey/backend/domain/value"
 	"github.com/ucchy108/whiskey/backend/sqlc/db"
 )
 
@@ -36,7 +38,7 @@ func (r *userRepository) Create(ctx context.Context, user *entity.User) error {
 		params.VerificationTokenExpiresAt = sql.NullTime{Time: user.VerificationToken.ExpiresAt(), Valid: true}
 	}
 
-	createdUser, err := r.queries.CreateUser(ctx, params)
+	createdUser, err := queriesFromContext(ctx, r.queries).CreateUser(ctx, params)
 	if err != nil {
 		return err
 	}
@@ -50,7 +52,7 @@ func (r *userRepository) Create(ctx context.Context, user *entity.User) error {
 
 // FindByID はIDでユーザーを取得する
 func (r *userRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
-	dbUser, err := r.queries.GetUser(ctx, id)
+	dbUser, err := queriesFromContext(ctx, r.queries).GetUser(ctx, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -63,7 +65,7 @@ func (r *userRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Us
 
 // FindByEmail はメールアドレスでユーザーを取得する
 func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
-	dbUser, err := r.queries.GetUserByEmail(ctx, email)
+	dbUser, err := queriesFromContext(ctx, r.queries).GetUserByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -76,7 +78,39 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*entity
 
 // FindByVerificationToken は検証トークンでユーザーを取得する
 func (r *userRepository) FindByVerificationToken(ctx context.Context, token string) (*entity.User, error) {
-	dbUser, err := r.queries.GetUserByVerificationToken(ctx, sql.NullString{String: token, Valid: true})
+	dbUser, err := queriesFromContext(ctx, r.queries).GetUserByVerificationToken(ctx, sql.NullString{String: token, Valid: true})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return reconstructUserFromDB(dbUser), nil
+}
+
+// ResetPasswordByTokenHash はパスワードリセットトークンのハッシュが一致し、有効期限内の場合のみ、
+// パスワードの更新とトークンの消費を1つのUPDATE文で行う。
+// 同じトークンで同時にリセットされても、成功するのは1回だけとなる。
+// 該当するユーザーが存在しない場合（トークンが不正・期限切れ・使用済み）はnilを返す。
+func (r *userRepository) ResetPasswordByTokenHash(ctx context.Context, tokenHash string, passwordHash value.HashedPassword) (*entity.User, error) {
+	dbUser, err := queriesFromContext(ctx, r.queries).ResetUserPasswordByTokenHash(ctx, db.ResetUserPasswordByTokenHashParams{
+		PasswordResetTokenHash: sql.NullString{String: tokenHash, Valid: true},
+		PasswordHash:           passwordHash.String(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return reconstructUserFromDB(dbUser), nil
+}
+
+// FindByEmailChangeTokenHash はメールアドレス変更トークンのハッシュでユーザーを取得する
+func (r *userRepository) FindByEmailChangeTokenHash(ctx context.Context, tokenHash string) (*entity.User, error) {
+	dbUser, err := queriesFromContext(ctx, r.queries).GetUserByEmailChangeTokenHash(ctx, sql.NullString{String: tokenHash, Valid: true})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -89,7 +123,22 @@ func (r *userRepository) FindByVerificationToken(ctx context.Context, token stri
 
 // FindAll は全ユーザーを取得する
 func (r *userRepository) FindAll(ctx context.Context) ([]*entity.User, error) {
-	dbUsers, err := r.queries.ListUsers(ctx)
+	dbUsers, err := queriesFromContext(ctx, r.queries).ListUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	domainUsers := make([]*entity.User, len(dbUsers))
+	for i, dbUser := range dbUsers {
+		domainUsers[i] = reconstructUserFromDB(dbUser)
+	}
+
+	return domainUsers, nil
+}
+
+// FindDueForDeletion は削除予定日時がbefore以前のユーザーを削除予定日時の古い順に取得する
+func (r *userRepository) FindDueForDeletion(ctx context.Context, before time.Time) ([]*entity.User, error) {
+	dbUsers, err := queriesFromContext(ctx, r.queries).ListUsersDueForDeletion(ctx, sql.NullTime{Time: before, Valid: true})
 	if err != nil {
 		return nil, err
 	}
@@ -105,10 +154,13 @@ func (r *userRepository) FindAll(ctx context.Context) ([]*entity.User, error) {
 // Update はユーザーを更新する
 func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
 	params := db.UpdateUserParams{
-		ID:            user.ID,
-		Email:         user.Email.String(),
-		PasswordHash:  user.PasswordHash.String(),
-		EmailVerified: user.EmailVerified,
+		ID:                 user.ID,
+		Email:              user.Email.String(),
+		PasswordHash:       user.PasswordHash.String(),
+		EmailVerified:      user.EmailVerified,
+		TwoFactorEnabled:   user.TwoFactorEnabled,
+		TotpLastUsedStep:   user.TOTPLastUsedStep,
+		RecoveryCodeHashes: user.RecoveryCodeHashes,
 	}
 
 	if user.VerificationToken != nil {
@@ -116,11 +168,41 @@ func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
 		params.VerificationTokenExpiresAt = sql.NullTime{Time: user.VerificationToken.ExpiresAt(), Valid: true}
 	}
 
-	updatedUser, err := r.queries.UpdateUser(ctx, params)
+	if user.PasswordResetToken != nil {
+		params.PasswordResetTokenHash = sql.NullString{String: user.PasswordResetToken.Hash(), Valid: true}
+		params.PasswordResetTokenExpiresAt = sql.NullTime{Time: user.PasswordResetToken.ExpiresAt(), Valid: true}
+	}
+
+	if user.PendingEmail != nil {
+		params.PendingEmail = sql.NullString{String: user.PendingEmail.String(), Valid: true}
+	}
+
+	if user.EmailChangeToken != nil {
+		params.EmailChangeTokenHash = sql.NullString{String: user.EmailChangeToken.Hash(), Valid: true}
+		params.EmailChangeTokenExpiresAt = sql.NullTime{Time: user.EmailChangeToken.ExpiresAt(), Valid: true}
+	}
+
+	if user.DeletionScheduledAt != nil {
+		params.DeletionScheduledAt = sql.NullTime{Time: *user.DeletionScheduledAt, Valid: true}
+	}
+
+	if user.TOTPSecret != nil {
+		params.TotpSecret = sql.NullString{String: user.TOTPSecret.String(), Valid: true}
+	}
+	// recovery_code_hashesはNOT NULLのため、未発行の場合は空配列として保存する
+	if params.RecoveryCodeHashes == nil {
+		params.RecoveryCodeHashes = []string{}
+	}
+
+	updatedUser, err := queriesFromContext(ctx, r.queries).UpdateUser(ctx, params)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil
 		}
+		// usersの一意制約はメールアドレスのみのため、他のユーザーと重複した場合に限られる
+		if isUniqueViolation(err) {
+			return value.ErrEmailAlreadyExists
+		}
 		return err
 	}
 
@@ -131,7 +213,7 @@ func (r *userRepository) Update(ctx context.Context, user *entity.User) error {
 
 // Delete はユーザーを削除する
 func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.queries.DeleteUser(ctx, id)
+	return queriesFromContext(ctx, r.queries).DeleteUser(ctx, id)
 }
 
 // ExistsByEmail はメールアドレスでユーザーが存在するか確認する
@@ -151,6 +233,31 @@ func reconstructUserFromDB(dbUser db.User) *entity.User {
 		tokenStr = dbUser.VerificationToken.String
 	}
 
+	var resetTokenHash string
+	if dbUser.PasswordResetTokenHash.Valid {
+		resetTokenHash = dbUser.PasswordResetTokenHash.String
+	}
+
+	var pendingEmail string
+	if dbUser.PendingEmail.Valid {
+		pendingEmail = dbUser.PendingEmail.String
+	}
+
+	var changeTokenHash string
+	if dbUser.EmailChangeTokenHash.Valid {
+		changeTokenHash = dbUser.EmailChangeTokenHash.String
+	}
+
+	var totpSecret string
+	if dbUser.TotpSecret.Valid {
+		totpSecret = dbUser.TotpSecret.String
+	}
+
+	var deletionScheduledAt *time.Time
+	if dbUser.DeletionScheduledAt.Valid {
+		deletionScheduledAt = &dbUser.DeletionScheduledAt.Time
+	}
+
 	return entity.ReconstructUser(
 		dbUser.ID,
 		dbUser.Email,
@@ -158,6 +265,16 @@ func reconstructUserFromDB(dbUser db.User) *entity.User {
 		dbUser.EmailVerified,
 		tokenStr,
 		tokenExpiresAt,
+		resetTokenHash,
+		dbUser.PasswordResetTokenExpiresAt.Time,
+		pendingEmail,
+		changeTokenHash,
+		dbUser.EmailChangeTokenExpiresAt.Time,
+		totpSecret,
+		dbUser.TwoFactorEnabled,
+		dbUser.TotpLastUsedStep,
+		dbUser.RecoveryCodeHashes,
+		deletionScheduledAt,
 		dbUser.CreatedAt,
 		dbUser.UpdatedAt,
 	)
@@ -2,10 +2,13 @@ package database
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/value"
 )
 
 func TestUserRepository_Create(t *testing.T) {
@@ -216,6 +219,247 @@ func TestUserRepository_Update(t *testing.T) {
 	}
 }
 
+func TestUserRepository_Update_DuplicateEmail(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repos := SetupRepos(db)
+	ctx := context.Background()
+
+	CreateUser(t, ctx, repos.User, WithEmail("taken@example.com"))
+	user := CreateUser(t, ctx, repos.User, WithEmail("test@example.com"))
+
+	if err := user.UpdateEmail("taken@example.com"); err != nil {
+		t.Fatalf("Failed to update email: %v", err)
+	}
+
+	err := repos.User.Update(ctx, user)
+	if !errors.Is(err, value.ErrEmailAlreadyExists) {
+		t.Fatalf("Update() error = %v, want %v", err, value.ErrEmailAlreadyExists)
+	}
+
+	found, _ := repos.User.FindByID(ctx, user.ID)
+	if found.Email.String() != "test@example.com" {
+		t.Errorf("Email = %v, want test@example.com", found.Email.String())
+	}
+}
+
+func TestUserRepository_ResetPasswordByTokenHash(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repos := SetupRepos(db)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	token, err := user.IssuePasswordResetToken()
+	if err != nil {
+		t.Fatalf("IssuePasswordResetToken() error = %v", err)
+	}
+	if err := repos.User.Update(ctx, user); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	passwordVO, _ := value.NewPassword("newpassword123")
+	passwordHash, err := passwordVO.Hash()
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+
+	// 有効なトークンでパスワードが更新され、トークンが消費される
+	reset, err := repos.User.ResetPasswordByTokenHash(ctx, value.HashPasswordResetToken(token), passwordHash)
+	if err != nil {
+		t.Fatalf("ResetPasswordByTokenHash() error = %v", err)
+	}
+	if reset == nil || reset.ID != user.ID {
+		t.Fatalf("ResetPasswordByTokenHash() = %v, want user %v", reset, user.ID)
+	}
+	if reset.PasswordResetToken != nil {
+		t.Error("ResetPasswordByTokenHash() should consume the token")
+	}
+	if err := reset.VerifyPassword("newpassword123"); err != nil {
+		t.Errorf("VerifyPassword() with new password error = %v", err)
+	}
+
+	// 同じトークンは二度使えない
+	again, err := repos.User.ResetPasswordByTokenHash(ctx, value.HashPasswordResetToken(token), passwordHash)
+	if err != nil {
+		t.Fatalf("ResetPasswordByTokenHash() error = %v", err)
+	}
+	if again != nil {
+		t.Error("ResetPasswordByTokenHash() should not match a consumed token")
+	}
+
+	// 期限切れのトークンは使えない
+	expiredToken, _ := user.IssuePasswordResetToken()
+	user.PasswordResetToken = value.ReconstructPasswordResetToken(user.PasswordResetToken.Hash(), time.Now().Add(-time.Minute))
+	if err := repos.User.Update(ctx, user); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	expired, err := repos.User.ResetPasswordByTokenHash(ctx, value.HashPasswordResetToken(expiredToken), passwordHash)
+	if err != nil {
+		t.Fatalf("ResetPasswordByTokenHash() error = %v", err)
+	}
+	if expired != nil {
+		t.Error("ResetPasswordByTokenHash() should not match an expired token")
+	}
+}
+
+func TestUserRepository_FindByEmailChangeTokenHash(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repos := SetupRepos(db)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+
+	token, err := user.RequestEmailChange("changed@example.com")
+	if err != nil {
+		t.Fatalf("RequestEmailChange() error = %v", err)
+	}
+	if err := repos.User.Update(ctx, user); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	// ハッシュで検索でき、変更先のメールアドレスが保存されていることを確認
+	found, err := repos.User.FindByEmailChangeTokenHash(ctx, value.HashEmailChangeToken(token))
+	if err != nil {
+		t.Fatalf("FindByEmailChangeTokenHash() error = %v", err)
+	}
+	if found == nil || found.ID != user.ID {
+		t.Fatalf("FindByEmailChangeTokenHash() = %v, want user %v", found, user.ID)
+	}
+	if found.PendingEmail == nil || found.PendingEmail.String() != "changed@example.com" {
+		t.Errorf("FindByEmailChangeTokenHash() PendingEmail = %v, want changed@example.com", found.PendingEmail)
+	}
+	if found.EmailChangeToken == nil || found.EmailChangeToken.IsExpired() {
+		t.Error("FindByEmailChangeTokenHash() should return a valid EmailChangeToken")
+	}
+
+	// 確定後はメールアドレスが切り替わり、トークンが破棄されることを確認
+	if err := found.ConfirmEmailChange(); err != nil {
+		t.Fatalf("ConfirmEmailChange() error = %v", err)
+	}
+	if err := repos.User.Update(ctx, found); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	found, err = repos.User.FindByEmailChangeTokenHash(ctx, value.HashEmailChangeToken(token))
+	if err != nil {
+		t.Fatalf("FindByEmailChangeTokenHash() error = %v", err)
+	}
+	if found != nil {
+		t.Error("FindByEmailChangeTokenHash() should not find a consumed token")
+	}
+	updated, _ := repos.User.FindByID(ctx, user.ID)
+	if updated.Email.String() != "changed@example.com" || updated.PendingEmail != nil {
+		t.Errorf("after confirm Email = %v, PendingEmail = %v", updated.Email.String(), updated.PendingEmail)
+	}
+}
+
+func TestUserRepository_FindDueForDeletion(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repos := SetupRepos(db)
+	ctx := context.Background()
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	due := CreateUser(t, ctx, repos.User)
+	due.ScheduleDeletion(now.Add(-time.Hour))
+	if err := repos.User.Update(ctx, due); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	notYetDue := CreateUser(t, ctx, repos.User)
+	notYetDue.ScheduleDeletion(now.Add(time.Hour))
+	if err := repos.User.Update(ctx, notYetDue); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	// 削除を要求していないユーザーは対象外
+	CreateUser(t, ctx, repos.User)
+
+	users, err := repos.User.FindDueForDeletion(ctx, now)
+	if err != nil {
+		t.Fatalf("FindDueForDeletion() error = %v", err)
+	}
+	if len(users) != 1 || users[0].ID != due.ID {
+		t.Fatalf("FindDueForDeletion() = %v, want only user %v", users, due.ID)
+	}
+	if users[0].DeletionScheduledAt == nil || !users[0].DeletionScheduledAt.Equal(now.Add(-time.Hour)) {
+		t.Errorf("DeletionScheduledAt = %v, want %v", users[0].DeletionScheduledAt, now.Add(-time.Hour))
+	}
+
+	// 取り消すと対象外になる
+	due.CancelDeletion()
+	if err := repos.User.Update(ctx, due); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	users, err = repos.User.FindDueForDeletion(ctx, now)
+	if err != nil {
+		t.Fatalf("FindDueForDeletion() error = %v", err)
+	}
+	if len(users) != 0 {
+		t.Errorf("FindDueForDeletion() = %d users, want 0 after cancel", len(users))
+	}
+}
+
+func TestUserRepository_Update_TwoFactor(t *testing.T) {
+	db := SetupTestDB(t)
+	defer CleanupTestDB(t, db)
+
+	repos := SetupRepos(db)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+
+	now := time.Now()
+	secret, err := user.BeginTwoFactorEnrollment()
+	if err != nil {
+		t.Fatalf("BeginTwoFactorEnrollment() error = %v", err)
+	}
+	code, _ := secret.Code(now)
+	recoveryCodes, err := user.ConfirmTwoFactor(code, now)
+	if err != nil {
+		t.Fatalf("ConfirmTwoFactor() error = %v", err)
+	}
+	if err := repos.User.Update(ctx, user); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	// 二要素認証の設定が保存されていることを確認
+	found, err := repos.User.FindByID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if !found.TwoFactorEnabled {
+		t.Error("FindByID() TwoFactorEnabled = false, want true")
+	}
+	if found.TOTPSecret == nil || found.TOTPSecret.String() != secret.String() {
+		t.Errorf("FindByID() TOTPSecret = %v, want %v", found.TOTPSecret, secret.String())
+	}
+	if found.TOTPLastUsedStep != user.TOTPLastUsedStep {
+		t.Errorf("FindByID() TOTPLastUsedStep = %v, want %v", found.TOTPLastUsedStep, user.TOTPLastUsedStep)
+	}
+	if len(found.RecoveryCodeHashes) != len(recoveryCodes) {
+		t.Errorf("FindByID() len(RecoveryCodeHashes) = %d, want %d", len(found.RecoveryCodeHashes), len(recoveryCodes))
+	}
+
+	// リカバリーコードの消費が保存されることを確認
+	if err := found.VerifyTwoFactor(recoveryCodes[0], now); err != nil {
+		t.Fatalf("VerifyTwoFactor() error = %v", err)
+	}
+	if err := repos.User.Update(ctx, found); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	found, _ = repos.User.FindByID(ctx, user.ID)
+	if len(found.RecoveryCodeHashes) != len(recoveryCodes)-1 {
+		t.Errorf("FindByID() len(RecoveryCodeHashes) = %d, want %d", len(found.RecoveryCodeHashes), len(recoveryCodes)-1)
+	}
+}
+
 func TestUserRepository_Delete(t *testing.T) {
 	db := SetupTestDB(t)
 	defer CleanupTestDB(t, db)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"iter"
 	"time"
 
 	"github.com/google/uuid"
@@ -37,11 +38,13 @@ func (r *workoutRepository) Create(ctx context.Context, workout *entity.Workout)
 	params := db.CreateWorkoutParams{
 		UserID:     workout.UserID,
 		Date:       workout.Date,
+		StartedAt:  workout.StartedAt,
+		EndedAt:    toNullTime(workout.EndedAt),
 		DailyScore: workout.DailyScore,
 		Memo:       toNullString(workout.Memo),
 	}
 
-	created, err := r.queries.CreateWorkout(ctx, params)
+	created, err := queriesFromContext(ctx, r.queries).CreateWorkout(ctx, params)
 	if err != nil {
 		return err
 	}
@@ -56,7 +59,7 @@ func (r *workoutRepository) Create(ctx context.Context, workout *entity.Workout)
 // FindByID はIDでワークアウトを取得する。
 // 該当するワークアウトが存在しない場合はnilを返す。
 func (r *workoutRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Workout, error) {
-	dbWorkout, err := r.queries.GetWorkout(ctx, id)
+	dbWorkout, err := queriesFromContext(ctx, r.queries).GetWorkout(ctx, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -68,9 +71,83 @@ func (r *workoutRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity
 }
 
 // FindByUserID はユーザーIDで全ワークアウトを取得する。
-// 結果は日付の降順でソートされる。
+// 結果は日付・開始時刻の降順でソートされる。
 func (r *workoutRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Workout, error) {
-	dbWorkouts, err := r.queries.ListAllWorkoutsByUser(ctx, userID)
+	dbWorkouts, err := queriesFromContext(ctx, r.queries).ListAllWorkoutsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toWorkoutEntities(dbWorkouts), nil
+}
+
+// FindPage はキーセットページネーションでワークアウト一覧の1ページを取得する。
+// 絞り込み条件はすべてSQLで評価し、(date, started_at, id) の順でソートする。
+// query.Afterが指定された場合は、そのカーソルより後ろのワークアウトのみを返す。
+func (r *workoutRepository) FindPage(ctx context.Context, query repository.WorkoutPageQuery) ([]*entity.Workout, error) {
+	var (
+		cursorDate, cursorStartedAt sql.NullTime
+		cursorID                    uuid.NullUUID
+	)
+	if query.After != nil {
+		cursorDate = sql.NullTime{Time: entity.CalendarDate(query.After.Date), Valid: true}
+		cursorStartedAt = sql.NullTime{Time: query.After.StartedAt, Valid: true}
+		cursorID = uuid.NullUUID{UUID: query.After.ID, Valid: true}
+	}
+
+	filter := query.Filter
+	var startDate, endDate sql.NullTime
+	if filter.StartDate != nil {
+		startDate = sql.NullTime{Time: entity.CalendarDate(*filter.StartDate), Valid: true}
+	}
+	if filter.EndDate != nil {
+		endDate = sql.NullTime{Time: entity.CalendarDate(*filter.EndDate), Valid: true}
+	}
+
+	var memoQuery sql.NullString
+	if filter.MemoQuery != nil {
+		memoQuery = sql.NullString{String: escapeLikePattern(*filter.MemoQuery), Valid: true}
+	}
+
+	var bodyPart sql.NullString
+	if filter.BodyPart != nil {
+		bodyPart = sql.NullString{String: string(*filter.BodyPart), Valid: true}
+	}
+
+	var (
+		dbWorkouts []db.Workout
+		err        error
+	)
+	q := queriesFromContext(ctx, r.queries)
+	if query.Sort == repository.WorkoutSortDateAsc {
+		dbWorkouts, err = q.ListWorkoutsPageDateAsc(ctx, db.ListWorkoutsPageDateAscParams{
+			UserID:          query.UserID,
+			CursorDate:      cursorDate,
+			CursorStartedAt: cursorStartedAt,
+			CursorID:        cursorID,
+			StartDate:       startDate,
+			EndDate:         endDate,
+			MinScore:        toNullInt32(filter.MinScore),
+			MemoQuery:       memoQuery,
+			ExerciseID:      toNullUUID(filter.ExerciseID),
+			BodyPart:        bodyPart,
+			PageLimit:       query.Limit,
+		})
+	} else {
+		dbWorkouts, err = q.ListWorkoutsPageDateDesc(ctx, db.ListWorkoutsPageDateDescParams{
+			UserID:          query.UserID,
+			CursorDate:      cursorDate,
+			CursorStartedAt: cursorStartedAt,
+			CursorID:        cursorID,
+			StartDate:       startDate,
+			EndDate:         endDate,
+			MinScore:        toNullInt32(filter.MinScore),
+			MemoQuery:       memoQuery,
+			ExerciseID:      toNullUUID(filter.ExerciseID),
+			BodyPart:        bodyPart,
+			PageLimit:       query.Limit,
+		})
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -79,15 +156,16 @@ func (r *workoutRepository) FindByUserID(ctx context.Context, userID uuid.UUID)
 }
 
 // FindByUserIDAndDateRange はユーザーIDと日付範囲でワークアウトを取得する。
-// startDateとendDateの両端を含む（>=, <=）。結果は日付の降順でソートされる。
+// startDateとendDateの両端を含む（>=, <=）。結果は日付・開始時刻の降順でソートされる。
+// 日付はそれぞれのタイムゾーンでの暦日として解釈される。
 func (r *workoutRepository) FindByUserIDAndDateRange(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]*entity.Workout, error) {
 	params := db.ListWorkoutsByUserAndDateRangeParams{
 		UserID: userID,
-		Date:   startDate,
-		Date_2: endDate,
+		Date:   entity.CalendarDate(startDate),
+		Date_2: entity.CalendarDate(endDate),
 	}
 
-	dbWorkouts, err := r.queries.ListWorkoutsByUserAndDateRange(ctx, params)
+	dbWorkouts, err := queriesFromContext(ctx, r.queries).ListWorkoutsByUserAndDateRange(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -95,36 +173,107 @@ func (r *workoutRepository) FindByUserIDAndDateRange(ctx context.Context, userID
 	return toWorkoutEntities(dbWorkouts), nil
 }
 
-// FindByUserIDAndDate はユーザーIDと日付でワークアウトを取得する。
-// 該当するワークアウトが存在しない場合はnilを返す。
-func (r *workoutRepository) FindByUserIDAndDate(ctx context.Context, userID uuid.UUID, date time.Time) (*entity.Workout, error) {
-	params := db.GetWorkoutByUserAndDateParams{
+// FindByUserIDAndDate はユーザーIDと日付で、その日の全ワークアウトを取得する。
+// 結果は開始時刻の昇順でソートされる。日付はdateのタイムゾーンでの暦日として解釈される。
+func (r *workoutRepository) FindByUserIDAndDate(ctx context.Context, userID uuid.UUID, date time.Time) ([]*entity.Workout, error) {
+	params := db.ListWorkoutsByUserAndDateParams{
 		UserID: userID,
-		Date:   date,
+		Date:   entity.CalendarDate(date),
 	}
 
-	dbWorkout, err := r.queries.GetWorkoutByUserAndDate(ctx, params)
+	dbWorkouts, err := queriesFromContext(ctx, r.queries).ListWorkoutsByUserAndDate(ctx, params)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, nil
-		}
 		return nil, err
 	}
 
-	return toWorkoutEntity(dbWorkout), nil
+	return toWorkoutEntities(dbWorkouts), nil
+}
+
+// FindDatesByUserID はユーザーがワークアウトを記録した日付を、重複なしで古い順に取得する。
+// 日付はUTCの0時で表される暦日となる。
+func (r *workoutRepository) FindDatesByUserID(ctx context.Context, userID uuid.UUID) ([]time.Time, error) {
+	return queriesFromContext(ctx, r.queries).ListWorkoutDatesByUser(ctx, userID)
+}
+
+// exportPageSize はエクスポートで1回のクエリで読み込むワークアウトの件数
+const exportPageSize = 200
+
+// StreamForExport はユーザーのワークアウトとセットを、種目名・身体部位付きで日付順に1行ずつ返す。
+// startDate・endDateがnilの場合は期間を限定しない。日付はそれぞれのタイムゾーンでの暦日として解釈される。
+// 全件をメモリに読み込まないよう、ワークアウトをexportPageSize件ずつキーセットページネーションで読み込む。
+// 呼び出し側が反復を途中でやめた場合は、それ以降のページを読み込まない。
+func (r *workoutRepository) StreamForExport(ctx context.Context, userID uuid.UUID, startDate, endDate *time.Time) iter.Seq2[repository.WorkoutExportRow, error] {
+	return func(yield func(repository.WorkoutExportRow, error) bool) {
+		params := db.ListWorkoutExportRowsParams{
+			UserID:    userID,
+			PageLimit: exportPageSize,
+		}
+		if startDate != nil {
+			params.StartDate = sql.NullTime{Time: entity.CalendarDate(*startDate), Valid: true}
+		}
+		if endDate != nil {
+			params.EndDate = sql.NullTime{Time: entity.CalendarDate(*endDate), Valid: true}
+		}
+
+		for {
+			rows, err := queriesFromContext(ctx, r.queries).ListWorkoutExportRows(ctx, params)
+			if err != nil {
+				yield(repository.WorkoutExportRow{}, err)
+				return
+			}
+
+			var workout *entity.Workout
+			workoutCount := 0
+			for _, i := range rows {
+				// 同じワークアウトの行は連続するため、ワークアウトが変わったときだけ変換する
+				if workout == nil || workout.ID != i.ID {
+					workout = toWorkoutEntity(db.Workout{
+						ID:         i.ID,
+						UserID:     i.UserID,
+						Date:       i.Date,
+						StartedAt:  i.StartedAt,
+						EndedAt:    i.EndedAt,
+						DailyScore: i.DailyScore,
+						Memo:       i.Memo,
+						CreatedAt:  i.CreatedAt,
+						UpdatedAt:  i.UpdatedAt,
+					})
+					workoutCount++
+				}
+
+				row, err := toWorkoutExportRow(workout, i)
+				if err != nil {
+					yield(repository.WorkoutExportRow{}, err)
+					return
+				}
+				if !yield(row, nil) {
+					return
+				}
+			}
+
+			if workoutCount < exportPageSize {
+				return
+			}
+			params.CursorDate = sql.NullTime{Time: workout.Date, Valid: true}
+			params.CursorStartedAt = sql.NullTime{Time: workout.StartedAt, Valid: true}
+			params.CursorID = uuid.NullUUID{UUID: workout.ID, Valid: true}
+		}
+	}
 }
 
 // Update はワークアウトを更新する。
-// DailyScoreとMemoを更新し、UpdatedAtが元のエンティティに反映される。
+// DailyScore、Memo、セッションの開始・終了時刻を更新し、UpdatedAtが元のエンティティに反映される。
 // 該当するワークアウトが存在しない場合はnilを返す。
 func (r *workoutRepository) Update(ctx context.Context, workout *entity.Workout) error {
 	params := db.UpdateWorkoutParams{
 		ID:         workout.ID,
 		DailyScore: workout.DailyScore,
 		Memo:       toNullString(workout.Memo),
+		StartedAt:  workout.StartedAt,
+		EndedAt:    toNullTime(workout.EndedAt),
 	}
 
-	updated, err := r.queries.UpdateWorkout(ctx, params)
+	updated, err := queriesFromContext(ctx, r.queries).UpdateWorkout(ctx, params)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil
@@ -139,16 +288,7 @@ func (r *workoutRepository) Update(ctx context.Context, workout *entity.Workout)
 
 // Delete はワークアウトを削除する
 func (r *workoutRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.queries.DeleteWorkout(ctx, id)
-}
-
-// ExistsByUserIDAndDate はユーザーIDと日付でワークアウトが存在するか確認する
-func (r *workoutRepository) ExistsByUserIDAndDate(ctx context.Context, userID uuid.UUID, date time.Time) (bool, error) {
-	workout, err := r.FindByUserIDAndDate(ctx, userID, date)
-	if err != nil {
-		return false, err
-	}
-	return workout != nil, nil
+	return queriesFromContext(ctx, r.queries).DeleteWorkout(ctx, id)
 }
 
 // toWorkoutEntity はDB層のWorkoutをDomain層のWorkoutに変換する
@@ -157,6 +297,8 @@ func toWorkoutEntity(w db.Workout) *entity.Workout {
 		w.ID,
 		w.UserID,
 		w.Date,
+		w.StartedAt,
+		fromNullTime(w.EndedAt),
 		w.DailyScore,
 		fromNullString(w.Memo),
 		w.CreatedAt,
@@ -164,6 +306,39 @@ func toWorkoutEntity(w db.Workout) *entity.Workout {
 	)
 }
 
+// toWorkoutExportRow はエクスポート用クエリの1行をWorkoutExportRowに変換する。
+// セットの列がNULLの行（セットのないワークアウト）はSetをnilとする。
+func toWorkoutExportRow(workout *entity.Workout, i db.ListWorkoutExportRowsRow) (repository.WorkoutExportRow, error) {
+	row := repository.WorkoutExportRow{Workout: workout}
+	if !i.SetID.Valid {
+		return row, nil
+	}
+
+	set, err := toWorkoutSetEntity(db.WorkoutSet{
+		ID:              i.SetID.UUID,
+		WorkoutID:       i.ID,
+		ExerciseID:      i.ExerciseID.UUID,
+		SetNumber:       i.SetNumber.Int32,
+		Reps:            i.Reps.Int32,
+		Weight:          i.Weight.String,
+		Estimated1rm:    i.Estimated1rm.String,
+		DurationSeconds: i.DurationSeconds,
+		Notes:           i.Notes,
+		CreatedAt:       i.SetCreatedAt.Time,
+		SetType:         i.SetType.String,
+		Rpe:             i.Rpe,
+		Rir:             i.Rir,
+	})
+	if err != nil {
+		return repository.WorkoutExportRow{}, err
+	}
+
+	row.Set = set
+	row.ExerciseName = i.ExerciseName.String
+	row.BodyPart = nullStringToBodyPart(i.ExerciseBodyPart)
+	return row, nil
+}
+
 // toWorkoutEntities はDB層のWorkoutスライスをDomain層のWorkoutスライスに変換する
 func toWorkoutEntities(dbWorkouts []db.Workout) []*entity.Workout {
 	workouts := make([]*entity.Workout, len(dbWorkouts))
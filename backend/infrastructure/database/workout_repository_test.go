@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
 )
 
 func TestWorkoutRepository_Create(t *testing.T) {
@@ -38,7 +39,7 @@ func TestWorkoutRepository_Create(t *testing.T) {
 	}
 }
 
-func TestWorkoutRepository_Create_DuplicateDate(t *testing.T) {
+func TestWorkoutRepository_Create_MultipleSessionsPerDay(t *testing.T) {
 	conn := SetupTestDB(t)
 	defer CleanupTestDB(t, conn)
 
@@ -48,15 +49,52 @@ func TestWorkoutRepository_Create_DuplicateDate(t *testing.T) {
 	user := CreateUser(t, ctx, repos.User)
 	date := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
 
-	workout1 := entity.NewWorkout(user.ID, date)
-	if err := repos.Workout.Create(ctx, workout1); err != nil {
-		t.Fatalf("Create() first workout error = %v", err)
+	morning := entity.NewWorkout(user.ID, date)
+	morningEnd := date.Add(8 * time.Hour)
+	if err := morning.UpdateSessionTime(date.Add(7*time.Hour), &morningEnd); err != nil {
+		t.Fatalf("UpdateSessionTime() error = %v", err)
 	}
+	if err := repos.Workout.Create(ctx, morning); err != nil {
+		t.Fatalf("Create() morning session error = %v", err)
+	}
+
+	evening := entity.NewWorkout(user.ID, date)
+	if err := evening.UpdateSessionTime(date.Add(18*time.Hour), nil); err != nil {
+		t.Fatalf("UpdateSessionTime() error = %v", err)
+	}
+	if err := repos.Workout.Create(ctx, evening); err != nil {
+		t.Fatalf("Create() evening session error = %v", err)
+	}
+
+	found, err := repos.Workout.FindByID(ctx, morning.ID)
+	if err != nil || found == nil {
+		t.Fatalf("FindByID() = %v, error = %v", found, err)
+	}
+	if !found.StartedAt.Equal(morning.StartedAt) {
+		t.Errorf("StartedAt = %v, want %v", found.StartedAt, morning.StartedAt)
+	}
+	if found.EndedAt == nil || !found.EndedAt.Equal(morningEnd) {
+		t.Errorf("EndedAt = %v, want %v", found.EndedAt, morningEnd)
+	}
+}
+
+func TestWorkoutRepository_Create_EndedBeforeStarted(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
 
-	workout2 := entity.NewWorkout(user.ID, date)
-	err := repos.Workout.Create(ctx, workout2)
-	if err == nil {
-		t.Error("Create() expected unique constraint error for duplicate user+date, got nil")
+	user := CreateUser(t, ctx, repos.User)
+	date := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	// エンティティのバリデーションを経由せずに不正な終了時刻を設定する
+	workout := entity.NewWorkout(user.ID, date)
+	endedAt := date.Add(-time.Hour)
+	workout.EndedAt = &endedAt
+
+	if err := repos.Workout.Create(ctx, workout); err == nil {
+		t.Error("Create() expected check constraint error for ended_at before started_at, got nil")
 	}
 }
 
@@ -168,6 +206,115 @@ func TestWorkoutRepository_FindByUserIDAndDateRange(t *testing.T) {
 	}
 }
 
+func TestWorkoutRepository_FindPage(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	other := CreateUser(t, ctx, repos.User)
+	bench := CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Bench Press Page"), WithBodyPart(entity.BodyPartChest))
+	squat := CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Squat Page"), WithBodyPart(entity.BodyPartLegs))
+
+	day1 := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2026, 1, 11, 0, 0, 0, 0, time.UTC)
+	w1 := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(day1), WithDailyScore(20), WithMemo("Easy 50%_day"))
+	w2 := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(day2), WithStartedAt(day2.Add(7*time.Hour)), WithDailyScore(60), WithMemo("Heavy bench"))
+	w3 := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(day2), WithStartedAt(day2.Add(18*time.Hour)), WithDailyScore(40))
+	CreateWorkout(t, ctx, repos.Workout, other.ID, WithDate(day2), WithDailyScore(90))
+
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, w1.ID, squat.ID)
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, w2.ID, bench.ID)
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, w3.ID, squat.ID)
+
+	chest := entity.BodyPartChest
+	minScore := int32(30)
+	memo := "BENCH"
+	likeChars := "50%_"
+
+	tests := []struct {
+		name    string
+		query   repository.WorkoutPageQuery
+		wantIDs []uuid.UUID
+	}{
+		{
+			name:    "正常系: 新しい順（日付・開始時刻）",
+			query:   repository.WorkoutPageQuery{UserID: user.ID, Sort: repository.WorkoutSortDateDesc, Limit: 10},
+			wantIDs: []uuid.UUID{w3.ID, w2.ID, w1.ID},
+		},
+		{
+			name:    "正常系: 古い順",
+			query:   repository.WorkoutPageQuery{UserID: user.ID, Sort: repository.WorkoutSortDateAsc, Limit: 10},
+			wantIDs: []uuid.UUID{w1.ID, w2.ID, w3.ID},
+		},
+		{
+			name:    "正常系: 件数制限",
+			query:   repository.WorkoutPageQuery{UserID: user.ID, Sort: repository.WorkoutSortDateDesc, Limit: 2},
+			wantIDs: []uuid.UUID{w3.ID, w2.ID},
+		},
+		{
+			name: "正常系: カーソル以降を取得",
+			query: repository.WorkoutPageQuery{
+				UserID: user.ID,
+				Sort:   repository.WorkoutSortDateDesc,
+				After:  &repository.WorkoutCursor{Date: w3.Date, StartedAt: w3.StartedAt, ID: w3.ID},
+				Limit:  10,
+			},
+			wantIDs: []uuid.UUID{w2.ID, w1.ID},
+		},
+		{
+			name:    "正常系: 種目で絞り込み",
+			query:   repository.WorkoutPageQuery{UserID: user.ID, Filter: repository.WorkoutFilter{ExerciseID: &squat.ID}, Limit: 10},
+			wantIDs: []uuid.UUID{w3.ID, w1.ID},
+		},
+		{
+			name:    "正常系: 身体部位で絞り込み",
+			query:   repository.WorkoutPageQuery{UserID: user.ID, Filter: repository.WorkoutFilter{BodyPart: &chest}, Limit: 10},
+			wantIDs: []uuid.UUID{w2.ID},
+		},
+		{
+			name:    "正常系: スコアの下限で絞り込み",
+			query:   repository.WorkoutPageQuery{UserID: user.ID, Filter: repository.WorkoutFilter{MinScore: &minScore}, Limit: 10},
+			wantIDs: []uuid.UUID{w3.ID, w2.ID},
+		},
+		{
+			name:    "正常系: メモの部分一致（大文字小文字を区別しない）",
+			query:   repository.WorkoutPageQuery{UserID: user.ID, Filter: repository.WorkoutFilter{MemoQuery: &memo}, Limit: 10},
+			wantIDs: []uuid.UUID{w2.ID},
+		},
+		{
+			name:    "正常系: メモ検索のワイルドカード文字はリテラルとして扱う",
+			query:   repository.WorkoutPageQuery{UserID: user.ID, Filter: repository.WorkoutFilter{MemoQuery: &likeChars}, Limit: 10},
+			wantIDs: []uuid.UUID{w1.ID},
+		},
+		{
+			name:    "正常系: 日付範囲で絞り込み",
+			query:   repository.WorkoutPageQuery{UserID: user.ID, Filter: repository.WorkoutFilter{StartDate: &day2, EndDate: &day2}, Limit: 10},
+			wantIDs: []uuid.UUID{w3.ID, w2.ID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workouts, err := repos.Workout.FindPage(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("FindPage() error = %v", err)
+			}
+
+			if len(workouts) != len(tt.wantIDs) {
+				t.Fatalf("FindPage() returned %d workouts, want %d", len(workouts), len(tt.wantIDs))
+			}
+			for i, id := range tt.wantIDs {
+				if workouts[i].ID != id {
+					t.Errorf("FindPage()[%d].ID = %v, want %v", i, workouts[i].ID, id)
+				}
+			}
+		})
+	}
+}
+
 func TestWorkoutRepository_FindByUserIDAndDate(t *testing.T) {
 	conn := SetupTestDB(t)
 	defer CleanupTestDB(t, conn)
@@ -178,22 +325,23 @@ func TestWorkoutRepository_FindByUserIDAndDate(t *testing.T) {
 	user := CreateUser(t, ctx, repos.User)
 	date := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
 
-	CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(date))
+	evening := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(date), WithStartedAt(date.Add(18*time.Hour)))
+	morning := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(date), WithStartedAt(date.Add(7*time.Hour)))
 
 	tests := []struct {
 		name    string
 		date    time.Time
-		wantNil bool
+		wantIDs []uuid.UUID
 	}{
 		{
-			name:    "正常系: 存在する日付",
+			name:    "正常系: 同日の複数セッションを開始時刻順に取得",
 			date:    date,
-			wantNil: false,
+			wantIDs: []uuid.UUID{morning.ID, evening.ID},
 		},
 		{
 			name:    "正常系: 存在しない日付",
 			date:    time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC),
-			wantNil: true,
+			wantIDs: []uuid.UUID{},
 		},
 	}
 
@@ -204,13 +352,172 @@ func TestWorkoutRepository_FindByUserIDAndDate(t *testing.T) {
 				t.Fatalf("FindByUserIDAndDate() error = %v", err)
 			}
 
-			if (found == nil) != tt.wantNil {
-				t.Errorf("FindByUserIDAndDate() got nil = %v, want nil = %v", found == nil, tt.wantNil)
+			if len(found) != len(tt.wantIDs) {
+				t.Fatalf("FindByUserIDAndDate() returned %d workouts, want %d", len(found), len(tt.wantIDs))
+			}
+			for i, w := range found {
+				if w.ID != tt.wantIDs[i] {
+					t.Errorf("FindByUserIDAndDate()[%d].ID = %v, want %v", i, w.ID, tt.wantIDs[i])
+				}
 			}
 		})
 	}
 }
 
+func TestWorkoutRepository_FindDatesByUserID(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	otherUser := CreateUser(t, ctx, repos.User)
+	jan15 := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	jan17 := time.Date(2026, 1, 17, 0, 0, 0, 0, time.UTC)
+
+	// 同日の複数セッションは1日として扱う
+	CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(jan17))
+	CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(jan15), WithStartedAt(jan15.Add(7*time.Hour)))
+	CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(jan15), WithStartedAt(jan15.Add(18*time.Hour)))
+	CreateWorkout(t, ctx, repos.Workout, otherUser.ID, WithDate(time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC)))
+
+	tests := []struct {
+		name   string
+		userID uuid.UUID
+		want   []time.Time
+	}{
+		{
+			name:   "正常系: 重複なしで古い順に取得",
+			userID: user.ID,
+			want:   []time.Time{jan15, jan17},
+		},
+		{
+			name:   "正常系: ワークアウトなし",
+			userID: uuid.New(),
+			want:   []time.Time{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repos.Workout.FindDatesByUserID(ctx, tt.userID)
+			if err != nil {
+				t.Fatalf("FindDatesByUserID() error = %v", err)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("FindDatesByUserID() returned %d dates, want %d: %v", len(got), len(tt.want), got)
+			}
+			for i, date := range got {
+				if !date.Equal(tt.want[i]) {
+					t.Errorf("FindDatesByUserID()[%d] = %v, want %v", i, date, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestWorkoutRepository_StreamForExport(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	other := CreateUser(t, ctx, repos.User, WithEmail("other@example.com"))
+	bench := CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Export Bench"), WithBodyPart(entity.BodyPartChest))
+
+	jan10 := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)))
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, jan10.ID, bench.ID, WithSetNumber(2), WithReps(8), WithWeight(62.5))
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, jan10.ID, bench.ID, WithSetNumber(1), WithReps(10), WithWeight(60), WithNotes("warm"))
+	empty := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)), WithMemo("rest"))
+	CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)))
+	CreateWorkout(t, ctx, repos.Workout, other.ID, WithDate(time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)))
+
+	endDate := time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC)
+	var rows []repository.WorkoutExportRow
+	for row, err := range repos.Workout.StreamForExport(ctx, user.ID, nil, &endDate) {
+		if err != nil {
+			t.Fatalf("StreamForExport() error = %v", err)
+		}
+		rows = append(rows, row)
+	}
+
+	// 1/10の2セット（セット番号順）と、セットのない1/15のワークアウト
+	if len(rows) != 3 {
+		t.Fatalf("StreamForExport() returned %d rows, want 3", len(rows))
+	}
+	if rows[0].Workout.ID != jan10.ID || rows[0].Workout != rows[1].Workout {
+		t.Error("rows of the same workout should share the workout")
+	}
+	if rows[0].Set.SetNumber != 1 || rows[0].Set.Weight != 60 || rows[0].Set.Notes == nil || *rows[0].Set.Notes != "warm" {
+		t.Errorf("first set = %+v, want set 1 60kg with notes", rows[0].Set)
+	}
+	if rows[0].ExerciseName != "Export Bench" || rows[0].BodyPart == nil || *rows[0].BodyPart != entity.BodyPartChest {
+		t.Errorf("exercise = %s %v, want Export Bench chest", rows[0].ExerciseName, rows[0].BodyPart)
+	}
+	if rows[2].Workout.ID != empty.ID || rows[2].Set != nil {
+		t.Errorf("last row = %+v, want the workout without sets", rows[2])
+	}
+	if rows[2].Workout.Memo == nil || *rows[2].Workout.Memo != "rest" {
+		t.Errorf("Memo = %v, want rest", rows[2].Workout.Memo)
+	}
+
+	// 途中で反復をやめられる
+	count := 0
+	for range repos.Workout.StreamForExport(ctx, user.ID, nil, nil) {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("iteration should stop after break, got %d rows", count)
+	}
+}
+
+func TestWorkoutRepository_StreamForExport_Pages(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	bench := CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Export Bench"))
+
+	// ページの境界をまたいでも、全ワークアウトを重複なく日付順に返す
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	total := exportPageSize + 1
+	for i := 0; i < total; i++ {
+		workout := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(start.AddDate(0, 0, i)))
+		CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout.ID, bench.ID, WithSetNumber(1))
+		CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout.ID, bench.ID, WithSetNumber(2))
+	}
+
+	seen := make(map[uuid.UUID]int)
+	var last time.Time
+	for row, err := range repos.Workout.StreamForExport(ctx, user.ID, nil, nil) {
+		if err != nil {
+			t.Fatalf("StreamForExport() error = %v", err)
+		}
+		if row.Workout.Date.Before(last) {
+			t.Fatalf("workout on %v returned after %v", row.Workout.Date, last)
+		}
+		last = row.Workout.Date
+		seen[row.Workout.ID]++
+	}
+
+	if len(seen) != total {
+		t.Errorf("StreamForExport() returned %d workouts, want %d", len(seen), total)
+	}
+	for id, count := range seen {
+		if count != 2 {
+			t.Errorf("workout %v returned %d rows, want 2", id, count)
+		}
+	}
+}
+
 func TestWorkoutRepository_Update(t *testing.T) {
 	conn := SetupTestDB(t)
 	defer CleanupTestDB(t, conn)
@@ -223,10 +530,13 @@ func TestWorkoutRepository_Update(t *testing.T) {
 
 	originalUpdatedAt := workout.UpdatedAt
 
-	// スコアとメモを更新
+	// スコア、メモ、セッション時刻を更新
 	workout.UpdateDailyScore(80)
 	memo := "Great workout!"
 	workout.UpdateMemo(&memo)
+	startedAt := workout.Date.Add(18 * time.Hour)
+	endedAt := startedAt.Add(time.Hour)
+	workout.UpdateSessionTime(startedAt, &endedAt)
 
 	err := repos.Workout.Update(ctx, workout)
 	if err != nil {
@@ -245,6 +555,12 @@ func TestWorkoutRepository_Update(t *testing.T) {
 	if found.Memo == nil || *found.Memo != "Great workout!" {
 		t.Errorf("Update() Memo = %v, want 'Great workout!'", found.Memo)
 	}
+	if !found.StartedAt.Equal(startedAt) {
+		t.Errorf("Update() StartedAt = %v, want %v", found.StartedAt, startedAt)
+	}
+	if found.EndedAt == nil || !found.EndedAt.Equal(endedAt) {
+		t.Errorf("Update() EndedAt = %v, want %v", found.EndedAt, endedAt)
+	}
 }
 
 func TestWorkoutRepository_Delete(t *testing.T) {
@@ -267,46 +583,3 @@ func TestWorkoutRepository_Delete(t *testing.T) {
 		t.Error("Delete() did not delete workout")
 	}
 }
-
-func TestWorkoutRepository_ExistsByUserIDAndDate(t *testing.T) {
-	conn := SetupTestDB(t)
-	defer CleanupTestDB(t, conn)
-
-	repos := SetupRepos(conn)
-	ctx := context.Background()
-
-	user := CreateUser(t, ctx, repos.User)
-	date := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
-
-	CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(date))
-
-	tests := []struct {
-		name string
-		date time.Time
-		want bool
-	}{
-		{
-			name: "正常系: 存在する日付",
-			date: date,
-			want: true,
-		},
-		{
-			name: "正常系: 存在しない日付",
-			date: time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC),
-			want: false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			exists, err := repos.Workout.ExistsByUserIDAndDate(ctx, user.ID, tt.date)
-			if err != nil {
-				t.Fatalf("ExistsByUserIDAndDate() error = %v", err)
-			}
-
-			if exists != tt.want {
-				t.Errorf("ExistsByUserIDAndDate() = %v, want %v", exists, tt.want)
-			}
-		})
-	}
-}
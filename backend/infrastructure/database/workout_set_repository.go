@@ -46,9 +46,12 @@ func (r *workoutSetRepository) Create(ctx context.Context, workoutSet *entity.Wo
 		Estimated1rm:    formatFloat(workoutSet.Estimated1RM),
 		DurationSeconds: toNullInt32(workoutSet.DurationSeconds),
 		Notes:           toNullString(workoutSet.Notes),
+		SetType:         string(workoutSet.SetType),
+		Rpe:             float64ToNullString(workoutSet.RPE),
+		Rir:             toNullInt32(workoutSet.RIR),
 	}
 
-	created, err := r.queries.CreateWorkoutSet(ctx, params)
+	created, err := queriesFromContext(ctx, r.queries).CreateWorkoutSet(ctx, params)
 	if err != nil {
 		return err
 	}
@@ -63,7 +66,7 @@ func (r *workoutSetRepository) Create(ctx context.Context, workoutSet *entity.Wo
 // 該当するセットが存在しない場合はnilを返す。
 // DB上の文字列型のWeight/Estimated1RMはfloat64に変換される。
 func (r *workoutSetRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.WorkoutSet, error) {
-	dbSet, err := r.queries.GetWorkoutSet(ctx, id)
+	dbSet, err := queriesFromContext(ctx, r.queries).GetWorkoutSet(ctx, id)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -77,7 +80,7 @@ func (r *workoutSetRepository) FindByID(ctx context.Context, id uuid.UUID) (*ent
 // FindByWorkoutID はワークアウトIDで全セットを取得する。
 // 結果はエクササイズID、セット番号の順でソートされる。
 func (r *workoutSetRepository) FindByWorkoutID(ctx context.Context, workoutID uuid.UUID) ([]*entity.WorkoutSet, error) {
-	dbSets, err := r.queries.ListWorkoutSetsByWorkout(ctx, workoutID)
+	dbSets, err := queriesFromContext(ctx, r.queries).ListWorkoutSetsByWorkout(ctx, workoutID)
 	if err != nil {
 		return nil, err
 	}
@@ -93,7 +96,7 @@ func (r *workoutSetRepository) FindByWorkoutIDAndExerciseID(ctx context.Context,
 		ExerciseID: exerciseID,
 	}
 
-	dbSets, err := r.queries.ListWorkoutSetsByWorkoutAndExercise(ctx, params)
+	dbSets, err := queriesFromContext(ctx, r.queries).ListWorkoutSetsByWorkoutAndExercise(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -101,10 +104,103 @@ func (r *workoutSetRepository) FindByWorkoutIDAndExerciseID(ctx context.Context,
 	return toWorkoutSetEntities(dbSets)
 }
 
+// FindDetailsByWorkoutIDs は複数ワークアウトの全セットを種目名・身体部位付きで1クエリで取得する。
+// ワークアウト一覧の展開表示でN+1クエリを避けるために使用される。
+// 結果はワークアウトごとに、種目を最初に記録したセットの順（同時刻の場合はエクササイズID順）、種目内はセット番号の順でソートされる。
+func (r *workoutSetRepository) FindDetailsByWorkoutIDs(ctx context.Context, workoutIDs []uuid.UUID) ([]repository.WorkoutSetDetail, error) {
+	if len(workoutIDs) == 0 {
+		return []repository.WorkoutSetDetail{}, nil
+	}
+
+	rows, err := queriesFromContext(ctx, r.queries).ListWorkoutSetsWithExerciseByWorkouts(ctx, workoutIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	details := make([]repository.WorkoutSetDetail, len(rows))
+	for i, row := range rows {
+		s, err := toWorkoutSetEntity(db.WorkoutSet{
+			ID:              row.ID,
+			WorkoutID:       row.WorkoutID,
+			ExerciseID:      row.ExerciseID,
+			SetNumber:       row.SetNumber,
+			Reps:            row.Reps,
+			Weight:          row.Weight,
+			Estimated1rm:    row.Estimated1rm,
+			DurationSeconds: row.DurationSeconds,
+			Notes:           row.Notes,
+			CreatedAt:       row.CreatedAt,
+			SetType:         row.SetType,
+			Rpe:             row.Rpe,
+			Rir:             row.Rir,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		details[i] = repository.WorkoutSetDetail{
+			Set:          s,
+			ExerciseName: row.ExerciseName,
+			BodyPart:     nullStringToBodyPart(row.ExerciseBodyPart),
+		}
+	}
+
+	return details, nil
+}
+
 // FindByExerciseID はエクササイズIDで全セットを取得する（全ワークアウト横断）。
 // 結果は作成日時の降順でソートされる。
 func (r *workoutSetRepository) FindByExerciseID(ctx context.Context, exerciseID uuid.UUID) ([]*entity.WorkoutSet, error) {
-	dbSets, err := r.queries.ListWorkoutSetsByExerciseID(ctx, exerciseID)
+	dbSets, err := queriesFromContext(ctx, r.queries).ListWorkoutSetsByExerciseID(ctx, exerciseID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toWorkoutSetEntities(dbSets)
+}
+
+// FindByUserIDAndExerciseID はユーザーの特定エクササイズの全セットを取得する（全ワークアウト横断）。
+// 自己ベスト判定の履歴として使用される。結果はワークアウト日付、ワークアウトの開始時刻、セット番号の昇順でソートされる。
+func (r *workoutSetRepository) FindByUserIDAndExerciseID(ctx context.Context, userID, exerciseID uuid.UUID) ([]*entity.WorkoutSet, error) {
+	rows, err := queriesFromContext(ctx, r.queries).ListWorkoutSetsByExercise(ctx, db.ListWorkoutSetsByExerciseParams{
+		UserID:     userID,
+		ExerciseID: exerciseID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sets := make([]*entity.WorkoutSet, len(rows))
+	for i, row := range rows {
+		s, err := toWorkoutSetEntity(db.WorkoutSet{
+			ID:              row.ID,
+			WorkoutID:       row.WorkoutID,
+			ExerciseID:      row.ExerciseID,
+			SetNumber:       row.SetNumber,
+			Reps:            row.Reps,
+			Weight:          row.Weight,
+			Estimated1rm:    row.Estimated1rm,
+			DurationSeconds: row.DurationSeconds,
+			Notes:           row.Notes,
+			CreatedAt:       row.CreatedAt,
+			SetType:         row.SetType,
+			Rpe:             row.Rpe,
+			Rir:             row.Rir,
+		})
+		if err != nil {
+			return nil, err
+		}
+		sets[i] = s
+	}
+
+	return sets, nil
+}
+
+// FindByUserID はユーザーの全セットを取得する（全ワークアウト・全エクササイズ横断）。
+// 1RM計算式の変更時に推定1RMを再計算するために使用される。
+// 結果はワークアウト日付の昇順、セット番号順でソートされる。
+func (r *workoutSetRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.WorkoutSet, error) {
+	dbSets, err := queriesFromContext(ctx, r.queries).ListWorkoutSetsByUser(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -113,7 +209,7 @@ func (r *workoutSetRepository) FindByExerciseID(ctx context.Context, exerciseID
 }
 
 // Update はワークアウトセットを更新する。
-// Reps、Weight、Estimated1RM、DurationSeconds、Notesを更新する。
+// Reps、Weight、Estimated1RM、DurationSeconds、Notes、SetType、RPE、RIRを更新する。
 // 該当するセットが存在しない場合はnilを返す。
 func (r *workoutSetRepository) Update(ctx context.Context, workoutSet *entity.WorkoutSet) error {
 	params := db.UpdateWorkoutSetParams{
@@ -123,9 +219,12 @@ func (r *workoutSetRepository) Update(ctx context.Context, workoutSet *entity.Wo
 		Estimated1rm:    formatFloat(workoutSet.Estimated1RM),
 		DurationSeconds: toNullInt32(workoutSet.DurationSeconds),
 		Notes:           toNullString(workoutSet.Notes),
+		SetType:         string(workoutSet.SetType),
+		Rpe:             float64ToNullString(workoutSet.RPE),
+		Rir:             toNullInt32(workoutSet.RIR),
 	}
 
-	_, err := r.queries.UpdateWorkoutSet(ctx, params)
+	_, err := queriesFromContext(ctx, r.queries).UpdateWorkoutSet(ctx, params)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil
@@ -136,14 +235,33 @@ func (r *workoutSetRepository) Update(ctx context.Context, workoutSet *entity.Wo
 	return nil
 }
 
+// UpdateEstimated1RMs は複数セットの推定1RMを1回のクエリで一括更新する。
+// 対象が空の場合は何もしない。
+func (r *workoutSetRepository) UpdateEstimated1RMs(ctx context.Context, workoutSets []*entity.WorkoutSet) error {
+	if len(workoutSets) == 0 {
+		return nil
+	}
+
+	params := db.UpdateWorkoutSetEstimated1RMsParams{
+		Ids:           make([]uuid.UUID, len(workoutSets)),
+		Estimated1rms: make([]string, len(workoutSets)),
+	}
+	for i, ws := range workoutSets {
+		params.Ids[i] = ws.ID
+		params.Estimated1rms[i] = formatFloat(ws.Estimated1RM)
+	}
+
+	return queriesFromContext(ctx, r.queries).UpdateWorkoutSetEstimated1RMs(ctx, params)
+}
+
 // Delete はワークアウトセットを削除する
 func (r *workoutSetRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.queries.DeleteWorkoutSet(ctx, id)
+	return queriesFromContext(ctx, r.queries).DeleteWorkoutSet(ctx, id)
 }
 
 // DeleteByWorkoutID はワークアウトIDで全セットを削除する
 func (r *workoutSetRepository) DeleteByWorkoutID(ctx context.Context, workoutID uuid.UUID) error {
-	return r.queries.DeleteWorkoutSetsByWorkout(ctx, workoutID)
+	return queriesFromContext(ctx, r.queries).DeleteWorkoutSetsByWorkout(ctx, workoutID)
 }
 
 // GetMaxEstimated1RMByExerciseAndUser はユーザーとエクササイズの全期間最大推定1RMを取得する。
@@ -154,7 +272,7 @@ func (r *workoutSetRepository) GetMaxEstimated1RMByExerciseAndUser(ctx context.C
 		ExerciseID: exerciseID,
 	}
 
-	result, err := r.queries.GetOverallMaxEstimated1RMByExerciseAndUser(ctx, params)
+	result, err := queriesFromContext(ctx, r.queries).GetOverallMaxEstimated1RMByExerciseAndUser(ctx, params)
 	if err != nil {
 		return 0, err
 	}
@@ -162,10 +280,11 @@ func (r *workoutSetRepository) GetMaxEstimated1RMByExerciseAndUser(ctx context.C
 	return parseFloat(result)
 }
 
-// GetWeightProgression は日別の最大推定1RMを取得する。
-// sqlc生成の GetMaxEstimated1RMByExercise クエリを使用する。
-func (r *workoutSetRepository) GetWeightProgression(ctx context.Context, userID, exerciseID uuid.UUID) ([]repository.WeightProgressionPoint, error) {
-	rows, err := r.queries.GetMaxEstimated1RMByExercise(ctx, db.GetMaxEstimated1RMByExerciseParams{
+// GetWeightProgression は日別の最大推定1RMを、指定された公式で計算して取得する。
+// 保存済みの estimated_1rm カラムは使用せず、生の重量・レップ数から再計算する。
+// sqlc生成の ListProgressionSetsByExercise クエリを使用する。
+func (r *workoutSetRepository) GetWeightProgression(ctx context.Context, userID, exerciseID uuid.UUID, formula entity.OneRMFormula) ([]repository.WeightProgressionPoint, error) {
+	rows, err := queriesFromContext(ctx, r.queries).ListProgressionSetsByExercise(ctx, db.ListProgressionSetsByExerciseParams{
 		UserID:     userID,
 		ExerciseID: exerciseID,
 	})
@@ -173,27 +292,27 @@ func (r *workoutSetRepository) GetWeightProgression(ctx context.Context, userID,
 		return nil, err
 	}
 
-	points := make([]repository.WeightProgressionPoint, 0, len(rows))
+	// 行は日付順で返されるため、同じ日付が連続する前提で日別の最大値を集計する
+	points := make([]repository.WeightProgressionPoint, 0)
 	for _, row := range rows {
-		// MAX(estimated_1rm) は interface{} で返される。実際の値は []byte (DECIMAL文字列)
-		max1rmStr, ok := row.Max1rm.(string)
-		if !ok {
-			// database/sql が []byte で返す場合
-			if b, ok2 := row.Max1rm.([]byte); ok2 {
-				max1rmStr = string(b)
-			} else {
-				continue
-			}
+		weight, err := parseFloat(row.Weight)
+		if err != nil {
+			return nil, err
 		}
 
-		max1rm, err := parseFloat(max1rmStr)
-		if err != nil {
+		estimated1RM := entity.CalculateEstimated1RMWithFormula(weight, row.Reps, formula)
+
+		last := len(points) - 1
+		if last >= 0 && points[last].Date.Equal(row.Date) {
+			if estimated1RM > points[last].Max1RM {
+				points[last].Max1RM = estimated1RM
+			}
 			continue
 		}
 
 		points = append(points, repository.WeightProgressionPoint{
 			Date:   row.Date,
-			Max1RM: max1rm,
+			Max1RM: estimated1RM,
 		})
 	}
 
@@ -232,6 +351,9 @@ func toWorkoutSetEntity(ws db.WorkoutSet) (*entity.WorkoutSet, error) {
 		estimated1RM,
 		fromNullInt32(ws.DurationSeconds),
 		fromNullString(ws.Notes),
+		entity.SetType(ws.SetType),
+		nullStringToFloat64(ws.Rpe),
+		fromNullInt32(ws.Rir),
 		ws.CreatedAt,
 	), nil
 }
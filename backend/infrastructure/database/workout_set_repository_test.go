@@ -162,6 +162,78 @@ func TestWorkoutSetRepository_FindByWorkoutIDAndExerciseID(t *testing.T) {
 	}
 }
 
+func TestWorkoutSetRepository_FindDetailsByWorkoutIDs(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	bench := CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Bench Press"), WithBodyPart(entity.BodyPartChest))
+	plank := CreateExercise(t, ctx, repos.Exercise, WithExerciseName("Plank"))
+	workout1 := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)))
+	workout2 := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC)))
+	other := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(time.Date(2026, 1, 17, 0, 0, 0, 0, time.UTC)))
+
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout1.ID, bench.ID, WithSetNumber(1), WithReps(10), WithWeight(60.0))
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout1.ID, bench.ID, WithSetNumber(2), WithReps(8), WithWeight(65.0))
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout2.ID, plank.ID, WithSetNumber(1), WithReps(1), WithWeight(0))
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, other.ID, bench.ID, WithSetNumber(1), WithReps(5), WithWeight(80.0))
+
+	details, err := repos.WorkoutSet.FindDetailsByWorkoutIDs(ctx, []uuid.UUID{workout1.ID, workout2.ID})
+	if err != nil {
+		t.Fatalf("FindDetailsByWorkoutIDs() error = %v", err)
+	}
+
+	if len(details) != 3 {
+		t.Fatalf("FindDetailsByWorkoutIDs() returned %d sets, want 3", len(details))
+	}
+	for _, d := range details {
+		if d.Set.WorkoutID == other.ID {
+			t.Errorf("FindDetailsByWorkoutIDs() returned set of workout %v not requested", other.ID)
+		}
+		switch d.Set.ExerciseID {
+		case bench.ID:
+			if d.ExerciseName != "Bench Press" || d.BodyPart == nil || *d.BodyPart != entity.BodyPartChest {
+				t.Errorf("bench detail = %q, %v, want Bench Press, chest", d.ExerciseName, d.BodyPart)
+			}
+		case plank.ID:
+			if d.ExerciseName != "Plank" || d.BodyPart != nil {
+				t.Errorf("plank detail = %q, %v, want Plank, nil", d.ExerciseName, d.BodyPart)
+			}
+		}
+	}
+
+	// 種目は最初にセットを記録した順、種目内はセット番号順に並ぶ
+	ordered := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(time.Date(2026, 1, 18, 0, 0, 0, 0, time.UTC)))
+	first := CreateWorkoutSet(t, ctx, repos.WorkoutSet, ordered.ID, plank.ID, WithSetNumber(1), WithReps(1), WithWeight(0))
+	second := CreateWorkoutSet(t, ctx, repos.WorkoutSet, ordered.ID, bench.ID, WithSetNumber(1), WithReps(10), WithWeight(60.0))
+	third := CreateWorkoutSet(t, ctx, repos.WorkoutSet, ordered.ID, plank.ID, WithSetNumber(2), WithReps(1), WithWeight(0))
+
+	orderedDetails, err := repos.WorkoutSet.FindDetailsByWorkoutIDs(ctx, []uuid.UUID{ordered.ID})
+	if err != nil {
+		t.Fatalf("FindDetailsByWorkoutIDs() error = %v", err)
+	}
+	wantOrder := []uuid.UUID{first.ID, third.ID, second.ID}
+	if len(orderedDetails) != len(wantOrder) {
+		t.Fatalf("FindDetailsByWorkoutIDs() returned %d sets, want %d", len(orderedDetails), len(wantOrder))
+	}
+	for i, id := range wantOrder {
+		if orderedDetails[i].Set.ID != id {
+			t.Errorf("FindDetailsByWorkoutIDs()[%d] = set %d of %s, want %v", i, orderedDetails[i].Set.SetNumber, orderedDetails[i].ExerciseName, id)
+		}
+	}
+
+	empty, err := repos.WorkoutSet.FindDetailsByWorkoutIDs(ctx, nil)
+	if err != nil {
+		t.Fatalf("FindDetailsByWorkoutIDs(nil) error = %v", err)
+	}
+	if len(empty) != 0 {
+		t.Errorf("FindDetailsByWorkoutIDs(nil) returned %d sets, want 0", len(empty))
+	}
+}
+
 func TestWorkoutSetRepository_FindByExerciseID(t *testing.T) {
 	conn := SetupTestDB(t)
 	defer CleanupTestDB(t, conn)
@@ -188,6 +260,82 @@ func TestWorkoutSetRepository_FindByExerciseID(t *testing.T) {
 	}
 }
 
+func TestWorkoutSetRepository_FindByUserIDAndExerciseID(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	other := CreateUser(t, ctx, repos.User)
+	exercise := CreateExercise(t, ctx, repos.Exercise)
+	otherExercise := CreateExercise(t, ctx, repos.Exercise)
+
+	workout1 := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)))
+	workout2 := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC)))
+	otherWorkout := CreateWorkout(t, ctx, repos.Workout, other.ID, WithDate(time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)))
+
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout1.ID, exercise.ID, WithSetNumber(1), WithReps(10), WithWeight(60.0))
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout2.ID, exercise.ID, WithSetNumber(1), WithReps(8), WithWeight(65.0))
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout2.ID, otherExercise.ID, WithSetNumber(1), WithReps(8), WithWeight(40.0))
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, otherWorkout.ID, exercise.ID, WithSetNumber(1), WithReps(5), WithWeight(100.0))
+
+	sets, err := repos.WorkoutSet.FindByUserIDAndExerciseID(ctx, user.ID, exercise.ID)
+	if err != nil {
+		t.Fatalf("FindByUserIDAndExerciseID() error = %v", err)
+	}
+
+	// 他ユーザー・他種目のセットは含まない
+	if len(sets) != 2 {
+		t.Fatalf("FindByUserIDAndExerciseID() returned %d sets, want 2", len(sets))
+	}
+
+	// ワークアウト日付の昇順（時系列順）
+	if sets[0].WorkoutID != workout1.ID || sets[1].WorkoutID != workout2.ID {
+		t.Error("FindByUserIDAndExerciseID() sets are not sorted by workout date ascending")
+	}
+	if sets[1].Weight != 65.0 || sets[1].Reps != 8 {
+		t.Errorf("FindByUserIDAndExerciseID() sets[1] = %v kg x %d, want 65 kg x 8", sets[1].Weight, sets[1].Reps)
+	}
+}
+
+func TestWorkoutSetRepository_FindByUserID(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	other := CreateUser(t, ctx, repos.User)
+	exercise := CreateExercise(t, ctx, repos.Exercise)
+	otherExercise := CreateExercise(t, ctx, repos.Exercise)
+
+	workout1 := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC)))
+	workout2 := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)))
+	otherWorkout := CreateWorkout(t, ctx, repos.Workout, other.ID)
+
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout1.ID, exercise.ID, WithSetNumber(1))
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout2.ID, otherExercise.ID, WithSetNumber(1))
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, otherWorkout.ID, exercise.ID, WithSetNumber(1))
+
+	sets, err := repos.WorkoutSet.FindByUserID(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("FindByUserID() error = %v", err)
+	}
+
+	// 他ユーザーのセットは含まず、全種目のセットを含む
+	if len(sets) != 2 {
+		t.Fatalf("FindByUserID() returned %d sets, want 2", len(sets))
+	}
+
+	// ワークアウト日付の昇順
+	if sets[0].WorkoutID != workout2.ID || sets[1].WorkoutID != workout1.ID {
+		t.Error("FindByUserID() sets are not sorted by workout date ascending")
+	}
+}
+
 func TestWorkoutSetRepository_Update(t *testing.T) {
 	conn := SetupTestDB(t)
 	defer CleanupTestDB(t, conn)
@@ -230,6 +378,94 @@ func TestWorkoutSetRepository_Update(t *testing.T) {
 	}
 }
 
+func TestWorkoutSetRepository_UpdateEstimated1RMs(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	exercise := CreateExercise(t, ctx, repos.Exercise)
+	workout := CreateWorkout(t, ctx, repos.Workout, user.ID)
+	set1 := CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout.ID, exercise.ID, WithSetNumber(1), WithReps(5), WithWeight(100.0))
+	set2 := CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout.ID, exercise.ID, WithSetNumber(2), WithReps(8), WithWeight(80.0))
+
+	set1.RecalculateEstimated1RM(entity.OneRMFormulaBrzycki)
+	set2.RecalculateEstimated1RM(entity.OneRMFormulaBrzycki)
+
+	if err := repos.WorkoutSet.UpdateEstimated1RMs(ctx, []*entity.WorkoutSet{set1, set2}); err != nil {
+		t.Fatalf("UpdateEstimated1RMs() error = %v", err)
+	}
+
+	for _, want := range []*entity.WorkoutSet{set1, set2} {
+		found, err := repos.WorkoutSet.FindByID(ctx, want.ID)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		// DECIMAL(6,2)で保存されるため小数第2位までで比較する
+		diff := found.Estimated1RM - want.Estimated1RM
+		if diff < -0.01 || diff > 0.01 {
+			t.Errorf("Estimated1RM = %v, want %v", found.Estimated1RM, want.Estimated1RM)
+		}
+	}
+
+	// 空の場合は何もしない
+	if err := repos.WorkoutSet.UpdateEstimated1RMs(ctx, nil); err != nil {
+		t.Errorf("UpdateEstimated1RMs(nil) error = %v", err)
+	}
+}
+
+func TestWorkoutSetRepository_SetTypeAndEffort(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	exercise := CreateExercise(t, ctx, repos.Exercise)
+	workout := CreateWorkout(t, ctx, repos.Workout, user.ID)
+	workoutSet := CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout.ID, exercise.ID,
+		WithSetType(entity.SetTypeWarmUp),
+	)
+
+	// 作成時の値を確認
+	found, _ := repos.WorkoutSet.FindByID(ctx, workoutSet.ID)
+	if found.SetType != entity.SetTypeWarmUp {
+		t.Errorf("Create() SetType = %v, want warmup", found.SetType)
+	}
+	if found.RPE != nil || found.RIR != nil {
+		t.Errorf("Create() RPE = %v, RIR = %v, want nil", found.RPE, found.RIR)
+	}
+
+	// RPEを記録
+	rpe := 8.5
+	workoutSet.UpdateSetType(entity.SetTypeFailure)
+	workoutSet.UpdateEffort(&rpe, nil)
+	if err := repos.WorkoutSet.Update(ctx, workoutSet); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	found, _ = repos.WorkoutSet.FindByID(ctx, workoutSet.ID)
+	if found.SetType != entity.SetTypeFailure {
+		t.Errorf("Update() SetType = %v, want failure", found.SetType)
+	}
+	if found.RPE == nil || *found.RPE != 8.5 || found.RIR != nil {
+		t.Errorf("Update() RPE = %v, RIR = %v, want 8.5, nil", found.RPE, found.RIR)
+	}
+
+	// RIRに切り替えるとRPEは消去される
+	rir := int32(2)
+	workoutSet.UpdateEffort(nil, &rir)
+	if err := repos.WorkoutSet.Update(ctx, workoutSet); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	found, _ = repos.WorkoutSet.FindByID(ctx, workoutSet.ID)
+	if found.RPE != nil || found.RIR == nil || *found.RIR != 2 {
+		t.Errorf("Update() RPE = %v, RIR = %v, want nil, 2", found.RPE, found.RIR)
+	}
+}
+
 func TestWorkoutSetRepository_Delete(t *testing.T) {
 	conn := SetupTestDB(t)
 	defer CleanupTestDB(t, conn)
@@ -309,6 +545,31 @@ func TestWorkoutSetRepository_GetMaxEstimated1RMByExerciseAndUser(t *testing.T)
 	}
 }
 
+func TestWorkoutSetRepository_GetMaxEstimated1RMByExerciseAndUser_ExcludesWarmUp(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	exercise := CreateExercise(t, ctx, repos.Exercise)
+	workout := CreateWorkout(t, ctx, repos.Workout, user.ID)
+
+	// 60kg x 10reps -> 1RM = 80.0
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout.ID, exercise.ID, WithSetNumber(1), WithReps(10), WithWeight(60.0))
+	// ウォームアップ 100kg x 5reps は対象外
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout.ID, exercise.ID, WithSetNumber(2), WithReps(5), WithWeight(100.0), WithSetType(entity.SetTypeWarmUp))
+
+	max1RM, err := repos.WorkoutSet.GetMaxEstimated1RMByExerciseAndUser(ctx, user.ID, exercise.ID)
+	if err != nil {
+		t.Fatalf("GetMaxEstimated1RMByExerciseAndUser() error = %v", err)
+	}
+	if max1RM != 80.0 {
+		t.Errorf("GetMaxEstimated1RMByExerciseAndUser() = %v, want 80.0", max1RM)
+	}
+}
+
 func TestWorkoutSetRepository_GetMaxEstimated1RMByExerciseAndUser_NoData(t *testing.T) {
 	conn := SetupTestDB(t)
 	defer CleanupTestDB(t, conn)
@@ -327,6 +588,62 @@ func TestWorkoutSetRepository_GetMaxEstimated1RMByExerciseAndUser_NoData(t *test
 	}
 }
 
+func TestWorkoutSetRepository_GetWeightProgression(t *testing.T) {
+	conn := SetupTestDB(t)
+	defer CleanupTestDB(t, conn)
+
+	repos := SetupRepos(conn)
+	ctx := context.Background()
+
+	user := CreateUser(t, ctx, repos.User)
+	exercise := CreateExercise(t, ctx, repos.Exercise)
+
+	day1 := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC)
+	workout1 := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(day1))
+	workout2 := CreateWorkout(t, ctx, repos.Workout, user.ID, WithDate(day2))
+
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout1.ID, exercise.ID, WithSetNumber(1), WithReps(10), WithWeight(60.0))
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout1.ID, exercise.ID, WithSetNumber(2), WithReps(5), WithWeight(70.0))
+	CreateWorkoutSet(t, ctx, repos.WorkoutSet, workout2.ID, exercise.ID, WithSetNumber(1), WithReps(5), WithWeight(80.0))
+
+	tests := []struct {
+		name    string
+		formula entity.OneRMFormula
+	}{
+		{name: "正常系: Epley式", formula: entity.OneRMFormulaEpley},
+		{name: "正常系: Brzycki式", formula: entity.OneRMFormulaBrzycki},
+		{name: "正常系: Wathan式", formula: entity.OneRMFormulaWathan},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			points, err := repos.WorkoutSet.GetWeightProgression(ctx, user.ID, exercise.ID, tt.formula)
+			if err != nil {
+				t.Fatalf("GetWeightProgression() error = %v", err)
+			}
+
+			if len(points) != 2 {
+				t.Fatalf("GetWeightProgression() returned %d points, want 2", len(points))
+			}
+
+			// 保存済みの推定1RM（Epley式）ではなく、生の重量・レップ数から再計算された値であること
+			want1 := entity.CalculateEstimated1RMWithFormula(60.0, 10, tt.formula)
+			if v := entity.CalculateEstimated1RMWithFormula(70.0, 5, tt.formula); v > want1 {
+				want1 = v
+			}
+			want2 := entity.CalculateEstimated1RMWithFormula(80.0, 5, tt.formula)
+
+			if !points[0].Date.Equal(day1) || points[0].Max1RM != want1 {
+				t.Errorf("points[0] = %+v, want {%v %v}", points[0], day1, want1)
+			}
+			if !points[1].Date.Equal(day2) || points[1].Max1RM != want2 {
+				t.Errorf("points[1] = %+v, want {%v %v}", points[1], day2, want2)
+			}
+		})
+	}
+}
+
 func TestWorkoutSetRepository_WeightConversion(t *testing.T) {
 	conn := SetupTestDB(t)
 	defer CleanupTestDB(t, conn)
@@ -50,3 +50,74 @@ func (s *SmtpSender) SendVerificationEmail(_ context.Context, toEmail string, to
 	logger.Info("Verification email sent", "to", toEmail)
 	return nil
 }
+
+// SendPasswordResetEmail はパスワードリセットメールを送信する
+func (s *SmtpSender) SendPasswordResetEmail(_ context.Context, toEmail string, token string) error {
+	resetURL := fmt.Sprintf("%s/reset-password?token=%s", s.frontendURL, token)
+
+	subject := "【whiskey】パスワードの再設定"
+	body := fmt.Sprintf(`以下のリンクをクリックして、新しいパスワードを設定してください。
+
+%s
+
+このリンクは1時間有効で、一度だけ使用できます。
+心当たりがない場合は、このメールを無視してください。パスワードは変更されません。`, resetURL)
+
+	msg := fmt.Sprintf("From: noreply@whiskey.app\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s",
+		toEmail, subject, body)
+
+	addr := fmt.Sprintf("%s:%s", s.host, s.port)
+	if err := smtp.SendMail(addr, nil, "noreply@whiskey.app", []string{toEmail}, []byte(msg)); err != nil {
+		logger.Error("Failed to send password reset email", "to", toEmail, "error", err)
+		return fmt.Errorf("failed to send password reset email: %w", err)
+	}
+
+	logger.Info("Password reset email sent", "to", toEmail)
+	return nil
+}
+
+// SendEmailChangeVerificationEmail は変更後のメールアドレスに確認メールを送信する
+func (s *SmtpSender) SendEmailChangeVerificationEmail(_ context.Context, toEmail string, token string) error {
+	confirmURL := fmt.Sprintf("%s/confirm-email-change?token=%s", s.frontendURL, token)
+
+	subject := "【whiskey】新しいメールアドレスの確認"
+	body := fmt.Sprintf(`以下のリンクをクリックして、メールアドレスの変更を完了してください。
+
+%s
+
+このリンクは24時間有効で、一度だけ使用できます。
+心当たりがない場合は、このメールを無視してください。メールアドレスは変更されません。`, confirmURL)
+
+	msg := fmt.Sprintf("From: noreply@whiskey.app\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s",
+		toEmail, subject, body)
+
+	addr := fmt.Sprintf("%s:%s", s.host, s.port)
+	if err := smtp.SendMail(addr, nil, "noreply@whiskey.app", []string{toEmail}, []byte(msg)); err != nil {
+		logger.Error("Failed to send email change verification email", "to", toEmail, "error", err)
+		return fmt.Errorf("failed to send email change verification email: %w", err)
+	}
+
+	logger.Info("Email change verification email sent", "to", toEmail)
+	return nil
+}
+
+// SendEmailChangeNotice は変更前のメールアドレスにメールアドレス変更の通知を送信する
+func (s *SmtpSender) SendEmailChangeNotice(_ context.Context, toEmail string, newEmail string) error {
+	subject := "【whiskey】メールアドレス変更の受付"
+	body := fmt.Sprintf(`アカウントのメールアドレスを %s に変更する手続きが行われました。
+新しいメールアドレスで確認が完了すると、変更が反映されます。
+
+心当たりがない場合は、パスワードを変更し、ログイン中のセッションを確認してください。`, newEmail)
+
+	msg := fmt.Sprintf("From: noreply@whiskey.app\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s",
+		toEmail, subject, body)
+
+	addr := fmt.Sprintf("%s:%s", s.host, s.port)
+	if err := smtp.SendMail(addr, nil, "noreply@whiskey.app", []string{toEmail}, []byte(msg)); err != nil {
+		logger.Error("Failed to send email change notice", "to", toEmail, "error", err)
+		return fmt.Errorf("failed to send email change notice: %w", err)
+	}
+
+	logger.Info("Email change notice sent", "to", toEmail)
+	return nil
+}
@@ -0,0 +1,160 @@
+// Package oidc は外部IDプロバイダ（OpenID Connect）との認可コードフローのインフラストラクチャ実装を提供する。
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+// defaultHTTPTimeout はHTTPクライアントが指定されない場合のプロバイダへのリクエストのタイムアウト
+const defaultHTTPTimeout = 10 * time.Second
+
+// defaultScopes はスコープが指定されない場合に要求するスコープ
+var defaultScopes = []string{"openid", "email", "profile"}
+
+// ErrTokenExchangeFailed はトークンエンドポイントが認可コードの交換に失敗した場合のエラー
+var ErrTokenExchangeFailed = errors.New("token exchange failed")
+
+// ProviderConfig は外部IDプロバイダの設定。
+// エンドポイントは全て設定で指定するため、テストではローカルのスタブIdPに向けることができる。
+type ProviderConfig struct {
+	// Name はプロバイダ名（例: google）。URLパスとユーザーIDの紐付けに使用する
+	Name string
+	// Issuer はIDトークンのissクレームとして期待する値
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+
+	AuthorizationEndpoint string
+	TokenEndpoint         string
+	JWKSEndpoint          string
+
+	// RedirectURL はプロバイダに登録したコールバックURL
+	RedirectURL string
+	// Scopes は要求するスコープ。空の場合はopenid, email, profileを要求する
+	Scopes []string
+}
+
+// Provider は設定されたエンドポイントを使用して認可コードフロー（PKCE）を行う。
+// repository.OIDCProviderインターフェースを実装する。
+// IDトークンの署名検証に使用する公開鍵（JWKS）はキャッシュし、未知のkidの場合のみ再取得する。
+type Provider struct {
+	config     ProviderConfig
+	httpClient *http.Client
+	now        func() time.Time
+
+	mu            sync.Mutex
+	keys          map[string]*rsa.PublicKey
+	keysFetchedAt time.Time
+}
+
+// Providerがrepository.OIDCProviderを実装していることをコンパイル時にチェック
+var _ repository.OIDCProvider = (*Provider)(nil)
+
+// NewProvider は指定された設定とHTTPクライアントで新しいProviderを生成する。
+// httpClientがnilの場合はタイムアウト付きのクライアントを使用する。
+func NewProvider(config ProviderConfig, httpClient *http.Client) *Provider {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultHTTPTimeout}
+	}
+	if len(config.Scopes) == 0 {
+		config.Scopes = defaultScopes
+	}
+
+	return &Provider{
+		config:     config,
+		httpClient: httpClient,
+		now:        time.Now,
+	}
+}
+
+// Name はプロバイダ名を返す
+func (p *Provider) Name() string {
+	return p.config.Name
+}
+
+// AuthorizationURL はユーザーを認証のためにリダイレクトさせる認可エンドポイントのURLを返す。
+func (p *Provider) AuthorizationURL(state, nonce, codeChallenge string) string {
+	authURL, err := url.Parse(p.config.AuthorizationEndpoint)
+	if err != nil {
+		// 設定が不正な場合もプロバイダ側でエラーになるよう、そのまま連結する
+		authURL = &url.URL{Path: p.config.AuthorizationEndpoint}
+	}
+
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.config.ClientID)
+	query.Set("redirect_uri", p.config.RedirectURL)
+	query.Set("scope", strings.Join(p.config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String()
+}
+
+// tokenResponse はトークンエンドポイントのレスポンス
+type tokenResponse struct {
+	IDToken string `json:"id_token"`
+}
+
+// Exchange は認可コードをトークンに交換し、IDトークンを検証して認証されたアカウントの情報を返す。
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*repository.OIDCIdentity, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.config.RedirectURL)
+	form.Set("client_id", p.config.ClientID)
+	form.Set("client_secret", p.config.ClientSecret)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("%w: status %d: %s", ErrTokenExchangeFailed, resp.StatusCode, body)
+	}
+
+	var token tokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return nil, fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if token.IDToken == "" {
+		return nil, fmt.Errorf("%w: no id_token in response", ErrTokenExchangeFailed)
+	}
+
+	claims, err := p.verifyIDToken(ctx, token.IDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return &repository.OIDCIdentity{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+	}, nil
+}
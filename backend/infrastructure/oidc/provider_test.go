@@ -0,0 +1,281 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testClientID     = "test-client"
+	testClientSecret = "test-secret"
+	testKeyID        = "test-key"
+	testNonce        = "test-nonce"
+	testCode         = "test-code"
+	testVerifier     = "test-verifier"
+)
+
+// stubIdP is a minimal OpenID Connect provider serving a token endpoint and a JWKS endpoint.
+type stubIdP struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	// claims are the ID token claims returned by the token endpoint
+	claims map[string]interface{}
+	// signingKey overrides the key used to sign the ID token
+	signingKey *rsa.PrivateKey
+	// tokenStatus overrides the status code of the token endpoint
+	tokenStatus int
+	// lastTokenRequest is the form of the last token request
+	lastTokenRequest url.Values
+}
+
+func newStubIdP(t *testing.T) *stubIdP {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idp := &stubIdP{key: key, tokenStatus: http.StatusOK}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		idp.lastTokenRequest = r.PostForm
+
+		if idp.tokenStatus != http.StatusOK {
+			w.WriteHeader(idp.tokenStatus)
+			w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+
+		signingKey := idp.key
+		if idp.signingKey != nil {
+			signingKey = idp.signingKey
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "test-access-token",
+			"token_type":   "Bearer",
+			"id_token":     signIDToken(t, signingKey, idp.claims),
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": testKeyID,
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+
+	idp.claims = map[string]interface{}{
+		"iss":            idp.server.URL,
+		"sub":            "subject-123",
+		"aud":            testClientID,
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          testNonce,
+		"email":          "oidc@example.com",
+		"email_verified": true,
+	}
+
+	return idp
+}
+
+func (idp *stubIdP) provider() *Provider {
+	return NewProvider(ProviderConfig{
+		Name:                  "stub",
+		Issuer:                idp.server.URL,
+		ClientID:              testClientID,
+		ClientSecret:          testClientSecret,
+		AuthorizationEndpoint: idp.server.URL + "/authorize",
+		TokenEndpoint:         idp.server.URL + "/token",
+		JWKSEndpoint:          idp.server.URL + "/jwks",
+		RedirectURL:           "http://localhost:8080/api/auth/oidc/stub/callback",
+	}, idp.server.Client())
+}
+
+func signIDToken(t *testing.T, key *rsa.PrivateKey, claims map[string]interface{}) string {
+	t.Helper()
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": testKeyID})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestProvider_AuthorizationURL(t *testing.T) {
+	idp := newStubIdP(t)
+	provider := idp.provider()
+
+	authURL, err := url.Parse(provider.AuthorizationURL("test-state", testNonce, "test-challenge"))
+	require.NoError(t, err)
+
+	assert.Equal(t, idp.server.URL+"/authorize", authURL.Scheme+"://"+authURL.Host+authURL.Path)
+	query := authURL.Query()
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, testClientID, query.Get("client_id"))
+	assert.Equal(t, "http://localhost:8080/api/auth/oidc/stub/callback", query.Get("redirect_uri"))
+	assert.Equal(t, "openid email profile", query.Get("scope"))
+	assert.Equal(t, "test-state", query.Get("state"))
+	assert.Equal(t, testNonce, query.Get("nonce"))
+	assert.Equal(t, "test-challenge", query.Get("code_challenge"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	idp := newStubIdP(t)
+	provider := idp.provider()
+
+	identity, err := provider.Exchange(context.Background(), testCode, testVerifier, testNonce)
+	require.NoError(t, err)
+	assert.Equal(t, "subject-123", identity.Subject)
+	assert.Equal(t, "oidc@example.com", identity.Email)
+	assert.True(t, identity.EmailVerified)
+
+	// Verify the token request carries the code and PKCE verifier
+	assert.Equal(t, "authorization_code", idp.lastTokenRequest.Get("grant_type"))
+	assert.Equal(t, testCode, idp.lastTokenRequest.Get("code"))
+	assert.Equal(t, testVerifier, idp.lastTokenRequest.Get("code_verifier"))
+	assert.Equal(t, testClientID, idp.lastTokenRequest.Get("client_id"))
+	assert.Equal(t, testClientSecret, idp.lastTokenRequest.Get("client_secret"))
+}
+
+func TestProvider_Exchange_ClaimFormats(t *testing.T) {
+	idp := newStubIdP(t)
+	provider := idp.provider()
+
+	// Some providers return aud as an array and email_verified as a string
+	idp.claims["aud"] = []string{testClientID, "other-client"}
+	idp.claims["azp"] = testClientID
+	idp.claims["email_verified"] = "true"
+
+	identity, err := provider.Exchange(context.Background(), testCode, testVerifier, testNonce)
+	require.NoError(t, err)
+	assert.True(t, identity.EmailVerified)
+
+	idp.claims["email_verified"] = false
+
+	identity, err = provider.Exchange(context.Background(), testCode, testVerifier, testNonce)
+	require.NoError(t, err)
+	assert.False(t, identity.EmailVerified)
+}
+
+func TestProvider_Exchange_InvalidIDToken(t *testing.T) {
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		modify func(idp *stubIdP)
+		nonce  string
+	}{
+		{
+			name:   "nonce mismatch",
+			modify: func(idp *stubIdP) {},
+			nonce:  "other-nonce",
+		},
+		{
+			name:   "wrong issuer",
+			modify: func(idp *stubIdP) { idp.claims["iss"] = "https://evil.example.com" },
+			nonce:  testNonce,
+		},
+		{
+			name:   "wrong audience",
+			modify: func(idp *stubIdP) { idp.claims["aud"] = "other-client" },
+			nonce:  testNonce,
+		},
+		{
+			name:   "wrong authorized party",
+			modify: func(idp *stubIdP) { idp.claims["azp"] = "other-client" },
+			nonce:  testNonce,
+		},
+		{
+			name:   "expired",
+			modify: func(idp *stubIdP) { idp.claims["exp"] = time.Now().Add(-time.Hour).Unix() },
+			nonce:  testNonce,
+		},
+		{
+			name:   "missing subject",
+			modify: func(idp *stubIdP) { delete(idp.claims, "sub") },
+			nonce:  testNonce,
+		},
+		{
+			name:   "signed with another key",
+			modify: func(idp *stubIdP) { idp.signingKey = otherKey },
+			nonce:  testNonce,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp := newStubIdP(t)
+			provider := idp.provider()
+			tt.modify(idp)
+
+			identity, err := provider.Exchange(context.Background(), testCode, testVerifier, tt.nonce)
+			assert.ErrorIs(t, err, ErrInvalidIDToken)
+			assert.Nil(t, identity)
+		})
+	}
+}
+
+func TestProvider_Exchange_TokenEndpointError(t *testing.T) {
+	idp := newStubIdP(t)
+	provider := idp.provider()
+	idp.tokenStatus = http.StatusBadRequest
+
+	identity, err := provider.Exchange(context.Background(), testCode, testVerifier, testNonce)
+	assert.ErrorIs(t, err, ErrTokenExchangeFailed)
+	assert.Nil(t, identity)
+}
+
+func TestProvider_Exchange_UnknownKeyIDRefetchIsThrottled(t *testing.T) {
+	idp := newStubIdP(t)
+	provider := idp.provider()
+
+	// Populate the key cache
+	_, err := provider.Exchange(context.Background(), testCode, testVerifier, testNonce)
+	require.NoError(t, err)
+
+	// A token with an unknown key ID does not trigger another JWKS fetch right away
+	provider.keys = map[string]*rsa.PublicKey{}
+	provider.keysFetchedAt = time.Now()
+
+	_, err = provider.Exchange(context.Background(), testCode, testVerifier, testNonce)
+	assert.ErrorIs(t, err, ErrInvalidIDToken)
+
+	// Once the refresh interval has passed, the JWKS is fetched again
+	provider.keysFetchedAt = time.Now().Add(-jwksRefreshInterval)
+
+	_, err = provider.Exchange(context.Background(), testCode, testVerifier, testNonce)
+	assert.NoError(t, err)
+}
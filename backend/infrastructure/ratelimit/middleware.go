@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+	"github.com/ucchy108/whiskey/backend/pkg/logger"
+)
+
+// Middleware は接続元IPアドレスごとにリクエスト数を制限するHTTPミドルウェアを返す。
+// 直近window内のリクエストがlimit回を超えた場合は、Retry-Afterヘッダー付きでHTTP 429 Too Many Requestsを返す。
+// nameはエンドポイントごとに制限を分けるためのキーの一部として使用する。
+// レート制限の判定自体が失敗した場合は、制限を迂回されないようHTTP 500 Internal Server Errorを返す。
+func Middleware(limiter repository.RateLimiter, name string, limit int, window time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ip := clientIP(r)
+
+			result, err := limiter.Allow(r.Context(), "ip:"+name+":"+ip, limit, window)
+			if err != nil {
+				logger.Error("Failed to check rate limit", "name", name, "ip", ip, "error", err)
+				writeJSONError(w, http.StatusInternalServerError, "Internal server error")
+				return
+			}
+
+			if !result.Allowed {
+				WriteTooManyRequests(w, result.RetryAfter, "Too many requests")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// WriteTooManyRequests はRetry-Afterヘッダー付きでHTTP 429 Too Many Requestsを返す。
+// Retry-Afterは秒単位で、1秒未満は切り上げる。
+func WriteTooManyRequests(w http.ResponseWriter, retryAfter time.Duration, message string) {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	writeJSONError(w, http.StatusTooManyRequests, message)
+}
+
+// writeJSONError は{"error": message}形式のJSONエラーレスポンスを返す。
+func writeJSONError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+// clientIP はリクエストの接続元IPアドレスを返す。
+// 偽装可能なX-Forwarded-For等のヘッダーは信頼せず、RemoteAddrを使用する。
+func clientIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
@@ -0,0 +1,104 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+	"github.com/ucchy108/whiskey/backend/pkg/logger"
+)
+
+func TestMain(m *testing.M) {
+	logger.Init(logger.Config{
+		Level:  slog.LevelDebug,
+		Format: "text",
+	})
+	os.Exit(m.Run())
+}
+
+// fakeLimiter is an in-memory fixed-result RateLimiter for middleware tests.
+type fakeLimiter struct {
+	keys   []string
+	result repository.RateLimitResult
+	err    error
+}
+
+func (f *fakeLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (repository.RateLimitResult, error) {
+	f.keys = append(f.keys, key)
+	return f.result, f.err
+}
+
+func (f *fakeLimiter) Reset(ctx context.Context, key string) error {
+	return f.err
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		limiter        *fakeLimiter
+		expectedStatus int
+		expectedRetry  string
+		expectCalled   bool
+	}{
+		{
+			name:           "正常系: 許可されたリクエストはハンドラーに到達する",
+			limiter:        &fakeLimiter{result: repository.RateLimitResult{Allowed: true}},
+			expectedStatus: http.StatusOK,
+			expectCalled:   true,
+		},
+		{
+			name:           "異常系: 拒否されたリクエストはRetry-After付きの429を返す",
+			limiter:        &fakeLimiter{result: repository.RateLimitResult{Allowed: false, RetryAfter: 1500 * time.Millisecond}},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedRetry:  "2",
+			expectCalled:   false,
+		},
+		{
+			name:           "異常系: 1秒未満のRetry-Afterは1に切り上げる",
+			limiter:        &fakeLimiter{result: repository.RateLimitResult{Allowed: false}},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedRetry:  "1",
+			expectCalled:   false,
+		},
+		{
+			name:           "異常系: レート制限の判定に失敗した場合は500を返す",
+			limiter:        &fakeLimiter{err: errors.New("redis error")},
+			expectedStatus: http.StatusInternalServerError,
+			expectCalled:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				w.WriteHeader(http.StatusOK)
+			})
+			handler := Middleware(tt.limiter, "login", 10, time.Minute)(next)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/login", nil)
+			req.RemoteAddr = "192.0.2.1:54321"
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectCalled, called)
+			assert.Equal(t, tt.expectedRetry, rec.Header().Get("Retry-After"))
+			assert.Equal(t, []string{"ip:login:192.0.2.1"}, tt.limiter.keys)
+			switch tt.expectedStatus {
+			case http.StatusTooManyRequests:
+				assert.JSONEq(t, `{"error":"Too many requests"}`, rec.Body.String())
+			case http.StatusInternalServerError:
+				assert.JSONEq(t, `{"error":"Internal server error"}`, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// Package ratelimit はRedisを使用したレート制限の機能を提供する。
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+// slidingWindowScript は古い試行を取り除いた上で、window内の試行回数がlimit未満であれば試行を記録する。
+// 判定と記録を1つのスクリプトで行うため、同時に実行されてもlimitを超えて許可されることはない。
+// 戻り値は {許可されたか(1/0), 次に許可されるまでのミリ秒}。
+var slidingWindowScript = redis.NewScript(`
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", now - window)
+
+local count = redis.call("ZCARD", KEYS[1])
+if count < limit then
+	redis.call("ZADD", KEYS[1], now, ARGV[4])
+	redis.call("PEXPIRE", KEYS[1], window)
+	return {1, 0}
+end
+
+local oldest = redis.call("ZRANGE", KEYS[1], 0, 0, "WITHSCORES")
+return {0, tonumber(oldest[2]) + window - now}
+`)
+
+// RedisRateLimiter はRedisのソート済みセットを使用したスライディングウィンドウ方式のレート制限。
+// repository.RateLimiterインターフェースを実装する。
+// キーごとに試行時刻をスコアとして記録し、window外になった試行は判定時に取り除く。
+type RedisRateLimiter struct {
+	client *redis.Client
+	// now は現在時刻を返す関数（テストで時刻を固定するために差し替える）
+	now func() time.Time
+}
+
+// RedisRateLimiterがrepository.RateLimiterを実装していることをコンパイル時にチェック
+var _ repository.RateLimiter = (*RedisRateLimiter)(nil)
+
+// NewRedisRateLimiter は指定されたRedisクライアントを使用して新しいRedisRateLimiterインスタンスを生成する。
+func NewRedisRateLimiter(client *redis.Client) *RedisRateLimiter {
+	return &RedisRateLimiter{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// Allow は直近window内の試行がlimit回未満であれば試行を記録して許可する。
+// limit回に達している場合は記録せずに拒否し、最も古い試行がwindow外になるまでの時間を返す。
+func (l *RedisRateLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (repository.RateLimitResult, error) {
+	values, err := slidingWindowScript.Run(ctx, l.client,
+		[]string{rateLimitKey(key)},
+		l.now().UnixMilli(), window.Milliseconds(), limit, uuid.New().String(),
+	).Int64Slice()
+	if err != nil {
+		return repository.RateLimitResult{}, fmt.Errorf("failed to check rate limit: %w", err)
+	}
+
+	return repository.RateLimitResult{
+		Allowed:    values[0] == 1,
+		RetryAfter: time.Duration(values[1]) * time.Millisecond,
+	}, nil
+}
+
+// Reset はキーに記録された試行を全て削除する。
+func (l *RedisRateLimiter) Reset(ctx context.Context, key string) error {
+	if err := l.client.Del(ctx, rateLimitKey(key)).Err(); err != nil {
+		return fmt.Errorf("failed to reset rate limit: %w", err)
+	}
+	return nil
+}
+
+// rateLimitKey はレート制限の試行を記録するキーを返す
+func rateLimitKey(key string) string {
+	return fmt.Sprintf("rate_limit:%s", key)
+}
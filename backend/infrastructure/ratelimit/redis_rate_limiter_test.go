@@ -0,0 +1,106 @@
+package ratelimit
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTestRedis creates a test Redis client.
+// Redis のアドレスは環境変数 REDIS_URL で指定可能（デフォルト: localhost:6379）。
+func setupTestRedis(t *testing.T) *redis.Client {
+	t.Helper()
+
+	addr := os.Getenv("REDIS_URL")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr: addr,
+		DB:   15, // Use DB 15 for testing to avoid conflicts
+	})
+
+	ctx := context.Background()
+	require.NoError(t, client.Ping(ctx).Err(), "Failed to connect to Redis")
+	require.NoError(t, client.FlushDB(ctx).Err(), "Failed to flush Redis DB")
+
+	return client
+}
+
+// newTestLimiter creates a limiter whose clock is controlled by the returned pointer.
+func newTestLimiter(client *redis.Client) (*RedisRateLimiter, *time.Time) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewRedisRateLimiter(client)
+	limiter.now = func() time.Time { return now }
+	return limiter, &now
+}
+
+func TestRedisRateLimiter_Allow(t *testing.T) {
+	client := setupTestRedis(t)
+	defer client.Close()
+
+	limiter, now := newTestLimiter(client)
+	ctx := context.Background()
+	window := 1 * time.Minute
+
+	// First 3 attempts are allowed
+	for i := 0; i < 3; i++ {
+		result, err := limiter.Allow(ctx, "test", 3, window)
+		require.NoError(t, err)
+		assert.True(t, result.Allowed, "attempt %d should be allowed", i+1)
+		*now = now.Add(10 * time.Second)
+	}
+
+	// 4th attempt is rejected until the oldest attempt leaves the window
+	result, err := limiter.Allow(ctx, "test", 3, window)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 30*time.Second, result.RetryAfter)
+
+	// Rejected attempts are not recorded, so the retry time does not move
+	*now = now.Add(29 * time.Second)
+	result, err = limiter.Allow(ctx, "test", 3, window)
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 1*time.Second, result.RetryAfter)
+
+	// Once the oldest attempt slides out, one more attempt is allowed
+	*now = now.Add(1 * time.Second)
+	result, err = limiter.Allow(ctx, "test", 3, window)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	// Other keys are independent
+	result, err = limiter.Allow(ctx, "other", 3, window)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+}
+
+func TestRedisRateLimiter_Reset(t *testing.T) {
+	client := setupTestRedis(t)
+	defer client.Close()
+
+	limiter, _ := newTestLimiter(client)
+	ctx := context.Background()
+
+	_, err := limiter.Allow(ctx, "test", 1, 1*time.Minute)
+	require.NoError(t, err)
+	result, err := limiter.Allow(ctx, "test", 1, 1*time.Minute)
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+
+	require.NoError(t, limiter.Reset(ctx, "test"))
+
+	result, err = limiter.Allow(ctx, "test", 1, 1*time.Minute)
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	// Resetting an unknown key is not an error
+	assert.NoError(t, limiter.Reset(ctx, "unknown"))
+}
@@ -8,17 +8,27 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/ucchy108/whiskey/backend/domain/repository"
 	"github.com/ucchy108/whiskey/backend/infrastructure/auth"
+	"github.com/ucchy108/whiskey/backend/infrastructure/ratelimit"
 	"github.com/ucchy108/whiskey/backend/interfaces/handler"
 	"github.com/ucchy108/whiskey/backend/pkg/logger"
 )
 
 // RouterConfig はルーター設定のための構成オプション。
 type RouterConfig struct {
-	UserHandler     *handler.UserHandler
-	WorkoutHandler  *handler.WorkoutHandler
-	ExerciseHandler *handler.ExerciseHandler
-	ProfileHandler  *handler.ProfileHandler
-	SessionRepo     repository.SessionRepository
+	UserHandler           *handler.UserHandler
+	AccountHandler        *handler.AccountHandler
+	OIDCHandler           *handler.OIDCHandler
+	WorkoutHandler        *handler.WorkoutHandler
+	ExerciseHandler       *handler.ExerciseHandler
+	ProfileHandler        *handler.ProfileHandler
+	PersonalRecordHandler *handler.PersonalRecordHandler
+	RoutineHandler        *handler.RoutineHandler
+	ProgramHandler        *handler.ProgramHandler
+	ImportHandler         *handler.ImportHandler
+	AnalyticsHandler      *handler.AnalyticsHandler
+	StatsHandler          *handler.StatsHandler
+	SessionRepo           repository.SessionRepository
+	RateLimiter           repository.RateLimiter
 }
 
 // NewRouter はすべてのルートとミドルウェアが設定された新しいHTTPハンドラーを生成する。
@@ -41,28 +51,52 @@ func NewRouter(config RouterConfig) http.Handler {
 	api := r.PathPrefix("/api").Subrouter()
 
 	// 認証不要のエンドポイント
-	api.HandleFunc("/users", config.UserHandler.Register).Methods("POST")
-	api.HandleFunc("/auth/login", config.UserHandler.Login).Methods("POST")
+	// パスワード総当たりやメール送信の乱用を防ぐため、接続元IPアドレスごとにリクエスト数を制限する
+	loginLimit := ratelimit.Middleware(config.RateLimiter, "login", 20, 1*time.Minute)
+	registerLimit := ratelimit.Middleware(config.RateLimiter, "register", 10, 1*time.Hour)
+	emailLimit := ratelimit.Middleware(config.RateLimiter, "email", 10, 1*time.Hour)
+	resetLimit := ratelimit.Middleware(config.RateLimiter, "reset-password", 20, 1*time.Hour)
+
+	api.Handle("/users", registerLimit(http.HandlerFunc(config.UserHandler.Register))).Methods("POST")
+	api.Handle("/auth/login", loginLimit(http.HandlerFunc(config.UserHandler.Login))).Methods("POST")
+	api.Handle("/auth/2fa/verify", loginLimit(http.HandlerFunc(config.UserHandler.VerifyTwoFactor))).Methods("POST")
+	api.Handle("/auth/oidc/{provider}/login", loginLimit(http.HandlerFunc(config.OIDCHandler.Login))).Methods("GET")
+	api.Handle("/auth/oidc/{provider}/callback", loginLimit(http.HandlerFunc(config.OIDCHandler.Callback))).Methods("GET")
 	api.HandleFunc("/auth/verify-email", config.UserHandler.VerifyEmail).Methods("GET")
-	api.HandleFunc("/auth/resend-verification", config.UserHandler.ResendVerificationEmail).Methods("POST")
+	api.HandleFunc("/auth/confirm-email-change", config.UserHandler.ConfirmEmailChange).Methods("GET")
+	api.Handle("/auth/resend-verification", emailLimit(http.HandlerFunc(config.UserHandler.ResendVerificationEmail))).Methods("POST")
+	api.Handle("/auth/forgot-password", emailLimit(http.HandlerFunc(config.UserHandler.ForgotPassword))).Methods("POST")
+	api.Handle("/auth/reset-password", resetLimit(http.HandlerFunc(config.UserHandler.ResetPassword))).Methods("POST")
 
 	// 認証が必要なエンドポイント
 	authRequired := api.PathPrefix("").Subrouter()
 	authRequired.Use(auth.AuthMiddleware(config.SessionRepo))
 	authRequired.HandleFunc("/auth/me", config.UserHandler.GetMe).Methods("GET")
 	authRequired.HandleFunc("/auth/logout", config.UserHandler.Logout).Methods("POST")
+	authRequired.HandleFunc("/auth/sessions", config.UserHandler.ListSessions).Methods("GET")
+	authRequired.HandleFunc("/auth/sessions/{id}", config.UserHandler.RevokeSession).Methods("DELETE")
+	authRequired.HandleFunc("/auth/2fa/enroll", config.UserHandler.EnrollTwoFactor).Methods("POST")
+	authRequired.HandleFunc("/auth/2fa/confirm", config.UserHandler.ConfirmTwoFactor).Methods("POST")
+	authRequired.HandleFunc("/auth/2fa/disable", config.UserHandler.DisableTwoFactor).Methods("POST")
+	authRequired.HandleFunc("/auth/2fa/recovery-codes", config.UserHandler.RegenerateRecoveryCodes).Methods("POST")
+	// 注意: /users/me, /users/me/* は /users/{id} より前に登録（Gorilla Muxの優先順位）
+	authRequired.HandleFunc("/users/me", config.AccountHandler.DeleteAccount).Methods("DELETE")
+	authRequired.HandleFunc("/users/me/export", config.AccountHandler.ExportAccount).Methods("GET")
+	authRequired.HandleFunc("/users/me/email", config.UserHandler.ChangeEmail).Methods("PUT")
 	authRequired.HandleFunc("/users/{id}", config.UserHandler.GetUser).Methods("GET")
 	authRequired.HandleFunc("/users/{id}/password", config.UserHandler.ChangePassword).Methods("PUT")
 
 	// ワークアウトルート
-	// 注意: /workouts/contributions は /workouts/{id} より前に登録（Gorilla Muxの優先順位）
+	// 注意: /workouts/contributions, /workouts/export は /workouts/{id} より前に登録（Gorilla Muxの優先順位）
 	authRequired.HandleFunc("/workouts", config.WorkoutHandler.RecordWorkout).Methods("POST")
 	authRequired.HandleFunc("/workouts", config.WorkoutHandler.GetUserWorkouts).Methods("GET")
 	authRequired.HandleFunc("/workouts/contributions", config.WorkoutHandler.GetContributionData).Methods("GET")
+	authRequired.HandleFunc("/workouts/export", config.WorkoutHandler.ExportWorkouts).Methods("GET")
 	authRequired.HandleFunc("/workouts/{id}", config.WorkoutHandler.GetWorkout).Methods("GET")
 	authRequired.HandleFunc("/workouts/{id}/memo", config.WorkoutHandler.UpdateWorkoutMemo).Methods("PUT")
 	authRequired.HandleFunc("/workouts/{id}/sets", config.WorkoutHandler.AddWorkoutSets).Methods("POST")
 	authRequired.HandleFunc("/workouts/{id}", config.WorkoutHandler.DeleteWorkout).Methods("DELETE")
+	authRequired.HandleFunc("/workout-sets/{id}", config.WorkoutHandler.UpdateWorkoutSet).Methods("PUT")
 	authRequired.HandleFunc("/workout-sets/{id}", config.WorkoutHandler.DeleteWorkoutSet).Methods("DELETE")
 
 	// プロフィールルート
@@ -75,14 +109,42 @@ func NewRouter(config RouterConfig) http.Handler {
 	authRequired.HandleFunc("/profile", config.ProfileHandler.UpdateProfile).Methods("PUT")
 
 	// エクササイズルート
-	// 注意: /exercises/{id}/progression は /exercises/{id} より前に登録（Gorilla Muxの優先順位）
+	// 注意: /exercises/{id}/progression, /exercises/{id}/records は /exercises/{id} より前に登録（Gorilla Muxの優先順位）
 	authRequired.HandleFunc("/exercises/{id}/progression", config.WorkoutHandler.GetWeightProgression).Methods("GET")
+	authRequired.HandleFunc("/exercises/{id}/records", config.PersonalRecordHandler.GetExercisePersonalRecords).Methods("GET")
 	authRequired.HandleFunc("/exercises", config.ExerciseHandler.CreateExercise).Methods("POST")
 	authRequired.HandleFunc("/exercises", config.ExerciseHandler.ListExercises).Methods("GET")
 	authRequired.HandleFunc("/exercises/{id}", config.ExerciseHandler.GetExercise).Methods("GET")
 	authRequired.HandleFunc("/exercises/{id}", config.ExerciseHandler.UpdateExercise).Methods("PUT")
 	authRequired.HandleFunc("/exercises/{id}", config.ExerciseHandler.DeleteExercise).Methods("DELETE")
 
+	// 自己ベストルート
+	authRequired.HandleFunc("/records", config.PersonalRecordHandler.GetPersonalRecords).Methods("GET")
+
+	// ルーティンルート
+	authRequired.HandleFunc("/routines", config.RoutineHandler.CreateRoutine).Methods("POST")
+	authRequired.HandleFunc("/routines", config.RoutineHandler.ListRoutines).Methods("GET")
+	authRequired.HandleFunc("/routines/{id}", config.RoutineHandler.GetRoutine).Methods("GET")
+	authRequired.HandleFunc("/routines/{id}", config.RoutineHandler.UpdateRoutine).Methods("PUT")
+	authRequired.HandleFunc("/routines/{id}", config.RoutineHandler.DeleteRoutine).Methods("DELETE")
+	authRequired.HandleFunc("/routines/{id}/start", config.RoutineHandler.StartRoutine).Methods("POST")
+
+	// プログラムルート
+	authRequired.HandleFunc("/programs", config.ProgramHandler.CreateProgram).Methods("POST")
+	authRequired.HandleFunc("/programs", config.ProgramHandler.ListPrograms).Methods("GET")
+	authRequired.HandleFunc("/programs/{id}", config.ProgramHandler.GetProgram).Methods("GET")
+	authRequired.HandleFunc("/programs/{id}", config.ProgramHandler.DeleteProgram).Methods("DELETE")
+	authRequired.HandleFunc("/programs/{id}/current", config.ProgramHandler.GetCurrentProgramDay).Methods("GET")
+
+	// インポートルート
+	authRequired.HandleFunc("/imports", config.ImportHandler.ImportWorkouts).Methods("POST")
+
+	// 分析ルート
+	authRequired.HandleFunc("/analytics/volume", config.AnalyticsHandler.GetVolume).Methods("GET")
+
+	// 統計ルート
+	authRequired.HandleFunc("/stats/streaks", config.StatsHandler.GetStreaks).Methods("GET")
+
 	// CORSミドルウェアをルーター全体にラップ（ルートマッチ前に実行される）
 	// Gorilla Mux の r.Use() はマッチしたルートでのみ実行されるため、
 	// OPTIONS プリフライトリクエスト（ルートマッチしない）にも CORS ヘッダーを返すには
@@ -158,6 +220,7 @@ func corsMiddleware(next http.Handler) http.Handler {
 		}
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
 		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Expose-Headers", "Retry-After")
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
 
 		// プリフライトリクエストに応答
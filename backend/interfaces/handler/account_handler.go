@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ucchy108/whiskey/backend/infrastructure/auth"
+	"github.com/ucchy108/whiskey/backend/usecase"
+)
+
+// AccountHandler はアカウントの削除とデータエクスポートのHTTPハンドラーを提供する。
+type AccountHandler struct {
+	accountUsecase usecase.AccountUsecaseInterface
+}
+
+// NewAccountHandler はAccountHandlerの新しいインスタンスを生成する。
+func NewAccountHandler(accountUsecase usecase.AccountUsecaseInterface) *AccountHandler {
+	return &AccountHandler{
+		accountUsecase: accountUsecase,
+	}
+}
+
+// DeleteAccountRequest はアカウント削除APIのリクエストボディ
+type DeleteAccountRequest struct {
+	Password string `json:"password"`
+}
+
+// DeleteAccountResponse はアカウント削除APIのレスポンスボディ
+type DeleteAccountResponse struct {
+	DeletionScheduledAt string `json:"deletion_scheduled_at"`
+}
+
+// AccountExportResponse はデータエクスポートAPIのレスポンスボディ。
+// Profileはプロフィールを作成していない場合はnullとなる。
+type AccountExportResponse struct {
+	ExportedAt string                      `json:"exported_at"`
+	User       AccountExportUserResponse   `json:"user"`
+	Profile    *ProfileResponse            `json:"profile"`
+	Workouts   []AccountExportWorkoutEntry `json:"workouts"`
+	Exercises  []ExerciseResponse          `json:"exercises"`
+}
+
+// AccountExportUserResponse はエクスポートに含めるユーザー情報。
+// パスワードハッシュや各種トークンなどの認証情報は含めない。
+type AccountExportUserResponse struct {
+	ID               string `json:"id"`
+	Email            string `json:"email"`
+	EmailVerified    bool   `json:"email_verified"`
+	TwoFactorEnabled bool   `json:"two_factor_enabled"`
+	CreatedAt        string `json:"created_at"`
+}
+
+// AccountExportWorkoutEntry はエクスポートに含めるワークアウトとそのセット
+type AccountExportWorkoutEntry struct {
+	WorkoutResponse
+	Sets []AccountExportSetResponse `json:"sets"`
+}
+
+// AccountExportSetResponse はエクスポートに含めるセット（種目名付き）
+type AccountExportSetResponse struct {
+	WorkoutSetResponse
+	ExerciseName string `json:"exercise_name"`
+}
+
+// DeleteAccount は本人確認のうえ、現在のユーザーのアカウント削除を予約する。
+// DELETE /api/users/me
+//
+// リクエストボディ:
+//
+//	{
+//	  "password": "currentpassword"
+//	}
+//
+// 外部IDプロバイダ経由のみでパスワードを持たないユーザーはpasswordの代わりに、
+// 直近（10分以内）にログインし直したセッションであることで本人確認する。
+//
+// データは猶予期間（30日）の経過後に完全に削除される。全てのセッションは無効化され、
+// 猶予期間中に再度ログインすると削除は取り消される。
+//
+// レスポンス:
+//   - 202 Accepted: 削除予約成功（削除予定日時を返す）
+//   - 400 Bad Request: リクエストボディが不正
+//   - 401 Unauthorized: パスワードが不正
+//   - 403 Forbidden: パスワードを持たないユーザーで、直近にログインし直していない
+//   - 500 Internal Server Error: サーバーエラー
+func (h *AccountHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	var req DeleteAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	sessionID := auth.GetSessionIDFromContext(r.Context())
+	scheduledAt, err := h.accountUsecase.RequestDeletion(r.Context(), userID, sessionID, req.Password)
+	if err != nil {
+		handleUsecaseError(w, err)
+		return
+	}
+
+	// セッションはUsecaseで無効化済みのため、Cookieも削除する
+	auth.ClearSessionCookie(w)
+
+	respondJSON(w, http.StatusAccepted, DeleteAccountResponse{
+		DeletionScheduledAt: scheduledAt.Format(time.RFC3339),
+	})
+}
+
+// ExportAccount は現在のユーザーの全データをJSONファイルとしてダウンロードさせる。
+// GET /api/users/me/export
+//
+// レスポンス:
+//   - 200 OK: エクスポート成功（Content-Disposition: attachment）
+//   - 401 Unauthorized: 未認証（AuthMiddlewareで処理）
+//   - 500 Internal Server Error: サーバーエラー
+func (h *AccountHandler) ExportAccount(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	export, err := h.accountUsecase.Export(r.Context(), userID)
+	if err != nil {
+		handleUsecaseError(w, err)
+		return
+	}
+
+	filename := fmt.Sprintf("whiskey-export-%s.json", export.ExportedAt.Format("20060102"))
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	respondJSON(w, http.StatusOK, toAccountExportResponse(export))
+}
+
+// toAccountExportResponse はエクスポート結果をAccountExportResponseに変換する。
+func toAccountExportResponse(export *usecase.AccountExport) AccountExportResponse {
+	resp := AccountExportResponse{
+		ExportedAt: export.ExportedAt.Format(time.RFC3339),
+		User: AccountExportUserResponse{
+			ID:               export.User.ID.String(),
+			Email:            export.User.Email.String(),
+			EmailVerified:    export.User.EmailVerified,
+			TwoFactorEnabled: export.User.TwoFactorEnabled,
+			CreatedAt:        export.User.CreatedAt.Format(time.RFC3339),
+		},
+		Workouts:  make([]AccountExportWorkoutEntry, 0, len(export.Workouts)),
+		Exercises: make([]ExerciseResponse, 0, len(export.Exercises)),
+	}
+
+	if export.Profile != nil {
+		profile := toProfileResponse(export.Profile)
+		resp.Profile = &profile
+	}
+
+	for _, we := range export.Workouts {
+		sets := make([]AccountExportSetResponse, 0, len(we.Sets))
+		for _, detail := range we.Sets {
+			sets = append(sets, AccountExportSetResponse{
+				WorkoutSetResponse: toWorkoutSetResponse(detail.Set),
+				ExerciseName:       detail.ExerciseName,
+			})
+		}
+		resp.Workouts = append(resp.Workouts, AccountExportWorkoutEntry{
+			WorkoutResponse: toWorkoutResponse(we.Workout),
+			Sets:            sets,
+		})
+	}
+
+	for _, exercise := range export.Exercises {
+		resp.Exercises = append(resp.Exercises, toExerciseResponse(exercise))
+	}
+
+	return resp
+}
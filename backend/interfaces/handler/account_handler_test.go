@@ -0,0 +1,243 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+	"github.com/ucchy108/whiskey/backend/infrastructure/auth"
+	"github.com/ucchy108/whiskey/backend/usecase"
+)
+
+// mockAccountUsecase はAccountUsecaseのモック実装
+type mockAccountUsecase struct {
+	requestDeletionFunc  func(ctx context.Context, userID uuid.UUID, sessionID, password string) (time.Time, error)
+	purgeDueAccountsFunc func(ctx context.Context) (int, error)
+	exportFunc           func(ctx context.Context, userID uuid.UUID) (*usecase.AccountExport, error)
+}
+
+func (m *mockAccountUsecase) RequestDeletion(ctx context.Context, userID uuid.UUID, sessionID, password string) (time.Time, error) {
+	if m.requestDeletionFunc != nil {
+		return m.requestDeletionFunc(ctx, userID, sessionID, password)
+	}
+	return time.Time{}, nil
+}
+
+func (m *mockAccountUsecase) PurgeDueAccounts(ctx context.Context) (int, error) {
+	if m.purgeDueAccountsFunc != nil {
+		return m.purgeDueAccountsFunc(ctx)
+	}
+	return 0, nil
+}
+
+func (m *mockAccountUsecase) Export(ctx context.Context, userID uuid.UUID) (*usecase.AccountExport, error) {
+	if m.exportFunc != nil {
+		return m.exportFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func TestAccountHandler_DeleteAccount(t *testing.T) {
+	scheduledAt := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockFunc       func(ctx context.Context, userID uuid.UUID, sessionID, password string) (time.Time, error)
+		expectedStatus int
+		wantCleared    bool
+	}{
+		{
+			name:        "成功: 削除を予約してセッションCookieを削除",
+			requestBody: DeleteAccountRequest{Password: "password123"},
+			mockFunc: func(ctx context.Context, userID uuid.UUID, sessionID, password string) (time.Time, error) {
+				if sessionID != "session-1" {
+					t.Errorf("unexpected session ID: %s", sessionID)
+				}
+				return scheduledAt, nil
+			},
+			expectedStatus: http.StatusAccepted,
+			wantCleared:    true,
+		},
+		{
+			name:           "失敗: リクエストボディが不正",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "失敗: パスワードが不正",
+			requestBody: DeleteAccountRequest{Password: "wrongpassword"},
+			mockFunc: func(ctx context.Context, userID uuid.UUID, sessionID, password string) (time.Time, error) {
+				return time.Time{}, usecase.ErrInvalidCredentials
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:        "失敗: パスワードを持たないユーザーが直近にログインし直していない",
+			requestBody: DeleteAccountRequest{},
+			mockFunc: func(ctx context.Context, userID uuid.UUID, sessionID, password string) (time.Time, error) {
+				return time.Time{}, usecase.ErrRecentLoginRequired
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:        "失敗: サーバーエラー",
+			requestBody: DeleteAccountRequest{Password: "password123"},
+			mockFunc: func(ctx context.Context, userID uuid.UUID, sessionID, password string) (time.Time, error) {
+				return time.Time{}, errors.New("database error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockAccountUsecase{requestDeletionFunc: tt.mockFunc}
+			h := NewAccountHandler(mock)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest("DELETE", "/api/users/me", bytes.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req = setAuthContext(req, uuid.New())
+			req = req.WithContext(context.WithValue(req.Context(), auth.SessionIDContextKey, "session-1"))
+
+			rec := httptest.NewRecorder()
+			h.DeleteAccount(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			cleared := false
+			for _, c := range rec.Result().Cookies() {
+				if c.Name == "session_id" && c.MaxAge < 0 {
+					cleared = true
+				}
+			}
+			if cleared != tt.wantCleared {
+				t.Errorf("session cookie cleared = %v, want %v", cleared, tt.wantCleared)
+			}
+
+			if tt.expectedStatus == http.StatusAccepted {
+				var resp DeleteAccountResponse
+				json.NewDecoder(rec.Body).Decode(&resp)
+				if resp.DeletionScheduledAt != scheduledAt.Format(time.RFC3339) {
+					t.Errorf("expected deletion_scheduled_at %s, got %s", scheduledAt.Format(time.RFC3339), resp.DeletionScheduledAt)
+				}
+			}
+		})
+	}
+}
+
+func TestAccountHandler_ExportAccount(t *testing.T) {
+	exportedAt := time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		mockFunc       func(ctx context.Context, userID uuid.UUID) (*usecase.AccountExport, error)
+		expectedStatus int
+		checkResponse  func(t *testing.T, rec *httptest.ResponseRecorder)
+	}{
+		{
+			name: "成功: 全データをダウンロード",
+			mockFunc: func(ctx context.Context, userID uuid.UUID) (*usecase.AccountExport, error) {
+				user, _ := entity.NewUser("user@example.com", "password123")
+				user.ID = userID
+				profile, _ := entity.NewProfile(userID, "テストユーザー")
+				exercise, _ := entity.NewUserExercise(userID, "カスタム種目", nil, nil)
+				workout := entity.NewWorkout(userID, exportedAt)
+				set, _ := entity.NewWorkoutSet(workout.ID, exercise.ID, 1, 10, 50)
+				return &usecase.AccountExport{
+					ExportedAt: exportedAt,
+					User:       user,
+					Profile:    profile,
+					Workouts: []usecase.WorkoutExport{
+						{Workout: workout, Sets: []repository.WorkoutSetDetail{{Set: set, ExerciseName: exercise.Name}}},
+					},
+					Exercises: []*entity.Exercise{exercise},
+				}, nil
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				want := `attachment; filename="whiskey-export-20260115.json"`
+				if got := rec.Header().Get("Content-Disposition"); got != want {
+					t.Errorf("expected Content-Disposition %s, got %s", want, got)
+				}
+
+				var resp AccountExportResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				if resp.User.Email != "user@example.com" {
+					t.Errorf("expected user email user@example.com, got %s", resp.User.Email)
+				}
+				if resp.Profile == nil || resp.Profile.DisplayName != "テストユーザー" {
+					t.Errorf("expected profile テストユーザー, got %v", resp.Profile)
+				}
+				if len(resp.Workouts) != 1 || len(resp.Workouts[0].Sets) != 1 {
+					t.Fatalf("expected 1 workout with 1 set, got %v", resp.Workouts)
+				}
+				if resp.Workouts[0].Sets[0].ExerciseName != "カスタム種目" {
+					t.Errorf("expected exercise_name カスタム種目, got %s", resp.Workouts[0].Sets[0].ExerciseName)
+				}
+				if len(resp.Exercises) != 1 {
+					t.Errorf("expected 1 exercise, got %d", len(resp.Exercises))
+				}
+			},
+		},
+		{
+			name: "成功: プロフィール未作成の場合はnull",
+			mockFunc: func(ctx context.Context, userID uuid.UUID) (*usecase.AccountExport, error) {
+				user, _ := entity.NewUser("user@example.com", "password123")
+				return &usecase.AccountExport{ExportedAt: exportedAt, User: user}, nil
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				var body map[string]interface{}
+				json.NewDecoder(rec.Body).Decode(&body)
+				if v, ok := body["profile"]; !ok || v != nil {
+					t.Errorf("expected profile null, got %v", v)
+				}
+				if workouts, ok := body["workouts"].([]interface{}); !ok || len(workouts) != 0 {
+					t.Errorf("expected empty workouts, got %v", body["workouts"])
+				}
+			},
+		},
+		{
+			name: "失敗: サーバーエラー",
+			mockFunc: func(ctx context.Context, userID uuid.UUID) (*usecase.AccountExport, error) {
+				return nil, errors.New("database error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockAccountUsecase{exportFunc: tt.mockFunc}
+			h := NewAccountHandler(mock)
+
+			req := httptest.NewRequest("GET", "/api/users/me/export", nil)
+			req = setAuthContext(req, uuid.New())
+
+			rec := httptest.NewRecorder()
+			h.ExportAccount(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, rec)
+			}
+		})
+	}
+}
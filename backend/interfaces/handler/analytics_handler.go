@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ucchy108/whiskey/backend/infrastructure/auth"
+	"github.com/ucchy108/whiskey/backend/usecase"
+)
+
+// AnalyticsHandler はトレーニング分析関連のHTTPハンドラーを提供する。
+// Usecase層のビジネスロジックをRESTful APIとして公開する。
+type AnalyticsHandler struct {
+	analyticsUsecase usecase.AnalyticsUsecaseInterface
+	profileUsecase   usecase.ProfileUsecaseInterface
+}
+
+// NewAnalyticsHandler はAnalyticsHandlerの新しいインスタンスを生成する。
+//
+// パラメータ:
+//   - analyticsUsecase: トレーニング分析に関するビジネスロジックを提供するユースケース
+//   - profileUsecase: 日付の解釈に使用するユーザーのタイムゾーンを提供するユースケース
+//
+// 戻り値:
+//   - *AnalyticsHandler: 生成されたAnalyticsHandlerインスタンス
+func NewAnalyticsHandler(analyticsUsecase usecase.AnalyticsUsecaseInterface, profileUsecase usecase.ProfileUsecaseInterface) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsUsecase: analyticsUsecase,
+		profileUsecase:   profileUsecase,
+	}
+}
+
+// --- レスポンスDTO ---
+
+// VolumeAggregateResponse はトレーニング量の1期間・1グループ分のレスポンスボディ。
+// exercise_id・exercise_name は身体部位ごとの集計ではnullとなる。
+type VolumeAggregateResponse struct {
+	PeriodStart      string  `json:"period_start"`
+	ExerciseID       *string `json:"exercise_id"`
+	ExerciseName     *string `json:"exercise_name"`
+	BodyPart         *string `json:"body_part"`
+	Tonnage          float64 `json:"tonnage"`
+	SetCount         int     `json:"set_count"`
+	RepCount         int     `json:"rep_count"`
+	AverageIntensity float64 `json:"average_intensity"`
+}
+
+// --- ハンドラーメソッド ---
+
+// GetVolume は期間と種目または身体部位ごとのトレーニング量を取得する。
+// GET /api/analytics/volume?group_by=week&dimension=exercise&start_date=...&end_date=...
+//
+// クエリパラメータ:
+//   - group_by: 集計期間の単位（week または month、省略時は week）
+//   - dimension: 集計軸（exercise または body_part、省略時は exercise）
+//   - start_date: 開始日（YYYY-MM-DDまたはRFC3339形式、省略可）
+//   - end_date: 終了日（YYYY-MM-DDまたはRFC3339形式、省略可）
+//
+// レスポンス:
+//   - 200 OK: 取得成功（古い期間から順）
+//   - 400 Bad Request: クエリパラメータが不正
+//   - 500 Internal Server Error: サーバーエラー
+func (h *AnalyticsHandler) GetVolume(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+	query := r.URL.Query()
+
+	loc, err := h.profileUsecase.GetLocation(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	var startDate, endDate *time.Time
+	if s := query.Get("start_date"); s != "" {
+		t, err := parseDate(s, loc)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid start_date format, expected YYYY-MM-DD or RFC3339")
+			return
+		}
+		startDate = &t
+	}
+	if e := query.Get("end_date"); e != "" {
+		t, err := parseDate(e, loc)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid end_date format, expected YYYY-MM-DD or RFC3339")
+			return
+		}
+		endDate = &t
+	}
+
+	aggregates, err := h.analyticsUsecase.GetVolume(r.Context(), usecase.GetVolumeInput{
+		UserID:    userID,
+		GroupBy:   usecase.VolumeGroupBy(query.Get("group_by")),
+		Dimension: usecase.VolumeDimension(query.Get("dimension")),
+		StartDate: startDate,
+		EndDate:   endDate,
+	})
+	if err != nil {
+		handleAnalyticsUsecaseError(w, err)
+		return
+	}
+
+	resp := make([]VolumeAggregateResponse, 0, len(aggregates))
+	for _, aggregate := range aggregates {
+		resp = append(resp, toVolumeAggregateResponse(aggregate))
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// --- ヘルパー関数 ---
+
+// handleAnalyticsUsecaseError はAnalytics Usecase層のエラーを適切なHTTPステータスコードに変換する。
+func handleAnalyticsUsecaseError(w http.ResponseWriter, err error) {
+	switch err {
+	case usecase.ErrInvalidVolumeGroupBy, usecase.ErrInvalidVolumeDimension:
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+// toVolumeAggregateResponse はVolumeAggregateをVolumeAggregateResponseに変換する。
+func toVolumeAggregateResponse(aggregate usecase.VolumeAggregate) VolumeAggregateResponse {
+	resp := VolumeAggregateResponse{
+		PeriodStart:      aggregate.PeriodStart.Format(time.DateOnly),
+		ExerciseName:     aggregate.ExerciseName,
+		Tonnage:          aggregate.Tonnage,
+		SetCount:         aggregate.SetCount,
+		RepCount:         aggregate.RepCount,
+		AverageIntensity: aggregate.AverageIntensity,
+	}
+	if aggregate.ExerciseID != nil {
+		id := aggregate.ExerciseID.String()
+		resp.ExerciseID = &id
+	}
+	if aggregate.BodyPart != nil {
+		bodyPart := string(*aggregate.BodyPart)
+		resp.BodyPart = &bodyPart
+	}
+	return resp
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/usecase"
+)
+
+// mockAnalyticsUsecase はAnalyticsUsecaseのモック実装
+type mockAnalyticsUsecase struct {
+	getVolumeFunc func(ctx context.Context, input usecase.GetVolumeInput) ([]usecase.VolumeAggregate, error)
+}
+
+func (m *mockAnalyticsUsecase) GetVolume(ctx context.Context, input usecase.GetVolumeInput) ([]usecase.VolumeAggregate, error) {
+	if m.getVolumeFunc != nil {
+		return m.getVolumeFunc(ctx, input)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func TestAnalyticsHandler_GetVolume(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	exerciseID := uuid.New()
+	exerciseName := "ベンチプレス"
+	chest := entity.BodyPartChest
+
+	tests := []struct {
+		name           string
+		query          string
+		mockFunc       func(ctx context.Context, input usecase.GetVolumeInput) ([]usecase.VolumeAggregate, error)
+		expectedStatus int
+		checkResponse  func(t *testing.T, resp []VolumeAggregateResponse)
+	}{
+		{
+			name:  "成功: 週・種目ごと",
+			query: "?group_by=week&dimension=exercise&start_date=2026-01-01&end_date=2026-01-31",
+			mockFunc: func(ctx context.Context, input usecase.GetVolumeInput) ([]usecase.VolumeAggregate, error) {
+				if input.GroupBy != "week" || input.Dimension != "exercise" {
+					return nil, errors.New("unexpected group_by or dimension")
+				}
+				if input.StartDate == nil || !input.StartDate.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, tokyo)) || input.EndDate == nil {
+					return nil, errors.New("unexpected date range")
+				}
+				return []usecase.VolumeAggregate{
+					{
+						PeriodStart:      time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+						ExerciseID:       &exerciseID,
+						ExerciseName:     &exerciseName,
+						BodyPart:         &chest,
+						Tonnage:          1350,
+						SetCount:         3,
+						RepCount:         20,
+						AverageIntensity: 67.5,
+					},
+				}, nil
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, resp []VolumeAggregateResponse) {
+				if len(resp) != 1 {
+					t.Fatalf("expected 1 aggregate, got %d", len(resp))
+				}
+				got := resp[0]
+				if got.PeriodStart != "2026-01-05" || got.ExerciseID == nil || *got.ExerciseID != exerciseID.String() {
+					t.Errorf("unexpected aggregate: %+v", got)
+				}
+				if got.BodyPart == nil || *got.BodyPart != "chest" {
+					t.Errorf("expected body_part chest, got %v", got.BodyPart)
+				}
+				if got.Tonnage != 1350 || got.SetCount != 3 || got.RepCount != 20 || got.AverageIntensity != 67.5 {
+					t.Errorf("unexpected volume: %+v", got)
+				}
+			},
+		},
+		{
+			name:  "成功: 身体部位ごと・期間指定なし",
+			query: "?group_by=month&dimension=body_part",
+			mockFunc: func(ctx context.Context, input usecase.GetVolumeInput) ([]usecase.VolumeAggregate, error) {
+				if input.StartDate != nil || input.EndDate != nil {
+					return nil, errors.New("expected no date range")
+				}
+				return []usecase.VolumeAggregate{
+					{PeriodStart: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Tonnage: 500, SetCount: 1, RepCount: 5, AverageIntensity: 100},
+				}, nil
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, resp []VolumeAggregateResponse) {
+				if len(resp) != 1 || resp[0].ExerciseID != nil || resp[0].ExerciseName != nil || resp[0].BodyPart != nil {
+					t.Errorf("expected exercise fields and body_part to be null, got %+v", resp)
+				}
+			},
+		},
+		{
+			name:  "成功: データなし",
+			query: "",
+			mockFunc: func(ctx context.Context, input usecase.GetVolumeInput) ([]usecase.VolumeAggregate, error) {
+				return []usecase.VolumeAggregate{}, nil
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, resp []VolumeAggregateResponse) {
+				if resp == nil || len(resp) != 0 {
+					t.Errorf("expected empty array, got %v", resp)
+				}
+			},
+		},
+		{
+			name:           "失敗: start_dateが不正",
+			query:          "?start_date=2026/01/01",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "失敗: group_byが不正",
+			query: "?group_by=day",
+			mockFunc: func(ctx context.Context, input usecase.GetVolumeInput) ([]usecase.VolumeAggregate, error) {
+				return nil, usecase.ErrInvalidVolumeGroupBy
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "失敗: dimensionが不正",
+			query: "?dimension=muscle",
+			mockFunc: func(ctx context.Context, input usecase.GetVolumeInput) ([]usecase.VolumeAggregate, error) {
+				return nil, usecase.ErrInvalidVolumeDimension
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "失敗: サーバーエラー",
+			query: "",
+			mockFunc: func(ctx context.Context, input usecase.GetVolumeInput) ([]usecase.VolumeAggregate, error) {
+				return nil, errors.New("database error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockAnalyticsUsecase{getVolumeFunc: tt.mockFunc}
+			profile := &mockProfileUsecase{
+				getLocationFunc: func(ctx context.Context, userID uuid.UUID) (*time.Location, error) {
+					return tokyo, nil
+				},
+			}
+			h := NewAnalyticsHandler(mock, profile)
+
+			req := httptest.NewRequest("GET", "/api/analytics/volume"+tt.query, nil)
+			req = setAuthContext(req, uuid.New())
+			rec := httptest.NewRecorder()
+
+			h.GetVolume(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tt.checkResponse != nil {
+				var resp []VolumeAggregateResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				tt.checkResponse(t, resp)
+			}
+		})
+	}
+}
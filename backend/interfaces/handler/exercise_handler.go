@@ -48,9 +48,11 @@ type UpdateExerciseRequest struct {
 	BodyPart    *string `json:"body_part"`
 }
 
-// ExerciseResponse はエクササイズのレスポンスボディ
+// ExerciseResponse はエクササイズのレスポンスボディ。
+// UserIDはカスタム種目の所有者で、システム種目の場合はnullとなる。
 type ExerciseResponse struct {
 	ID          string  `json:"id"`
+	UserID      *string `json:"user_id"`
 	Name        string  `json:"name"`
 	Description *string `json:"description"`
 	BodyPart    *string `json:"body_part"`
@@ -60,7 +62,7 @@ type ExerciseResponse struct {
 
 // --- ハンドラーメソッド ---
 
-// CreateExercise は新しいエクササイズを作成する。
+// CreateExercise はログインユーザーが所有する新しいカスタムエクササイズを作成する。
 // POST /api/exercises
 //
 // リクエストボディ:
@@ -74,11 +76,10 @@ type ExerciseResponse struct {
 // レスポンス:
 //   - 201 Created: 作成成功
 //   - 400 Bad Request: リクエストボディが不正、バリデーションエラー
-//   - 409 Conflict: エクササイズ名が既に存在
+//   - 409 Conflict: システム種目または自身の種目に同名が存在
 //   - 500 Internal Server Error: サーバーエラー
 func (h *ExerciseHandler) CreateExercise(w http.ResponseWriter, r *http.Request) {
-	// 認証チェック（認証ミドルウェアで保護されているが念のため）
-	_ = auth.GetUserIDFromContext(r.Context())
+	userID := auth.GetUserIDFromContext(r.Context())
 
 	var req CreateExerciseRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -92,7 +93,7 @@ func (h *ExerciseHandler) CreateExercise(w http.ResponseWriter, r *http.Request)
 		bodyPart = &bp
 	}
 
-	exercise, err := h.exerciseUsecase.CreateExercise(r.Context(), req.Name, req.Description, bodyPart)
+	exercise, err := h.exerciseUsecase.CreateExercise(r.Context(), userID, req.Name, req.Description, bodyPart)
 	if err != nil {
 		handleExerciseUsecaseError(w, err)
 		return
@@ -101,7 +102,7 @@ func (h *ExerciseHandler) CreateExercise(w http.ResponseWriter, r *http.Request)
 	respondJSON(w, http.StatusCreated, toExerciseResponse(exercise))
 }
 
-// ListExercises はエクササイズ一覧を取得する。
+// ListExercises はシステム種目とログインユーザーのカスタム種目の一覧を取得する。
 // GET /api/exercises?body_part=chest
 //
 // クエリパラメータ:
@@ -112,7 +113,7 @@ func (h *ExerciseHandler) CreateExercise(w http.ResponseWriter, r *http.Request)
 //   - 400 Bad Request: クエリパラメータが不正
 //   - 500 Internal Server Error: サーバーエラー
 func (h *ExerciseHandler) ListExercises(w http.ResponseWriter, r *http.Request) {
-	_ = auth.GetUserIDFromContext(r.Context())
+	userID := auth.GetUserIDFromContext(r.Context())
 
 	var bodyPart *entity.BodyPart
 	if bp := r.URL.Query().Get("body_part"); bp != "" {
@@ -120,7 +121,7 @@ func (h *ExerciseHandler) ListExercises(w http.ResponseWriter, r *http.Request)
 		bodyPart = &bodyPartVal
 	}
 
-	exercises, err := h.exerciseUsecase.ListExercises(r.Context(), bodyPart)
+	exercises, err := h.exerciseUsecase.ListExercises(r.Context(), userID, bodyPart)
 	if err != nil {
 		handleExerciseUsecaseError(w, err)
 		return
@@ -146,7 +147,7 @@ func (h *ExerciseHandler) ListExercises(w http.ResponseWriter, r *http.Request)
 //   - 404 Not Found: エクササイズが見つからない
 //   - 500 Internal Server Error: サーバーエラー
 func (h *ExerciseHandler) GetExercise(w http.ResponseWriter, r *http.Request) {
-	_ = auth.GetUserIDFromContext(r.Context())
+	userID := auth.GetUserIDFromContext(r.Context())
 
 	vars := mux.Vars(r)
 	exerciseID, err := uuid.Parse(vars["id"])
@@ -155,7 +156,7 @@ func (h *ExerciseHandler) GetExercise(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	exercise, err := h.exerciseUsecase.GetExercise(r.Context(), exerciseID)
+	exercise, err := h.exerciseUsecase.GetExercise(r.Context(), userID, exerciseID)
 	if err != nil {
 		handleExerciseUsecaseError(w, err)
 		return
@@ -164,7 +165,7 @@ func (h *ExerciseHandler) GetExercise(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, toExerciseResponse(exercise))
 }
 
-// UpdateExercise はエクササイズを更新する。
+// UpdateExercise はログインユーザーが所有するカスタムエクササイズを更新する。
 // PUT /api/exercises/{id}
 //
 // パスパラメータ:
@@ -181,11 +182,12 @@ func (h *ExerciseHandler) GetExercise(w http.ResponseWriter, r *http.Request) {
 // レスポンス:
 //   - 200 OK: 更新成功
 //   - 400 Bad Request: リクエストが不正、バリデーションエラー
+//   - 403 Forbidden: システム種目のため編集不可
 //   - 404 Not Found: エクササイズが見つからない
 //   - 409 Conflict: エクササイズ名が既に存在
 //   - 500 Internal Server Error: サーバーエラー
 func (h *ExerciseHandler) UpdateExercise(w http.ResponseWriter, r *http.Request) {
-	_ = auth.GetUserIDFromContext(r.Context())
+	userID := auth.GetUserIDFromContext(r.Context())
 
 	vars := mux.Vars(r)
 	exerciseID, err := uuid.Parse(vars["id"])
@@ -206,7 +208,7 @@ func (h *ExerciseHandler) UpdateExercise(w http.ResponseWriter, r *http.Request)
 		bodyPart = &bp
 	}
 
-	exercise, err := h.exerciseUsecase.UpdateExercise(r.Context(), exerciseID, req.Name, req.Description, bodyPart)
+	exercise, err := h.exerciseUsecase.UpdateExercise(r.Context(), userID, exerciseID, req.Name, req.Description, bodyPart)
 	if err != nil {
 		handleExerciseUsecaseError(w, err)
 		return
@@ -215,7 +217,7 @@ func (h *ExerciseHandler) UpdateExercise(w http.ResponseWriter, r *http.Request)
 	respondJSON(w, http.StatusOK, toExerciseResponse(exercise))
 }
 
-// DeleteExercise はエクササイズを削除する。
+// DeleteExercise はログインユーザーが所有するカスタムエクササイズを削除する。
 // DELETE /api/exercises/{id}
 //
 // パスパラメータ:
@@ -224,10 +226,11 @@ func (h *ExerciseHandler) UpdateExercise(w http.ResponseWriter, r *http.Request)
 // レスポンス:
 //   - 204 No Content: 削除成功
 //   - 400 Bad Request: エクササイズIDが不正
+//   - 403 Forbidden: システム種目のため削除不可
 //   - 404 Not Found: エクササイズが見つからない
 //   - 500 Internal Server Error: サーバーエラー
 func (h *ExerciseHandler) DeleteExercise(w http.ResponseWriter, r *http.Request) {
-	_ = auth.GetUserIDFromContext(r.Context())
+	userID := auth.GetUserIDFromContext(r.Context())
 
 	vars := mux.Vars(r)
 	exerciseID, err := uuid.Parse(vars["id"])
@@ -236,7 +239,7 @@ func (h *ExerciseHandler) DeleteExercise(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if err := h.exerciseUsecase.DeleteExercise(r.Context(), exerciseID); err != nil {
+	if err := h.exerciseUsecase.DeleteExercise(r.Context(), userID, exerciseID); err != nil {
 		handleExerciseUsecaseError(w, err)
 		return
 	}
@@ -251,8 +254,12 @@ func handleExerciseUsecaseError(w http.ResponseWriter, err error) {
 	switch err {
 	case usecase.ErrExerciseNotFound:
 		respondError(w, http.StatusNotFound, "Exercise not found")
+	case usecase.ErrExerciseAccessDenied:
+		respondError(w, http.StatusForbidden, "Access denied to this exercise")
 	case service.ErrExerciseNameAlreadyExists:
 		respondError(w, http.StatusConflict, "Exercise name already exists")
+	case usecase.ErrExerciseInUse:
+		respondError(w, http.StatusConflict, "Exercise is used by workouts, routines or programs")
 	default:
 		if isExerciseValidationError(err) {
 			respondError(w, http.StatusBadRequest, err.Error())
@@ -286,8 +293,15 @@ func toExerciseResponse(exercise *entity.Exercise) ExerciseResponse {
 		bodyPart = &bp
 	}
 
+	var userID *string
+	if exercise.UserID != nil {
+		id := exercise.UserID.String()
+		userID = &id
+	}
+
 	return ExerciseResponse{
 		ID:          exercise.ID.String(),
+		UserID:      userID,
 		Name:        exercise.Name,
 		Description: exercise.Description,
 		BodyPart:    bodyPart,
@@ -18,44 +18,44 @@ import (
 
 // mockExerciseUsecase はExerciseUsecaseのモック実装
 type mockExerciseUsecase struct {
-	createExerciseFunc func(ctx context.Context, name string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error)
-	getExerciseFunc    func(ctx context.Context, id uuid.UUID) (*entity.Exercise, error)
-	listExercisesFunc  func(ctx context.Context, bodyPart *entity.BodyPart) ([]*entity.Exercise, error)
-	updateExerciseFunc func(ctx context.Context, id uuid.UUID, name *string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error)
-	deleteExerciseFunc func(ctx context.Context, id uuid.UUID) error
+	createExerciseFunc func(ctx context.Context, userID uuid.UUID, name string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error)
+	getExerciseFunc    func(ctx context.Context, userID, id uuid.UUID) (*entity.Exercise, error)
+	listExercisesFunc  func(ctx context.Context, userID uuid.UUID, bodyPart *entity.BodyPart) ([]*entity.Exercise, error)
+	updateExerciseFunc func(ctx context.Context, userID, id uuid.UUID, name *string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error)
+	deleteExerciseFunc func(ctx context.Context, userID, id uuid.UUID) error
 }
 
-func (m *mockExerciseUsecase) CreateExercise(ctx context.Context, name string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error) {
+func (m *mockExerciseUsecase) CreateExercise(ctx context.Context, userID uuid.UUID, name string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error) {
 	if m.createExerciseFunc != nil {
-		return m.createExerciseFunc(ctx, name, description, bodyPart)
+		return m.createExerciseFunc(ctx, userID, name, description, bodyPart)
 	}
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockExerciseUsecase) GetExercise(ctx context.Context, id uuid.UUID) (*entity.Exercise, error) {
+func (m *mockExerciseUsecase) GetExercise(ctx context.Context, userID, id uuid.UUID) (*entity.Exercise, error) {
 	if m.getExerciseFunc != nil {
-		return m.getExerciseFunc(ctx, id)
+		return m.getExerciseFunc(ctx, userID, id)
 	}
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockExerciseUsecase) ListExercises(ctx context.Context, bodyPart *entity.BodyPart) ([]*entity.Exercise, error) {
+func (m *mockExerciseUsecase) ListExercises(ctx context.Context, userID uuid.UUID, bodyPart *entity.BodyPart) ([]*entity.Exercise, error) {
 	if m.listExercisesFunc != nil {
-		return m.listExercisesFunc(ctx, bodyPart)
+		return m.listExercisesFunc(ctx, userID, bodyPart)
 	}
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockExerciseUsecase) UpdateExercise(ctx context.Context, id uuid.UUID, name *string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error) {
+func (m *mockExerciseUsecase) UpdateExercise(ctx context.Context, userID, id uuid.UUID, name *string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error) {
 	if m.updateExerciseFunc != nil {
-		return m.updateExerciseFunc(ctx, id, name, description, bodyPart)
+		return m.updateExerciseFunc(ctx, userID, id, name, description, bodyPart)
 	}
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockExerciseUsecase) DeleteExercise(ctx context.Context, id uuid.UUID) error {
+func (m *mockExerciseUsecase) DeleteExercise(ctx context.Context, userID, id uuid.UUID) error {
 	if m.deleteExerciseFunc != nil {
-		return m.deleteExerciseFunc(ctx, id)
+		return m.deleteExerciseFunc(ctx, userID, id)
 	}
 	return errors.New("not implemented")
 }
@@ -67,7 +67,7 @@ func TestExerciseHandler_CreateExercise(t *testing.T) {
 	tests := []struct {
 		name           string
 		requestBody    interface{}
-		mockFunc       func(ctx context.Context, name string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error)
+		mockFunc       func(ctx context.Context, userID uuid.UUID, name string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error)
 		expectedStatus int
 		checkResponse  func(t *testing.T, body map[string]interface{})
 	}{
@@ -77,8 +77,8 @@ func TestExerciseHandler_CreateExercise(t *testing.T) {
 				Name:     "Bench Press",
 				BodyPart: &bodyPart,
 			},
-			mockFunc: func(ctx context.Context, name string, description *string, bp *entity.BodyPart) (*entity.Exercise, error) {
-				exercise, _ := entity.NewExercise(name, description, bp)
+			mockFunc: func(ctx context.Context, uid uuid.UUID, name string, description *string, bp *entity.BodyPart) (*entity.Exercise, error) {
+				exercise, _ := entity.NewUserExercise(uid, name, description, bp)
 				return exercise, nil
 			},
 			expectedStatus: http.StatusCreated,
@@ -86,6 +86,9 @@ func TestExerciseHandler_CreateExercise(t *testing.T) {
 				if body["name"] != "Bench Press" {
 					t.Errorf("expected name 'Bench Press', got %v", body["name"])
 				}
+				if body["user_id"] != userID.String() {
+					t.Errorf("expected user_id %v, got %v", userID, body["user_id"])
+				}
 				if body["body_part"] != "chest" {
 					t.Errorf("expected body_part 'chest', got %v", body["body_part"])
 				}
@@ -102,7 +105,7 @@ func TestExerciseHandler_CreateExercise(t *testing.T) {
 			requestBody: CreateExerciseRequest{
 				Name: "",
 			},
-			mockFunc: func(ctx context.Context, name string, description *string, bp *entity.BodyPart) (*entity.Exercise, error) {
+			mockFunc: func(ctx context.Context, uid uuid.UUID, name string, description *string, bp *entity.BodyPart) (*entity.Exercise, error) {
 				return nil, entity.ErrInvalidExerciseName
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -112,7 +115,7 @@ func TestExerciseHandler_CreateExercise(t *testing.T) {
 			requestBody: CreateExerciseRequest{
 				Name: "Bench Press",
 			},
-			mockFunc: func(ctx context.Context, name string, description *string, bp *entity.BodyPart) (*entity.Exercise, error) {
+			mockFunc: func(ctx context.Context, uid uuid.UUID, name string, description *string, bp *entity.BodyPart) (*entity.Exercise, error) {
 				return nil, service.ErrExerciseNameAlreadyExists
 			},
 			expectedStatus: http.StatusConflict,
@@ -123,7 +126,7 @@ func TestExerciseHandler_CreateExercise(t *testing.T) {
 				Name:     "Test Exercise",
 				BodyPart: strPtr("invalid_part"),
 			},
-			mockFunc: func(ctx context.Context, name string, description *string, bp *entity.BodyPart) (*entity.Exercise, error) {
+			mockFunc: func(ctx context.Context, uid uuid.UUID, name string, description *string, bp *entity.BodyPart) (*entity.Exercise, error) {
 				return nil, entity.ErrInvalidBodyPart
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -169,13 +172,13 @@ func TestExerciseHandler_ListExercises(t *testing.T) {
 	tests := []struct {
 		name           string
 		queryParams    string
-		mockFunc       func(ctx context.Context, bodyPart *entity.BodyPart) ([]*entity.Exercise, error)
+		mockFunc       func(ctx context.Context, uid uuid.UUID, bodyPart *entity.BodyPart) ([]*entity.Exercise, error)
 		expectedStatus int
 	}{
 		{
 			name:        "成功: 全エクササイズ取得",
 			queryParams: "",
-			mockFunc: func(ctx context.Context, bodyPart *entity.BodyPart) ([]*entity.Exercise, error) {
+			mockFunc: func(ctx context.Context, uid uuid.UUID, bodyPart *entity.BodyPart) ([]*entity.Exercise, error) {
 				exercise, _ := entity.NewExercise("Bench Press", nil, nil)
 				return []*entity.Exercise{exercise}, nil
 			},
@@ -184,7 +187,7 @@ func TestExerciseHandler_ListExercises(t *testing.T) {
 		{
 			name:        "成功: body_partでフィルタ",
 			queryParams: "?body_part=chest",
-			mockFunc: func(ctx context.Context, bodyPart *entity.BodyPart) ([]*entity.Exercise, error) {
+			mockFunc: func(ctx context.Context, uid uuid.UUID, bodyPart *entity.BodyPart) ([]*entity.Exercise, error) {
 				bp := entity.BodyPartChest
 				exercise, _ := entity.NewExercise("Bench Press", nil, &bp)
 				return []*entity.Exercise{exercise}, nil
@@ -194,7 +197,7 @@ func TestExerciseHandler_ListExercises(t *testing.T) {
 		{
 			name:        "成功: 空のリスト",
 			queryParams: "?body_part=legs",
-			mockFunc: func(ctx context.Context, bodyPart *entity.BodyPart) ([]*entity.Exercise, error) {
+			mockFunc: func(ctx context.Context, uid uuid.UUID, bodyPart *entity.BodyPart) ([]*entity.Exercise, error) {
 				return []*entity.Exercise{}, nil
 			},
 			expectedStatus: http.StatusOK,
@@ -228,13 +231,13 @@ func TestExerciseHandler_GetExercise(t *testing.T) {
 	tests := []struct {
 		name           string
 		exerciseID     string
-		mockFunc       func(ctx context.Context, id uuid.UUID) (*entity.Exercise, error)
+		mockFunc       func(ctx context.Context, uid, id uuid.UUID) (*entity.Exercise, error)
 		expectedStatus int
 	}{
 		{
 			name:       "成功: エクササイズ取得",
 			exerciseID: exerciseID.String(),
-			mockFunc: func(ctx context.Context, id uuid.UUID) (*entity.Exercise, error) {
+			mockFunc: func(ctx context.Context, uid, id uuid.UUID) (*entity.Exercise, error) {
 				exercise, _ := entity.NewExercise("Bench Press", nil, nil)
 				return exercise, nil
 			},
@@ -249,7 +252,7 @@ func TestExerciseHandler_GetExercise(t *testing.T) {
 		{
 			name:       "失敗: エクササイズが見つからない",
 			exerciseID: exerciseID.String(),
-			mockFunc: func(ctx context.Context, id uuid.UUID) (*entity.Exercise, error) {
+			mockFunc: func(ctx context.Context, uid, id uuid.UUID) (*entity.Exercise, error) {
 				return nil, usecase.ErrExerciseNotFound
 			},
 			expectedStatus: http.StatusNotFound,
@@ -287,7 +290,7 @@ func TestExerciseHandler_UpdateExercise(t *testing.T) {
 		name           string
 		exerciseID     string
 		requestBody    interface{}
-		mockFunc       func(ctx context.Context, id uuid.UUID, name *string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error)
+		mockFunc       func(ctx context.Context, uid, id uuid.UUID, name *string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error)
 		expectedStatus int
 	}{
 		{
@@ -297,8 +300,8 @@ func TestExerciseHandler_UpdateExercise(t *testing.T) {
 				Name:     &newName,
 				BodyPart: &newBodyPart,
 			},
-			mockFunc: func(ctx context.Context, id uuid.UUID, name *string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error) {
-				exercise, _ := entity.NewExercise(*name, description, bodyPart)
+			mockFunc: func(ctx context.Context, uid, id uuid.UUID, name *string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error) {
+				exercise, _ := entity.NewUserExercise(uid, *name, description, bodyPart)
 				return exercise, nil
 			},
 			expectedStatus: http.StatusOK,
@@ -323,7 +326,7 @@ func TestExerciseHandler_UpdateExercise(t *testing.T) {
 			requestBody: UpdateExerciseRequest{
 				Name: &newName,
 			},
-			mockFunc: func(ctx context.Context, id uuid.UUID, name *string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error) {
+			mockFunc: func(ctx context.Context, uid, id uuid.UUID, name *string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error) {
 				return nil, usecase.ErrExerciseNotFound
 			},
 			expectedStatus: http.StatusNotFound,
@@ -334,11 +337,22 @@ func TestExerciseHandler_UpdateExercise(t *testing.T) {
 			requestBody: UpdateExerciseRequest{
 				Name: &newName,
 			},
-			mockFunc: func(ctx context.Context, id uuid.UUID, name *string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error) {
+			mockFunc: func(ctx context.Context, uid, id uuid.UUID, name *string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error) {
 				return nil, service.ErrExerciseNameAlreadyExists
 			},
 			expectedStatus: http.StatusConflict,
 		},
+		{
+			name:       "失敗: システム種目は編集不可",
+			exerciseID: exerciseID.String(),
+			requestBody: UpdateExerciseRequest{
+				Name: &newName,
+			},
+			mockFunc: func(ctx context.Context, uid, id uuid.UUID, name *string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error) {
+				return nil, usecase.ErrExerciseAccessDenied
+			},
+			expectedStatus: http.StatusForbidden,
+		},
 	}
 
 	for _, tt := range tests {
@@ -374,13 +388,13 @@ func TestExerciseHandler_DeleteExercise(t *testing.T) {
 	tests := []struct {
 		name           string
 		exerciseID     string
-		mockFunc       func(ctx context.Context, id uuid.UUID) error
+		mockFunc       func(ctx context.Context, uid, id uuid.UUID) error
 		expectedStatus int
 	}{
 		{
 			name:       "成功: エクササイズ削除",
 			exerciseID: exerciseID.String(),
-			mockFunc: func(ctx context.Context, id uuid.UUID) error {
+			mockFunc: func(ctx context.Context, uid, id uuid.UUID) error {
 				return nil
 			},
 			expectedStatus: http.StatusNoContent,
@@ -394,11 +408,27 @@ func TestExerciseHandler_DeleteExercise(t *testing.T) {
 		{
 			name:       "失敗: エクササイズが見つからない",
 			exerciseID: exerciseID.String(),
-			mockFunc: func(ctx context.Context, id uuid.UUID) error {
+			mockFunc: func(ctx context.Context, uid, id uuid.UUID) error {
 				return usecase.ErrExerciseNotFound
 			},
 			expectedStatus: http.StatusNotFound,
 		},
+		{
+			name:       "失敗: システム種目は削除不可",
+			exerciseID: exerciseID.String(),
+			mockFunc: func(ctx context.Context, uid, id uuid.UUID) error {
+				return usecase.ErrExerciseAccessDenied
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:       "失敗: 使用中のエクササイズは削除不可",
+			exerciseID: exerciseID.String(),
+			mockFunc: func(ctx context.Context, uid, id uuid.UUID) error {
+				return usecase.ErrExerciseInUse
+			},
+			expectedStatus: http.StatusConflict,
+		},
 	}
 
 	for _, tt := range tests {
@@ -435,6 +465,12 @@ func TestHandleExerciseUsecaseError(t *testing.T) {
 			expectedStatus: http.StatusNotFound,
 			expectedError:  "Exercise not found",
 		},
+		{
+			name:           "エクササイズへのアクセス拒否",
+			err:            usecase.ErrExerciseAccessDenied,
+			expectedStatus: http.StatusForbidden,
+			expectedError:  "Access denied to this exercise",
+		},
 		{
 			name:           "エクササイズ名重複",
 			err:            service.ErrExerciseNameAlreadyExists,
@@ -0,0 +1,203 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+	"github.com/ucchy108/whiskey/backend/infrastructure/auth"
+	"github.com/ucchy108/whiskey/backend/usecase"
+)
+
+// maxImportFileSize はインポートできるファイルの最大サイズ（10MB）
+const maxImportFileSize = 10 << 20
+
+// ImportHandler はワークアウト履歴のインポートのHTTPハンドラーを提供する。
+type ImportHandler struct {
+	importUsecase  usecase.ImportUsecaseInterface
+	profileUsecase usecase.ProfileUsecaseInterface
+}
+
+// NewImportHandler はImportHandlerの新しいインスタンスを生成する。
+//
+// パラメータ:
+//   - importUsecase: ワークアウト履歴のインポートを提供するユースケース
+//   - profileUsecase: 日時の解釈に使用するユーザーのタイムゾーンを提供するユースケース
+//
+// 戻り値:
+//   - *ImportHandler: 生成されたImportHandlerインスタンス
+func NewImportHandler(importUsecase usecase.ImportUsecaseInterface, profileUsecase usecase.ProfileUsecaseInterface) *ImportHandler {
+	return &ImportHandler{
+		importUsecase:  importUsecase,
+		profileUsecase: profileUsecase,
+	}
+}
+
+// ImportWorkoutsResponse はワークアウト履歴のインポートAPIのレスポンスボディ
+type ImportWorkoutsResponse struct {
+	DryRun    bool                       `json:"dry_run"`
+	Workouts  []ImportedWorkoutResponse  `json:"workouts"`
+	Exercises []ImportedExerciseResponse `json:"exercises"`
+	Errors    []ImportRowErrorResponse   `json:"errors"`
+}
+
+// ImportedWorkoutResponse はインポートしたワークアウト1件分の結果。
+// WorkoutIDはドライランまたは重複の場合はnullとなる。
+type ImportedWorkoutResponse struct {
+	WorkoutID     *string `json:"workout_id"`
+	Date          string  `json:"date"`
+	StartedAt     *string `json:"started_at"`
+	SetCount      int     `json:"set_count"`
+	ExerciseCount int     `json:"exercise_count"`
+	Duplicate     bool    `json:"duplicate"`
+}
+
+// ImportedExerciseResponse はファイル内の種目名と対応付けた種目。
+// ExerciseIDはドライランで新しく作成する種目と、取り込んだワークアウトで使われず作成しなかった種目の場合はnullとなる。
+type ImportedExerciseResponse struct {
+	SourceName   string  `json:"source_name"`
+	ExerciseID   *string `json:"exercise_id"`
+	ExerciseName string  `json:"exercise_name"`
+	Created      bool    `json:"created"`
+}
+
+// ImportRowErrorResponse は取り込めなかった行のエラー
+type ImportRowErrorResponse struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// ImportWorkouts は他のアプリからエクスポートしたワークアウト履歴のCSVを取り込む。
+// POST /api/imports
+//
+// リクエスト（multipart/form-data）:
+//   - file: CSVファイル（最大10MB）
+//   - format: ファイル形式（strong, hevy, whiskey）
+//   - weight_unit: ファイルから重量の単位を判別できない場合の単位（kg, lb）。省略時はkg
+//   - dry_run: trueの場合は何も保存せず、取り込み結果のプレビューのみを返す
+//
+// 日時はユーザーのタイムゾーンの時刻として解釈する。
+// 検証に失敗した行は取り込まずに errors に行番号付きで返し、残りの行は取り込む。
+//
+// レスポンス:
+//   - 200 OK: ドライラン成功
+//   - 201 Created: 取り込み成功
+//   - 400 Bad Request: リクエストが不正、対応していないファイル形式、ファイルを読み取れない
+//   - 413 Request Entity Too Large: ファイルが大きすぎる
+//   - 500 Internal Server Error: サーバーエラー
+func (h *ImportHandler) ImportWorkouts(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportFileSize)
+	if err := r.ParseMultipartForm(maxImportFileSize); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondError(w, http.StatusRequestEntityTooLarge, "File too large")
+			return
+		}
+		respondError(w, http.StatusBadRequest, "Invalid multipart form")
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "file is required")
+		return
+	}
+	defer file.Close()
+
+	dryRun := false
+	if s := r.FormValue("dry_run"); s != "" {
+		dryRun, err = strconv.ParseBool(s)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid dry_run parameter")
+			return
+		}
+	}
+
+	loc, err := h.profileUsecase.GetLocation(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	output, err := h.importUsecase.ImportWorkouts(r.Context(), usecase.ImportWorkoutsInput{
+		UserID:     userID,
+		Format:     r.FormValue("format"),
+		File:       file,
+		WeightUnit: repository.WeightUnit(r.FormValue("weight_unit")),
+		Location:   loc,
+		DryRun:     dryRun,
+	})
+	if err != nil {
+		handleImportError(w, err)
+		return
+	}
+
+	status := http.StatusCreated
+	if output.DryRun {
+		status = http.StatusOK
+	}
+	respondJSON(w, status, toImportWorkoutsResponse(output))
+}
+
+// toImportWorkoutsResponse はインポート結果をImportWorkoutsResponseに変換する。
+func toImportWorkoutsResponse(output *usecase.ImportWorkoutsOutput) ImportWorkoutsResponse {
+	resp := ImportWorkoutsResponse{
+		DryRun:    output.DryRun,
+		Workouts:  make([]ImportedWorkoutResponse, 0, len(output.Workouts)),
+		Exercises: make([]ImportedExerciseResponse, 0, len(output.Exercises)),
+		Errors:    make([]ImportRowErrorResponse, 0, len(output.Errors)),
+	}
+	for _, workout := range output.Workouts {
+		entry := ImportedWorkoutResponse{
+			Date:          workout.Date.Format(time.DateOnly),
+			SetCount:      workout.SetCount,
+			ExerciseCount: workout.ExerciseCount,
+			Duplicate:     workout.Duplicate,
+		}
+		if workout.WorkoutID != nil {
+			id := workout.WorkoutID.String()
+			entry.WorkoutID = &id
+		}
+		if workout.StartedAt != nil {
+			startedAt := workout.StartedAt.Format(time.RFC3339)
+			entry.StartedAt = &startedAt
+		}
+		resp.Workouts = append(resp.Workouts, entry)
+	}
+	for _, exercise := range output.Exercises {
+		entry := ImportedExerciseResponse{
+			SourceName:   exercise.SourceName,
+			ExerciseName: exercise.Name,
+			Created:      exercise.Created,
+		}
+		if exercise.ExerciseID != uuid.Nil {
+			id := exercise.ExerciseID.String()
+			entry.ExerciseID = &id
+		}
+		resp.Exercises = append(resp.Exercises, entry)
+	}
+	for _, rowErr := range output.Errors {
+		resp.Errors = append(resp.Errors, ImportRowErrorResponse{
+			Line:    rowErr.Line,
+			Message: rowErr.Message,
+		})
+	}
+	return resp
+}
+
+// handleImportError はインポートのエラーを適切なHTTPレスポンスに変換する。
+func handleImportError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, usecase.ErrUnsupportedImportFormat),
+		errors.Is(err, usecase.ErrInvalidWeightUnit),
+		errors.Is(err, usecase.ErrInvalidImportFile):
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+	"github.com/ucchy108/whiskey/backend/usecase"
+)
+
+// mockImportUsecase はImportUsecaseのモック実装
+type mockImportUsecase struct {
+	importWorkoutsFunc func(ctx context.Context, input usecase.ImportWorkoutsInput) (*usecase.ImportWorkoutsOutput, error)
+}
+
+func (m *mockImportUsecase) ImportWorkouts(ctx context.Context, input usecase.ImportWorkoutsInput) (*usecase.ImportWorkoutsOutput, error) {
+	if m.importWorkoutsFunc != nil {
+		return m.importWorkoutsFunc(ctx, input)
+	}
+	return &usecase.ImportWorkoutsOutput{}, nil
+}
+
+// newImportRequest はmultipart/form-dataのインポートリクエストを生成する。
+// fileがnilの場合はファイルを添付しない。
+func newImportRequest(fields map[string]string, file []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for k, v := range fields {
+		writer.WriteField(k, v)
+	}
+	if file != nil {
+		part, _ := writer.CreateFormFile("file", "export.csv")
+		part.Write(file)
+	}
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/api/imports", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return setAuthContext(req, uuid.New())
+}
+
+func TestImportHandler_ImportWorkouts(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	workoutID := uuid.New()
+	exerciseID := uuid.New()
+	startedAt := time.Date(2023, 1, 15, 8, 30, 0, 0, tokyo)
+	csv := []byte("Date,Exercise Name,Weight,Reps\n2023-01-15 08:30:00,Bench Press,60,10\n")
+
+	tests := []struct {
+		name           string
+		fields         map[string]string
+		file           []byte
+		mockFunc       func(ctx context.Context, input usecase.ImportWorkoutsInput) (*usecase.ImportWorkoutsOutput, error)
+		expectedStatus int
+		checkResponse  func(t *testing.T, resp ImportWorkoutsResponse)
+	}{
+		{
+			name:   "成功: 取り込み",
+			fields: map[string]string{"format": "strong", "weight_unit": "lb"},
+			file:   csv,
+			mockFunc: func(ctx context.Context, input usecase.ImportWorkoutsInput) (*usecase.ImportWorkoutsOutput, error) {
+				data, _ := io.ReadAll(input.File)
+				if input.Format != "strong" || input.WeightUnit != repository.WeightUnitLb || input.DryRun || !bytes.Equal(data, csv) {
+					return nil, fmt.Errorf("unexpected input: %+v", input)
+				}
+				if input.Location != tokyo {
+					return nil, fmt.Errorf("unexpected location: %v", input.Location)
+				}
+				return &usecase.ImportWorkoutsOutput{
+					Workouts: []usecase.ImportedWorkout{
+						{WorkoutID: &workoutID, Date: startedAt, StartedAt: &startedAt, SetCount: 1, ExerciseCount: 1},
+					},
+					Exercises: []usecase.ImportedExercise{
+						{SourceName: "Bench Press", ExerciseID: exerciseID, Name: "ベンチプレス"},
+					},
+					Errors: []repository.ImportRowError{{Line: 3, Message: "reps must be greater than 0"}},
+				}, nil
+			},
+			expectedStatus: http.StatusCreated,
+			checkResponse: func(t *testing.T, resp ImportWorkoutsResponse) {
+				if len(resp.Workouts) != 1 || resp.Workouts[0].WorkoutID == nil || *resp.Workouts[0].WorkoutID != workoutID.String() {
+					t.Errorf("unexpected workouts: %+v", resp.Workouts)
+				}
+				if resp.Workouts[0].Date != "2023-01-15" || *resp.Workouts[0].StartedAt != "2023-01-15T08:30:00+09:00" {
+					t.Errorf("unexpected workout dates: %+v", resp.Workouts[0])
+				}
+				if len(resp.Exercises) != 1 || *resp.Exercises[0].ExerciseID != exerciseID.String() || resp.Exercises[0].ExerciseName != "ベンチプレス" {
+					t.Errorf("unexpected exercises: %+v", resp.Exercises)
+				}
+				if len(resp.Errors) != 1 || resp.Errors[0].Line != 3 {
+					t.Errorf("unexpected errors: %+v", resp.Errors)
+				}
+			},
+		},
+		{
+			name:   "成功: ドライラン",
+			fields: map[string]string{"format": "hevy", "dry_run": "true"},
+			file:   csv,
+			mockFunc: func(ctx context.Context, input usecase.ImportWorkoutsInput) (*usecase.ImportWorkoutsOutput, error) {
+				if !input.DryRun {
+					return nil, errors.New("dry_run should be true")
+				}
+				return &usecase.ImportWorkoutsOutput{
+					DryRun: true,
+					Workouts: []usecase.ImportedWorkout{
+						{Date: startedAt, SetCount: 1, ExerciseCount: 1},
+					},
+					Exercises: []usecase.ImportedExercise{
+						{SourceName: "Cable Fly", Name: "Cable Fly", Created: true},
+					},
+				}, nil
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, resp ImportWorkoutsResponse) {
+				if !resp.DryRun {
+					t.Error("expected dry_run true")
+				}
+				if resp.Workouts[0].WorkoutID != nil || resp.Workouts[0].StartedAt != nil {
+					t.Errorf("expected workout_id and started_at null, got %+v", resp.Workouts[0])
+				}
+				if resp.Exercises[0].ExerciseID != nil || !resp.Exercises[0].Created {
+					t.Errorf("expected exercise to be created without ID, got %+v", resp.Exercises[0])
+				}
+				if resp.Errors == nil {
+					t.Error("expected errors to be an empty array")
+				}
+			},
+		},
+		{
+			name:           "失敗: ファイルがない",
+			fields:         map[string]string{"format": "strong"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "失敗: dry_runが不正",
+			fields:         map[string]string{"format": "strong", "dry_run": "maybe"},
+			file:           csv,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "失敗: 対応していないファイル形式",
+			fields: map[string]string{"format": "fitbod"},
+			file:   csv,
+			mockFunc: func(ctx context.Context, input usecase.ImportWorkoutsInput) (*usecase.ImportWorkoutsOutput, error) {
+				return nil, usecase.ErrUnsupportedImportFormat
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "失敗: ファイルを読み取れない",
+			fields: map[string]string{"format": "strong"},
+			file:   csv,
+			mockFunc: func(ctx context.Context, input usecase.ImportWorkoutsInput) (*usecase.ImportWorkoutsOutput, error) {
+				return nil, fmt.Errorf("%w: missing required column", usecase.ErrInvalidImportFile)
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "失敗: ファイルが大きすぎる",
+			fields:         map[string]string{"format": "strong"},
+			file:           bytes.Repeat([]byte("a"), maxImportFileSize+1),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:   "失敗: サーバーエラー",
+			fields: map[string]string{"format": "strong"},
+			file:   csv,
+			mockFunc: func(ctx context.Context, input usecase.ImportWorkoutsInput) (*usecase.ImportWorkoutsOutput, error) {
+				return nil, errors.New("database error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockImportUsecase{importWorkoutsFunc: tt.mockFunc}
+			profile := &mockProfileUsecase{
+				getLocationFunc: func(ctx context.Context, userID uuid.UUID) (*time.Location, error) {
+					return tokyo, nil
+				},
+			}
+			h := NewImportHandler(mock, profile)
+
+			rec := httptest.NewRecorder()
+			h.ImportWorkouts(rec, newImportRequest(tt.fields, tt.file))
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tt.checkResponse != nil {
+				var resp ImportWorkoutsResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				tt.checkResponse(t, resp)
+			}
+		})
+	}
+}
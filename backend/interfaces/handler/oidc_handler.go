@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/ucchy108/whiskey/backend/infrastructure/auth"
+	"github.com/ucchy108/whiskey/backend/usecase"
+)
+
+// OIDCStateCookieName は認可リクエストのstateをブラウザに保持させるクッキーの名前。
+// コールバックのstateと照合し、他人の認可コードでログインさせられる攻撃（ログインCSRF）を防ぐ。
+const OIDCStateCookieName = "oidc_state"
+
+// oidcCookiePath はstateクッキーを送信するパス（OIDCのエンドポイントに限定する）
+const oidcCookiePath = "/api/auth/oidc"
+
+// OIDCHandler は外部IDプロバイダ（OpenID Connect）によるログインのHTTPハンドラーを提供する。
+// ブラウザのリダイレクトで遷移するエンドポイントのため、結果はJSONではなくフロントエンドへのリダイレクトで返す。
+type OIDCHandler struct {
+	oidcUsecase usecase.OIDCUsecaseInterface
+	frontendURL string
+}
+
+// NewOIDCHandler はOIDCHandlerの新しいインスタンスを生成する。
+//
+// パラメータ:
+//   - oidcUsecase: 外部IDプロバイダによるログインのビジネスロジックを提供するユースケース
+//   - frontendURL: ログイン完了・失敗時のリダイレクト先となるフロントエンドのURL
+//
+// 戻り値:
+//   - *OIDCHandler: 生成されたOIDCHandlerインスタンス
+func NewOIDCHandler(oidcUsecase usecase.OIDCUsecaseInterface, frontendURL string) *OIDCHandler {
+	return &OIDCHandler{
+		oidcUsecase: oidcUsecase,
+		frontendURL: frontendURL,
+	}
+}
+
+// Login は外部IDプロバイダでのログインを開始し、プロバイダの認可エンドポイントへリダイレクトする。
+// GET /api/auth/oidc/{provider}/login?remember_me=true
+//
+// レスポンス:
+//   - 302 Found: プロバイダの認可エンドポイントへリダイレクト（stateをoidc_stateクッキーに設定）
+//   - 404 Not Found: プロバイダが設定されていない
+//   - 500 Internal Server Error: サーバーエラー
+func (h *OIDCHandler) Login(w http.ResponseWriter, r *http.Request) {
+	provider := mux.Vars(r)["provider"]
+	rememberMe, _ := strconv.ParseBool(r.URL.Query().Get("remember_me"))
+
+	out, err := h.oidcUsecase.StartLogin(r.Context(), provider, rememberMe)
+	if err != nil {
+		if errors.Is(err, usecase.ErrOIDCProviderNotFound) {
+			respondError(w, http.StatusNotFound, "OIDC provider not found")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     OIDCStateCookieName,
+		Value:    out.State,
+		Path:     oidcCookiePath,
+		HttpOnly: true,
+		Secure:   true,
+		// プロバイダからのリダイレクト（クロスサイトのトップレベルGET）でも送信されるようLaxにする
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   int(usecase.OIDCStateTTL.Seconds()),
+	})
+
+	http.Redirect(w, r, out.AuthorizationURL, http.StatusFound)
+}
+
+// Callback は外部IDプロバイダからのコールバックを処理し、ログインを完了する。
+// GET /api/auth/oidc/{provider}/callback?state=...&code=...
+//
+// レスポンス:
+//   - 302 Found: 結果に応じてフロントエンドへリダイレクト
+//   - ログイン成功: セッションCookieを設定して / へ
+//   - 二要素認証が必要: /login?two_factor_challenge={challenge_token} へ（2FA検証APIでログインを完了する）
+//   - 失敗: /login?error={reason} へ（reasonはaccess_denied, invalid_state, email_not_verified, authentication_failed, server_error）
+//   - 404 Not Found: プロバイダが設定されていない
+func (h *OIDCHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	provider := mux.Vars(r)["provider"]
+	query := r.URL.Query()
+
+	// stateクッキーは成否に関わらず一度きりで破棄する
+	stateCookie, _ := r.Cookie(OIDCStateCookieName)
+	clearOIDCStateCookie(w)
+
+	// ユーザーがプロバイダでの認可を拒否した場合など
+	if query.Get("error") != "" {
+		h.redirectToLogin(w, r, "error", "access_denied")
+		return
+	}
+
+	state := query.Get("state")
+	if stateCookie == nil || state == "" || stateCookie.Value != state {
+		h.redirectToLogin(w, r, "error", "invalid_state")
+		return
+	}
+
+	out, err := h.oidcUsecase.CompleteLogin(r.Context(), usecase.CompleteOIDCLoginInput{
+		Provider: provider,
+		State:    state,
+		Code:     query.Get("code"),
+		Metadata: sessionMetadataFromRequest(r),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecase.ErrOIDCProviderNotFound):
+			respondError(w, http.StatusNotFound, "OIDC provider not found")
+		case errors.Is(err, usecase.ErrInvalidOIDCState):
+			h.redirectToLogin(w, r, "error", "invalid_state")
+		case errors.Is(err, usecase.ErrOIDCEmailNotVerified):
+			h.redirectToLogin(w, r, "error", "email_not_verified")
+		case errors.Is(err, usecase.ErrOIDCAuthenticationFailed):
+			h.redirectToLogin(w, r, "error", "authentication_failed")
+		default:
+			h.redirectToLogin(w, r, "error", "server_error")
+		}
+		return
+	}
+
+	if out.TwoFactorRequired {
+		h.redirectToLogin(w, r, "two_factor_challenge", out.ChallengeToken)
+		return
+	}
+
+	auth.SetSessionCookie(w, out.SessionID, out.SessionTTL)
+	http.Redirect(w, r, h.frontendURL+"/", http.StatusFound)
+}
+
+// redirectToLogin はフロントエンドのログイン画面へ、指定されたクエリパラメータを付けてリダイレクトする
+func (h *OIDCHandler) redirectToLogin(w http.ResponseWriter, r *http.Request, key, value string) {
+	query := url.Values{}
+	query.Set(key, value)
+	http.Redirect(w, r, h.frontendURL+"/login?"+query.Encode(), http.StatusFound)
+}
+
+// clearOIDCStateCookie はstateクッキーを削除する
+func clearOIDCStateCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     OIDCStateCookieName,
+		Value:    "",
+		Path:     oidcCookiePath,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+		MaxAge:   -1,
+	})
+}
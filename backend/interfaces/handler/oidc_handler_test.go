@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/infrastructure/auth"
+	"github.com/ucchy108/whiskey/backend/usecase"
+)
+
+const testFrontendURL = "http://localhost:3000"
+
+// mockOIDCUsecase はOIDCUsecaseのモック実装
+type mockOIDCUsecase struct {
+	startLoginFunc    func(ctx context.Context, provider string, rememberMe bool) (*usecase.StartOIDCLoginOutput, error)
+	completeLoginFunc func(ctx context.Context, input usecase.CompleteOIDCLoginInput) (*usecase.LoginOutput, error)
+}
+
+func (m *mockOIDCUsecase) StartLogin(ctx context.Context, provider string, rememberMe bool) (*usecase.StartOIDCLoginOutput, error) {
+	if m.startLoginFunc != nil {
+		return m.startLoginFunc(ctx, provider, rememberMe)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockOIDCUsecase) CompleteLogin(ctx context.Context, input usecase.CompleteOIDCLoginInput) (*usecase.LoginOutput, error) {
+	if m.completeLoginFunc != nil {
+		return m.completeLoginFunc(ctx, input)
+	}
+	return nil, errors.New("not implemented")
+}
+
+// findCookie はレスポンスから指定された名前のクッキーを返す
+func findCookie(rec *httptest.ResponseRecorder, name string) *http.Cookie {
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func TestOIDCHandler_Login(t *testing.T) {
+	tests := []struct {
+		name             string
+		provider         string
+		query            string
+		mockStartLogin   func(ctx context.Context, provider string, rememberMe bool) (*usecase.StartOIDCLoginOutput, error)
+		expectedStatus   int
+		expectedLocation string
+	}{
+		{
+			name:     "成功: プロバイダの認可エンドポイントへリダイレクト",
+			provider: "google",
+			query:    "?remember_me=true",
+			mockStartLogin: func(ctx context.Context, provider string, rememberMe bool) (*usecase.StartOIDCLoginOutput, error) {
+				if provider != "google" || !rememberMe {
+					t.Errorf("StartLogin() called with provider = %v, rememberMe = %v", provider, rememberMe)
+				}
+				return &usecase.StartOIDCLoginOutput{
+					AuthorizationURL: "https://idp.example.com/authorize?state=state-123",
+					State:            "state-123",
+				}, nil
+			},
+			expectedStatus:   http.StatusFound,
+			expectedLocation: "https://idp.example.com/authorize?state=state-123",
+		},
+		{
+			name:     "失敗: 設定されていないプロバイダ",
+			provider: "unknown",
+			mockStartLogin: func(ctx context.Context, provider string, rememberMe bool) (*usecase.StartOIDCLoginOutput, error) {
+				return nil, usecase.ErrOIDCProviderNotFound
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewOIDCHandler(&mockOIDCUsecase{startLoginFunc: tt.mockStartLogin}, testFrontendURL)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/"+tt.provider+"/login"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"provider": tt.provider})
+			rec := httptest.NewRecorder()
+
+			h.Login(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("status = %v, want %v", rec.Code, tt.expectedStatus)
+			}
+			if tt.expectedStatus != http.StatusFound {
+				return
+			}
+
+			if location := rec.Header().Get("Location"); location != tt.expectedLocation {
+				t.Errorf("Location = %v, want %v", location, tt.expectedLocation)
+			}
+			cookie := findCookie(rec, OIDCStateCookieName)
+			if cookie == nil || cookie.Value != "state-123" {
+				t.Fatalf("state cookie = %+v, want state-123", cookie)
+			}
+			if !cookie.HttpOnly || !cookie.Secure || cookie.SameSite != http.SameSiteLaxMode {
+				t.Errorf("state cookie attributes = %+v, want HttpOnly, Secure and SameSite=Lax", cookie)
+			}
+			if cookie.MaxAge != int(usecase.OIDCStateTTL.Seconds()) {
+				t.Errorf("state cookie MaxAge = %v, want %v", cookie.MaxAge, int(usecase.OIDCStateTTL.Seconds()))
+			}
+		})
+	}
+}
+
+func TestOIDCHandler_Callback(t *testing.T) {
+	successLogin := func(ctx context.Context, input usecase.CompleteOIDCLoginInput) (*usecase.LoginOutput, error) {
+		user, _ := entity.NewOIDCUser("oidc@example.com")
+		return &usecase.LoginOutput{User: user, SessionID: "session-id-123", SessionTTL: 24 * time.Hour}, nil
+	}
+
+	tests := []struct {
+		name              string
+		query             string
+		stateCookie       string
+		mockCompleteLogin func(ctx context.Context, input usecase.CompleteOIDCLoginInput) (*usecase.LoginOutput, error)
+		expectedStatus    int
+		expectedLocation  string
+		expectSession     bool
+	}{
+		{
+			name:        "成功: セッションを作成してフロントエンドへリダイレクト",
+			query:       "?state=state-123&code=code-456",
+			stateCookie: "state-123",
+			mockCompleteLogin: func(ctx context.Context, input usecase.CompleteOIDCLoginInput) (*usecase.LoginOutput, error) {
+				if input.Provider != "google" || input.State != "state-123" || input.Code != "code-456" {
+					t.Errorf("CompleteLogin() called with %+v", input)
+				}
+				return successLogin(ctx, input)
+			},
+			expectedStatus:   http.StatusFound,
+			expectedLocation: testFrontendURL + "/",
+			expectSession:    true,
+		},
+		{
+			name:        "成功: 二要素認証が必要な場合はチャレンジを付けてログイン画面へ",
+			query:       "?state=state-123&code=code-456",
+			stateCookie: "state-123",
+			mockCompleteLogin: func(ctx context.Context, input usecase.CompleteOIDCLoginInput) (*usecase.LoginOutput, error) {
+				return &usecase.LoginOutput{TwoFactorRequired: true, ChallengeToken: "challenge-789"}, nil
+			},
+			expectedStatus:   http.StatusFound,
+			expectedLocation: testFrontendURL + "/login?two_factor_challenge=challenge-789",
+		},
+		{
+			name:              "失敗: stateクッキーがない",
+			query:             "?state=state-123&code=code-456",
+			mockCompleteLogin: successLogin,
+			expectedStatus:    http.StatusFound,
+			expectedLocation:  testFrontendURL + "/login?error=invalid_state",
+		},
+		{
+			name:              "失敗: stateクッキーと一致しない",
+			query:             "?state=state-123&code=code-456",
+			stateCookie:       "other-state",
+			mockCompleteLogin: successLogin,
+			expectedStatus:    http.StatusFound,
+			expectedLocation:  testFrontendURL + "/login?error=invalid_state",
+		},
+		{
+			name:              "失敗: プロバイダで認可が拒否された",
+			query:             "?state=state-123&error=access_denied",
+			stateCookie:       "state-123",
+			mockCompleteLogin: successLogin,
+			expectedStatus:    http.StatusFound,
+			expectedLocation:  testFrontendURL + "/login?error=access_denied",
+		},
+		{
+			name:        "失敗: 期限切れ・使用済みのstate",
+			query:       "?state=state-123&code=code-456",
+			stateCookie: "state-123",
+			mockCompleteLogin: func(ctx context.Context, input usecase.CompleteOIDCLoginInput) (*usecase.LoginOutput, error) {
+				return nil, usecase.ErrInvalidOIDCState
+			},
+			expectedStatus:   http.StatusFound,
+			expectedLocation: testFrontendURL + "/login?error=invalid_state",
+		},
+		{
+			name:        "失敗: IDトークンの検証に失敗",
+			query:       "?state=state-123&code=code-456",
+			stateCookie: "state-123",
+			mockCompleteLogin: func(ctx context.Context, input usecase.CompleteOIDCLoginInput) (*usecase.LoginOutput, error) {
+				return nil, fmt.Errorf("%w: nonce mismatch", usecase.ErrOIDCAuthenticationFailed)
+			},
+			expectedStatus:   http.StatusFound,
+			expectedLocation: testFrontendURL + "/login?error=authentication_failed",
+		},
+		{
+			name:        "失敗: プロバイダでメールアドレスが確認されていない",
+			query:       "?state=state-123&code=code-456",
+			stateCookie: "state-123",
+			mockCompleteLogin: func(ctx context.Context, input usecase.CompleteOIDCLoginInput) (*usecase.LoginOutput, error) {
+				return nil, usecase.ErrOIDCEmailNotVerified
+			},
+			expectedStatus:   http.StatusFound,
+			expectedLocation: testFrontendURL + "/login?error=email_not_verified",
+		},
+		{
+			name:        "失敗: 設定されていないプロバイダ",
+			query:       "?state=state-123&code=code-456",
+			stateCookie: "state-123",
+			mockCompleteLogin: func(ctx context.Context, input usecase.CompleteOIDCLoginInput) (*usecase.LoginOutput, error) {
+				return nil, usecase.ErrOIDCProviderNotFound
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewOIDCHandler(&mockOIDCUsecase{completeLoginFunc: tt.mockCompleteLogin}, testFrontendURL)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/google/callback"+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"provider": "google"})
+			if tt.stateCookie != "" {
+				req.AddCookie(&http.Cookie{Name: OIDCStateCookieName, Value: tt.stateCookie})
+			}
+			rec := httptest.NewRecorder()
+
+			h.Callback(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("status = %v, want %v", rec.Code, tt.expectedStatus)
+			}
+			if tt.expectedLocation != "" {
+				if location := rec.Header().Get("Location"); location != tt.expectedLocation {
+					t.Errorf("Location = %v, want %v", location, tt.expectedLocation)
+				}
+			}
+
+			// stateクッキーは常に削除される
+			if cookie := findCookie(rec, OIDCStateCookieName); cookie == nil || cookie.MaxAge >= 0 {
+				t.Errorf("state cookie = %+v, want cleared", cookie)
+			}
+
+			session := findCookie(rec, auth.SessionCookieName)
+			if tt.expectSession {
+				if session == nil || session.Value != "session-id-123" || session.MaxAge != 86400 {
+					t.Errorf("session cookie = %+v, want session-id-123 with MaxAge 86400", session)
+				}
+			} else if session != nil {
+				t.Errorf("session cookie should not be set, got %+v", session)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/infrastructure/auth"
+	"github.com/ucchy108/whiskey/backend/usecase"
+)
+
+// PersonalRecordHandler は自己ベスト（PR）関連のHTTPハンドラーを提供する。
+// Usecase層のビジネスロジックをRESTful APIとして公開する。
+type PersonalRecordHandler struct {
+	personalRecordUsecase usecase.PersonalRecordUsecaseInterface
+}
+
+// NewPersonalRecordHandler はPersonalRecordHandlerの新しいインスタンスを生成する。
+//
+// パラメータ:
+//   - personalRecordUsecase: 自己ベスト履歴に関するビジネスロジックを提供するユースケース
+//
+// 戻り値:
+//   - *PersonalRecordHandler: 生成されたPersonalRecordHandlerインスタンス
+func NewPersonalRecordHandler(personalRecordUsecase usecase.PersonalRecordUsecaseInterface) *PersonalRecordHandler {
+	return &PersonalRecordHandler{
+		personalRecordUsecase: personalRecordUsecase,
+	}
+}
+
+// --- レスポンスDTO ---
+
+// PersonalRecordResponse は自己ベスト更新イベントのレスポンスボディ
+type PersonalRecordResponse struct {
+	ID            string   `json:"id"`
+	ExerciseID    string   `json:"exercise_id"`
+	WorkoutID     string   `json:"workout_id"`
+	WorkoutSetID  *string  `json:"workout_set_id"`
+	RecordType    string   `json:"record_type"`
+	Reps          *int32   `json:"reps"`
+	Value         float64  `json:"value"`
+	PreviousValue *float64 `json:"previous_value"`
+	AchievedDate  string   `json:"achieved_date"`
+	CreatedAt     string   `json:"created_at"`
+}
+
+// --- ハンドラーメソッド ---
+
+// GetPersonalRecords はユーザーの全種目の自己ベスト履歴を取得する。
+// GET /api/records
+//
+// レスポンス:
+//   - 200 OK: 取得成功（新しい順）
+//   - 500 Internal Server Error: サーバーエラー
+func (h *PersonalRecordHandler) GetPersonalRecords(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	records, err := h.personalRecordUsecase.GetPersonalRecords(r.Context(), userID)
+	if err != nil {
+		handlePersonalRecordUsecaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toPersonalRecordResponses(records))
+}
+
+// GetExercisePersonalRecords は種目の自己ベスト履歴を取得する。
+// GET /api/exercises/{id}/records
+//
+// パスパラメータ:
+//   - id: エクササイズID (UUID)
+//
+// レスポンス:
+//   - 200 OK: 取得成功（新しい順）
+//   - 400 Bad Request: エクササイズIDが不正
+//   - 404 Not Found: エクササイズが見つからない
+//   - 500 Internal Server Error: サーバーエラー
+func (h *PersonalRecordHandler) GetExercisePersonalRecords(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	vars := mux.Vars(r)
+	exerciseID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid exercise ID")
+		return
+	}
+
+	records, err := h.personalRecordUsecase.GetExercisePersonalRecords(r.Context(), userID, exerciseID)
+	if err != nil {
+		handlePersonalRecordUsecaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toPersonalRecordResponses(records))
+}
+
+// --- ヘルパー関数 ---
+
+// handlePersonalRecordUsecaseError はPersonalRecord Usecase層のエラーを適切なHTTPステータスコードに変換する。
+func handlePersonalRecordUsecaseError(w http.ResponseWriter, err error) {
+	switch err {
+	case usecase.ErrExerciseNotFound:
+		respondError(w, http.StatusNotFound, "Exercise not found")
+	default:
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+// toPersonalRecordResponses はPersonalRecordエンティティのスライスをPersonalRecordResponseのスライスに変換する。
+func toPersonalRecordResponses(records []*entity.PersonalRecord) []PersonalRecordResponse {
+	resp := make([]PersonalRecordResponse, 0, len(records))
+	for _, record := range records {
+		resp = append(resp, toPersonalRecordResponse(record))
+	}
+	return resp
+}
+
+// toPersonalRecordResponse はPersonalRecordエンティティをPersonalRecordResponseに変換する。
+func toPersonalRecordResponse(record *entity.PersonalRecord) PersonalRecordResponse {
+	var workoutSetID *string
+	if record.WorkoutSetID != nil {
+		id := record.WorkoutSetID.String()
+		workoutSetID = &id
+	}
+
+	return PersonalRecordResponse{
+		ID:            record.ID.String(),
+		ExerciseID:    record.ExerciseID.String(),
+		WorkoutID:     record.WorkoutID.String(),
+		WorkoutSetID:  workoutSetID,
+		RecordType:    string(record.RecordType),
+		Reps:          record.Reps,
+		Value:         record.Value,
+		PreviousValue: record.PreviousValue,
+		AchievedDate:  record.AchievedDate.Format("2006-01-02"),
+		CreatedAt:     record.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/usecase"
+)
+
+// mockPersonalRecordUsecase はPersonalRecordUsecaseのモック実装
+type mockPersonalRecordUsecase struct {
+	getPersonalRecordsFunc         func(ctx context.Context, userID uuid.UUID) ([]*entity.PersonalRecord, error)
+	getExercisePersonalRecordsFunc func(ctx context.Context, userID, exerciseID uuid.UUID) ([]*entity.PersonalRecord, error)
+}
+
+func (m *mockPersonalRecordUsecase) GetPersonalRecords(ctx context.Context, userID uuid.UUID) ([]*entity.PersonalRecord, error) {
+	if m.getPersonalRecordsFunc != nil {
+		return m.getPersonalRecordsFunc(ctx, userID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockPersonalRecordUsecase) GetExercisePersonalRecords(ctx context.Context, userID, exerciseID uuid.UUID) ([]*entity.PersonalRecord, error) {
+	if m.getExercisePersonalRecordsFunc != nil {
+		return m.getExercisePersonalRecordsFunc(ctx, userID, exerciseID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func TestPersonalRecordHandler_GetPersonalRecords(t *testing.T) {
+	userID := uuid.New()
+	achievedDate := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		mockFunc       func(ctx context.Context, userID uuid.UUID) ([]*entity.PersonalRecord, error)
+		expectedStatus int
+		expectedCount  int
+	}{
+		{
+			name: "成功: 自己ベスト履歴を取得",
+			mockFunc: func(ctx context.Context, uid uuid.UUID) ([]*entity.PersonalRecord, error) {
+				reps := int32(5)
+				return []*entity.PersonalRecord{
+					entity.NewPersonalRecord(uid, uuid.New(), uuid.New(), nil, entity.PersonalRecordTypeSessionVolume, nil, 1500.0, nil, achievedDate),
+					entity.NewPersonalRecord(uid, uuid.New(), uuid.New(), nil, entity.PersonalRecordTypeRepMax, &reps, 100.0, nil, achievedDate),
+				}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedCount:  2,
+		},
+		{
+			name: "成功: 自己ベストが存在しない場合は空配列",
+			mockFunc: func(ctx context.Context, uid uuid.UUID) ([]*entity.PersonalRecord, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedCount:  0,
+		},
+		{
+			name: "失敗: サーバーエラー",
+			mockFunc: func(ctx context.Context, uid uuid.UUID) ([]*entity.PersonalRecord, error) {
+				return nil, errors.New("db error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := &mockPersonalRecordUsecase{
+				getPersonalRecordsFunc: tt.mockFunc,
+			}
+			handler := NewPersonalRecordHandler(mockUsecase)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/records", nil)
+			req = req.WithContext(contextWithUserID(req.Context(), userID))
+			rec := httptest.NewRecorder()
+
+			handler.GetPersonalRecords(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var resp []PersonalRecordResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				if resp == nil {
+					t.Error("expected empty array, got null")
+				}
+				if len(resp) != tt.expectedCount {
+					t.Errorf("expected %d records, got %d", tt.expectedCount, len(resp))
+				}
+			}
+		})
+	}
+}
+
+func TestPersonalRecordHandler_GetExercisePersonalRecords(t *testing.T) {
+	userID := uuid.New()
+	exerciseID := uuid.New()
+	achievedDate := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		exerciseID     string
+		mockFunc       func(ctx context.Context, userID, exerciseID uuid.UUID) ([]*entity.PersonalRecord, error)
+		expectedStatus int
+		checkResponse  func(t *testing.T, resp []PersonalRecordResponse)
+	}{
+		{
+			name:       "成功: 種目の自己ベスト履歴を取得",
+			exerciseID: exerciseID.String(),
+			mockFunc: func(ctx context.Context, uid, eid uuid.UUID) ([]*entity.PersonalRecord, error) {
+				setID := uuid.New()
+				reps := int32(3)
+				previous := 90.0
+				return []*entity.PersonalRecord{
+					entity.NewPersonalRecord(uid, eid, uuid.New(), &setID, entity.PersonalRecordTypeRepMax, &reps, 95.0, &previous, achievedDate),
+				}, nil
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, resp []PersonalRecordResponse) {
+				if len(resp) != 1 {
+					t.Fatalf("expected 1 record, got %d", len(resp))
+				}
+				r := resp[0]
+				if r.ExerciseID != exerciseID.String() {
+					t.Errorf("expected exercise_id %s, got %s", exerciseID, r.ExerciseID)
+				}
+				if r.RecordType != "rep_max" || r.Reps == nil || *r.Reps != 3 {
+					t.Errorf("expected rep_max with reps 3, got %s %v", r.RecordType, r.Reps)
+				}
+				if r.WorkoutSetID == nil {
+					t.Error("expected workout_set_id")
+				}
+				if r.PreviousValue == nil || *r.PreviousValue != 90.0 {
+					t.Errorf("expected previous_value 90, got %v", r.PreviousValue)
+				}
+				if r.AchievedDate != "2026-01-15" {
+					t.Errorf("expected achieved_date 2026-01-15, got %s", r.AchievedDate)
+				}
+			},
+		},
+		{
+			name:           "失敗: 不正なエクササイズID",
+			exerciseID:     "invalid-uuid",
+			mockFunc:       nil,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "失敗: エクササイズが見つからない",
+			exerciseID: exerciseID.String(),
+			mockFunc: func(ctx context.Context, uid, eid uuid.UUID) ([]*entity.PersonalRecord, error) {
+				return nil, usecase.ErrExerciseNotFound
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := &mockPersonalRecordUsecase{
+				getExercisePersonalRecordsFunc: tt.mockFunc,
+			}
+			handler := NewPersonalRecordHandler(mockUsecase)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/exercises/"+tt.exerciseID+"/records", nil)
+			req = req.WithContext(contextWithUserID(req.Context(), userID))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.exerciseID})
+			rec := httptest.NewRecorder()
+
+			handler.GetExercisePersonalRecords(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			if tt.checkResponse != nil {
+				var resp []PersonalRecordResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				tt.checkResponse(t, resp)
+			}
+		})
+	}
+}
@@ -23,29 +23,45 @@ func NewProfileHandler(profileUsecase usecase.ProfileUsecaseInterface) *ProfileH
 
 // CreateProfileRequest はプロフィール作成APIのリクエストボディ
 type CreateProfileRequest struct {
-	DisplayName string   `json:"display_name"`
-	Age         *int32   `json:"age,omitempty"`
-	Weight      *float64 `json:"weight,omitempty"`
-	Height      *float64 `json:"height,omitempty"`
+	DisplayName        string   `json:"display_name"`
+	Age                *int32   `json:"age,omitempty"`
+	Weight             *float64 `json:"weight,omitempty"`
+	Height             *float64 `json:"height,omitempty"`
+	OneRMFormula       *string  `json:"one_rm_formula,omitempty"`
+	Timezone           *string  `json:"timezone,omitempty"`
+	WeeklyWorkoutGoal  *int32   `json:"weekly_workout_goal,omitempty"`
+	RestDayTolerance   *int32   `json:"rest_day_tolerance,omitempty"`
+	DailyScoreStrategy *string  `json:"daily_score_strategy,omitempty"`
 }
 
 // UpdateProfileRequest はプロフィール更新APIのリクエストボディ
 type UpdateProfileRequest struct {
-	DisplayName *string  `json:"display_name,omitempty"`
-	Age         *int32   `json:"age,omitempty"`
-	Weight      *float64 `json:"weight,omitempty"`
-	Height      *float64 `json:"height,omitempty"`
+	DisplayName        *string  `json:"display_name,omitempty"`
+	Age                *int32   `json:"age,omitempty"`
+	Weight             *float64 `json:"weight,omitempty"`
+	Height             *float64 `json:"height,omitempty"`
+	OneRMFormula       *string  `json:"one_rm_formula,omitempty"`
+	Timezone           *string  `json:"timezone,omitempty"`
+	WeeklyWorkoutGoal  *int32   `json:"weekly_workout_goal,omitempty"`
+	RestDayTolerance   *int32   `json:"rest_day_tolerance,omitempty"`
+	DailyScoreStrategy *string  `json:"daily_score_strategy,omitempty"`
 }
 
 // ProfileResponse はプロフィールAPIのレスポンスボディ
 type ProfileResponse struct {
-	ID          string   `json:"id"`
-	UserID      string   `json:"user_id"`
-	DisplayName string   `json:"display_name"`
-	Age         *int32   `json:"age,omitempty"`
-	Weight      *float64 `json:"weight,omitempty"`
-	Height      *float64 `json:"height,omitempty"`
-	BMI         *float64 `json:"bmi,omitempty"`
+	ID                        string   `json:"id"`
+	UserID                    string   `json:"user_id"`
+	DisplayName               string   `json:"display_name"`
+	Age                       *int32   `json:"age,omitempty"`
+	Weight                    *float64 `json:"weight,omitempty"`
+	Height                    *float64 `json:"height,omitempty"`
+	BMI                       *float64 `json:"bmi,omitempty"`
+	OneRMFormula              string   `json:"one_rm_formula"`
+	Timezone                  string   `json:"timezone"`
+	WeeklyWorkoutGoal         int32    `json:"weekly_workout_goal"`
+	RestDayTolerance          int32    `json:"rest_day_tolerance"`
+	DailyScoreStrategy        string   `json:"daily_score_strategy"`
+	DailyScoreBackfillPending bool     `json:"daily_score_backfill_pending"`
 }
 
 // CreateProfile はプロフィールを作成する。
@@ -59,7 +75,18 @@ func (h *ProfileHandler) CreateProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	profile, err := h.profileUsecase.CreateProfile(r.Context(), userID, req.DisplayName, req.Age, req.Weight, req.Height)
+	profile, err := h.profileUsecase.CreateProfile(r.Context(), usecase.CreateProfileInput{
+		UserID:             userID,
+		DisplayName:        req.DisplayName,
+		Age:                req.Age,
+		Weight:             req.Weight,
+		Height:             req.Height,
+		OneRMFormula:       toOneRMFormula(req.OneRMFormula),
+		Timezone:           req.Timezone,
+		WeeklyWorkoutGoal:  req.WeeklyWorkoutGoal,
+		RestDayTolerance:   req.RestDayTolerance,
+		DailyScoreStrategy: toDailyScoreStrategy(req.DailyScoreStrategy),
+	})
 	if err != nil {
 		handleProfileError(w, err)
 		return
@@ -93,7 +120,18 @@ func (h *ProfileHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	profile, err := h.profileUsecase.UpdateProfile(r.Context(), userID, req.DisplayName, req.Age, req.Weight, req.Height)
+	profile, err := h.profileUsecase.UpdateProfile(r.Context(), usecase.UpdateProfileInput{
+		UserID:             userID,
+		DisplayName:        req.DisplayName,
+		Age:                req.Age,
+		Weight:             req.Weight,
+		Height:             req.Height,
+		OneRMFormula:       toOneRMFormula(req.OneRMFormula),
+		Timezone:           req.Timezone,
+		WeeklyWorkoutGoal:  req.WeeklyWorkoutGoal,
+		RestDayTolerance:   req.RestDayTolerance,
+		DailyScoreStrategy: toDailyScoreStrategy(req.DailyScoreStrategy),
+	})
 	if err != nil {
 		handleProfileError(w, err)
 		return
@@ -171,14 +209,40 @@ func (h *ProfileHandler) DeleteAvatar(w http.ResponseWriter, r *http.Request) {
 // toProfileResponse はProfileエンティティをレスポンスDTOに変換する。
 func toProfileResponse(p *entity.Profile) ProfileResponse {
 	return ProfileResponse{
-		ID:          p.ID.String(),
-		UserID:      p.UserID.String(),
-		DisplayName: p.DisplayName,
-		Age:         p.Age,
-		Weight:      p.Weight,
-		Height:      p.Height,
-		BMI:         p.CalculateBMI(),
+		ID:                        p.ID.String(),
+		UserID:                    p.UserID.String(),
+		DisplayName:               p.DisplayName,
+		Age:                       p.Age,
+		Weight:                    p.Weight,
+		Height:                    p.Height,
+		BMI:                       p.CalculateBMI(),
+		OneRMFormula:              string(p.OneRMFormula),
+		Timezone:                  p.Timezone,
+		WeeklyWorkoutGoal:         p.WeeklyWorkoutGoal,
+		RestDayTolerance:          p.RestDayTolerance,
+		DailyScoreStrategy:        string(p.DailyScoreStrategy),
+		DailyScoreBackfillPending: p.DailyScoreBackfillPending,
+	}
+}
+
+// toOneRMFormula はリクエストの公式名をentity.OneRMFormulaに変換する。
+// nilの場合はnilを返す（変更なし・デフォルト値を使用）。
+func toOneRMFormula(s *string) *entity.OneRMFormula {
+	if s == nil {
+		return nil
+	}
+	formula := entity.OneRMFormula(*s)
+	return &formula
+}
+
+// toDailyScoreStrategy はリクエストの計算方法名をentity.DailyScoreStrategyTypeに変換する。
+// nilの場合はnilを返す（変更なし・デフォルト値を使用）。
+func toDailyScoreStrategy(s *string) *entity.DailyScoreStrategyType {
+	if s == nil {
+		return nil
 	}
+	strategy := entity.DailyScoreStrategyType(*s)
+	return &strategy
 }
 
 // handleProfileError はProfileUsecase層のエラーを適切なHTTPステータスコードに変換する。
@@ -206,6 +270,11 @@ func isProfileValidationError(err error) bool {
 		"age must be",
 		"weight must be",
 		"height must be",
+		"invalid 1RM formula",
+		"invalid timezone",
+		"weekly workout goal must be",
+		"rest day tolerance must be",
+		"invalid daily score strategy",
 	}
 
 	errMsg := err.Error()
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ucchy108/whiskey/backend/domain/entity"
@@ -16,17 +17,18 @@ import (
 
 // mockProfileUsecase はProfileUsecaseのモック実装
 type mockProfileUsecase struct {
-	createProfileFunc        func(ctx context.Context, userID uuid.UUID, displayName string, age *int32, weight *float64, height *float64) (*entity.Profile, error)
+	createProfileFunc        func(ctx context.Context, input usecase.CreateProfileInput) (*entity.Profile, error)
 	getProfileFunc           func(ctx context.Context, userID uuid.UUID) (*entity.Profile, error)
-	updateProfileFunc        func(ctx context.Context, userID uuid.UUID, displayName *string, age *int32, weight *float64, height *float64) (*entity.Profile, error)
+	getLocationFunc          func(ctx context.Context, userID uuid.UUID) (*time.Location, error)
+	updateProfileFunc        func(ctx context.Context, input usecase.UpdateProfileInput) (*entity.Profile, error)
 	getAvatarUploadURLFunc func(ctx context.Context, userID uuid.UUID, contentType string) (string, string, error)
 	getAvatarURLFunc       func(ctx context.Context, userID uuid.UUID) (string, error)
 	deleteAvatarFunc       func(ctx context.Context, userID uuid.UUID) error
 }
 
-func (m *mockProfileUsecase) CreateProfile(ctx context.Context, userID uuid.UUID, displayName string, age *int32, weight *float64, height *float64) (*entity.Profile, error) {
+func (m *mockProfileUsecase) CreateProfile(ctx context.Context, input usecase.CreateProfileInput) (*entity.Profile, error) {
 	if m.createProfileFunc != nil {
-		return m.createProfileFunc(ctx, userID, displayName, age, weight, height)
+		return m.createProfileFunc(ctx, input)
 	}
 	return nil, nil
 }
@@ -38,9 +40,16 @@ func (m *mockProfileUsecase) GetProfile(ctx context.Context, userID uuid.UUID) (
 	return nil, nil
 }
 
-func (m *mockProfileUsecase) UpdateProfile(ctx context.Context, userID uuid.UUID, displayName *string, age *int32, weight *float64, height *float64) (*entity.Profile, error) {
+func (m *mockProfileUsecase) GetLocation(ctx context.Context, userID uuid.UUID) (*time.Location, error) {
+	if m.getLocationFunc != nil {
+		return m.getLocationFunc(ctx, userID)
+	}
+	return time.UTC, nil
+}
+
+func (m *mockProfileUsecase) UpdateProfile(ctx context.Context, input usecase.UpdateProfileInput) (*entity.Profile, error) {
 	if m.updateProfileFunc != nil {
-		return m.updateProfileFunc(ctx, userID, displayName, age, weight, height)
+		return m.updateProfileFunc(ctx, input)
 	}
 	return nil, nil
 }
@@ -66,6 +75,19 @@ func (m *mockProfileUsecase) DeleteAvatar(ctx context.Context, userID uuid.UUID)
 	return nil
 }
 
+// newMockProfileUsecaseWithLocation はGetLocationでlocを返すモックを生成する
+// locがnilの場合はUTCを返す
+func newMockProfileUsecaseWithLocation(loc *time.Location) *mockProfileUsecase {
+	if loc == nil {
+		return &mockProfileUsecase{}
+	}
+	return &mockProfileUsecase{
+		getLocationFunc: func(ctx context.Context, userID uuid.UUID) (*time.Location, error) {
+			return loc, nil
+		},
+	}
+}
+
 // setAuthContext はテスト用にリクエストコンテキストにuserIDを設定する
 func setAuthContext(r *http.Request, userID uuid.UUID) *http.Request {
 	ctx := context.WithValue(r.Context(), auth.UserIDContextKey, userID)
@@ -76,7 +98,7 @@ func TestProfileHandler_CreateProfile(t *testing.T) {
 	tests := []struct {
 		name           string
 		requestBody    interface{}
-		mockFunc       func(ctx context.Context, userID uuid.UUID, displayName string, age *int32, weight *float64, height *float64) (*entity.Profile, error)
+		mockFunc       func(ctx context.Context, input usecase.CreateProfileInput) (*entity.Profile, error)
 		expectedStatus int
 		checkBody      func(t *testing.T, body map[string]interface{})
 	}{
@@ -88,11 +110,11 @@ func TestProfileHandler_CreateProfile(t *testing.T) {
 				Weight:      float64Ptr(70.5),
 				Height:      float64Ptr(175.0),
 			},
-			mockFunc: func(ctx context.Context, userID uuid.UUID, displayName string, age *int32, weight *float64, height *float64) (*entity.Profile, error) {
-				profile, _ := entity.NewProfile(userID, displayName)
-				profile.Age = age
-				profile.Weight = weight
-				profile.Height = height
+			mockFunc: func(ctx context.Context, input usecase.CreateProfileInput) (*entity.Profile, error) {
+				profile, _ := entity.NewProfile(input.UserID, input.DisplayName)
+				profile.Age = input.Age
+				profile.Weight = input.Weight
+				profile.Height = input.Height
 				return profile, nil
 			},
 			expectedStatus: http.StatusCreated,
@@ -107,8 +129,8 @@ func TestProfileHandler_CreateProfile(t *testing.T) {
 			requestBody: CreateProfileRequest{
 				DisplayName: "ユーザー",
 			},
-			mockFunc: func(ctx context.Context, userID uuid.UUID, displayName string, age *int32, weight *float64, height *float64) (*entity.Profile, error) {
-				profile, _ := entity.NewProfile(userID, displayName)
+			mockFunc: func(ctx context.Context, input usecase.CreateProfileInput) (*entity.Profile, error) {
+				profile, _ := entity.NewProfile(input.UserID, input.DisplayName)
 				return profile, nil
 			},
 			expectedStatus: http.StatusCreated,
@@ -129,17 +151,37 @@ func TestProfileHandler_CreateProfile(t *testing.T) {
 			requestBody: CreateProfileRequest{
 				DisplayName: "テスト",
 			},
-			mockFunc: func(ctx context.Context, userID uuid.UUID, displayName string, age *int32, weight *float64, height *float64) (*entity.Profile, error) {
+			mockFunc: func(ctx context.Context, input usecase.CreateProfileInput) (*entity.Profile, error) {
 				return nil, usecase.ErrProfileAlreadyExists
 			},
 			expectedStatus: http.StatusConflict,
 		},
+		{
+			name: "成功: 1RM計算式を指定",
+			requestBody: CreateProfileRequest{
+				DisplayName:  "ユーザー",
+				OneRMFormula: strPtr("brzycki"),
+			},
+			mockFunc: func(ctx context.Context, input usecase.CreateProfileInput) (*entity.Profile, error) {
+				profile, _ := entity.NewProfile(input.UserID, input.DisplayName)
+				if err := profile.UpdateOneRMFormula(*input.OneRMFormula); err != nil {
+					return nil, err
+				}
+				return profile, nil
+			},
+			expectedStatus: http.StatusCreated,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				if body["one_rm_formula"] != "brzycki" {
+					t.Errorf("expected one_rm_formula brzycki, got %v", body["one_rm_formula"])
+				}
+			},
+		},
 		{
 			name: "失敗: バリデーションエラー（表示名が空）",
 			requestBody: CreateProfileRequest{
 				DisplayName: "",
 			},
-			mockFunc: func(ctx context.Context, userID uuid.UUID, displayName string, age *int32, weight *float64, height *float64) (*entity.Profile, error) {
+			mockFunc: func(ctx context.Context, input usecase.CreateProfileInput) (*entity.Profile, error) {
 				return nil, entity.ErrInvalidDisplayName
 			},
 			expectedStatus: http.StatusBadRequest,
@@ -238,7 +280,7 @@ func TestProfileHandler_UpdateProfile(t *testing.T) {
 	tests := []struct {
 		name           string
 		requestBody    interface{}
-		mockFunc       func(ctx context.Context, userID uuid.UUID, displayName *string, age *int32, weight *float64, height *float64) (*entity.Profile, error)
+		mockFunc       func(ctx context.Context, input usecase.UpdateProfileInput) (*entity.Profile, error)
 		expectedStatus int
 		checkBody      func(t *testing.T, body map[string]interface{})
 	}{
@@ -250,11 +292,11 @@ func TestProfileHandler_UpdateProfile(t *testing.T) {
 				Weight:      float64Ptr(75.0),
 				Height:      float64Ptr(180.0),
 			},
-			mockFunc: func(ctx context.Context, userID uuid.UUID, displayName *string, age *int32, weight *float64, height *float64) (*entity.Profile, error) {
-				profile, _ := entity.NewProfile(userID, *displayName)
-				profile.Age = age
-				profile.Weight = weight
-				profile.Height = height
+			mockFunc: func(ctx context.Context, input usecase.UpdateProfileInput) (*entity.Profile, error) {
+				profile, _ := entity.NewProfile(input.UserID, *input.DisplayName)
+				profile.Age = input.Age
+				profile.Weight = input.Weight
+				profile.Height = input.Height
 				return profile, nil
 			},
 			expectedStatus: http.StatusOK,
@@ -269,8 +311,8 @@ func TestProfileHandler_UpdateProfile(t *testing.T) {
 			requestBody: UpdateProfileRequest{
 				DisplayName: strPtr("名前だけ変更"),
 			},
-			mockFunc: func(ctx context.Context, userID uuid.UUID, displayName *string, age *int32, weight *float64, height *float64) (*entity.Profile, error) {
-				profile, _ := entity.NewProfile(userID, *displayName)
+			mockFunc: func(ctx context.Context, input usecase.UpdateProfileInput) (*entity.Profile, error) {
+				profile, _ := entity.NewProfile(input.UserID, *input.DisplayName)
 				return profile, nil
 			},
 			expectedStatus: http.StatusOK,
@@ -286,11 +328,122 @@ func TestProfileHandler_UpdateProfile(t *testing.T) {
 			requestBody: UpdateProfileRequest{
 				DisplayName: strPtr("テスト"),
 			},
-			mockFunc: func(ctx context.Context, userID uuid.UUID, displayName *string, age *int32, weight *float64, height *float64) (*entity.Profile, error) {
+			mockFunc: func(ctx context.Context, input usecase.UpdateProfileInput) (*entity.Profile, error) {
 				return nil, usecase.ErrProfileNotFound
 			},
 			expectedStatus: http.StatusNotFound,
 		},
+		{
+			name: "失敗: 無効な1RM計算式",
+			requestBody: UpdateProfileRequest{
+				OneRMFormula: strPtr("unknown"),
+			},
+			mockFunc: func(ctx context.Context, input usecase.UpdateProfileInput) (*entity.Profile, error) {
+				return nil, entity.ErrInvalidOneRMFormula
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "成功: タイムゾーンを更新",
+			requestBody: UpdateProfileRequest{
+				Timezone: strPtr("Asia/Tokyo"),
+			},
+			mockFunc: func(ctx context.Context, input usecase.UpdateProfileInput) (*entity.Profile, error) {
+				profile, _ := entity.NewProfile(input.UserID, "テスト")
+				if err := profile.UpdateTimezone(*input.Timezone); err != nil {
+					return nil, err
+				}
+				return profile, nil
+			},
+			expectedStatus: http.StatusOK,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				if body["timezone"] != "Asia/Tokyo" {
+					t.Errorf("expected timezone Asia/Tokyo, got %v", body["timezone"])
+				}
+			},
+		},
+		{
+			name: "失敗: 無効なタイムゾーン",
+			requestBody: UpdateProfileRequest{
+				Timezone: strPtr("Mars/Olympus"),
+			},
+			mockFunc: func(ctx context.Context, input usecase.UpdateProfileInput) (*entity.Profile, error) {
+				return nil, entity.ErrInvalidTimezone
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "成功: 週の目標と休息日数を更新",
+			requestBody: UpdateProfileRequest{
+				WeeklyWorkoutGoal: int32Ptr(4),
+				RestDayTolerance:  int32Ptr(2),
+			},
+			mockFunc: func(ctx context.Context, input usecase.UpdateProfileInput) (*entity.Profile, error) {
+				profile, _ := entity.NewProfile(input.UserID, "テスト")
+				if err := profile.UpdateWeeklyWorkoutGoal(*input.WeeklyWorkoutGoal); err != nil {
+					return nil, err
+				}
+				if err := profile.UpdateRestDayTolerance(*input.RestDayTolerance); err != nil {
+					return nil, err
+				}
+				return profile, nil
+			},
+			expectedStatus: http.StatusOK,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				if body["weekly_workout_goal"] != float64(4) || body["rest_day_tolerance"] != float64(2) {
+					t.Errorf("expected weekly_workout_goal 4 and rest_day_tolerance 2, got %v, %v", body["weekly_workout_goal"], body["rest_day_tolerance"])
+				}
+			},
+		},
+		{
+			name: "失敗: 週の目標が範囲外",
+			requestBody: UpdateProfileRequest{
+				WeeklyWorkoutGoal: int32Ptr(8),
+			},
+			mockFunc: func(ctx context.Context, input usecase.UpdateProfileInput) (*entity.Profile, error) {
+				return nil, entity.ErrInvalidWeeklyWorkoutGoal
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "失敗: 休息日数が範囲外",
+			requestBody: UpdateProfileRequest{
+				RestDayTolerance: int32Ptr(7),
+			},
+			mockFunc: func(ctx context.Context, input usecase.UpdateProfileInput) (*entity.Profile, error) {
+				return nil, entity.ErrInvalidRestDayTolerance
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "成功: デイリースコアの計算方法を更新",
+			requestBody: UpdateProfileRequest{
+				DailyScoreStrategy: strPtr("rolling_average"),
+			},
+			mockFunc: func(ctx context.Context, input usecase.UpdateProfileInput) (*entity.Profile, error) {
+				profile, _ := entity.NewProfile(input.UserID, "テスト")
+				if err := profile.UpdateDailyScoreStrategy(*input.DailyScoreStrategy); err != nil {
+					return nil, err
+				}
+				return profile, nil
+			},
+			expectedStatus: http.StatusOK,
+			checkBody: func(t *testing.T, body map[string]interface{}) {
+				if body["daily_score_strategy"] != "rolling_average" || body["daily_score_backfill_pending"] != true {
+					t.Errorf("expected daily_score_strategy rolling_average and daily_score_backfill_pending true, got %v, %v", body["daily_score_strategy"], body["daily_score_backfill_pending"])
+				}
+			},
+		},
+		{
+			name: "失敗: 無効なデイリースコアの計算方法",
+			requestBody: UpdateProfileRequest{
+				DailyScoreStrategy: strPtr("unknown"),
+			},
+			mockFunc: func(ctx context.Context, input usecase.UpdateProfileInput) (*entity.Profile, error) {
+				return nil, entity.ErrInvalidDailyScoreStrategy
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,382 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/infrastructure/auth"
+	"github.com/ucchy108/whiskey/backend/usecase"
+)
+
+// ProgramHandler は複数週にわたるトレーニングプログラム関連のHTTPハンドラーを提供する。
+// Usecase層のビジネスロジックをRESTful APIとして公開する。
+type ProgramHandler struct {
+	programUsecase usecase.ProgramUsecaseInterface
+}
+
+// NewProgramHandler はProgramHandlerの新しいインスタンスを生成する。
+//
+// パラメータ:
+//   - programUsecase: プログラムに関するビジネスロジックを提供するユースケース
+//
+// 戻り値:
+//   - *ProgramHandler: 生成されたProgramHandlerインスタンス
+func NewProgramHandler(programUsecase usecase.ProgramUsecaseInterface) *ProgramHandler {
+	return &ProgramHandler{
+		programUsecase: programUsecase,
+	}
+}
+
+// --- リクエスト/レスポンスDTO ---
+
+// ProgramSetRequest はプログラムの1セット分の処方のリクエストボディ
+type ProgramSetRequest struct {
+	ExerciseID      string  `json:"exercise_id"`
+	Reps            int32   `json:"reps"`
+	PercentageOf1RM float64 `json:"percentage_1rm"`
+}
+
+// ProgramDayRequest はプログラムの1日分のリクエストボディ
+type ProgramDayRequest struct {
+	Week int32               `json:"week"`
+	Day  int32               `json:"day"`
+	Sets []ProgramSetRequest `json:"sets"`
+}
+
+// CreateProgramRequest はプログラム作成APIのリクエストボディ
+type CreateProgramRequest struct {
+	Name        string              `json:"name"`
+	Description *string             `json:"description"`
+	Days        []ProgramDayRequest `json:"days"`
+}
+
+// ProgramSetResponse はプログラムの1セット分の処方のレスポンスボディ
+type ProgramSetResponse struct {
+	ExerciseID      string  `json:"exercise_id"`
+	Reps            int32   `json:"reps"`
+	PercentageOf1RM float64 `json:"percentage_1rm"`
+}
+
+// ProgramDayResponse はプログラムの1日分のレスポンスボディ
+type ProgramDayResponse struct {
+	Week int32                `json:"week"`
+	Day  int32                `json:"day"`
+	Sets []ProgramSetResponse `json:"sets"`
+}
+
+// ProgramResponse はプログラムのレスポンスボディ
+type ProgramResponse struct {
+	ID              string               `json:"id"`
+	UserID          string               `json:"user_id"`
+	Name            string               `json:"name"`
+	Description     *string              `json:"description"`
+	Days            []ProgramDayResponse `json:"days"`
+	CurrentDayIndex int32                `json:"current_day_index"`
+	Completed       bool                 `json:"completed"`
+	CreatedAt       string               `json:"created_at"`
+	UpdatedAt       string               `json:"updated_at"`
+}
+
+// PrescribedSetResponse は処方重量を計算済みの1セット分のレスポンスボディ
+type PrescribedSetResponse struct {
+	ExerciseID      string   `json:"exercise_id"`
+	SetNumber       int32    `json:"set_number"`
+	Reps            int32    `json:"reps"`
+	PercentageOf1RM float64  `json:"percentage_1rm"`
+	Estimated1RM    float64  `json:"estimated_1rm"`
+	Weight          *float64 `json:"weight"`
+}
+
+// CurrentProgramDayResponse はプログラムの現在の日のレスポンスボディ
+type CurrentProgramDayResponse struct {
+	ProgramID string                  `json:"program_id"`
+	DayIndex  int32                   `json:"day_index"`
+	Week      int32                   `json:"week"`
+	Day       int32                   `json:"day"`
+	Sets      []PrescribedSetResponse `json:"sets"`
+}
+
+// --- ハンドラーメソッド ---
+
+// CreateProgram は新しいプログラムを作成する。
+// POST /api/programs
+//
+// リクエストボディ:
+//
+//	{
+//	  "name": "5/3/1",
+//	  "days": [{"week": 1, "day": 1, "sets": [{"exercise_id": "...", "reps": 5, "percentage_1rm": 65}]}]
+//	}
+//
+// レスポンス:
+//   - 201 Created: 作成成功
+//   - 400 Bad Request: リクエストボディが不正、バリデーションエラー
+//   - 404 Not Found: エクササイズが見つからない
+//   - 500 Internal Server Error: サーバーエラー
+func (h *ProgramHandler) CreateProgram(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	var req CreateProgramRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	days, err := toProgramDayInputs(req.Days)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid exercise ID")
+		return
+	}
+
+	input := usecase.CreateProgramInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Days:        days,
+	}
+
+	program, err := h.programUsecase.CreateProgram(r.Context(), userID, input)
+	if err != nil {
+		handleProgramUsecaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, toProgramResponse(program))
+}
+
+// ListPrograms はログインユーザーのプログラム一覧を取得する。
+// GET /api/programs
+//
+// レスポンス:
+//   - 200 OK: 取得成功
+//   - 500 Internal Server Error: サーバーエラー
+func (h *ProgramHandler) ListPrograms(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	programs, err := h.programUsecase.ListPrograms(r.Context(), userID)
+	if err != nil {
+		handleProgramUsecaseError(w, err)
+		return
+	}
+
+	resp := make([]ProgramResponse, 0, len(programs))
+	for _, program := range programs {
+		resp = append(resp, toProgramResponse(program))
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// GetProgram はプログラムの詳細を取得する。
+// GET /api/programs/{id}
+//
+// パスパラメータ:
+//   - id: プログラムID (UUID)
+//
+// レスポンス:
+//   - 200 OK: 取得成功
+//   - 400 Bad Request: プログラムIDが不正
+//   - 403 Forbidden: アクセス権がない
+//   - 404 Not Found: プログラムが見つからない
+//   - 500 Internal Server Error: サーバーエラー
+func (h *ProgramHandler) GetProgram(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	vars := mux.Vars(r)
+	programID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid program ID")
+		return
+	}
+
+	program, err := h.programUsecase.GetProgram(r.Context(), userID, programID)
+	if err != nil {
+		handleProgramUsecaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toProgramResponse(program))
+}
+
+// DeleteProgram はプログラムを削除する。
+// DELETE /api/programs/{id}
+//
+// パスパラメータ:
+//   - id: プログラムID (UUID)
+//
+// レスポンス:
+//   - 204 No Content: 削除成功
+//   - 400 Bad Request: プログラムIDが不正
+//   - 403 Forbidden: アクセス権がない
+//   - 404 Not Found: プログラムが見つからない
+//   - 500 Internal Server Error: サーバーエラー
+func (h *ProgramHandler) DeleteProgram(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	vars := mux.Vars(r)
+	programID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid program ID")
+		return
+	}
+
+	if err := h.programUsecase.DeleteProgram(r.Context(), userID, programID); err != nil {
+		handleProgramUsecaseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetCurrentProgramDay はプログラムの現在の日と、ユーザーの推定1RMから計算した処方重量を取得する。
+// GET /api/programs/{id}/current
+//
+// パスパラメータ:
+//   - id: プログラムID (UUID)
+//
+// レスポンス:
+//   - 200 OK: 取得成功
+//   - 400 Bad Request: プログラムIDが不正
+//   - 403 Forbidden: アクセス権がない
+//   - 404 Not Found: プログラムが見つからない
+//   - 409 Conflict: プログラムの全日程を終えている
+//   - 500 Internal Server Error: サーバーエラー
+func (h *ProgramHandler) GetCurrentProgramDay(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	vars := mux.Vars(r)
+	programID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid program ID")
+		return
+	}
+
+	output, err := h.programUsecase.GetCurrentProgramDay(r.Context(), userID, programID)
+	if err != nil {
+		handleProgramUsecaseError(w, err)
+		return
+	}
+
+	sets := make([]PrescribedSetResponse, 0, len(output.Sets))
+	for _, s := range output.Sets {
+		sets = append(sets, PrescribedSetResponse{
+			ExerciseID:      s.ExerciseID.String(),
+			SetNumber:       s.SetNumber,
+			Reps:            s.Reps,
+			PercentageOf1RM: s.PercentageOf1RM,
+			Estimated1RM:    s.Estimated1RM,
+			Weight:          s.Weight,
+		})
+	}
+
+	respondJSON(w, http.StatusOK, CurrentProgramDayResponse{
+		ProgramID: output.Program.ID.String(),
+		DayIndex:  output.DayIndex,
+		Week:      output.Week,
+		Day:       output.Day,
+		Sets:      sets,
+	})
+}
+
+// --- ヘルパー関数 ---
+
+// handleProgramUsecaseError はProgram Usecase層のエラーを適切なHTTPステータスコードに変換する。
+func handleProgramUsecaseError(w http.ResponseWriter, err error) {
+	switch err {
+	case usecase.ErrProgramNotFound:
+		respondError(w, http.StatusNotFound, "Program not found")
+	case usecase.ErrProgramAccessDenied:
+		respondError(w, http.StatusForbidden, "Access denied to this program")
+	case usecase.ErrProgramCompleted:
+		respondError(w, http.StatusConflict, "Program is already completed")
+	case usecase.ErrExerciseNotFound:
+		respondError(w, http.StatusNotFound, "Exercise not found")
+	default:
+		if isProgramValidationError(err) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+// isProgramValidationError はプログラム関連のバリデーションエラーかどうかを判定する。
+func isProgramValidationError(err error) bool {
+	errMsg := err.Error()
+	validationErrors := []string{
+		"program name must be",
+		"program must have",
+		"program day must",
+		"program week must be",
+		"program days must be",
+		"prescribed reps must be",
+		"percentage of 1RM must be",
+	}
+
+	for _, ve := range validationErrors {
+		if len(errMsg) >= len(ve) && errMsg[:len(ve)] == ve {
+			return true
+		}
+	}
+	return false
+}
+
+// toProgramDayInputs はProgramDayRequestのスライスをusecase.ProgramDayInputのスライスに変換する。
+func toProgramDayInputs(reqs []ProgramDayRequest) ([]usecase.ProgramDayInput, error) {
+	days := make([]usecase.ProgramDayInput, 0, len(reqs))
+	for _, d := range reqs {
+		sets := make([]usecase.ProgramSetInput, 0, len(d.Sets))
+		for _, s := range d.Sets {
+			exerciseID, err := uuid.Parse(s.ExerciseID)
+			if err != nil {
+				return nil, err
+			}
+			sets = append(sets, usecase.ProgramSetInput{
+				ExerciseID:      exerciseID,
+				Reps:            s.Reps,
+				PercentageOf1RM: s.PercentageOf1RM,
+			})
+		}
+		days = append(days, usecase.ProgramDayInput{
+			Week: d.Week,
+			Day:  d.Day,
+			Sets: sets,
+		})
+	}
+	return days, nil
+}
+
+// toProgramResponse はProgramエンティティをProgramResponseに変換する。
+func toProgramResponse(program *entity.Program) ProgramResponse {
+	days := make([]ProgramDayResponse, 0, len(program.Days))
+	for _, d := range program.Days {
+		sets := make([]ProgramSetResponse, 0, len(d.Sets))
+		for _, s := range d.Sets {
+			sets = append(sets, ProgramSetResponse{
+				ExerciseID:      s.ExerciseID.String(),
+				Reps:            s.Reps,
+				PercentageOf1RM: s.PercentageOf1RM,
+			})
+		}
+		days = append(days, ProgramDayResponse{
+			Week: d.Week,
+			Day:  d.Day,
+			Sets: sets,
+		})
+	}
+
+	return ProgramResponse{
+		ID:              program.ID.String(),
+		UserID:          program.UserID.String(),
+		Name:            program.Name,
+		Description:     program.Description,
+		Days:            days,
+		CurrentDayIndex: program.CurrentDayIndex,
+		Completed:       program.IsCompleted(),
+		CreatedAt:       program.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:       program.UpdatedAt.Format(time.RFC3339),
+	}
+}
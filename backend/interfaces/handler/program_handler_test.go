@@ -0,0 +1,454 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/usecase"
+)
+
+// mockProgramUsecase はProgramUsecaseのモック実装
+type mockProgramUsecase struct {
+	createProgramFunc        func(ctx context.Context, userID uuid.UUID, input usecase.CreateProgramInput) (*entity.Program, error)
+	getProgramFunc           func(ctx context.Context, userID, programID uuid.UUID) (*entity.Program, error)
+	listProgramsFunc         func(ctx context.Context, userID uuid.UUID) ([]*entity.Program, error)
+	deleteProgramFunc        func(ctx context.Context, userID, programID uuid.UUID) error
+	getCurrentProgramDayFunc func(ctx context.Context, userID, programID uuid.UUID) (*usecase.ProgramDayOutput, error)
+}
+
+func (m *mockProgramUsecase) CreateProgram(ctx context.Context, userID uuid.UUID, input usecase.CreateProgramInput) (*entity.Program, error) {
+	if m.createProgramFunc != nil {
+		return m.createProgramFunc(ctx, userID, input)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockProgramUsecase) GetProgram(ctx context.Context, userID, programID uuid.UUID) (*entity.Program, error) {
+	if m.getProgramFunc != nil {
+		return m.getProgramFunc(ctx, userID, programID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockProgramUsecase) ListPrograms(ctx context.Context, userID uuid.UUID) ([]*entity.Program, error) {
+	if m.listProgramsFunc != nil {
+		return m.listProgramsFunc(ctx, userID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockProgramUsecase) DeleteProgram(ctx context.Context, userID, programID uuid.UUID) error {
+	if m.deleteProgramFunc != nil {
+		return m.deleteProgramFunc(ctx, userID, programID)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockProgramUsecase) GetCurrentProgramDay(ctx context.Context, userID, programID uuid.UUID) (*usecase.ProgramDayOutput, error) {
+	if m.getCurrentProgramDayFunc != nil {
+		return m.getCurrentProgramDayFunc(ctx, userID, programID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+// testProgramDays はテスト用の1週2日分の日程を返す
+func testProgramDays(exerciseID uuid.UUID) []entity.ProgramDay {
+	return []entity.ProgramDay{
+		{Week: 1, Day: 1, Sets: []entity.ProgramSet{{ExerciseID: exerciseID, Reps: 5, PercentageOf1RM: 65}}},
+		{Week: 1, Day: 2, Sets: []entity.ProgramSet{{ExerciseID: exerciseID, Reps: 3, PercentageOf1RM: 70}}},
+	}
+}
+
+func TestProgramHandler_CreateProgram(t *testing.T) {
+	userID := uuid.New()
+	exerciseID := uuid.New()
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockFunc       func(ctx context.Context, userID uuid.UUID, input usecase.CreateProgramInput) (*entity.Program, error)
+		expectedStatus int
+		checkResponse  func(t *testing.T, resp ProgramResponse)
+	}{
+		{
+			name: "成功: プログラム作成",
+			requestBody: CreateProgramRequest{
+				Name: "5/3/1",
+				Days: []ProgramDayRequest{
+					{Week: 1, Day: 1, Sets: []ProgramSetRequest{{ExerciseID: exerciseID.String(), Reps: 5, PercentageOf1RM: 65}}},
+				},
+			},
+			mockFunc: func(ctx context.Context, uid uuid.UUID, input usecase.CreateProgramInput) (*entity.Program, error) {
+				days := make([]entity.ProgramDay, 0, len(input.Days))
+				for _, d := range input.Days {
+					sets := make([]entity.ProgramSet, 0, len(d.Sets))
+					for _, s := range d.Sets {
+						sets = append(sets, entity.ProgramSet{ExerciseID: s.ExerciseID, Reps: s.Reps, PercentageOf1RM: s.PercentageOf1RM})
+					}
+					days = append(days, entity.ProgramDay{Week: d.Week, Day: d.Day, Sets: sets})
+				}
+				return entity.NewProgram(uid, input.Name, input.Description, days)
+			},
+			expectedStatus: http.StatusCreated,
+			checkResponse: func(t *testing.T, resp ProgramResponse) {
+				if resp.Name != "5/3/1" || resp.CurrentDayIndex != 0 || resp.Completed {
+					t.Errorf("unexpected program: %+v", resp)
+				}
+				if len(resp.Days) != 1 || len(resp.Days[0].Sets) != 1 || resp.Days[0].Sets[0].PercentageOf1RM != 65 {
+					t.Errorf("unexpected days: %+v", resp.Days)
+				}
+			},
+		},
+		{
+			name:           "失敗: 不正なリクエストボディ",
+			requestBody:    "invalid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "失敗: 不正なエクササイズID",
+			requestBody: CreateProgramRequest{
+				Name: "5/3/1",
+				Days: []ProgramDayRequest{{Week: 1, Day: 1, Sets: []ProgramSetRequest{{ExerciseID: "invalid", Reps: 5, PercentageOf1RM: 65}}}},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "失敗: バリデーションエラー",
+			requestBody: CreateProgramRequest{
+				Name: "5/3/1",
+				Days: []ProgramDayRequest{{Week: 1, Day: 1, Sets: []ProgramSetRequest{{ExerciseID: exerciseID.String(), Reps: 5, PercentageOf1RM: 200}}}},
+			},
+			mockFunc: func(ctx context.Context, uid uuid.UUID, input usecase.CreateProgramInput) (*entity.Program, error) {
+				return nil, entity.ErrInvalidPercentageOf1RM
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "失敗: エクササイズが見つからない",
+			requestBody: CreateProgramRequest{
+				Name: "5/3/1",
+				Days: []ProgramDayRequest{{Week: 1, Day: 1, Sets: []ProgramSetRequest{{ExerciseID: exerciseID.String(), Reps: 5, PercentageOf1RM: 65}}}},
+			},
+			mockFunc: func(ctx context.Context, uid uuid.UUID, input usecase.CreateProgramInput) (*entity.Program, error) {
+				return nil, usecase.ErrExerciseNotFound
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewProgramHandler(&mockProgramUsecase{createProgramFunc: tt.mockFunc})
+
+			var body bytes.Buffer
+			if err := json.NewEncoder(&body).Encode(tt.requestBody); err != nil {
+				t.Fatal(err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/programs", &body)
+			req = req.WithContext(contextWithUserID(req.Context(), userID))
+			rec := httptest.NewRecorder()
+
+			handler.CreateProgram(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			if tt.checkResponse != nil {
+				var resp ProgramResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatal(err)
+				}
+				tt.checkResponse(t, resp)
+			}
+		})
+	}
+}
+
+func TestProgramHandler_ListPrograms(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		mockFunc       func(ctx context.Context, userID uuid.UUID) ([]*entity.Program, error)
+		expectedStatus int
+		expectedCount  int
+	}{
+		{
+			name: "成功: プログラム一覧を取得",
+			mockFunc: func(ctx context.Context, uid uuid.UUID) ([]*entity.Program, error) {
+				days := testProgramDays(uuid.New())
+				a, _ := entity.NewProgram(uid, "5/3/1", nil, days)
+				b, _ := entity.NewProgram(uid, "Linear", nil, days)
+				return []*entity.Program{a, b}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedCount:  2,
+		},
+		{
+			name: "成功: プログラムが存在しない場合は空配列",
+			mockFunc: func(ctx context.Context, uid uuid.UUID) ([]*entity.Program, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedCount:  0,
+		},
+		{
+			name: "失敗: サーバーエラー",
+			mockFunc: func(ctx context.Context, uid uuid.UUID) ([]*entity.Program, error) {
+				return nil, errors.New("db error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewProgramHandler(&mockProgramUsecase{listProgramsFunc: tt.mockFunc})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/programs", nil)
+			req = req.WithContext(contextWithUserID(req.Context(), userID))
+			rec := httptest.NewRecorder()
+
+			handler.ListPrograms(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var resp []ProgramResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatal(err)
+				}
+				if resp == nil {
+					t.Error("expected empty array, got null")
+				}
+				if len(resp) != tt.expectedCount {
+					t.Errorf("expected %d programs, got %d", tt.expectedCount, len(resp))
+				}
+			}
+		})
+	}
+}
+
+func TestProgramHandler_GetProgram(t *testing.T) {
+	userID := uuid.New()
+	programID := uuid.New()
+
+	tests := []struct {
+		name           string
+		programID      string
+		mockFunc       func(ctx context.Context, userID, programID uuid.UUID) (*entity.Program, error)
+		expectedStatus int
+		checkResponse  func(t *testing.T, resp ProgramResponse)
+	}{
+		{
+			name:      "成功: 全日程を終えたプログラム",
+			programID: programID.String(),
+			mockFunc: func(ctx context.Context, uid, pid uuid.UUID) (*entity.Program, error) {
+				return entity.ReconstructProgram(pid, uid, "5/3/1", nil, testProgramDays(uuid.New()), 2, time.Now(), time.Now()), nil
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, resp ProgramResponse) {
+				if resp.CurrentDayIndex != 2 || !resp.Completed {
+					t.Errorf("expected completed program at day 2, got %+v", resp)
+				}
+			},
+		},
+		{
+			name:           "失敗: 不正なプログラムID",
+			programID:      "invalid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "失敗: プログラムが見つからない",
+			programID: programID.String(),
+			mockFunc: func(ctx context.Context, uid, pid uuid.UUID) (*entity.Program, error) {
+				return nil, usecase.ErrProgramNotFound
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:      "失敗: アクセス権なし",
+			programID: programID.String(),
+			mockFunc: func(ctx context.Context, uid, pid uuid.UUID) (*entity.Program, error) {
+				return nil, usecase.ErrProgramAccessDenied
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewProgramHandler(&mockProgramUsecase{getProgramFunc: tt.mockFunc})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/programs/"+tt.programID, nil)
+			req = req.WithContext(contextWithUserID(req.Context(), userID))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.programID})
+			rec := httptest.NewRecorder()
+
+			handler.GetProgram(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			if tt.checkResponse != nil {
+				var resp ProgramResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatal(err)
+				}
+				tt.checkResponse(t, resp)
+			}
+		})
+	}
+}
+
+func TestProgramHandler_DeleteProgram(t *testing.T) {
+	userID := uuid.New()
+	programID := uuid.New()
+
+	tests := []struct {
+		name           string
+		programID      string
+		mockFunc       func(ctx context.Context, userID, programID uuid.UUID) error
+		expectedStatus int
+	}{
+		{
+			name:      "成功: プログラム削除",
+			programID: programID.String(),
+			mockFunc: func(ctx context.Context, uid, pid uuid.UUID) error {
+				return nil
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "失敗: 不正なプログラムID",
+			programID:      "invalid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "失敗: アクセス権なし",
+			programID: programID.String(),
+			mockFunc: func(ctx context.Context, uid, pid uuid.UUID) error {
+				return usecase.ErrProgramAccessDenied
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewProgramHandler(&mockProgramUsecase{deleteProgramFunc: tt.mockFunc})
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/programs/"+tt.programID, nil)
+			req = req.WithContext(contextWithUserID(req.Context(), userID))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.programID})
+			rec := httptest.NewRecorder()
+
+			handler.DeleteProgram(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestProgramHandler_GetCurrentProgramDay(t *testing.T) {
+	userID := uuid.New()
+	programID := uuid.New()
+	exerciseID := uuid.New()
+
+	tests := []struct {
+		name           string
+		programID      string
+		mockFunc       func(ctx context.Context, userID, programID uuid.UUID) (*usecase.ProgramDayOutput, error)
+		expectedStatus int
+		checkResponse  func(t *testing.T, resp CurrentProgramDayResponse)
+	}{
+		{
+			name:      "成功: 現在の日の処方重量を取得",
+			programID: programID.String(),
+			mockFunc: func(ctx context.Context, uid, pid uuid.UUID) (*usecase.ProgramDayOutput, error) {
+				program := entity.ReconstructProgram(pid, uid, "5/3/1", nil, testProgramDays(exerciseID), 1, time.Now(), time.Now())
+				weight := 70.0
+				return &usecase.ProgramDayOutput{
+					Program:  program,
+					DayIndex: 1,
+					Week:     1,
+					Day:      2,
+					Sets: []usecase.PrescribedSet{
+						{ExerciseID: exerciseID, SetNumber: 1, Reps: 3, PercentageOf1RM: 70, Estimated1RM: 100, Weight: &weight},
+					},
+				}, nil
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, resp CurrentProgramDayResponse) {
+				if resp.ProgramID != programID.String() || resp.DayIndex != 1 || resp.Week != 1 || resp.Day != 2 {
+					t.Errorf("unexpected day: %+v", resp)
+				}
+				if len(resp.Sets) != 1 || resp.Sets[0].Weight == nil || *resp.Sets[0].Weight != 70.0 || resp.Sets[0].Estimated1RM != 100 {
+					t.Errorf("unexpected sets: %+v", resp.Sets)
+				}
+			},
+		},
+		{
+			name:           "失敗: 不正なプログラムID",
+			programID:      "invalid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "失敗: 全日程を終えている",
+			programID: programID.String(),
+			mockFunc: func(ctx context.Context, uid, pid uuid.UUID) (*usecase.ProgramDayOutput, error) {
+				return nil, usecase.ErrProgramCompleted
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:      "失敗: プログラムが見つからない",
+			programID: programID.String(),
+			mockFunc: func(ctx context.Context, uid, pid uuid.UUID) (*usecase.ProgramDayOutput, error) {
+				return nil, usecase.ErrProgramNotFound
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewProgramHandler(&mockProgramUsecase{getCurrentProgramDayFunc: tt.mockFunc})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/programs/"+tt.programID+"/current", nil)
+			req = req.WithContext(contextWithUserID(req.Context(), userID))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.programID})
+			rec := httptest.NewRecorder()
+
+			handler.GetCurrentProgramDay(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			if tt.checkResponse != nil {
+				var resp CurrentProgramDayResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatal(err)
+				}
+				tt.checkResponse(t, resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,415 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/infrastructure/auth"
+	"github.com/ucchy108/whiskey/backend/usecase"
+)
+
+// RoutineHandler はルーティン（ワークアウトのテンプレート）関連のHTTPハンドラーを提供する。
+// Usecase層のビジネスロジックをRESTful APIとして公開する。
+type RoutineHandler struct {
+	routineUsecase usecase.RoutineUsecaseInterface
+	profileUsecase usecase.ProfileUsecaseInterface
+}
+
+// NewRoutineHandler はRoutineHandlerの新しいインスタンスを生成する。
+//
+// パラメータ:
+//   - routineUsecase: ルーティンに関するビジネスロジックを提供するユースケース
+//   - profileUsecase: 日付の解釈に使用するユーザーのタイムゾーンを提供するユースケース
+//
+// 戻り値:
+//   - *RoutineHandler: 生成されたRoutineHandlerインスタンス
+func NewRoutineHandler(routineUsecase usecase.RoutineUsecaseInterface, profileUsecase usecase.ProfileUsecaseInterface) *RoutineHandler {
+	return &RoutineHandler{
+		routineUsecase: routineUsecase,
+		profileUsecase: profileUsecase,
+	}
+}
+
+// --- リクエスト/レスポンスDTO ---
+
+// RoutineExerciseRequest はルーティンに含める種目の目標のリクエストボディ
+type RoutineExerciseRequest struct {
+	ExerciseID   string  `json:"exercise_id"`
+	TargetSets   int32   `json:"target_sets"`
+	TargetReps   int32   `json:"target_reps"`
+	TargetWeight float64 `json:"target_weight"`
+}
+
+// CreateRoutineRequest はルーティン作成APIのリクエストボディ
+type CreateRoutineRequest struct {
+	Name        string                   `json:"name"`
+	Description *string                  `json:"description"`
+	Exercises   []RoutineExerciseRequest `json:"exercises"`
+}
+
+// UpdateRoutineRequest はルーティン更新APIのリクエストボディ。
+// 省略（null）したフィールドは更新しない。exercisesを指定した場合は種目一覧全体を置き換える。
+type UpdateRoutineRequest struct {
+	Name        *string                  `json:"name"`
+	Description *string                  `json:"description"`
+	Exercises   []RoutineExerciseRequest `json:"exercises"`
+}
+
+// StartRoutineRequest はルーティン開始APIのリクエストボディ
+type StartRoutineRequest struct {
+	Date string  `json:"date"`
+	Memo *string `json:"memo"`
+}
+
+// RoutineExerciseResponse はルーティンに含まれる種目の目標のレスポンスボディ
+type RoutineExerciseResponse struct {
+	ExerciseID   string  `json:"exercise_id"`
+	TargetSets   int32   `json:"target_sets"`
+	TargetReps   int32   `json:"target_reps"`
+	TargetWeight float64 `json:"target_weight"`
+}
+
+// RoutineResponse はルーティンのレスポンスボディ
+type RoutineResponse struct {
+	ID          string                    `json:"id"`
+	UserID      string                    `json:"user_id"`
+	Name        string                    `json:"name"`
+	Description *string                   `json:"description"`
+	Exercises   []RoutineExerciseResponse `json:"exercises"`
+	CreatedAt   string                    `json:"created_at"`
+	UpdatedAt   string                    `json:"updated_at"`
+}
+
+// --- ハンドラーメソッド ---
+
+// CreateRoutine は新しいルーティンを作成する。
+// POST /api/routines
+//
+// リクエストボディ:
+//
+//	{
+//	  "name": "Push",
+//	  "description": "Chest and triceps",
+//	  "exercises": [{"exercise_id": "...", "target_sets": 5, "target_reps": 5, "target_weight": 80.0}]
+//	}
+//
+// レスポンス:
+//   - 201 Created: 作成成功
+//   - 400 Bad Request: リクエストボディが不正、バリデーションエラー
+//   - 404 Not Found: エクササイズが見つからない
+//   - 500 Internal Server Error: サーバーエラー
+func (h *RoutineHandler) CreateRoutine(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	var req CreateRoutineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	exercises, err := toRoutineExerciseInputs(req.Exercises)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid exercise ID")
+		return
+	}
+
+	input := usecase.CreateRoutineInput{
+		Name:        req.Name,
+		Description: req.Description,
+		Exercises:   exercises,
+	}
+
+	routine, err := h.routineUsecase.CreateRoutine(r.Context(), userID, input)
+	if err != nil {
+		handleRoutineUsecaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, toRoutineResponse(routine))
+}
+
+// ListRoutines はログインユーザーのルーティン一覧を取得する。
+// GET /api/routines
+//
+// レスポンス:
+//   - 200 OK: 取得成功
+//   - 500 Internal Server Error: サーバーエラー
+func (h *RoutineHandler) ListRoutines(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	routines, err := h.routineUsecase.ListRoutines(r.Context(), userID)
+	if err != nil {
+		handleRoutineUsecaseError(w, err)
+		return
+	}
+
+	resp := make([]RoutineResponse, 0, len(routines))
+	for _, routine := range routines {
+		resp = append(resp, toRoutineResponse(routine))
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// GetRoutine はルーティンの詳細を取得する。
+// GET /api/routines/{id}
+//
+// パスパラメータ:
+//   - id: ルーティンID (UUID)
+//
+// レスポンス:
+//   - 200 OK: 取得成功
+//   - 400 Bad Request: ルーティンIDが不正
+//   - 403 Forbidden: アクセス権がない
+//   - 404 Not Found: ルーティンが見つからない
+//   - 500 Internal Server Error: サーバーエラー
+func (h *RoutineHandler) GetRoutine(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	vars := mux.Vars(r)
+	routineID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid routine ID")
+		return
+	}
+
+	routine, err := h.routineUsecase.GetRoutine(r.Context(), userID, routineID)
+	if err != nil {
+		handleRoutineUsecaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toRoutineResponse(routine))
+}
+
+// UpdateRoutine はルーティンを更新する。
+// PUT /api/routines/{id}
+//
+// パスパラメータ:
+//   - id: ルーティンID (UUID)
+//
+// リクエストボディ（省略したフィールドは変更なし）:
+//
+//	{
+//	  "name": "Push A",
+//	  "exercises": [{"exercise_id": "...", "target_sets": 3, "target_reps": 8, "target_weight": 85.0}]
+//	}
+//
+// レスポンス:
+//   - 200 OK: 更新成功
+//   - 400 Bad Request: リクエストが不正、バリデーションエラー
+//   - 403 Forbidden: アクセス権がない
+//   - 404 Not Found: ルーティンまたはエクササイズが見つからない
+//   - 500 Internal Server Error: サーバーエラー
+func (h *RoutineHandler) UpdateRoutine(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	vars := mux.Vars(r)
+	routineID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid routine ID")
+		return
+	}
+
+	var req UpdateRoutineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	input := usecase.UpdateRoutineInput{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	if req.Exercises != nil {
+		exercises, err := toRoutineExerciseInputs(req.Exercises)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid exercise ID")
+			return
+		}
+		input.Exercises = exercises
+	}
+
+	routine, err := h.routineUsecase.UpdateRoutine(r.Context(), userID, routineID, input)
+	if err != nil {
+		handleRoutineUsecaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toRoutineResponse(routine))
+}
+
+// DeleteRoutine はルーティンを削除する。
+// DELETE /api/routines/{id}
+//
+// パスパラメータ:
+//   - id: ルーティンID (UUID)
+//
+// レスポンス:
+//   - 204 No Content: 削除成功
+//   - 400 Bad Request: ルーティンIDが不正
+//   - 403 Forbidden: アクセス権がない
+//   - 404 Not Found: ルーティンが見つからない
+//   - 500 Internal Server Error: サーバーエラー
+func (h *RoutineHandler) DeleteRoutine(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	vars := mux.Vars(r)
+	routineID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid routine ID")
+		return
+	}
+
+	if err := h.routineUsecase.DeleteRoutine(r.Context(), userID, routineID); err != nil {
+		handleRoutineUsecaseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// StartRoutine はルーティンの目標からセットを組み立て、指定日のワークアウトとして記録する。
+// POST /api/routines/{id}/start
+//
+// パスパラメータ:
+//   - id: ルーティンID (UUID)
+//
+// リクエストボディ:
+//
+//	{
+//	  "date": "2026-01-15T00:00:00Z",
+//	  "memo": "Push day"
+//	}
+//
+// レスポンス:
+//   - 201 Created: 記録成功（レスポンス形式は POST /api/workouts と同じ）
+//   - 400 Bad Request: リクエストボディが不正
+//   - 403 Forbidden: アクセス権がない
+//   - 404 Not Found: ルーティンまたはエクササイズが見つからない
+//   - 500 Internal Server Error: サーバーエラー
+func (h *RoutineHandler) StartRoutine(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	vars := mux.Vars(r)
+	routineID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid routine ID")
+		return
+	}
+
+	var req StartRoutineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	loc, err := h.profileUsecase.GetLocation(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	date, err := parseDate(req.Date, loc)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid date format, expected YYYY-MM-DD or RFC3339")
+		return
+	}
+
+	output, err := h.routineUsecase.StartRoutine(r.Context(), userID, routineID, date, req.Memo)
+	if err != nil {
+		handleRoutineUsecaseError(w, err)
+		return
+	}
+
+	resp := RecordWorkoutResponse{
+		Workout:         toWorkoutResponse(output.Workout),
+		Sets:            toWorkoutSetResponses(output.Sets),
+		PersonalRecords: toPersonalRecordResponses(output.PersonalRecords),
+	}
+
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+// --- ヘルパー関数 ---
+
+// handleRoutineUsecaseError はRoutine Usecase層のエラーを適切なHTTPステータスコードに変換する。
+// ルーティン開始時にWorkout Usecase層から返されるエラーはhandleWorkoutUsecaseErrorに委譲する。
+func handleRoutineUsecaseError(w http.ResponseWriter, err error) {
+	switch err {
+	case usecase.ErrRoutineNotFound:
+		respondError(w, http.StatusNotFound, "Routine not found")
+	case usecase.ErrRoutineAccessDenied:
+		respondError(w, http.StatusForbidden, "Access denied to this routine")
+	default:
+		if isRoutineValidationError(err) {
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		handleWorkoutUsecaseError(w, err)
+	}
+}
+
+// isRoutineValidationError はルーティン関連のバリデーションエラーかどうかを判定する。
+func isRoutineValidationError(err error) bool {
+	errMsg := err.Error()
+	validationErrors := []string{
+		"routine name must be",
+		"routine must have",
+		"target sets must be",
+		"target reps must be",
+		"target weight must be",
+	}
+
+	for _, ve := range validationErrors {
+		if len(errMsg) >= len(ve) && errMsg[:len(ve)] == ve {
+			return true
+		}
+	}
+	return false
+}
+
+// toRoutineExerciseInputs はRoutineExerciseRequestのスライスをusecase.RoutineExerciseInputのスライスに変換する。
+func toRoutineExerciseInputs(reqs []RoutineExerciseRequest) ([]usecase.RoutineExerciseInput, error) {
+	inputs := make([]usecase.RoutineExerciseInput, 0, len(reqs))
+	for _, re := range reqs {
+		exerciseID, err := uuid.Parse(re.ExerciseID)
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, usecase.RoutineExerciseInput{
+			ExerciseID:   exerciseID,
+			TargetSets:   re.TargetSets,
+			TargetReps:   re.TargetReps,
+			TargetWeight: re.TargetWeight,
+		})
+	}
+	return inputs, nil
+}
+
+// toRoutineResponse はRoutineエンティティをRoutineResponseに変換する。
+func toRoutineResponse(routine *entity.Routine) RoutineResponse {
+	exercises := make([]RoutineExerciseResponse, 0, len(routine.Exercises))
+	for _, re := range routine.Exercises {
+		exercises = append(exercises, RoutineExerciseResponse{
+			ExerciseID:   re.ExerciseID.String(),
+			TargetSets:   re.TargetSets,
+			TargetReps:   re.TargetReps,
+			TargetWeight: re.TargetWeight,
+		})
+	}
+
+	return RoutineResponse{
+		ID:          routine.ID.String(),
+		UserID:      routine.UserID.String(),
+		Name:        routine.Name,
+		Description: routine.Description,
+		Exercises:   exercises,
+		CreatedAt:   routine.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   routine.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,550 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/usecase"
+)
+
+// mockRoutineUsecase はRoutineUsecaseのモック実装
+type mockRoutineUsecase struct {
+	createRoutineFunc func(ctx context.Context, userID uuid.UUID, input usecase.CreateRoutineInput) (*entity.Routine, error)
+	getRoutineFunc    func(ctx context.Context, userID, routineID uuid.UUID) (*entity.Routine, error)
+	listRoutinesFunc  func(ctx context.Context, userID uuid.UUID) ([]*entity.Routine, error)
+	updateRoutineFunc func(ctx context.Context, userID, routineID uuid.UUID, input usecase.UpdateRoutineInput) (*entity.Routine, error)
+	deleteRoutineFunc func(ctx context.Context, userID, routineID uuid.UUID) error
+	startRoutineFunc  func(ctx context.Context, userID, routineID uuid.UUID, date time.Time, memo *string) (*usecase.RecordWorkoutOutput, error)
+}
+
+func (m *mockRoutineUsecase) CreateRoutine(ctx context.Context, userID uuid.UUID, input usecase.CreateRoutineInput) (*entity.Routine, error) {
+	if m.createRoutineFunc != nil {
+		return m.createRoutineFunc(ctx, userID, input)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockRoutineUsecase) GetRoutine(ctx context.Context, userID, routineID uuid.UUID) (*entity.Routine, error) {
+	if m.getRoutineFunc != nil {
+		return m.getRoutineFunc(ctx, userID, routineID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockRoutineUsecase) ListRoutines(ctx context.Context, userID uuid.UUID) ([]*entity.Routine, error) {
+	if m.listRoutinesFunc != nil {
+		return m.listRoutinesFunc(ctx, userID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockRoutineUsecase) UpdateRoutine(ctx context.Context, userID, routineID uuid.UUID, input usecase.UpdateRoutineInput) (*entity.Routine, error) {
+	if m.updateRoutineFunc != nil {
+		return m.updateRoutineFunc(ctx, userID, routineID, input)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockRoutineUsecase) DeleteRoutine(ctx context.Context, userID, routineID uuid.UUID) error {
+	if m.deleteRoutineFunc != nil {
+		return m.deleteRoutineFunc(ctx, userID, routineID)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockRoutineUsecase) StartRoutine(ctx context.Context, userID, routineID uuid.UUID, date time.Time, memo *string) (*usecase.RecordWorkoutOutput, error) {
+	if m.startRoutineFunc != nil {
+		return m.startRoutineFunc(ctx, userID, routineID, date, memo)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func TestRoutineHandler_CreateRoutine(t *testing.T) {
+	userID := uuid.New()
+	exerciseID := uuid.New()
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockFunc       func(ctx context.Context, userID uuid.UUID, input usecase.CreateRoutineInput) (*entity.Routine, error)
+		expectedStatus int
+		checkResponse  func(t *testing.T, resp RoutineResponse)
+	}{
+		{
+			name: "成功: ルーティン作成",
+			requestBody: CreateRoutineRequest{
+				Name: "Push",
+				Exercises: []RoutineExerciseRequest{
+					{ExerciseID: exerciseID.String(), TargetSets: 5, TargetReps: 5, TargetWeight: 80.0},
+				},
+			},
+			mockFunc: func(ctx context.Context, uid uuid.UUID, input usecase.CreateRoutineInput) (*entity.Routine, error) {
+				exercises := make([]entity.RoutineExercise, 0, len(input.Exercises))
+				for _, in := range input.Exercises {
+					exercises = append(exercises, entity.RoutineExercise{ExerciseID: in.ExerciseID, TargetSets: in.TargetSets, TargetReps: in.TargetReps, TargetWeight: in.TargetWeight})
+				}
+				return entity.NewRoutine(uid, input.Name, input.Description, exercises)
+			},
+			expectedStatus: http.StatusCreated,
+			checkResponse: func(t *testing.T, resp RoutineResponse) {
+				if resp.Name != "Push" {
+					t.Errorf("expected name Push, got %s", resp.Name)
+				}
+				if len(resp.Exercises) != 1 || resp.Exercises[0].ExerciseID != exerciseID.String() || resp.Exercises[0].TargetWeight != 80.0 {
+					t.Errorf("unexpected exercises: %+v", resp.Exercises)
+				}
+			},
+		},
+		{
+			name:           "失敗: 不正なリクエストボディ",
+			requestBody:    "invalid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "失敗: 不正なエクササイズID",
+			requestBody: CreateRoutineRequest{
+				Name:      "Push",
+				Exercises: []RoutineExerciseRequest{{ExerciseID: "invalid", TargetSets: 3, TargetReps: 10}},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "失敗: バリデーションエラー",
+			requestBody: CreateRoutineRequest{
+				Name:      "Push",
+				Exercises: []RoutineExerciseRequest{{ExerciseID: exerciseID.String(), TargetSets: 0, TargetReps: 10}},
+			},
+			mockFunc: func(ctx context.Context, uid uuid.UUID, input usecase.CreateRoutineInput) (*entity.Routine, error) {
+				return nil, entity.ErrInvalidTargetSets
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "失敗: エクササイズが見つからない",
+			requestBody: CreateRoutineRequest{
+				Name:      "Push",
+				Exercises: []RoutineExerciseRequest{{ExerciseID: exerciseID.String(), TargetSets: 3, TargetReps: 10}},
+			},
+			mockFunc: func(ctx context.Context, uid uuid.UUID, input usecase.CreateRoutineInput) (*entity.Routine, error) {
+				return nil, usecase.ErrExerciseNotFound
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewRoutineHandler(&mockRoutineUsecase{createRoutineFunc: tt.mockFunc}, &mockProfileUsecase{})
+
+			var body bytes.Buffer
+			if err := json.NewEncoder(&body).Encode(tt.requestBody); err != nil {
+				t.Fatal(err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/routines", &body)
+			req = req.WithContext(contextWithUserID(req.Context(), userID))
+			rec := httptest.NewRecorder()
+
+			handler.CreateRoutine(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			if tt.checkResponse != nil {
+				var resp RoutineResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatal(err)
+				}
+				tt.checkResponse(t, resp)
+			}
+		})
+	}
+}
+
+func TestRoutineHandler_ListRoutines(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		mockFunc       func(ctx context.Context, userID uuid.UUID) ([]*entity.Routine, error)
+		expectedStatus int
+		expectedCount  int
+	}{
+		{
+			name: "成功: ルーティン一覧を取得",
+			mockFunc: func(ctx context.Context, uid uuid.UUID) ([]*entity.Routine, error) {
+				exercises := []entity.RoutineExercise{{ExerciseID: uuid.New(), TargetSets: 3, TargetReps: 10}}
+				push, _ := entity.NewRoutine(uid, "Push", nil, exercises)
+				pull, _ := entity.NewRoutine(uid, "Pull", nil, exercises)
+				return []*entity.Routine{push, pull}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedCount:  2,
+		},
+		{
+			name: "成功: ルーティンが存在しない場合は空配列",
+			mockFunc: func(ctx context.Context, uid uuid.UUID) ([]*entity.Routine, error) {
+				return nil, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedCount:  0,
+		},
+		{
+			name: "失敗: サーバーエラー",
+			mockFunc: func(ctx context.Context, uid uuid.UUID) ([]*entity.Routine, error) {
+				return nil, errors.New("db error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewRoutineHandler(&mockRoutineUsecase{listRoutinesFunc: tt.mockFunc}, &mockProfileUsecase{})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/routines", nil)
+			req = req.WithContext(contextWithUserID(req.Context(), userID))
+			rec := httptest.NewRecorder()
+
+			handler.ListRoutines(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			if tt.expectedStatus == http.StatusOK {
+				var resp []RoutineResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatal(err)
+				}
+				if resp == nil {
+					t.Error("expected empty array, got null")
+				}
+				if len(resp) != tt.expectedCount {
+					t.Errorf("expected %d routines, got %d", tt.expectedCount, len(resp))
+				}
+			}
+		})
+	}
+}
+
+func TestRoutineHandler_GetRoutine(t *testing.T) {
+	userID := uuid.New()
+	routineID := uuid.New()
+
+	tests := []struct {
+		name           string
+		routineID      string
+		mockFunc       func(ctx context.Context, userID, routineID uuid.UUID) (*entity.Routine, error)
+		expectedStatus int
+	}{
+		{
+			name:      "成功: ルーティン詳細を取得",
+			routineID: routineID.String(),
+			mockFunc: func(ctx context.Context, uid, rid uuid.UUID) (*entity.Routine, error) {
+				return entity.ReconstructRoutine(rid, uid, "Push", nil, []entity.RoutineExercise{{ExerciseID: uuid.New(), TargetSets: 3, TargetReps: 10}}, time.Now(), time.Now()), nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "失敗: 不正なルーティンID",
+			routineID:      "invalid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "失敗: ルーティンが見つからない",
+			routineID: routineID.String(),
+			mockFunc: func(ctx context.Context, uid, rid uuid.UUID) (*entity.Routine, error) {
+				return nil, usecase.ErrRoutineNotFound
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:      "失敗: アクセス権なし",
+			routineID: routineID.String(),
+			mockFunc: func(ctx context.Context, uid, rid uuid.UUID) (*entity.Routine, error) {
+				return nil, usecase.ErrRoutineAccessDenied
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewRoutineHandler(&mockRoutineUsecase{getRoutineFunc: tt.mockFunc}, &mockProfileUsecase{})
+
+			req := httptest.NewRequest(http.MethodGet, "/api/routines/"+tt.routineID, nil)
+			req = req.WithContext(contextWithUserID(req.Context(), userID))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.routineID})
+			rec := httptest.NewRecorder()
+
+			handler.GetRoutine(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestRoutineHandler_UpdateRoutine(t *testing.T) {
+	userID := uuid.New()
+	routineID := uuid.New()
+	exerciseID := uuid.New()
+
+	tests := []struct {
+		name           string
+		routineID      string
+		requestBody    interface{}
+		mockFunc       func(ctx context.Context, userID, routineID uuid.UUID, input usecase.UpdateRoutineInput) (*entity.Routine, error)
+		expectedStatus int
+	}{
+		{
+			name:        "成功: 名前のみ更新（種目は指定なし）",
+			routineID:   routineID.String(),
+			requestBody: map[string]interface{}{"name": "Push A"},
+			mockFunc: func(ctx context.Context, uid, rid uuid.UUID, input usecase.UpdateRoutineInput) (*entity.Routine, error) {
+				if input.Exercises != nil {
+					t.Errorf("expected nil exercises, got %+v", input.Exercises)
+				}
+				return entity.ReconstructRoutine(rid, uid, *input.Name, nil, []entity.RoutineExercise{{ExerciseID: exerciseID, TargetSets: 3, TargetReps: 10}}, time.Now(), time.Now()), nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:      "成功: 種目を置き換え",
+			routineID: routineID.String(),
+			requestBody: UpdateRoutineRequest{
+				Exercises: []RoutineExerciseRequest{{ExerciseID: exerciseID.String(), TargetSets: 4, TargetReps: 8, TargetWeight: 85.0}},
+			},
+			mockFunc: func(ctx context.Context, uid, rid uuid.UUID, input usecase.UpdateRoutineInput) (*entity.Routine, error) {
+				if len(input.Exercises) != 1 || input.Exercises[0].TargetSets != 4 {
+					t.Errorf("unexpected exercises: %+v", input.Exercises)
+				}
+				return entity.ReconstructRoutine(rid, uid, "Push", nil, []entity.RoutineExercise{{ExerciseID: exerciseID, TargetSets: 4, TargetReps: 8, TargetWeight: 85.0}}, time.Now(), time.Now()), nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "失敗: 不正なルーティンID",
+			routineID:      "invalid",
+			requestBody:    UpdateRoutineRequest{},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "失敗: 種目が空",
+			routineID: routineID.String(),
+			requestBody: UpdateRoutineRequest{
+				Exercises: []RoutineExerciseRequest{},
+			},
+			mockFunc: func(ctx context.Context, uid, rid uuid.UUID, input usecase.UpdateRoutineInput) (*entity.Routine, error) {
+				return nil, entity.ErrEmptyRoutineExercises
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "失敗: アクセス権なし",
+			routineID:   routineID.String(),
+			requestBody: UpdateRoutineRequest{Name: strPtr("Mine")},
+			mockFunc: func(ctx context.Context, uid, rid uuid.UUID, input usecase.UpdateRoutineInput) (*entity.Routine, error) {
+				return nil, usecase.ErrRoutineAccessDenied
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewRoutineHandler(&mockRoutineUsecase{updateRoutineFunc: tt.mockFunc}, &mockProfileUsecase{})
+
+			var body bytes.Buffer
+			if err := json.NewEncoder(&body).Encode(tt.requestBody); err != nil {
+				t.Fatal(err)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/api/routines/"+tt.routineID, &body)
+			req = req.WithContext(contextWithUserID(req.Context(), userID))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.routineID})
+			rec := httptest.NewRecorder()
+
+			handler.UpdateRoutine(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestRoutineHandler_DeleteRoutine(t *testing.T) {
+	userID := uuid.New()
+	routineID := uuid.New()
+
+	tests := []struct {
+		name           string
+		routineID      string
+		mockFunc       func(ctx context.Context, userID, routineID uuid.UUID) error
+		expectedStatus int
+	}{
+		{
+			name:      "成功: ルーティン削除",
+			routineID: routineID.String(),
+			mockFunc: func(ctx context.Context, uid, rid uuid.UUID) error {
+				return nil
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "失敗: 不正なルーティンID",
+			routineID:      "invalid",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:      "失敗: ルーティンが見つからない",
+			routineID: routineID.String(),
+			mockFunc: func(ctx context.Context, uid, rid uuid.UUID) error {
+				return usecase.ErrRoutineNotFound
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewRoutineHandler(&mockRoutineUsecase{deleteRoutineFunc: tt.mockFunc}, &mockProfileUsecase{})
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/routines/"+tt.routineID, nil)
+			req = req.WithContext(contextWithUserID(req.Context(), userID))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.routineID})
+			rec := httptest.NewRecorder()
+
+			handler.DeleteRoutine(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestRoutineHandler_StartRoutine(t *testing.T) {
+	userID := uuid.New()
+	routineID := uuid.New()
+	exerciseID := uuid.New()
+	workoutDate := "2026-01-15T00:00:00Z"
+
+	tests := []struct {
+		name           string
+		routineID      string
+		requestBody    interface{}
+		location       *time.Location
+		mockFunc       func(ctx context.Context, userID, routineID uuid.UUID, date time.Time, memo *string) (*usecase.RecordWorkoutOutput, error)
+		expectedStatus int
+		checkResponse  func(t *testing.T, resp RecordWorkoutResponse)
+	}{
+		{
+			name:        "成功: ルーティンからワークアウトを記録",
+			routineID:   routineID.String(),
+			requestBody: StartRoutineRequest{Date: workoutDate, Memo: strPtr("Push day")},
+			mockFunc: func(ctx context.Context, uid, rid uuid.UUID, date time.Time, memo *string) (*usecase.RecordWorkoutOutput, error) {
+				if rid != routineID {
+					t.Errorf("expected routine %v, got %v", routineID, rid)
+				}
+				workout := entity.NewWorkout(uid, date)
+				workout.UpdateMemo(memo)
+				set1, _ := entity.NewWorkoutSet(workout.ID, exerciseID, 1, 5, 80.0)
+				set2, _ := entity.NewWorkoutSet(workout.ID, exerciseID, 2, 5, 80.0)
+				return &usecase.RecordWorkoutOutput{
+					Workout: workout,
+					Sets:    []*entity.WorkoutSet{set1, set2},
+				}, nil
+			},
+			expectedStatus: http.StatusCreated,
+			checkResponse: func(t *testing.T, resp RecordWorkoutResponse) {
+				if resp.Workout.Memo == nil || *resp.Workout.Memo != "Push day" {
+					t.Errorf("unexpected memo: %v", resp.Workout.Memo)
+				}
+				if len(resp.Sets) != 2 {
+					t.Errorf("expected 2 sets, got %d", len(resp.Sets))
+				}
+				if resp.PersonalRecords == nil {
+					t.Error("expected personal_records to be an empty array, got null")
+				}
+			},
+		},
+		{
+			name:           "失敗: 不正なルーティンID",
+			routineID:      "invalid",
+			requestBody:    StartRoutineRequest{Date: workoutDate},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "成功: YYYY-MM-DD形式の日付をユーザーのタイムゾーンで解釈",
+			routineID:   routineID.String(),
+			requestBody: StartRoutineRequest{Date: "2026-01-15"},
+			location:    time.FixedZone("JST", 9*60*60),
+			mockFunc: func(ctx context.Context, uid, rid uuid.UUID, date time.Time, memo *string) (*usecase.RecordWorkoutOutput, error) {
+				if date.Format(time.RFC3339) != "2026-01-15T00:00:00+09:00" {
+					t.Errorf("expected date 2026-01-15T00:00:00+09:00, got %v", date.Format(time.RFC3339))
+				}
+				return &usecase.RecordWorkoutOutput{Workout: entity.NewWorkout(uid, date)}, nil
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "失敗: 不正な日付形式",
+			routineID:      routineID.String(),
+			requestBody:    StartRoutineRequest{Date: "2026/01/15"},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "失敗: ルーティンが見つからない",
+			routineID:   routineID.String(),
+			requestBody: StartRoutineRequest{Date: workoutDate},
+			mockFunc: func(ctx context.Context, uid, rid uuid.UUID, date time.Time, memo *string) (*usecase.RecordWorkoutOutput, error) {
+				return nil, usecase.ErrRoutineNotFound
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewRoutineHandler(&mockRoutineUsecase{startRoutineFunc: tt.mockFunc}, newMockProfileUsecaseWithLocation(tt.location))
+
+			var body bytes.Buffer
+			if err := json.NewEncoder(&body).Encode(tt.requestBody); err != nil {
+				t.Fatal(err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/routines/"+tt.routineID+"/start", &body)
+			req = req.WithContext(contextWithUserID(req.Context(), userID))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.routineID})
+			rec := httptest.NewRecorder()
+
+			handler.StartRoutine(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			if tt.checkResponse != nil {
+				var resp RecordWorkoutResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatal(err)
+				}
+				tt.checkResponse(t, resp)
+			}
+		})
+	}
+}
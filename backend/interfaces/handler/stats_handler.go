@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ucchy108/whiskey/backend/infrastructure/auth"
+	"github.com/ucchy108/whiskey/backend/usecase"
+)
+
+// StatsHandler はワークアウトの継続状況に関するHTTPハンドラーを提供する。
+// Usecase層のビジネスロジックをRESTful APIとして公開する。
+type StatsHandler struct {
+	statsUsecase usecase.StatsUsecaseInterface
+}
+
+// NewStatsHandler はStatsHandlerの新しいインスタンスを生成する。
+//
+// パラメータ:
+//   - statsUsecase: 連続記録・週の目標に関するビジネスロジックを提供するユースケース
+//
+// 戻り値:
+//   - *StatsHandler: 生成されたStatsHandlerインスタンス
+func NewStatsHandler(statsUsecase usecase.StatsUsecaseInterface) *StatsHandler {
+	return &StatsHandler{
+		statsUsecase: statsUsecase,
+	}
+}
+
+// --- レスポンスDTO ---
+
+// StreakResponse は連続記録の現在値と最長記録のレスポンスボディ。
+type StreakResponse struct {
+	Current int `json:"current"`
+	Longest int `json:"longest"`
+}
+
+// WeeklyGoalProgressResponse は今週の目標の進捗のレスポンスボディ。
+type WeeklyGoalProgressResponse struct {
+	WeekStart   string `json:"week_start"`
+	WorkoutDays int    `json:"workout_days"`
+	Goal        int    `json:"goal"`
+	Remaining   int    `json:"remaining"`
+	Achieved    bool   `json:"achieved"`
+}
+
+// StreakStatsResponse は連続記録と今週の目標の進捗のレスポンスボディ。
+// last_workout_date はワークアウトの記録がない場合はnullとなる。
+type StreakStatsResponse struct {
+	Daily            StreakResponse             `json:"daily"`
+	Weekly           StreakResponse             `json:"weekly"`
+	RestDayTolerance int32                      `json:"rest_day_tolerance"`
+	ThisWeek         WeeklyGoalProgressResponse `json:"this_week"`
+	LastWorkoutDate  *string                    `json:"last_workout_date"`
+}
+
+// --- ハンドラーメソッド ---
+
+// GetStreaks は日単位・週単位の連続記録と今週の目標の進捗を取得する。
+// GET /api/stats/streaks
+//
+// レスポンス:
+//   - 200 OK: 取得成功
+//   - 500 Internal Server Error: サーバーエラー
+func (h *StatsHandler) GetStreaks(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	stats, err := h.statsUsecase.GetStreaks(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toStreakStatsResponse(stats))
+}
+
+// --- ヘルパー関数 ---
+
+// toStreakStatsResponse はStreakStatsをStreakStatsResponseに変換する。
+func toStreakStatsResponse(stats *usecase.StreakStats) StreakStatsResponse {
+	resp := StreakStatsResponse{
+		Daily: StreakResponse{
+			Current: stats.CurrentDailyStreak,
+			Longest: stats.LongestDailyStreak,
+		},
+		Weekly: StreakResponse{
+			Current: stats.CurrentWeeklyStreak,
+			Longest: stats.LongestWeeklyStreak,
+		},
+		RestDayTolerance: stats.RestDayTolerance,
+		ThisWeek: WeeklyGoalProgressResponse{
+			WeekStart:   stats.ThisWeek.WeekStart.Format(time.DateOnly),
+			WorkoutDays: stats.ThisWeek.WorkoutDays,
+			Goal:        stats.ThisWeek.Goal,
+			Remaining:   stats.ThisWeek.Remaining,
+			Achieved:    stats.ThisWeek.Achieved,
+		},
+	}
+	if stats.LastWorkoutDate != nil {
+		date := stats.LastWorkoutDate.Format(time.DateOnly)
+		resp.LastWorkoutDate = &date
+	}
+	return resp
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/service"
+	"github.com/ucchy108/whiskey/backend/usecase"
+)
+
+// mockStatsUsecase はStatsUsecaseのモック実装
+type mockStatsUsecase struct {
+	getStreaksFunc func(ctx context.Context, userID uuid.UUID) (*usecase.StreakStats, error)
+}
+
+func (m *mockStatsUsecase) GetStreaks(ctx context.Context, userID uuid.UUID) (*usecase.StreakStats, error) {
+	if m.getStreaksFunc != nil {
+		return m.getStreaksFunc(ctx, userID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func TestStatsHandler_GetStreaks(t *testing.T) {
+	lastWorkout := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		mockFunc       func(ctx context.Context, userID uuid.UUID) (*usecase.StreakStats, error)
+		expectedStatus int
+		checkResponse  func(t *testing.T, resp StreakStatsResponse)
+	}{
+		{
+			name: "成功: 連続記録と今週の進捗を取得",
+			mockFunc: func(ctx context.Context, userID uuid.UUID) (*usecase.StreakStats, error) {
+				return &usecase.StreakStats{
+					StreakSummary: service.StreakSummary{
+						CurrentDailyStreak:  4,
+						LongestDailyStreak:  10,
+						CurrentWeeklyStreak: 2,
+						LongestWeeklyStreak: 5,
+						ThisWeek: service.WeeklyGoalProgress{
+							WeekStart:   time.Date(2026, 2, 9, 0, 0, 0, 0, time.UTC),
+							WorkoutDays: 2,
+							Goal:        3,
+							Remaining:   1,
+						},
+						LastWorkoutDate: &lastWorkout,
+					},
+					RestDayTolerance: 1,
+				}, nil
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, resp StreakStatsResponse) {
+				if resp.Daily.Current != 4 || resp.Daily.Longest != 10 || resp.Weekly.Current != 2 || resp.Weekly.Longest != 5 {
+					t.Errorf("unexpected streaks: daily %+v, weekly %+v", resp.Daily, resp.Weekly)
+				}
+				if resp.RestDayTolerance != 1 {
+					t.Errorf("expected rest_day_tolerance 1, got %d", resp.RestDayTolerance)
+				}
+				want := WeeklyGoalProgressResponse{WeekStart: "2026-02-09", WorkoutDays: 2, Goal: 3, Remaining: 1}
+				if resp.ThisWeek != want {
+					t.Errorf("expected this_week %+v, got %+v", want, resp.ThisWeek)
+				}
+				if resp.LastWorkoutDate == nil || *resp.LastWorkoutDate != "2026-02-10" {
+					t.Errorf("expected last_workout_date 2026-02-10, got %v", resp.LastWorkoutDate)
+				}
+			},
+		},
+		{
+			name: "成功: ワークアウトなし",
+			mockFunc: func(ctx context.Context, userID uuid.UUID) (*usecase.StreakStats, error) {
+				return &usecase.StreakStats{
+					StreakSummary: service.StreakSummary{
+						ThisWeek: service.WeeklyGoalProgress{
+							WeekStart: time.Date(2026, 2, 9, 0, 0, 0, 0, time.UTC),
+							Goal:      3,
+							Remaining: 3,
+						},
+					},
+					RestDayTolerance: 1,
+				}, nil
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, resp StreakStatsResponse) {
+				if resp.Daily.Current != 0 || resp.Weekly.Current != 0 {
+					t.Errorf("expected no streaks, got daily %+v, weekly %+v", resp.Daily, resp.Weekly)
+				}
+				if resp.LastWorkoutDate != nil {
+					t.Errorf("expected last_workout_date to be null, got %v", *resp.LastWorkoutDate)
+				}
+			},
+		},
+		{
+			name: "失敗: サーバーエラー",
+			mockFunc: func(ctx context.Context, userID uuid.UUID) (*usecase.StreakStats, error) {
+				return nil, errors.New("database error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockStatsUsecase{getStreaksFunc: tt.mockFunc}
+			h := NewStatsHandler(mock)
+
+			req := httptest.NewRequest("GET", "/api/stats/streaks", nil)
+			req = setAuthContext(req, uuid.New())
+			rec := httptest.NewRecorder()
+
+			h.GetStreaks(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tt.checkResponse != nil {
+				var resp StreakStatsResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				tt.checkResponse(t, resp)
+			}
+		})
+	}
+}
@@ -2,11 +2,17 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"net"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
 	"github.com/ucchy108/whiskey/backend/infrastructure/auth"
+	"github.com/ucchy108/whiskey/backend/infrastructure/ratelimit"
 	"github.com/ucchy108/whiskey/backend/usecase"
 )
 
@@ -43,8 +49,9 @@ type RegisterResponse struct {
 
 // LoginRequest はログインAPIのリクエストボディ
 type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email      string `json:"email"`
+	Password   string `json:"password"`
+	RememberMe bool   `json:"remember_me"`
 }
 
 // LoginResponse はログインAPIのレスポンスボディ
@@ -53,6 +60,50 @@ type LoginResponse struct {
 	Email string `json:"email"`
 }
 
+// TwoFactorChallengeResponse は二要素認証が必要な場合のログインAPIのレスポンスボディ
+type TwoFactorChallengeResponse struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token"`
+	ExpiresIn         int    `json:"expires_in"`
+}
+
+// VerifyTwoFactorRequest は二要素認証のコード検証APIのリクエストボディ
+type VerifyTwoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+}
+
+// EnrollTwoFactorResponse は二要素認証の登録開始APIのレスポンスボディ
+type EnrollTwoFactorResponse struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauth_uri"`
+}
+
+// ConfirmTwoFactorRequest は二要素認証の有効化APIのリクエストボディ
+type ConfirmTwoFactorRequest struct {
+	Code string `json:"code"`
+}
+
+// ConfirmTwoFactorResponse は二要素認証の有効化APIのレスポンスボディ
+type ConfirmTwoFactorResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// DisableTwoFactorRequest は二要素認証の無効化APIのリクエストボディ
+type DisableTwoFactorRequest struct {
+	Code string `json:"code"`
+}
+
+// RegenerateRecoveryCodesRequest はリカバリーコードの再発行APIのリクエストボディ
+type RegenerateRecoveryCodesRequest struct {
+	Code string `json:"code"`
+}
+
+// RegenerateRecoveryCodesResponse はリカバリーコードの再発行APIのレスポンスボディ
+type RegenerateRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
 // GetUserResponse はユーザー情報取得APIのレスポンスボディ
 type GetUserResponse struct {
 	ID    string `json:"id"`
@@ -65,11 +116,43 @@ type ChangePasswordRequest struct {
 	NewPassword     string `json:"new_password"`
 }
 
+// ChangeEmailRequest はメールアドレス変更APIのリクエストボディ
+type ChangeEmailRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewEmail        string `json:"new_email"`
+}
+
+// SessionResponse はセッション一覧APIのレスポンスの要素
+type SessionResponse struct {
+	ID         string `json:"id"`
+	CreatedAt  string `json:"created_at"`
+	LastSeenAt string `json:"last_seen_at"`
+	UserAgent  string `json:"user_agent"`
+	IPAddress  string `json:"ip_address"`
+	Current    bool   `json:"current"`
+}
+
+// SessionListResponse はセッション一覧APIのレスポンスボディ
+type SessionListResponse struct {
+	Sessions []SessionResponse `json:"sessions"`
+}
+
 // ResendVerificationRequest は確認メール再送APIのリクエストボディ
 type ResendVerificationRequest struct {
 	Email string `json:"email"`
 }
 
+// ForgotPasswordRequest はパスワードリセット要求APIのリクエストボディ
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// ResetPasswordRequest はパスワードリセットAPIのリクエストボディ
+type ResetPasswordRequest struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
 // ErrorResponse はエラーレスポンスボディ
 type ErrorResponse struct {
 	Error string `json:"error"`
@@ -118,13 +201,16 @@ func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
 //
 //	{
 //	  "email": "user@example.com",
-//	  "password": "securepassword"
+//	  "password": "securepassword",
+//	  "remember_me": true
 //	}
 //
 // レスポンス:
-//   - 200 OK: ログイン成功（セッションCookieを設定）
+//   - 200 OK: ログイン成功（セッションの有効期限に合わせたMax-AgeでセッションCookieを設定）
+//     二要素認証が有効なユーザーの場合はCookieを設定せず、two_factor_requiredとchallenge_tokenを返す
 //   - 400 Bad Request: リクエストボディが不正
 //   - 401 Unauthorized: 認証失敗
+//   - 429 Too Many Requests: ログイン失敗が続いたためアカウントが一時ロック中（Retry-Afterヘッダー付き）
 //   - 500 Internal Server Error: サーバーエラー
 func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req LoginRequest
@@ -134,29 +220,194 @@ func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Usecaseで認証とセッション作成を実行
-	user, sessionID, err := h.userUsecase.Login(r.Context(), req.Email, req.Password)
+	out, err := h.userUsecase.Login(r.Context(), usecase.LoginInput{
+		Email:      req.Email,
+		Password:   req.Password,
+		RememberMe: req.RememberMe,
+		Metadata:   sessionMetadataFromRequest(r),
+	})
 	if err != nil {
 		handleUsecaseError(w, err)
 		return
 	}
 
-	// セッションCookieを設定（HTTP層の責務）
-	http.SetCookie(w, &http.Cookie{
-		Name:     "session_id",
-		Value:    sessionID,
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   true,
-		SameSite: http.SameSiteLaxMode,
-		MaxAge:   86400, // 24 hours
+	if out.TwoFactorRequired {
+		respondJSON(w, http.StatusOK, TwoFactorChallengeResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    out.ChallengeToken,
+			ExpiresIn:         int(usecase.TwoFactorChallengeTTL.Seconds()),
+		})
+		return
+	}
+
+	respondLoginSuccess(w, out)
+}
+
+// VerifyTwoFactor は二要素認証のコードを検証し、ログインを完了する。
+// POST /api/auth/2fa/verify
+//
+// リクエストボディ:
+//
+//	{
+//	  "challenge_token": "ログインAPIが返したchallenge_token",
+//	  "code": "123456"
+//	}
+//
+// codeには認証アプリのワンタイムコードの代わりにリカバリーコードも使用できる。
+//
+// レスポンス:
+//   - 200 OK: ログイン成功（ログインAPIと同様にセッションCookieを設定）
+//   - 400 Bad Request: リクエストボディが不正、またはコードが一致しない
+//   - 401 Unauthorized: チャレンジが存在しない・期限切れ・試行回数超過（ログインからやり直す）
+//   - 429 Too Many Requests: ログインまたはコードの失敗が続いたため一時ロック中
+//   - 500 Internal Server Error: サーバーエラー
+func (h *UserHandler) VerifyTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var req VerifyTwoFactorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	out, err := h.userUsecase.VerifyTwoFactor(r.Context(), usecase.VerifyTwoFactorInput{
+		ChallengeToken: req.ChallengeToken,
+		Code:           req.Code,
+		Metadata:       sessionMetadataFromRequest(r),
 	})
+	if err != nil {
+		handleUsecaseError(w, err)
+		return
+	}
 
-	resp := LoginResponse{
-		ID:    user.ID.String(),
-		Email: user.Email.String(),
+	respondLoginSuccess(w, out)
+}
+
+// EnrollTwoFactor は二要素認証の登録を開始し、認証アプリに登録する秘密鍵を返す。
+// POST /api/auth/2fa/enroll
+//
+// レスポンス:
+//   - 200 OK: 登録開始（secretとotpauth_uriを返却。確認APIで有効化するまで二要素認証は無効のまま）
+//   - 401 Unauthorized: 未認証（AuthMiddlewareで処理）
+//   - 409 Conflict: 二要素認証が既に有効
+//   - 500 Internal Server Error: サーバーエラー
+func (h *UserHandler) EnrollTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	enrollment, err := h.userUsecase.EnrollTwoFactor(r.Context(), userID)
+	if err != nil {
+		handleUsecaseError(w, err)
+		return
 	}
 
-	respondJSON(w, http.StatusOK, resp)
+	respondJSON(w, http.StatusOK, EnrollTwoFactorResponse{
+		Secret:     enrollment.Secret,
+		OtpauthURI: enrollment.ProvisioningURI,
+	})
+}
+
+// ConfirmTwoFactor は認証アプリのコードで登録を確認し、二要素認証を有効にする。
+// POST /api/auth/2fa/confirm
+//
+// リクエストボディ:
+//
+//	{
+//	  "code": "123456"
+//	}
+//
+// レスポンス:
+//   - 200 OK: 有効化成功（リカバリーコードを返却。再表示はできない）
+//   - 400 Bad Request: リクエストボディが不正、コードが一致しない、または登録が開始されていない
+//   - 401 Unauthorized: 未認証（AuthMiddlewareで処理）
+//   - 409 Conflict: 二要素認証が既に有効
+//   - 500 Internal Server Error: サーバーエラー
+func (h *UserHandler) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	var req ConfirmTwoFactorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	recoveryCodes, err := h.userUsecase.ConfirmTwoFactor(r.Context(), userID, req.Code)
+	if err != nil {
+		handleUsecaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, ConfirmTwoFactorResponse{
+		RecoveryCodes: recoveryCodes,
+	})
+}
+
+// DisableTwoFactor は現在の二要素認証のコードを確認した上で、二要素認証を無効にする。
+// POST /api/auth/2fa/disable
+//
+// リクエストボディ:
+//
+//	{
+//	  "code": "123456"
+//	}
+//
+// codeには認証アプリのワンタイムコードの代わりにリカバリーコードも使用できる。
+//
+// レスポンス:
+//   - 204 No Content: 無効化成功
+//   - 400 Bad Request: リクエストボディが不正、またはコードが一致しない
+//   - 401 Unauthorized: 未認証（AuthMiddlewareで処理）
+//   - 409 Conflict: 二要素認証が有効でない
+//   - 429 Too Many Requests: コードの失敗が続いたため一時ロック中
+//   - 500 Internal Server Error: サーバーエラー
+func (h *UserHandler) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	var req DisableTwoFactorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.userUsecase.DisableTwoFactor(r.Context(), userID, req.Code); err != nil {
+		handleUsecaseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegenerateRecoveryCodes は現在の二要素認証のコードを確認した上で、リカバリーコードを発行し直す。
+// POST /api/auth/2fa/recovery-codes
+//
+// リクエストボディ:
+//
+//	{
+//	  "code": "123456"
+//	}
+//
+// レスポンス:
+//   - 200 OK: 再発行成功（新しいリカバリーコードを返却。未使用だった以前のコードは無効になり、再表示はできない）
+//   - 400 Bad Request: リクエストボディが不正、またはコードが一致しない
+//   - 401 Unauthorized: 未認証（AuthMiddlewareで処理）
+//   - 409 Conflict: 二要素認証が有効でない
+//   - 429 Too Many Requests: コードの失敗が続いたため一時ロック中
+//   - 500 Internal Server Error: サーバーエラー
+func (h *UserHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	var req RegenerateRecoveryCodesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	recoveryCodes, err := h.userUsecase.RegenerateRecoveryCodes(r.Context(), userID, req.Code)
+	if err != nil {
+		handleUsecaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, RegenerateRecoveryCodesResponse{
+		RecoveryCodes: recoveryCodes,
+	})
 }
 
 // GetMe は現在のセッションに紐づくユーザー情報を返す。
@@ -206,15 +457,7 @@ func (h *UserHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// セッションCookieを削除（HTTP層の責務）
-	http.SetCookie(w, &http.Cookie{
-		Name:     "session_id",
-		Value:    "",
-		Path:     "/",
-		HttpOnly: true,
-		Secure:   true,
-		SameSite: http.SameSiteLaxMode,
-		MaxAge:   -1,
-	})
+	auth.ClearSessionCookie(w)
 
 	w.WriteHeader(http.StatusNoContent)
 }
@@ -267,6 +510,8 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 //	  "new_password": "newpassword"
 //	}
 //
+// 変更に成功すると、現在のセッション以外のセッションは全て無効化される。
+//
 // レスポンス:
 //   - 204 No Content: 変更成功
 //   - 400 Bad Request: リクエストボディが不正、バリデーションエラー
@@ -289,7 +534,8 @@ func (h *UserHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.userUsecase.ChangePassword(r.Context(), userID, req.CurrentPassword, req.NewPassword)
+	currentSessionID := auth.GetSessionIDFromContext(r.Context())
+	err = h.userUsecase.ChangePassword(r.Context(), userID, currentSessionID, req.CurrentPassword, req.NewPassword)
 	if err != nil {
 		handleUsecaseError(w, err)
 		return
@@ -298,6 +544,126 @@ func (h *UserHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// ChangeEmail は現在のユーザーのメールアドレス変更を受け付け、変更後のメールアドレスに確認メールを送信する。
+// PUT /api/users/me/email
+//
+// リクエストボディ:
+//
+//	{
+//	  "current_password": "currentpassword",
+//	  "new_email": "new@example.com"
+//	}
+//
+// メールアドレスは確認メールのリンクから変更が確定されるまで変更されない。
+//
+// レスポンス:
+//   - 202 Accepted: 受付成功（確認メールを送信）
+//   - 400 Bad Request: リクエストボディが不正、現在と同じメールアドレス、バリデーションエラー（使用中のメールアドレスを含む）
+//   - 401 Unauthorized: 現在のパスワードが不正
+//   - 429 Too Many Requests: 同じメールアドレス宛の送信回数が上限を超えた（Retry-Afterヘッダー付き）
+//   - 500 Internal Server Error: サーバーエラー
+func (h *UserHandler) ChangeEmail(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	var req ChangeEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := h.userUsecase.RequestEmailChange(r.Context(), userID, req.CurrentPassword, req.NewEmail); err != nil {
+		handleUsecaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusAccepted, map[string]string{"message": "A confirmation email has been sent to the new address"})
+}
+
+// ConfirmEmailChange はメールアドレス変更トークンを検証し、メールアドレスの変更を確定する。
+// GET /api/auth/confirm-email-change?token=xxx
+//
+// レスポンス:
+//   - 200 OK: 変更成功
+//   - 400 Bad Request: トークンが不正・期限切れ・使用済み、変更後のメールアドレスが既に使用されている
+//   - 500 Internal Server Error: サーバーエラー
+func (h *UserHandler) ConfirmEmailChange(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		respondError(w, http.StatusBadRequest, "Token is required")
+		return
+	}
+
+	if err := h.userUsecase.ConfirmEmailChange(r.Context(), token); err != nil {
+		handleUsecaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "Email changed successfully"})
+}
+
+// ListSessions は現在のユーザーの有効なセッション一覧を返す。
+// GET /api/auth/sessions
+//
+// レスポンス:
+//   - 200 OK: 取得成功（最終アクセス日時の新しい順、現在のセッションはcurrent=true）
+//   - 401 Unauthorized: 未認証（AuthMiddlewareで処理）
+//   - 500 Internal Server Error: サーバーエラー
+func (h *UserHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+	currentSessionID := auth.GetSessionIDFromContext(r.Context())
+
+	sessions, err := h.userUsecase.ListSessions(r.Context(), userID)
+	if err != nil {
+		handleUsecaseError(w, err)
+		return
+	}
+
+	resp := SessionListResponse{
+		Sessions: make([]SessionResponse, 0, len(sessions)),
+	}
+	for _, session := range sessions {
+		resp.Sessions = append(resp.Sessions, SessionResponse{
+			ID:         session.ID,
+			CreatedAt:  session.CreatedAt.Format(time.RFC3339),
+			LastSeenAt: session.LastSeenAt.Format(time.RFC3339),
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			Current:    session.ID == currentSessionID,
+		})
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// RevokeSession は現在のユーザーの指定されたセッションを無効化する。
+// DELETE /api/auth/sessions/:id
+//
+// パスパラメータ:
+//   - id: セッションID
+//
+// 現在のセッションを指定した場合はログアウトと同様にセッションCookieも削除する。
+//
+// レスポンス:
+//   - 204 No Content: 無効化成功
+//   - 401 Unauthorized: 未認証（AuthMiddlewareで処理）
+//   - 404 Not Found: セッションが見つからない（他のユーザーのセッションを含む）
+//   - 500 Internal Server Error: サーバーエラー
+func (h *UserHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+	sessionID := mux.Vars(r)["id"]
+
+	if err := h.userUsecase.RevokeSession(r.Context(), userID, sessionID); err != nil {
+		handleUsecaseError(w, err)
+		return
+	}
+
+	if sessionID == auth.GetSessionIDFromContext(r.Context()) {
+		auth.ClearSessionCookie(w)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // VerifyEmail はメール検証を行う。
 // GET /api/auth/verify-email?token=xxx
 //
@@ -324,6 +690,7 @@ func (h *UserHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
 //
 // レスポンス:
 //   - 200 OK: 常に200を返す（メール列挙攻撃防止）
+//   - 429 Too Many Requests: 同じメールアドレス宛の送信回数が上限を超えた（Retry-Afterヘッダー付き）
 func (h *UserHandler) ResendVerificationEmail(w http.ResponseWriter, r *http.Request) {
 	var req ResendVerificationRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -331,12 +698,86 @@ func (h *UserHandler) ResendVerificationEmail(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	// エラーがあっても常に200を返す（メール列挙攻撃防止）
-	_ = h.userUsecase.ResendVerificationEmail(r.Context(), req.Email)
+	// レート制限以外のエラーは常に200を返す（メール列挙攻撃防止）
+	// レート制限はメールアドレスの存在有無に関わらず適用されるため、429を返しても列挙には使えない
+	if err := h.userUsecase.ResendVerificationEmail(r.Context(), req.Email); err != nil {
+		var retryErr *usecase.RetryAfterError
+		if errors.As(err, &retryErr) {
+			handleUsecaseError(w, err)
+			return
+		}
+	}
 
 	respondJSON(w, http.StatusOK, map[string]string{"message": "If the email exists, a verification email has been sent"})
 }
 
+// ForgotPassword はパスワードリセットメールを送信する。
+// POST /api/auth/forgot-password
+//
+// リクエストボディ:
+//
+//	{
+//	  "email": "user@example.com"
+//	}
+//
+// レスポンス:
+//   - 200 OK: 常に200を返す（メール列挙攻撃防止）
+//   - 429 Too Many Requests: 同じメールアドレス宛の送信回数が上限を超えた（Retry-Afterヘッダー付き）
+//   - 400 Bad Request: リクエストボディが不正
+func (h *UserHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// レート制限以外のエラーは常に200を返す（メール列挙攻撃防止）
+	if err := h.userUsecase.RequestPasswordReset(r.Context(), req.Email); err != nil {
+		var retryErr *usecase.RetryAfterError
+		if errors.As(err, &retryErr) {
+			handleUsecaseError(w, err)
+			return
+		}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "If the email exists, a password reset email has been sent"})
+}
+
+// ResetPassword はリセットトークンを使用して新しいパスワードを設定する。
+// 成功した場合、そのユーザーの既存セッションは全て無効化される。
+// POST /api/auth/reset-password
+//
+// リクエストボディ:
+//
+//	{
+//	  "token": "reset-token",
+//	  "new_password": "newpassword"
+//	}
+//
+// レスポンス:
+//   - 204 No Content: リセット成功
+//   - 400 Bad Request: リクエストボディが不正、トークンが不正・期限切れ・使用済み、バリデーションエラー
+//   - 500 Internal Server Error: サーバーエラー
+func (h *UserHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.Token == "" {
+		respondError(w, http.StatusBadRequest, "Token is required")
+		return
+	}
+
+	if err := h.userUsecase.ResetPassword(r.Context(), req.Token, req.NewPassword); err != nil {
+		handleUsecaseError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 // respondJSON はJSON形式でレスポンスを返す。
 func respondJSON(w http.ResponseWriter, statusCode int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -354,6 +795,17 @@ func respondError(w http.ResponseWriter, statusCode int, message string) {
 
 // handleUsecaseError はUsecase層のエラーを適切なHTTPステータスコードに変換する。
 func handleUsecaseError(w http.ResponseWriter, err error) {
+	// レート制限・アカウントロックは再試行までの時間をRetry-Afterヘッダーで返す
+	var retryErr *usecase.RetryAfterError
+	if errors.As(err, &retryErr) {
+		message := "Too many requests"
+		if errors.Is(retryErr, usecase.ErrAccountLocked) {
+			message = "Too many failed login attempts, please try again later"
+		}
+		ratelimit.WriteTooManyRequests(w, retryErr.RetryAfter, message)
+		return
+	}
+
 	switch err {
 	case usecase.ErrInvalidCredentials:
 		respondError(w, http.StatusUnauthorized, "Invalid email or password")
@@ -363,6 +815,26 @@ func handleUsecaseError(w http.ResponseWriter, err error) {
 		respondError(w, http.StatusForbidden, "Email not verified")
 	case usecase.ErrInvalidVerificationToken:
 		respondError(w, http.StatusBadRequest, "Invalid or expired verification token")
+	case usecase.ErrInvalidPasswordResetToken:
+		respondError(w, http.StatusBadRequest, "Invalid or expired password reset token")
+	case usecase.ErrInvalidEmailChangeToken:
+		respondError(w, http.StatusBadRequest, "Invalid or expired email change token")
+	case entity.ErrSameEmail:
+		respondError(w, http.StatusBadRequest, "New email must be different from the current email")
+	case usecase.ErrRecentLoginRequired:
+		respondError(w, http.StatusForbidden, "Recent login required")
+	case usecase.ErrSessionNotFound:
+		respondError(w, http.StatusNotFound, "Session not found")
+	case usecase.ErrInvalidTwoFactorChallenge:
+		respondError(w, http.StatusUnauthorized, "Invalid or expired two-factor challenge")
+	case entity.ErrInvalidTwoFactorCode:
+		respondError(w, http.StatusBadRequest, "Invalid two-factor authentication code")
+	case entity.ErrTwoFactorNotEnrolled:
+		respondError(w, http.StatusBadRequest, "Two-factor authentication enrollment has not been started")
+	case entity.ErrTwoFactorAlreadyEnabled:
+		respondError(w, http.StatusConflict, "Two-factor authentication is already enabled")
+	case entity.ErrTwoFactorNotEnabled:
+		respondError(w, http.StatusConflict, "Two-factor authentication is not enabled")
 	default:
 		// バリデーションエラー（値オブジェクトのエラー）をチェック
 		if isValidationError(err) {
@@ -374,6 +846,33 @@ func handleUsecaseError(w http.ResponseWriter, err error) {
 	}
 }
 
+// respondLoginSuccess はセッションCookieを設定し、ログインしたユーザーの情報を返す。
+// セッションCookieの設定はHTTP層の責務として、ここで行う。
+func respondLoginSuccess(w http.ResponseWriter, out *usecase.LoginOutput) {
+	auth.SetSessionCookie(w, out.SessionID, out.SessionTTL)
+
+	resp := LoginResponse{
+		ID:    out.User.ID.String(),
+		Email: out.User.Email.String(),
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// sessionMetadataFromRequest はセッションに記録するクライアント情報をリクエストから取得する。
+// IPアドレスは接続元（RemoteAddr）を使用し、偽装可能なX-Forwarded-For等のヘッダーは信頼しない。
+func sessionMetadataFromRequest(r *http.Request) repository.SessionMetadata {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		ip = host
+	}
+
+	return repository.SessionMetadata{
+		UserAgent: r.UserAgent(),
+		IPAddress: ip,
+	}
+}
+
 // isValidationError はバリデーションエラーかどうかを判定する。
 // 値オブジェクトのエラーメッセージに基づいて判定する。
 func isValidationError(err error) bool {
@@ -8,10 +8,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+	"github.com/ucchy108/whiskey/backend/domain/value"
 	"github.com/ucchy108/whiskey/backend/infrastructure/auth"
 	"github.com/ucchy108/whiskey/backend/usecase"
 )
@@ -19,12 +22,24 @@ import (
 // mockUserUsecase はUserUsecaseのモック実装
 type mockUserUsecase struct {
 	registerFunc                func(ctx context.Context, email, password string) (*entity.User, error)
-	loginFunc                   func(ctx context.Context, email, password string) (*entity.User, string, error)
+	loginFunc                   func(ctx context.Context, input usecase.LoginInput) (*usecase.LoginOutput, error)
+	startSessionFunc            func(ctx context.Context, user *entity.User, rememberMe bool, metadata repository.SessionMetadata) (*usecase.LoginOutput, error)
 	logoutFunc                  func(ctx context.Context, sessionID string) error
 	getUserFunc                 func(ctx context.Context, userID uuid.UUID) (*entity.User, error)
-	changePasswordFunc          func(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) error
+	changePasswordFunc          func(ctx context.Context, userID uuid.UUID, currentSessionID, currentPassword, newPassword string) error
 	verifyEmailFunc             func(ctx context.Context, token string) error
 	resendVerificationEmailFunc func(ctx context.Context, email string) error
+	requestPasswordResetFunc    func(ctx context.Context, email string) error
+	resetPasswordFunc           func(ctx context.Context, token, newPassword string) error
+	listSessionsFunc            func(ctx context.Context, userID uuid.UUID) ([]*repository.Session, error)
+	revokeSessionFunc           func(ctx context.Context, userID uuid.UUID, sessionID string) error
+	verifyTwoFactorFunc         func(ctx context.Context, input usecase.VerifyTwoFactorInput) (*usecase.LoginOutput, error)
+	enrollTwoFactorFunc         func(ctx context.Context, userID uuid.UUID) (*usecase.TwoFactorEnrollment, error)
+	confirmTwoFactorFunc        func(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	disableTwoFactorFunc        func(ctx context.Context, userID uuid.UUID, code string) error
+	regenerateRecoveryCodesFunc func(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	requestEmailChangeFunc      func(ctx context.Context, userID uuid.UUID, currentPassword, newEmail string) error
+	confirmEmailChangeFunc      func(ctx context.Context, token string) error
 }
 
 func (m *mockUserUsecase) Register(ctx context.Context, email, password string) (*entity.User, error) {
@@ -34,11 +49,18 @@ func (m *mockUserUsecase) Register(ctx context.Context, email, password string)
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockUserUsecase) Login(ctx context.Context, email, password string) (*entity.User, string, error) {
+func (m *mockUserUsecase) Login(ctx context.Context, input usecase.LoginInput) (*usecase.LoginOutput, error) {
 	if m.loginFunc != nil {
-		return m.loginFunc(ctx, email, password)
+		return m.loginFunc(ctx, input)
 	}
-	return nil, "", errors.New("not implemented")
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockUserUsecase) StartSession(ctx context.Context, user *entity.User, rememberMe bool, metadata repository.SessionMetadata) (*usecase.LoginOutput, error) {
+	if m.startSessionFunc != nil {
+		return m.startSessionFunc(ctx, user, rememberMe, metadata)
+	}
+	return nil, errors.New("not implemented")
 }
 
 func (m *mockUserUsecase) Logout(ctx context.Context, sessionID string) error {
@@ -55,9 +77,9 @@ func (m *mockUserUsecase) GetUser(ctx context.Context, userID uuid.UUID) (*entit
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockUserUsecase) ChangePassword(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) error {
+func (m *mockUserUsecase) ChangePassword(ctx context.Context, userID uuid.UUID, currentSessionID, currentPassword, newPassword string) error {
 	if m.changePasswordFunc != nil {
-		return m.changePasswordFunc(ctx, userID, currentPassword, newPassword)
+		return m.changePasswordFunc(ctx, userID, currentSessionID, currentPassword, newPassword)
 	}
 	return errors.New("not implemented")
 }
@@ -76,6 +98,83 @@ func (m *mockUserUsecase) ResendVerificationEmail(ctx context.Context, email str
 	return errors.New("not implemented")
 }
 
+func (m *mockUserUsecase) RequestPasswordReset(ctx context.Context, email string) error {
+	if m.requestPasswordResetFunc != nil {
+		return m.requestPasswordResetFunc(ctx, email)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockUserUsecase) ResetPassword(ctx context.Context, token, newPassword string) error {
+	if m.resetPasswordFunc != nil {
+		return m.resetPasswordFunc(ctx, token, newPassword)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockUserUsecase) ListSessions(ctx context.Context, userID uuid.UUID) ([]*repository.Session, error) {
+	if m.listSessionsFunc != nil {
+		return m.listSessionsFunc(ctx, userID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockUserUsecase) RevokeSession(ctx context.Context, userID uuid.UUID, sessionID string) error {
+	if m.revokeSessionFunc != nil {
+		return m.revokeSessionFunc(ctx, userID, sessionID)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockUserUsecase) VerifyTwoFactor(ctx context.Context, input usecase.VerifyTwoFactorInput) (*usecase.LoginOutput, error) {
+	if m.verifyTwoFactorFunc != nil {
+		return m.verifyTwoFactorFunc(ctx, input)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockUserUsecase) EnrollTwoFactor(ctx context.Context, userID uuid.UUID) (*usecase.TwoFactorEnrollment, error) {
+	if m.enrollTwoFactorFunc != nil {
+		return m.enrollTwoFactorFunc(ctx, userID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockUserUsecase) ConfirmTwoFactor(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	if m.confirmTwoFactorFunc != nil {
+		return m.confirmTwoFactorFunc(ctx, userID, code)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockUserUsecase) DisableTwoFactor(ctx context.Context, userID uuid.UUID, code string) error {
+	if m.disableTwoFactorFunc != nil {
+		return m.disableTwoFactorFunc(ctx, userID, code)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockUserUsecase) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	if m.regenerateRecoveryCodesFunc != nil {
+		return m.regenerateRecoveryCodesFunc(ctx, userID, code)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockUserUsecase) RequestEmailChange(ctx context.Context, userID uuid.UUID, currentPassword, newEmail string) error {
+	if m.requestEmailChangeFunc != nil {
+		return m.requestEmailChangeFunc(ctx, userID, currentPassword, newEmail)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockUserUsecase) ConfirmEmailChange(ctx context.Context, token string) error {
+	if m.confirmEmailChangeFunc != nil {
+		return m.confirmEmailChangeFunc(ctx, token)
+	}
+	return errors.New("not implemented")
+}
+
 func TestUserHandler_Register(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -184,10 +283,11 @@ func TestUserHandler_Login(t *testing.T) {
 	tests := []struct {
 		name                string
 		requestBody         interface{}
-		mockLoginFunc       func(ctx context.Context, email, password string) (*entity.User, string, error)
+		mockLoginFunc       func(ctx context.Context, input usecase.LoginInput) (*usecase.LoginOutput, error)
 		expectedStatus      int
 		expectedBody        map[string]interface{}
 		expectSessionCookie bool
+		expectedMaxAge      int
 	}{
 		{
 			name: "成功: ログイン",
@@ -195,15 +295,43 @@ func TestUserHandler_Login(t *testing.T) {
 				Email:    "test@example.com",
 				Password: "password123",
 			},
-			mockLoginFunc: func(ctx context.Context, email, password string) (*entity.User, string, error) {
-				user, _ := entity.NewUser(email, password)
-				return user, "session-id-123", nil
+			mockLoginFunc: func(ctx context.Context, input usecase.LoginInput) (*usecase.LoginOutput, error) {
+				if input.Metadata.UserAgent != "test-agent" || input.Metadata.IPAddress != "192.0.2.1" {
+					t.Errorf("unexpected session metadata: %+v", input.Metadata)
+				}
+				if input.RememberMe {
+					t.Error("RememberMe = true, want false")
+				}
+				user, _ := entity.NewUser(input.Email, input.Password)
+				return &usecase.LoginOutput{User: user, SessionID: "session-id-123", SessionTTL: 24 * time.Hour}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"email": "test@example.com",
+			},
+			expectSessionCookie: true,
+			expectedMaxAge:      86400,
+		},
+		{
+			name: "成功: ログインしたままにする",
+			requestBody: LoginRequest{
+				Email:      "test@example.com",
+				Password:   "password123",
+				RememberMe: true,
+			},
+			mockLoginFunc: func(ctx context.Context, input usecase.LoginInput) (*usecase.LoginOutput, error) {
+				if !input.RememberMe {
+					t.Error("RememberMe = false, want true")
+				}
+				user, _ := entity.NewUser(input.Email, input.Password)
+				return &usecase.LoginOutput{User: user, SessionID: "session-id-456", SessionTTL: 30 * 24 * time.Hour}, nil
 			},
 			expectedStatus: http.StatusOK,
 			expectedBody: map[string]interface{}{
 				"email": "test@example.com",
 			},
 			expectSessionCookie: true,
+			expectedMaxAge:      30 * 86400,
 		},
 		{
 			name:            "失敗: 不正なリクエストボディ",
@@ -221,8 +349,8 @@ func TestUserHandler_Login(t *testing.T) {
 				Email:    "test@example.com",
 				Password: "wrongpassword",
 			},
-			mockLoginFunc: func(ctx context.Context, email, password string) (*entity.User, string, error) {
-				return nil, "", usecase.ErrInvalidCredentials
+			mockLoginFunc: func(ctx context.Context, input usecase.LoginInput) (*usecase.LoginOutput, error) {
+				return nil, usecase.ErrInvalidCredentials
 			},
 			expectedStatus: http.StatusUnauthorized,
 			expectedBody: map[string]interface{}{
@@ -236,8 +364,8 @@ func TestUserHandler_Login(t *testing.T) {
 				Email:    "test@example.com",
 				Password: "password123",
 			},
-			mockLoginFunc: func(ctx context.Context, email, password string) (*entity.User, string, error) {
-				return nil, "", errors.New("failed to create session: redis connection failed")
+			mockLoginFunc: func(ctx context.Context, input usecase.LoginInput) (*usecase.LoginOutput, error) {
+				return nil, errors.New("failed to create session: redis connection failed")
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody: map[string]interface{}{
@@ -262,6 +390,8 @@ func TestUserHandler_Login(t *testing.T) {
 			}
 
 			req := httptest.NewRequest(http.MethodPost, "/api/auth/login", &body)
+			req.Header.Set("User-Agent", "test-agent")
+			req.RemoteAddr = "192.0.2.1:54321"
 			rec := httptest.NewRecorder()
 
 			// ハンドラーの実行
@@ -291,6 +421,9 @@ func TestUserHandler_Login(t *testing.T) {
 						if cookie.SameSite != http.SameSiteLaxMode {
 							t.Error("session cookie should have SameSite=Lax")
 						}
+						if cookie.MaxAge != tt.expectedMaxAge {
+							t.Errorf("session cookie MaxAge = %d, want %d", cookie.MaxAge, tt.expectedMaxAge)
+						}
 					}
 				}
 				if !found {
@@ -587,7 +720,7 @@ func TestUserHandler_ChangePassword(t *testing.T) {
 		name           string
 		userID         string
 		requestBody    interface{}
-		mockFunc       func(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) error
+		mockFunc       func(ctx context.Context, userID uuid.UUID, currentSessionID, currentPassword, newPassword string) error
 		expectedStatus int
 		expectedBody   map[string]interface{}
 	}{
@@ -598,7 +731,10 @@ func TestUserHandler_ChangePassword(t *testing.T) {
 				CurrentPassword: "oldpassword",
 				NewPassword:     "newpassword123",
 			},
-			mockFunc: func(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) error {
+			mockFunc: func(ctx context.Context, userID uuid.UUID, currentSessionID, currentPassword, newPassword string) error {
+				if currentSessionID != "current-session" {
+					t.Errorf("currentSessionID = %q, want current-session", currentSessionID)
+				}
 				return nil
 			},
 			expectedStatus: http.StatusNoContent,
@@ -631,7 +767,7 @@ func TestUserHandler_ChangePassword(t *testing.T) {
 				CurrentPassword: "wrongpassword",
 				NewPassword:     "newpassword123",
 			},
-			mockFunc: func(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) error {
+			mockFunc: func(ctx context.Context, userID uuid.UUID, currentSessionID, currentPassword, newPassword string) error {
 				return usecase.ErrInvalidCredentials
 			},
 			expectedStatus: http.StatusUnauthorized,
@@ -646,7 +782,7 @@ func TestUserHandler_ChangePassword(t *testing.T) {
 				CurrentPassword: "oldpassword",
 				NewPassword:     "newpassword123",
 			},
-			mockFunc: func(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) error {
+			mockFunc: func(ctx context.Context, userID uuid.UUID, currentSessionID, currentPassword, newPassword string) error {
 				return usecase.ErrUserNotFound
 			},
 			expectedStatus: http.StatusNotFound,
@@ -675,6 +811,8 @@ func TestUserHandler_ChangePassword(t *testing.T) {
 
 			// Gorilla Muxのパスパラメータを設定
 			req = mux.SetURLVars(req, map[string]string{"id": tt.userID})
+			ctx := context.WithValue(req.Context(), auth.SessionIDContextKey, "current-session")
+			req = req.WithContext(ctx)
 
 			// ハンドラーの実行
 			handler.ChangePassword(rec, req)
@@ -705,3 +843,1045 @@ func TestUserHandler_ChangePassword(t *testing.T) {
 		})
 	}
 }
+
+func TestUserHandler_ForgotPassword(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockFunc       func(ctx context.Context, email string) error
+		expectedStatus int
+	}{
+		{
+			name:        "成功: リセットメール送信",
+			requestBody: ForgotPasswordRequest{Email: "user@example.com"},
+			mockFunc: func(ctx context.Context, email string) error {
+				if email != "user@example.com" {
+					t.Errorf("expected email user@example.com, got %s", email)
+				}
+				return nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "成功: Usecaseがエラーを返しても200を返す",
+			requestBody: ForgotPasswordRequest{Email: "user@example.com"},
+			mockFunc: func(ctx context.Context, email string) error {
+				return errors.New("database error")
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "失敗: 不正なリクエストボディ",
+			requestBody:    "invalid json",
+			mockFunc:       nil,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := &mockUserUsecase{
+				requestPasswordResetFunc: tt.mockFunc,
+			}
+			handler := NewUserHandler(mockUsecase)
+
+			var body bytes.Buffer
+			if err := json.NewEncoder(&body).Encode(tt.requestBody); err != nil {
+				t.Fatal(err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/forgot-password", &body)
+			rec := httptest.NewRecorder()
+
+			handler.ForgotPassword(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestUserHandler_ResetPassword(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockFunc       func(ctx context.Context, token, newPassword string) error
+		expectedStatus int
+		expectedBody   map[string]interface{}
+	}{
+		{
+			name:        "成功: パスワードリセット",
+			requestBody: ResetPasswordRequest{Token: "token", NewPassword: "newpassword123"},
+			mockFunc: func(ctx context.Context, token, newPassword string) error {
+				if token != "token" || newPassword != "newpassword123" {
+					t.Errorf("unexpected args: %s, %s", token, newPassword)
+				}
+				return nil
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "失敗: 不正なリクエストボディ",
+			requestBody:    "invalid json",
+			mockFunc:       nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"error": "Invalid request body",
+			},
+		},
+		{
+			name:           "失敗: トークンが空",
+			requestBody:    ResetPasswordRequest{NewPassword: "newpassword123"},
+			mockFunc:       nil,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"error": "Token is required",
+			},
+		},
+		{
+			name:        "失敗: トークンが不正・期限切れ",
+			requestBody: ResetPasswordRequest{Token: "expired", NewPassword: "newpassword123"},
+			mockFunc: func(ctx context.Context, token, newPassword string) error {
+				return usecase.ErrInvalidPasswordResetToken
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"error": "Invalid or expired password reset token",
+			},
+		},
+		{
+			name:        "失敗: 新しいパスワードが短すぎる",
+			requestBody: ResetPasswordRequest{Token: "token", NewPassword: "short"},
+			mockFunc: func(ctx context.Context, token, newPassword string) error {
+				return errors.New("password must be at least 8 characters")
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := &mockUserUsecase{
+				resetPasswordFunc: tt.mockFunc,
+			}
+			handler := NewUserHandler(mockUsecase)
+
+			var body bytes.Buffer
+			if err := json.NewEncoder(&body).Encode(tt.requestBody); err != nil {
+				t.Fatal(err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/reset-password", &body)
+			rec := httptest.NewRecorder()
+
+			handler.ResetPassword(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			if tt.expectedBody == nil {
+				return
+			}
+
+			var respBody map[string]interface{}
+			if err := json.NewDecoder(rec.Body).Decode(&respBody); err != nil {
+				t.Fatal(err)
+			}
+			for key, expectedValue := range tt.expectedBody {
+				if actualValue, ok := respBody[key]; !ok {
+					t.Errorf("expected key %s not found in response", key)
+				} else if actualValue != expectedValue {
+					t.Errorf("expected %s = %v, got %v", key, expectedValue, actualValue)
+				}
+			}
+		})
+	}
+}
+
+func TestUserHandler_ChangeEmail(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockFunc       func(ctx context.Context, userID uuid.UUID, currentPassword, newEmail string) error
+		expectedStatus int
+		expectedBody   map[string]interface{}
+	}{
+		{
+			name:        "成功: 変更を受け付けて確認メールを送信",
+			requestBody: ChangeEmailRequest{CurrentPassword: "password123", NewEmail: "new@example.com"},
+			mockFunc: func(ctx context.Context, id uuid.UUID, currentPassword, newEmail string) error {
+				if id != userID || currentPassword != "password123" || newEmail != "new@example.com" {
+					t.Errorf("unexpected args: %v, %s, %s", id, currentPassword, newEmail)
+				}
+				return nil
+			},
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "失敗: 不正なリクエストボディ",
+			requestBody:    "invalid json",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"error": "Invalid request body",
+			},
+		},
+		{
+			name:        "失敗: 現在のパスワードが不正",
+			requestBody: ChangeEmailRequest{CurrentPassword: "wrongpassword", NewEmail: "new@example.com"},
+			mockFunc: func(ctx context.Context, id uuid.UUID, currentPassword, newEmail string) error {
+				return usecase.ErrInvalidCredentials
+			},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:        "失敗: 現在と同じメールアドレス",
+			requestBody: ChangeEmailRequest{CurrentPassword: "password123", NewEmail: "user@example.com"},
+			mockFunc: func(ctx context.Context, id uuid.UUID, currentPassword, newEmail string) error {
+				return entity.ErrSameEmail
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"error": "New email must be different from the current email",
+			},
+		},
+		{
+			name:        "失敗: 使用中のメールアドレス",
+			requestBody: ChangeEmailRequest{CurrentPassword: "password123", NewEmail: "taken@example.com"},
+			mockFunc: func(ctx context.Context, id uuid.UUID, currentPassword, newEmail string) error {
+				return value.ErrEmailAlreadyExists
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"error": "email already exists",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := &mockUserUsecase{
+				requestEmailChangeFunc: tt.mockFunc,
+			}
+			handler := NewUserHandler(mockUsecase)
+
+			var body bytes.Buffer
+			if err := json.NewEncoder(&body).Encode(tt.requestBody); err != nil {
+				t.Fatal(err)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/api/users/me/email", &body)
+			req = req.WithContext(context.WithValue(req.Context(), auth.UserIDContextKey, userID))
+			rec := httptest.NewRecorder()
+
+			handler.ChangeEmail(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			if tt.expectedBody == nil {
+				return
+			}
+			var respBody map[string]interface{}
+			if err := json.NewDecoder(rec.Body).Decode(&respBody); err != nil {
+				t.Fatal(err)
+			}
+			for key, expectedValue := range tt.expectedBody {
+				if respBody[key] != expectedValue {
+					t.Errorf("expected %s = %v, got %v", key, expectedValue, respBody[key])
+				}
+			}
+		})
+	}
+}
+
+func TestUserHandler_ConfirmEmailChange(t *testing.T) {
+	tests := []struct {
+		name           string
+		token          string
+		mockFunc       func(ctx context.Context, token string) error
+		expectedStatus int
+		expectedBody   map[string]interface{}
+	}{
+		{
+			name:  "成功: メールアドレスを変更",
+			token: "token",
+			mockFunc: func(ctx context.Context, token string) error {
+				if token != "token" {
+					t.Errorf("unexpected token: %s", token)
+				}
+				return nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "失敗: トークンが空",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"error": "Token is required",
+			},
+		},
+		{
+			name:  "失敗: トークンが不正・期限切れ",
+			token: "expired",
+			mockFunc: func(ctx context.Context, token string) error {
+				return usecase.ErrInvalidEmailChangeToken
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"error": "Invalid or expired email change token",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := &mockUserUsecase{
+				confirmEmailChangeFunc: tt.mockFunc,
+			}
+			handler := NewUserHandler(mockUsecase)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/auth/confirm-email-change?token="+tt.token, nil)
+			rec := httptest.NewRecorder()
+
+			handler.ConfirmEmailChange(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			if tt.expectedBody == nil {
+				return
+			}
+			var respBody map[string]interface{}
+			if err := json.NewDecoder(rec.Body).Decode(&respBody); err != nil {
+				t.Fatal(err)
+			}
+			for key, expectedValue := range tt.expectedBody {
+				if respBody[key] != expectedValue {
+					t.Errorf("expected %s = %v, got %v", key, expectedValue, respBody[key])
+				}
+			}
+		})
+	}
+}
+
+func TestUserHandler_ListSessions(t *testing.T) {
+	userID := uuid.New()
+	lastSeenAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		mockFunc       func(ctx context.Context, userID uuid.UUID) ([]*repository.Session, error)
+		expectedStatus int
+		expectedCount  int
+	}{
+		{
+			name: "成功: セッション一覧取得",
+			mockFunc: func(ctx context.Context, id uuid.UUID) ([]*repository.Session, error) {
+				if id != userID {
+					t.Errorf("userID = %v, want %v", id, userID)
+				}
+				return []*repository.Session{
+					{ID: "current-session", UserID: userID, CreatedAt: lastSeenAt, LastSeenAt: lastSeenAt, UserAgent: "agent-a", IPAddress: "192.0.2.1"},
+					{ID: "other-session", UserID: userID, CreatedAt: lastSeenAt, LastSeenAt: lastSeenAt, UserAgent: "agent-b", IPAddress: "192.0.2.2"},
+				}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedCount:  2,
+		},
+		{
+			name: "成功: セッションなし",
+			mockFunc: func(ctx context.Context, id uuid.UUID) ([]*repository.Session, error) {
+				return []*repository.Session{}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedCount:  0,
+		},
+		{
+			name: "失敗: サーバーエラー",
+			mockFunc: func(ctx context.Context, id uuid.UUID) ([]*repository.Session, error) {
+				return nil, errors.New("redis error")
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := &mockUserUsecase{
+				listSessionsFunc: tt.mockFunc,
+			}
+			handler := NewUserHandler(mockUsecase)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/auth/sessions", nil)
+			ctx := context.WithValue(req.Context(), auth.UserIDContextKey, userID)
+			ctx = context.WithValue(ctx, auth.SessionIDContextKey, "current-session")
+			req = req.WithContext(ctx)
+			rec := httptest.NewRecorder()
+
+			handler.ListSessions(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var resp SessionListResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+			if len(resp.Sessions) != tt.expectedCount {
+				t.Fatalf("expected %d sessions, got %d", tt.expectedCount, len(resp.Sessions))
+			}
+			for _, session := range resp.Sessions {
+				if session.Current != (session.ID == "current-session") {
+					t.Errorf("session %s current = %v", session.ID, session.Current)
+				}
+				if session.LastSeenAt != "2026-01-02T03:04:05Z" {
+					t.Errorf("last_seen_at = %s", session.LastSeenAt)
+				}
+			}
+		})
+	}
+}
+
+func TestUserHandler_RevokeSession(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name              string
+		sessionID         string
+		mockFunc          func(ctx context.Context, userID uuid.UUID, sessionID string) error
+		expectedStatus    int
+		expectClearCookie bool
+		expectedBody      map[string]interface{}
+	}{
+		{
+			name:      "成功: 他の端末のセッションを無効化",
+			sessionID: "other-session",
+			mockFunc: func(ctx context.Context, id uuid.UUID, sessionID string) error {
+				if id != userID || sessionID != "other-session" {
+					t.Errorf("unexpected args: %v, %s", id, sessionID)
+				}
+				return nil
+			},
+			expectedStatus:    http.StatusNoContent,
+			expectClearCookie: false,
+		},
+		{
+			name:      "成功: 現在のセッションを無効化するとCookieも削除",
+			sessionID: "current-session",
+			mockFunc: func(ctx context.Context, id uuid.UUID, sessionID string) error {
+				return nil
+			},
+			expectedStatus:    http.StatusNoContent,
+			expectClearCookie: true,
+		},
+		{
+			name:      "失敗: セッションが見つからない",
+			sessionID: "unknown-session",
+			mockFunc: func(ctx context.Context, id uuid.UUID, sessionID string) error {
+				return usecase.ErrSessionNotFound
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody: map[string]interface{}{
+				"error": "Session not found",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := &mockUserUsecase{
+				revokeSessionFunc: tt.mockFunc,
+			}
+			handler := NewUserHandler(mockUsecase)
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/auth/sessions/"+tt.sessionID, nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.sessionID})
+			ctx := context.WithValue(req.Context(), auth.UserIDContextKey, userID)
+			ctx = context.WithValue(ctx, auth.SessionIDContextKey, "current-session")
+			req = req.WithContext(ctx)
+			rec := httptest.NewRecorder()
+
+			handler.RevokeSession(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			cleared := false
+			for _, cookie := range rec.Result().Cookies() {
+				if cookie.Name == "session_id" && cookie.MaxAge < 0 {
+					cleared = true
+				}
+			}
+			if cleared != tt.expectClearCookie {
+				t.Errorf("session cookie cleared = %v, want %v", cleared, tt.expectClearCookie)
+			}
+
+			if tt.expectedBody == nil {
+				return
+			}
+			var respBody map[string]interface{}
+			if err := json.NewDecoder(rec.Body).Decode(&respBody); err != nil {
+				t.Fatal(err)
+			}
+			for key, expectedValue := range tt.expectedBody {
+				if respBody[key] != expectedValue {
+					t.Errorf("expected %s = %v, got %v", key, expectedValue, respBody[key])
+				}
+			}
+		})
+	}
+}
+
+func TestUserHandler_Login_TwoFactorRequired(t *testing.T) {
+	mockUsecase := &mockUserUsecase{
+		loginFunc: func(ctx context.Context, input usecase.LoginInput) (*usecase.LoginOutput, error) {
+			return &usecase.LoginOutput{
+				TwoFactorRequired: true,
+				ChallengeToken:    "challenge-token",
+			}, nil
+		},
+	}
+	handler := NewUserHandler(mockUsecase)
+
+	body, _ := json.Marshal(LoginRequest{Email: "test@example.com", Password: "password123"})
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/login", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	handler.Login(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.Name == "session_id" {
+			t.Error("session cookie should not be set before two-factor verification")
+		}
+	}
+
+	var respBody TwoFactorChallengeResponse
+	if err := json.NewDecoder(rec.Body).Decode(&respBody); err != nil {
+		t.Fatal(err)
+	}
+	if !respBody.TwoFactorRequired || respBody.ChallengeToken != "challenge-token" {
+		t.Errorf("unexpected response: %+v", respBody)
+	}
+	if respBody.ExpiresIn != int(usecase.TwoFactorChallengeTTL.Seconds()) {
+		t.Errorf("expected expires_in %d, got %d", int(usecase.TwoFactorChallengeTTL.Seconds()), respBody.ExpiresIn)
+	}
+}
+
+func TestUserHandler_VerifyTwoFactor(t *testing.T) {
+	user, _ := entity.NewUser("test@example.com", "password123")
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockFunc       func(ctx context.Context, input usecase.VerifyTwoFactorInput) (*usecase.LoginOutput, error)
+		expectedStatus int
+		expectCookie   bool
+		expectedBody   map[string]interface{}
+	}{
+		{
+			name: "成功: コードを検証してセッションCookieを設定",
+			requestBody: VerifyTwoFactorRequest{
+				ChallengeToken: "challenge-token",
+				Code:           "123456",
+			},
+			mockFunc: func(ctx context.Context, input usecase.VerifyTwoFactorInput) (*usecase.LoginOutput, error) {
+				if input.ChallengeToken != "challenge-token" || input.Code != "123456" {
+					t.Errorf("unexpected input: %+v", input)
+				}
+				return &usecase.LoginOutput{User: user, SessionID: "session-id", SessionTTL: time.Hour}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectCookie:   true,
+			expectedBody: map[string]interface{}{
+				"email": "test@example.com",
+			},
+		},
+		{
+			name: "失敗: コードが一致しない",
+			requestBody: VerifyTwoFactorRequest{
+				ChallengeToken: "challenge-token",
+				Code:           "000000",
+			},
+			mockFunc: func(ctx context.Context, input usecase.VerifyTwoFactorInput) (*usecase.LoginOutput, error) {
+				return nil, entity.ErrInvalidTwoFactorCode
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"error": "Invalid two-factor authentication code",
+			},
+		},
+		{
+			name: "失敗: チャレンジが期限切れ",
+			requestBody: VerifyTwoFactorRequest{
+				ChallengeToken: "expired-token",
+				Code:           "123456",
+			},
+			mockFunc: func(ctx context.Context, input usecase.VerifyTwoFactorInput) (*usecase.LoginOutput, error) {
+				return nil, usecase.ErrInvalidTwoFactorChallenge
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody: map[string]interface{}{
+				"error": "Invalid or expired two-factor challenge",
+			},
+		},
+		{
+			name:           "失敗: リクエストボディが不正",
+			requestBody:    "invalid",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: map[string]interface{}{
+				"error": "Invalid request body",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := &mockUserUsecase{
+				verifyTwoFactorFunc: tt.mockFunc,
+			}
+			handler := NewUserHandler(mockUsecase)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/2fa/verify", bytes.NewReader(body))
+			rec := httptest.NewRecorder()
+
+			handler.VerifyTwoFactor(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			hasCookie := false
+			for _, cookie := range rec.Result().Cookies() {
+				if cookie.Name == "session_id" && cookie.Value == "session-id" {
+					hasCookie = true
+				}
+			}
+			if hasCookie != tt.expectCookie {
+				t.Errorf("session cookie set = %v, want %v", hasCookie, tt.expectCookie)
+			}
+
+			var respBody map[string]interface{}
+			if err := json.NewDecoder(rec.Body).Decode(&respBody); err != nil {
+				t.Fatal(err)
+			}
+			for key, expectedValue := range tt.expectedBody {
+				if respBody[key] != expectedValue {
+					t.Errorf("expected %s = %v, got %v", key, expectedValue, respBody[key])
+				}
+			}
+		})
+	}
+}
+
+func TestUserHandler_EnrollTwoFactor(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		mockFunc       func(ctx context.Context, userID uuid.UUID) (*usecase.TwoFactorEnrollment, error)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+	}{
+		{
+			name: "成功: 秘密鍵とotpauth URIを返す",
+			mockFunc: func(ctx context.Context, id uuid.UUID) (*usecase.TwoFactorEnrollment, error) {
+				if id != userID {
+					t.Errorf("unexpected user ID: %v", id)
+				}
+				return &usecase.TwoFactorEnrollment{
+					Secret:          "SECRET",
+					ProvisioningURI: "otpauth://totp/Whiskey:test@example.com?secret=SECRET",
+				}, nil
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: map[string]interface{}{
+				"secret":      "SECRET",
+				"otpauth_uri": "otpauth://totp/Whiskey:test@example.com?secret=SECRET",
+			},
+		},
+		{
+			name: "失敗: 既に有効",
+			mockFunc: func(ctx context.Context, id uuid.UUID) (*usecase.TwoFactorEnrollment, error) {
+				return nil, entity.ErrTwoFactorAlreadyEnabled
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody: map[string]interface{}{
+				"error": "Two-factor authentication is already enabled",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := &mockUserUsecase{
+				enrollTwoFactorFunc: tt.mockFunc,
+			}
+			handler := NewUserHandler(mockUsecase)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/2fa/enroll", nil)
+			req = req.WithContext(context.WithValue(req.Context(), auth.UserIDContextKey, userID))
+			rec := httptest.NewRecorder()
+
+			handler.EnrollTwoFactor(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			var respBody map[string]interface{}
+			if err := json.NewDecoder(rec.Body).Decode(&respBody); err != nil {
+				t.Fatal(err)
+			}
+			for key, expectedValue := range tt.expectedBody {
+				if respBody[key] != expectedValue {
+					t.Errorf("expected %s = %v, got %v", key, expectedValue, respBody[key])
+				}
+			}
+		})
+	}
+}
+
+func TestUserHandler_ConfirmTwoFactor(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name              string
+		requestBody       interface{}
+		mockFunc          func(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+		expectedStatus    int
+		expectedCodeCount int
+		expectedError     string
+	}{
+		{
+			name:        "成功: リカバリーコードを返す",
+			requestBody: ConfirmTwoFactorRequest{Code: "123456"},
+			mockFunc: func(ctx context.Context, id uuid.UUID, code string) ([]string, error) {
+				if id != userID || code != "123456" {
+					t.Errorf("unexpected args: %v, %s", id, code)
+				}
+				return []string{"aaaaa-bbbbb", "ccccc-ddddd"}, nil
+			},
+			expectedStatus:    http.StatusOK,
+			expectedCodeCount: 2,
+		},
+		{
+			name:        "失敗: コードが一致しない",
+			requestBody: ConfirmTwoFactorRequest{Code: "000000"},
+			mockFunc: func(ctx context.Context, id uuid.UUID, code string) ([]string, error) {
+				return nil, entity.ErrInvalidTwoFactorCode
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid two-factor authentication code",
+		},
+		{
+			name:        "失敗: 登録が開始されていない",
+			requestBody: ConfirmTwoFactorRequest{Code: "123456"},
+			mockFunc: func(ctx context.Context, id uuid.UUID, code string) ([]string, error) {
+				return nil, entity.ErrTwoFactorNotEnrolled
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Two-factor authentication enrollment has not been started",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := &mockUserUsecase{
+				confirmTwoFactorFunc: tt.mockFunc,
+			}
+			handler := NewUserHandler(mockUsecase)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/2fa/confirm", bytes.NewReader(body))
+			req = req.WithContext(context.WithValue(req.Context(), auth.UserIDContextKey, userID))
+			rec := httptest.NewRecorder()
+
+			handler.ConfirmTwoFactor(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			if tt.expectedError != "" {
+				var respBody ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&respBody); err != nil {
+					t.Fatal(err)
+				}
+				if respBody.Error != tt.expectedError {
+					t.Errorf("expected error %q, got %q", tt.expectedError, respBody.Error)
+				}
+				return
+			}
+
+			var respBody ConfirmTwoFactorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&respBody); err != nil {
+				t.Fatal(err)
+			}
+			if len(respBody.RecoveryCodes) != tt.expectedCodeCount {
+				t.Errorf("expected %d recovery codes, got %d", tt.expectedCodeCount, len(respBody.RecoveryCodes))
+			}
+		})
+	}
+}
+
+func TestUserHandler_DisableTwoFactor(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name           string
+		requestBody    interface{}
+		mockFunc       func(ctx context.Context, userID uuid.UUID, code string) error
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:        "成功: 二要素認証を無効化",
+			requestBody: DisableTwoFactorRequest{Code: "123456"},
+			mockFunc: func(ctx context.Context, id uuid.UUID, code string) error {
+				if id != userID || code != "123456" {
+					t.Errorf("unexpected args: %v, %s", id, code)
+				}
+				return nil
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:        "失敗: コードが一致しない",
+			requestBody: DisableTwoFactorRequest{Code: "000000"},
+			mockFunc: func(ctx context.Context, id uuid.UUID, code string) error {
+				return entity.ErrInvalidTwoFactorCode
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid two-factor authentication code",
+		},
+		{
+			name:        "失敗: 二要素認証が有効でない",
+			requestBody: DisableTwoFactorRequest{Code: "123456"},
+			mockFunc: func(ctx context.Context, id uuid.UUID, code string) error {
+				return entity.ErrTwoFactorNotEnabled
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "Two-factor authentication is not enabled",
+		},
+		{
+			name:        "失敗: コードの失敗が続いたためロック中",
+			requestBody: DisableTwoFactorRequest{Code: "123456"},
+			mockFunc: func(ctx context.Context, id uuid.UUID, code string) error {
+				return &usecase.RetryAfterError{Err: usecase.ErrAccountLocked, RetryAfter: time.Minute}
+			},
+			expectedStatus: http.StatusTooManyRequests,
+			expectedError:  "Too many failed login attempts, please try again later",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := &mockUserUsecase{
+				disableTwoFactorFunc: tt.mockFunc,
+			}
+			handler := NewUserHandler(mockUsecase)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/2fa/disable", bytes.NewReader(body))
+			req = req.WithContext(context.WithValue(req.Context(), auth.UserIDContextKey, userID))
+			rec := httptest.NewRecorder()
+
+			handler.DisableTwoFactor(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			if tt.expectedError != "" {
+				var respBody ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&respBody); err != nil {
+					t.Fatal(err)
+				}
+				if respBody.Error != tt.expectedError {
+					t.Errorf("expected error %q, got %q", tt.expectedError, respBody.Error)
+				}
+			}
+		})
+	}
+}
+
+func TestUserHandler_RegenerateRecoveryCodes(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name              string
+		requestBody       interface{}
+		mockFunc          func(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+		expectedStatus    int
+		expectedCodeCount int
+		expectedError     string
+	}{
+		{
+			name:        "成功: 新しいリカバリーコードを返す",
+			requestBody: RegenerateRecoveryCodesRequest{Code: "123456"},
+			mockFunc: func(ctx context.Context, id uuid.UUID, code string) ([]string, error) {
+				if id != userID || code != "123456" {
+					t.Errorf("unexpected args: %v, %s", id, code)
+				}
+				return []string{"aaaaa-bbbbb", "ccccc-ddddd"}, nil
+			},
+			expectedStatus:    http.StatusOK,
+			expectedCodeCount: 2,
+		},
+		{
+			name:        "失敗: コードが一致しない",
+			requestBody: RegenerateRecoveryCodesRequest{Code: "000000"},
+			mockFunc: func(ctx context.Context, id uuid.UUID, code string) ([]string, error) {
+				return nil, entity.ErrInvalidTwoFactorCode
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid two-factor authentication code",
+		},
+		{
+			name:        "失敗: 二要素認証が有効でない",
+			requestBody: RegenerateRecoveryCodesRequest{Code: "123456"},
+			mockFunc: func(ctx context.Context, id uuid.UUID, code string) ([]string, error) {
+				return nil, entity.ErrTwoFactorNotEnabled
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "Two-factor authentication is not enabled",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := &mockUserUsecase{
+				regenerateRecoveryCodesFunc: tt.mockFunc,
+			}
+			handler := NewUserHandler(mockUsecase)
+
+			body, _ := json.Marshal(tt.requestBody)
+			req := httptest.NewRequest(http.MethodPost, "/api/auth/2fa/recovery-codes", bytes.NewReader(body))
+			req = req.WithContext(context.WithValue(req.Context(), auth.UserIDContextKey, userID))
+			rec := httptest.NewRecorder()
+
+			handler.RegenerateRecoveryCodes(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			if tt.expectedError != "" {
+				var respBody ErrorResponse
+				if err := json.NewDecoder(rec.Body).Decode(&respBody); err != nil {
+					t.Fatal(err)
+				}
+				if respBody.Error != tt.expectedError {
+					t.Errorf("expected error %q, got %q", tt.expectedError, respBody.Error)
+				}
+				return
+			}
+
+			var respBody RegenerateRecoveryCodesResponse
+			if err := json.NewDecoder(rec.Body).Decode(&respBody); err != nil {
+				t.Fatal(err)
+			}
+			if len(respBody.RecoveryCodes) != tt.expectedCodeCount {
+				t.Errorf("expected %d recovery codes, got %d", tt.expectedCodeCount, len(respBody.RecoveryCodes))
+			}
+		})
+	}
+}
+
+func TestUserHandler_RateLimited(t *testing.T) {
+	tests := []struct {
+		name              string
+		path              string
+		requestBody       interface{}
+		mockUsecase       *mockUserUsecase
+		call              func(h *UserHandler, w http.ResponseWriter, r *http.Request)
+		expectedRetry     string
+		expectedErrorBody string
+	}{
+		{
+			name:        "失敗: アカウントロック中のログイン",
+			path:        "/api/auth/login",
+			requestBody: LoginRequest{Email: "test@example.com", Password: "password123"},
+			mockUsecase: &mockUserUsecase{
+				loginFunc: func(ctx context.Context, input usecase.LoginInput) (*usecase.LoginOutput, error) {
+					return nil, &usecase.RetryAfterError{Err: usecase.ErrAccountLocked, RetryAfter: 90*time.Second + 500*time.Millisecond}
+				},
+			},
+			call:              (*UserHandler).Login,
+			expectedRetry:     "91",
+			expectedErrorBody: "Too many failed login attempts, please try again later",
+		},
+		{
+			name:        "失敗: 確認メール再送の上限超過",
+			path:        "/api/auth/resend-verification",
+			requestBody: ResendVerificationRequest{Email: "test@example.com"},
+			mockUsecase: &mockUserUsecase{
+				resendVerificationEmailFunc: func(ctx context.Context, email string) error {
+					return &usecase.RetryAfterError{Err: usecase.ErrTooManyRequests, RetryAfter: 30 * time.Minute}
+				},
+			},
+			call:              (*UserHandler).ResendVerificationEmail,
+			expectedRetry:     "1800",
+			expectedErrorBody: "Too many requests",
+		},
+		{
+			name:        "失敗: パスワードリセット要求の上限超過",
+			path:        "/api/auth/forgot-password",
+			requestBody: ForgotPasswordRequest{Email: "test@example.com"},
+			mockUsecase: &mockUserUsecase{
+				requestPasswordResetFunc: func(ctx context.Context, email string) error {
+					return &usecase.RetryAfterError{Err: usecase.ErrTooManyRequests, RetryAfter: 10 * time.Second}
+				},
+			},
+			call:              (*UserHandler).ForgotPassword,
+			expectedRetry:     "10",
+			expectedErrorBody: "Too many requests",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewUserHandler(tt.mockUsecase)
+
+			var body bytes.Buffer
+			if err := json.NewEncoder(&body).Encode(tt.requestBody); err != nil {
+				t.Fatal(err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, &body)
+			rec := httptest.NewRecorder()
+
+			tt.call(handler, rec, req)
+
+			if rec.Code != http.StatusTooManyRequests {
+				t.Errorf("expected status %d, got %d", http.StatusTooManyRequests, rec.Code)
+			}
+			if got := rec.Header().Get("Retry-After"); got != tt.expectedRetry {
+				t.Errorf("Retry-After = %q, want %q", got, tt.expectedRetry)
+			}
+
+			var respBody map[string]interface{}
+			if err := json.NewDecoder(rec.Body).Decode(&respBody); err != nil {
+				t.Fatal(err)
+			}
+			if respBody["error"] != tt.expectedErrorBody {
+				t.Errorf("expected error = %v, got %v", tt.expectedErrorBody, respBody["error"])
+			}
+		})
+	}
+}
@@ -2,14 +2,19 @@ package handler
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/ucchy108/whiskey/backend/domain/entity"
-	"github.com/ucchy108/whiskey/backend/domain/service"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
 	"github.com/ucchy108/whiskey/backend/infrastructure/auth"
+	"github.com/ucchy108/whiskey/backend/pkg/logger"
 	"github.com/ucchy108/whiskey/backend/usecase"
 )
 
@@ -17,38 +22,54 @@ import (
 // Usecase層のビジネスロジックをRESTful APIとして公開する。
 type WorkoutHandler struct {
 	workoutUsecase usecase.WorkoutUsecaseInterface
+	profileUsecase usecase.ProfileUsecaseInterface
+	// newCSVWriter はCSV形式のエクスポートでファイルを書き出すwriterを生成する
+	newCSVWriter repository.WorkoutExportWriterFactory
 }
 
 // NewWorkoutHandler はWorkoutHandlerの新しいインスタンスを生成する。
 //
 // パラメータ:
 //   - workoutUsecase: ワークアウトに関するビジネスロジックを提供するユースケース
+//   - profileUsecase: 日付の解釈に使用するユーザーのタイムゾーンを提供するユースケース
+//   - newCSVWriter: CSV形式のエクスポートでファイルを書き出すwriterを生成する関数
 //
 // 戻り値:
 //   - *WorkoutHandler: 生成されたWorkoutHandlerインスタンス
-func NewWorkoutHandler(workoutUsecase usecase.WorkoutUsecaseInterface) *WorkoutHandler {
+func NewWorkoutHandler(workoutUsecase usecase.WorkoutUsecaseInterface, profileUsecase usecase.ProfileUsecaseInterface, newCSVWriter repository.WorkoutExportWriterFactory) *WorkoutHandler {
 	return &WorkoutHandler{
 		workoutUsecase: workoutUsecase,
+		profileUsecase: profileUsecase,
+		newCSVWriter:   newCSVWriter,
 	}
 }
 
 // --- リクエスト/レスポンスDTO ---
 
-// RecordWorkoutRequest はワークアウト記録APIのリクエストボディ
+// RecordWorkoutRequest はワークアウト記録APIのリクエストボディ。
+// StartedAt・EndedAtは省略可能で、StartedAtを省略した場合はDateを開始時刻とする。
+// ProgramIDを指定した場合は、そのプログラムの現在の日として記録する。
 type RecordWorkoutRequest struct {
-	Date string              `json:"date"`
-	Memo *string             `json:"memo"`
-	Sets []WorkoutSetRequest `json:"sets"`
+	Date      string              `json:"date"`
+	StartedAt *string             `json:"started_at"`
+	EndedAt   *string             `json:"ended_at"`
+	Memo      *string             `json:"memo"`
+	Sets      []WorkoutSetRequest `json:"sets"`
+	ProgramID *string             `json:"program_id"`
 }
 
-// WorkoutSetRequest はワークアウトセットのリクエストボディ
+// WorkoutSetRequest はワークアウトセットのリクエストボディ。
+// SetTypeを省略した場合は通常のセット（working）となる。RPEとRIRはどちらか一方のみ指定できる。
 type WorkoutSetRequest struct {
-	ExerciseID      string  `json:"exercise_id"`
-	SetNumber       int32   `json:"set_number"`
-	Reps            int32   `json:"reps"`
-	Weight          float64 `json:"weight"`
-	DurationSeconds *int32  `json:"duration_seconds"`
-	Notes           *string `json:"notes"`
+	ExerciseID      string   `json:"exercise_id"`
+	SetNumber       int32    `json:"set_number"`
+	Reps            int32    `json:"reps"`
+	Weight          float64  `json:"weight"`
+	DurationSeconds *int32   `json:"duration_seconds"`
+	Notes           *string  `json:"notes"`
+	SetType         *string  `json:"set_type"`
+	RPE             *float64 `json:"rpe"`
+	RIR             *int32   `json:"rir"`
 }
 
 // UpdateWorkoutMemoRequest はメモ更新APIのリクエストボディ
@@ -61,35 +82,94 @@ type AddWorkoutSetsRequest struct {
 	Sets []WorkoutSetRequest `json:"sets"`
 }
 
-// WorkoutResponse はワークアウトのレスポンスボディ
+// UpdateWorkoutSetRequest はセット更新APIのリクエストボディ。
+// 省略（null）したフィールドは更新しない。RPEまたはRIRを指定した場合、指定しなかった方は消去する。
+type UpdateWorkoutSetRequest struct {
+	Reps            *int32   `json:"reps"`
+	Weight          *float64 `json:"weight"`
+	DurationSeconds *int32   `json:"duration_seconds"`
+	Notes           *string  `json:"notes"`
+	SetType         *string  `json:"set_type"`
+	RPE             *float64 `json:"rpe"`
+	RIR             *int32   `json:"rir"`
+}
+
+// WorkoutResponse はワークアウトのレスポンスボディ。
+// EndedAt・DurationSecondsは終了時刻が記録されていない場合はnullとなる。
 type WorkoutResponse struct {
-	ID         string  `json:"id"`
-	UserID     string  `json:"user_id"`
-	Date       string  `json:"date"`
-	DailyScore int32   `json:"daily_score"`
-	Memo       *string `json:"memo"`
-	CreatedAt  string  `json:"created_at"`
-	UpdatedAt  string  `json:"updated_at"`
+	ID              string  `json:"id"`
+	UserID          string  `json:"user_id"`
+	Date            string  `json:"date"`
+	StartedAt       string  `json:"started_at"`
+	EndedAt         *string `json:"ended_at"`
+	DurationSeconds *int64  `json:"duration_seconds"`
+	DailyScore      int32   `json:"daily_score"`
+	Memo            *string `json:"memo"`
+	CreatedAt       string  `json:"created_at"`
+	UpdatedAt       string  `json:"updated_at"`
+}
+
+// WorkoutListResponse はワークアウト一覧APIのレスポンスボディ。
+// NextCursorは次のページが存在しない場合はnullとなる。
+type WorkoutListResponse struct {
+	Workouts   []WorkoutListItemResponse `json:"workouts"`
+	NextCursor *string                   `json:"next_cursor"`
+}
+
+// WorkoutListItemResponse はワークアウト一覧の1件分のレスポンスボディ。
+// Sets/Exercisesはexpandで指定された場合のみ、Summaryはexpandが指定された場合のみ含まれる。
+type WorkoutListItemResponse struct {
+	WorkoutResponse
+	Sets      *[]WorkoutSetResponse      `json:"sets,omitempty"`
+	Exercises *[]WorkoutExerciseResponse `json:"exercises,omitempty"`
+	Summary   *WorkoutSummaryResponse    `json:"summary,omitempty"`
+}
+
+// WorkoutExerciseResponse はワークアウト内で実施された種目のレスポンスボディ
+type WorkoutExerciseResponse struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	BodyPart *string `json:"body_part"`
+}
+
+// WorkoutSummaryResponse はワークアウト1件分の集計値のレスポンスボディ
+type WorkoutSummaryResponse struct {
+	TotalVolume   float64  `json:"total_volume"`
+	SetCount      int32    `json:"set_count"`
+	ExerciseCount int32    `json:"exercise_count"`
+	BodyParts     []string `json:"body_parts"`
 }
 
 // WorkoutSetResponse はワークアウトセットのレスポンスボディ
 type WorkoutSetResponse struct {
-	ID              string  `json:"id"`
-	WorkoutID       string  `json:"workout_id"`
-	ExerciseID      string  `json:"exercise_id"`
-	SetNumber       int32   `json:"set_number"`
-	Reps            int32   `json:"reps"`
-	Weight          float64 `json:"weight"`
-	Estimated1RM    float64 `json:"estimated_1rm"`
-	DurationSeconds *int32  `json:"duration_seconds"`
-	Notes           *string `json:"notes"`
-	CreatedAt       string  `json:"created_at"`
+	ID              string   `json:"id"`
+	WorkoutID       string   `json:"workout_id"`
+	ExerciseID      string   `json:"exercise_id"`
+	SetNumber       int32    `json:"set_number"`
+	Reps            int32    `json:"reps"`
+	Weight          float64  `json:"weight"`
+	Estimated1RM    float64  `json:"estimated_1rm"`
+	DurationSeconds *int32   `json:"duration_seconds"`
+	Notes           *string  `json:"notes"`
+	SetType         string   `json:"set_type"`
+	RPE             *float64 `json:"rpe"`
+	RIR             *int32   `json:"rir"`
+	CreatedAt       string   `json:"created_at"`
 }
 
-// RecordWorkoutResponse はワークアウト記録APIのレスポンスボディ
+// RecordWorkoutResponse はワークアウト記録APIのレスポンスボディ。
+// PersonalRecords には今回の記録で更新された自己ベストが含まれる。
 type RecordWorkoutResponse struct {
-	Workout WorkoutResponse      `json:"workout"`
-	Sets    []WorkoutSetResponse `json:"sets"`
+	Workout         WorkoutResponse          `json:"workout"`
+	Sets            []WorkoutSetResponse     `json:"sets"`
+	PersonalRecords []PersonalRecordResponse `json:"personal_records"`
+}
+
+// AddWorkoutSetsResponse はセット追加APIのレスポンスボディ。
+// PersonalRecords には追加したセットで更新された自己ベストが含まれる。
+type AddWorkoutSetsResponse struct {
+	Sets            []WorkoutSetResponse     `json:"sets"`
+	PersonalRecords []PersonalRecordResponse `json:"personal_records"`
 }
 
 // WorkoutDetailResponse はワークアウト詳細APIのレスポンスボディ
@@ -100,28 +180,34 @@ type WorkoutDetailResponse struct {
 
 // ContributionDataPointResponse はコントリビューションデータポイントのレスポンスボディ
 type ContributionDataPointResponse struct {
-	Date       string `json:"date"`
-	DailyScore int32  `json:"daily_score"`
+	Date         string `json:"date"`
+	DailyScore   int32  `json:"daily_score"`
+	SessionCount int32  `json:"session_count"`
 }
 
 // --- ハンドラーメソッド ---
 
-// RecordWorkout は新しいワークアウトを記録する。
+// RecordWorkout は新しいワークアウト（セッション）を記録する。
+// 同じ日に複数のセッションを記録できる。
 // POST /api/workouts
 //
 // リクエストボディ:
 //
 //	{
 //	  "date": "2026-01-15T00:00:00Z",
+//	  "started_at": "2026-01-15T18:00:00+09:00",
+//	  "ended_at": "2026-01-15T19:15:00+09:00",
 //	  "memo": "Good workout",
-//	  "sets": [{"exercise_id": "...", "set_number": 1, "reps": 10, "weight": 60.0}]
+//	  "sets": [{"exercise_id": "...", "set_number": 1, "reps": 10, "weight": 60.0}],
+//	  "program_id": "..."
 //	}
 //
 // レスポンス:
-//   - 201 Created: 記録成功
+//   - 201 Created: 記録成功（更新された自己ベストを personal_records に含む）
 //   - 400 Bad Request: リクエストボディが不正、バリデーションエラー
-//   - 404 Not Found: エクササイズが見つからない
-//   - 409 Conflict: 同日に既にワークアウトが存在
+//   - 403 Forbidden: 指定したプログラムへのアクセス権がない
+//   - 404 Not Found: エクササイズまたはプログラムが見つからない
+//   - 409 Conflict: 指定したプログラムは全日程を終えている
 //   - 500 Internal Server Error: サーバーエラー
 func (h *WorkoutHandler) RecordWorkout(w http.ResponseWriter, r *http.Request) {
 	userID := auth.GetUserIDFromContext(r.Context())
@@ -132,9 +218,26 @@ func (h *WorkoutHandler) RecordWorkout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	date, err := time.Parse(time.RFC3339, req.Date)
+	loc, err := h.profileUsecase.GetLocation(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	date, err := parseDate(req.Date, loc)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid date format, expected YYYY-MM-DD or RFC3339")
+		return
+	}
+
+	startedAt, err := parseOptionalTime(req.StartedAt)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid date format, expected RFC3339")
+		respondError(w, http.StatusBadRequest, "Invalid started_at format, expected RFC3339")
+		return
+	}
+
+	endedAt, err := parseOptionalTime(req.EndedAt)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid ended_at format, expected RFC3339")
 		return
 	}
 
@@ -145,10 +248,20 @@ func (h *WorkoutHandler) RecordWorkout(w http.ResponseWriter, r *http.Request) {
 	}
 
 	input := usecase.RecordWorkoutInput{
-		UserID: userID,
-		Date:   date,
-		Memo:   req.Memo,
-		Sets:   setInputs,
+		UserID:    userID,
+		Date:      date,
+		StartedAt: startedAt,
+		EndedAt:   endedAt,
+		Memo:      req.Memo,
+		Sets:      setInputs,
+	}
+	if req.ProgramID != nil {
+		programID, err := uuid.Parse(*req.ProgramID)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid program ID")
+			return
+		}
+		input.ProgramID = &programID
 	}
 
 	output, err := h.workoutUsecase.RecordWorkout(r.Context(), input)
@@ -158,19 +271,29 @@ func (h *WorkoutHandler) RecordWorkout(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp := RecordWorkoutResponse{
-		Workout: toWorkoutResponse(output.Workout),
-		Sets:    toWorkoutSetResponses(output.Sets),
+		Workout:         toWorkoutResponse(output.Workout),
+		Sets:            toWorkoutSetResponses(output.Sets),
+		PersonalRecords: toPersonalRecordResponses(output.PersonalRecords),
 	}
 
 	respondJSON(w, http.StatusCreated, resp)
 }
 
-// GetUserWorkouts はユーザーのワークアウト一覧を取得する。
-// GET /api/workouts?start_date=...&end_date=...
+// GetUserWorkouts はユーザーのワークアウト一覧をページ単位で取得する。
+// GET /api/workouts?start_date=...&end_date=...&cursor=...&limit=...
 //
 // クエリパラメータ:
-//   - start_date: 開始日（RFC3339形式、省略可）
-//   - end_date: 終了日（RFC3339形式、省略可）
+//   - start_date: 開始日（YYYY-MM-DDまたはRFC3339形式、省略可）
+//   - end_date: 終了日（YYYY-MM-DDまたはRFC3339形式、省略可）
+//   - exercise_id: 指定した種目を含むワークアウトに絞り込む（省略可）
+//   - body_part: 指定した身体部位の種目を含むワークアウトに絞り込む（省略可）
+//   - min_score: デイリースコアの下限（省略可）
+//   - memo: メモの部分一致検索（大文字小文字を区別しない、省略可）
+//   - sort: 並び順（date_desc または date_asc、省略時は date_desc）
+//   - cursor: 前ページのレスポンスの next_cursor（省略時は先頭ページ）
+//   - limit: 取得件数（1〜100、省略時は20）
+//   - expand: 各ワークアウトに含める追加情報（sets, exercises をカンマ区切りで指定、省略可）
+//     いずれかを指定した場合はサマリー（総ボリューム・セット数・種目数・身体部位）も含まれる
 //
 // レスポンス:
 //   - 200 OK: 取得成功
@@ -178,36 +301,110 @@ func (h *WorkoutHandler) RecordWorkout(w http.ResponseWriter, r *http.Request) {
 //   - 500 Internal Server Error: サーバーエラー
 func (h *WorkoutHandler) GetUserWorkouts(w http.ResponseWriter, r *http.Request) {
 	userID := auth.GetUserIDFromContext(r.Context())
+	query := r.URL.Query()
 
-	var startDate, endDate *time.Time
+	input := usecase.GetUserWorkoutsInput{
+		UserID: userID,
+		Sort:   usecase.WorkoutSortOrder(query.Get("sort")),
+	}
+	loc, err := h.profileUsecase.GetLocation(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
 
-	if s := r.URL.Query().Get("start_date"); s != "" {
-		t, err := time.Parse(time.RFC3339, s)
+	if s := query.Get("start_date"); s != "" {
+		t, err := parseDate(s, loc)
 		if err != nil {
-			respondError(w, http.StatusBadRequest, "Invalid start_date format, expected RFC3339")
+			respondError(w, http.StatusBadRequest, "Invalid start_date format, expected YYYY-MM-DD or RFC3339")
 			return
 		}
-		startDate = &t
+		input.Filter.StartDate = &t
 	}
 
-	if e := r.URL.Query().Get("end_date"); e != "" {
-		t, err := time.Parse(time.RFC3339, e)
+	if e := query.Get("end_date"); e != "" {
+		t, err := parseDate(e, loc)
 		if err != nil {
-			respondError(w, http.StatusBadRequest, "Invalid end_date format, expected RFC3339")
+			respondError(w, http.StatusBadRequest, "Invalid end_date format, expected YYYY-MM-DD or RFC3339")
 			return
 		}
-		endDate = &t
+		input.Filter.EndDate = &t
+	}
+
+	if id := query.Get("exercise_id"); id != "" {
+		exerciseID, err := uuid.Parse(id)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid exercise ID")
+			return
+		}
+		input.Filter.ExerciseID = &exerciseID
+	}
+
+	if bp := query.Get("body_part"); bp != "" {
+		bodyPart := entity.BodyPart(bp)
+		input.Filter.BodyPart = &bodyPart
 	}
 
-	workouts, err := h.workoutUsecase.GetUserWorkouts(r.Context(), userID, startDate, endDate)
+	if ms := query.Get("min_score"); ms != "" {
+		minScore, err := strconv.ParseInt(ms, 10, 32)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid min_score, expected integer")
+			return
+		}
+		v := int32(minScore)
+		input.Filter.MinScore = &v
+	}
+
+	if memo := query.Get("memo"); memo != "" {
+		input.Filter.MemoQuery = &memo
+	}
+
+	if c := query.Get("cursor"); c != "" {
+		input.Cursor = &c
+	}
+
+	if l := query.Get("limit"); l != "" {
+		limit, err := strconv.ParseInt(l, 10, 32)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid limit, expected integer")
+			return
+		}
+		v := int32(limit)
+		input.Limit = &v
+	}
+
+	expandSets, expandExercises, ok := parseWorkoutExpand(query["expand"])
+	if !ok {
+		respondError(w, http.StatusBadRequest, "Invalid expand, expected sets or exercises")
+		return
+	}
+	input.WithDetails = expandSets || expandExercises
+
+	output, err := h.workoutUsecase.GetUserWorkouts(r.Context(), input)
 	if err != nil {
 		handleWorkoutUsecaseError(w, err)
 		return
 	}
 
-	resp := make([]WorkoutResponse, 0, len(workouts))
-	for _, workout := range workouts {
-		resp = append(resp, toWorkoutResponse(workout))
+	resp := WorkoutListResponse{
+		Workouts:   make([]WorkoutListItemResponse, 0, len(output.Workouts)),
+		NextCursor: output.NextCursor,
+	}
+	for _, workout := range output.Workouts {
+		item := WorkoutListItemResponse{WorkoutResponse: toWorkoutResponse(workout)}
+		if detail, ok := output.Details[workout.ID]; ok {
+			if expandSets {
+				sets := toWorkoutSetResponses(detail.Sets)
+				item.Sets = &sets
+			}
+			if expandExercises {
+				exercises := toWorkoutExerciseResponses(detail.Exercises)
+				item.Exercises = &exercises
+			}
+			summary := toWorkoutSummaryResponse(detail.Summary)
+			item.Summary = &summary
+		}
+		resp.Workouts = append(resp.Workouts, item)
 	}
 
 	respondJSON(w, http.StatusOK, resp)
@@ -305,7 +502,7 @@ func (h *WorkoutHandler) UpdateWorkoutMemo(w http.ResponseWriter, r *http.Reques
 //	}
 //
 // レスポンス:
-//   - 201 Created: 追加成功
+//   - 201 Created: 追加成功（更新された自己ベストを personal_records に含む）
 //   - 400 Bad Request: リクエストが不正
 //   - 403 Forbidden: アクセス権がない
 //   - 404 Not Found: ワークアウトまたはエクササイズが見つからない
@@ -332,13 +529,16 @@ func (h *WorkoutHandler) AddWorkoutSets(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	sets, err := h.workoutUsecase.AddWorkoutSets(r.Context(), userID, workoutID, setInputs)
+	output, err := h.workoutUsecase.AddWorkoutSets(r.Context(), userID, workoutID, setInputs)
 	if err != nil {
 		handleWorkoutUsecaseError(w, err)
 		return
 	}
 
-	respondJSON(w, http.StatusCreated, toWorkoutSetResponses(sets))
+	respondJSON(w, http.StatusCreated, AddWorkoutSetsResponse{
+		Sets:            toWorkoutSetResponses(output.Sets),
+		PersonalRecords: toPersonalRecordResponses(output.PersonalRecords),
+	})
 }
 
 // DeleteWorkout はワークアウトを削除する。
@@ -371,6 +571,62 @@ func (h *WorkoutHandler) DeleteWorkout(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// UpdateWorkoutSet はワークアウトセットを部分更新する。
+// PUT /api/workout-sets/{id}
+//
+// パスパラメータ:
+//   - id: ワークアウトセットID (UUID)
+//
+// リクエストボディ（省略したフィールドは変更なし）:
+//
+//	{
+//	  "reps": 8,
+//	  "weight": 62.5,
+//	  "duration_seconds": 60,
+//	  "notes": "Updated notes"
+//	}
+//
+// レスポンス:
+//   - 200 OK: 更新成功
+//   - 400 Bad Request: リクエストが不正、バリデーションエラー
+//   - 403 Forbidden: アクセス権がない
+//   - 404 Not Found: ワークアウトセットが見つからない
+//   - 500 Internal Server Error: サーバーエラー
+func (h *WorkoutHandler) UpdateWorkoutSet(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+
+	vars := mux.Vars(r)
+	workoutSetID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid workout set ID")
+		return
+	}
+
+	var req UpdateWorkoutSetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	input := usecase.UpdateWorkoutSetInput{
+		Reps:            req.Reps,
+		Weight:          req.Weight,
+		DurationSeconds: req.DurationSeconds,
+		Notes:           req.Notes,
+		SetType:         toSetType(req.SetType),
+		RPE:             req.RPE,
+		RIR:             req.RIR,
+	}
+
+	set, err := h.workoutUsecase.UpdateWorkoutSet(r.Context(), userID, workoutSetID, input)
+	if err != nil {
+		handleWorkoutUsecaseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toWorkoutSetResponse(set))
+}
+
 // DeleteWorkoutSet はワークアウトセットを削除する。
 // DELETE /api/workout-sets/{id}
 //
@@ -405,8 +661,8 @@ func (h *WorkoutHandler) DeleteWorkoutSet(w http.ResponseWriter, r *http.Request
 // GET /api/workouts/contributions?start_date=...&end_date=...
 //
 // クエリパラメータ:
-//   - start_date: 開始日（RFC3339形式、必須）
-//   - end_date: 終了日（RFC3339形式、必須）
+//   - start_date: 開始日（YYYY-MM-DDまたはRFC3339形式、必須）
+//   - end_date: 終了日（YYYY-MM-DDまたはRFC3339形式、必須）
 //
 // レスポンス:
 //   - 200 OK: 取得成功
@@ -420,9 +676,14 @@ func (h *WorkoutHandler) GetContributionData(w http.ResponseWriter, r *http.Requ
 		respondError(w, http.StatusBadRequest, "start_date is required")
 		return
 	}
-	startDate, err := time.Parse(time.RFC3339, startDateStr)
+	loc, err := h.profileUsecase.GetLocation(r.Context(), userID)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid start_date format, expected RFC3339")
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	startDate, err := parseDate(startDateStr, loc)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid start_date format, expected YYYY-MM-DD or RFC3339")
 		return
 	}
 
@@ -431,9 +692,9 @@ func (h *WorkoutHandler) GetContributionData(w http.ResponseWriter, r *http.Requ
 		respondError(w, http.StatusBadRequest, "end_date is required")
 		return
 	}
-	endDate, err := time.Parse(time.RFC3339, endDateStr)
+	endDate, err := parseDate(endDateStr, loc)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid end_date format, expected RFC3339")
+		respondError(w, http.StatusBadRequest, "Invalid end_date format, expected YYYY-MM-DD or RFC3339")
 		return
 	}
 
@@ -446,14 +707,195 @@ func (h *WorkoutHandler) GetContributionData(w http.ResponseWriter, r *http.Requ
 	resp := make([]ContributionDataPointResponse, 0, len(dataPoints))
 	for _, dp := range dataPoints {
 		resp = append(resp, ContributionDataPointResponse{
-			Date:       dp.Date.Format(time.RFC3339),
-			DailyScore: dp.DailyScore,
+			Date:         dp.Date.Format(time.RFC3339),
+			DailyScore:   dp.DailyScore,
+			SessionCount: dp.SessionCount,
 		})
 	}
 
 	respondJSON(w, http.StatusOK, resp)
 }
 
+// WorkoutExportEntry はワークアウトのエクスポート（JSON形式）の1ワークアウト分
+type WorkoutExportEntry struct {
+	WorkoutResponse
+	Sets []WorkoutExportSetResponse `json:"sets"`
+}
+
+// WorkoutExportSetResponse はワークアウトのエクスポートに含めるセット（種目名・身体部位付き）。
+// BodyPartは種目に身体部位が設定されていない場合はnullとなる。
+type WorkoutExportSetResponse struct {
+	WorkoutSetResponse
+	ExerciseName string  `json:"exercise_name"`
+	BodyPart     *string `json:"body_part"`
+}
+
+// ExportWorkouts はユーザーのワークアウトとセットをファイルとしてダウンロードさせる。
+// GET /api/workouts/export?format=csv&start_date=...&end_date=...
+//
+// クエリパラメータ:
+//   - format: ファイル形式（csv または json、省略時は json）
+//   - start_date: 開始日（YYYY-MM-DDまたはRFC3339形式、省略可）
+//   - end_date: 終了日（YYYY-MM-DDまたはRFC3339形式、省略可）
+//
+// ワークアウトは日付の古い順に、全件をメモリに読み込まずに1件ずつ書き出す。
+// CSVはインポート（format=whiskey）でそのまま取り込める形式で、セットのないワークアウトはセットの列を空にした1行となる。
+// 書き出しの途中でエラーが発生した場合は接続を切断し、不完全なファイルを正常なレスポンスとして扱わせない。
+//
+// レスポンス:
+//   - 200 OK: エクスポート成功（Content-Disposition: attachment）
+//   - 400 Bad Request: クエリパラメータが不正
+//   - 500 Internal Server Error: サーバーエラー
+func (h *WorkoutHandler) ExportWorkouts(w http.ResponseWriter, r *http.Request) {
+	userID := auth.GetUserIDFromContext(r.Context())
+	query := r.URL.Query()
+
+	format := query.Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "csv" && format != "json" {
+		respondError(w, http.StatusBadRequest, "Invalid format, expected csv or json")
+		return
+	}
+
+	loc, err := h.profileUsecase.GetLocation(r.Context(), userID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+
+	var startDate, endDate *time.Time
+	if s := query.Get("start_date"); s != "" {
+		t, err := parseDate(s, loc)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid start_date format, expected YYYY-MM-DD or RFC3339")
+			return
+		}
+		startDate = &t
+	}
+	if e := query.Get("end_date"); e != "" {
+		t, err := parseDate(e, loc)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid end_date format, expected YYYY-MM-DD or RFC3339")
+			return
+		}
+		endDate = &t
+	}
+
+	// 最初の行を読み取れるまではヘッダーを書き出さず、エラーを通常のエラーレスポンスとして返す
+	var writer repository.WorkoutExportWriter
+	for row, err := range h.workoutUsecase.ExportWorkouts(r.Context(), userID, startDate, endDate) {
+		if err != nil {
+			if writer == nil {
+				respondError(w, http.StatusInternalServerError, "Internal server error")
+				return
+			}
+			abortWorkoutExport(r, err)
+		}
+		if writer == nil {
+			writer = h.startWorkoutExport(w, format, loc)
+		}
+		if err := writer.Write(row); err != nil {
+			abortWorkoutExport(r, err)
+		}
+	}
+	if writer == nil {
+		writer = h.startWorkoutExport(w, format, loc)
+	}
+	if err := writer.Flush(); err != nil {
+		abortWorkoutExport(r, err)
+	}
+}
+
+// startWorkoutExport はエクスポートのレスポンスヘッダーを書き出し、形式に応じたwriterを返す。
+func (h *WorkoutHandler) startWorkoutExport(w http.ResponseWriter, format string, loc *time.Location) repository.WorkoutExportWriter {
+	filename := fmt.Sprintf("whiskey-workouts-%s.%s", time.Now().In(loc).Format("20060102"), format)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		return h.newCSVWriter(w, loc)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	return &jsonWorkoutExportWriter{w: w, enc: json.NewEncoder(w)}
+}
+
+// abortWorkoutExport はレスポンスの書き出しを始めた後のエラーを記録し、接続を切断する。
+// ステータスコードは送信済みのため、切断によってクライアントに失敗を伝える。
+func abortWorkoutExport(r *http.Request, err error) {
+	logger.ErrorContext(r.Context(), "failed to export workouts", "error", err)
+	panic(http.ErrAbortHandler)
+}
+
+// jsonWorkoutExportWriter はワークアウトをJSON配列として1件ずつ書き出す。
+// 同じワークアウトの行は連続するため、ワークアウトが変わった時点で前のワークアウトを書き出す。
+type jsonWorkoutExportWriter struct {
+	w       io.Writer
+	enc     *json.Encoder
+	current *WorkoutExportEntry
+	started bool
+}
+
+// Write は行をワークアウトにまとめ、ワークアウトが変わった時点で前のワークアウトを書き出す。
+func (j *jsonWorkoutExportWriter) Write(row usecase.WorkoutExportRow) error {
+	if j.current == nil || j.current.ID != row.Workout.ID.String() {
+		if err := j.writeCurrent(); err != nil {
+			return err
+		}
+		j.current = &WorkoutExportEntry{
+			WorkoutResponse: toWorkoutResponse(row.Workout),
+			Sets:            []WorkoutExportSetResponse{},
+		}
+	}
+	if row.Set != nil {
+		set := WorkoutExportSetResponse{
+			WorkoutSetResponse: toWorkoutSetResponse(row.Set),
+			ExerciseName:       row.ExerciseName,
+		}
+		if row.BodyPart != nil {
+			bodyPart := string(*row.BodyPart)
+			set.BodyPart = &bodyPart
+		}
+		j.current.Sets = append(j.current.Sets, set)
+	}
+	return nil
+}
+
+// Flush は最後のワークアウトを書き出し、JSON配列を閉じる。
+func (j *jsonWorkoutExportWriter) Flush() error {
+	if err := j.writeCurrent(); err != nil {
+		return err
+	}
+	if !j.started {
+		_, err := io.WriteString(j.w, "[]\n")
+		return err
+	}
+	_, err := io.WriteString(j.w, "]\n")
+	return err
+}
+
+// writeCurrent はまとめ中のワークアウトがあれば配列の要素として書き出す。
+func (j *jsonWorkoutExportWriter) writeCurrent() error {
+	if j.current == nil {
+		return nil
+	}
+	sep := ","
+	if !j.started {
+		sep = "["
+		j.started = true
+	}
+	if _, err := io.WriteString(j.w, sep); err != nil {
+		return err
+	}
+	if err := j.enc.Encode(j.current); err != nil {
+		return err
+	}
+	j.current = nil
+	return nil
+}
+
 // WeightProgressionPointResponse は重量推移データポイントのレスポンスボディ
 type WeightProgressionPointResponse struct {
 	Date   string  `json:"date"`
@@ -466,9 +908,12 @@ type WeightProgressionPointResponse struct {
 // パスパラメータ:
 //   - id: エクササイズID (UUID)
 //
+// クエリパラメータ:
+//   - formula: 推定1RMの計算式（省略時はプロフィールで選択した公式）
+//
 // レスポンス:
 //   - 200 OK: 取得成功
-//   - 400 Bad Request: エクササイズIDが不正
+//   - 400 Bad Request: エクササイズIDまたは計算式が不正
 //   - 500 Internal Server Error: サーバーエラー
 func (h *WorkoutHandler) GetWeightProgression(w http.ResponseWriter, r *http.Request) {
 	userID := auth.GetUserIDFromContext(r.Context())
@@ -480,9 +925,15 @@ func (h *WorkoutHandler) GetWeightProgression(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	points, err := h.workoutUsecase.GetWeightProgression(r.Context(), userID, exerciseID)
+	var formula *entity.OneRMFormula
+	if f := r.URL.Query().Get("formula"); f != "" {
+		parsed := entity.OneRMFormula(f)
+		formula = &parsed
+	}
+
+	points, err := h.workoutUsecase.GetWeightProgression(r.Context(), userID, exerciseID, formula)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Internal server error")
+		handleWorkoutUsecaseError(w, err)
 		return
 	}
 
@@ -512,8 +963,17 @@ func handleWorkoutUsecaseError(w http.ResponseWriter, err error) {
 		respondError(w, http.StatusNotFound, "Workout set not found")
 	case usecase.ErrExerciseNotFound:
 		respondError(w, http.StatusNotFound, "Exercise not found")
-	case service.ErrDuplicateWorkoutDate:
-		respondError(w, http.StatusConflict, "Workout already exists for this date")
+	case usecase.ErrProgramNotFound:
+		respondError(w, http.StatusNotFound, "Program not found")
+	case usecase.ErrProgramAccessDenied:
+		respondError(w, http.StatusForbidden, "Access denied to this program")
+	case usecase.ErrProgramCompleted:
+		respondError(w, http.StatusConflict, "Program is already completed")
+	case usecase.ErrInvalidWorkoutCursor,
+		usecase.ErrInvalidWorkoutSort,
+		usecase.ErrInvalidWorkoutPageLimit,
+		usecase.ErrInvalidMinScore:
+		respondError(w, http.StatusBadRequest, err.Error())
 	default:
 		if isWorkoutValidationError(err) {
 			respondError(w, http.StatusBadRequest, err.Error())
@@ -532,6 +992,13 @@ func isWorkoutValidationError(err error) bool {
 		"exercise weight must be",
 		"duration must be",
 		"daily score must be",
+		"workout end time must not be",
+		"invalid 1RM formula",
+		"invalid body part",
+		"invalid set type",
+		"rpe must be",
+		"rir must be",
+		"rpe and rir cannot",
 	}
 
 	for _, ve := range validationErrors {
@@ -557,22 +1024,91 @@ func toSetInputs(reqs []WorkoutSetRequest) ([]usecase.SetInput, error) {
 			Weight:          s.Weight,
 			DurationSeconds: s.DurationSeconds,
 			Notes:           s.Notes,
+			SetType:         toSetType(s.SetType),
+			RPE:             s.RPE,
+			RIR:             s.RIR,
 		})
 	}
 	return setInputs, nil
 }
 
+// toSetType はリクエストのセットの種類をentity.SetTypeに変換する。
+// nilの場合はnilを返す（変更なし・デフォルト値を使用）。
+func toSetType(s *string) *entity.SetType {
+	if s == nil {
+		return nil
+	}
+	setType := entity.SetType(*s)
+	return &setType
+}
+
+// parseDate は日付文字列をユーザーのタイムゾーンで解釈する。
+// YYYY-MM-DD形式はlocの0時として、RFC3339形式はlocに変換した時刻として扱う。
+// いずれの場合も、暦日はlocにおける日付となる。
+func parseDate(s string, loc *time.Location) (time.Time, error) {
+	if t, err := time.ParseInLocation(time.DateOnly, s, loc); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.In(loc), nil
+}
+
+// parseOptionalTime は省略可能なRFC3339形式の時刻文字列をパースする。
+// nilの場合はnilを返す。
+func parseOptionalTime(s *string) (*time.Time, error) {
+	if s == nil {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, *s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// parseWorkoutExpand はexpandクエリパラメータを解析する。
+// カンマ区切り・複数指定のどちらも受け付け、未知の値が含まれる場合はokがfalseとなる。
+func parseWorkoutExpand(values []string) (sets, exercises, ok bool) {
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			switch strings.TrimSpace(v) {
+			case "sets":
+				sets = true
+			case "exercises":
+				exercises = true
+			case "":
+			default:
+				return false, false, false
+			}
+		}
+	}
+	return sets, exercises, true
+}
+
 // toWorkoutResponse はWorkoutエンティティをWorkoutResponseに変換する。
 func toWorkoutResponse(workout *entity.Workout) WorkoutResponse {
-	return WorkoutResponse{
+	resp := WorkoutResponse{
 		ID:         workout.ID.String(),
 		UserID:     workout.UserID.String(),
 		Date:       workout.Date.Format(time.RFC3339),
+		StartedAt:  workout.StartedAt.Format(time.RFC3339),
 		DailyScore: workout.DailyScore,
 		Memo:       workout.Memo,
 		CreatedAt:  workout.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:  workout.UpdatedAt.Format(time.RFC3339),
 	}
+	if workout.EndedAt != nil {
+		endedAt := workout.EndedAt.Format(time.RFC3339)
+		resp.EndedAt = &endedAt
+	}
+	if d := workout.Duration(); d != nil {
+		seconds := int64(d.Seconds())
+		resp.DurationSeconds = &seconds
+	}
+	return resp
 }
 
 // toWorkoutSetResponses はWorkoutSetエンティティのスライスをWorkoutSetResponseのスライスに変換する。
@@ -584,6 +1120,38 @@ func toWorkoutSetResponses(sets []*entity.WorkoutSet) []WorkoutSetResponse {
 	return resp
 }
 
+// toWorkoutExerciseResponses はワークアウト内の種目をWorkoutExerciseResponseのスライスに変換する。
+func toWorkoutExerciseResponses(exercises []usecase.WorkoutExerciseSummary) []WorkoutExerciseResponse {
+	resp := make([]WorkoutExerciseResponse, 0, len(exercises))
+	for _, e := range exercises {
+		var bodyPart *string
+		if e.BodyPart != nil {
+			bp := string(*e.BodyPart)
+			bodyPart = &bp
+		}
+		resp = append(resp, WorkoutExerciseResponse{
+			ID:       e.ID.String(),
+			Name:     e.Name,
+			BodyPart: bodyPart,
+		})
+	}
+	return resp
+}
+
+// toWorkoutSummaryResponse はワークアウトの集計値をWorkoutSummaryResponseに変換する。
+func toWorkoutSummaryResponse(summary usecase.WorkoutSummary) WorkoutSummaryResponse {
+	bodyParts := make([]string, 0, len(summary.BodyParts))
+	for _, bp := range summary.BodyParts {
+		bodyParts = append(bodyParts, string(bp))
+	}
+	return WorkoutSummaryResponse{
+		TotalVolume:   summary.TotalVolume,
+		SetCount:      summary.SetCount,
+		ExerciseCount: summary.ExerciseCount,
+		BodyParts:     bodyParts,
+	}
+}
+
 // toWorkoutSetResponse はWorkoutSetエンティティをWorkoutSetResponseに変換する。
 func toWorkoutSetResponse(set *entity.WorkoutSet) WorkoutSetResponse {
 	return WorkoutSetResponse{
@@ -596,6 +1164,9 @@ func toWorkoutSetResponse(set *entity.WorkoutSet) WorkoutSetResponse {
 		Estimated1RM:    set.Estimated1RM,
 		DurationSeconds: set.DurationSeconds,
 		Notes:           set.Notes,
+		SetType:         string(set.SetType),
+		RPE:             set.RPE,
+		RIR:             set.RIR,
 		CreatedAt:       set.CreatedAt.Format(time.RFC3339),
 	}
 }
@@ -5,30 +5,46 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"iter"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/ucchy108/whiskey/backend/domain/entity"
-	"github.com/ucchy108/whiskey/backend/domain/service"
 	"github.com/ucchy108/whiskey/backend/infrastructure/auth"
+	"github.com/ucchy108/whiskey/backend/infrastructure/csvimport"
+	"github.com/ucchy108/whiskey/backend/pkg/logger"
 	"github.com/ucchy108/whiskey/backend/usecase"
 )
 
+func TestMain(m *testing.M) {
+	logger.Init(logger.Config{
+		Level:  slog.LevelError,
+		Format: "text",
+	})
+	os.Exit(m.Run())
+}
+
 // mockWorkoutUsecase はWorkoutUsecaseのモック実装
 type mockWorkoutUsecase struct {
 	recordWorkoutFunc      func(ctx context.Context, input usecase.RecordWorkoutInput) (*usecase.RecordWorkoutOutput, error)
+	recordImportedWorkoutsFunc func(ctx context.Context, input usecase.RecordImportedWorkoutsInput) (*usecase.RecordImportedWorkoutsOutput, error)
 	getWorkoutFunc         func(ctx context.Context, userID, workoutID uuid.UUID) (*usecase.WorkoutDetailOutput, error)
-	getUserWorkoutsFunc    func(ctx context.Context, userID uuid.UUID, startDate, endDate *time.Time) ([]*entity.Workout, error)
+	getUserWorkoutsFunc    func(ctx context.Context, input usecase.GetUserWorkoutsInput) (*usecase.GetUserWorkoutsOutput, error)
 	updateWorkoutMemoFunc  func(ctx context.Context, userID, workoutID uuid.UUID, memo *string) (*entity.Workout, error)
-	addWorkoutSetsFunc     func(ctx context.Context, userID, workoutID uuid.UUID, sets []usecase.SetInput) ([]*entity.WorkoutSet, error)
+	addWorkoutSetsFunc     func(ctx context.Context, userID, workoutID uuid.UUID, sets []usecase.SetInput) (*usecase.AddWorkoutSetsOutput, error)
+	updateWorkoutSetFunc   func(ctx context.Context, userID, workoutSetID uuid.UUID, input usecase.UpdateWorkoutSetInput) (*entity.WorkoutSet, error)
 	deleteWorkoutSetFunc   func(ctx context.Context, userID uuid.UUID, workoutSetID uuid.UUID) error
 	deleteWorkoutFunc      func(ctx context.Context, userID, workoutID uuid.UUID) error
 	getContributionDataFunc    func(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]usecase.ContributionDataPoint, error)
-	getWeightProgressionFunc   func(ctx context.Context, userID, exerciseID uuid.UUID) ([]usecase.WeightProgressionPoint, error)
+	getWeightProgressionFunc   func(ctx context.Context, userID, exerciseID uuid.UUID, formula *entity.OneRMFormula) ([]usecase.WeightProgressionPoint, error)
+	exportWorkoutsFunc         func(ctx context.Context, userID uuid.UUID, startDate, endDate *time.Time) iter.Seq2[usecase.WorkoutExportRow, error]
 }
 
 func (m *mockWorkoutUsecase) RecordWorkout(ctx context.Context, input usecase.RecordWorkoutInput) (*usecase.RecordWorkoutOutput, error) {
@@ -38,6 +54,13 @@ func (m *mockWorkoutUsecase) RecordWorkout(ctx context.Context, input usecase.Re
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockWorkoutUsecase) RecordImportedWorkouts(ctx context.Context, input usecase.RecordImportedWorkoutsInput) (*usecase.RecordImportedWorkoutsOutput, error) {
+	if m.recordImportedWorkoutsFunc != nil {
+		return m.recordImportedWorkoutsFunc(ctx, input)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockWorkoutUsecase) GetWorkout(ctx context.Context, userID, workoutID uuid.UUID) (*usecase.WorkoutDetailOutput, error) {
 	if m.getWorkoutFunc != nil {
 		return m.getWorkoutFunc(ctx, userID, workoutID)
@@ -45,9 +68,9 @@ func (m *mockWorkoutUsecase) GetWorkout(ctx context.Context, userID, workoutID u
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockWorkoutUsecase) GetUserWorkouts(ctx context.Context, userID uuid.UUID, startDate, endDate *time.Time) ([]*entity.Workout, error) {
+func (m *mockWorkoutUsecase) GetUserWorkouts(ctx context.Context, input usecase.GetUserWorkoutsInput) (*usecase.GetUserWorkoutsOutput, error) {
 	if m.getUserWorkoutsFunc != nil {
-		return m.getUserWorkoutsFunc(ctx, userID, startDate, endDate)
+		return m.getUserWorkoutsFunc(ctx, input)
 	}
 	return nil, errors.New("not implemented")
 }
@@ -59,13 +82,20 @@ func (m *mockWorkoutUsecase) UpdateWorkoutMemo(ctx context.Context, userID, work
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockWorkoutUsecase) AddWorkoutSets(ctx context.Context, userID, workoutID uuid.UUID, sets []usecase.SetInput) ([]*entity.WorkoutSet, error) {
+func (m *mockWorkoutUsecase) AddWorkoutSets(ctx context.Context, userID, workoutID uuid.UUID, sets []usecase.SetInput) (*usecase.AddWorkoutSetsOutput, error) {
 	if m.addWorkoutSetsFunc != nil {
 		return m.addWorkoutSetsFunc(ctx, userID, workoutID, sets)
 	}
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockWorkoutUsecase) UpdateWorkoutSet(ctx context.Context, userID, workoutSetID uuid.UUID, input usecase.UpdateWorkoutSetInput) (*entity.WorkoutSet, error) {
+	if m.updateWorkoutSetFunc != nil {
+		return m.updateWorkoutSetFunc(ctx, userID, workoutSetID, input)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockWorkoutUsecase) DeleteWorkoutSet(ctx context.Context, userID uuid.UUID, workoutSetID uuid.UUID) error {
 	if m.deleteWorkoutSetFunc != nil {
 		return m.deleteWorkoutSetFunc(ctx, userID, workoutSetID)
@@ -87,13 +117,22 @@ func (m *mockWorkoutUsecase) GetContributionData(ctx context.Context, userID uui
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockWorkoutUsecase) GetWeightProgression(ctx context.Context, userID, exerciseID uuid.UUID) ([]usecase.WeightProgressionPoint, error) {
+func (m *mockWorkoutUsecase) GetWeightProgression(ctx context.Context, userID, exerciseID uuid.UUID, formula *entity.OneRMFormula) ([]usecase.WeightProgressionPoint, error) {
 	if m.getWeightProgressionFunc != nil {
-		return m.getWeightProgressionFunc(ctx, userID, exerciseID)
+		return m.getWeightProgressionFunc(ctx, userID, exerciseID, formula)
 	}
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockWorkoutUsecase) ExportWorkouts(ctx context.Context, userID uuid.UUID, startDate, endDate *time.Time) iter.Seq2[usecase.WorkoutExportRow, error] {
+	if m.exportWorkoutsFunc != nil {
+		return m.exportWorkoutsFunc(ctx, userID, startDate, endDate)
+	}
+	return func(yield func(usecase.WorkoutExportRow, error) bool) {
+		yield(usecase.WorkoutExportRow{}, errors.New("not implemented"))
+	}
+}
+
 // contextWithUserID はテスト用にユーザーIDをcontextにセットするヘルパー
 func contextWithUserID(ctx context.Context, userID uuid.UUID) context.Context {
 	return context.WithValue(ctx, auth.UserIDContextKey, userID)
@@ -102,11 +141,14 @@ func contextWithUserID(ctx context.Context, userID uuid.UUID) context.Context {
 func TestWorkoutHandler_RecordWorkout(t *testing.T) {
 	userID := uuid.New()
 	exerciseID := uuid.New()
+	programID := uuid.New()
 	workoutDate := "2026-01-15T00:00:00Z"
 
 	tests := []struct {
 		name           string
 		requestBody    interface{}
+		location       *time.Location
+		locationErr    error
 		mockFunc       func(ctx context.Context, input usecase.RecordWorkoutInput) (*usecase.RecordWorkoutOutput, error)
 		expectedStatus int
 		checkResponse  func(t *testing.T, body map[string]interface{})
@@ -142,6 +184,173 @@ func TestWorkoutHandler_RecordWorkout(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "成功: セットの種類とRPEを指定",
+			requestBody: RecordWorkoutRequest{
+				Date: workoutDate,
+				Sets: []WorkoutSetRequest{
+					{
+						ExerciseID: exerciseID.String(),
+						SetNumber:  1,
+						Reps:       10,
+						Weight:     60.0,
+						SetType:    strPtr("warmup"),
+						RPE:        float64Ptr(7.5),
+					},
+				},
+			},
+			mockFunc: func(ctx context.Context, input usecase.RecordWorkoutInput) (*usecase.RecordWorkoutOutput, error) {
+				in := input.Sets[0]
+				if in.SetType == nil || *in.SetType != entity.SetTypeWarmUp || in.RPE == nil || in.RIR != nil {
+					return nil, errors.New("set_type and rpe should be passed to usecase")
+				}
+				workout := entity.NewWorkout(input.UserID, input.Date)
+				set, _ := entity.NewWorkoutSet(workout.ID, exerciseID, 1, 10, 60.0)
+				_ = set.UpdateSetType(*in.SetType)
+				_ = set.UpdateEffort(in.RPE, in.RIR)
+				return &usecase.RecordWorkoutOutput{
+					Workout: workout,
+					Sets:    []*entity.WorkoutSet{set},
+				}, nil
+			},
+			expectedStatus: http.StatusCreated,
+			checkResponse: func(t *testing.T, body map[string]interface{}) {
+				set := body["sets"].([]interface{})[0].(map[string]interface{})
+				if set["set_type"] != "warmup" {
+					t.Errorf("expected set_type 'warmup', got %v", set["set_type"])
+				}
+				if set["rpe"] != 7.5 {
+					t.Errorf("expected rpe 7.5, got %v", set["rpe"])
+				}
+				if set["rir"] != nil {
+					t.Errorf("expected rir null, got %v", set["rir"])
+				}
+			},
+		},
+		{
+			name: "成功: 開始時刻と終了時刻を指定",
+			requestBody: RecordWorkoutRequest{
+				Date:      workoutDate,
+				StartedAt: strPtr("2026-01-15T18:00:00Z"),
+				EndedAt:   strPtr("2026-01-15T19:15:00Z"),
+				Sets: []WorkoutSetRequest{
+					{
+						ExerciseID: exerciseID.String(),
+						SetNumber:  1,
+						Reps:       10,
+						Weight:     60.0,
+					},
+				},
+			},
+			mockFunc: func(ctx context.Context, input usecase.RecordWorkoutInput) (*usecase.RecordWorkoutOutput, error) {
+				if input.StartedAt == nil || input.EndedAt == nil {
+					return nil, errors.New("started_at and ended_at should be passed to usecase")
+				}
+				workout := entity.NewWorkout(input.UserID, input.Date)
+				if err := workout.UpdateSessionTime(*input.StartedAt, input.EndedAt); err != nil {
+					return nil, err
+				}
+				set, _ := entity.NewWorkoutSet(workout.ID, exerciseID, 1, 10, 60.0)
+				return &usecase.RecordWorkoutOutput{
+					Workout: workout,
+					Sets:    []*entity.WorkoutSet{set},
+				}, nil
+			},
+			expectedStatus: http.StatusCreated,
+			checkResponse: func(t *testing.T, body map[string]interface{}) {
+				workout := body["workout"].(map[string]interface{})
+				if workout["started_at"] != "2026-01-15T18:00:00Z" {
+					t.Errorf("expected started_at '2026-01-15T18:00:00Z', got %v", workout["started_at"])
+				}
+				if workout["ended_at"] != "2026-01-15T19:15:00Z" {
+					t.Errorf("expected ended_at '2026-01-15T19:15:00Z', got %v", workout["ended_at"])
+				}
+				if workout["duration_seconds"] != 4500.0 {
+					t.Errorf("expected duration_seconds 4500, got %v", workout["duration_seconds"])
+				}
+			},
+		},
+		{
+			name: "成功: 更新された自己ベストを含む",
+			requestBody: RecordWorkoutRequest{
+				Date: workoutDate,
+				Sets: []WorkoutSetRequest{
+					{
+						ExerciseID: exerciseID.String(),
+						SetNumber:  1,
+						Reps:       1,
+						Weight:     100.0,
+					},
+				},
+			},
+			mockFunc: func(ctx context.Context, input usecase.RecordWorkoutInput) (*usecase.RecordWorkoutOutput, error) {
+				workout := entity.NewWorkout(input.UserID, input.Date)
+				set, _ := entity.NewWorkoutSet(workout.ID, exerciseID, 1, 1, 100.0)
+				previous := 95.0
+				record := entity.NewPersonalRecord(input.UserID, exerciseID, workout.ID, &set.ID, entity.PersonalRecordTypeEstimated1RM, nil, 100.0, &previous, input.Date)
+				return &usecase.RecordWorkoutOutput{
+					Workout:         workout,
+					Sets:            []*entity.WorkoutSet{set},
+					PersonalRecords: []*entity.PersonalRecord{record},
+				}, nil
+			},
+			expectedStatus: http.StatusCreated,
+			checkResponse: func(t *testing.T, body map[string]interface{}) {
+				records, ok := body["personal_records"].([]interface{})
+				if !ok || len(records) != 1 {
+					t.Fatalf("expected 1 personal record, got %v", body["personal_records"])
+				}
+				record := records[0].(map[string]interface{})
+				if record["record_type"] != "estimated_1rm" {
+					t.Errorf("expected record_type 'estimated_1rm', got %v", record["record_type"])
+				}
+				if record["previous_value"] != 95.0 {
+					t.Errorf("expected previous_value 95, got %v", record["previous_value"])
+				}
+			},
+		},
+		{
+			name: "成功: プログラムの日として記録",
+			requestBody: RecordWorkoutRequest{
+				Date:      workoutDate,
+				Sets:      []WorkoutSetRequest{{ExerciseID: exerciseID.String(), SetNumber: 1, Reps: 5, Weight: 80.0}},
+				ProgramID: strPtr(programID.String()),
+			},
+			mockFunc: func(ctx context.Context, input usecase.RecordWorkoutInput) (*usecase.RecordWorkoutOutput, error) {
+				if input.ProgramID == nil || *input.ProgramID != programID {
+					return nil, errors.New("program_id should be passed to usecase")
+				}
+				workout := entity.NewWorkout(input.UserID, input.Date)
+				set, _ := entity.NewWorkoutSet(workout.ID, exerciseID, 1, 5, 80.0)
+				return &usecase.RecordWorkoutOutput{
+					Workout: workout,
+					Sets:    []*entity.WorkoutSet{set},
+				}, nil
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "失敗: 不正なプログラムID",
+			requestBody: RecordWorkoutRequest{
+				Date:      workoutDate,
+				Sets:      []WorkoutSetRequest{{ExerciseID: exerciseID.String(), SetNumber: 1, Reps: 5, Weight: 80.0}},
+				ProgramID: strPtr("invalid-uuid"),
+			},
+			mockFunc:       nil,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "失敗: 全日程を終えたプログラム",
+			requestBody: RecordWorkoutRequest{
+				Date:      workoutDate,
+				Sets:      []WorkoutSetRequest{{ExerciseID: exerciseID.String(), SetNumber: 1, Reps: 5, Weight: 80.0}},
+				ProgramID: strPtr(programID.String()),
+			},
+			mockFunc: func(ctx context.Context, input usecase.RecordWorkoutInput) (*usecase.RecordWorkoutOutput, error) {
+				return nil, usecase.ErrProgramCompleted
+			},
+			expectedStatus: http.StatusConflict,
+		},
 		{
 			name:           "失敗: 不正なリクエストボディ",
 			requestBody:    "invalid json",
@@ -192,9 +401,9 @@ func TestWorkoutHandler_RecordWorkout(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
-			name: "失敗: 日付重複",
+			name: "成功: RFC3339の日付をユーザーのタイムゾーンの暦日として記録",
 			requestBody: RecordWorkoutRequest{
-				Date: workoutDate,
+				Date: "2026-02-06T15:30:00Z",
 				Sets: []WorkoutSetRequest{
 					{
 						ExerciseID: exerciseID.String(),
@@ -204,10 +413,101 @@ func TestWorkoutHandler_RecordWorkout(t *testing.T) {
 					},
 				},
 			},
+			location: time.FixedZone("JST", 9*60*60),
 			mockFunc: func(ctx context.Context, input usecase.RecordWorkoutInput) (*usecase.RecordWorkoutOutput, error) {
-				return nil, service.ErrDuplicateWorkoutDate
+				if got := input.Date.Format(time.DateOnly); got != "2026-02-07" {
+					t.Errorf("expected date 2026-02-07 in user's timezone, got %v", got)
+				}
+				return &usecase.RecordWorkoutOutput{Workout: entity.NewWorkout(input.UserID, input.Date)}, nil
 			},
-			expectedStatus: http.StatusConflict,
+			expectedStatus: http.StatusCreated,
+			checkResponse: func(t *testing.T, body map[string]interface{}) {
+				workout := body["workout"].(map[string]interface{})
+				if workout["date"] != "2026-02-07T00:00:00Z" {
+					t.Errorf("expected date 2026-02-07T00:00:00Z, got %v", workout["date"])
+				}
+			},
+		},
+		{
+			name: "成功: YYYY-MM-DD形式の日付",
+			requestBody: RecordWorkoutRequest{
+				Date: "2026-02-07",
+				Sets: []WorkoutSetRequest{
+					{
+						ExerciseID: exerciseID.String(),
+						SetNumber:  1,
+						Reps:       10,
+						Weight:     60.0,
+					},
+				},
+			},
+			location: time.FixedZone("JST", 9*60*60),
+			mockFunc: func(ctx context.Context, input usecase.RecordWorkoutInput) (*usecase.RecordWorkoutOutput, error) {
+				if got := input.Date.Format(time.RFC3339); got != "2026-02-07T00:00:00+09:00" {
+					t.Errorf("expected date 2026-02-07T00:00:00+09:00, got %v", got)
+				}
+				return &usecase.RecordWorkoutOutput{Workout: entity.NewWorkout(input.UserID, input.Date)}, nil
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "失敗: 不正な日付フォーマット",
+			requestBody: RecordWorkoutRequest{
+				Date: "2026/02/07",
+				Sets: []WorkoutSetRequest{},
+			},
+			mockFunc:       nil,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "失敗: 不正な開始時刻フォーマット",
+			requestBody: RecordWorkoutRequest{
+				Date:      workoutDate,
+				StartedAt: strPtr("18:00"),
+				Sets:      []WorkoutSetRequest{},
+			},
+			mockFunc:       nil,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "失敗: 終了時刻が開始時刻より前",
+			requestBody: RecordWorkoutRequest{
+				Date:      workoutDate,
+				StartedAt: strPtr("2026-02-07T18:00:00Z"),
+				EndedAt:   strPtr("2026-02-07T17:00:00Z"),
+				Sets: []WorkoutSetRequest{
+					{
+						ExerciseID: exerciseID.String(),
+						SetNumber:  1,
+						Reps:       10,
+						Weight:     60.0,
+					},
+				},
+			},
+			mockFunc: func(ctx context.Context, input usecase.RecordWorkoutInput) (*usecase.RecordWorkoutOutput, error) {
+				return nil, entity.ErrInvalidWorkoutEndTime
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "失敗: タイムゾーンを取得できない場合は日付を解釈しない",
+			requestBody: RecordWorkoutRequest{
+				Date: "2026-01-15",
+				Sets: []WorkoutSetRequest{
+					{
+						ExerciseID: exerciseID.String(),
+						SetNumber:  1,
+						Reps:       10,
+						Weight:     60.0,
+					},
+				},
+			},
+			locationErr: errors.New("db error"),
+			mockFunc: func(ctx context.Context, input usecase.RecordWorkoutInput) (*usecase.RecordWorkoutOutput, error) {
+				t.Error("RecordWorkout should not be called")
+				return nil, nil
+			},
+			expectedStatus: http.StatusInternalServerError,
 		},
 		{
 			name: "失敗: エクササイズが見つからない",
@@ -234,7 +534,13 @@ func TestWorkoutHandler_RecordWorkout(t *testing.T) {
 			mockUsecase := &mockWorkoutUsecase{
 				recordWorkoutFunc: tt.mockFunc,
 			}
-			handler := NewWorkoutHandler(mockUsecase)
+			profileUsecase := newMockProfileUsecaseWithLocation(tt.location)
+			if tt.locationErr != nil {
+				profileUsecase.getLocationFunc = func(ctx context.Context, userID uuid.UUID) (*time.Location, error) {
+					return nil, tt.locationErr
+				}
+			}
+			handler := NewWorkoutHandler(mockUsecase, profileUsecase, csvimport.NewExportWriter)
 
 			var body bytes.Buffer
 			if err := json.NewEncoder(&body).Encode(tt.requestBody); err != nil {
@@ -264,29 +570,178 @@ func TestWorkoutHandler_RecordWorkout(t *testing.T) {
 
 func TestWorkoutHandler_GetUserWorkouts(t *testing.T) {
 	userID := uuid.New()
+	exerciseID := uuid.New()
 
 	tests := []struct {
 		name           string
 		queryParams    string
-		mockFunc       func(ctx context.Context, userID uuid.UUID, startDate, endDate *time.Time) ([]*entity.Workout, error)
+		mockFunc       func(ctx context.Context, input usecase.GetUserWorkoutsInput) (*usecase.GetUserWorkoutsOutput, error)
 		expectedStatus int
+		checkResponse  func(t *testing.T, resp WorkoutListResponse)
 	}{
 		{
-			name:        "成功: 全ワークアウト取得",
+			name:        "成功: 先頭ページを取得",
 			queryParams: "",
-			mockFunc: func(ctx context.Context, uid uuid.UUID, startDate, endDate *time.Time) ([]*entity.Workout, error) {
-				workout := entity.NewWorkout(uid, time.Now())
-				return []*entity.Workout{workout}, nil
+			mockFunc: func(ctx context.Context, input usecase.GetUserWorkoutsInput) (*usecase.GetUserWorkoutsOutput, error) {
+				if input.Cursor != nil || input.Limit != nil || input.Sort != "" {
+					t.Errorf("expected no cursor, limit or sort, got %v, %v, %q", input.Cursor, input.Limit, input.Sort)
+				}
+				workout := entity.NewWorkout(input.UserID, time.Now())
+				return &usecase.GetUserWorkoutsOutput{
+					Workouts:   []*entity.Workout{workout},
+					NextCursor: strPtr("next"),
+				}, nil
 			},
 			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, resp WorkoutListResponse) {
+				if len(resp.Workouts) != 1 {
+					t.Errorf("expected 1 workout, got %d", len(resp.Workouts))
+				}
+				if resp.NextCursor == nil || *resp.NextCursor != "next" {
+					t.Errorf("expected next_cursor next, got %v", resp.NextCursor)
+				}
+			},
 		},
 		{
-			name:        "成功: 日付範囲でフィルタ",
-			queryParams: "?start_date=2026-01-01T00:00:00Z&end_date=2026-01-31T23:59:59Z",
-			mockFunc: func(ctx context.Context, uid uuid.UUID, startDate, endDate *time.Time) ([]*entity.Workout, error) {
-				return []*entity.Workout{}, nil
+			name:        "成功: 最終ページはnext_cursorがnull",
+			queryParams: "?cursor=abc&limit=10",
+			mockFunc: func(ctx context.Context, input usecase.GetUserWorkoutsInput) (*usecase.GetUserWorkoutsOutput, error) {
+				if input.Cursor == nil || *input.Cursor != "abc" {
+					t.Errorf("expected cursor abc, got %v", input.Cursor)
+				}
+				if input.Limit == nil || *input.Limit != 10 {
+					t.Errorf("expected limit 10, got %v", input.Limit)
+				}
+				return &usecase.GetUserWorkoutsOutput{Workouts: []*entity.Workout{}}, nil
 			},
 			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, resp WorkoutListResponse) {
+				if resp.Workouts == nil || len(resp.Workouts) != 0 {
+					t.Errorf("expected empty workouts array, got %v", resp.Workouts)
+				}
+				if resp.NextCursor != nil {
+					t.Errorf("expected null next_cursor, got %v", *resp.NextCursor)
+				}
+			},
+		},
+		{
+			name:        "成功: 絞り込みと並び順を指定",
+			queryParams: "?start_date=2026-01-01&end_date=2026-01-31&exercise_id=" + exerciseID.String() + "&body_part=chest&min_score=30&memo=bench&sort=date_asc",
+			mockFunc: func(ctx context.Context, input usecase.GetUserWorkoutsInput) (*usecase.GetUserWorkoutsOutput, error) {
+				f := input.Filter
+				if f.StartDate == nil || f.StartDate.Format(time.DateOnly) != "2026-01-01" ||
+					f.EndDate == nil || f.EndDate.Format(time.DateOnly) != "2026-01-31" {
+					t.Errorf("unexpected date range: %v - %v", f.StartDate, f.EndDate)
+				}
+				if f.ExerciseID == nil || *f.ExerciseID != exerciseID {
+					t.Errorf("expected exercise_id %v, got %v", exerciseID, f.ExerciseID)
+				}
+				if f.BodyPart == nil || *f.BodyPart != entity.BodyPartChest {
+					t.Errorf("expected body_part chest, got %v", f.BodyPart)
+				}
+				if f.MinScore == nil || *f.MinScore != 30 {
+					t.Errorf("expected min_score 30, got %v", f.MinScore)
+				}
+				if f.MemoQuery == nil || *f.MemoQuery != "bench" {
+					t.Errorf("expected memo bench, got %v", f.MemoQuery)
+				}
+				if input.Sort != "date_asc" {
+					t.Errorf("expected sort date_asc, got %q", input.Sort)
+				}
+				return &usecase.GetUserWorkoutsOutput{Workouts: []*entity.Workout{}}, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "成功: expandでセット・種目・サマリーを含める",
+			queryParams: "?expand=sets,exercises",
+			mockFunc: func(ctx context.Context, input usecase.GetUserWorkoutsInput) (*usecase.GetUserWorkoutsOutput, error) {
+				if !input.WithDetails {
+					t.Error("expected WithDetails to be true")
+				}
+				workout := entity.NewWorkout(input.UserID, time.Now())
+				set, _ := entity.NewWorkoutSet(workout.ID, exerciseID, 1, 10, 60)
+				chest := entity.BodyPartChest
+				return &usecase.GetUserWorkoutsOutput{
+					Workouts: []*entity.Workout{workout},
+					Details: map[uuid.UUID]*usecase.WorkoutListDetail{
+						workout.ID: {
+							Sets:      []*entity.WorkoutSet{set},
+							Exercises: []usecase.WorkoutExerciseSummary{{ID: exerciseID, Name: "ベンチプレス", BodyPart: &chest}},
+							Summary: usecase.WorkoutSummary{
+								TotalVolume:   600,
+								SetCount:      1,
+								ExerciseCount: 1,
+								BodyParts:     []entity.BodyPart{entity.BodyPartChest},
+							},
+						},
+					},
+				}, nil
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, resp WorkoutListResponse) {
+				if len(resp.Workouts) != 1 {
+					t.Fatalf("expected 1 workout, got %d", len(resp.Workouts))
+				}
+				item := resp.Workouts[0]
+				if item.Sets == nil || len(*item.Sets) != 1 {
+					t.Errorf("expected 1 set, got %v", item.Sets)
+				}
+				if item.Exercises == nil || len(*item.Exercises) != 1 || (*item.Exercises)[0].Name != "ベンチプレス" {
+					t.Errorf("expected exercise ベンチプレス, got %v", item.Exercises)
+				}
+				if item.Summary == nil || item.Summary.TotalVolume != 600 || item.Summary.SetCount != 1 ||
+					item.Summary.ExerciseCount != 1 || len(item.Summary.BodyParts) != 1 || item.Summary.BodyParts[0] != "chest" {
+					t.Errorf("unexpected summary: %+v", item.Summary)
+				}
+			},
+		},
+		{
+			name:        "成功: expand=exercisesのみの場合はセットを含めない",
+			queryParams: "?expand=exercises",
+			mockFunc: func(ctx context.Context, input usecase.GetUserWorkoutsInput) (*usecase.GetUserWorkoutsOutput, error) {
+				workout := entity.NewWorkout(input.UserID, time.Now())
+				return &usecase.GetUserWorkoutsOutput{
+					Workouts: []*entity.Workout{workout},
+					Details: map[uuid.UUID]*usecase.WorkoutListDetail{
+						workout.ID: {Summary: usecase.WorkoutSummary{BodyParts: []entity.BodyPart{}}},
+					},
+				}, nil
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, resp WorkoutListResponse) {
+				item := resp.Workouts[0]
+				if item.Sets != nil {
+					t.Errorf("expected sets to be omitted, got %v", *item.Sets)
+				}
+				if item.Exercises == nil || item.Summary == nil {
+					t.Errorf("expected exercises and summary, got %v, %v", item.Exercises, item.Summary)
+				}
+			},
+		},
+		{
+			name:        "成功: expand未指定の場合は詳細を含めない",
+			queryParams: "",
+			mockFunc: func(ctx context.Context, input usecase.GetUserWorkoutsInput) (*usecase.GetUserWorkoutsOutput, error) {
+				if input.WithDetails {
+					t.Error("expected WithDetails to be false")
+				}
+				workout := entity.NewWorkout(input.UserID, time.Now())
+				return &usecase.GetUserWorkoutsOutput{Workouts: []*entity.Workout{workout}}, nil
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, resp WorkoutListResponse) {
+				item := resp.Workouts[0]
+				if item.Sets != nil || item.Exercises != nil || item.Summary != nil {
+					t.Errorf("expected no details, got %+v", item)
+				}
+			},
+		},
+		{
+			name:           "失敗: 不正なexpand",
+			queryParams:    "?expand=sets,records",
+			mockFunc:       nil,
+			expectedStatus: http.StatusBadRequest,
 		},
 		{
 			name:           "失敗: 不正なstart_dateフォーマット",
@@ -300,6 +755,48 @@ func TestWorkoutHandler_GetUserWorkouts(t *testing.T) {
 			mockFunc:       nil,
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name:           "失敗: 不正なexercise_id",
+			queryParams:    "?exercise_id=invalid",
+			mockFunc:       nil,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "失敗: 数値でないlimit",
+			queryParams:    "?limit=ten",
+			mockFunc:       nil,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "失敗: 数値でないmin_score",
+			queryParams:    "?min_score=high",
+			mockFunc:       nil,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "失敗: 不正なカーソル",
+			queryParams: "?cursor=broken",
+			mockFunc: func(ctx context.Context, input usecase.GetUserWorkoutsInput) (*usecase.GetUserWorkoutsOutput, error) {
+				return nil, usecase.ErrInvalidWorkoutCursor
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "失敗: 不正な並び順",
+			queryParams: "?sort=score",
+			mockFunc: func(ctx context.Context, input usecase.GetUserWorkoutsInput) (*usecase.GetUserWorkoutsOutput, error) {
+				return nil, usecase.ErrInvalidWorkoutSort
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "失敗: 不正な身体部位",
+			queryParams: "?body_part=tail",
+			mockFunc: func(ctx context.Context, input usecase.GetUserWorkoutsInput) (*usecase.GetUserWorkoutsOutput, error) {
+				return nil, entity.ErrInvalidBodyPart
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -307,7 +804,7 @@ func TestWorkoutHandler_GetUserWorkouts(t *testing.T) {
 			mockUsecase := &mockWorkoutUsecase{
 				getUserWorkoutsFunc: tt.mockFunc,
 			}
-			handler := NewWorkoutHandler(mockUsecase)
+			handler := NewWorkoutHandler(mockUsecase, &mockProfileUsecase{}, csvimport.NewExportWriter)
 
 			req := httptest.NewRequest(http.MethodGet, "/api/workouts"+tt.queryParams, nil)
 			req = req.WithContext(contextWithUserID(req.Context(), userID))
@@ -316,7 +813,15 @@ func TestWorkoutHandler_GetUserWorkouts(t *testing.T) {
 			handler.GetUserWorkouts(rec, req)
 
 			if rec.Code != tt.expectedStatus {
-				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+				t.Fatalf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			if tt.checkResponse != nil {
+				var resp WorkoutListResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatal(err)
+				}
+				tt.checkResponse(t, resp)
 			}
 		})
 	}
@@ -373,7 +878,7 @@ func TestWorkoutHandler_GetWorkout(t *testing.T) {
 			mockUsecase := &mockWorkoutUsecase{
 				getWorkoutFunc: tt.mockFunc,
 			}
-			handler := NewWorkoutHandler(mockUsecase)
+			handler := NewWorkoutHandler(mockUsecase, &mockProfileUsecase{}, csvimport.NewExportWriter)
 
 			req := httptest.NewRequest(http.MethodGet, "/api/workouts/"+tt.workoutID, nil)
 			req = req.WithContext(contextWithUserID(req.Context(), userID))
@@ -446,7 +951,7 @@ func TestWorkoutHandler_UpdateWorkoutMemo(t *testing.T) {
 			mockUsecase := &mockWorkoutUsecase{
 				updateWorkoutMemoFunc: tt.mockFunc,
 			}
-			handler := NewWorkoutHandler(mockUsecase)
+			handler := NewWorkoutHandler(mockUsecase, &mockProfileUsecase{}, csvimport.NewExportWriter)
 
 			var body bytes.Buffer
 			if err := json.NewEncoder(&body).Encode(tt.requestBody); err != nil {
@@ -476,8 +981,10 @@ func TestWorkoutHandler_AddWorkoutSets(t *testing.T) {
 		name           string
 		workoutID      string
 		requestBody    interface{}
-		mockFunc       func(ctx context.Context, userID, workoutID uuid.UUID, sets []usecase.SetInput) ([]*entity.WorkoutSet, error)
+		mockFunc       func(ctx context.Context, userID, workoutID uuid.UUID, sets []usecase.SetInput) (*usecase.AddWorkoutSetsOutput, error)
 		expectedStatus int
+		// wantRecords は成功時のレスポンスに含まれる自己ベストの件数
+		wantRecords int
 	}{
 		{
 			name:      "成功: セット追加",
@@ -492,11 +999,17 @@ func TestWorkoutHandler_AddWorkoutSets(t *testing.T) {
 					},
 				},
 			},
-			mockFunc: func(ctx context.Context, uid, wid uuid.UUID, sets []usecase.SetInput) ([]*entity.WorkoutSet, error) {
+			mockFunc: func(ctx context.Context, uid, wid uuid.UUID, sets []usecase.SetInput) (*usecase.AddWorkoutSetsOutput, error) {
 				set, _ := entity.NewWorkoutSet(wid, exerciseID, 2, 8, 65.0)
-				return []*entity.WorkoutSet{set}, nil
+				setID := set.ID
+				record := entity.NewPersonalRecord(uid, exerciseID, wid, &setID, entity.PersonalRecordTypeEstimated1RM, nil, set.Estimated1RM, nil, time.Now())
+				return &usecase.AddWorkoutSetsOutput{
+					Sets:            []*entity.WorkoutSet{set},
+					PersonalRecords: []*entity.PersonalRecord{record},
+				}, nil
 			},
 			expectedStatus: http.StatusCreated,
+			wantRecords:    1,
 		},
 		{
 			name:           "失敗: 不正なワークアウトID",
@@ -541,7 +1054,7 @@ func TestWorkoutHandler_AddWorkoutSets(t *testing.T) {
 					},
 				},
 			},
-			mockFunc: func(ctx context.Context, uid, wid uuid.UUID, sets []usecase.SetInput) ([]*entity.WorkoutSet, error) {
+			mockFunc: func(ctx context.Context, uid, wid uuid.UUID, sets []usecase.SetInput) (*usecase.AddWorkoutSetsOutput, error) {
 				return nil, usecase.ErrExerciseNotFound
 			},
 			expectedStatus: http.StatusNotFound,
@@ -553,7 +1066,7 @@ func TestWorkoutHandler_AddWorkoutSets(t *testing.T) {
 			mockUsecase := &mockWorkoutUsecase{
 				addWorkoutSetsFunc: tt.mockFunc,
 			}
-			handler := NewWorkoutHandler(mockUsecase)
+			handler := NewWorkoutHandler(mockUsecase, &mockProfileUsecase{}, csvimport.NewExportWriter)
 
 			var body bytes.Buffer
 			if err := json.NewEncoder(&body).Encode(tt.requestBody); err != nil {
@@ -570,6 +1083,16 @@ func TestWorkoutHandler_AddWorkoutSets(t *testing.T) {
 			if rec.Code != tt.expectedStatus {
 				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
 			}
+
+			if rec.Code == http.StatusCreated {
+				var resp AddWorkoutSetsResponse
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				if len(resp.Sets) != 1 || len(resp.PersonalRecords) != tt.wantRecords {
+					t.Errorf("sets, personal_records = %d, %d, want 1, %d", len(resp.Sets), len(resp.PersonalRecords), tt.wantRecords)
+				}
+			}
 		})
 	}
 }
@@ -621,7 +1144,7 @@ func TestWorkoutHandler_DeleteWorkout(t *testing.T) {
 			mockUsecase := &mockWorkoutUsecase{
 				deleteWorkoutFunc: tt.mockFunc,
 			}
-			handler := NewWorkoutHandler(mockUsecase)
+			handler := NewWorkoutHandler(mockUsecase, &mockProfileUsecase{}, csvimport.NewExportWriter)
 
 			req := httptest.NewRequest(http.MethodDelete, "/api/workouts/"+tt.workoutID, nil)
 			req = req.WithContext(contextWithUserID(req.Context(), userID))
@@ -637,6 +1160,134 @@ func TestWorkoutHandler_DeleteWorkout(t *testing.T) {
 	}
 }
 
+func TestWorkoutHandler_UpdateWorkoutSet(t *testing.T) {
+	userID := uuid.New()
+	workoutSetID := uuid.New()
+	newWeight := 62.5
+
+	tests := []struct {
+		name           string
+		workoutSetID   string
+		requestBody    interface{}
+		mockFunc       func(ctx context.Context, userID, workoutSetID uuid.UUID, input usecase.UpdateWorkoutSetInput) (*entity.WorkoutSet, error)
+		expectedStatus int
+		checkResponse  func(t *testing.T, body map[string]interface{})
+	}{
+		{
+			name:         "成功: 重量のみ更新",
+			workoutSetID: workoutSetID.String(),
+			requestBody: UpdateWorkoutSetRequest{
+				Weight: &newWeight,
+			},
+			mockFunc: func(ctx context.Context, uid, wsID uuid.UUID, input usecase.UpdateWorkoutSetInput) (*entity.WorkoutSet, error) {
+				if input.Reps != nil || input.DurationSeconds != nil || input.Notes != nil {
+					t.Errorf("unexpected fields set in input: %+v", input)
+				}
+				set, _ := entity.NewWorkoutSet(uuid.New(), uuid.New(), 1, 10, *input.Weight)
+				return set, nil
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, body map[string]interface{}) {
+				if body["weight"] != 62.5 {
+					t.Errorf("expected weight 62.5, got %v", body["weight"])
+				}
+			},
+		},
+		{
+			name:           "失敗: 不正なワークアウトセットID",
+			workoutSetID:   "invalid-uuid",
+			requestBody:    UpdateWorkoutSetRequest{},
+			mockFunc:       nil,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "失敗: 不正なリクエストボディ",
+			workoutSetID:   workoutSetID.String(),
+			requestBody:    "invalid json",
+			mockFunc:       nil,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "失敗: バリデーションエラー",
+			workoutSetID: workoutSetID.String(),
+			requestBody:  UpdateWorkoutSetRequest{Weight: &newWeight},
+			mockFunc: func(ctx context.Context, uid, wsID uuid.UUID, input usecase.UpdateWorkoutSetInput) (*entity.WorkoutSet, error) {
+				return nil, entity.ErrInvalidReps
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "失敗: 不正なRPE",
+			workoutSetID: workoutSetID.String(),
+			requestBody:  UpdateWorkoutSetRequest{RPE: float64Ptr(11)},
+			mockFunc: func(ctx context.Context, uid, wsID uuid.UUID, input usecase.UpdateWorkoutSetInput) (*entity.WorkoutSet, error) {
+				return nil, entity.ErrInvalidRPE
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "失敗: 不正なセットの種類",
+			workoutSetID: workoutSetID.String(),
+			requestBody:  UpdateWorkoutSetRequest{SetType: strPtr("cooldown")},
+			mockFunc: func(ctx context.Context, uid, wsID uuid.UUID, input usecase.UpdateWorkoutSetInput) (*entity.WorkoutSet, error) {
+				return nil, entity.ErrInvalidSetType
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "失敗: ワークアウトセットが見つからない",
+			workoutSetID: workoutSetID.String(),
+			requestBody:  UpdateWorkoutSetRequest{Weight: &newWeight},
+			mockFunc: func(ctx context.Context, uid, wsID uuid.UUID, input usecase.UpdateWorkoutSetInput) (*entity.WorkoutSet, error) {
+				return nil, usecase.ErrWorkoutSetNotFound
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:         "失敗: アクセス拒否",
+			workoutSetID: workoutSetID.String(),
+			requestBody:  UpdateWorkoutSetRequest{Weight: &newWeight},
+			mockFunc: func(ctx context.Context, uid, wsID uuid.UUID, input usecase.UpdateWorkoutSetInput) (*entity.WorkoutSet, error) {
+				return nil, usecase.ErrWorkoutAccessDenied
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := &mockWorkoutUsecase{
+				updateWorkoutSetFunc: tt.mockFunc,
+			}
+			handler := NewWorkoutHandler(mockUsecase, &mockProfileUsecase{}, csvimport.NewExportWriter)
+
+			var body bytes.Buffer
+			if err := json.NewEncoder(&body).Encode(tt.requestBody); err != nil {
+				t.Fatal(err)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/api/workout-sets/"+tt.workoutSetID, &body)
+			req = req.WithContext(contextWithUserID(req.Context(), userID))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.workoutSetID})
+			rec := httptest.NewRecorder()
+
+			handler.UpdateWorkoutSet(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+
+			if tt.checkResponse != nil {
+				var respBody map[string]interface{}
+				if err := json.NewDecoder(rec.Body).Decode(&respBody); err != nil {
+					t.Fatal(err)
+				}
+				tt.checkResponse(t, respBody)
+			}
+		})
+	}
+}
+
 func TestWorkoutHandler_DeleteWorkoutSet(t *testing.T) {
 	userID := uuid.New()
 	workoutSetID := uuid.New()
@@ -676,7 +1327,7 @@ func TestWorkoutHandler_DeleteWorkoutSet(t *testing.T) {
 			mockUsecase := &mockWorkoutUsecase{
 				deleteWorkoutSetFunc: tt.mockFunc,
 			}
-			handler := NewWorkoutHandler(mockUsecase)
+			handler := NewWorkoutHandler(mockUsecase, &mockProfileUsecase{}, csvimport.NewExportWriter)
 
 			req := httptest.NewRequest(http.MethodDelete, "/api/workout-sets/"+tt.workoutSetID, nil)
 			req = req.WithContext(contextWithUserID(req.Context(), userID))
@@ -698,6 +1349,7 @@ func TestWorkoutHandler_GetContributionData(t *testing.T) {
 	tests := []struct {
 		name           string
 		queryParams    string
+		location       *time.Location
 		mockFunc       func(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]usecase.ContributionDataPoint, error)
 		expectedStatus int
 	}{
@@ -714,6 +1366,21 @@ func TestWorkoutHandler_GetContributionData(t *testing.T) {
 			},
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name:        "成功: YYYY-MM-DD形式の期間をユーザーのタイムゾーンで解釈",
+			queryParams: "?start_date=2026-01-01&end_date=2026-12-31",
+			location:    time.FixedZone("JST", 9*60*60),
+			mockFunc: func(ctx context.Context, uid uuid.UUID, startDate, endDate time.Time) ([]usecase.ContributionDataPoint, error) {
+				if got := startDate.Format(time.RFC3339); got != "2026-01-01T00:00:00+09:00" {
+					t.Errorf("expected start_date 2026-01-01T00:00:00+09:00, got %v", got)
+				}
+				if got := endDate.Format(time.RFC3339); got != "2026-12-31T00:00:00+09:00" {
+					t.Errorf("expected end_date 2026-12-31T00:00:00+09:00, got %v", got)
+				}
+				return []usecase.ContributionDataPoint{}, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
 		{
 			name:           "失敗: start_dateが未指定",
 			queryParams:    "?end_date=2026-12-31T23:59:59Z",
@@ -745,7 +1412,7 @@ func TestWorkoutHandler_GetContributionData(t *testing.T) {
 			mockUsecase := &mockWorkoutUsecase{
 				getContributionDataFunc: tt.mockFunc,
 			}
-			handler := NewWorkoutHandler(mockUsecase)
+			handler := NewWorkoutHandler(mockUsecase, newMockProfileUsecaseWithLocation(tt.location), csvimport.NewExportWriter)
 
 			req := httptest.NewRequest(http.MethodGet, "/api/workouts/contributions"+tt.queryParams, nil)
 			req = req.WithContext(contextWithUserID(req.Context(), userID))
@@ -760,6 +1427,80 @@ func TestWorkoutHandler_GetContributionData(t *testing.T) {
 	}
 }
 
+func TestWorkoutHandler_GetWeightProgression(t *testing.T) {
+	userID := uuid.New()
+	exerciseID := uuid.New()
+
+	tests := []struct {
+		name           string
+		exerciseID     string
+		queryParams    string
+		mockFunc       func(ctx context.Context, userID, exerciseID uuid.UUID, formula *entity.OneRMFormula) ([]usecase.WeightProgressionPoint, error)
+		expectedStatus int
+	}{
+		{
+			name:       "成功: 計算式の指定なし",
+			exerciseID: exerciseID.String(),
+			mockFunc: func(ctx context.Context, uid, eid uuid.UUID, formula *entity.OneRMFormula) ([]usecase.WeightProgressionPoint, error) {
+				if formula != nil {
+					t.Errorf("expected nil formula, got %v", *formula)
+				}
+				return []usecase.WeightProgressionPoint{
+					{Date: time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC), Max1RM: 100},
+				}, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "成功: 計算式を指定",
+			exerciseID:  exerciseID.String(),
+			queryParams: "?formula=brzycki",
+			mockFunc: func(ctx context.Context, uid, eid uuid.UUID, formula *entity.OneRMFormula) ([]usecase.WeightProgressionPoint, error) {
+				if formula == nil || *formula != entity.OneRMFormulaBrzycki {
+					t.Errorf("expected formula brzycki, got %v", formula)
+				}
+				return []usecase.WeightProgressionPoint{}, nil
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "失敗: 不正なエクササイズID",
+			exerciseID:     "invalid-uuid",
+			mockFunc:       nil,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "失敗: 不正な計算式",
+			exerciseID:  exerciseID.String(),
+			queryParams: "?formula=unknown",
+			mockFunc: func(ctx context.Context, uid, eid uuid.UUID, formula *entity.OneRMFormula) ([]usecase.WeightProgressionPoint, error) {
+				return nil, entity.ErrInvalidOneRMFormula
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := &mockWorkoutUsecase{
+				getWeightProgressionFunc: tt.mockFunc,
+			}
+			handler := NewWorkoutHandler(mockUsecase, &mockProfileUsecase{}, csvimport.NewExportWriter)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/exercises/"+tt.exerciseID+"/progression"+tt.queryParams, nil)
+			req = req.WithContext(contextWithUserID(req.Context(), userID))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.exerciseID})
+			rec := httptest.NewRecorder()
+
+			handler.GetWeightProgression(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rec.Code)
+			}
+		})
+	}
+}
+
 func TestHandleWorkoutUsecaseError(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -798,10 +1539,10 @@ func TestHandleWorkoutUsecaseError(t *testing.T) {
 			expectedError:  "Exercise not found",
 		},
 		{
-			name:           "日付重複",
-			err:            service.ErrDuplicateWorkoutDate,
-			expectedStatus: http.StatusConflict,
-			expectedError:  "Workout already exists for this date",
+			name:           "バリデーションエラー: 終了時刻",
+			err:            entity.ErrInvalidWorkoutEndTime,
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "workout end time must not be before start time",
 		},
 		{
 			name:           "バリデーションエラー: セット番号",
@@ -843,3 +1584,170 @@ func TestHandleWorkoutUsecaseError(t *testing.T) {
 		})
 	}
 }
+
+// exportRows は指定した行を順に返すイテレーターを生成する。errが指定された場合は最後にerrを返す。
+func exportRows(rows []usecase.WorkoutExportRow, err error) iter.Seq2[usecase.WorkoutExportRow, error] {
+	return func(yield func(usecase.WorkoutExportRow, error) bool) {
+		for _, row := range rows {
+			if !yield(row, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(usecase.WorkoutExportRow{}, err)
+		}
+	}
+}
+
+func TestWorkoutHandler_ExportWorkouts(t *testing.T) {
+	userID := uuid.New()
+	chest := entity.BodyPartChest
+	first := entity.NewWorkout(userID, time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC))
+	bench1, _ := entity.NewWorkoutSet(first.ID, uuid.New(), 1, 10, 60)
+	bench2, _ := entity.NewWorkoutSet(first.ID, bench1.ExerciseID, 2, 8, 65)
+	second := entity.NewWorkout(userID, time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC))
+	rows := []usecase.WorkoutExportRow{
+		{Workout: first, Set: bench1, ExerciseName: "ベンチプレス", BodyPart: &chest},
+		{Workout: first, Set: bench2, ExerciseName: "ベンチプレス", BodyPart: &chest},
+		{Workout: second},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		rows           []usecase.WorkoutExportRow
+		err            error
+		expectedStatus int
+		checkResponse  func(t *testing.T, rec *httptest.ResponseRecorder, start, end *time.Time)
+	}{
+		{
+			name:           "成功: JSON形式（ワークアウトごとにセットをまとめる）",
+			query:          "",
+			rows:           rows,
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder, start, end *time.Time) {
+				if cd := rec.Header().Get("Content-Disposition"); !strings.HasPrefix(cd, `attachment; filename="whiskey-workouts-`) || !strings.HasSuffix(cd, `.json"`) {
+					t.Errorf("unexpected Content-Disposition: %s", cd)
+				}
+				if start != nil || end != nil {
+					t.Errorf("expected no date range, got %v - %v", start, end)
+				}
+				var resp []WorkoutExportEntry
+				if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				if len(resp) != 2 || resp[0].ID != first.ID.String() || resp[1].ID != second.ID.String() {
+					t.Fatalf("unexpected workouts: %+v", resp)
+				}
+				if len(resp[0].Sets) != 2 || resp[0].Sets[1].SetNumber != 2 || resp[0].Sets[0].ExerciseName != "ベンチプレス" {
+					t.Errorf("unexpected sets: %+v", resp[0].Sets)
+				}
+				if resp[0].Sets[0].BodyPart == nil || *resp[0].Sets[0].BodyPart != "chest" {
+					t.Errorf("expected body_part chest, got %v", resp[0].Sets[0].BodyPart)
+				}
+				if resp[1].Sets == nil || len(resp[1].Sets) != 0 {
+					t.Errorf("expected empty sets, got %v", resp[1].Sets)
+				}
+			},
+		},
+		{
+			name:           "成功: CSV形式（インポートで取り込める）",
+			query:          "?format=csv&start_date=2026-01-01&end_date=2026-01-31",
+			rows:           rows,
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder, start, end *time.Time) {
+				if ct := rec.Header().Get("Content-Type"); ct != "text/csv; charset=utf-8" {
+					t.Errorf("unexpected Content-Type: %s", ct)
+				}
+				if start == nil || start.Format(time.DateOnly) != "2026-01-01" || end == nil || end.Format(time.DateOnly) != "2026-01-31" {
+					t.Errorf("unexpected date range: %v - %v", start, end)
+				}
+				parsed, rowErrors, err := csvimport.NewWhiskeyParser().Parse(rec.Body)
+				if err != nil || len(rowErrors) != 0 {
+					t.Fatalf("Parse() error = %v, rowErrors = %v", err, rowErrors)
+				}
+				if len(parsed) != 3 || parsed[1].Weight != 65 || parsed[1].WorkoutKey != first.ID.String() {
+					t.Errorf("unexpected rows: %+v", parsed)
+				}
+				// セットのないワークアウトもワークアウトの列のみの行として取り込める
+				if len(parsed) == 3 && !parsed[2].WorkoutOnly {
+					t.Errorf("expected the workout without sets, got %+v", parsed[2])
+				}
+			},
+		},
+		{
+			name:           "成功: ワークアウトがない場合は空の配列",
+			query:          "?format=json",
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder, start, end *time.Time) {
+				if body := strings.TrimSpace(rec.Body.String()); body != "[]" {
+					t.Errorf("expected empty array, got %s", body)
+				}
+			},
+		},
+		{
+			name:           "失敗: 形式が不正",
+			query:          "?format=xml",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "失敗: 日付の形式が不正",
+			query:          "?start_date=2026/01/01",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "失敗: 最初の行を読み取れない",
+			err:            errors.New("database error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotStart, gotEnd *time.Time
+			mockUsecase := &mockWorkoutUsecase{
+				exportWorkoutsFunc: func(ctx context.Context, uid uuid.UUID, startDate, endDate *time.Time) iter.Seq2[usecase.WorkoutExportRow, error] {
+					gotStart, gotEnd = startDate, endDate
+					return exportRows(tt.rows, tt.err)
+				},
+			}
+			handler := NewWorkoutHandler(mockUsecase, &mockProfileUsecase{}, csvimport.NewExportWriter)
+
+			req := httptest.NewRequest("GET", "/api/workouts/export"+tt.query, nil)
+			req = req.WithContext(contextWithUserID(req.Context(), userID))
+			rec := httptest.NewRecorder()
+
+			handler.ExportWorkouts(rec, req)
+
+			if rec.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, rec.Code, rec.Body.String())
+			}
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, rec, gotStart, gotEnd)
+			}
+		})
+	}
+}
+
+func TestWorkoutHandler_ExportWorkouts_AbortOnError(t *testing.T) {
+	userID := uuid.New()
+	workout := entity.NewWorkout(userID, time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC))
+	mockUsecase := &mockWorkoutUsecase{
+		exportWorkoutsFunc: func(ctx context.Context, uid uuid.UUID, startDate, endDate *time.Time) iter.Seq2[usecase.WorkoutExportRow, error] {
+			return exportRows([]usecase.WorkoutExportRow{{Workout: workout}}, errors.New("connection reset"))
+		},
+	}
+	handler := NewWorkoutHandler(mockUsecase, &mockProfileUsecase{}, csvimport.NewExportWriter)
+
+	req := httptest.NewRequest("GET", "/api/workouts/export?format=csv", nil)
+	req = req.WithContext(contextWithUserID(req.Context(), userID))
+	rec := httptest.NewRecorder()
+
+	// 書き出しを始めた後のエラーは、不完全なファイルを正常に見せないよう接続を切断する
+	defer func() {
+		if p := recover(); p != http.ErrAbortHandler {
+			t.Errorf("expected panic with http.ErrAbortHandler, got %v", p)
+		}
+	}()
+	handler.ExportWorkouts(rec, req)
+}
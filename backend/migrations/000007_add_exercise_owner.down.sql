@@ -0,0 +1,10 @@
+-- Remove user-owned exercises before restoring global name uniqueness
+DELETE FROM exercises WHERE user_id IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_exercises_user_name;
+DROP INDEX IF EXISTS idx_exercises_global_name;
+
+ALTER TABLE exercises DROP COLUMN IF EXISTS user_id;
+
+ALTER TABLE exercises ADD CONSTRAINT exercises_name_key UNIQUE (name);
+CREATE UNIQUE INDEX idx_exercises_name ON exercises(name);
@@ -0,0 +1,10 @@
+-- Add owner to exercises (NULL = system-owned global exercise)
+ALTER TABLE exercises
+  ADD COLUMN user_id UUID REFERENCES users(id) ON DELETE CASCADE;
+
+-- Replace global name uniqueness with per-owner uniqueness
+ALTER TABLE exercises DROP CONSTRAINT IF EXISTS exercises_name_key;
+DROP INDEX IF EXISTS idx_exercises_name;
+
+CREATE UNIQUE INDEX idx_exercises_global_name ON exercises(name) WHERE user_id IS NULL;
+CREATE UNIQUE INDEX idx_exercises_user_name ON exercises(user_id, name) WHERE user_id IS NOT NULL;
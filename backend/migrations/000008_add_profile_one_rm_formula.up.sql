@@ -0,0 +1,4 @@
+-- Add preferred 1RM formula to profiles
+ALTER TABLE profiles
+  ADD COLUMN one_rm_formula VARCHAR(20) NOT NULL DEFAULT 'epley'
+  CHECK (one_rm_formula IN ('epley', 'brzycki', 'lombardi', 'mayhew', 'oconner', 'wathan'));
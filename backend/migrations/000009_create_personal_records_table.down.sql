@@ -0,0 +1,2 @@
+-- Drop personal_records table
+DROP TABLE IF EXISTS personal_records CASCADE;
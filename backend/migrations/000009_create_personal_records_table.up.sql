@@ -0,0 +1,22 @@
+-- Create personal_records table
+CREATE TABLE IF NOT EXISTS personal_records (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    exercise_id UUID NOT NULL,
+    workout_id UUID NOT NULL,
+    workout_set_id UUID,
+    record_type VARCHAR(20) NOT NULL CHECK (record_type IN ('estimated_1rm', 'rep_max', 'session_volume')),
+    reps INTEGER CHECK (reps > 0),
+    value DECIMAL(10,2) NOT NULL,
+    previous_value DECIMAL(10,2),
+    achieved_date DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_personal_records_user_id FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_personal_records_exercise_id FOREIGN KEY (exercise_id) REFERENCES exercises(id) ON DELETE CASCADE,
+    CONSTRAINT fk_personal_records_workout_id FOREIGN KEY (workout_id) REFERENCES workouts(id) ON DELETE CASCADE,
+    CONSTRAINT fk_personal_records_workout_set_id FOREIGN KEY (workout_set_id) REFERENCES workout_sets(id) ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE INDEX idx_personal_records_user_exercise ON personal_records(user_id, exercise_id);
+CREATE INDEX idx_personal_records_user_achieved_date ON personal_records(user_id, achieved_date DESC);
@@ -0,0 +1,3 @@
+-- Drop routine tables
+DROP TABLE IF EXISTS routine_exercises CASCADE;
+DROP TABLE IF EXISTS routines CASCADE;
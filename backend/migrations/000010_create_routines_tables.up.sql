@@ -0,0 +1,27 @@
+-- Create routines table
+CREATE TABLE IF NOT EXISTS routines (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_routines_user_id FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create routine_exercises table
+CREATE TABLE IF NOT EXISTS routine_exercises (
+    routine_id UUID NOT NULL,
+    position INTEGER NOT NULL CHECK (position >= 0),
+    exercise_id UUID NOT NULL,
+    target_sets INTEGER NOT NULL CHECK (target_sets > 0),
+    target_reps INTEGER NOT NULL CHECK (target_reps > 0),
+    target_weight DECIMAL(6,2) NOT NULL CHECK (target_weight >= 0),
+    PRIMARY KEY (routine_id, position),
+    CONSTRAINT fk_routine_exercises_routine_id FOREIGN KEY (routine_id) REFERENCES routines(id) ON DELETE CASCADE,
+    CONSTRAINT fk_routine_exercises_exercise_id FOREIGN KEY (exercise_id) REFERENCES exercises(id) ON DELETE RESTRICT
+);
+
+-- Create indexes
+CREATE INDEX idx_routines_user_id ON routines(user_id);
+CREATE INDEX idx_routine_exercises_exercise_id ON routine_exercises(exercise_id);
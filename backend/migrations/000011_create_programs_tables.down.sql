@@ -0,0 +1,4 @@
+-- Drop program tables
+DROP TABLE IF EXISTS program_sets CASCADE;
+DROP TABLE IF EXISTS program_days CASCADE;
+DROP TABLE IF EXISTS programs CASCADE;
@@ -0,0 +1,39 @@
+-- Create programs table
+CREATE TABLE IF NOT EXISTS programs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    current_day_index INTEGER NOT NULL DEFAULT 0 CHECK (current_day_index >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_programs_user_id FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create program_days table
+CREATE TABLE IF NOT EXISTS program_days (
+    program_id UUID NOT NULL,
+    day_index INTEGER NOT NULL CHECK (day_index >= 0),
+    week_number INTEGER NOT NULL CHECK (week_number > 0),
+    day_number INTEGER NOT NULL CHECK (day_number > 0),
+    PRIMARY KEY (program_id, day_index),
+    CONSTRAINT uq_program_days_week_day UNIQUE (program_id, week_number, day_number),
+    CONSTRAINT fk_program_days_program_id FOREIGN KEY (program_id) REFERENCES programs(id) ON DELETE CASCADE
+);
+
+-- Create program_sets table
+CREATE TABLE IF NOT EXISTS program_sets (
+    program_id UUID NOT NULL,
+    day_index INTEGER NOT NULL,
+    position INTEGER NOT NULL CHECK (position >= 0),
+    exercise_id UUID NOT NULL,
+    reps INTEGER NOT NULL CHECK (reps > 0),
+    percentage_1rm DECIMAL(5,2) NOT NULL CHECK (percentage_1rm > 0 AND percentage_1rm <= 150),
+    PRIMARY KEY (program_id, day_index, position),
+    CONSTRAINT fk_program_sets_program_day FOREIGN KEY (program_id, day_index) REFERENCES program_days(program_id, day_index) ON DELETE CASCADE,
+    CONSTRAINT fk_program_sets_exercise_id FOREIGN KEY (exercise_id) REFERENCES exercises(id) ON DELETE RESTRICT
+);
+
+-- Create indexes
+CREATE INDEX idx_programs_user_id ON programs(user_id);
+CREATE INDEX idx_program_sets_exercise_id ON program_sets(exercise_id);
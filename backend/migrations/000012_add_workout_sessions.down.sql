@@ -0,0 +1,15 @@
+-- Keep only the first session of each day before restoring per-day uniqueness
+DELETE FROM workouts w
+USING workouts other
+WHERE w.user_id = other.user_id
+  AND w.date = other.date
+  AND (w.started_at, w.id) > (other.started_at, other.id);
+
+DROP INDEX IF EXISTS idx_workouts_user_date;
+
+ALTER TABLE workouts DROP CONSTRAINT IF EXISTS check_workouts_ended_at;
+ALTER TABLE workouts DROP COLUMN IF EXISTS ended_at;
+ALTER TABLE workouts DROP COLUMN IF EXISTS started_at;
+
+ALTER TABLE workouts ADD CONSTRAINT unique_user_date UNIQUE (user_id, date);
+CREATE UNIQUE INDEX idx_workouts_user_date ON workouts(user_id, date);
@@ -0,0 +1,17 @@
+-- Allow multiple workouts (sessions) per day
+ALTER TABLE workouts DROP CONSTRAINT IF EXISTS unique_user_date;
+DROP INDEX IF EXISTS idx_workouts_user_date;
+
+-- Add session start/end time (existing workouts start at midnight of their date)
+ALTER TABLE workouts
+  ADD COLUMN started_at TIMESTAMPTZ,
+  ADD COLUMN ended_at TIMESTAMPTZ;
+
+UPDATE workouts SET started_at = date::timestamptz;
+
+ALTER TABLE workouts
+  ALTER COLUMN started_at SET NOT NULL,
+  ALTER COLUMN started_at SET DEFAULT NOW(),
+  ADD CONSTRAINT check_workouts_ended_at CHECK (ended_at IS NULL OR ended_at >= started_at);
+
+CREATE INDEX idx_workouts_user_date ON workouts(user_id, date, started_at);
@@ -0,0 +1,3 @@
+-- Add IANA timezone used to interpret workout dates
+ALTER TABLE profiles
+  ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
@@ -0,0 +1,5 @@
+ALTER TABLE users
+  ADD COLUMN password_reset_token_hash VARCHAR(64),
+  ADD COLUMN password_reset_token_expires_at TIMESTAMPTZ;
+
+CREATE INDEX idx_users_password_reset_token_hash ON users(password_reset_token_hash);
@@ -0,0 +1,5 @@
+ALTER TABLE users
+  DROP COLUMN IF EXISTS totp_secret,
+  DROP COLUMN IF EXISTS two_factor_enabled,
+  DROP COLUMN IF EXISTS totp_last_used_step,
+  DROP COLUMN IF EXISTS recovery_code_hashes;
@@ -0,0 +1,5 @@
+ALTER TABLE users
+  ADD COLUMN totp_secret VARCHAR(64),
+  ADD COLUMN two_factor_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD COLUMN totp_last_used_step BIGINT NOT NULL DEFAULT 0,
+  ADD COLUMN recovery_code_hashes TEXT[] NOT NULL DEFAULT '{}';
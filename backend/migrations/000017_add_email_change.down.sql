@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_users_email_change_token_hash;
+
+ALTER TABLE users
+  DROP COLUMN IF EXISTS pending_email,
+  DROP COLUMN IF EXISTS email_change_token_hash,
+  DROP COLUMN IF EXISTS email_change_token_expires_at;
@@ -0,0 +1,3 @@
+ALTER TABLE profiles
+  DROP COLUMN IF EXISTS rest_day_tolerance,
+  DROP COLUMN IF EXISTS weekly_workout_goal;
@@ -0,0 +1,4 @@
+-- Add weekly workout goal and rest-day tolerance used for streak tracking
+ALTER TABLE profiles
+  ADD COLUMN weekly_workout_goal INTEGER NOT NULL DEFAULT 3 CHECK (weekly_workout_goal >= 1 AND weekly_workout_goal <= 7),
+  ADD COLUMN rest_day_tolerance INTEGER NOT NULL DEFAULT 1 CHECK (rest_day_tolerance >= 0 AND rest_day_tolerance <= 6);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_profiles_daily_score_backfill_pending;
+
+ALTER TABLE profiles
+  DROP COLUMN IF EXISTS daily_score_backfill_pending,
+  DROP COLUMN IF EXISTS daily_score_strategy;
@@ -0,0 +1,7 @@
+-- Add selectable daily score strategy and a flag for recalculating stored scores after it changes
+ALTER TABLE profiles
+  ADD COLUMN daily_score_strategy VARCHAR(20) NOT NULL DEFAULT 'absolute'
+    CHECK (daily_score_strategy IN ('absolute', 'bodyweight_relative', 'rolling_average', 'rpe_weighted')),
+  ADD COLUMN daily_score_backfill_pending BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_profiles_daily_score_backfill_pending ON profiles(user_id) WHERE daily_score_backfill_pending;
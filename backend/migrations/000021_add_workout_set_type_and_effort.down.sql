@@ -0,0 +1,5 @@
+ALTER TABLE workout_sets
+  DROP CONSTRAINT IF EXISTS chk_workout_sets_rpe_or_rir,
+  DROP COLUMN IF EXISTS rir,
+  DROP COLUMN IF EXISTS rpe,
+  DROP COLUMN IF EXISTS set_type;
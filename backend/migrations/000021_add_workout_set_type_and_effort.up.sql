@@ -0,0 +1,9 @@
+-- Add set type and RPE/RIR to workout sets
+ALTER TABLE workout_sets
+  ADD COLUMN set_type VARCHAR(10) NOT NULL DEFAULT 'working'
+    CHECK (set_type IN ('warmup', 'working', 'drop', 'failure')),
+  ADD COLUMN rpe DECIMAL(3,1)
+    CHECK (rpe BETWEEN 6 AND 10 AND rpe * 2 = TRUNC(rpe * 2)),
+  ADD COLUMN rir INTEGER
+    CHECK (rir BETWEEN 0 AND 10),
+  ADD CONSTRAINT chk_workout_sets_rpe_or_rir CHECK (rpe IS NULL OR rir IS NULL);
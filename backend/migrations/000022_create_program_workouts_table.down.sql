@@ -0,0 +1,2 @@
+-- Drop program_workouts table
+DROP TABLE IF EXISTS program_workouts CASCADE;
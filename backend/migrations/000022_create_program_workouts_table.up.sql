@@ -0,0 +1,12 @@
+-- Create program_workouts table（ワークアウトをプログラムのどの日として記録したかの紐付け）
+CREATE TABLE IF NOT EXISTS program_workouts (
+    workout_id UUID PRIMARY KEY,
+    program_id UUID NOT NULL,
+    day_index INTEGER NOT NULL CHECK (day_index >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_program_workouts_workout_id FOREIGN KEY (workout_id) REFERENCES workouts(id) ON DELETE CASCADE,
+    CONSTRAINT fk_program_workouts_program_id FOREIGN KEY (program_id) REFERENCES programs(id) ON DELETE CASCADE
+);
+
+-- Create indexes
+CREATE INDEX idx_program_workouts_program_id ON program_workouts(program_id);
@@ -0,0 +1,2 @@
+ALTER TABLE workout_sets
+  ALTER COLUMN created_at SET DEFAULT NOW();
@@ -0,0 +1,3 @@
+-- 同じトランザクションで記録したセットも記録順に並ぶよう、作成日時を文の実行時刻ではなく実時刻にする
+ALTER TABLE workout_sets
+  ALTER COLUMN created_at SET DEFAULT clock_timestamp();
@@ -14,24 +14,31 @@ import (
 
 const CreateExercise = `-- name: CreateExercise :one
 INSERT INTO exercises (
-  name, description, body_part
+  user_id, name, description, body_part
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4
 )
-RETURNING id, name, description, body_part, created_at, updated_at
+RETURNING id, user_id, name, description, body_part, created_at, updated_at
 `
 
 type CreateExerciseParams struct {
+	UserID      uuid.NullUUID  `json:"user_id"`
 	Name        string         `json:"name"`
 	Description sql.NullString `json:"description"`
 	BodyPart    sql.NullString `json:"body_part"`
 }
 
 func (q *Queries) CreateExercise(ctx context.Context, arg CreateExerciseParams) (Exercise, error) {
-	row := q.db.QueryRowContext(ctx, CreateExercise, arg.Name, arg.Description, arg.BodyPart)
+	row := q.db.QueryRowContext(ctx, CreateExercise,
+		arg.UserID,
+		arg.Name,
+		arg.Description,
+		arg.BodyPart,
+	)
 	var i Exercise
 	err := row.Scan(
 		&i.ID,
+		&i.UserID,
 		&i.Name,
 		&i.Description,
 		&i.BodyPart,
@@ -52,7 +59,7 @@ func (q *Queries) DeleteExercise(ctx context.Context, id uuid.UUID) error {
 }
 
 const GetExercise = `-- name: GetExercise :one
-SELECT id, name, description, body_part, created_at, updated_at FROM exercises
+SELECT id, user_id, name, description, body_part, created_at, updated_at FROM exercises
 WHERE id = $1 LIMIT 1
 `
 
@@ -61,6 +68,7 @@ func (q *Queries) GetExercise(ctx context.Context, id uuid.UUID) (Exercise, erro
 	var i Exercise
 	err := row.Scan(
 		&i.ID,
+		&i.UserID,
 		&i.Name,
 		&i.Description,
 		&i.BodyPart,
@@ -70,16 +78,24 @@ func (q *Queries) GetExercise(ctx context.Context, id uuid.UUID) (Exercise, erro
 	return i, err
 }
 
-const GetExerciseByName = `-- name: GetExerciseByName :one
-SELECT id, name, description, body_part, created_at, updated_at FROM exercises
-WHERE name = $1 LIMIT 1
+const GetExerciseByNameForUser = `-- name: GetExerciseByNameForUser :one
+SELECT id, user_id, name, description, body_part, created_at, updated_at FROM exercises
+WHERE name = $1 AND (user_id IS NULL OR user_id = $2)
+ORDER BY user_id NULLS FIRST
+LIMIT 1
 `
 
-func (q *Queries) GetExerciseByName(ctx context.Context, name string) (Exercise, error) {
-	row := q.db.QueryRowContext(ctx, GetExerciseByName, name)
+type GetExerciseByNameForUserParams struct {
+	Name   string        `json:"name"`
+	UserID uuid.NullUUID `json:"user_id"`
+}
+
+func (q *Queries) GetExerciseByNameForUser(ctx context.Context, arg GetExerciseByNameForUserParams) (Exercise, error) {
+	row := q.db.QueryRowContext(ctx, GetExerciseByNameForUser, arg.Name, arg.UserID)
 	var i Exercise
 	err := row.Scan(
 		&i.ID,
+		&i.UserID,
 		&i.Name,
 		&i.Description,
 		&i.BodyPart,
@@ -89,13 +105,19 @@ func (q *Queries) GetExerciseByName(ctx context.Context, name string) (Exercise,
 	return i, err
 }
 
-const ListExercises = `-- name: ListExercises :many
-SELECT id, name, description, body_part, created_at, updated_at FROM exercises
+const ListExercisesByBodyPartForUser = `-- name: ListExercisesByBodyPartForUser :many
+SELECT id, user_id, name, description, body_part, created_at, updated_at FROM exercises
+WHERE body_part = $1 AND (user_id IS NULL OR user_id = $2)
 ORDER BY name
 `
 
-func (q *Queries) ListExercises(ctx context.Context) ([]Exercise, error) {
-	rows, err := q.db.QueryContext(ctx, ListExercises)
+type ListExercisesByBodyPartForUserParams struct {
+	BodyPart sql.NullString `json:"body_part"`
+	UserID   uuid.NullUUID  `json:"user_id"`
+}
+
+func (q *Queries) ListExercisesByBodyPartForUser(ctx context.Context, arg ListExercisesByBodyPartForUserParams) ([]Exercise, error) {
+	rows, err := q.db.QueryContext(ctx, ListExercisesByBodyPartForUser, arg.BodyPart, arg.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -105,6 +127,7 @@ func (q *Queries) ListExercises(ctx context.Context) ([]Exercise, error) {
 		var i Exercise
 		if err := rows.Scan(
 			&i.ID,
+			&i.UserID,
 			&i.Name,
 			&i.Description,
 			&i.BodyPart,
@@ -124,14 +147,14 @@ func (q *Queries) ListExercises(ctx context.Context) ([]Exercise, error) {
 	return items, nil
 }
 
-const ListExercisesByBodyPart = `-- name: ListExercisesByBodyPart :many
-SELECT id, name, description, body_part, created_at, updated_at FROM exercises
-WHERE body_part = $1
+const ListExercisesForUser = `-- name: ListExercisesForUser :many
+SELECT id, user_id, name, description, body_part, created_at, updated_at FROM exercises
+WHERE user_id IS NULL OR user_id = $1
 ORDER BY name
 `
 
-func (q *Queries) ListExercisesByBodyPart(ctx context.Context, bodyPart sql.NullString) ([]Exercise, error) {
-	rows, err := q.db.QueryContext(ctx, ListExercisesByBodyPart, bodyPart)
+func (q *Queries) ListExercisesForUser(ctx context.Context, userID uuid.NullUUID) ([]Exercise, error) {
+	rows, err := q.db.QueryContext(ctx, ListExercisesForUser, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -141,6 +164,7 @@ func (q *Queries) ListExercisesByBodyPart(ctx context.Context, bodyPart sql.Null
 		var i Exercise
 		if err := rows.Scan(
 			&i.ID,
+			&i.UserID,
 			&i.Name,
 			&i.Description,
 			&i.BodyPart,
@@ -164,7 +188,7 @@ const UpdateExercise = `-- name: UpdateExercise :one
 UPDATE exercises
 SET name = $2, description = $3, body_part = $4, updated_at = NOW()
 WHERE id = $1
-RETURNING id, name, description, body_part, created_at, updated_at
+RETURNING id, user_id, name, description, body_part, created_at, updated_at
 `
 
 type UpdateExerciseParams struct {
@@ -184,6 +208,7 @@ func (q *Queries) UpdateExercise(ctx context.Context, arg UpdateExerciseParams)
 	var i Exercise
 	err := row.Scan(
 		&i.ID,
+		&i.UserID,
 		&i.Name,
 		&i.Description,
 		&i.BodyPart,
@@ -13,6 +13,7 @@ import (
 
 type Exercise struct {
 	ID          uuid.UUID      `json:"id"`
+	UserID      uuid.NullUUID  `json:"user_id"`
 	Name        string         `json:"name"`
 	Description sql.NullString `json:"description"`
 	BodyPart    sql.NullString `json:"body_part"`
@@ -20,32 +21,124 @@ type Exercise struct {
 	UpdatedAt   time.Time      `json:"updated_at"`
 }
 
+type PersonalRecord struct {
+	ID            uuid.UUID      `json:"id"`
+	UserID        uuid.UUID      `json:"user_id"`
+	ExerciseID    uuid.UUID      `json:"exercise_id"`
+	WorkoutID     uuid.UUID      `json:"workout_id"`
+	WorkoutSetID  uuid.NullUUID  `json:"workout_set_id"`
+	RecordType    string         `json:"record_type"`
+	Reps          sql.NullInt32  `json:"reps"`
+	Value         string         `json:"value"`
+	PreviousValue sql.NullString `json:"previous_value"`
+	AchievedDate  time.Time      `json:"achieved_date"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
 type Profile struct {
+	ID                        uuid.UUID      `json:"id"`
+	UserID                    uuid.UUID      `json:"user_id"`
+	DisplayName               string         `json:"display_name"`
+	Age                       sql.NullInt32  `json:"age"`
+	Weight                    sql.NullString `json:"weight"`
+	Height                    sql.NullString `json:"height"`
+	OneRmFormula              string         `json:"one_rm_formula"`
+	Timezone                  string         `json:"timezone"`
+	CreatedAt                 time.Time      `json:"created_at"`
+	UpdatedAt                 time.Time      `json:"updated_at"`
+	WeeklyWorkoutGoal         int32          `json:"weekly_workout_goal"`
+	RestDayTolerance          int32          `json:"rest_day_tolerance"`
+	DailyScoreStrategy        string         `json:"daily_score_strategy"`
+	DailyScoreBackfillPending bool           `json:"daily_score_backfill_pending"`
+}
+
+type Program struct {
+	ID              uuid.UUID      `json:"id"`
+	UserID          uuid.UUID      `json:"user_id"`
+	Name            string         `json:"name"`
+	Description     sql.NullString `json:"description"`
+	CurrentDayIndex int32          `json:"current_day_index"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+}
+
+type ProgramDay struct {
+	ProgramID  uuid.UUID `json:"program_id"`
+	DayIndex   int32     `json:"day_index"`
+	WeekNumber int32     `json:"week_number"`
+	DayNumber  int32     `json:"day_number"`
+}
+
+type ProgramSet struct {
+	ProgramID     uuid.UUID `json:"program_id"`
+	DayIndex      int32     `json:"day_index"`
+	Position      int32     `json:"position"`
+	ExerciseID    uuid.UUID `json:"exercise_id"`
+	Reps          int32     `json:"reps"`
+	Percentage1rm string    `json:"percentage_1rm"`
+}
+
+type ProgramWorkout struct {
+	WorkoutID uuid.UUID `json:"workout_id"`
+	ProgramID uuid.UUID `json:"program_id"`
+	DayIndex  int32     `json:"day_index"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Routine struct {
 	ID          uuid.UUID      `json:"id"`
 	UserID      uuid.UUID      `json:"user_id"`
-	DisplayName string         `json:"display_name"`
-	Age         sql.NullInt32  `json:"age"`
-	Weight      sql.NullString `json:"weight"`
-	Height      sql.NullString `json:"height"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
 	CreatedAt   time.Time      `json:"created_at"`
 	UpdatedAt   time.Time      `json:"updated_at"`
 }
 
+type RoutineExercise struct {
+	RoutineID    uuid.UUID `json:"routine_id"`
+	Position     int32     `json:"position"`
+	ExerciseID   uuid.UUID `json:"exercise_id"`
+	TargetSets   int32     `json:"target_sets"`
+	TargetReps   int32     `json:"target_reps"`
+	TargetWeight string    `json:"target_weight"`
+}
+
 type User struct {
-	ID                         uuid.UUID      `json:"id"`
-	Email                      string         `json:"email"`
-	PasswordHash               string         `json:"password_hash"`
-	EmailVerified              bool           `json:"email_verified"`
-	VerificationToken          sql.NullString `json:"verification_token"`
-	VerificationTokenExpiresAt sql.NullTime   `json:"verification_token_expires_at"`
-	CreatedAt                  time.Time      `json:"created_at"`
-	UpdatedAt                  time.Time      `json:"updated_at"`
+	ID                          uuid.UUID      `json:"id"`
+	Email                       string         `json:"email"`
+	PasswordHash                string         `json:"password_hash"`
+	EmailVerified               bool           `json:"email_verified"`
+	VerificationToken           sql.NullString `json:"verification_token"`
+	VerificationTokenExpiresAt  sql.NullTime   `json:"verification_token_expires_at"`
+	PasswordResetTokenHash      sql.NullString `json:"password_reset_token_hash"`
+	PasswordResetTokenExpiresAt sql.NullTime   `json:"password_reset_token_expires_at"`
+	PendingEmail                sql.NullString `json:"pending_email"`
+	EmailChangeTokenHash        sql.NullString `json:"email_change_token_hash"`
+	EmailChangeTokenExpiresAt   sql.NullTime   `json:"email_change_token_expires_at"`
+	TotpSecret                  sql.NullString `json:"totp_secret"`
+	TwoFactorEnabled            bool           `json:"two_factor_enabled"`
+	TotpLastUsedStep            int64          `json:"totp_last_used_step"`
+	RecoveryCodeHashes          []string       `json:"recovery_code_hashes"`
+	DeletionScheduledAt         sql.NullTime   `json:"deletion_scheduled_at"`
+	CreatedAt                   time.Time      `json:"created_at"`
+	UpdatedAt                   time.Time      `json:"updated_at"`
+}
+
+type UserIdentity struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type Workout struct {
 	ID         uuid.UUID      `json:"id"`
 	UserID     uuid.UUID      `json:"user_id"`
 	Date       time.Time      `json:"date"`
+	StartedAt  time.Time      `json:"started_at"`
+	EndedAt    sql.NullTime   `json:"ended_at"`
 	DailyScore int32          `json:"daily_score"`
 	Memo       sql.NullString `json:"memo"`
 	CreatedAt  time.Time      `json:"created_at"`
@@ -63,4 +156,7 @@ type WorkoutSet struct {
 	DurationSeconds sql.NullInt32  `json:"duration_seconds"`
 	Notes           sql.NullString `json:"notes"`
 	CreatedAt       time.Time      `json:"created_at"`
+	SetType         string         `json:"set_type"`
+	Rpe             sql.NullString `json:"rpe"`
+	Rir             sql.NullInt32  `json:"rir"`
 }
@@ -13,24 +13,47 @@ import (
 	"github.com/google/uuid"
 )
 
+const CompleteDailyScoreBackfill = `-- name: CompleteDailyScoreBackfill :exec
+UPDATE profiles
+SET daily_score_backfill_pending = FALSE
+WHERE user_id = $1 AND daily_score_strategy = $2
+`
+
+type CompleteDailyScoreBackfillParams struct {
+	UserID             uuid.UUID `json:"user_id"`
+	DailyScoreStrategy string    `json:"daily_score_strategy"`
+}
+
+// 再計算に使用した計算方法が現在の設定と一致する場合のみ再計算待ちを解除する
+func (q *Queries) CompleteDailyScoreBackfill(ctx context.Context, arg CompleteDailyScoreBackfillParams) error {
+	_, err := q.db.ExecContext(ctx, CompleteDailyScoreBackfill, arg.UserID, arg.DailyScoreStrategy)
+	return err
+}
+
 const CreateProfile = `-- name: CreateProfile :one
 INSERT INTO profiles (
-  id, user_id, display_name, age, weight, height, created_at, updated_at
+  id, user_id, display_name, age, weight, height, one_rm_formula, timezone, weekly_workout_goal, rest_day_tolerance, daily_score_strategy, daily_score_backfill_pending, created_at, updated_at
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
 )
-RETURNING id, user_id, display_name, age, weight, height, created_at, updated_at
+RETURNING id, user_id, display_name, age, weight, height, one_rm_formula, timezone, created_at, updated_at, weekly_workout_goal, rest_day_tolerance, daily_score_strategy, daily_score_backfill_pending
 `
 
 type CreateProfileParams struct {
-	ID          uuid.UUID      `json:"id"`
-	UserID      uuid.UUID      `json:"user_id"`
-	DisplayName string         `json:"display_name"`
-	Age         sql.NullInt32  `json:"age"`
-	Weight      sql.NullString `json:"weight"`
-	Height      sql.NullString `json:"height"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
+	ID                        uuid.UUID      `json:"id"`
+	UserID                    uuid.UUID      `json:"user_id"`
+	DisplayName               string         `json:"display_name"`
+	Age                       sql.NullInt32  `json:"age"`
+	Weight                    sql.NullString `json:"weight"`
+	Height                    sql.NullString `json:"height"`
+	OneRmFormula              string         `json:"one_rm_formula"`
+	Timezone                  string         `json:"timezone"`
+	WeeklyWorkoutGoal         int32          `json:"weekly_workout_goal"`
+	RestDayTolerance          int32          `json:"rest_day_tolerance"`
+	DailyScoreStrategy        string         `json:"daily_score_strategy"`
+	DailyScoreBackfillPending bool           `json:"daily_score_backfill_pending"`
+	CreatedAt                 time.Time      `json:"created_at"`
+	UpdatedAt                 time.Time      `json:"updated_at"`
 }
 
 func (q *Queries) CreateProfile(ctx context.Context, arg CreateProfileParams) (Profile, error) {
@@ -41,6 +64,12 @@ func (q *Queries) CreateProfile(ctx context.Context, arg CreateProfileParams) (P
 		arg.Age,
 		arg.Weight,
 		arg.Height,
+		arg.OneRmFormula,
+		arg.Timezone,
+		arg.WeeklyWorkoutGoal,
+		arg.RestDayTolerance,
+		arg.DailyScoreStrategy,
+		arg.DailyScoreBackfillPending,
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
@@ -52,8 +81,14 @@ func (q *Queries) CreateProfile(ctx context.Context, arg CreateProfileParams) (P
 		&i.Age,
 		&i.Weight,
 		&i.Height,
+		&i.OneRmFormula,
+		&i.Timezone,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.WeeklyWorkoutGoal,
+		&i.RestDayTolerance,
+		&i.DailyScoreStrategy,
+		&i.DailyScoreBackfillPending,
 	)
 	return i, err
 }
@@ -80,7 +115,7 @@ func (q *Queries) ExistsProfileByUserID(ctx context.Context, userID uuid.UUID) (
 }
 
 const GetProfile = `-- name: GetProfile :one
-SELECT id, user_id, display_name, age, weight, height, created_at, updated_at FROM profiles
+SELECT id, user_id, display_name, age, weight, height, one_rm_formula, timezone, created_at, updated_at, weekly_workout_goal, rest_day_tolerance, daily_score_strategy, daily_score_backfill_pending FROM profiles
 WHERE id = $1 LIMIT 1
 `
 
@@ -94,14 +129,20 @@ func (q *Queries) GetProfile(ctx context.Context, id uuid.UUID) (Profile, error)
 		&i.Age,
 		&i.Weight,
 		&i.Height,
+		&i.OneRmFormula,
+		&i.Timezone,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.WeeklyWorkoutGoal,
+		&i.RestDayTolerance,
+		&i.DailyScoreStrategy,
+		&i.DailyScoreBackfillPending,
 	)
 	return i, err
 }
 
 const GetProfileByUserID = `-- name: GetProfileByUserID :one
-SELECT id, user_id, display_name, age, weight, height, created_at, updated_at FROM profiles
+SELECT id, user_id, display_name, age, weight, height, one_rm_formula, timezone, created_at, updated_at, weekly_workout_goal, rest_day_tolerance, daily_score_strategy, daily_score_backfill_pending FROM profiles
 WHERE user_id = $1 LIMIT 1
 `
 
@@ -115,25 +156,82 @@ func (q *Queries) GetProfileByUserID(ctx context.Context, userID uuid.UUID) (Pro
 		&i.Age,
 		&i.Weight,
 		&i.Height,
+		&i.OneRmFormula,
+		&i.Timezone,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.WeeklyWorkoutGoal,
+		&i.RestDayTolerance,
+		&i.DailyScoreStrategy,
+		&i.DailyScoreBackfillPending,
 	)
 	return i, err
 }
 
+const ListProfilesPendingDailyScoreBackfill = `-- name: ListProfilesPendingDailyScoreBackfill :many
+SELECT id, user_id, display_name, age, weight, height, one_rm_formula, timezone, created_at, updated_at, weekly_workout_goal, rest_day_tolerance, daily_score_strategy, daily_score_backfill_pending FROM profiles
+WHERE daily_score_backfill_pending
+ORDER BY updated_at
+`
+
+// デイリースコアの計算方法が変更され、保存済みのスコアの再計算が必要なプロフィールを取得
+func (q *Queries) ListProfilesPendingDailyScoreBackfill(ctx context.Context) ([]Profile, error) {
+	rows, err := q.db.QueryContext(ctx, ListProfilesPendingDailyScoreBackfill)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Profile{}
+	for rows.Next() {
+		var i Profile
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.DisplayName,
+			&i.Age,
+			&i.Weight,
+			&i.Height,
+			&i.OneRmFormula,
+			&i.Timezone,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.WeeklyWorkoutGoal,
+			&i.RestDayTolerance,
+			&i.DailyScoreStrategy,
+			&i.DailyScoreBackfillPending,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const UpdateProfile = `-- name: UpdateProfile :one
 UPDATE profiles
-SET display_name = $2, age = $3, weight = $4, height = $5, updated_at = NOW()
+SET display_name = $2, age = $3, weight = $4, height = $5, one_rm_formula = $6, timezone = $7, weekly_workout_goal = $8, rest_day_tolerance = $9, daily_score_strategy = $10, daily_score_backfill_pending = $11, updated_at = NOW()
 WHERE id = $1
-RETURNING id, user_id, display_name, age, weight, height, created_at, updated_at
+RETURNING id, user_id, display_name, age, weight, height, one_rm_formula, timezone, created_at, updated_at, weekly_workout_goal, rest_day_tolerance, daily_score_strategy, daily_score_backfill_pending
 `
 
 type UpdateProfileParams struct {
-	ID          uuid.UUID      `json:"id"`
-	DisplayName string         `json:"display_name"`
-	Age         sql.NullInt32  `json:"age"`
-	Weight      sql.NullString `json:"weight"`
-	Height      sql.NullString `json:"height"`
+	ID                        uuid.UUID      `json:"id"`
+	DisplayName               string         `json:"display_name"`
+	Age                       sql.NullInt32  `json:"age"`
+	Weight                    sql.NullString `json:"weight"`
+	Height                    sql.NullString `json:"height"`
+	OneRmFormula              string         `json:"one_rm_formula"`
+	Timezone                  string         `json:"timezone"`
+	WeeklyWorkoutGoal         int32          `json:"weekly_workout_goal"`
+	RestDayTolerance          int32          `json:"rest_day_tolerance"`
+	DailyScoreStrategy        string         `json:"daily_score_strategy"`
+	DailyScoreBackfillPending bool           `json:"daily_score_backfill_pending"`
 }
 
 func (q *Queries) UpdateProfile(ctx context.Context, arg UpdateProfileParams) (Profile, error) {
@@ -143,6 +241,12 @@ func (q *Queries) UpdateProfile(ctx context.Context, arg UpdateProfileParams) (P
 		arg.Age,
 		arg.Weight,
 		arg.Height,
+		arg.OneRmFormula,
+		arg.Timezone,
+		arg.WeeklyWorkoutGoal,
+		arg.RestDayTolerance,
+		arg.DailyScoreStrategy,
+		arg.DailyScoreBackfillPending,
 	)
 	var i Profile
 	err := row.Scan(
@@ -152,8 +256,14 @@ func (q *Queries) UpdateProfile(ctx context.Context, arg UpdateProfileParams) (P
 		&i.Age,
 		&i.Weight,
 		&i.Height,
+		&i.OneRmFormula,
+		&i.Timezone,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.WeeklyWorkoutGoal,
+		&i.RestDayTolerance,
+		&i.DailyScoreStrategy,
+		&i.DailyScoreBackfillPending,
 	)
 	return i, err
 }
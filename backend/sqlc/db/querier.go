@@ -7,55 +7,124 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	// 再計算に使用した計算方法が現在の設定と一致する場合のみ再計算待ちを解除する
+	CompleteDailyScoreBackfill(ctx context.Context, arg CompleteDailyScoreBackfillParams) error
 	CreateExercise(ctx context.Context, arg CreateExerciseParams) (Exercise, error)
+	CreatePersonalRecord(ctx context.Context, arg CreatePersonalRecordParams) (PersonalRecord, error)
 	CreateProfile(ctx context.Context, arg CreateProfileParams) (Profile, error)
+	CreateProgram(ctx context.Context, arg CreateProgramParams) (Program, error)
+	CreateProgramDay(ctx context.Context, arg CreateProgramDayParams) error
+	CreateProgramSet(ctx context.Context, arg CreateProgramSetParams) error
+	CreateProgramWorkout(ctx context.Context, arg CreateProgramWorkoutParams) error
+	CreateRoutine(ctx context.Context, arg CreateRoutineParams) (Routine, error)
+	CreateRoutineExercise(ctx context.Context, arg CreateRoutineExerciseParams) error
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateUserIdentity(ctx context.Context, arg CreateUserIdentityParams) (UserIdentity, error)
 	CreateWorkout(ctx context.Context, arg CreateWorkoutParams) (Workout, error)
 	CreateWorkoutSet(ctx context.Context, arg CreateWorkoutSetParams) (WorkoutSet, error)
 	DeleteExercise(ctx context.Context, id uuid.UUID) error
+	// 自己ベストの再計算用：特定種目の自己ベスト更新イベントをすべて削除
+	DeletePersonalRecordsByUserAndExercise(ctx context.Context, arg DeletePersonalRecordsByUserAndExerciseParams) error
 	DeleteProfile(ctx context.Context, id uuid.UUID) error
+	DeleteProgram(ctx context.Context, id uuid.UUID) error
+	DeleteRoutine(ctx context.Context, id uuid.UUID) error
+	DeleteRoutineExercisesByRoutine(ctx context.Context, routineID uuid.UUID) error
 	DeleteUser(ctx context.Context, id uuid.UUID) error
 	DeleteWorkout(ctx context.Context, id uuid.UUID) error
 	DeleteWorkoutSet(ctx context.Context, id uuid.UUID) error
 	DeleteWorkoutSetsByWorkout(ctx context.Context, workoutID uuid.UUID) error
 	ExistsProfileByUserID(ctx context.Context, userID uuid.UUID) (bool, error)
 	GetExercise(ctx context.Context, id uuid.UUID) (Exercise, error)
-	GetExerciseByName(ctx context.Context, name string) (Exercise, error)
+	GetExerciseByNameForUser(ctx context.Context, arg GetExerciseByNameForUserParams) (Exercise, error)
 	// 各日の最大推定1RMを取得（重量成長グラフ用）
 	GetMaxEstimated1RMByExercise(ctx context.Context, arg GetMaxEstimated1RMByExerciseParams) ([]GetMaxEstimated1RMByExerciseRow, error)
 	// 全期間の最大推定1RMを取得
 	GetOverallMaxEstimated1RMByExerciseAndUser(ctx context.Context, arg GetOverallMaxEstimated1RMByExerciseAndUserParams) (string, error)
 	GetProfile(ctx context.Context, id uuid.UUID) (Profile, error)
 	GetProfileByUserID(ctx context.Context, userID uuid.UUID) (Profile, error)
+	GetProgram(ctx context.Context, id uuid.UUID) (Program, error)
+	GetProgramWorkout(ctx context.Context, workoutID uuid.UUID) (ProgramWorkout, error)
+	GetRoutine(ctx context.Context, id uuid.UUID) (Routine, error)
 	GetUser(ctx context.Context, id uuid.UUID) (User, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
+	GetUserByEmailChangeTokenHash(ctx context.Context, emailChangeTokenHash sql.NullString) (User, error)
 	GetUserByVerificationToken(ctx context.Context, verificationToken sql.NullString) (User, error)
+	GetUserIdentityByProviderSubject(ctx context.Context, arg GetUserIdentityByProviderSubjectParams) (UserIdentity, error)
 	GetWorkout(ctx context.Context, id uuid.UUID) (Workout, error)
-	GetWorkoutByUserAndDate(ctx context.Context, arg GetWorkoutByUserAndDateParams) (Workout, error)
 	GetWorkoutSet(ctx context.Context, id uuid.UUID) (WorkoutSet, error)
 	ListAllWorkoutsByUser(ctx context.Context, userID uuid.UUID) ([]Workout, error)
-	ListExercises(ctx context.Context) ([]Exercise, error)
-	ListExercisesByBodyPart(ctx context.Context, bodyPart sql.NullString) ([]Exercise, error)
+	ListExercisesByBodyPartForUser(ctx context.Context, arg ListExercisesByBodyPartForUserParams) ([]Exercise, error)
+	ListExercisesForUser(ctx context.Context, userID uuid.NullUUID) ([]Exercise, error)
+	// 自己ベスト履歴：ユーザーの全種目の自己ベスト更新を新しい順に取得
+	ListPersonalRecordsByUser(ctx context.Context, userID uuid.UUID) ([]PersonalRecord, error)
+	// 自己ベスト履歴：特定種目の自己ベスト更新を新しい順に取得
+	ListPersonalRecordsByUserAndExercise(ctx context.Context, arg ListPersonalRecordsByUserAndExerciseParams) ([]PersonalRecord, error)
+	// デイリースコアの計算方法が変更され、保存済みのスコアの再計算が必要なプロフィールを取得
+	ListProfilesPendingDailyScoreBackfill(ctx context.Context) ([]Profile, error)
+	ListProgramDaysByProgram(ctx context.Context, programID uuid.UUID) ([]ProgramDay, error)
+	// プログラム一覧用：ユーザーの全プログラムの日程をまとめて取得
+	ListProgramDaysByUser(ctx context.Context, userID uuid.UUID) ([]ProgramDay, error)
+	ListProgramSetsByProgram(ctx context.Context, programID uuid.UUID) ([]ProgramSet, error)
+	// プログラム一覧用：ユーザーの全プログラムのセット処方をまとめて取得
+	ListProgramSetsByUser(ctx context.Context, userID uuid.UUID) ([]ProgramSet, error)
+	ListProgramsByUser(ctx context.Context, userID uuid.UUID) ([]Program, error)
+	// 重量成長グラフ用：任意の公式で推定1RMを再計算するため、生の重量・レップ数を日付順に取得
+	ListProgressionSetsByExercise(ctx context.Context, arg ListProgressionSetsByExerciseParams) ([]ListProgressionSetsByExerciseRow, error)
+	ListRoutineExercisesByRoutine(ctx context.Context, routineID uuid.UUID) ([]RoutineExercise, error)
+	// ルーティン一覧用：ユーザーの全ルーティンの種目をまとめて取得
+	ListRoutineExercisesByUser(ctx context.Context, userID uuid.UUID) ([]RoutineExercise, error)
+	ListRoutinesByUser(ctx context.Context, userID uuid.UUID) ([]Routine, error)
+	ListUserIdentitiesByUser(ctx context.Context, userID uuid.UUID) ([]UserIdentity, error)
 	ListUsers(ctx context.Context) ([]User, error)
-	// 重量成長グラフ用：特定種目の推定1RMの推移を取得
+	ListUsersDueForDeletion(ctx context.Context, deletionScheduledAt sql.NullTime) ([]User, error)
+	// トレーニング量の分析用：期間（週・月）と身体部位ごとに総負荷量・セット数・レップ数・平均強度を集計（NULLの条件は無視する）
+	// 身体部位が設定されていない種目のセットは、身体部位がNULLの1グループにまとめる
+	ListVolumeByBodyPart(ctx context.Context, arg ListVolumeByBodyPartParams) ([]ListVolumeByBodyPartRow, error)
+	// トレーニング量の分析用：期間（週・月）と種目ごとに総負荷量・セット数・レップ数・平均強度を集計（NULLの条件は無視する）
+	// 平均強度は1レップあたりの平均重量（総負荷量 / 総レップ数）
+	ListVolumeByExercise(ctx context.Context, arg ListVolumeByExerciseParams) ([]ListVolumeByExerciseRow, error)
+	// 連続記録の計算用：ワークアウトを記録した日付を重複なしで古い順に取得
+	ListWorkoutDatesByUser(ctx context.Context, userID uuid.UUID) ([]time.Time, error)
+	// エクスポート用：ワークアウトをキーセットページネーションで古い順に取得し、セットを種目名・身体部位付きで結合する（NULLの条件は無視する）
+	// 1ページはワークアウト単位で区切るため、ワークアウトのセットがページをまたぐことはない
+	// セットのないワークアウトは、セットの列がNULLの1行となる
+	ListWorkoutExportRows(ctx context.Context, arg ListWorkoutExportRowsParams) ([]ListWorkoutExportRowsRow, error)
+	// 自己ベスト判定用：特定種目のセットを時系列順（日付、ワークアウトの開始時刻、セット番号の順）に取得
 	ListWorkoutSetsByExercise(ctx context.Context, arg ListWorkoutSetsByExerciseParams) ([]ListWorkoutSetsByExerciseRow, error)
 	ListWorkoutSetsByExerciseID(ctx context.Context, exerciseID uuid.UUID) ([]WorkoutSet, error)
+	// 1RM計算式の変更時の再計算用：ユーザーの全セットを取得
+	ListWorkoutSetsByUser(ctx context.Context, userID uuid.UUID) ([]WorkoutSet, error)
 	ListWorkoutSetsByWorkout(ctx context.Context, workoutID uuid.UUID) ([]WorkoutSet, error)
 	ListWorkoutSetsByWorkoutAndExercise(ctx context.Context, arg ListWorkoutSetsByWorkoutAndExerciseParams) ([]WorkoutSet, error)
+	// ワークアウト一覧の展開用：複数ワークアウトのセットを種目名・身体部位付きで一括取得（種目は最初に記録した順、種目内はセット番号順）
+	ListWorkoutSetsWithExerciseByWorkouts(ctx context.Context, workoutIds []uuid.UUID) ([]ListWorkoutSetsWithExerciseByWorkoutsRow, error)
 	ListWorkoutsByUser(ctx context.Context, arg ListWorkoutsByUserParams) ([]Workout, error)
+	// 同日の全セッションを開始時刻順に取得
+	ListWorkoutsByUserAndDate(ctx context.Context, arg ListWorkoutsByUserAndDateParams) ([]Workout, error)
 	ListWorkoutsByUserAndDateRange(ctx context.Context, arg ListWorkoutsByUserAndDateRangeParams) ([]Workout, error)
-	// GitHub風ヒートマップ用：過去365日の運動強度スコアを取得
+	// GitHub風ヒートマップ用：過去365日の運動強度スコアを日別に合算して取得（上限100）
 	ListWorkoutsForHeatmap(ctx context.Context, userID uuid.UUID) ([]ListWorkoutsForHeatmapRow, error)
+	// ワークアウト一覧をキーセットページネーションで古い順に取得（NULLの条件は無視する）
+	ListWorkoutsPageDateAsc(ctx context.Context, arg ListWorkoutsPageDateAscParams) ([]Workout, error)
+	// ワークアウト一覧をキーセットページネーションで新しい順に取得（NULLの条件は無視する）
+	ListWorkoutsPageDateDesc(ctx context.Context, arg ListWorkoutsPageDateDescParams) ([]Workout, error)
+	// パスワードリセット：有効期限内のトークンに一致する場合のみ、パスワードの更新とトークンの消費を1文で行う
+	ResetUserPasswordByTokenHash(ctx context.Context, arg ResetUserPasswordByTokenHashParams) (User, error)
 	UpdateExercise(ctx context.Context, arg UpdateExerciseParams) (Exercise, error)
 	UpdateProfile(ctx context.Context, arg UpdateProfileParams) (Profile, error)
+	UpdateProgramProgress(ctx context.Context, arg UpdateProgramProgressParams) (Program, error)
+	UpdateRoutine(ctx context.Context, arg UpdateRoutineParams) (Routine, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
 	UpdateWorkout(ctx context.Context, arg UpdateWorkoutParams) (Workout, error)
 	UpdateWorkoutSet(ctx context.Context, arg UpdateWorkoutSetParams) (WorkoutSet, error)
+	// 1RM計算式の変更時の再計算用：複数セットの推定1RMを一括更新
+	UpdateWorkoutSetEstimated1RMs(ctx context.Context, arg UpdateWorkoutSetEstimated1RMsParams) error
 }
 
 var _ Querier = (*Queries)(nil)
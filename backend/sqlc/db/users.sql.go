@@ -10,6 +10,7 @@ import (
 	"database/sql"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const CreateUser = `-- name: CreateUser :one
@@ -18,7 +19,7 @@ INSERT INTO users (
 ) VALUES (
   $1, $2, $3, $4, $5
 )
-RETURNING id, email, password_hash, email_verified, verification_token, verification_token_expires_at, created_at, updated_at
+RETURNING id, email, password_hash, email_verified, verification_token, verification_token_expires_at, password_reset_token_hash, password_reset_token_expires_at, pending_email, email_change_token_hash, email_change_token_expires_at, totp_secret, two_factor_enabled, totp_last_used_step, recovery_code_hashes, deletion_scheduled_at, created_at, updated_at
 `
 
 type CreateUserParams struct {
@@ -45,6 +46,16 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.EmailVerified,
 		&i.VerificationToken,
 		&i.VerificationTokenExpiresAt,
+		&i.PasswordResetTokenHash,
+		&i.PasswordResetTokenExpiresAt,
+		&i.PendingEmail,
+		&i.EmailChangeTokenHash,
+		&i.EmailChangeTokenExpiresAt,
+		&i.TotpSecret,
+		&i.TwoFactorEnabled,
+		&i.TotpLastUsedStep,
+		pq.Array(&i.RecoveryCodeHashes),
+		&i.DeletionScheduledAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -62,7 +73,7 @@ func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) error {
 }
 
 const GetUser = `-- name: GetUser :one
-SELECT id, email, password_hash, email_verified, verification_token, verification_token_expires_at, created_at, updated_at FROM users
+SELECT id, email, password_hash, email_verified, verification_token, verification_token_expires_at, password_reset_token_hash, password_reset_token_expires_at, pending_email, email_change_token_hash, email_change_token_expires_at, totp_secret, two_factor_enabled, totp_last_used_step, recovery_code_hashes, deletion_scheduled_at, created_at, updated_at FROM users
 WHERE id = $1 LIMIT 1
 `
 
@@ -76,6 +87,16 @@ func (q *Queries) GetUser(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.EmailVerified,
 		&i.VerificationToken,
 		&i.VerificationTokenExpiresAt,
+		&i.PasswordResetTokenHash,
+		&i.PasswordResetTokenExpiresAt,
+		&i.PendingEmail,
+		&i.EmailChangeTokenHash,
+		&i.EmailChangeTokenExpiresAt,
+		&i.TotpSecret,
+		&i.TwoFactorEnabled,
+		&i.TotpLastUsedStep,
+		pq.Array(&i.RecoveryCodeHashes),
+		&i.DeletionScheduledAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -83,7 +104,7 @@ func (q *Queries) GetUser(ctx context.Context, id uuid.UUID) (User, error) {
 }
 
 const GetUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, password_hash, email_verified, verification_token, verification_token_expires_at, created_at, updated_at FROM users
+SELECT id, email, password_hash, email_verified, verification_token, verification_token_expires_at, password_reset_token_hash, password_reset_token_expires_at, pending_email, email_change_token_hash, email_change_token_expires_at, totp_secret, two_factor_enabled, totp_last_used_step, recovery_code_hashes, deletion_scheduled_at, created_at, updated_at FROM users
 WHERE email = $1 LIMIT 1
 `
 
@@ -97,6 +118,47 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.EmailVerified,
 		&i.VerificationToken,
 		&i.VerificationTokenExpiresAt,
+		&i.PasswordResetTokenHash,
+		&i.PasswordResetTokenExpiresAt,
+		&i.PendingEmail,
+		&i.EmailChangeTokenHash,
+		&i.EmailChangeTokenExpiresAt,
+		&i.TotpSecret,
+		&i.TwoFactorEnabled,
+		&i.TotpLastUsedStep,
+		pq.Array(&i.RecoveryCodeHashes),
+		&i.DeletionScheduledAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const GetUserByEmailChangeTokenHash = `-- name: GetUserByEmailChangeTokenHash :one
+SELECT id, email, password_hash, email_verified, verification_token, verification_token_expires_at, password_reset_token_hash, password_reset_token_expires_at, pending_email, email_change_token_hash, email_change_token_expires_at, totp_secret, two_factor_enabled, totp_last_used_step, recovery_code_hashes, deletion_scheduled_at, created_at, updated_at FROM users
+WHERE email_change_token_hash = $1 LIMIT 1
+`
+
+func (q *Queries) GetUserByEmailChangeTokenHash(ctx context.Context, emailChangeTokenHash sql.NullString) (User, error) {
+	row := q.db.QueryRowContext(ctx, GetUserByEmailChangeTokenHash, emailChangeTokenHash)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.EmailVerified,
+		&i.VerificationToken,
+		&i.VerificationTokenExpiresAt,
+		&i.PasswordResetTokenHash,
+		&i.PasswordResetTokenExpiresAt,
+		&i.PendingEmail,
+		&i.EmailChangeTokenHash,
+		&i.EmailChangeTokenExpiresAt,
+		&i.TotpSecret,
+		&i.TwoFactorEnabled,
+		&i.TotpLastUsedStep,
+		pq.Array(&i.RecoveryCodeHashes),
+		&i.DeletionScheduledAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -104,7 +166,7 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 }
 
 const GetUserByVerificationToken = `-- name: GetUserByVerificationToken :one
-SELECT id, email, password_hash, email_verified, verification_token, verification_token_expires_at, created_at, updated_at FROM users
+SELECT id, email, password_hash, email_verified, verification_token, verification_token_expires_at, password_reset_token_hash, password_reset_token_expires_at, pending_email, email_change_token_hash, email_change_token_expires_at, totp_secret, two_factor_enabled, totp_last_used_step, recovery_code_hashes, deletion_scheduled_at, created_at, updated_at FROM users
 WHERE verification_token = $1 LIMIT 1
 `
 
@@ -118,6 +180,16 @@ func (q *Queries) GetUserByVerificationToken(ctx context.Context, verificationTo
 		&i.EmailVerified,
 		&i.VerificationToken,
 		&i.VerificationTokenExpiresAt,
+		&i.PasswordResetTokenHash,
+		&i.PasswordResetTokenExpiresAt,
+		&i.PendingEmail,
+		&i.EmailChangeTokenHash,
+		&i.EmailChangeTokenExpiresAt,
+		&i.TotpSecret,
+		&i.TwoFactorEnabled,
+		&i.TotpLastUsedStep,
+		pq.Array(&i.RecoveryCodeHashes),
+		&i.DeletionScheduledAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -125,7 +197,7 @@ func (q *Queries) GetUserByVerificationToken(ctx context.Context, verificationTo
 }
 
 const ListUsers = `-- name: ListUsers :many
-SELECT id, email, password_hash, email_verified, verification_token, verification_token_expires_at, created_at, updated_at FROM users
+SELECT id, email, password_hash, email_verified, verification_token, verification_token_expires_at, password_reset_token_hash, password_reset_token_expires_at, pending_email, email_change_token_hash, email_change_token_expires_at, totp_secret, two_factor_enabled, totp_last_used_step, recovery_code_hashes, deletion_scheduled_at, created_at, updated_at FROM users
 ORDER BY created_at DESC
 `
 
@@ -145,6 +217,64 @@ func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
 			&i.EmailVerified,
 			&i.VerificationToken,
 			&i.VerificationTokenExpiresAt,
+			&i.PasswordResetTokenHash,
+			&i.PasswordResetTokenExpiresAt,
+			&i.PendingEmail,
+			&i.EmailChangeTokenHash,
+			&i.EmailChangeTokenExpiresAt,
+			&i.TotpSecret,
+			&i.TwoFactorEnabled,
+			&i.TotpLastUsedStep,
+			pq.Array(&i.RecoveryCodeHashes),
+			&i.DeletionScheduledAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListUsersDueForDeletion = `-- name: ListUsersDueForDeletion :many
+SELECT id, email, password_hash, email_verified, verification_token, verification_token_expires_at, password_reset_token_hash, password_reset_token_expires_at, pending_email, email_change_token_hash, email_change_token_expires_at, totp_secret, two_factor_enabled, totp_last_used_step, recovery_code_hashes, deletion_scheduled_at, created_at, updated_at FROM users
+WHERE deletion_scheduled_at <= $1
+ORDER BY deletion_scheduled_at
+`
+
+func (q *Queries) ListUsersDueForDeletion(ctx context.Context, deletionScheduledAt sql.NullTime) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, ListUsersDueForDeletion, deletionScheduledAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.PasswordHash,
+			&i.EmailVerified,
+			&i.VerificationToken,
+			&i.VerificationTokenExpiresAt,
+			&i.PasswordResetTokenHash,
+			&i.PasswordResetTokenExpiresAt,
+			&i.PendingEmail,
+			&i.EmailChangeTokenHash,
+			&i.EmailChangeTokenExpiresAt,
+			&i.TotpSecret,
+			&i.TwoFactorEnabled,
+			&i.TotpLastUsedStep,
+			pq.Array(&i.RecoveryCodeHashes),
+			&i.DeletionScheduledAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -161,20 +291,69 @@ func (q *Queries) ListUsers(ctx context.Context) ([]User, error) {
 	return items, nil
 }
 
+const ResetUserPasswordByTokenHash = `-- name: ResetUserPasswordByTokenHash :one
+UPDATE users
+SET password_hash = $2, password_reset_token_hash = NULL, password_reset_token_expires_at = NULL, updated_at = NOW()
+WHERE password_reset_token_hash = $1 AND password_reset_token_expires_at > NOW()
+RETURNING id, email, password_hash, email_verified, verification_token, verification_token_expires_at, password_reset_token_hash, password_reset_token_expires_at, pending_email, email_change_token_hash, email_change_token_expires_at, totp_secret, two_factor_enabled, totp_last_used_step, recovery_code_hashes, deletion_scheduled_at, created_at, updated_at
+`
+
+type ResetUserPasswordByTokenHashParams struct {
+	PasswordResetTokenHash sql.NullString `json:"password_reset_token_hash"`
+	PasswordHash           string         `json:"password_hash"`
+}
+
+// パスワードリセット：有効期限内のトークンに一致する場合のみ、パスワードの更新とトークンの消費を1文で行う
+func (q *Queries) ResetUserPasswordByTokenHash(ctx context.Context, arg ResetUserPasswordByTokenHashParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, ResetUserPasswordByTokenHash, arg.PasswordResetTokenHash, arg.PasswordHash)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.PasswordHash,
+		&i.EmailVerified,
+		&i.VerificationToken,
+		&i.VerificationTokenExpiresAt,
+		&i.PasswordResetTokenHash,
+		&i.PasswordResetTokenExpiresAt,
+		&i.PendingEmail,
+		&i.EmailChangeTokenHash,
+		&i.EmailChangeTokenExpiresAt,
+		&i.TotpSecret,
+		&i.TwoFactorEnabled,
+		&i.TotpLastUsedStep,
+		pq.Array(&i.RecoveryCodeHashes),
+		&i.DeletionScheduledAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const UpdateUser = `-- name: UpdateUser :one
 UPDATE users
-SET email = $2, password_hash = $3, email_verified = $4, verification_token = $5, verification_token_expires_at = $6, updated_at = NOW()
+SET email = $2, password_hash = $3, email_verified = $4, verification_token = $5, verification_token_expires_at = $6, password_reset_token_hash = $7, password_reset_token_expires_at = $8, totp_secret = $9, two_factor_enabled = $10, totp_last_used_step = $11, recovery_code_hashes = $12, pending_email = $13, email_change_token_hash = $14, email_change_token_expires_at = $15, deletion_scheduled_at = $16, updated_at = NOW()
 WHERE id = $1
-RETURNING id, email, password_hash, email_verified, verification_token, verification_token_expires_at, created_at, updated_at
+RETURNING id, email, password_hash, email_verified, verification_token, verification_token_expires_at, password_reset_token_hash, password_reset_token_expires_at, pending_email, email_change_token_hash, email_change_token_expires_at, totp_secret, two_factor_enabled, totp_last_used_step, recovery_code_hashes, deletion_scheduled_at, created_at, updated_at
 `
 
 type UpdateUserParams struct {
-	ID                         uuid.UUID      `json:"id"`
-	Email                      string         `json:"email"`
-	PasswordHash               string         `json:"password_hash"`
-	EmailVerified              bool           `json:"email_verified"`
-	VerificationToken          sql.NullString `json:"verification_token"`
-	VerificationTokenExpiresAt sql.NullTime   `json:"verification_token_expires_at"`
+	ID                          uuid.UUID      `json:"id"`
+	Email                       string         `json:"email"`
+	PasswordHash                string         `json:"password_hash"`
+	EmailVerified               bool           `json:"email_verified"`
+	VerificationToken           sql.NullString `json:"verification_token"`
+	VerificationTokenExpiresAt  sql.NullTime   `json:"verification_token_expires_at"`
+	PasswordResetTokenHash      sql.NullString `json:"password_reset_token_hash"`
+	PasswordResetTokenExpiresAt sql.NullTime   `json:"password_reset_token_expires_at"`
+	TotpSecret                  sql.NullString `json:"totp_secret"`
+	TwoFactorEnabled            bool           `json:"two_factor_enabled"`
+	TotpLastUsedStep            int64          `json:"totp_last_used_step"`
+	RecoveryCodeHashes          []string       `json:"recovery_code_hashes"`
+	PendingEmail                sql.NullString `json:"pending_email"`
+	EmailChangeTokenHash        sql.NullString `json:"email_change_token_hash"`
+	EmailChangeTokenExpiresAt   sql.NullTime   `json:"email_change_token_expires_at"`
+	DeletionScheduledAt         sql.NullTime   `json:"deletion_scheduled_at"`
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
@@ -185,6 +364,16 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		arg.EmailVerified,
 		arg.VerificationToken,
 		arg.VerificationTokenExpiresAt,
+		arg.PasswordResetTokenHash,
+		arg.PasswordResetTokenExpiresAt,
+		arg.TotpSecret,
+		arg.TwoFactorEnabled,
+		arg.TotpLastUsedStep,
+		pq.Array(arg.RecoveryCodeHashes),
+		arg.PendingEmail,
+		arg.EmailChangeTokenHash,
+		arg.EmailChangeTokenExpiresAt,
+		arg.DeletionScheduledAt,
 	)
 	var i User
 	err := row.Scan(
@@ -194,6 +383,16 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.EmailVerified,
 		&i.VerificationToken,
 		&i.VerificationTokenExpiresAt,
+		&i.PasswordResetTokenHash,
+		&i.PasswordResetTokenExpiresAt,
+		&i.PendingEmail,
+		&i.EmailChangeTokenHash,
+		&i.EmailChangeTokenExpiresAt,
+		&i.TotpSecret,
+		&i.TwoFactorEnabled,
+		&i.TotpLastUsedStep,
+		pq.Array(&i.RecoveryCodeHashes),
+		&i.DeletionScheduledAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -11,15 +11,16 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const CreateWorkoutSet = `-- name: CreateWorkoutSet :one
 INSERT INTO workout_sets (
-  workout_id, exercise_id, set_number, reps, weight, estimated_1rm, duration_seconds, notes
+  workout_id, exercise_id, set_number, reps, weight, estimated_1rm, duration_seconds, notes, set_type, rpe, rir
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
 )
-RETURNING id, workout_id, exercise_id, set_number, reps, weight, estimated_1rm, duration_seconds, notes, created_at
+RETURNING id, workout_id, exercise_id, set_number, reps, weight, estimated_1rm, duration_seconds, notes, created_at, set_type, rpe, rir
 `
 
 type CreateWorkoutSetParams struct {
@@ -31,6 +32,9 @@ type CreateWorkoutSetParams struct {
 	Estimated1rm    string         `json:"estimated_1rm"`
 	DurationSeconds sql.NullInt32  `json:"duration_seconds"`
 	Notes           sql.NullString `json:"notes"`
+	SetType         string         `json:"set_type"`
+	Rpe             sql.NullString `json:"rpe"`
+	Rir             sql.NullInt32  `json:"rir"`
 }
 
 func (q *Queries) CreateWorkoutSet(ctx context.Context, arg CreateWorkoutSetParams) (WorkoutSet, error) {
@@ -43,6 +47,9 @@ func (q *Queries) CreateWorkoutSet(ctx context.Context, arg CreateWorkoutSetPara
 		arg.Estimated1rm,
 		arg.DurationSeconds,
 		arg.Notes,
+		arg.SetType,
+		arg.Rpe,
+		arg.Rir,
 	)
 	var i WorkoutSet
 	err := row.Scan(
@@ -56,6 +63,9 @@ func (q *Queries) CreateWorkoutSet(ctx context.Context, arg CreateWorkoutSetPara
 		&i.DurationSeconds,
 		&i.Notes,
 		&i.CreatedAt,
+		&i.SetType,
+		&i.Rpe,
+		&i.Rir,
 	)
 	return i, err
 }
@@ -86,7 +96,7 @@ SELECT
   MAX(ws.estimated_1rm) as max_1rm
 FROM workouts w
 JOIN workout_sets ws ON w.id = ws.workout_id
-WHERE w.user_id = $1 AND ws.exercise_id = $2
+WHERE w.user_id = $1 AND ws.exercise_id = $2 AND ws.set_type <> 'warmup'
 GROUP BY w.date
 ORDER BY w.date
 `
@@ -101,7 +111,7 @@ type GetMaxEstimated1RMByExerciseRow struct {
 	Max1rm interface{} `json:"max_1rm"`
 }
 
-// 各日の最大推定1RMを取得（重量成長グラフ用）
+// 各日の最大推定1RMを取得（重量成長グラフ用、ウォームアップセットを除く）
 func (q *Queries) GetMaxEstimated1RMByExercise(ctx context.Context, arg GetMaxEstimated1RMByExerciseParams) ([]GetMaxEstimated1RMByExerciseRow, error) {
 	rows, err := q.db.QueryContext(ctx, GetMaxEstimated1RMByExercise, arg.UserID, arg.ExerciseID)
 	if err != nil {
@@ -129,7 +139,7 @@ const GetOverallMaxEstimated1RMByExerciseAndUser = `-- name: GetOverallMaxEstima
 SELECT COALESCE(MAX(ws.estimated_1rm), '0')::text as max_1rm
 FROM workout_sets ws
 JOIN workouts w ON ws.workout_id = w.id
-WHERE w.user_id = $1 AND ws.exercise_id = $2
+WHERE w.user_id = $1 AND ws.exercise_id = $2 AND ws.set_type <> 'warmup'
 `
 
 type GetOverallMaxEstimated1RMByExerciseAndUserParams struct {
@@ -137,7 +147,7 @@ type GetOverallMaxEstimated1RMByExerciseAndUserParams struct {
 	ExerciseID uuid.UUID `json:"exercise_id"`
 }
 
-// 全期間の最大推定1RMを取得
+// 全期間の最大推定1RMを取得（ウォームアップセットを除く）
 func (q *Queries) GetOverallMaxEstimated1RMByExerciseAndUser(ctx context.Context, arg GetOverallMaxEstimated1RMByExerciseAndUserParams) (string, error) {
 	row := q.db.QueryRowContext(ctx, GetOverallMaxEstimated1RMByExerciseAndUser, arg.UserID, arg.ExerciseID)
 	var max_1rm string
@@ -146,7 +156,7 @@ func (q *Queries) GetOverallMaxEstimated1RMByExerciseAndUser(ctx context.Context
 }
 
 const GetWorkoutSet = `-- name: GetWorkoutSet :one
-SELECT id, workout_id, exercise_id, set_number, reps, weight, estimated_1rm, duration_seconds, notes, created_at FROM workout_sets
+SELECT id, workout_id, exercise_id, set_number, reps, weight, estimated_1rm, duration_seconds, notes, created_at, set_type, rpe, rir FROM workout_sets
 WHERE id = $1 LIMIT 1
 `
 
@@ -164,10 +174,59 @@ func (q *Queries) GetWorkoutSet(ctx context.Context, id uuid.UUID) (WorkoutSet,
 		&i.DurationSeconds,
 		&i.Notes,
 		&i.CreatedAt,
+		&i.SetType,
+		&i.Rpe,
+		&i.Rir,
 	)
 	return i, err
 }
 
+const ListProgressionSetsByExercise = `-- name: ListProgressionSetsByExercise :many
+SELECT
+  w.date,
+  ws.reps,
+  ws.weight
+FROM workout_sets ws
+JOIN workouts w ON ws.workout_id = w.id
+WHERE w.user_id = $1 AND ws.exercise_id = $2 AND ws.set_type <> 'warmup'
+ORDER BY w.date, ws.set_number
+`
+
+type ListProgressionSetsByExerciseParams struct {
+	UserID     uuid.UUID `json:"user_id"`
+	ExerciseID uuid.UUID `json:"exercise_id"`
+}
+
+type ListProgressionSetsByExerciseRow struct {
+	Date   time.Time `json:"date"`
+	Reps   int32     `json:"reps"`
+	Weight string    `json:"weight"`
+}
+
+// 重量成長グラフ用：任意の公式で推定1RMを再計算するため、生の重量・レップ数を日付順に取得（ウォームアップセットを除く）
+func (q *Queries) ListProgressionSetsByExercise(ctx context.Context, arg ListProgressionSetsByExerciseParams) ([]ListProgressionSetsByExerciseRow, error) {
+	rows, err := q.db.QueryContext(ctx, ListProgressionSetsByExercise, arg.UserID, arg.ExerciseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProgressionSetsByExerciseRow{}
+	for rows.Next() {
+		var i ListProgressionSetsByExerciseRow
+		if err := rows.Scan(&i.Date, &i.Reps, &i.Weight); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const ListWorkoutSetsByExercise = `-- name: ListWorkoutSetsByExercise :many
 SELECT
   ws.id,
@@ -180,11 +239,14 @@ SELECT
   ws.duration_seconds,
   ws.notes,
   ws.created_at,
+  ws.set_type,
+  ws.rpe,
+  ws.rir,
   w.date as workout_date
 FROM workout_sets ws
 JOIN workouts w ON ws.workout_id = w.id
 WHERE w.user_id = $1 AND ws.exercise_id = $2
-ORDER BY w.date DESC, ws.set_number
+ORDER BY w.date, w.started_at, w.created_at, w.id, ws.set_number
 `
 
 type ListWorkoutSetsByExerciseParams struct {
@@ -203,10 +265,13 @@ type ListWorkoutSetsByExerciseRow struct {
 	DurationSeconds sql.NullInt32  `json:"duration_seconds"`
 	Notes           sql.NullString `json:"notes"`
 	CreatedAt       time.Time      `json:"created_at"`
+	SetType         string         `json:"set_type"`
+	Rpe             sql.NullString `json:"rpe"`
+	Rir             sql.NullInt32  `json:"rir"`
 	WorkoutDate     time.Time      `json:"workout_date"`
 }
 
-// 重量成長グラフ用：特定種目の推定1RMの推移を取得
+// 自己ベスト判定用：特定種目のセットを時系列順（日付、ワークアウトの開始時刻、セット番号の順）に取得
 func (q *Queries) ListWorkoutSetsByExercise(ctx context.Context, arg ListWorkoutSetsByExerciseParams) ([]ListWorkoutSetsByExerciseRow, error) {
 	rows, err := q.db.QueryContext(ctx, ListWorkoutSetsByExercise, arg.UserID, arg.ExerciseID)
 	if err != nil {
@@ -227,6 +292,9 @@ func (q *Queries) ListWorkoutSetsByExercise(ctx context.Context, arg ListWorkout
 			&i.DurationSeconds,
 			&i.Notes,
 			&i.CreatedAt,
+			&i.SetType,
+			&i.Rpe,
+			&i.Rir,
 			&i.WorkoutDate,
 		); err != nil {
 			return nil, err
@@ -243,7 +311,7 @@ func (q *Queries) ListWorkoutSetsByExercise(ctx context.Context, arg ListWorkout
 }
 
 const ListWorkoutSetsByExerciseID = `-- name: ListWorkoutSetsByExerciseID :many
-SELECT id, workout_id, exercise_id, set_number, reps, weight, estimated_1rm, duration_seconds, notes, created_at FROM workout_sets
+SELECT id, workout_id, exercise_id, set_number, reps, weight, estimated_1rm, duration_seconds, notes, created_at, set_type, rpe, rir FROM workout_sets
 WHERE exercise_id = $1
 ORDER BY created_at DESC
 `
@@ -268,6 +336,54 @@ func (q *Queries) ListWorkoutSetsByExerciseID(ctx context.Context, exerciseID uu
 			&i.DurationSeconds,
 			&i.Notes,
 			&i.CreatedAt,
+			&i.SetType,
+			&i.Rpe,
+			&i.Rir,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListWorkoutSetsByUser = `-- name: ListWorkoutSetsByUser :many
+SELECT ws.id, ws.workout_id, ws.exercise_id, ws.set_number, ws.reps, ws.weight, ws.estimated_1rm, ws.duration_seconds, ws.notes, ws.created_at, ws.set_type, ws.rpe, ws.rir FROM workout_sets ws
+JOIN workouts w ON ws.workout_id = w.id
+WHERE w.user_id = $1
+ORDER BY w.date, ws.set_number
+`
+
+// 1RM計算式の変更時の再計算用：ユーザーの全セットを取得
+func (q *Queries) ListWorkoutSetsByUser(ctx context.Context, userID uuid.UUID) ([]WorkoutSet, error) {
+	rows, err := q.db.QueryContext(ctx, ListWorkoutSetsByUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WorkoutSet{}
+	for rows.Next() {
+		var i WorkoutSet
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkoutID,
+			&i.ExerciseID,
+			&i.SetNumber,
+			&i.Reps,
+			&i.Weight,
+			&i.Estimated1rm,
+			&i.DurationSeconds,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.SetType,
+			&i.Rpe,
+			&i.Rir,
 		); err != nil {
 			return nil, err
 		}
@@ -283,7 +399,7 @@ func (q *Queries) ListWorkoutSetsByExerciseID(ctx context.Context, exerciseID uu
 }
 
 const ListWorkoutSetsByWorkout = `-- name: ListWorkoutSetsByWorkout :many
-SELECT id, workout_id, exercise_id, set_number, reps, weight, estimated_1rm, duration_seconds, notes, created_at FROM workout_sets
+SELECT id, workout_id, exercise_id, set_number, reps, weight, estimated_1rm, duration_seconds, notes, created_at, set_type, rpe, rir FROM workout_sets
 WHERE workout_id = $1
 ORDER BY exercise_id, set_number
 `
@@ -308,6 +424,9 @@ func (q *Queries) ListWorkoutSetsByWorkout(ctx context.Context, workoutID uuid.U
 			&i.DurationSeconds,
 			&i.Notes,
 			&i.CreatedAt,
+			&i.SetType,
+			&i.Rpe,
+			&i.Rir,
 		); err != nil {
 			return nil, err
 		}
@@ -323,7 +442,7 @@ func (q *Queries) ListWorkoutSetsByWorkout(ctx context.Context, workoutID uuid.U
 }
 
 const ListWorkoutSetsByWorkoutAndExercise = `-- name: ListWorkoutSetsByWorkoutAndExercise :many
-SELECT id, workout_id, exercise_id, set_number, reps, weight, estimated_1rm, duration_seconds, notes, created_at FROM workout_sets
+SELECT id, workout_id, exercise_id, set_number, reps, weight, estimated_1rm, duration_seconds, notes, created_at, set_type, rpe, rir FROM workout_sets
 WHERE workout_id = $1 AND exercise_id = $2
 ORDER BY set_number
 `
@@ -353,6 +472,90 @@ func (q *Queries) ListWorkoutSetsByWorkoutAndExercise(ctx context.Context, arg L
 			&i.DurationSeconds,
 			&i.Notes,
 			&i.CreatedAt,
+			&i.SetType,
+			&i.Rpe,
+			&i.Rir,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListWorkoutSetsWithExerciseByWorkouts = `-- name: ListWorkoutSetsWithExerciseByWorkouts :many
+SELECT
+  ws.id,
+  ws.workout_id,
+  ws.exercise_id,
+  ws.set_number,
+  ws.reps,
+  ws.weight,
+  ws.estimated_1rm,
+  ws.duration_seconds,
+  ws.notes,
+  ws.created_at,
+  ws.set_type,
+  ws.rpe,
+  ws.rir,
+  e.name as exercise_name,
+  e.body_part as exercise_body_part
+FROM workout_sets ws
+JOIN exercises e ON ws.exercise_id = e.id
+WHERE ws.workout_id = ANY($1::uuid[])
+ORDER BY ws.workout_id, MIN(ws.created_at) OVER (PARTITION BY ws.workout_id, ws.exercise_id), ws.exercise_id, ws.set_number
+`
+
+type ListWorkoutSetsWithExerciseByWorkoutsRow struct {
+	ID               uuid.UUID      `json:"id"`
+	WorkoutID        uuid.UUID      `json:"workout_id"`
+	ExerciseID       uuid.UUID      `json:"exercise_id"`
+	SetNumber        int32          `json:"set_number"`
+	Reps             int32          `json:"reps"`
+	Weight           string         `json:"weight"`
+	Estimated1rm     string         `json:"estimated_1rm"`
+	DurationSeconds  sql.NullInt32  `json:"duration_seconds"`
+	Notes            sql.NullString `json:"notes"`
+	CreatedAt        time.Time      `json:"created_at"`
+	SetType          string         `json:"set_type"`
+	Rpe              sql.NullString `json:"rpe"`
+	Rir              sql.NullInt32  `json:"rir"`
+	ExerciseName     string         `json:"exercise_name"`
+	ExerciseBodyPart sql.NullString `json:"exercise_body_part"`
+}
+
+// ワークアウト一覧の展開用：複数ワークアウトのセットを種目名・身体部位付きで一括取得（種目は最初に記録した順、種目内はセット番号順）
+func (q *Queries) ListWorkoutSetsWithExerciseByWorkouts(ctx context.Context, workoutIds []uuid.UUID) ([]ListWorkoutSetsWithExerciseByWorkoutsRow, error) {
+	rows, err := q.db.QueryContext(ctx, ListWorkoutSetsWithExerciseByWorkouts, pq.Array(workoutIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListWorkoutSetsWithExerciseByWorkoutsRow{}
+	for rows.Next() {
+		var i ListWorkoutSetsWithExerciseByWorkoutsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkoutID,
+			&i.ExerciseID,
+			&i.SetNumber,
+			&i.Reps,
+			&i.Weight,
+			&i.Estimated1rm,
+			&i.DurationSeconds,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.SetType,
+			&i.Rpe,
+			&i.Rir,
+			&i.ExerciseName,
+			&i.ExerciseBodyPart,
 		); err != nil {
 			return nil, err
 		}
@@ -369,9 +572,9 @@ func (q *Queries) ListWorkoutSetsByWorkoutAndExercise(ctx context.Context, arg L
 
 const UpdateWorkoutSet = `-- name: UpdateWorkoutSet :one
 UPDATE workout_sets
-SET reps = $2, weight = $3, estimated_1rm = $4, duration_seconds = $5, notes = $6
+SET reps = $2, weight = $3, estimated_1rm = $4, duration_seconds = $5, notes = $6, set_type = $7, rpe = $8, rir = $9
 WHERE id = $1
-RETURNING id, workout_id, exercise_id, set_number, reps, weight, estimated_1rm, duration_seconds, notes, created_at
+RETURNING id, workout_id, exercise_id, set_number, reps, weight, estimated_1rm, duration_seconds, notes, created_at, set_type, rpe, rir
 `
 
 type UpdateWorkoutSetParams struct {
@@ -381,6 +584,9 @@ type UpdateWorkoutSetParams struct {
 	Estimated1rm    string         `json:"estimated_1rm"`
 	DurationSeconds sql.NullInt32  `json:"duration_seconds"`
 	Notes           sql.NullString `json:"notes"`
+	SetType         string         `json:"set_type"`
+	Rpe             sql.NullString `json:"rpe"`
+	Rir             sql.NullInt32  `json:"rir"`
 }
 
 func (q *Queries) UpdateWorkoutSet(ctx context.Context, arg UpdateWorkoutSetParams) (WorkoutSet, error) {
@@ -391,6 +597,9 @@ func (q *Queries) UpdateWorkoutSet(ctx context.Context, arg UpdateWorkoutSetPara
 		arg.Estimated1rm,
 		arg.DurationSeconds,
 		arg.Notes,
+		arg.SetType,
+		arg.Rpe,
+		arg.Rir,
 	)
 	var i WorkoutSet
 	err := row.Scan(
@@ -404,6 +613,27 @@ func (q *Queries) UpdateWorkoutSet(ctx context.Context, arg UpdateWorkoutSetPara
 		&i.DurationSeconds,
 		&i.Notes,
 		&i.CreatedAt,
+		&i.SetType,
+		&i.Rpe,
+		&i.Rir,
 	)
 	return i, err
 }
+
+const UpdateWorkoutSetEstimated1RMs = `-- name: UpdateWorkoutSetEstimated1RMs :exec
+UPDATE workout_sets AS ws
+SET estimated_1rm = v.estimated_1rm
+FROM unnest($1::uuid[], $2::numeric[]) AS v(id, estimated_1rm)
+WHERE ws.id = v.id
+`
+
+type UpdateWorkoutSetEstimated1RMsParams struct {
+	Ids           []uuid.UUID `json:"ids"`
+	Estimated1rms []string    `json:"estimated_1rms"`
+}
+
+// 1RM計算式の変更時の再計算用：複数セットの推定1RMを一括更新
+func (q *Queries) UpdateWorkoutSetEstimated1RMs(ctx context.Context, arg UpdateWorkoutSetEstimated1RMsParams) error {
+	_, err := q.db.ExecContext(ctx, UpdateWorkoutSetEstimated1RMs, pq.Array(arg.Ids), pq.Array(arg.Estimated1rms))
+	return err
+}
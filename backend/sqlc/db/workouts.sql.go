@@ -15,16 +15,18 @@ import (
 
 const CreateWorkout = `-- name: CreateWorkout :one
 INSERT INTO workouts (
-  user_id, date, daily_score, memo
+  user_id, date, started_at, ended_at, daily_score, memo
 ) VALUES (
-  $1, $2, $3, $4
+  $1, $2, $3, $4, $5, $6
 )
-RETURNING id, user_id, date, daily_score, memo, created_at, updated_at
+RETURNING id, user_id, date, started_at, ended_at, daily_score, memo, created_at, updated_at
 `
 
 type CreateWorkoutParams struct {
 	UserID     uuid.UUID      `json:"user_id"`
 	Date       time.Time      `json:"date"`
+	StartedAt  time.Time      `json:"started_at"`
+	EndedAt    sql.NullTime   `json:"ended_at"`
 	DailyScore int32          `json:"daily_score"`
 	Memo       sql.NullString `json:"memo"`
 }
@@ -33,6 +35,8 @@ func (q *Queries) CreateWorkout(ctx context.Context, arg CreateWorkoutParams) (W
 	row := q.db.QueryRowContext(ctx, CreateWorkout,
 		arg.UserID,
 		arg.Date,
+		arg.StartedAt,
+		arg.EndedAt,
 		arg.DailyScore,
 		arg.Memo,
 	)
@@ -41,6 +45,8 @@ func (q *Queries) CreateWorkout(ctx context.Context, arg CreateWorkoutParams) (W
 		&i.ID,
 		&i.UserID,
 		&i.Date,
+		&i.StartedAt,
+		&i.EndedAt,
 		&i.DailyScore,
 		&i.Memo,
 		&i.CreatedAt,
@@ -60,7 +66,7 @@ func (q *Queries) DeleteWorkout(ctx context.Context, id uuid.UUID) error {
 }
 
 const GetWorkout = `-- name: GetWorkout :one
-SELECT id, user_id, date, daily_score, memo, created_at, updated_at FROM workouts
+SELECT id, user_id, date, started_at, ended_at, daily_score, memo, created_at, updated_at FROM workouts
 WHERE id = $1 LIMIT 1
 `
 
@@ -71,31 +77,8 @@ func (q *Queries) GetWorkout(ctx context.Context, id uuid.UUID) (Workout, error)
 		&i.ID,
 		&i.UserID,
 		&i.Date,
-		&i.DailyScore,
-		&i.Memo,
-		&i.CreatedAt,
-		&i.UpdatedAt,
-	)
-	return i, err
-}
-
-const GetWorkoutByUserAndDate = `-- name: GetWorkoutByUserAndDate :one
-SELECT id, user_id, date, daily_score, memo, created_at, updated_at FROM workouts
-WHERE user_id = $1 AND date = $2 LIMIT 1
-`
-
-type GetWorkoutByUserAndDateParams struct {
-	UserID uuid.UUID `json:"user_id"`
-	Date   time.Time `json:"date"`
-}
-
-func (q *Queries) GetWorkoutByUserAndDate(ctx context.Context, arg GetWorkoutByUserAndDateParams) (Workout, error) {
-	row := q.db.QueryRowContext(ctx, GetWorkoutByUserAndDate, arg.UserID, arg.Date)
-	var i Workout
-	err := row.Scan(
-		&i.ID,
-		&i.UserID,
-		&i.Date,
+		&i.StartedAt,
+		&i.EndedAt,
 		&i.DailyScore,
 		&i.Memo,
 		&i.CreatedAt,
@@ -105,9 +88,9 @@ func (q *Queries) GetWorkoutByUserAndDate(ctx context.Context, arg GetWorkoutByU
 }
 
 const ListAllWorkoutsByUser = `-- name: ListAllWorkoutsByUser :many
-SELECT id, user_id, date, daily_score, memo, created_at, updated_at FROM workouts
+SELECT id, user_id, date, started_at, ended_at, daily_score, memo, created_at, updated_at FROM workouts
 WHERE user_id = $1
-ORDER BY date DESC
+ORDER BY date DESC, started_at DESC
 `
 
 func (q *Queries) ListAllWorkoutsByUser(ctx context.Context, userID uuid.UUID) ([]Workout, error) {
@@ -123,10 +106,178 @@ func (q *Queries) ListAllWorkoutsByUser(ctx context.Context, userID uuid.UUID) (
 			&i.ID,
 			&i.UserID,
 			&i.Date,
+			&i.StartedAt,
+			&i.EndedAt,
+			&i.DailyScore,
+			&i.Memo,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListWorkoutDatesByUser = `-- name: ListWorkoutDatesByUser :many
+SELECT DISTINCT date FROM workouts
+WHERE user_id = $1
+ORDER BY date
+`
+
+// 連続記録の計算用：ワークアウトを記録した日付を重複なしで古い順に取得
+func (q *Queries) ListWorkoutDatesByUser(ctx context.Context, userID uuid.UUID) ([]time.Time, error) {
+	rows, err := q.db.QueryContext(ctx, ListWorkoutDatesByUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []time.Time{}
+	for rows.Next() {
+		var date time.Time
+		if err := rows.Scan(&date); err != nil {
+			return nil, err
+		}
+		items = append(items, date)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListWorkoutExportRows = `-- name: ListWorkoutExportRows :many
+WITH page AS (
+  SELECT w.id, w.user_id, w.date, w.started_at, w.ended_at, w.daily_score, w.memo, w.created_at, w.updated_at
+  FROM workouts w
+  WHERE w.user_id = $1
+    AND ($2::date IS NULL
+      OR (w.date, w.started_at, w.id) > ($2::date, $3::timestamptz, $4::uuid))
+    AND ($5::date IS NULL OR w.date >= $5::date)
+    AND ($6::date IS NULL OR w.date <= $6::date)
+  ORDER BY w.date ASC, w.started_at ASC, w.id ASC
+  LIMIT $7
+)
+SELECT
+  p.id,
+  p.user_id,
+  p.date,
+  p.started_at,
+  p.ended_at,
+  p.daily_score,
+  p.memo,
+  p.created_at,
+  p.updated_at,
+  ws.id as set_id,
+  ws.exercise_id,
+  ws.set_number,
+  ws.reps,
+  ws.weight,
+  ws.estimated_1rm,
+  ws.duration_seconds,
+  ws.notes,
+  ws.created_at as set_created_at,
+  ws.set_type,
+  ws.rpe,
+  ws.rir,
+  e.name as exercise_name,
+  e.body_part as exercise_body_part
+FROM page p
+LEFT JOIN workout_sets ws ON ws.workout_id = p.id
+LEFT JOIN exercises e ON e.id = ws.exercise_id
+ORDER BY p.date ASC, p.started_at ASC, p.id ASC, ws.exercise_id, ws.set_number
+`
+
+type ListWorkoutExportRowsParams struct {
+	UserID          uuid.UUID     `json:"user_id"`
+	CursorDate      sql.NullTime  `json:"cursor_date"`
+	CursorStartedAt sql.NullTime  `json:"cursor_started_at"`
+	CursorID        uuid.NullUUID `json:"cursor_id"`
+	StartDate       sql.NullTime  `json:"start_date"`
+	EndDate         sql.NullTime  `json:"end_date"`
+	PageLimit       int32         `json:"page_limit"`
+}
+
+type ListWorkoutExportRowsRow struct {
+	ID               uuid.UUID      `json:"id"`
+	UserID           uuid.UUID      `json:"user_id"`
+	Date             time.Time      `json:"date"`
+	StartedAt        time.Time      `json:"started_at"`
+	EndedAt          sql.NullTime   `json:"ended_at"`
+	DailyScore       int32          `json:"daily_score"`
+	Memo             sql.NullString `json:"memo"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	SetID            uuid.NullUUID  `json:"set_id"`
+	ExerciseID       uuid.NullUUID  `json:"exercise_id"`
+	SetNumber        sql.NullInt32  `json:"set_number"`
+	Reps             sql.NullInt32  `json:"reps"`
+	Weight           sql.NullString `json:"weight"`
+	Estimated1rm     sql.NullString `json:"estimated_1rm"`
+	DurationSeconds  sql.NullInt32  `json:"duration_seconds"`
+	Notes            sql.NullString `json:"notes"`
+	SetCreatedAt     sql.NullTime   `json:"set_created_at"`
+	SetType          sql.NullString `json:"set_type"`
+	Rpe              sql.NullString `json:"rpe"`
+	Rir              sql.NullInt32  `json:"rir"`
+	ExerciseName     sql.NullString `json:"exercise_name"`
+	ExerciseBodyPart sql.NullString `json:"exercise_body_part"`
+}
+
+// エクスポート用：ワークアウトをキーセットページネーションで古い順に取得し、セットを種目名・身体部位付きで結合する（NULLの条件は無視する）
+// 1ページはワークアウト単位で区切るため、ワークアウトのセットがページをまたぐことはない
+// セットのないワークアウトは、セットの列がNULLの1行となる
+func (q *Queries) ListWorkoutExportRows(ctx context.Context, arg ListWorkoutExportRowsParams) ([]ListWorkoutExportRowsRow, error) {
+	rows, err := q.db.QueryContext(ctx, ListWorkoutExportRows,
+		arg.UserID,
+		arg.CursorDate,
+		arg.CursorStartedAt,
+		arg.CursorID,
+		arg.StartDate,
+		arg.EndDate,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListWorkoutExportRowsRow{}
+	for rows.Next() {
+		var i ListWorkoutExportRowsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Date,
+			&i.StartedAt,
+			&i.EndedAt,
 			&i.DailyScore,
 			&i.Memo,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.SetID,
+			&i.ExerciseID,
+			&i.SetNumber,
+			&i.Reps,
+			&i.Weight,
+			&i.Estimated1rm,
+			&i.DurationSeconds,
+			&i.Notes,
+			&i.SetCreatedAt,
+			&i.SetType,
+			&i.Rpe,
+			&i.Rir,
+			&i.ExerciseName,
+			&i.ExerciseBodyPart,
 		); err != nil {
 			return nil, err
 		}
@@ -142,9 +293,9 @@ func (q *Queries) ListAllWorkoutsByUser(ctx context.Context, userID uuid.UUID) (
 }
 
 const ListWorkoutsByUser = `-- name: ListWorkoutsByUser :many
-SELECT id, user_id, date, daily_score, memo, created_at, updated_at FROM workouts
+SELECT id, user_id, date, started_at, ended_at, daily_score, memo, created_at, updated_at FROM workouts
 WHERE user_id = $1
-ORDER BY date DESC
+ORDER BY date DESC, started_at DESC
 LIMIT $2 OFFSET $3
 `
 
@@ -167,6 +318,53 @@ func (q *Queries) ListWorkoutsByUser(ctx context.Context, arg ListWorkoutsByUser
 			&i.ID,
 			&i.UserID,
 			&i.Date,
+			&i.StartedAt,
+			&i.EndedAt,
+			&i.DailyScore,
+			&i.Memo,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListWorkoutsByUserAndDate = `-- name: ListWorkoutsByUserAndDate :many
+SELECT id, user_id, date, started_at, ended_at, daily_score, memo, created_at, updated_at FROM workouts
+WHERE user_id = $1 AND date = $2
+ORDER BY started_at
+`
+
+type ListWorkoutsByUserAndDateParams struct {
+	UserID uuid.UUID `json:"user_id"`
+	Date   time.Time `json:"date"`
+}
+
+// 同日の全セッションを開始時刻順に取得
+func (q *Queries) ListWorkoutsByUserAndDate(ctx context.Context, arg ListWorkoutsByUserAndDateParams) ([]Workout, error) {
+	rows, err := q.db.QueryContext(ctx, ListWorkoutsByUserAndDate, arg.UserID, arg.Date)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Workout{}
+	for rows.Next() {
+		var i Workout
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Date,
+			&i.StartedAt,
+			&i.EndedAt,
 			&i.DailyScore,
 			&i.Memo,
 			&i.CreatedAt,
@@ -186,9 +384,9 @@ func (q *Queries) ListWorkoutsByUser(ctx context.Context, arg ListWorkoutsByUser
 }
 
 const ListWorkoutsByUserAndDateRange = `-- name: ListWorkoutsByUserAndDateRange :many
-SELECT id, user_id, date, daily_score, memo, created_at, updated_at FROM workouts
+SELECT id, user_id, date, started_at, ended_at, daily_score, memo, created_at, updated_at FROM workouts
 WHERE user_id = $1 AND date >= $2 AND date <= $3
-ORDER BY date DESC
+ORDER BY date DESC, started_at DESC
 `
 
 type ListWorkoutsByUserAndDateRangeParams struct {
@@ -210,6 +408,8 @@ func (q *Queries) ListWorkoutsByUserAndDateRange(ctx context.Context, arg ListWo
 			&i.ID,
 			&i.UserID,
 			&i.Date,
+			&i.StartedAt,
+			&i.EndedAt,
 			&i.DailyScore,
 			&i.Memo,
 			&i.CreatedAt,
@@ -229,10 +429,11 @@ func (q *Queries) ListWorkoutsByUserAndDateRange(ctx context.Context, arg ListWo
 }
 
 const ListWorkoutsForHeatmap = `-- name: ListWorkoutsForHeatmap :many
-SELECT date, daily_score
+SELECT date, LEAST(SUM(daily_score), 100)::integer AS daily_score
 FROM workouts
 WHERE user_id = $1
   AND date >= CURRENT_DATE - INTERVAL '365 days'
+GROUP BY date
 ORDER BY date
 `
 
@@ -241,7 +442,7 @@ type ListWorkoutsForHeatmapRow struct {
 	DailyScore int32     `json:"daily_score"`
 }
 
-// GitHub風ヒートマップ用：過去365日の運動強度スコアを取得
+// GitHub風ヒートマップ用：過去365日の運動強度スコアを日別に合算して取得（上限100）
 func (q *Queries) ListWorkoutsForHeatmap(ctx context.Context, userID uuid.UUID) ([]ListWorkoutsForHeatmapRow, error) {
 	rows, err := q.db.QueryContext(ctx, ListWorkoutsForHeatmap, userID)
 	if err != nil {
@@ -265,26 +466,200 @@ func (q *Queries) ListWorkoutsForHeatmap(ctx context.Context, userID uuid.UUID)
 	return items, nil
 }
 
+const ListWorkoutsPageDateAsc = `-- name: ListWorkoutsPageDateAsc :many
+SELECT w.id, w.user_id, w.date, w.started_at, w.ended_at, w.daily_score, w.memo, w.created_at, w.updated_at FROM workouts w
+WHERE w.user_id = $1
+  AND ($2::date IS NULL
+    OR (w.date, w.started_at, w.id) > ($2::date, $3::timestamptz, $4::uuid))
+  AND ($5::date IS NULL OR w.date >= $5::date)
+  AND ($6::date IS NULL OR w.date <= $6::date)
+  AND ($7::integer IS NULL OR w.daily_score >= $7::integer)
+  AND ($8::text IS NULL OR w.memo ILIKE '%' || $8::text || '%')
+  AND ($9::uuid IS NULL OR EXISTS (
+    SELECT 1 FROM workout_sets ws
+    WHERE ws.workout_id = w.id AND ws.exercise_id = $9::uuid
+  ))
+  AND ($10::text IS NULL OR EXISTS (
+    SELECT 1 FROM workout_sets ws
+    JOIN exercises e ON e.id = ws.exercise_id
+    WHERE ws.workout_id = w.id AND e.body_part = $10::text
+  ))
+ORDER BY w.date ASC, w.started_at ASC, w.id ASC
+LIMIT $11
+`
+
+type ListWorkoutsPageDateAscParams struct {
+	UserID          uuid.UUID      `json:"user_id"`
+	CursorDate      sql.NullTime   `json:"cursor_date"`
+	CursorStartedAt sql.NullTime   `json:"cursor_started_at"`
+	CursorID        uuid.NullUUID  `json:"cursor_id"`
+	StartDate       sql.NullTime   `json:"start_date"`
+	EndDate         sql.NullTime   `json:"end_date"`
+	MinScore        sql.NullInt32  `json:"min_score"`
+	MemoQuery       sql.NullString `json:"memo_query"`
+	ExerciseID      uuid.NullUUID  `json:"exercise_id"`
+	BodyPart        sql.NullString `json:"body_part"`
+	PageLimit       int32          `json:"page_limit"`
+}
+
+// ワークアウト一覧をキーセットページネーションで古い順に取得（NULLの条件は無視する）
+func (q *Queries) ListWorkoutsPageDateAsc(ctx context.Context, arg ListWorkoutsPageDateAscParams) ([]Workout, error) {
+	rows, err := q.db.QueryContext(ctx, ListWorkoutsPageDateAsc,
+		arg.UserID,
+		arg.CursorDate,
+		arg.CursorStartedAt,
+		arg.CursorID,
+		arg.StartDate,
+		arg.EndDate,
+		arg.MinScore,
+		arg.MemoQuery,
+		arg.ExerciseID,
+		arg.BodyPart,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Workout{}
+	for rows.Next() {
+		var i Workout
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Date,
+			&i.StartedAt,
+			&i.EndedAt,
+			&i.DailyScore,
+			&i.Memo,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const ListWorkoutsPageDateDesc = `-- name: ListWorkoutsPageDateDesc :many
+SELECT w.id, w.user_id, w.date, w.started_at, w.ended_at, w.daily_score, w.memo, w.created_at, w.updated_at FROM workouts w
+WHERE w.user_id = $1
+  AND ($2::date IS NULL
+    OR (w.date, w.started_at, w.id) < ($2::date, $3::timestamptz, $4::uuid))
+  AND ($5::date IS NULL OR w.date >= $5::date)
+  AND ($6::date IS NULL OR w.date <= $6::date)
+  AND ($7::integer IS NULL OR w.daily_score >= $7::integer)
+  AND ($8::text IS NULL OR w.memo ILIKE '%' || $8::text || '%')
+  AND ($9::uuid IS NULL OR EXISTS (
+    SELECT 1 FROM workout_sets ws
+    WHERE ws.workout_id = w.id AND ws.exercise_id = $9::uuid
+  ))
+  AND ($10::text IS NULL OR EXISTS (
+    SELECT 1 FROM workout_sets ws
+    JOIN exercises e ON e.id = ws.exercise_id
+    WHERE ws.workout_id = w.id AND e.body_part = $10::text
+  ))
+ORDER BY w.date DESC, w.started_at DESC, w.id DESC
+LIMIT $11
+`
+
+type ListWorkoutsPageDateDescParams struct {
+	UserID          uuid.UUID      `json:"user_id"`
+	CursorDate      sql.NullTime   `json:"cursor_date"`
+	CursorStartedAt sql.NullTime   `json:"cursor_started_at"`
+	CursorID        uuid.NullUUID  `json:"cursor_id"`
+	StartDate       sql.NullTime   `json:"start_date"`
+	EndDate         sql.NullTime   `json:"end_date"`
+	MinScore        sql.NullInt32  `json:"min_score"`
+	MemoQuery       sql.NullString `json:"memo_query"`
+	ExerciseID      uuid.NullUUID  `json:"exercise_id"`
+	BodyPart        sql.NullString `json:"body_part"`
+	PageLimit       int32          `json:"page_limit"`
+}
+
+// ワークアウト一覧をキーセットページネーションで新しい順に取得（NULLの条件は無視する）
+func (q *Queries) ListWorkoutsPageDateDesc(ctx context.Context, arg ListWorkoutsPageDateDescParams) ([]Workout, error) {
+	rows, err := q.db.QueryContext(ctx, ListWorkoutsPageDateDesc,
+		arg.UserID,
+		arg.CursorDate,
+		arg.CursorStartedAt,
+		arg.CursorID,
+		arg.StartDate,
+		arg.EndDate,
+		arg.MinScore,
+		arg.MemoQuery,
+		arg.ExerciseID,
+		arg.BodyPart,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Workout{}
+	for rows.Next() {
+		var i Workout
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Date,
+			&i.StartedAt,
+			&i.EndedAt,
+			&i.DailyScore,
+			&i.Memo,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const UpdateWorkout = `-- name: UpdateWorkout :one
 UPDATE workouts
-SET daily_score = $2, memo = $3, updated_at = NOW()
+SET daily_score = $2, memo = $3, started_at = $4, ended_at = $5, updated_at = NOW()
 WHERE id = $1
-RETURNING id, user_id, date, daily_score, memo, created_at, updated_at
+RETURNING id, user_id, date, started_at, ended_at, daily_score, memo, created_at, updated_at
 `
 
 type UpdateWorkoutParams struct {
 	ID         uuid.UUID      `json:"id"`
 	DailyScore int32          `json:"daily_score"`
 	Memo       sql.NullString `json:"memo"`
+	StartedAt  time.Time      `json:"started_at"`
+	EndedAt    sql.NullTime   `json:"ended_at"`
 }
 
 func (q *Queries) UpdateWorkout(ctx context.Context, arg UpdateWorkoutParams) (Workout, error) {
-	row := q.db.QueryRowContext(ctx, UpdateWorkout, arg.ID, arg.DailyScore, arg.Memo)
+	row := q.db.QueryRowContext(ctx, UpdateWorkout,
+		arg.ID,
+		arg.DailyScore,
+		arg.Memo,
+		arg.StartedAt,
+		arg.EndedAt,
+	)
 	var i Workout
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
 		&i.Date,
+		&i.StartedAt,
+		&i.EndedAt,
 		&i.DailyScore,
 		&i.Memo,
 		&i.CreatedAt,
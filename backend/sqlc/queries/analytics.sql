@@ -0,0 +1,43 @@
+-- name: ListVolumeByExercise :many
+-- トレーニング量の分析用：期間（週・月）と種目ごとに総負荷量・セット数・レップ数・平均強度を集計（NULLの条件は無視する）
+-- ウォームアップのセットは集計に含めない
+-- 平均強度は1レップあたりの平均重量（総負荷量 / 総レップ数）
+SELECT
+  date_trunc(@period::text, w.date::timestamp)::date as period_start,
+  e.id as exercise_id,
+  e.name as exercise_name,
+  e.body_part as exercise_body_part,
+  ROUND(COALESCE(SUM(ws.weight * ws.reps), 0), 2)::text as tonnage,
+  COUNT(ws.id) as set_count,
+  COALESCE(SUM(ws.reps), 0)::bigint as rep_count,
+  ROUND(COALESCE(SUM(ws.weight * ws.reps) / NULLIF(SUM(ws.reps), 0), 0), 2)::text as average_intensity
+FROM workout_sets ws
+JOIN workouts w ON ws.workout_id = w.id
+JOIN exercises e ON ws.exercise_id = e.id
+WHERE w.user_id = @user_id
+  AND (sqlc.narg('start_date')::date IS NULL OR w.date >= sqlc.narg('start_date')::date)
+  AND (sqlc.narg('end_date')::date IS NULL OR w.date <= sqlc.narg('end_date')::date)
+  AND ws.set_type <> 'warmup'
+GROUP BY period_start, e.id, e.name, e.body_part
+ORDER BY period_start, e.name, e.id;
+
+-- name: ListVolumeByBodyPart :many
+-- トレーニング量の分析用：期間（週・月）と身体部位ごとに総負荷量・セット数・レップ数・平均強度を集計（NULLの条件は無視する）
+-- ウォームアップのセットは集計に含めない
+-- 身体部位が設定されていない種目のセットは、身体部位がNULLの1グループにまとめる
+SELECT
+  date_trunc(@period::text, w.date::timestamp)::date as period_start,
+  e.body_part as exercise_body_part,
+  ROUND(COALESCE(SUM(ws.weight * ws.reps), 0), 2)::text as tonnage,
+  COUNT(ws.id) as set_count,
+  COALESCE(SUM(ws.reps), 0)::bigint as rep_count,
+  ROUND(COALESCE(SUM(ws.weight * ws.reps) / NULLIF(SUM(ws.reps), 0), 0), 2)::text as average_intensity
+FROM workout_sets ws
+JOIN workouts w ON ws.workout_id = w.id
+JOIN exercises e ON ws.exercise_id = e.id
+WHERE w.user_id = @user_id
+  AND (sqlc.narg('start_date')::date IS NULL OR w.date >= sqlc.narg('start_date')::date)
+  AND (sqlc.narg('end_date')::date IS NULL OR w.date <= sqlc.narg('end_date')::date)
+  AND ws.set_type <> 'warmup'
+GROUP BY period_start, e.body_part
+ORDER BY period_start, e.body_part NULLS LAST;
@@ -2,24 +2,27 @@
 SELECT * FROM exercises
 WHERE id = $1 LIMIT 1;
 
--- name: GetExerciseByName :one
+-- name: GetExerciseByNameForUser :one
 SELECT * FROM exercises
-WHERE name = $1 LIMIT 1;
+WHERE name = $1 AND (user_id IS NULL OR user_id = $2)
+ORDER BY user_id NULLS FIRST
+LIMIT 1;
 
--- name: ListExercises :many
+-- name: ListExercisesForUser :many
 SELECT * FROM exercises
+WHERE user_id IS NULL OR user_id = $1
 ORDER BY name;
 
--- name: ListExercisesByBodyPart :many
+-- name: ListExercisesByBodyPartForUser :many
 SELECT * FROM exercises
-WHERE body_part = $1
+WHERE body_part = $1 AND (user_id IS NULL OR user_id = $2)
 ORDER BY name;
 
 -- name: CreateExercise :one
 INSERT INTO exercises (
-  name, description, body_part
+  user_id, name, description, body_part
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4
 )
 RETURNING *;
 
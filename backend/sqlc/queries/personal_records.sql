@@ -0,0 +1,24 @@
+-- name: CreatePersonalRecord :one
+INSERT INTO personal_records (
+  id, user_id, exercise_id, workout_id, workout_set_id, record_type, reps, value, previous_value, achieved_date, created_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+)
+RETURNING *;
+
+-- name: DeletePersonalRecordsByUserAndExercise :exec
+-- 自己ベストの再計算用：特定種目の自己ベスト更新イベントをすべて削除
+DELETE FROM personal_records
+WHERE user_id = $1 AND exercise_id = $2;
+
+-- name: ListPersonalRecordsByUser :many
+-- 自己ベスト履歴：ユーザーの全種目の自己ベスト更新を新しい順に取得
+SELECT * FROM personal_records
+WHERE user_id = $1
+ORDER BY achieved_date DESC, created_at DESC;
+
+-- name: ListPersonalRecordsByUserAndExercise :many
+-- 自己ベスト履歴：特定種目の自己ベスト更新を新しい順に取得
+SELECT * FROM personal_records
+WHERE user_id = $1 AND exercise_id = $2
+ORDER BY achieved_date DESC, created_at DESC;
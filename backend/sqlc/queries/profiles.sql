@@ -1,8 +1,8 @@
 -- name: CreateProfile :one
 INSERT INTO profiles (
-  id, user_id, display_name, age, weight, height, created_at, updated_at
+  id, user_id, display_name, age, weight, height, one_rm_formula, timezone, weekly_workout_goal, rest_day_tolerance, daily_score_strategy, daily_score_backfill_pending, created_at, updated_at
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14
 )
 RETURNING *;
 
@@ -16,7 +16,7 @@ WHERE user_id = $1 LIMIT 1;
 
 -- name: UpdateProfile :one
 UPDATE profiles
-SET display_name = $2, age = $3, weight = $4, height = $5, updated_at = NOW()
+SET display_name = $2, age = $3, weight = $4, height = $5, one_rm_formula = $6, timezone = $7, weekly_workout_goal = $8, rest_day_tolerance = $9, daily_score_strategy = $10, daily_score_backfill_pending = $11, updated_at = NOW()
 WHERE id = $1
 RETURNING *;
 
@@ -26,3 +26,15 @@ WHERE id = $1;
 
 -- name: ExistsProfileByUserID :one
 SELECT EXISTS(SELECT 1 FROM profiles WHERE user_id = $1);
+
+-- name: ListProfilesPendingDailyScoreBackfill :many
+-- デイリースコアの計算方法が変更され、保存済みのスコアの再計算が必要なプロフィールを取得
+SELECT * FROM profiles
+WHERE daily_score_backfill_pending
+ORDER BY updated_at;
+
+-- name: CompleteDailyScoreBackfill :exec
+-- 再計算に使用した計算方法が現在の設定と一致する場合のみ再計算待ちを解除する
+UPDATE profiles
+SET daily_score_backfill_pending = FALSE
+WHERE user_id = $1 AND daily_score_strategy = $2;
@@ -0,0 +1,75 @@
+-- name: GetProgram :one
+SELECT * FROM programs
+WHERE id = $1 LIMIT 1;
+
+-- name: ListProgramsByUser :many
+SELECT * FROM programs
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: CreateProgram :one
+INSERT INTO programs (
+  user_id, name, description
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: UpdateProgramProgress :one
+UPDATE programs
+SET current_day_index = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteProgram :exec
+DELETE FROM programs
+WHERE id = $1;
+
+-- name: ListProgramDaysByProgram :many
+SELECT * FROM program_days
+WHERE program_id = $1
+ORDER BY day_index;
+
+-- name: ListProgramDaysByUser :many
+-- プログラム一覧用：ユーザーの全プログラムの日程をまとめて取得
+SELECT pd.* FROM program_days pd
+JOIN programs p ON pd.program_id = p.id
+WHERE p.user_id = $1
+ORDER BY pd.program_id, pd.day_index;
+
+-- name: ListProgramSetsByProgram :many
+SELECT * FROM program_sets
+WHERE program_id = $1
+ORDER BY day_index, position;
+
+-- name: ListProgramSetsByUser :many
+-- プログラム一覧用：ユーザーの全プログラムのセット処方をまとめて取得
+SELECT ps.* FROM program_sets ps
+JOIN programs p ON ps.program_id = p.id
+WHERE p.user_id = $1
+ORDER BY ps.program_id, ps.day_index, ps.position;
+
+-- name: CreateProgramDay :exec
+INSERT INTO program_days (
+  program_id, day_index, week_number, day_number
+) VALUES (
+  $1, $2, $3, $4
+);
+
+-- name: CreateProgramSet :exec
+INSERT INTO program_sets (
+  program_id, day_index, position, exercise_id, reps, percentage_1rm
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+);
+
+-- name: CreateProgramWorkout :exec
+INSERT INTO program_workouts (
+  workout_id, program_id, day_index
+) VALUES (
+  $1, $2, $3
+);
+
+-- name: GetProgramWorkout :one
+SELECT * FROM program_workouts
+WHERE workout_id = $1 LIMIT 1;
@@ -0,0 +1,49 @@
+-- name: GetRoutine :one
+SELECT * FROM routines
+WHERE id = $1 LIMIT 1;
+
+-- name: ListRoutinesByUser :many
+SELECT * FROM routines
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: CreateRoutine :one
+INSERT INTO routines (
+  user_id, name, description
+) VALUES (
+  $1, $2, $3
+)
+RETURNING *;
+
+-- name: UpdateRoutine :one
+UPDATE routines
+SET name = $2, description = $3, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteRoutine :exec
+DELETE FROM routines
+WHERE id = $1;
+
+-- name: ListRoutineExercisesByRoutine :many
+SELECT * FROM routine_exercises
+WHERE routine_id = $1
+ORDER BY position;
+
+-- name: ListRoutineExercisesByUser :many
+-- ルーティン一覧用：ユーザーの全ルーティンの種目をまとめて取得
+SELECT re.* FROM routine_exercises re
+JOIN routines r ON re.routine_id = r.id
+WHERE r.user_id = $1
+ORDER BY re.routine_id, re.position;
+
+-- name: CreateRoutineExercise :exec
+INSERT INTO routine_exercises (
+  routine_id, position, exercise_id, target_sets, target_reps, target_weight
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+);
+
+-- name: DeleteRoutineExercisesByRoutine :exec
+DELETE FROM routine_exercises
+WHERE routine_id = $1;
@@ -10,10 +10,19 @@ WHERE email = $1 LIMIT 1;
 SELECT * FROM users
 WHERE verification_token = $1 LIMIT 1;
 
+-- name: GetUserByEmailChangeTokenHash :one
+SELECT * FROM users
+WHERE email_change_token_hash = $1 LIMIT 1;
+
 -- name: ListUsers :many
 SELECT * FROM users
 ORDER BY created_at DESC;
 
+-- name: ListUsersDueForDeletion :many
+SELECT * FROM users
+WHERE deletion_scheduled_at <= $1
+ORDER BY deletion_scheduled_at;
+
 -- name: CreateUser :one
 INSERT INTO users (
   email, password_hash, email_verified, verification_token, verification_token_expires_at
@@ -24,10 +33,17 @@ RETURNING *;
 
 -- name: UpdateUser :one
 UPDATE users
-SET email = $2, password_hash = $3, email_verified = $4, verification_token = $5, verification_token_expires_at = $6, updated_at = NOW()
+SET email = $2, password_hash = $3, email_verified = $4, verification_token = $5, verification_token_expires_at = $6, password_reset_token_hash = $7, password_reset_token_expires_at = $8, totp_secret = $9, two_factor_enabled = $10, totp_last_used_step = $11, recovery_code_hashes = $12, pending_email = $13, email_change_token_hash = $14, email_change_token_expires_at = $15, deletion_scheduled_at = $16, updated_at = NOW()
 WHERE id = $1
 RETURNING *;
 
+-- name: ResetUserPasswordByTokenHash :one
+-- パスワードリセット：有効期限内のトークンに一致する場合のみ、パスワードの更新とトークンの消費を1文で行う
+UPDATE users
+SET password_hash = $2, password_reset_token_hash = NULL, password_reset_token_expires_at = NULL, updated_at = NOW()
+WHERE password_reset_token_hash = $1 AND password_reset_token_expires_at > NOW()
+RETURNING *;
+
 -- name: DeleteUser :exec
 DELETE FROM users
 WHERE id = $1;
@@ -8,7 +8,7 @@ WHERE workout_id = $1
 ORDER BY exercise_id, set_number;
 
 -- name: ListWorkoutSetsByExercise :many
--- 重量成長グラフ用：特定種目の推定1RMの推移を取得
+-- 自己ベスト判定用：特定種目のセットを時系列順（日付、ワークアウトの開始時刻、セット番号の順）に取得
 SELECT
   ws.id,
   ws.workout_id,
@@ -20,54 +20,105 @@ SELECT
   ws.duration_seconds,
   ws.notes,
   ws.created_at,
+  ws.set_type,
+  ws.rpe,
+  ws.rir,
   w.date as workout_date
 FROM workout_sets ws
 JOIN workouts w ON ws.workout_id = w.id
 WHERE w.user_id = $1 AND ws.exercise_id = $2
-ORDER BY w.date DESC, ws.set_number;
+ORDER BY w.date, w.started_at, w.created_at, w.id, ws.set_number;
 
 -- name: GetMaxEstimated1RMByExercise :many
--- 各日の最大推定1RMを取得（重量成長グラフ用）
+-- 各日の最大推定1RMを取得（重量成長グラフ用、ウォームアップセットを除く）
 SELECT
   w.date,
   MAX(ws.estimated_1rm) as max_1rm
 FROM workouts w
 JOIN workout_sets ws ON w.id = ws.workout_id
-WHERE w.user_id = $1 AND ws.exercise_id = $2
+WHERE w.user_id = $1 AND ws.exercise_id = $2 AND ws.set_type <> 'warmup'
 GROUP BY w.date
 ORDER BY w.date;
 
+-- name: ListProgressionSetsByExercise :many
+-- 重量成長グラフ用：任意の公式で推定1RMを再計算するため、生の重量・レップ数を日付順に取得（ウォームアップセットを除く）
+SELECT
+  w.date,
+  ws.reps,
+  ws.weight
+FROM workout_sets ws
+JOIN workouts w ON ws.workout_id = w.id
+WHERE w.user_id = $1 AND ws.exercise_id = $2 AND ws.set_type <> 'warmup'
+ORDER BY w.date, ws.set_number;
+
 -- name: ListWorkoutSetsByWorkoutAndExercise :many
 SELECT * FROM workout_sets
 WHERE workout_id = $1 AND exercise_id = $2
 ORDER BY set_number;
 
+-- name: ListWorkoutSetsWithExerciseByWorkouts :many
+-- ワークアウト一覧の展開用：複数ワークアウトのセットを種目名・身体部位付きで一括取得（種目は最初に記録した順、種目内はセット番号順）
+SELECT
+  ws.id,
+  ws.workout_id,
+  ws.exercise_id,
+  ws.set_number,
+  ws.reps,
+  ws.weight,
+  ws.estimated_1rm,
+  ws.duration_seconds,
+  ws.notes,
+  ws.created_at,
+  ws.set_type,
+  ws.rpe,
+  ws.rir,
+  e.name as exercise_name,
+  e.body_part as exercise_body_part
+FROM workout_sets ws
+JOIN exercises e ON ws.exercise_id = e.id
+WHERE ws.workout_id = ANY(@workout_ids::uuid[])
+ORDER BY ws.workout_id, MIN(ws.created_at) OVER (PARTITION BY ws.workout_id, ws.exercise_id), ws.exercise_id, ws.set_number;
+
 -- name: ListWorkoutSetsByExerciseID :many
 SELECT * FROM workout_sets
 WHERE exercise_id = $1
 ORDER BY created_at DESC;
 
+-- name: ListWorkoutSetsByUser :many
+-- 1RM計算式の変更時の再計算用：ユーザーの全セットを取得
+SELECT ws.* FROM workout_sets ws
+JOIN workouts w ON ws.workout_id = w.id
+WHERE w.user_id = $1
+ORDER BY w.date, ws.set_number;
+
 -- name: GetOverallMaxEstimated1RMByExerciseAndUser :one
--- 全期間の最大推定1RMを取得
+-- 全期間の最大推定1RMを取得（ウォームアップセットを除く）
 SELECT COALESCE(MAX(ws.estimated_1rm), '0')::text as max_1rm
 FROM workout_sets ws
 JOIN workouts w ON ws.workout_id = w.id
-WHERE w.user_id = $1 AND ws.exercise_id = $2;
+WHERE w.user_id = $1 AND ws.exercise_id = $2 AND ws.set_type <> 'warmup';
 
 -- name: CreateWorkoutSet :one
 INSERT INTO workout_sets (
-  workout_id, exercise_id, set_number, reps, weight, estimated_1rm, duration_seconds, notes
+  workout_id, exercise_id, set_number, reps, weight, estimated_1rm, duration_seconds, notes, set_type, rpe, rir
 ) VALUES (
-  $1, $2, $3, $4, $5, $6, $7, $8
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
 )
 RETURNING *;
 
 -- name: UpdateWorkoutSet :one
 UPDATE workout_sets
-SET reps = $2, weight = $3, estimated_1rm = $4, duration_seconds = $5, notes = $6
+SET reps = $2, weight = $3, estimated_1rm = $4, duration_seconds = $5, notes = $6, set_type = $7, rpe = $8, rir = $9
 WHERE id = $1
 RETURNING *;
 
+-- name: UpdateWorkoutSetEstimated1RMs :exec
+-- 1RM計算式の変更時の再計算用：複数セットの推定1RMを一括更新
+UPDATE workout_sets AS ws
+SET estimated_1rm = v.estimated_1rm
+FROM unnest(@ids::uuid[], @estimated_1rms::numeric[]) AS v(id, estimated_1rm)
+WHERE ws.id = v.id;
+
 -- name: DeleteWorkoutSet :exec
 DELETE FROM workout_sets
 WHERE id = $1;
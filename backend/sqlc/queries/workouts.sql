@@ -2,47 +2,144 @@
 SELECT * FROM workouts
 WHERE id = $1 LIMIT 1;
 
--- name: GetWorkoutByUserAndDate :one
+-- name: ListWorkoutsByUserAndDate :many
+-- 同日の全セッションを開始時刻順に取得
 SELECT * FROM workouts
-WHERE user_id = $1 AND date = $2 LIMIT 1;
+WHERE user_id = $1 AND date = $2
+ORDER BY started_at;
 
 -- name: ListWorkoutsByUser :many
 SELECT * FROM workouts
 WHERE user_id = $1
-ORDER BY date DESC
+ORDER BY date DESC, started_at DESC
 LIMIT $2 OFFSET $3;
 
 -- name: ListWorkoutsForHeatmap :many
--- GitHub風ヒートマップ用：過去365日の運動強度スコアを取得
-SELECT date, daily_score
+-- GitHub風ヒートマップ用：過去365日の運動強度スコアを日別に合算して取得（上限100）
+SELECT date, LEAST(SUM(daily_score), 100)::integer AS daily_score
 FROM workouts
 WHERE user_id = $1
   AND date >= CURRENT_DATE - INTERVAL '365 days'
+GROUP BY date
 ORDER BY date;
 
+-- name: ListWorkoutsPageDateDesc :many
+-- ワークアウト一覧をキーセットページネーションで新しい順に取得（NULLの条件は無視する）
+SELECT w.* FROM workouts w
+WHERE w.user_id = @user_id
+  AND (sqlc.narg('cursor_date')::date IS NULL
+    OR (w.date, w.started_at, w.id) < (sqlc.narg('cursor_date')::date, sqlc.narg('cursor_started_at')::timestamptz, sqlc.narg('cursor_id')::uuid))
+  AND (sqlc.narg('start_date')::date IS NULL OR w.date >= sqlc.narg('start_date')::date)
+  AND (sqlc.narg('end_date')::date IS NULL OR w.date <= sqlc.narg('end_date')::date)
+  AND (sqlc.narg('min_score')::integer IS NULL OR w.daily_score >= sqlc.narg('min_score')::integer)
+  AND (sqlc.narg('memo_query')::text IS NULL OR w.memo ILIKE '%' || sqlc.narg('memo_query')::text || '%')
+  AND (sqlc.narg('exercise_id')::uuid IS NULL OR EXISTS (
+    SELECT 1 FROM workout_sets ws
+    WHERE ws.workout_id = w.id AND ws.exercise_id = sqlc.narg('exercise_id')::uuid
+  ))
+  AND (sqlc.narg('body_part')::text IS NULL OR EXISTS (
+    SELECT 1 FROM workout_sets ws
+    JOIN exercises e ON e.id = ws.exercise_id
+    WHERE ws.workout_id = w.id AND e.body_part = sqlc.narg('body_part')::text
+  ))
+ORDER BY w.date DESC, w.started_at DESC, w.id DESC
+LIMIT @page_limit;
+
+-- name: ListWorkoutsPageDateAsc :many
+-- ワークアウト一覧をキーセットページネーションで古い順に取得（NULLの条件は無視する）
+SELECT w.* FROM workouts w
+WHERE w.user_id = @user_id
+  AND (sqlc.narg('cursor_date')::date IS NULL
+    OR (w.date, w.started_at, w.id) > (sqlc.narg('cursor_date')::date, sqlc.narg('cursor_started_at')::timestamptz, sqlc.narg('cursor_id')::uuid))
+  AND (sqlc.narg('start_date')::date IS NULL OR w.date >= sqlc.narg('start_date')::date)
+  AND (sqlc.narg('end_date')::date IS NULL OR w.date <= sqlc.narg('end_date')::date)
+  AND (sqlc.narg('min_score')::integer IS NULL OR w.daily_score >= sqlc.narg('min_score')::integer)
+  AND (sqlc.narg('memo_query')::text IS NULL OR w.memo ILIKE '%' || sqlc.narg('memo_query')::text || '%')
+  AND (sqlc.narg('exercise_id')::uuid IS NULL OR EXISTS (
+    SELECT 1 FROM workout_sets ws
+    WHERE ws.workout_id = w.id AND ws.exercise_id = sqlc.narg('exercise_id')::uuid
+  ))
+  AND (sqlc.narg('body_part')::text IS NULL OR EXISTS (
+    SELECT 1 FROM workout_sets ws
+    JOIN exercises e ON e.id = ws.exercise_id
+    WHERE ws.workout_id = w.id AND e.body_part = sqlc.narg('body_part')::text
+  ))
+ORDER BY w.date ASC, w.started_at ASC, w.id ASC
+LIMIT @page_limit;
+
+-- name: ListWorkoutExportRows :many
+-- エクスポート用：ワークアウトをキーセットページネーションで古い順に取得し、セットを種目名・身体部位付きで結合する（NULLの条件は無視する）
+-- 1ページはワークアウト単位で区切るため、ワークアウトのセットがページをまたぐことはない
+-- セットのないワークアウトは、セットの列がNULLの1行となる
+WITH page AS (
+  SELECT w.id, w.user_id, w.date, w.started_at, w.ended_at, w.daily_score, w.memo, w.created_at, w.updated_at
+  FROM workouts w
+  WHERE w.user_id = @user_id
+    AND (sqlc.narg('cursor_date')::date IS NULL
+      OR (w.date, w.started_at, w.id) > (sqlc.narg('cursor_date')::date, sqlc.narg('cursor_started_at')::timestamptz, sqlc.narg('cursor_id')::uuid))
+    AND (sqlc.narg('start_date')::date IS NULL OR w.date >= sqlc.narg('start_date')::date)
+    AND (sqlc.narg('end_date')::date IS NULL OR w.date <= sqlc.narg('end_date')::date)
+  ORDER BY w.date ASC, w.started_at ASC, w.id ASC
+  LIMIT @page_limit
+)
+SELECT
+  p.id,
+  p.user_id,
+  p.date,
+  p.started_at,
+  p.ended_at,
+  p.daily_score,
+  p.memo,
+  p.created_at,
+  p.updated_at,
+  ws.id as set_id,
+  ws.exercise_id,
+  ws.set_number,
+  ws.reps,
+  ws.weight,
+  ws.estimated_1rm,
+  ws.duration_seconds,
+  ws.notes,
+  ws.created_at as set_created_at,
+  ws.set_type,
+  ws.rpe,
+  ws.rir,
+  e.name as exercise_name,
+  e.body_part as exercise_body_part
+FROM page p
+LEFT JOIN workout_sets ws ON ws.workout_id = p.id
+LEFT JOIN exercises e ON e.id = ws.exercise_id
+ORDER BY p.date ASC, p.started_at ASC, p.id ASC, ws.exercise_id, ws.set_number;
+
 -- name: CreateWorkout :one
 INSERT INTO workouts (
-  user_id, date, daily_score, memo
+  user_id, date, started_at, ended_at, daily_score, memo
 ) VALUES (
-  $1, $2, $3, $4
+  $1, $2, $3, $4, $5, $6
 )
 RETURNING *;
 
 -- name: UpdateWorkout :one
 UPDATE workouts
-SET daily_score = $2, memo = $3, updated_at = NOW()
+SET daily_score = $2, memo = $3, started_at = $4, ended_at = $5, updated_at = NOW()
 WHERE id = $1
 RETURNING *;
 
 -- name: ListAllWorkoutsByUser :many
 SELECT * FROM workouts
 WHERE user_id = $1
-ORDER BY date DESC;
+ORDER BY date DESC, started_at DESC;
 
 -- name: ListWorkoutsByUserAndDateRange :many
 SELECT * FROM workouts
 WHERE user_id = $1 AND date >= $2 AND date <= $3
-ORDER BY date DESC;
+ORDER BY date DESC, started_at DESC;
+
+-- name: ListWorkoutDatesByUser :many
+-- 連続記録の計算用：ワークアウトを記録した日付を重複なしで古い順に取得
+SELECT DISTINCT date FROM workouts
+WHERE user_id = $1
+ORDER BY date;
 
 -- name: DeleteWorkout :exec
 DELETE FROM workouts
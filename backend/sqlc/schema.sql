@@ -6,12 +6,25 @@ CREATE TABLE users (
     email_verified BOOLEAN NOT NULL DEFAULT FALSE,
     verification_token VARCHAR(255),
     verification_token_expires_at TIMESTAMPTZ,
+    password_reset_token_hash VARCHAR(64),
+    password_reset_token_expires_at TIMESTAMPTZ,
+    pending_email VARCHAR(255),
+    email_change_token_hash VARCHAR(64),
+    email_change_token_expires_at TIMESTAMPTZ,
+    totp_secret VARCHAR(64),
+    two_factor_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    totp_last_used_step BIGINT NOT NULL DEFAULT 0,
+    recovery_code_hashes TEXT[] NOT NULL DEFAULT '{}',
+    deletion_scheduled_at TIMESTAMPTZ,
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
 
 CREATE INDEX idx_users_email ON users(email);
 CREATE INDEX idx_users_verification_token ON users(verification_token);
+CREATE INDEX idx_users_password_reset_token_hash ON users(password_reset_token_hash);
+CREATE INDEX idx_users_email_change_token_hash ON users(email_change_token_hash);
+CREATE INDEX idx_users_deletion_scheduled_at ON users(deletion_scheduled_at) WHERE deletion_scheduled_at IS NOT NULL;
 
 -- Profiles table
 CREATE TABLE profiles (
@@ -21,24 +34,33 @@ CREATE TABLE profiles (
     age INTEGER CHECK (age >= 0),
     weight DECIMAL(5,2) CHECK (weight > 0),
     height DECIMAL(5,2) CHECK (height >= 1 AND height <= 300),
+    one_rm_formula VARCHAR(20) NOT NULL DEFAULT 'epley' CHECK (one_rm_formula IN ('epley', 'brzycki', 'lombardi', 'mayhew', 'oconner', 'wathan')),
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    weekly_workout_goal INTEGER NOT NULL DEFAULT 3 CHECK (weekly_workout_goal >= 1 AND weekly_workout_goal <= 7),
+    rest_day_tolerance INTEGER NOT NULL DEFAULT 1 CHECK (rest_day_tolerance >= 0 AND rest_day_tolerance <= 6),
+    daily_score_strategy VARCHAR(20) NOT NULL DEFAULT 'absolute' CHECK (daily_score_strategy IN ('absolute', 'bodyweight_relative', 'rolling_average', 'rpe_weighted')),
+    daily_score_backfill_pending BOOLEAN NOT NULL DEFAULT FALSE,
     CONSTRAINT fk_profiles_user_id FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
 CREATE UNIQUE INDEX idx_profiles_user_id ON profiles(user_id);
+CREATE INDEX idx_profiles_daily_score_backfill_pending ON profiles(user_id) WHERE daily_score_backfill_pending;
 
 -- Exercises table
 CREATE TABLE exercises (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
-    name VARCHAR(100) UNIQUE NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
     description TEXT,
     body_part VARCHAR(50),
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
 
-CREATE UNIQUE INDEX idx_exercises_name ON exercises(name);
+CREATE UNIQUE INDEX idx_exercises_global_name ON exercises(name) WHERE user_id IS NULL;
+CREATE UNIQUE INDEX idx_exercises_user_name ON exercises(user_id, name) WHERE user_id IS NOT NULL;
 CREATE INDEX idx_exercises_body_part ON exercises(body_part);
 
 -- Workouts table
@@ -46,17 +68,19 @@ CREATE TABLE workouts (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     user_id UUID NOT NULL,
     date DATE NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ended_at TIMESTAMPTZ,
     daily_score INTEGER NOT NULL DEFAULT 0,
     memo TEXT,
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     CONSTRAINT fk_workouts_user_id FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
-    CONSTRAINT unique_user_date UNIQUE (user_id, date)
+    CONSTRAINT check_workouts_ended_at CHECK (ended_at IS NULL OR ended_at >= started_at)
 );
 
 CREATE INDEX idx_workouts_user_id ON workouts(user_id);
 CREATE INDEX idx_workouts_date ON workouts(date);
-CREATE UNIQUE INDEX idx_workouts_user_date ON workouts(user_id, date);
+CREATE INDEX idx_workouts_user_date ON workouts(user_id, date, started_at);
 
 -- Workout Sets table
 CREATE TABLE workout_sets (
@@ -69,7 +93,11 @@ CREATE TABLE workout_sets (
     estimated_1rm DECIMAL(6,2) NOT NULL,
     duration_seconds INTEGER CHECK (duration_seconds >= 0),
     notes TEXT,
-    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT clock_timestamp(),
+    set_type VARCHAR(10) NOT NULL DEFAULT 'working' CHECK (set_type IN ('warmup', 'working', 'drop', 'failure')),
+    rpe DECIMAL(3,1) CHECK (rpe BETWEEN 6 AND 10 AND rpe * 2 = TRUNC(rpe * 2)),
+    rir INTEGER CHECK (rir BETWEEN 0 AND 10),
+    CONSTRAINT chk_workout_sets_rpe_or_rir CHECK (rpe IS NULL OR rir IS NULL),
     CONSTRAINT fk_workout_sets_workout_id FOREIGN KEY (workout_id) REFERENCES workouts(id) ON DELETE CASCADE,
     CONSTRAINT fk_workout_sets_exercise_id FOREIGN KEY (exercise_id) REFERENCES exercises(id) ON DELETE RESTRICT,
     CONSTRAINT unique_workout_exercise_set UNIQUE (workout_id, exercise_id, set_number)
@@ -78,3 +106,119 @@ CREATE TABLE workout_sets (
 CREATE INDEX idx_workout_sets_workout_id ON workout_sets(workout_id);
 CREATE INDEX idx_workout_sets_exercise_id ON workout_sets(exercise_id);
 CREATE UNIQUE INDEX idx_workout_sets_unique ON workout_sets(workout_id, exercise_id, set_number);
+
+-- Personal Records table
+CREATE TABLE personal_records (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    exercise_id UUID NOT NULL,
+    workout_id UUID NOT NULL,
+    workout_set_id UUID,
+    record_type VARCHAR(20) NOT NULL CHECK (record_type IN ('estimated_1rm', 'rep_max', 'session_volume')),
+    reps INTEGER CHECK (reps > 0),
+    value DECIMAL(10,2) NOT NULL,
+    previous_value DECIMAL(10,2),
+    achieved_date DATE NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_personal_records_user_id FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_personal_records_exercise_id FOREIGN KEY (exercise_id) REFERENCES exercises(id) ON DELETE CASCADE,
+    CONSTRAINT fk_personal_records_workout_id FOREIGN KEY (workout_id) REFERENCES workouts(id) ON DELETE CASCADE,
+    CONSTRAINT fk_personal_records_workout_set_id FOREIGN KEY (workout_set_id) REFERENCES workout_sets(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_personal_records_user_exercise ON personal_records(user_id, exercise_id);
+CREATE INDEX idx_personal_records_user_achieved_date ON personal_records(user_id, achieved_date DESC);
+
+-- Routines table
+CREATE TABLE routines (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_routines_user_id FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_routines_user_id ON routines(user_id);
+
+-- Routine Exercises table
+CREATE TABLE routine_exercises (
+    routine_id UUID NOT NULL,
+    position INTEGER NOT NULL CHECK (position >= 0),
+    exercise_id UUID NOT NULL,
+    target_sets INTEGER NOT NULL CHECK (target_sets > 0),
+    target_reps INTEGER NOT NULL CHECK (target_reps > 0),
+    target_weight DECIMAL(6,2) NOT NULL CHECK (target_weight >= 0),
+    PRIMARY KEY (routine_id, position),
+    CONSTRAINT fk_routine_exercises_routine_id FOREIGN KEY (routine_id) REFERENCES routines(id) ON DELETE CASCADE,
+    CONSTRAINT fk_routine_exercises_exercise_id FOREIGN KEY (exercise_id) REFERENCES exercises(id) ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_routine_exercises_exercise_id ON routine_exercises(exercise_id);
+
+-- Programs table
+CREATE TABLE programs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    current_day_index INTEGER NOT NULL DEFAULT 0 CHECK (current_day_index >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_programs_user_id FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_programs_user_id ON programs(user_id);
+
+-- Program Days table
+CREATE TABLE program_days (
+    program_id UUID NOT NULL,
+    day_index INTEGER NOT NULL CHECK (day_index >= 0),
+    week_number INTEGER NOT NULL CHECK (week_number > 0),
+    day_number INTEGER NOT NULL CHECK (day_number > 0),
+    PRIMARY KEY (program_id, day_index),
+    CONSTRAINT uq_program_days_week_day UNIQUE (program_id, week_number, day_number),
+    CONSTRAINT fk_program_days_program_id FOREIGN KEY (program_id) REFERENCES programs(id) ON DELETE CASCADE
+);
+
+-- Program Sets table
+CREATE TABLE program_sets (
+    program_id UUID NOT NULL,
+    day_index INTEGER NOT NULL,
+    position INTEGER NOT NULL CHECK (position >= 0),
+    exercise_id UUID NOT NULL,
+    reps INTEGER NOT NULL CHECK (reps > 0),
+    percentage_1rm DECIMAL(5,2) NOT NULL CHECK (percentage_1rm > 0 AND percentage_1rm <= 150),
+    PRIMARY KEY (program_id, day_index, position),
+    CONSTRAINT fk_program_sets_program_day FOREIGN KEY (program_id, day_index) REFERENCES program_days(program_id, day_index) ON DELETE CASCADE,
+    CONSTRAINT fk_program_sets_exercise_id FOREIGN KEY (exercise_id) REFERENCES exercises(id) ON DELETE RESTRICT
+);
+
+CREATE INDEX idx_program_sets_exercise_id ON program_sets(exercise_id);
+
+-- Program Workouts table（ワークアウトをプログラムのどの日として記録したかの紐付け）
+CREATE TABLE program_workouts (
+    workout_id UUID PRIMARY KEY,
+    program_id UUID NOT NULL,
+    day_index INTEGER NOT NULL CHECK (day_index >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT fk_program_workouts_workout_id FOREIGN KEY (workout_id) REFERENCES workouts(id) ON DELETE CASCADE,
+    CONSTRAINT fk_program_workouts_program_id FOREIGN KEY (program_id) REFERENCES programs(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_program_workouts_program_id ON program_workouts(program_id);
+
+-- User Identities table（外部IDプロバイダのアカウントとの紐付け）
+CREATE TABLE user_identities (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_user_identities_provider_subject UNIQUE (provider, subject),
+    CONSTRAINT fk_user_identities_user_id FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
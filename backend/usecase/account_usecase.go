@@ -0,0 +1,294 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+var (
+	// ErrRecentLoginRequired はパスワードを持たないユーザーが、直近のログインなしに本人確認が必要な操作を行った場合のエラー
+	ErrRecentLoginRequired = errors.New("recent login required")
+)
+
+const (
+	// AccountDeletionGracePeriod はアカウントの削除を要求してから実際にデータを削除するまでの猶予期間。
+	// 猶予期間中にログインすると削除は取り消される。
+	AccountDeletionGracePeriod = 30 * 24 * time.Hour
+	// RecentLoginWindow はパスワードを持たないユーザーの本人確認として、ログインし直した直後とみなす期間
+	RecentLoginWindow = 10 * time.Minute
+)
+
+// AccountUsecaseInterface はAccountUsecaseのインターフェース。
+// テスト時のモック作成に使用する。
+type AccountUsecaseInterface interface {
+	RequestDeletion(ctx context.Context, userID uuid.UUID, sessionID, password string) (time.Time, error)
+	PurgeDueAccounts(ctx context.Context) (int, error)
+	Export(ctx context.Context, userID uuid.UUID) (*AccountExport, error)
+}
+
+// AccountExport はユーザーが保持する全データのエクスポート結果
+type AccountExport struct {
+	ExportedAt time.Time
+	User       *entity.User
+	// Profile はプロフィール。作成していない場合はnil
+	Profile  *entity.Profile
+	Workouts []WorkoutExport
+	// Exercises はユーザーが作成したカスタム種目（システム種目は含まない）
+	Exercises []*entity.Exercise
+}
+
+// WorkoutExport はワークアウトと、そのセット（種目名付き）の組
+type WorkoutExport struct {
+	Workout *entity.Workout
+	Sets    []repository.WorkoutSetDetail
+}
+
+// AccountUsecase はアカウントの削除とデータエクスポートのビジネスロジックを提供する。
+type AccountUsecase struct {
+	userRepo       repository.UserRepository
+	profileRepo    repository.ProfileRepository
+	workoutRepo    repository.WorkoutRepository
+	workoutSetRepo repository.WorkoutSetRepository
+	exerciseRepo   repository.ExerciseRepository
+	routineRepo    repository.RoutineRepository
+	programRepo    repository.ProgramRepository
+	sessionRepo    repository.SessionRepository
+	objectStorage  repository.ObjectStorageRepository
+	txManager      repository.TransactionManager
+	// now は現在時刻を返す関数（テストで時刻を固定するために差し替える）
+	now func() time.Time
+}
+
+// NewAccountUsecase はAccountUsecaseの新しいインスタンスを生成する。
+// objectStorageはアカウント削除時にアバター画像を削除するために使用する。
+func NewAccountUsecase(
+	userRepo repository.UserRepository,
+	profileRepo repository.ProfileRepository,
+	workoutRepo repository.WorkoutRepository,
+	workoutSetRepo repository.WorkoutSetRepository,
+	exerciseRepo repository.ExerciseRepository,
+	routineRepo repository.RoutineRepository,
+	programRepo repository.ProgramRepository,
+	sessionRepo repository.SessionRepository,
+	objectStorage repository.ObjectStorageRepository,
+	txManager repository.TransactionManager,
+) *AccountUsecase {
+	return &AccountUsecase{
+		userRepo:       userRepo,
+		profileRepo:    profileRepo,
+		workoutRepo:    workoutRepo,
+		workoutSetRepo: workoutSetRepo,
+		exerciseRepo:   exerciseRepo,
+		routineRepo:    routineRepo,
+		programRepo:    programRepo,
+		sessionRepo:    sessionRepo,
+		objectStorage:  objectStorage,
+		txManager:      txManager,
+		now:            time.Now,
+	}
+}
+
+// RequestDeletion は本人確認のうえアカウントの削除を予約し、削除予定日時を返す。
+// パスワードを持つユーザーはパスワードで、外部IDプロバイダ経由のみのユーザーは
+// 現在のセッションがRecentLoginWindow以内のログインで作成されたことで本人確認する。
+// データはAccountDeletionGracePeriodの間残し、PurgeDueAccountsで削除する。
+// 予約と同時に全てのセッションを無効化するため、猶予期間中に削除を取り消すには再度ログインする。
+func (u *AccountUsecase) RequestDeletion(ctx context.Context, userID uuid.UUID, sessionID, password string) (time.Time, error) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil || user == nil {
+		return time.Time{}, ErrUserNotFound
+	}
+
+	if user.HasPassword() {
+		if err := user.VerifyPassword(password); err != nil {
+			return time.Time{}, ErrInvalidCredentials
+		}
+	} else if err := u.checkRecentLogin(ctx, userID, sessionID); err != nil {
+		return time.Time{}, err
+	}
+
+	scheduledAt := u.now().Add(AccountDeletionGracePeriod)
+	user.ScheduleDeletion(scheduledAt)
+
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return time.Time{}, fmt.Errorf("failed to schedule account deletion: %w", err)
+	}
+
+	if err := u.sessionRepo.DeleteAllForUser(ctx, user.ID); err != nil {
+		return time.Time{}, fmt.Errorf("failed to invalidate sessions: %w", err)
+	}
+
+	return scheduledAt, nil
+}
+
+// checkRecentLogin は現在のセッションがRecentLoginWindow以内のログインで作成されたかどうかを確認する。
+func (u *AccountUsecase) checkRecentLogin(ctx context.Context, userID uuid.UUID, sessionID string) error {
+	session, err := u.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil || session.UserID != userID || u.now().Sub(session.CreatedAt) > RecentLoginWindow {
+		return ErrRecentLoginRequired
+	}
+	return nil
+}
+
+// PurgeDueAccounts は削除予定日時を過ぎたアカウントを完全に削除し、削除した件数を返す。
+// 定期実行されることを想定しており、1件の削除に失敗しても残りのアカウントの削除を続け、
+// 失敗したアカウントは次回の実行で再度削除を試みる。
+func (u *AccountUsecase) PurgeDueAccounts(ctx context.Context) (int, error) {
+	users, err := u.userRepo.FindDueForDeletion(ctx, u.now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to find accounts due for deletion: %w", err)
+	}
+
+	purged := 0
+	var firstErr error
+	for _, user := range users {
+		deleted, err := u.purgeAccount(ctx, user.ID)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to purge account %s: %w", user.ID, err)
+			}
+			continue
+		}
+		if deleted {
+			purged++
+		}
+	}
+
+	return purged, firstErr
+}
+
+// purgeAccount はアカウントのデータベース上の全データとアバター画像を削除する。
+// 種目を参照するワークアウト・ルーティン・プログラムを先に削除し、
+// 残り（プロフィール・カスタム種目・自己ベスト・外部アカウントの紐付け）はユーザーの削除に連動して削除される。
+// 削除が取り消されたアカウントの画像を消さないよう、アバター画像はトランザクションのコミット後に削除する。
+// アカウントを削除した場合はtrue、既に存在しないか削除が取り消されていたため何もしなかった場合はfalseを返す。
+func (u *AccountUsecase) purgeAccount(ctx context.Context, userID uuid.UUID) (bool, error) {
+	purged := false
+	err := u.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		user, err := u.userRepo.FindByID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get user: %w", err)
+		}
+		// 一覧の取得後にログインして削除が取り消された場合は削除しない
+		if user == nil || !user.IsDeletionDue(u.now()) {
+			return nil
+		}
+
+		workouts, err := u.workoutRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		for _, workout := range workouts {
+			if err := u.workoutRepo.Delete(ctx, workout.ID); err != nil {
+				return err
+			}
+		}
+
+		routines, err := u.routineRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		for _, routine := range routines {
+			if err := u.routineRepo.Delete(ctx, routine.ID); err != nil {
+				return err
+			}
+		}
+
+		programs, err := u.programRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		for _, program := range programs {
+			if err := u.programRepo.Delete(ctx, program.ID); err != nil {
+				return err
+			}
+		}
+
+		if err := u.userRepo.Delete(ctx, userID); err != nil {
+			return err
+		}
+		purged = true
+		return nil
+	})
+	if err != nil || !purged {
+		return false, err
+	}
+
+	keys, err := u.objectStorage.ListByPrefix(ctx, avatarPrefix(userID))
+	if err != nil {
+		return true, fmt.Errorf("failed to list avatar objects: %w", err)
+	}
+	for _, key := range keys {
+		if err := u.objectStorage.Delete(ctx, key); err != nil {
+			return true, fmt.Errorf("failed to delete avatar object: %w", err)
+		}
+	}
+	return true, nil
+}
+
+// Export はユーザー・プロフィール・ワークアウトとセット・カスタム種目の全データを取得する。
+func (u *AccountUsecase) Export(ctx context.Context, userID uuid.UUID) (*AccountExport, error) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil || user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	profile, err := u.profileRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+
+	workouts, err := u.workoutRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workouts: %w", err)
+	}
+
+	workoutIDs := make([]uuid.UUID, len(workouts))
+	for i, workout := range workouts {
+		workoutIDs[i] = workout.ID
+	}
+	details, err := u.workoutSetRepo.FindDetailsByWorkoutIDs(ctx, workoutIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workout sets: %w", err)
+	}
+	setsByWorkout := make(map[uuid.UUID][]repository.WorkoutSetDetail, len(workouts))
+	for _, detail := range details {
+		setsByWorkout[detail.Set.WorkoutID] = append(setsByWorkout[detail.Set.WorkoutID], detail)
+	}
+
+	workoutExports := make([]WorkoutExport, len(workouts))
+	for i, workout := range workouts {
+		workoutExports[i] = WorkoutExport{
+			Workout: workout,
+			Sets:    setsByWorkout[workout.ID],
+		}
+	}
+
+	exercises, err := u.exerciseRepo.FindAll(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exercises: %w", err)
+	}
+	customExercises := []*entity.Exercise{}
+	for _, exercise := range exercises {
+		if exercise.IsOwnedBy(userID) {
+			customExercises = append(customExercises, exercise)
+		}
+	}
+
+	return &AccountExport{
+		ExportedAt: u.now(),
+		User:       user,
+		Profile:    profile,
+		Workouts:   workoutExports,
+		Exercises:  customExercises,
+	}, nil
+}
@@ -0,0 +1,367 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+)
+
+// accountTestSetup はAccountUsecaseのテストで使用するモックの組
+type accountTestSetup struct {
+	usecase        *AccountUsecase
+	userRepo       *mockUserRepository
+	profileRepo    *mockProfileRepository
+	workoutRepo    *mockWorkoutRepository
+	workoutSetRepo *mockWorkoutSetRepository
+	exerciseRepo   *mockExerciseRepository
+	routineRepo    *mockRoutineRepository
+	programRepo    *mockProgramRepository
+	sessionRepo    *mockSessionRepository
+	objectStorage  *mockObjectStorage
+	now            time.Time
+}
+
+func newAccountTestSetup() *accountTestSetup {
+	s := &accountTestSetup{
+		userRepo:       newMockUserRepository(),
+		profileRepo:    newMockProfileRepository(),
+		workoutRepo:    newMockWorkoutRepository(),
+		workoutSetRepo: newMockWorkoutSetRepository(),
+		exerciseRepo:   newMockExerciseRepository(),
+		routineRepo:    newMockRoutineRepository(),
+		programRepo:    newMockProgramRepository(),
+		sessionRepo:    newMockSessionRepository(),
+		objectStorage:  newMockObjectStorage(),
+		now:            time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	s.workoutSetRepo.exerciseRepo = s.exerciseRepo
+	s.usecase = NewAccountUsecase(s.userRepo, s.profileRepo, s.workoutRepo, s.workoutSetRepo, s.exerciseRepo, s.routineRepo, s.programRepo, s.sessionRepo, s.objectStorage, passthroughTransactionManager{})
+	s.usecase.now = func() time.Time { return s.now }
+	return s
+}
+
+func TestAccountUsecase_RequestDeletion(t *testing.T) {
+	const password = "password123"
+
+	tests := []struct {
+		name     string
+		password string
+		wantErr  error
+	}{
+		{
+			name:     "正常系: 猶予期間後の削除を予約してセッションを無効化",
+			password: password,
+		},
+		{
+			name:     "異常系: パスワードが不正",
+			password: "wrongpassword",
+			wantErr:  ErrInvalidCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newAccountTestSetup()
+			user := s.userRepo.addUser("user@example.com", password)
+			s.sessionRepo.sessions["session-1"] = user.ID
+			s.sessionRepo.sessions["session-2"] = user.ID
+
+			scheduledAt, err := s.usecase.RequestDeletion(context.Background(), user.ID, "session-1", tt.password)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("RequestDeletion() error = %v, want %v", err, tt.wantErr)
+				}
+				if user.DeletionScheduledAt != nil {
+					t.Error("DeletionScheduledAt should not be set on failure")
+				}
+				if len(s.sessionRepo.sessions) != 2 {
+					t.Errorf("sessions = %d, want 2 (should not be invalidated on failure)", len(s.sessionRepo.sessions))
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("RequestDeletion() unexpected error = %v", err)
+			}
+			want := s.now.Add(AccountDeletionGracePeriod)
+			if !scheduledAt.Equal(want) {
+				t.Errorf("RequestDeletion() = %v, want %v", scheduledAt, want)
+			}
+			if user.DeletionScheduledAt == nil || !user.DeletionScheduledAt.Equal(want) {
+				t.Errorf("DeletionScheduledAt = %v, want %v", user.DeletionScheduledAt, want)
+			}
+			if len(s.sessionRepo.sessions) != 0 {
+				t.Errorf("sessions = %d, want 0", len(s.sessionRepo.sessions))
+			}
+		})
+	}
+}
+
+func TestAccountUsecase_RequestDeletion_WithoutPassword(t *testing.T) {
+	tests := []struct {
+		name string
+		// loggedInAgo は現在のセッションを作成してからの経過時間
+		loggedInAgo time.Duration
+		wantErr     error
+	}{
+		{
+			name:        "正常系: ログインし直した直後であればパスワードなしで削除を予約",
+			loggedInAgo: RecentLoginWindow - time.Second,
+		},
+		{
+			name:        "異常系: ログインから時間が経っている",
+			loggedInAgo: RecentLoginWindow + time.Second,
+			wantErr:     ErrRecentLoginRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newAccountTestSetup()
+			user, _ := entity.NewOIDCUser("oidc@example.com")
+			s.userRepo.users["oidc@example.com"] = user
+			s.sessionRepo.sessions["session-1"] = user.ID
+			s.sessionRepo.createdAt["session-1"] = s.now.Add(-tt.loggedInAgo)
+
+			_, err := s.usecase.RequestDeletion(context.Background(), user.ID, "session-1", "")
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RequestDeletion() error = %v, want %v", err, tt.wantErr)
+			}
+			if scheduled := user.DeletionScheduledAt != nil; scheduled != (tt.wantErr == nil) {
+				t.Errorf("deletion scheduled = %v, want %v", scheduled, tt.wantErr == nil)
+			}
+		})
+	}
+
+	t.Run("異常系: 他のユーザーのセッション", func(t *testing.T) {
+		s := newAccountTestSetup()
+		user, _ := entity.NewOIDCUser("oidc@example.com")
+		s.userRepo.users["oidc@example.com"] = user
+		s.sessionRepo.sessions["session-1"] = uuid.New()
+		s.sessionRepo.createdAt["session-1"] = s.now
+
+		if _, err := s.usecase.RequestDeletion(context.Background(), user.ID, "session-1", ""); !errors.Is(err, ErrRecentLoginRequired) {
+			t.Errorf("RequestDeletion() error = %v, want %v", err, ErrRecentLoginRequired)
+		}
+	})
+}
+
+func TestAccountUsecase_PurgeDueAccounts(t *testing.T) {
+	s := newAccountTestSetup()
+	ctx := context.Background()
+
+	// 猶予期間を過ぎたユーザー（データ一式とアバター画像を持つ）
+	due := s.userRepo.addUser("due@example.com", "password123")
+	due.ScheduleDeletion(s.now.Add(-time.Minute))
+	exercise := s.exerciseRepo.addUserExercise(due.ID, "カスタム種目", nil, nil)
+	workout := s.workoutRepo.addWorkout(due.ID, s.now)
+	s.workoutSetRepo.addWorkoutSet(workout.ID, exercise.ID, 1, 10, 50)
+	routineExercise, _ := entity.NewRoutineExercise(exercise.ID, 3, 10, 50)
+	s.routineRepo.addRoutine(due.ID, "ルーティン", routineExercise)
+	programSet, _ := entity.NewProgramSet(exercise.ID, 5, 80)
+	programDay, _ := entity.NewProgramDay(1, 1, []entity.ProgramSet{programSet})
+	s.programRepo.addProgram(due.ID, "プログラム", programDay)
+	s.objectStorage.objects[avatarPrefix(due.ID)+"avatar.png"] = []byte("image")
+
+	// 猶予期間中のユーザーと、削除を要求していないユーザー
+	pending := s.userRepo.addUser("pending@example.com", "password123")
+	pending.ScheduleDeletion(s.now.Add(time.Hour))
+	pendingWorkout := s.workoutRepo.addWorkout(pending.ID, s.now)
+	active := s.userRepo.addUser("active@example.com", "password123")
+	s.objectStorage.objects[avatarPrefix(active.ID)+"avatar.png"] = []byte("image")
+
+	purged, err := s.usecase.PurgeDueAccounts(ctx)
+	if err != nil {
+		t.Fatalf("PurgeDueAccounts() error = %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("PurgeDueAccounts() = %d, want 1", purged)
+	}
+
+	if _, ok := s.userRepo.users["due@example.com"]; ok {
+		t.Error("due user should be deleted")
+	}
+	if _, ok := s.workoutRepo.workouts[workout.ID]; ok {
+		t.Error("workouts of the due user should be deleted")
+	}
+	if len(s.routineRepo.routines) != 0 || len(s.programRepo.programs) != 0 {
+		t.Error("routines and programs of the due user should be deleted")
+	}
+	if _, ok := s.objectStorage.objects[avatarPrefix(due.ID)+"avatar.png"]; ok {
+		t.Error("avatar of the due user should be deleted")
+	}
+
+	if _, ok := s.userRepo.users["pending@example.com"]; !ok {
+		t.Error("user within the grace period should not be deleted")
+	}
+	if _, ok := s.workoutRepo.workouts[pendingWorkout.ID]; !ok {
+		t.Error("workouts of the user within the grace period should not be deleted")
+	}
+	if _, ok := s.userRepo.users["active@example.com"]; !ok {
+		t.Error("active user should not be deleted")
+	}
+	if _, ok := s.objectStorage.objects[avatarPrefix(active.ID)+"avatar.png"]; !ok {
+		t.Error("avatar of the active user should not be deleted")
+	}
+}
+
+func TestAccountUsecase_PurgeDueAccounts_StorageError(t *testing.T) {
+	s := newAccountTestSetup()
+
+	due := s.userRepo.addUser("due@example.com", "password123")
+	due.ScheduleDeletion(s.now.Add(-time.Minute))
+	s.objectStorage.err = errors.New("storage unavailable")
+
+	purged, err := s.usecase.PurgeDueAccounts(context.Background())
+	if err == nil {
+		t.Fatal("PurgeDueAccounts() error = nil, want error")
+	}
+	if purged != 0 {
+		t.Errorf("PurgeDueAccounts() = %d, want 0", purged)
+	}
+	// データベース上のデータはアバター画像より先に削除される
+	if _, ok := s.userRepo.users["due@example.com"]; ok {
+		t.Error("user should be deleted before the avatar")
+	}
+}
+
+// snapshotUserRepository は一覧の取得後にアカウントの状態が変わった場合を再現するため、
+// FindDueForDeletion で取得時点の一覧を返すUserRepositoryのモック
+type snapshotUserRepository struct {
+	*mockUserRepository
+	due []*entity.User
+}
+
+func (m *snapshotUserRepository) FindDueForDeletion(ctx context.Context, before time.Time) ([]*entity.User, error) {
+	return m.due, nil
+}
+
+func TestAccountUsecase_PurgeDueAccounts_SkippedAccounts(t *testing.T) {
+	tests := []struct {
+		name string
+		// setup は一覧の取得後、削除前のアカウントの状態の変化を再現する
+		setup   func(s *accountTestSetup, user *entity.User)
+		wantErr bool
+	}{
+		{
+			name: "正常系: 一覧の取得後に削除が取り消されたアカウントは件数に含めない",
+			setup: func(s *accountTestSetup, user *entity.User) {
+				user.CancelDeletion()
+			},
+		},
+		{
+			name: "異常系: ユーザーを取得できない場合はエラーを返し件数に含めない",
+			setup: func(s *accountTestSetup, user *entity.User) {
+				s.userRepo.err = errors.New("database error")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newAccountTestSetup()
+			user := s.userRepo.addUser("due@example.com", "password123")
+			user.ScheduleDeletion(s.now.Add(-time.Minute))
+			s.usecase.userRepo = &snapshotUserRepository{mockUserRepository: s.userRepo, due: []*entity.User{user}}
+			tt.setup(s, user)
+
+			purged, err := s.usecase.PurgeDueAccounts(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PurgeDueAccounts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if purged != 0 {
+				t.Errorf("PurgeDueAccounts() = %d, want 0", purged)
+			}
+			if _, ok := s.userRepo.users["due@example.com"]; !ok {
+				t.Error("skipped user should not be deleted")
+			}
+		})
+	}
+}
+
+func TestAccountUsecase_PurgeAccount_Cancelled(t *testing.T) {
+	s := newAccountTestSetup()
+
+	// 一覧の取得後にログインして削除が取り消されたユーザー
+	user := s.userRepo.addUser("user@example.com", "password123")
+	s.objectStorage.objects[avatarPrefix(user.ID)+"avatar.png"] = []byte("image")
+
+	deleted, err := s.usecase.purgeAccount(context.Background(), user.ID)
+	if err != nil {
+		t.Fatalf("purgeAccount() error = %v", err)
+	}
+	if deleted {
+		t.Error("purgeAccount() = true, want false for a cancelled deletion")
+	}
+
+	if _, ok := s.userRepo.users["user@example.com"]; !ok {
+		t.Error("user whose deletion was cancelled should not be deleted")
+	}
+	if _, ok := s.objectStorage.objects[avatarPrefix(user.ID)+"avatar.png"]; !ok {
+		t.Error("avatar of the user whose deletion was cancelled should not be deleted")
+	}
+}
+
+func TestAccountUsecase_Export(t *testing.T) {
+	s := newAccountTestSetup()
+	ctx := context.Background()
+
+	user := s.userRepo.addUser("user@example.com", "password123")
+	other := uuid.New()
+	profile := s.profileRepo.addProfile(user.ID, "テストユーザー")
+	global := s.exerciseRepo.addExercise("ベンチプレス", nil, nil)
+	custom := s.exerciseRepo.addUserExercise(user.ID, "カスタム種目", nil, nil)
+	s.exerciseRepo.addUserExercise(other, "他人の種目", nil, nil)
+	workout := s.workoutRepo.addWorkout(user.ID, s.now)
+	s.workoutSetRepo.addWorkoutSet(workout.ID, global.ID, 1, 10, 60)
+	s.workoutSetRepo.addWorkoutSet(workout.ID, custom.ID, 1, 8, 20)
+	s.workoutRepo.addWorkout(other, s.now)
+
+	export, err := s.usecase.Export(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	if export.User.ID != user.ID {
+		t.Errorf("User.ID = %v, want %v", export.User.ID, user.ID)
+	}
+	if export.Profile == nil || export.Profile.ID != profile.ID {
+		t.Errorf("Profile = %v, want %v", export.Profile, profile.ID)
+	}
+	if !export.ExportedAt.Equal(s.now) {
+		t.Errorf("ExportedAt = %v, want %v", export.ExportedAt, s.now)
+	}
+	if len(export.Workouts) != 1 || export.Workouts[0].Workout.ID != workout.ID {
+		t.Fatalf("Workouts = %v, want only %v", export.Workouts, workout.ID)
+	}
+	if len(export.Workouts[0].Sets) != 2 {
+		t.Errorf("len(Sets) = %d, want 2", len(export.Workouts[0].Sets))
+	}
+	for _, set := range export.Workouts[0].Sets {
+		if set.ExerciseName == "" {
+			t.Errorf("set %v should have its exercise name", set.Set.ID)
+		}
+	}
+	// システム種目と他のユーザーのカスタム種目は含めない
+	if len(export.Exercises) != 1 || export.Exercises[0].ID != custom.ID {
+		t.Errorf("Exercises = %v, want only %v", export.Exercises, custom.ID)
+	}
+}
+
+func TestAccountUsecase_Export_UserNotFound(t *testing.T) {
+	s := newAccountTestSetup()
+
+	if _, err := s.usecase.Export(context.Background(), uuid.New()); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Export() error = %v, want %v", err, ErrUserNotFound)
+	}
+}
+
+// Ensure AccountUsecase implements AccountUsecaseInterface
+var _ AccountUsecaseInterface = (*AccountUsecase)(nil)
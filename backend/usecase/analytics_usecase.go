@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+var (
+	// ErrInvalidVolumeGroupBy はトレーニング量の集計期間の単位が不正な場合のエラー
+	ErrInvalidVolumeGroupBy = errors.New("invalid group_by: must be week or month")
+	// ErrInvalidVolumeDimension はトレーニング量の集計軸が不正な場合のエラー
+	ErrInvalidVolumeDimension = errors.New("invalid dimension: must be exercise or body_part")
+)
+
+// VolumeGroupBy はトレーニング量を集計する期間の単位を表す（リポジトリ層の型のエイリアス）。
+type VolumeGroupBy = repository.VolumeGroupBy
+
+// VolumeDimension はトレーニング量を集計する軸を表す（リポジトリ層の型のエイリアス）。
+type VolumeDimension = repository.VolumeDimension
+
+// VolumeAggregate は1期間・1グループ分のトレーニング量を表す（レスポンス用エイリアス）。
+type VolumeAggregate = repository.VolumeAggregate
+
+// GetVolumeInput はトレーニング量の集計の入力。
+// GroupByが空の場合は週ごと、Dimensionが空の場合は種目ごとに集計する。
+// StartDate・EndDateがnilの場合は期間を限定しない。
+type GetVolumeInput struct {
+	UserID    uuid.UUID
+	GroupBy   VolumeGroupBy
+	Dimension VolumeDimension
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
+// AnalyticsUsecaseInterface はAnalyticsUsecaseのインターフェース。
+// テスト時のモック作成に使用する。
+type AnalyticsUsecaseInterface interface {
+	GetVolume(ctx context.Context, input GetVolumeInput) ([]VolumeAggregate, error)
+}
+
+// AnalyticsUsecase はダッシュボード向けのトレーニング分析に関するビジネスロジックを提供する。
+type AnalyticsUsecase struct {
+	analyticsRepo repository.AnalyticsRepository
+}
+
+// NewAnalyticsUsecase はAnalyticsUsecaseの新しいインスタンスを生成する。
+//
+// パラメータ:
+//   - analyticsRepo: トレーニング量の集計を担当するリポジトリ
+//
+// 戻り値:
+//   - *AnalyticsUsecase: 生成されたAnalyticsUsecaseインスタンス
+func NewAnalyticsUsecase(analyticsRepo repository.AnalyticsRepository) *AnalyticsUsecase {
+	return &AnalyticsUsecase{
+		analyticsRepo: analyticsRepo,
+	}
+}
+
+// GetVolume は期間（週・月）と種目または身体部位ごとのトレーニング量を、古い期間から順に集計する。
+// 総負荷量（重量 × レップ数の合計）・セット数・レップ数・平均強度（1レップあたりの平均重量）を返す。
+//
+// パラメータ:
+//   - ctx: リクエストのコンテキスト
+//   - input: 集計条件
+//
+// 戻り値:
+//   - []VolumeAggregate: 期間・グループごとのトレーニング量
+//   - error: 以下のエラーが返される可能性がある
+//   - ErrInvalidVolumeGroupBy: 集計期間の単位が不正
+//   - ErrInvalidVolumeDimension: 集計軸が不正
+//   - その他のリポジトリエラー
+func (u *AnalyticsUsecase) GetVolume(ctx context.Context, input GetVolumeInput) ([]VolumeAggregate, error) {
+	groupBy := input.GroupBy
+	if groupBy == "" {
+		groupBy = repository.VolumeGroupByWeek
+	}
+	if groupBy != repository.VolumeGroupByWeek && groupBy != repository.VolumeGroupByMonth {
+		return nil, ErrInvalidVolumeGroupBy
+	}
+
+	dimension := input.Dimension
+	if dimension == "" {
+		dimension = repository.VolumeDimensionExercise
+	}
+	if dimension != repository.VolumeDimensionExercise && dimension != repository.VolumeDimensionBodyPart {
+		return nil, ErrInvalidVolumeDimension
+	}
+
+	return u.analyticsRepo.GetVolume(ctx, repository.VolumeQuery{
+		UserID:    input.UserID,
+		GroupBy:   groupBy,
+		Dimension: dimension,
+		StartDate: input.StartDate,
+		EndDate:   input.EndDate,
+	})
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+// mockAnalyticsRepository はAnalyticsRepositoryのモック実装
+type mockAnalyticsRepository struct {
+	aggregates []VolumeAggregate
+	lastQuery  *repository.VolumeQuery
+	err        error
+}
+
+func (m *mockAnalyticsRepository) GetVolume(ctx context.Context, query repository.VolumeQuery) ([]VolumeAggregate, error) {
+	m.lastQuery = &query
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.aggregates, nil
+}
+
+// Ensure mockAnalyticsRepository implements repository.AnalyticsRepository
+var _ repository.AnalyticsRepository = (*mockAnalyticsRepository)(nil)
+
+func TestAnalyticsUsecase_GetVolume(t *testing.T) {
+	userID := uuid.New()
+	startDate := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	aggregates := []VolumeAggregate{
+		{PeriodStart: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), Tonnage: 1350, SetCount: 3, RepCount: 20, AverageIntensity: 67.5},
+	}
+
+	tests := []struct {
+		name      string
+		input     GetVolumeInput
+		repoErr   error
+		wantQuery *repository.VolumeQuery
+		wantErr   error
+	}{
+		{
+			name:  "正常系: 省略時は週・種目ごとに集計",
+			input: GetVolumeInput{UserID: userID},
+			wantQuery: &repository.VolumeQuery{
+				UserID:    userID,
+				GroupBy:   repository.VolumeGroupByWeek,
+				Dimension: repository.VolumeDimensionExercise,
+			},
+		},
+		{
+			name: "正常系: 月・身体部位ごとに期間を指定して集計",
+			input: GetVolumeInput{
+				UserID:    userID,
+				GroupBy:   repository.VolumeGroupByMonth,
+				Dimension: repository.VolumeDimensionBodyPart,
+				StartDate: &startDate,
+				EndDate:   &endDate,
+			},
+			wantQuery: &repository.VolumeQuery{
+				UserID:    userID,
+				GroupBy:   repository.VolumeGroupByMonth,
+				Dimension: repository.VolumeDimensionBodyPart,
+				StartDate: &startDate,
+				EndDate:   &endDate,
+			},
+		},
+		{
+			name:    "異常系: 集計期間の単位が不正",
+			input:   GetVolumeInput{UserID: userID, GroupBy: "day"},
+			wantErr: ErrInvalidVolumeGroupBy,
+		},
+		{
+			name:    "異常系: 集計軸が不正",
+			input:   GetVolumeInput{UserID: userID, Dimension: "muscle"},
+			wantErr: ErrInvalidVolumeDimension,
+		},
+		{
+			name:    "異常系: リポジトリエラー",
+			input:   GetVolumeInput{UserID: userID},
+			repoErr: errors.New("database error"),
+			wantErr: errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockAnalyticsRepository{aggregates: aggregates, err: tt.repoErr}
+			uc := NewAnalyticsUsecase(repo)
+
+			got, err := uc.GetVolume(context.Background(), tt.input)
+
+			if tt.wantErr != nil {
+				if err == nil || err.Error() != tt.wantErr.Error() {
+					t.Fatalf("GetVolume() error = %v, want %v", err, tt.wantErr)
+				}
+				if tt.repoErr == nil && repo.lastQuery != nil {
+					t.Error("GetVolume() should not query the repository for invalid input")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetVolume() unexpected error = %v", err)
+			}
+
+			if len(got) != len(aggregates) {
+				t.Errorf("GetVolume() returned %d aggregates, want %d", len(got), len(aggregates))
+			}
+			q := repo.lastQuery
+			if q == nil || q.UserID != tt.wantQuery.UserID || q.GroupBy != tt.wantQuery.GroupBy || q.Dimension != tt.wantQuery.Dimension ||
+				q.StartDate != tt.wantQuery.StartDate || q.EndDate != tt.wantQuery.EndDate {
+				t.Errorf("GetVolume() query = %+v, want %+v", q, tt.wantQuery)
+			}
+		})
+	}
+}
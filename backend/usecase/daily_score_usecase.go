@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+	"github.com/ucchy108/whiskey/backend/domain/service"
+)
+
+// DailyScoreUsecaseInterface はDailyScoreUsecaseのインターフェース。
+// テスト時のモック作成に使用する。
+type DailyScoreUsecaseInterface interface {
+	BackfillDailyScores(ctx context.Context) (int, error)
+}
+
+// DailyScoreUsecase は保存済みのデイリースコアの再計算に関するビジネスロジックを提供する。
+type DailyScoreUsecase struct {
+	profileRepo       repository.ProfileRepository
+	workoutRepo       repository.WorkoutRepository
+	workoutSetRepo    repository.WorkoutSetRepository
+	dailyScoreService *service.DailyScoreService
+	txManager         repository.TransactionManager
+}
+
+// NewDailyScoreUsecase はDailyScoreUsecaseの新しいインスタンスを生成する。
+//
+// パラメータ:
+//   - profileRepo: 再計算待ちのプロフィールの取得と完了の記録を担当するリポジトリ
+//   - workoutRepo: ワークアウトの取得とスコアの保存を担当するリポジトリ
+//   - workoutSetRepo: ワークアウトのセットの取得を担当するリポジトリ
+//   - dailyScoreService: デイリースコアを計算するドメインサービス
+//   - txManager: ユーザーごとの再計算を1トランザクションで実行するためのトランザクションマネージャー
+//
+// 戻り値:
+//   - *DailyScoreUsecase: 生成されたDailyScoreUsecaseインスタンス
+func NewDailyScoreUsecase(
+	profileRepo repository.ProfileRepository,
+	workoutRepo repository.WorkoutRepository,
+	workoutSetRepo repository.WorkoutSetRepository,
+	dailyScoreService *service.DailyScoreService,
+	txManager repository.TransactionManager,
+) *DailyScoreUsecase {
+	return &DailyScoreUsecase{
+		profileRepo:       profileRepo,
+		workoutRepo:       workoutRepo,
+		workoutSetRepo:    workoutSetRepo,
+		dailyScoreService: dailyScoreService,
+		txManager:         txManager,
+	}
+}
+
+// BackfillDailyScores はデイリースコアの計算方法が変更されたユーザーの全ワークアウトのスコアを
+// 現在の計算方法で再計算し、再計算したユーザー数を返す。
+// 定期実行されることを想定しており、1ユーザーの再計算に失敗しても残りのユーザーの再計算を続け、
+// 失敗したユーザーは次回の実行で再度再計算を試みる。
+func (u *DailyScoreUsecase) BackfillDailyScores(ctx context.Context) (int, error) {
+	profiles, err := u.profileRepo.FindPendingDailyScoreBackfill(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find profiles pending daily score backfill: %w", err)
+	}
+
+	backfilled := 0
+	var firstErr error
+	for _, profile := range profiles {
+		if err := u.backfillUser(ctx, profile.UserID); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to backfill daily scores for user %s: %w", profile.UserID, err)
+			}
+			continue
+		}
+		backfilled++
+	}
+
+	return backfilled, firstErr
+}
+
+// backfillUser はユーザーの全ワークアウトのデイリースコアを再計算し、再計算待ちを解除する。
+// 再計算待ちの解除は再計算に使用した計算方法が現在も選択されている場合に限るため、
+// 再計算中に計算方法が再度変更された場合は次回の実行で改めて再計算される。
+func (u *DailyScoreUsecase) backfillUser(ctx context.Context, userID uuid.UUID) error {
+	return u.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		// 一覧の取得後に更新された計算方法・体重で計算する
+		profile, err := u.profileRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if profile == nil || !profile.DailyScoreBackfillPending {
+			return nil
+		}
+
+		workouts, err := u.workoutRepo.FindByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		for _, workout := range workouts {
+			sets, err := u.workoutSetRepo.FindByWorkoutID(ctx, workout.ID)
+			if err != nil {
+				return err
+			}
+
+			score, err := u.dailyScoreService.CalculateDailyScore(ctx, workout, sets, profile)
+			if err != nil {
+				return err
+			}
+			if score == workout.DailyScore {
+				continue
+			}
+			if err := workout.UpdateDailyScore(score); err != nil {
+				return err
+			}
+			if err := u.workoutRepo.Update(ctx, workout); err != nil {
+				return err
+			}
+		}
+
+		return u.profileRepo.CompleteDailyScoreBackfill(ctx, userID, profile.DailyScoreStrategy)
+	})
+}
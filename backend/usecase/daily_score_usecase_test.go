@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/service"
+)
+
+func TestDailyScoreUsecase_BackfillDailyScores(t *testing.T) {
+	testDate := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		strategy       entity.DailyScoreStrategyType
+		setErr         error
+		wantBackfilled int
+		wantScore      int32
+		wantPending    bool
+		wantErr        bool
+	}{
+		{
+			name:           "正常系: 変更後の計算方法でスコアを再計算",
+			strategy:       entity.DailyScoreStrategyBodyweightRelative,
+			wantBackfilled: 1,
+			wantScore:      20, // 100 × √((80kg × 10レップ / 80kg) / 250)
+			wantPending:    false,
+		},
+		{
+			name:           "正常系: 計算方法を変更していないユーザーは対象外",
+			strategy:       entity.DefaultDailyScoreStrategy,
+			wantBackfilled: 0,
+			wantScore:      0,
+			wantPending:    false,
+		},
+		{
+			name:           "異常系: セットの取得に失敗した場合は再計算待ちのまま",
+			strategy:       entity.DailyScoreStrategyBodyweightRelative,
+			setErr:         errors.New("database error"),
+			wantBackfilled: 0,
+			wantScore:      0,
+			wantPending:    true,
+			wantErr:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profileRepo := newMockProfileRepository()
+			workoutRepo := newMockWorkoutRepository()
+			workoutSetRepo := newMockWorkoutSetRepository()
+			uc := NewDailyScoreUsecase(profileRepo, workoutRepo, workoutSetRepo, service.NewDailyScoreService(workoutRepo, workoutSetRepo), passthroughTransactionManager{})
+
+			userID := uuid.New()
+			profile := profileRepo.addProfile(userID, "テストユーザー")
+			if err := profile.UpdateWeight(float64Ptr(80)); err != nil {
+				t.Fatalf("UpdateWeight() unexpected error = %v", err)
+			}
+			if err := profile.UpdateDailyScoreStrategy(tt.strategy); err != nil {
+				t.Fatalf("UpdateDailyScoreStrategy() unexpected error = %v", err)
+			}
+
+			// 自重種目（懸垂10レップ）のみのワークアウト。絶対値での計算では0になる
+			workout := workoutRepo.addWorkout(userID, testDate)
+			set, _ := entity.NewWorkoutSet(workout.ID, uuid.New(), 1, 10, 0)
+			workoutSetRepo.sets[set.ID] = set
+			workoutSetRepo.err = tt.setErr
+
+			backfilled, err := uc.BackfillDailyScores(context.Background())
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("BackfillDailyScores() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if backfilled != tt.wantBackfilled {
+				t.Errorf("BackfillDailyScores() = %v, want %v", backfilled, tt.wantBackfilled)
+			}
+			if workout.DailyScore != tt.wantScore {
+				t.Errorf("workout.DailyScore = %v, want %v", workout.DailyScore, tt.wantScore)
+			}
+			if profile.DailyScoreBackfillPending != tt.wantPending {
+				t.Errorf("profile.DailyScoreBackfillPending = %v, want %v", profile.DailyScoreBackfillPending, tt.wantPending)
+			}
+		})
+	}
+}
@@ -13,20 +13,25 @@ import (
 var (
 	// ErrExerciseNotFound はエクササイズが見つからない場合のエラー
 	ErrExerciseNotFound = errors.New("exercise not found")
+	// ErrExerciseAccessDenied はエクササイズの編集・削除が許可されていない場合のエラー
+	ErrExerciseAccessDenied = errors.New("access denied to this exercise")
+	// ErrExerciseInUse はワークアウト・ルーティン・プログラムで使用中のエクササイズを削除しようとした場合のエラー
+	ErrExerciseInUse = errors.New("exercise is used by workouts, routines or programs")
 )
 
 // ExerciseUsecaseInterface はExerciseUsecaseのインターフェース。
 // テスト時のモック作成に使用する。
 type ExerciseUsecaseInterface interface {
-	CreateExercise(ctx context.Context, name string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error)
-	GetExercise(ctx context.Context, id uuid.UUID) (*entity.Exercise, error)
-	ListExercises(ctx context.Context, bodyPart *entity.BodyPart) ([]*entity.Exercise, error)
-	UpdateExercise(ctx context.Context, id uuid.UUID, name *string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error)
-	DeleteExercise(ctx context.Context, id uuid.UUID) error
+	CreateExercise(ctx context.Context, userID uuid.UUID, name string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error)
+	GetExercise(ctx context.Context, userID, id uuid.UUID) (*entity.Exercise, error)
+	ListExercises(ctx context.Context, userID uuid.UUID, bodyPart *entity.BodyPart) ([]*entity.Exercise, error)
+	UpdateExercise(ctx context.Context, userID, id uuid.UUID, name *string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error)
+	DeleteExercise(ctx context.Context, userID, id uuid.UUID) error
 }
 
 // ExerciseUsecase はエクササイズに関するビジネスロジックを提供する。
 // エクササイズの作成、取得、一覧、更新、削除のユースケースを実装する。
+// エクササイズはシステム種目（全ユーザー共通・編集不可）とユーザーごとのカスタム種目に分かれる。
 type ExerciseUsecase struct {
 	exerciseRepo    repository.ExerciseRepository
 	exerciseService *service.ExerciseService
@@ -47,11 +52,12 @@ func NewExerciseUsecase(exerciseRepo repository.ExerciseRepository, exerciseServ
 	}
 }
 
-// CreateExercise は新しいエクササイズを作成する。
+// CreateExercise はユーザーが所有する新しいカスタムエクササイズを作成する。
 // 名前の重複チェック、バリデーションを実施し、エクササイズを永続化する。
 //
 // パラメータ:
 //   - ctx: リクエストのコンテキスト
+//   - userID: エクササイズを所有するユーザーのID
 //   - name: エクササイズ名（1〜100文字）
 //   - description: エクササイズの説明（省略可）
 //   - bodyPart: 対象の身体部位（省略可）
@@ -59,18 +65,18 @@ func NewExerciseUsecase(exerciseRepo repository.ExerciseRepository, exerciseServ
 // 戻り値:
 //   - *entity.Exercise: 作成されたエクササイズエンティティ
 //   - error: 以下のエラーが返される可能性がある
-//     - service.ErrExerciseNameAlreadyExists: エクササイズ名が既に存在
+//     - service.ErrExerciseNameAlreadyExists: システム種目または自身の種目に同名が存在
 //     - entity.ErrInvalidExerciseName: エクササイズ名が不正
 //     - entity.ErrInvalidBodyPart: 身体部位が不正
 //     - その他のリポジトリエラー
-func (u *ExerciseUsecase) CreateExercise(ctx context.Context, name string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error) {
+func (u *ExerciseUsecase) CreateExercise(ctx context.Context, userID uuid.UUID, name string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error) {
 	// 名前のユニーク性チェック（ドメインサービス）
-	if err := u.exerciseService.CheckNameUniqueness(ctx, name); err != nil {
+	if err := u.exerciseService.CheckNameUniqueness(ctx, userID, name); err != nil {
 		return nil, err
 	}
 
 	// エンティティ作成（バリデーション含む）
-	exercise, err := entity.NewExercise(name, description, bodyPart)
+	exercise, err := entity.NewUserExercise(userID, name, description, bodyPart)
 	if err != nil {
 		return nil, err
 	}
@@ -84,9 +90,11 @@ func (u *ExerciseUsecase) CreateExercise(ctx context.Context, name string, descr
 }
 
 // GetExercise は指定されたIDのエクササイズを取得する。
+// システム種目と自身のカスタム種目のみ取得でき、他ユーザーのカスタム種目は存在しないものとして扱う。
 //
 // パラメータ:
 //   - ctx: リクエストのコンテキスト
+//   - userID: リクエストしたユーザーのID
 //   - id: 取得するエクササイズのID
 //
 // 戻り値:
@@ -94,37 +102,34 @@ func (u *ExerciseUsecase) CreateExercise(ctx context.Context, name string, descr
 //   - error: 以下のエラーが返される可能性がある
 //     - ErrExerciseNotFound: 指定されたIDのエクササイズが存在しない
 //     - その他のリポジトリエラー
-func (u *ExerciseUsecase) GetExercise(ctx context.Context, id uuid.UUID) (*entity.Exercise, error) {
-	exercise, err := u.exerciseRepo.FindByID(ctx, id)
-	if err != nil {
-		return nil, ErrExerciseNotFound
-	}
-
-	return exercise, nil
+func (u *ExerciseUsecase) GetExercise(ctx context.Context, userID, id uuid.UUID) (*entity.Exercise, error) {
+	return u.getVisibleExercise(ctx, userID, id)
 }
 
-// ListExercises はエクササイズの一覧を取得する。
+// ListExercises はシステム種目と自身のカスタム種目の一覧を取得する。
 // 身体部位が指定された場合はフィルタリングして返す。
 //
 // パラメータ:
 //   - ctx: リクエストのコンテキスト
+//   - userID: リクエストしたユーザーのID
 //   - bodyPart: フィルタリングする身体部位（nilの場合は全件取得）
 //
 // 戻り値:
 //   - []*entity.Exercise: エクササイズのリスト
 //   - error: リポジトリエラー
-func (u *ExerciseUsecase) ListExercises(ctx context.Context, bodyPart *entity.BodyPart) ([]*entity.Exercise, error) {
+func (u *ExerciseUsecase) ListExercises(ctx context.Context, userID uuid.UUID, bodyPart *entity.BodyPart) ([]*entity.Exercise, error) {
 	if bodyPart != nil {
-		return u.exerciseRepo.FindByBodyPart(ctx, *bodyPart)
+		return u.exerciseRepo.FindByBodyPart(ctx, userID, *bodyPart)
 	}
-	return u.exerciseRepo.FindAll(ctx)
+	return u.exerciseRepo.FindAll(ctx, userID)
 }
 
-// UpdateExercise は既存のエクササイズを更新する。
+// UpdateExercise は自身が所有するカスタムエクササイズを更新する。
 // 名前変更時は重複チェックを実施する。nilのフィールドは更新しない。
 //
 // パラメータ:
 //   - ctx: リクエストのコンテキスト
+//   - userID: リクエストしたユーザーのID
 //   - id: 更新するエクササイズのID
 //   - name: 新しい名前（nilの場合は変更なし）
 //   - description: 新しい説明（nilの場合は変更なし）
@@ -134,20 +139,21 @@ func (u *ExerciseUsecase) ListExercises(ctx context.Context, bodyPart *entity.Bo
 //   - *entity.Exercise: 更新されたエクササイズエンティティ
 //   - error: 以下のエラーが返される可能性がある
 //     - ErrExerciseNotFound: 指定されたIDのエクササイズが存在しない
+//     - ErrExerciseAccessDenied: システム種目のため編集できない
 //     - service.ErrExerciseNameAlreadyExists: 新しい名前が既に存在
 //     - entity.ErrInvalidExerciseName: エクササイズ名が不正
 //     - entity.ErrInvalidBodyPart: 身体部位が不正
 //     - その他のリポジトリエラー
-func (u *ExerciseUsecase) UpdateExercise(ctx context.Context, id uuid.UUID, name *string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error) {
-	// エクササイズ取得
-	exercise, err := u.exerciseRepo.FindByID(ctx, id)
+func (u *ExerciseUsecase) UpdateExercise(ctx context.Context, userID, id uuid.UUID, name *string, description *string, bodyPart *entity.BodyPart) (*entity.Exercise, error) {
+	// エクササイズ取得（所有者チェック）
+	exercise, err := u.getOwnedExercise(ctx, userID, id)
 	if err != nil {
-		return nil, ErrExerciseNotFound
+		return nil, err
 	}
 
 	// 名前変更時はユニーク性チェック（ドメインサービス）
 	if name != nil && *name != exercise.Name {
-		if err := u.exerciseService.CheckNameUniqueness(ctx, *name); err != nil {
+		if err := u.exerciseService.CheckNameUniqueness(ctx, userID, *name); err != nil {
 			return nil, err
 		}
 		if err := exercise.UpdateName(*name); err != nil {
@@ -175,22 +181,56 @@ func (u *ExerciseUsecase) UpdateExercise(ctx context.Context, id uuid.UUID, name
 	return exercise, nil
 }
 
-// DeleteExercise は指定されたIDのエクササイズを削除する。
+// DeleteExercise は自身が所有する指定されたIDのカスタムエクササイズを削除する。
 //
 // パラメータ:
 //   - ctx: リクエストのコンテキスト
+//   - userID: リクエストしたユーザーのID
 //   - id: 削除するエクササイズのID
 //
 // 戻り値:
 //   - error: 以下のエラーが返される可能性がある
 //     - ErrExerciseNotFound: 指定されたIDのエクササイズが存在しない
+//     - ErrExerciseAccessDenied: システム種目のため削除できない
+//     - ErrExerciseInUse: ワークアウトのセット・ルーティン・プログラムで使用されている
 //     - その他のリポジトリエラー
-func (u *ExerciseUsecase) DeleteExercise(ctx context.Context, id uuid.UUID) error {
-	// 存在確認
-	_, err := u.exerciseRepo.FindByID(ctx, id)
+func (u *ExerciseUsecase) DeleteExercise(ctx context.Context, userID, id uuid.UUID) error {
+	// 存在確認（所有者チェック）
+	if _, err := u.getOwnedExercise(ctx, userID, id); err != nil {
+		return err
+	}
+
+	if err := u.exerciseRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrExerciseInUse) {
+			return ErrExerciseInUse
+		}
+		return err
+	}
+	return nil
+}
+
+// getVisibleExercise はユーザーが参照可能なエクササイズを取得する。
+// 存在しない場合、または他ユーザーのカスタム種目の場合はErrExerciseNotFoundを返す。
+func (u *ExerciseUsecase) getVisibleExercise(ctx context.Context, userID, id uuid.UUID) (*entity.Exercise, error) {
+	exercise, err := u.exerciseRepo.FindByID(ctx, id)
+	if err != nil || exercise == nil || !exercise.IsVisibleTo(userID) {
+		return nil, ErrExerciseNotFound
+	}
+
+	return exercise, nil
+}
+
+// getOwnedExercise はユーザーが編集・削除可能なエクササイズを取得する。
+// システム種目の場合はErrExerciseAccessDeniedを返す。
+func (u *ExerciseUsecase) getOwnedExercise(ctx context.Context, userID, id uuid.UUID) (*entity.Exercise, error) {
+	exercise, err := u.getVisibleExercise(ctx, userID, id)
 	if err != nil {
-		return ErrExerciseNotFound
+		return nil, err
 	}
 
-	return u.exerciseRepo.Delete(ctx, id)
+	if !exercise.IsOwnedBy(userID) {
+		return nil, ErrExerciseAccessDenied
+	}
+
+	return exercise, nil
 }
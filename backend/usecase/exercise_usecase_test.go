@@ -15,6 +15,7 @@ import (
 type mockExerciseRepository struct {
 	exercises map[uuid.UUID]*entity.Exercise
 	err       error
+	deleteErr error
 }
 
 func newMockExerciseRepository() *mockExerciseRepository {
@@ -42,36 +43,38 @@ func (m *mockExerciseRepository) FindByID(ctx context.Context, id uuid.UUID) (*e
 	return exercise, nil
 }
 
-func (m *mockExerciseRepository) FindByName(ctx context.Context, name string) (*entity.Exercise, error) {
+func (m *mockExerciseRepository) FindByName(ctx context.Context, userID uuid.UUID, name string) (*entity.Exercise, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
 	for _, exercise := range m.exercises {
-		if exercise.Name == name {
+		if exercise.Name == name && exercise.IsVisibleTo(userID) {
 			return exercise, nil
 		}
 	}
 	return nil, errors.New("exercise not found")
 }
 
-func (m *mockExerciseRepository) FindAll(ctx context.Context) ([]*entity.Exercise, error) {
+func (m *mockExerciseRepository) FindAll(ctx context.Context, userID uuid.UUID) ([]*entity.Exercise, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
 	result := make([]*entity.Exercise, 0, len(m.exercises))
 	for _, exercise := range m.exercises {
-		result = append(result, exercise)
+		if exercise.IsVisibleTo(userID) {
+			result = append(result, exercise)
+		}
 	}
 	return result, nil
 }
 
-func (m *mockExerciseRepository) FindByBodyPart(ctx context.Context, bodyPart entity.BodyPart) ([]*entity.Exercise, error) {
+func (m *mockExerciseRepository) FindByBodyPart(ctx context.Context, userID uuid.UUID, bodyPart entity.BodyPart) ([]*entity.Exercise, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
 	var result []*entity.Exercise
 	for _, exercise := range m.exercises {
-		if exercise.BodyPart != nil && *exercise.BodyPart == bodyPart {
+		if exercise.IsVisibleTo(userID) && exercise.BodyPart != nil && *exercise.BodyPart == bodyPart {
 			result = append(result, exercise)
 		}
 	}
@@ -90,29 +93,39 @@ func (m *mockExerciseRepository) Delete(ctx context.Context, id uuid.UUID) error
 	if m.err != nil {
 		return m.err
 	}
+	if m.deleteErr != nil {
+		return m.deleteErr
+	}
 	delete(m.exercises, id)
 	return nil
 }
 
-func (m *mockExerciseRepository) ExistsByName(ctx context.Context, name string) (bool, error) {
+func (m *mockExerciseRepository) ExistsByName(ctx context.Context, userID uuid.UUID, name string) (bool, error) {
 	if m.err != nil {
 		return false, m.err
 	}
 	for _, exercise := range m.exercises {
-		if exercise.Name == name {
+		if exercise.Name == name && exercise.IsVisibleTo(userID) {
 			return true, nil
 		}
 	}
 	return false, nil
 }
 
-// テストヘルパー: リポジトリにエクササイズを追加
+// テストヘルパー: リポジトリにシステム種目を追加
 func (m *mockExerciseRepository) addExercise(name string, description *string, bodyPart *entity.BodyPart) *entity.Exercise {
 	exercise, _ := entity.NewExercise(name, description, bodyPart)
 	m.exercises[exercise.ID] = exercise
 	return exercise
 }
 
+// テストヘルパー: リポジトリにユーザー所有のカスタム種目を追加
+func (m *mockExerciseRepository) addUserExercise(userID uuid.UUID, name string, description *string, bodyPart *entity.BodyPart) *entity.Exercise {
+	exercise, _ := entity.NewUserExercise(userID, name, description, bodyPart)
+	m.exercises[exercise.ID] = exercise
+	return exercise
+}
+
 // Ensure mockExerciseRepository implements repository.ExerciseRepository
 var _ repository.ExerciseRepository = (*mockExerciseRepository)(nil)
 
@@ -124,6 +137,8 @@ func newExerciseUsecaseForTest(mockRepo *mockExerciseRepository) *ExerciseUsecas
 
 func TestExerciseUsecase_CreateExercise(t *testing.T) {
 	chestPart := entity.BodyPartChest
+	userID := uuid.New()
+	otherUserID := uuid.New()
 
 	tests := []struct {
 		name         string
@@ -151,7 +166,17 @@ func TestExerciseUsecase_CreateExercise(t *testing.T) {
 			wantErr:      false,
 		},
 		{
-			name:         "異常系: 名前が既に存在",
+			name:         "正常系: 他ユーザーのカスタム種目と同じ名前で作成成功",
+			exerciseName: "マイベンチ",
+			description:  nil,
+			bodyPart:     nil,
+			setup: func(m *mockExerciseRepository) {
+				m.addUserExercise(otherUserID, "マイベンチ", nil, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name:         "異常系: システム種目に同じ名前が存在",
 			exerciseName: "ベンチプレス",
 			description:  nil,
 			bodyPart:     &chestPart,
@@ -163,6 +188,19 @@ func TestExerciseUsecase_CreateExercise(t *testing.T) {
 				return errors.Is(err, service.ErrExerciseNameAlreadyExists)
 			},
 		},
+		{
+			name:         "異常系: 自身のカスタム種目に同じ名前が存在",
+			exerciseName: "マイベンチ",
+			description:  nil,
+			bodyPart:     nil,
+			setup: func(m *mockExerciseRepository) {
+				m.addUserExercise(userID, "マイベンチ", nil, nil)
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, service.ErrExerciseNameAlreadyExists)
+			},
+		},
 		{
 			name:         "異常系: 名前が空文字",
 			exerciseName: "",
@@ -194,7 +232,7 @@ func TestExerciseUsecase_CreateExercise(t *testing.T) {
 
 			usecase := newExerciseUsecaseForTest(mockRepo)
 
-			exercise, err := usecase.CreateExercise(context.Background(), tt.exerciseName, tt.description, tt.bodyPart)
+			exercise, err := usecase.CreateExercise(context.Background(), userID, tt.exerciseName, tt.description, tt.bodyPart)
 
 			if tt.wantErr {
 				if err == nil {
@@ -221,6 +259,10 @@ func TestExerciseUsecase_CreateExercise(t *testing.T) {
 				t.Errorf("exercise.Name = %v, want %v", exercise.Name, tt.exerciseName)
 			}
 
+			if !exercise.IsOwnedBy(userID) {
+				t.Errorf("exercise.UserID = %v, want %v", exercise.UserID, userID)
+			}
+
 			// リポジトリに保存されているか確認
 			saved, err := mockRepo.FindByID(context.Background(), exercise.ID)
 			if err != nil {
@@ -237,6 +279,8 @@ func TestExerciseUsecase_CreateExercise(t *testing.T) {
 
 func TestExerciseUsecase_GetExercise(t *testing.T) {
 	chestPart := entity.BodyPartChest
+	userID := uuid.New()
+	otherUserID := uuid.New()
 
 	tests := []struct {
 		name     string
@@ -245,13 +289,21 @@ func TestExerciseUsecase_GetExercise(t *testing.T) {
 		checkErr func(error) bool
 	}{
 		{
-			name: "正常系: エクササイズ取得成功",
+			name: "正常系: システム種目の取得成功",
 			setup: func(m *mockExerciseRepository) uuid.UUID {
 				exercise := m.addExercise("ベンチプレス", nil, &chestPart)
 				return exercise.ID
 			},
 			wantErr: false,
 		},
+		{
+			name: "正常系: 自身のカスタム種目の取得成功",
+			setup: func(m *mockExerciseRepository) uuid.UUID {
+				exercise := m.addUserExercise(userID, "マイベンチ", nil, &chestPart)
+				return exercise.ID
+			},
+			wantErr: false,
+		},
 		{
 			name: "異常系: エクササイズが存在しない",
 			setup: func(m *mockExerciseRepository) uuid.UUID {
@@ -262,6 +314,17 @@ func TestExerciseUsecase_GetExercise(t *testing.T) {
 				return errors.Is(err, ErrExerciseNotFound)
 			},
 		},
+		{
+			name: "異常系: 他ユーザーのカスタム種目は取得できない",
+			setup: func(m *mockExerciseRepository) uuid.UUID {
+				exercise := m.addUserExercise(otherUserID, "他人の種目", nil, nil)
+				return exercise.ID
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrExerciseNotFound)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -271,7 +334,7 @@ func TestExerciseUsecase_GetExercise(t *testing.T) {
 
 			usecase := newExerciseUsecaseForTest(mockRepo)
 
-			exercise, err := usecase.GetExercise(context.Background(), exerciseID)
+			exercise, err := usecase.GetExercise(context.Background(), userID, exerciseID)
 
 			if tt.wantErr {
 				if err == nil {
@@ -304,6 +367,8 @@ func TestExerciseUsecase_GetExercise(t *testing.T) {
 func TestExerciseUsecase_ListExercises(t *testing.T) {
 	chestPart := entity.BodyPartChest
 	legsPart := entity.BodyPartLegs
+	userID := uuid.New()
+	otherUserID := uuid.New()
 
 	tests := []struct {
 		name      string
@@ -332,6 +397,27 @@ func TestExerciseUsecase_ListExercises(t *testing.T) {
 			wantCount: 1,
 			wantErr:   false,
 		},
+		{
+			name:     "正常系: システム種目と自身のカスタム種目のみ取得",
+			bodyPart: nil,
+			setup: func(m *mockExerciseRepository) {
+				m.addExercise("ベンチプレス", nil, &chestPart)
+				m.addUserExercise(userID, "マイベンチ", nil, &chestPart)
+				m.addUserExercise(otherUserID, "他人の種目", nil, &chestPart)
+			},
+			wantCount: 2,
+			wantErr:   false,
+		},
+		{
+			name:     "正常系: 身体部位でフィルタリング（他ユーザーの種目は除外）",
+			bodyPart: &chestPart,
+			setup: func(m *mockExerciseRepository) {
+				m.addUserExercise(userID, "マイベンチ", nil, &chestPart)
+				m.addUserExercise(otherUserID, "他人の種目", nil, &chestPart)
+			},
+			wantCount: 1,
+			wantErr:   false,
+		},
 		{
 			name:      "正常系: 空結果",
 			bodyPart:  nil,
@@ -348,7 +434,7 @@ func TestExerciseUsecase_ListExercises(t *testing.T) {
 
 			usecase := newExerciseUsecaseForTest(mockRepo)
 
-			exercises, err := usecase.ListExercises(context.Background(), tt.bodyPart)
+			exercises, err := usecase.ListExercises(context.Background(), userID, tt.bodyPart)
 
 			if tt.wantErr {
 				if err == nil {
@@ -372,6 +458,8 @@ func TestExerciseUsecase_ListExercises(t *testing.T) {
 func TestExerciseUsecase_UpdateExercise(t *testing.T) {
 	chestPart := entity.BodyPartChest
 	backPart := entity.BodyPartBack
+	userID := uuid.New()
+	otherUserID := uuid.New()
 
 	tests := []struct {
 		name        string
@@ -388,7 +476,7 @@ func TestExerciseUsecase_UpdateExercise(t *testing.T) {
 			description: nil,
 			bodyPart:    nil,
 			setup: func(m *mockExerciseRepository) uuid.UUID {
-				exercise := m.addExercise("ベンチプレス", nil, &chestPart)
+				exercise := m.addUserExercise(userID, "ベンチプレス", nil, &chestPart)
 				return exercise.ID
 			},
 			wantErr: false,
@@ -399,7 +487,7 @@ func TestExerciseUsecase_UpdateExercise(t *testing.T) {
 			description: strPtr("フラットベンチプレス"),
 			bodyPart:    nil,
 			setup: func(m *mockExerciseRepository) uuid.UUID {
-				exercise := m.addExercise("ベンチプレス", nil, &chestPart)
+				exercise := m.addUserExercise(userID, "ベンチプレス", nil, &chestPart)
 				return exercise.ID
 			},
 			wantErr: false,
@@ -410,7 +498,7 @@ func TestExerciseUsecase_UpdateExercise(t *testing.T) {
 			description: nil,
 			bodyPart:    &backPart,
 			setup: func(m *mockExerciseRepository) uuid.UUID {
-				exercise := m.addExercise("ベンチプレス", nil, &chestPart)
+				exercise := m.addUserExercise(userID, "ベンチプレス", nil, &chestPart)
 				return exercise.ID
 			},
 			wantErr: false,
@@ -434,8 +522,8 @@ func TestExerciseUsecase_UpdateExercise(t *testing.T) {
 			description: nil,
 			bodyPart:    nil,
 			setup: func(m *mockExerciseRepository) uuid.UUID {
-				exercise := m.addExercise("ベンチプレス", nil, &chestPart)
-				m.addExercise("スクワット", nil, nil)
+				exercise := m.addUserExercise(userID, "ベンチプレス", nil, &chestPart)
+				m.addUserExercise(userID, "スクワット", nil, nil)
 				return exercise.ID
 			},
 			wantErr: true,
@@ -443,13 +531,56 @@ func TestExerciseUsecase_UpdateExercise(t *testing.T) {
 				return errors.Is(err, service.ErrExerciseNameAlreadyExists)
 			},
 		},
+		{
+			name:        "異常系: システム種目の名前と重複",
+			newName:     strPtr("デッドリフト"),
+			description: nil,
+			bodyPart:    nil,
+			setup: func(m *mockExerciseRepository) uuid.UUID {
+				exercise := m.addUserExercise(userID, "ベンチプレス", nil, &chestPart)
+				m.addExercise("デッドリフト", nil, nil)
+				return exercise.ID
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, service.ErrExerciseNameAlreadyExists)
+			},
+		},
+		{
+			name:        "異常系: システム種目は編集できない",
+			newName:     strPtr("テスト"),
+			description: nil,
+			bodyPart:    nil,
+			setup: func(m *mockExerciseRepository) uuid.UUID {
+				exercise := m.addExercise("ベンチプレス", nil, &chestPart)
+				return exercise.ID
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrExerciseAccessDenied)
+			},
+		},
+		{
+			name:        "異常系: 他ユーザーのカスタム種目は編集できない",
+			newName:     strPtr("テスト"),
+			description: nil,
+			bodyPart:    nil,
+			setup: func(m *mockExerciseRepository) uuid.UUID {
+				exercise := m.addUserExercise(otherUserID, "他人の種目", nil, nil)
+				return exercise.ID
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrExerciseNotFound)
+			},
+		},
 		{
 			name:        "異常系: 名前が空文字",
 			newName:     strPtr(""),
 			description: nil,
 			bodyPart:    nil,
 			setup: func(m *mockExerciseRepository) uuid.UUID {
-				exercise := m.addExercise("ベンチプレス", nil, &chestPart)
+				exercise := m.addUserExercise(userID, "ベンチプレス", nil, &chestPart)
 				return exercise.ID
 			},
 			wantErr: true,
@@ -466,7 +597,7 @@ func TestExerciseUsecase_UpdateExercise(t *testing.T) {
 
 			usecase := newExerciseUsecaseForTest(mockRepo)
 
-			exercise, err := usecase.UpdateExercise(context.Background(), exerciseID, tt.newName, tt.description, tt.bodyPart)
+			exercise, err := usecase.UpdateExercise(context.Background(), userID, exerciseID, tt.newName, tt.description, tt.bodyPart)
 
 			if tt.wantErr {
 				if err == nil {
@@ -502,6 +633,8 @@ func TestExerciseUsecase_UpdateExercise(t *testing.T) {
 
 func TestExerciseUsecase_DeleteExercise(t *testing.T) {
 	chestPart := entity.BodyPartChest
+	userID := uuid.New()
+	otherUserID := uuid.New()
 
 	tests := []struct {
 		name     string
@@ -512,7 +645,7 @@ func TestExerciseUsecase_DeleteExercise(t *testing.T) {
 		{
 			name: "正常系: エクササイズ削除成功",
 			setup: func(m *mockExerciseRepository) uuid.UUID {
-				exercise := m.addExercise("ベンチプレス", nil, &chestPart)
+				exercise := m.addUserExercise(userID, "ベンチプレス", nil, &chestPart)
 				return exercise.ID
 			},
 			wantErr: false,
@@ -527,6 +660,40 @@ func TestExerciseUsecase_DeleteExercise(t *testing.T) {
 				return errors.Is(err, ErrExerciseNotFound)
 			},
 		},
+		{
+			name: "異常系: システム種目は削除できない",
+			setup: func(m *mockExerciseRepository) uuid.UUID {
+				exercise := m.addExercise("ベンチプレス", nil, &chestPart)
+				return exercise.ID
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrExerciseAccessDenied)
+			},
+		},
+		{
+			name: "異常系: 他ユーザーのカスタム種目は削除できない",
+			setup: func(m *mockExerciseRepository) uuid.UUID {
+				exercise := m.addUserExercise(otherUserID, "他人の種目", nil, nil)
+				return exercise.ID
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrExerciseNotFound)
+			},
+		},
+		{
+			name: "異常系: 使用中のエクササイズは削除できない",
+			setup: func(m *mockExerciseRepository) uuid.UUID {
+				exercise := m.addUserExercise(userID, "ベンチプレス", nil, &chestPart)
+				m.deleteErr = repository.ErrExerciseInUse
+				return exercise.ID
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrExerciseInUse)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -536,7 +703,7 @@ func TestExerciseUsecase_DeleteExercise(t *testing.T) {
 
 			usecase := newExerciseUsecaseForTest(mockRepo)
 
-			err := usecase.DeleteExercise(context.Background(), exerciseID)
+			err := usecase.DeleteExercise(context.Background(), userID, exerciseID)
 
 			if tt.wantErr {
 				if err == nil {
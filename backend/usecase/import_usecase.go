@@ -0,0 +1,584 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+var (
+	// ErrUnsupportedImportFormat はインポート元のファイル形式に対応していない場合のエラー
+	ErrUnsupportedImportFormat = errors.New("unsupported import format")
+	// ErrInvalidImportFile はインポート元のファイルを読み取れない場合のエラー（必須の列がないなど）
+	ErrInvalidImportFile = errors.New("invalid import file")
+	// ErrInvalidWeightUnit は重量の単位が不正な場合のエラー
+	ErrInvalidWeightUnit = errors.New("weight unit must be kg or lb")
+)
+
+// kgPerPound は1ポンドあたりのキログラム
+const kgPerPound = 0.45359237
+
+// ImportUsecaseInterface はImportUsecaseのインターフェース。
+// テスト時のモック作成に使用する。
+type ImportUsecaseInterface interface {
+	ImportWorkouts(ctx context.Context, input ImportWorkoutsInput) (*ImportWorkoutsOutput, error)
+}
+
+// ImportWorkoutsInput はワークアウト履歴のインポートの入力データを表す。
+type ImportWorkoutsInput struct {
+	UserID uuid.UUID
+	// Format はファイル形式（strong, hevy, whiskey）
+	Format string
+	File   io.Reader
+	// WeightUnit はファイルから重量の単位を判別できない場合に使用する単位。空の場合はkg
+	WeightUnit repository.WeightUnit
+	// Location はファイルの日時を解釈するタイムゾーン（ユーザーのタイムゾーン）
+	Location *time.Location
+	// DryRun がtrueの場合は種目の作成・ワークアウトの記録を行わず、結果のプレビューのみを返す
+	DryRun bool
+}
+
+// ImportWorkoutsOutput はワークアウト履歴のインポートの出力データを表す。
+type ImportWorkoutsOutput struct {
+	DryRun bool
+	// Workouts は記録した（ドライランの場合は記録する）ワークアウトと、重複のため記録しなかったワークアウトの日付順の一覧
+	Workouts []ImportedWorkout
+	// Exercises はファイル内の種目名と対応付けた種目の一覧（ファイル内の出現順）
+	Exercises []ImportedExercise
+	// Errors は取り込めなかった行のエラー（行番号順）
+	Errors []repository.ImportRowError
+}
+
+// ImportedWorkout はインポートしたワークアウト1件分の結果
+type ImportedWorkout struct {
+	// WorkoutID は記録したワークアウトのID。ドライランまたは重複の場合はnil
+	WorkoutID     *uuid.UUID
+	Date          time.Time
+	StartedAt     *time.Time
+	SetCount      int
+	ExerciseCount int
+	// Duplicate は同じワークアウトが既に記録されているため記録しなかった（ドライランの場合は記録しない）かどうか
+	Duplicate bool
+}
+
+// ImportedExercise はファイル内の種目名と対応付けた種目
+type ImportedExercise struct {
+	SourceName string
+	// ExerciseID は対応付けた種目のID。ドライランで新しく作成する種目と、取り込んだワークアウトで使われず作成しなかった種目の場合はuuid.Nil
+	ExerciseID uuid.UUID
+	Name       string
+	// Created は対応する種目がなく、新しくカスタム種目として作成した（ドライランの場合は作成する）かどうか
+	Created bool
+}
+
+// ImportUsecase は他のアプリからエクスポートされたワークアウト履歴のインポートのビジネスロジックを提供する。
+type ImportUsecase struct {
+	parsers        map[string]repository.WorkoutHistoryParser
+	exerciseRepo   repository.ExerciseRepository
+	workoutUsecase WorkoutUsecaseInterface
+}
+
+// NewImportUsecase はImportUsecaseの新しいインスタンスを生成する。
+//
+// パラメータ:
+//   - parsers: ファイル形式名をキーとしたファイルのパーサー
+//   - exerciseRepo: 種目の対応付けに使用するリポジトリ
+//   - workoutUsecase: 種目の作成とワークアウトの記録を1トランザクションで行うUsecase
+//
+// 戻り値:
+//   - *ImportUsecase: 生成されたImportUsecaseインスタンス
+func NewImportUsecase(parsers map[string]repository.WorkoutHistoryParser, exerciseRepo repository.ExerciseRepository, workoutUsecase WorkoutUsecaseInterface) *ImportUsecase {
+	return &ImportUsecase{
+		parsers:        parsers,
+		exerciseRepo:   exerciseRepo,
+		workoutUsecase: workoutUsecase,
+	}
+}
+
+// importGroup はインポート元の1ワークアウト分の行
+type importGroup struct {
+	firstLine int
+	first     repository.ImportedSetRow
+	memo      *string
+	sets      []SetInput
+	lines     []int
+}
+
+// ImportWorkouts はワークアウト履歴のファイルを読み取り、ワークアウトとして記録する。
+// 各行の種目名はユーザーから見える種目（システム種目と自身のカスタム種目）に対応付け、
+// 対応する種目がない場合はカスタム種目として作成する。
+// 行はファイル形式ごとのキー（開始日時など）でワークアウトにまとめ、日付順にRecordImportedWorkoutsで一括して記録する。
+// 種目の作成とワークアウトの記録は1トランザクションで行い、既に記録されているワークアウトは重複として記録しない。
+// 検証に失敗した行・ワークアウトは取り込まずに行番号付きのエラーとして返し、残りの取り込みを続ける。
+//
+// パラメータ:
+//   - ctx: リクエストのコンテキスト
+//   - input: ファイル形式・ファイル・重量の単位・タイムゾーン・ドライランの指定
+//
+// 戻り値:
+//   - *ImportWorkoutsOutput: 記録したワークアウト、種目の対応付け、行ごとのエラー
+//   - error: 以下のエラーが返される可能性がある
+//   - ErrUnsupportedImportFormat: ファイル形式に対応していない
+//   - ErrInvalidWeightUnit: 重量の単位が不正
+//   - ErrInvalidImportFile: ファイルを読み取れない
+//   - その他のリポジトリエラー（この場合は何も保存されない）
+func (u *ImportUsecase) ImportWorkouts(ctx context.Context, input ImportWorkoutsInput) (*ImportWorkoutsOutput, error) {
+	parser, ok := u.parsers[input.Format]
+	if !ok {
+		return nil, ErrUnsupportedImportFormat
+	}
+
+	defaultUnit := input.WeightUnit
+	if defaultUnit == "" {
+		defaultUnit = repository.WeightUnitKg
+	}
+	if defaultUnit != repository.WeightUnitKg && defaultUnit != repository.WeightUnitLb {
+		return nil, ErrInvalidWeightUnit
+	}
+
+	loc := input.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	rows, rowErrors, err := parser.Parse(input.File)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+
+	exercises, err := u.exerciseRepo.FindAll(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exercises: %w", err)
+	}
+	matcher := newExerciseMatcher(exercises)
+
+	output := &ImportWorkoutsOutput{DryRun: input.DryRun}
+	resolved := make(map[string]*entity.Exercise)
+	created := make(map[uuid.UUID]*pendingExercise)
+	var groups []*importGroup
+	groupsByKey := make(map[string]*importGroup)
+	setCounts := make(map[string]map[uuid.UUID]int32)
+	groupFor := func(row repository.ImportedSetRow) *importGroup {
+		group, ok := groupsByKey[row.WorkoutKey]
+		if !ok {
+			group = &importGroup{firstLine: row.Line, first: row}
+			groupsByKey[row.WorkoutKey] = group
+			setCounts[row.WorkoutKey] = make(map[uuid.UUID]int32)
+			groups = append(groups, group)
+		}
+		if group.memo == nil {
+			group.memo = row.Memo
+		}
+		group.lines = append(group.lines, row.Line)
+		return group
+	}
+
+	for _, row := range rows {
+		// セットのないワークアウトはセットを加えずに記録する
+		if row.WorkoutOnly {
+			groupFor(row)
+			continue
+		}
+
+		unit := row.WeightUnit
+		if unit == "" {
+			unit = defaultUnit
+		}
+		weight := row.Weight
+		if unit == repository.WeightUnitLb {
+			weight = math.Round(weight*kgPerPound*100) / 100
+		}
+
+		if err := validateImportedSet(row, weight); err != nil {
+			rowErrors = append(rowErrors, repository.ImportRowError{Line: row.Line, Message: err.Error()})
+			continue
+		}
+
+		exercise, ok := resolved[row.ExerciseName]
+		if !ok {
+			exercise, err = resolveExercise(input.UserID, matcher, row, output, created)
+			if err != nil {
+				rowErrors = append(rowErrors, repository.ImportRowError{Line: row.Line, Message: err.Error()})
+				continue
+			}
+			resolved[row.ExerciseName] = exercise
+		}
+
+		group := groupFor(row)
+
+		// セット番号がファイルに記録されていない場合は、ワークアウト内の種目ごとに記録順に採番する
+		setCounts[row.WorkoutKey][exercise.ID]++
+		setNumber := setCounts[row.WorkoutKey][exercise.ID]
+		if row.SetNumber != nil {
+			setNumber = *row.SetNumber
+		}
+
+		group.sets = append(group.sets, SetInput{
+			ExerciseID:      exercise.ID,
+			SetNumber:       setNumber,
+			Reps:            row.Reps,
+			Weight:          weight,
+			DurationSeconds: row.DurationSeconds,
+			Notes:           row.Notes,
+			SetType:         row.SetType,
+			RPE:             row.RPE,
+			RIR:             row.RIR,
+		})
+	}
+
+	// 結果を日付順に返すため、日付順に並べる
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groupStartedAt(groups[i]).Before(groupStartedAt(groups[j]))
+	})
+
+	var recordInputs []RecordWorkoutInput
+	var imported []ImportedWorkout
+	for _, group := range groups {
+		recordInput := RecordWorkoutInput{
+			UserID:    input.UserID,
+			Date:      inLocation(group.first.Date, loc),
+			StartedAt: inLocationPtr(group.first.StartedAt, loc),
+			EndedAt:   inLocationPtr(group.first.EndedAt, loc),
+			Memo:      group.memo,
+			Sets:      group.sets,
+		}
+		if err := validateImportedSession(recordInput); err != nil {
+			rowErrors = append(rowErrors, repository.ImportRowError{
+				Line:    group.firstLine,
+				Message: fmt.Sprintf("failed to import workout on %s (lines %s): %v", recordInput.Date.Format(time.DateOnly), formatLines(group.lines), err),
+			})
+			continue
+		}
+
+		recordInputs = append(recordInputs, recordInput)
+		imported = append(imported, ImportedWorkout{
+			Date:          recordInput.Date,
+			StartedAt:     recordInput.StartedAt,
+			SetCount:      len(group.sets),
+			ExerciseCount: countExercises(group.sets),
+		})
+	}
+
+	// 取り込むワークアウトで使われる種目のみを作成する
+	var newExercises []*entity.Exercise
+	used := make(map[uuid.UUID]bool)
+	for _, recordInput := range recordInputs {
+		for _, set := range recordInput.Sets {
+			if pending, ok := created[set.ExerciseID]; ok && !used[set.ExerciseID] {
+				used[set.ExerciseID] = true
+				newExercises = append(newExercises, pending.exercise)
+			}
+		}
+	}
+
+	if len(recordInputs) > 0 {
+		recorded, err := u.workoutUsecase.RecordImportedWorkouts(ctx, RecordImportedWorkoutsInput{
+			UserID:    input.UserID,
+			Exercises: newExercises,
+			Workouts:  recordInputs,
+			DryRun:    input.DryRun,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to record workouts: %w", err)
+		}
+		for i, result := range recorded.Workouts {
+			imported[i].Duplicate = result.Duplicate
+			if result.Workout != nil {
+				imported[i].WorkoutID = &result.Workout.ID
+			}
+		}
+		if !input.DryRun {
+			for _, exercise := range newExercises {
+				for _, index := range created[exercise.ID].indexes {
+					output.Exercises[index].ExerciseID = exercise.ID
+				}
+			}
+		}
+	}
+	output.Workouts = imported
+
+	sort.SliceStable(rowErrors, func(i, j int) bool {
+		return rowErrors[i].Line < rowErrors[j].Line
+	})
+	output.Errors = rowErrors
+
+	return output, nil
+}
+
+// pendingExercise はインポートのために新しく作成するカスタム種目と、その種目に対応付けたImportWorkoutsOutput.Exercises内の位置
+type pendingExercise struct {
+	exercise *entity.Exercise
+	indexes  []int
+}
+
+// resolveExercise はファイル内の種目名に対応する種目を探し、ない場合はカスタム種目を生成してcreatedに加える。
+// 生成した種目は以降の行の対応付けの候補に加える。永続化はワークアウトの記録と同じトランザクションで行うため、ここでは行わない。
+func resolveExercise(userID uuid.UUID, matcher *exerciseMatcher, row repository.ImportedSetRow, output *ImportWorkoutsOutput, created map[uuid.UUID]*pendingExercise) (*entity.Exercise, error) {
+	if exercise := matcher.match(row.ExerciseName); exercise != nil {
+		entry := ImportedExercise{
+			SourceName: row.ExerciseName,
+			ExerciseID: exercise.ID,
+			Name:       exercise.Name,
+		}
+		// まだ作成していない種目のIDは作成後に設定する
+		if pending, ok := created[exercise.ID]; ok {
+			entry.ExerciseID = uuid.Nil
+			entry.Created = true
+			pending.indexes = append(pending.indexes, len(output.Exercises))
+		}
+		output.Exercises = append(output.Exercises, entry)
+		return exercise, nil
+	}
+
+	exercise, err := entity.NewUserExercise(userID, row.ExerciseName, nil, row.BodyPart)
+	if err != nil {
+		return nil, err
+	}
+	matcher.add(exercise)
+
+	created[exercise.ID] = &pendingExercise{exercise: exercise, indexes: []int{len(output.Exercises)}}
+	output.Exercises = append(output.Exercises, ImportedExercise{
+		SourceName: row.ExerciseName,
+		Name:       exercise.Name,
+		Created:    true,
+	})
+	return exercise, nil
+}
+
+// validateImportedSet はRecordWorkoutと同じ基準でセット1件分の値を検証する。
+// 行番号付きのエラーとして報告するため、ワークアウトにまとめる前に行ごとに検証する。
+func validateImportedSet(row repository.ImportedSetRow, weight float64) error {
+	if row.SetNumber != nil {
+		if err := entity.ValidateSetNumber(*row.SetNumber); err != nil {
+			return err
+		}
+	}
+	if err := entity.ValidateReps(row.Reps); err != nil {
+		return err
+	}
+	if err := entity.ValidateExerciseWeight(weight); err != nil {
+		return err
+	}
+	if row.DurationSeconds != nil {
+		if err := entity.ValidateDuration(*row.DurationSeconds); err != nil {
+			return err
+		}
+	}
+	if row.SetType != nil && !row.SetType.IsValid() {
+		return entity.ErrInvalidSetType
+	}
+	if row.RPE != nil && row.RIR != nil {
+		return entity.ErrRPEAndRIRBothSet
+	}
+	if row.RPE != nil {
+		if err := entity.ValidateRPE(*row.RPE); err != nil {
+			return err
+		}
+	}
+	if row.RIR != nil {
+		if err := entity.ValidateRIR(*row.RIR); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateImportedSession はRecordWorkoutと同じ基準でセッションの時刻を検証する。
+// 一括記録は1件でも不正なワークアウトがあると全体が失敗するため、記録する前にワークアウトごとに検証する。
+func validateImportedSession(input RecordWorkoutInput) error {
+	if input.StartedAt == nil && input.EndedAt == nil {
+		return nil
+	}
+	startedAt := input.Date
+	if input.StartedAt != nil {
+		startedAt = *input.StartedAt
+	}
+	return entity.NewWorkout(input.UserID, input.Date).UpdateSessionTime(startedAt, input.EndedAt)
+}
+
+// groupStartedAt はワークアウトの並び替えに使用する開始日時を返す。
+func groupStartedAt(group *importGroup) time.Time {
+	if group.first.StartedAt != nil {
+		return *group.first.StartedAt
+	}
+	return group.first.Date
+}
+
+// countExercises はセットに含まれる種目の数を返す。
+func countExercises(sets []SetInput) int {
+	seen := make(map[uuid.UUID]bool)
+	for _, set := range sets {
+		seen[set.ExerciseID] = true
+	}
+	return len(seen)
+}
+
+// formatLines は行番号の一覧を "2-5" または "2, 4, 7" の形式で返す。
+func formatLines(lines []int) string {
+	contiguous := true
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != lines[i-1]+1 {
+			contiguous = false
+			break
+		}
+	}
+	if contiguous && len(lines) > 1 {
+		return fmt.Sprintf("%d-%d", lines[0], lines[len(lines)-1])
+	}
+	parts := make([]string, len(lines))
+	for i, line := range lines {
+		parts[i] = fmt.Sprint(line)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// inLocation はUTCとして保持された壁時計の時刻を、指定されたタイムゾーンの同じ時刻に変換する。
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// inLocationPtr はinLocationのポインタ版。nilの場合はnilを返す。
+func inLocationPtr(t *time.Time, loc *time.Location) *time.Time {
+	if t == nil {
+		return nil
+	}
+	converted := inLocation(*t, loc)
+	return &converted
+}
+
+// exerciseAliases は他のアプリで使われる英語の種目名と、対応するシステム種目の名前
+var exerciseAliases = map[string][]string{
+	"ベンチプレス":         {"Bench Press", "Bench Press (Barbell)", "Barbell Bench Press"},
+	"スクワット":          {"Squat", "Squat (Barbell)", "Back Squat", "Barbell Squat"},
+	"デッドリフト":         {"Deadlift", "Deadlift (Barbell)", "Conventional Deadlift"},
+	"ショルダープレス":       {"Shoulder Press", "Overhead Press", "Overhead Press (Barbell)", "Military Press"},
+	"バーベルロウ":         {"Barbell Row", "Bent Over Row", "Bent Over Row (Barbell)"},
+	"ラットプルダウン":       {"Lat Pulldown", "Lat Pulldown (Cable)", "Lat Pulldown (Machine)"},
+	"バイセップカール":       {"Bicep Curl", "Biceps Curl", "Bicep Curl (Barbell)", "Bicep Curl (Dumbbell)"},
+	"トライセップエクステンション": {"Triceps Extension", "Tricep Extension", "Triceps Extension (Cable)", "Triceps Extension (Dumbbell)"},
+}
+
+const (
+	// fuzzyMatchMinLength は表記揺れでの対応付けを行う、正規化した種目名の最小の文字数
+	fuzzyMatchMinLength = 12
+	// fuzzyMatchMaxDistance は表記揺れとして許容する編集距離の上限
+	fuzzyMatchMaxDistance = 1
+)
+
+// exerciseMatcher はファイル内の種目名を、ユーザーから見える種目に曖昧一致で対応付ける。
+type exerciseMatcher struct {
+	// exercises は正規化した名前をキーとした種目
+	exercises map[string]*entity.Exercise
+	// keys は正規化した名前の一覧（曖昧一致の結果を決定的にするため並べ替えて保持する）
+	keys []string
+}
+
+// newExerciseMatcher は対応付けの候補となる種目からexerciseMatcherを生成する。
+func newExerciseMatcher(exercises []*entity.Exercise) *exerciseMatcher {
+	m := &exerciseMatcher{exercises: make(map[string]*entity.Exercise, len(exercises))}
+	for _, exercise := range exercises {
+		m.add(exercise)
+	}
+	return m
+}
+
+// add は対応付けの候補に種目を加える。正規化した名前が同じ種目がある場合は先に加えた種目を優先する。
+func (m *exerciseMatcher) add(exercise *entity.Exercise) {
+	key := normalizeExerciseName(exercise.Name)
+	if _, ok := m.exercises[key]; ok {
+		return
+	}
+	m.exercises[key] = exercise
+	i := sort.SearchStrings(m.keys, key)
+	m.keys = append(m.keys, "")
+	copy(m.keys[i+1:], m.keys[i:])
+	m.keys[i] = key
+}
+
+// match は種目名に対応する種目を返す。対応する種目がない場合はnilを返す。
+// 正規化した名前の完全一致、システム種目の英語名との一致、表記揺れ（編集距離）の順に探す。
+func (m *exerciseMatcher) match(name string) *entity.Exercise {
+	key := normalizeExerciseName(name)
+	if key == "" {
+		return nil
+	}
+	if exercise, ok := m.exercises[key]; ok {
+		return exercise
+	}
+
+	for catalogName, aliases := range exerciseAliases {
+		for _, alias := range aliases {
+			if normalizeExerciseName(alias) != key {
+				continue
+			}
+			if exercise, ok := m.exercises[normalizeExerciseName(catalogName)]; ok {
+				return exercise
+			}
+		}
+	}
+
+	// 綴りの誤り1文字までに限り、十分に長い名前同士でのみ対応付ける。
+	// Incline / Decline のように数文字だけ異なる別の種目を同じ種目として扱わないよう、
+	// 同じ距離の候補が複数ある場合も対応付けない
+	target := []rune(key)
+	if len(target) < fuzzyMatchMinLength {
+		return nil
+	}
+	var best *entity.Exercise
+	for _, candidate := range m.keys {
+		c := []rune(candidate)
+		if len(c) < fuzzyMatchMinLength || levenshtein(target, c) > fuzzyMatchMaxDistance {
+			continue
+		}
+		if best != nil {
+			return nil
+		}
+		best = m.exercises[candidate]
+	}
+	return best
+}
+
+// normalizeExerciseName は種目名を比較用に正規化する。
+// 大文字小文字・空白・記号の違いと、英単語の複数形の違いを無視する。
+func normalizeExerciseName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		if len(word) > 2 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
+			words[i] = strings.TrimSuffix(word, "s")
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// levenshtein は2つの文字列の編集距離を返す。
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
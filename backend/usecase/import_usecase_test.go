@@ -0,0 +1,518 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+// mockWorkoutHistoryParser はWorkoutHistoryParserのモック実装。
+// ファイルの内容に関わらず、設定された行を返す。
+type mockWorkoutHistoryParser struct {
+	rows      []repository.ImportedSetRow
+	rowErrors []repository.ImportRowError
+	err       error
+}
+
+func (m *mockWorkoutHistoryParser) Parse(r io.Reader) ([]repository.ImportedSetRow, []repository.ImportRowError, error) {
+	return m.rows, m.rowErrors, m.err
+}
+
+// Ensure mockWorkoutHistoryParser implements repository.WorkoutHistoryParser
+var _ repository.WorkoutHistoryParser = (*mockWorkoutHistoryParser)(nil)
+
+// importTestSetup はImportUsecaseのテストで使用するモックの組。
+// ワークアウトの記録は実際のWorkoutUsecaseに委譲する。
+type importTestSetup struct {
+	*workoutTestSetup
+	parser  *mockWorkoutHistoryParser
+	usecase *ImportUsecase
+}
+
+func newImportTestSetup() *importTestSetup {
+	ws := newWorkoutTestSetup()
+	parser := &mockWorkoutHistoryParser{}
+	return &importTestSetup{
+		workoutTestSetup: ws,
+		parser:           parser,
+		usecase:          NewImportUsecase(map[string]repository.WorkoutHistoryParser{"test": parser}, ws.exerciseRepo, ws.usecase),
+	}
+}
+
+// importRow はテスト用の行を生成する。開始日時は壁時計の時刻をUTCとして保持する。
+func importRow(line int, key string, startedAt time.Time, exerciseName string, reps int32, weight float64) repository.ImportedSetRow {
+	date := time.Date(startedAt.Year(), startedAt.Month(), startedAt.Day(), 0, 0, 0, 0, time.UTC)
+	return repository.ImportedSetRow{
+		Line:         line,
+		WorkoutKey:   key,
+		Date:         date,
+		StartedAt:    &startedAt,
+		ExerciseName: exerciseName,
+		Reps:         reps,
+		Weight:       weight,
+	}
+}
+
+func TestImportUsecase_ImportWorkouts(t *testing.T) {
+	userID := uuid.New()
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	day1 := time.Date(2023, 1, 15, 8, 30, 0, 0, time.UTC)
+	day2 := time.Date(2023, 1, 17, 18, 0, 0, 0, time.UTC)
+
+	s := newImportTestSetup()
+	bench := s.exerciseRepo.addExercise("ベンチプレス", nil, nil)
+	curl := s.exerciseRepo.addUserExercise(userID, "Hammer Curl", nil, nil)
+	s.parser.rows = []repository.ImportedSetRow{
+		// 2日目の行が先に並んでいても、日付順に記録する
+		importRow(2, "b", day2, "Bench Press (Barbell)", 5, 100),
+		importRow(3, "a", day1, "Bench Press (Barbell)", 10, 60),
+		importRow(4, "a", day1, "Bench Press (Barbell)", 8, 70),
+		importRow(5, "a", day1, "hammer curls", 12, 14),
+		importRow(6, "a", day1, "Cable Fly", 15, 10),
+		importRow(7, "a", day1, "Cable Fly", 0, 10),
+	}
+	s.parser.rowErrors = []repository.ImportRowError{{Line: 8, Message: "invalid date"}}
+
+	out, err := s.usecase.ImportWorkouts(context.Background(), ImportWorkoutsInput{
+		UserID:   userID,
+		Format:   "test",
+		Location: tokyo,
+	})
+	if err != nil {
+		t.Fatalf("ImportWorkouts() error = %v", err)
+	}
+
+	if len(out.Workouts) != 2 {
+		t.Fatalf("len(Workouts) = %d, want 2", len(out.Workouts))
+	}
+	first := out.Workouts[0]
+	if first.WorkoutID == nil {
+		t.Fatal("WorkoutID should be set")
+	}
+	if first.SetCount != 4 || first.ExerciseCount != 3 {
+		t.Errorf("first workout = %d sets / %d exercises, want 4 / 3", first.SetCount, first.ExerciseCount)
+	}
+	wantStart := time.Date(2023, 1, 15, 8, 30, 0, 0, tokyo)
+	if first.StartedAt == nil || !first.StartedAt.Equal(wantStart) {
+		t.Errorf("StartedAt = %v, want %v (wall clock in the user's timezone)", first.StartedAt, wantStart)
+	}
+	workout := s.workoutRepo.workouts[*first.WorkoutID]
+	if workout == nil || workout.Date.Format(time.DateOnly) != "2023-01-15" {
+		t.Errorf("recorded workout = %v, want the workout on 2023-01-15", workout)
+	}
+	if len(s.workoutSetRepo.sets) != 5 {
+		t.Errorf("recorded sets = %d, want 5", len(s.workoutSetRepo.sets))
+	}
+
+	// 英語名はシステム種目、表記揺れは既存のカスタム種目に対応付け、対応する種目がない場合は作成する
+	wantExercises := map[string]struct {
+		id      uuid.UUID
+		created bool
+	}{
+		"Bench Press (Barbell)": {id: bench.ID},
+		"hammer curls":          {id: curl.ID},
+		"Cable Fly":             {created: true},
+	}
+	if len(out.Exercises) != len(wantExercises) {
+		t.Fatalf("Exercises = %v, want %d entries", out.Exercises, len(wantExercises))
+	}
+	for _, e := range out.Exercises {
+		want := wantExercises[e.SourceName]
+		if e.Created != want.created {
+			t.Errorf("%s: Created = %v, want %v", e.SourceName, e.Created, want.created)
+		}
+		if !want.created && e.ExerciseID != want.id {
+			t.Errorf("%s: ExerciseID = %v, want %v", e.SourceName, e.ExerciseID, want.id)
+		}
+		if want.created {
+			created, ok := s.exerciseRepo.exercises[e.ExerciseID]
+			if !ok || !created.IsOwnedBy(userID) || created.Name != "Cable Fly" {
+				t.Errorf("Cable Fly should be created as a custom exercise, got %v", created)
+			}
+		}
+	}
+
+	// ファイルの読み取りエラーと検証エラーを行番号順に返す
+	if len(out.Errors) != 2 || out.Errors[0].Line != 7 || out.Errors[1].Line != 8 {
+		t.Errorf("Errors = %v, want errors on lines 7 and 8", out.Errors)
+	}
+	if out.Errors[0].Message != entity.ErrInvalidReps.Error() {
+		t.Errorf("Errors[0] = %q, want %q", out.Errors[0].Message, entity.ErrInvalidReps.Error())
+	}
+}
+
+func TestImportUsecase_ImportWorkouts_DryRun(t *testing.T) {
+	userID := uuid.New()
+	s := newImportTestSetup()
+	s.exerciseRepo.addExercise("ベンチプレス", nil, nil)
+	start := time.Date(2023, 1, 15, 8, 30, 0, 0, time.UTC)
+	endBeforeStart := start.Add(-time.Hour)
+	invalid := importRow(4, "b", start.Add(48*time.Hour), "Squat", 5, 100)
+	invalid.EndedAt = &endBeforeStart
+	s.parser.rows = []repository.ImportedSetRow{
+		importRow(2, "a", start, "Bench Press", 10, 60),
+		importRow(3, "a", start, "Cable Fly", 15, 10),
+		invalid,
+	}
+
+	out, err := s.usecase.ImportWorkouts(context.Background(), ImportWorkoutsInput{
+		UserID: userID,
+		Format: "test",
+		DryRun: true,
+	})
+	if err != nil {
+		t.Fatalf("ImportWorkouts() error = %v", err)
+	}
+
+	if !out.DryRun {
+		t.Error("DryRun should be true")
+	}
+	if len(out.Workouts) != 1 || out.Workouts[0].WorkoutID != nil {
+		t.Errorf("Workouts = %v, want one preview without ID", out.Workouts)
+	}
+	// 終了時刻が開始時刻より前のワークアウトはRecordWorkoutと同じくエラーにする
+	if len(out.Errors) != 1 || out.Errors[0].Line != 4 {
+		t.Errorf("Errors = %v, want one error on line 4", out.Errors)
+	}
+
+	// 何も保存しない
+	if len(s.workoutRepo.workouts) != 0 || len(s.workoutSetRepo.sets) != 0 {
+		t.Error("dry run should not record workouts")
+	}
+	if len(s.exerciseRepo.exercises) != 1 {
+		t.Errorf("exercises = %d, want 1 (dry run should not create exercises)", len(s.exerciseRepo.exercises))
+	}
+	for _, e := range out.Exercises {
+		if e.SourceName == "Cable Fly" && (!e.Created || e.ExerciseID != uuid.Nil) {
+			t.Errorf("Cable Fly = %+v, want to be created without ID", e)
+		}
+	}
+}
+
+func TestImportUsecase_ImportWorkouts_Duplicate(t *testing.T) {
+	userID := uuid.New()
+	day1 := time.Date(2023, 1, 15, 8, 30, 0, 0, time.UTC)
+	day2 := time.Date(2023, 1, 17, 18, 0, 0, 0, time.UTC)
+
+	s := newImportTestSetup()
+	s.exerciseRepo.addExercise("ベンチプレス", nil, nil)
+	s.parser.rows = []repository.ImportedSetRow{
+		importRow(2, "a", day1, "Bench Press", 10, 60),
+		importRow(3, "a", day1, "Cable Fly", 15, 10),
+		// キーが異なっても、同じ日時・同じセットのワークアウトは重複とする
+		importRow(4, "b", day1, "Bench Press", 10, 60),
+		importRow(5, "b", day1, "Cable Fly", 15, 10),
+	}
+	input := ImportWorkoutsInput{UserID: userID, Format: "test"}
+
+	out, err := s.usecase.ImportWorkouts(context.Background(), input)
+	if err != nil {
+		t.Fatalf("ImportWorkouts() error = %v", err)
+	}
+	if len(out.Workouts) != 2 || out.Workouts[0].Duplicate || !out.Workouts[1].Duplicate || out.Workouts[1].WorkoutID != nil {
+		t.Fatalf("Workouts = %+v, want the second workout skipped as a duplicate", out.Workouts)
+	}
+
+	// 同じファイルを再度インポートしても二重に記録しない（新しい日のワークアウトのみ記録する）
+	s.parser.rows = append(s.parser.rows, importRow(6, "c", day2, "Bench Press", 5, 80))
+	for _, dryRun := range []bool{true, false} {
+		input.DryRun = dryRun
+		out, err = s.usecase.ImportWorkouts(context.Background(), input)
+		if err != nil {
+			t.Fatalf("ImportWorkouts(dryRun=%v) error = %v", dryRun, err)
+		}
+		if len(out.Workouts) != 3 || !out.Workouts[0].Duplicate || !out.Workouts[1].Duplicate || out.Workouts[2].Duplicate {
+			t.Errorf("Workouts(dryRun=%v) = %+v, want only the workout on day2 recorded", dryRun, out.Workouts)
+		}
+	}
+
+	if len(s.workoutRepo.workouts) != 2 || len(s.workoutSetRepo.sets) != 3 {
+		t.Errorf("recorded %d workouts / %d sets, want 2 / 3", len(s.workoutRepo.workouts), len(s.workoutSetRepo.sets))
+	}
+	// 2回目のインポートでは既存のカスタム種目に対応付け、種目を作成しない
+	if len(s.exerciseRepo.exercises) != 2 {
+		t.Errorf("exercises = %d, want 2", len(s.exerciseRepo.exercises))
+	}
+}
+
+func TestImportUsecase_ImportWorkouts_WithoutSets(t *testing.T) {
+	userID := uuid.New()
+	s := newImportTestSetup()
+	memo := "休養"
+	row := repository.ImportedSetRow{
+		Line:        2,
+		WorkoutKey:  "a",
+		Date:        time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC),
+		Memo:        &memo,
+		WorkoutOnly: true,
+	}
+	s.parser.rows = []repository.ImportedSetRow{row}
+	input := ImportWorkoutsInput{UserID: userID, Format: "test"}
+
+	// エクスポートしたセットのないワークアウトもそのまま復元する
+	out, err := s.usecase.ImportWorkouts(context.Background(), input)
+	if err != nil {
+		t.Fatalf("ImportWorkouts() error = %v", err)
+	}
+	if len(out.Workouts) != 1 || out.Workouts[0].WorkoutID == nil || out.Workouts[0].SetCount != 0 || len(out.Errors) != 0 {
+		t.Fatalf("Workouts = %+v, Errors = %v, want one workout without sets", out.Workouts, out.Errors)
+	}
+	workout := s.workoutRepo.workouts[*out.Workouts[0].WorkoutID]
+	if workout.Memo == nil || *workout.Memo != memo {
+		t.Errorf("Memo = %v, want %q", workout.Memo, memo)
+	}
+
+	out, err = s.usecase.ImportWorkouts(context.Background(), input)
+	if err != nil {
+		t.Fatalf("ImportWorkouts() error = %v", err)
+	}
+	if !out.Workouts[0].Duplicate || len(s.workoutRepo.workouts) != 1 {
+		t.Errorf("re-import = %+v, want a duplicate", out.Workouts)
+	}
+}
+
+func TestImportUsecase_ImportWorkouts_Rollback(t *testing.T) {
+	day1 := time.Date(2023, 1, 15, 8, 30, 0, 0, time.UTC)
+	day2 := time.Date(2023, 1, 17, 18, 0, 0, 0, time.UTC)
+
+	s := newImportTestSetup()
+	s.exerciseRepo.addExercise("ベンチプレス", nil, nil)
+	s.parser.rows = []repository.ImportedSetRow{
+		importRow(2, "a", day1, "Cable Fly", 15, 10),
+		importRow(3, "b", day2, "Bench Press", 5, 80),
+	}
+	s.workoutSetRepo.failCreateAt = 2
+	s.workoutSetRepo.createErr = errors.New("db error")
+
+	_, err := s.usecase.ImportWorkouts(context.Background(), ImportWorkoutsInput{UserID: uuid.New(), Format: "test"})
+	if err == nil {
+		t.Fatal("ImportWorkouts() should fail")
+	}
+
+	// 作成したカスタム種目を含め、何も保存しない
+	if len(s.workoutRepo.workouts) != 0 || len(s.workoutSetRepo.sets) != 0 {
+		t.Errorf("recorded %d workouts / %d sets, want none", len(s.workoutRepo.workouts), len(s.workoutSetRepo.sets))
+	}
+	if len(s.exerciseRepo.exercises) != 1 {
+		t.Errorf("exercises = %d, want 1 (the custom exercise should be rolled back)", len(s.exerciseRepo.exercises))
+	}
+}
+
+func TestImportUsecase_ImportWorkouts_RecalculatesHistory(t *testing.T) {
+	userID := uuid.New()
+	s := newImportTestSetup()
+	bench := s.exerciseRepo.addExercise("ベンチプレス", nil, nil)
+	profile := s.profileRepo.addProfile(userID, "テスト")
+	profile.DailyScoreStrategy = entity.DailyScoreStrategyRollingAverage
+
+	// 既存のワークアウトより前の、より重い記録をインポートする
+	existing := s.workoutRepo.addWorkout(userID, time.Date(2023, 1, 20, 0, 0, 0, 0, time.UTC))
+	s.workoutSetRepo.addWorkoutSet(existing.ID, bench.ID, 1, 5, 100)
+
+	// 現在の日に同じ種目を含むプログラムがあっても、インポートしたワークアウトでは進めない
+	program := s.programRepo.addProgram(userID, "5x5", entity.ProgramDay{Week: 1, Day: 1, Sets: []entity.ProgramSet{{ExerciseID: bench.ID, Reps: 5, PercentageOf1RM: 80}}})
+
+	s.parser.rows = []repository.ImportedSetRow{
+		importRow(2, "a", time.Date(2023, 1, 15, 8, 30, 0, 0, time.UTC), "Bench Press", 5, 200),
+	}
+	out, err := s.usecase.ImportWorkouts(context.Background(), ImportWorkoutsInput{UserID: userID, Format: "test"})
+	if err != nil {
+		t.Fatalf("ImportWorkouts() error = %v", err)
+	}
+	imported := *out.Workouts[0].WorkoutID
+
+	// 自己ベストは全履歴から時系列で再構築し、後の軽い記録は自己ベストとしない
+	if len(s.personalRecordRepo.records) == 0 {
+		t.Fatal("personal records should be rebuilt")
+	}
+	for _, record := range s.personalRecordRepo.records {
+		if record.WorkoutID != imported {
+			t.Errorf("record %v on workout %v, want only records on the imported workout", record.RecordType, record.WorkoutID)
+		}
+	}
+
+	// 後続のワークアウトのスコアは、インポートしたワークアウトを含む平均負荷量で再計算する（50 × 500 / 1000）
+	if existing.DailyScore != 25 {
+		t.Errorf("existing DailyScore = %d, want 25", existing.DailyScore)
+	}
+
+	if program.CurrentDayIndex != 0 || len(s.programRepo.links) != 0 {
+		t.Errorf("program = day %d with %d links, want not advanced", program.CurrentDayIndex, len(s.programRepo.links))
+	}
+}
+
+func TestImportUsecase_ImportWorkouts_WeightUnit(t *testing.T) {
+	tests := []struct {
+		name       string
+		rowUnit    repository.WeightUnit
+		inputUnit  repository.WeightUnit
+		wantWeight float64
+		wantErr    error
+	}{
+		{
+			name:       "正常系: 単位が判別できない場合は指定された単位（lb）で読み取る",
+			inputUnit:  repository.WeightUnitLb,
+			wantWeight: 102.06,
+		},
+		{
+			name:       "正常系: 単位の指定がない場合はkg",
+			wantWeight: 225,
+		},
+		{
+			name:       "正常系: ファイルに記録された単位を優先する",
+			rowUnit:    repository.WeightUnitKg,
+			inputUnit:  repository.WeightUnitLb,
+			wantWeight: 225,
+		},
+		{
+			name:      "異常系: 単位が不正",
+			inputUnit: "stone",
+			wantErr:   ErrInvalidWeightUnit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newImportTestSetup()
+			row := importRow(2, "a", time.Date(2023, 1, 15, 8, 30, 0, 0, time.UTC), "Deadlift", 5, 225)
+			row.WeightUnit = tt.rowUnit
+			s.parser.rows = []repository.ImportedSetRow{row}
+
+			_, err := s.usecase.ImportWorkouts(context.Background(), ImportWorkoutsInput{
+				UserID:     uuid.New(),
+				Format:     "test",
+				WeightUnit: tt.inputUnit,
+			})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ImportWorkouts() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ImportWorkouts() error = %v", err)
+			}
+			for _, set := range s.workoutSetRepo.sets {
+				if set.Weight != tt.wantWeight {
+					t.Errorf("Weight = %v, want %v", set.Weight, tt.wantWeight)
+				}
+			}
+		})
+	}
+}
+
+func TestImportUsecase_ImportWorkouts_SetTypeAndEffort(t *testing.T) {
+	day := time.Date(2023, 1, 15, 8, 30, 0, 0, time.UTC)
+	warmUp := entity.SetTypeWarmUp
+
+	s := newImportTestSetup()
+	s.exerciseRepo.addExercise("ベンチプレス", nil, nil)
+	warmUpRow := importRow(2, "a", day, "ベンチプレス", 10, 40)
+	warmUpRow.SetType = &warmUp
+	rpeRow := importRow(3, "a", day, "ベンチプレス", 5, 80)
+	rpeRow.RPE = float64Ptr(8.5)
+	invalidRow := importRow(4, "a", day, "ベンチプレス", 5, 80)
+	invalidRow.RPE = float64Ptr(8.25)
+	s.parser.rows = []repository.ImportedSetRow{warmUpRow, rpeRow, invalidRow}
+
+	out, err := s.usecase.ImportWorkouts(context.Background(), ImportWorkoutsInput{
+		UserID: uuid.New(),
+		Format: "test",
+	})
+	if err != nil {
+		t.Fatalf("ImportWorkouts() error = %v", err)
+	}
+
+	if len(out.Errors) != 1 || out.Errors[0].Line != 4 || out.Errors[0].Message != entity.ErrInvalidRPE.Error() {
+		t.Errorf("Errors = %v, want %q on line 4", out.Errors, entity.ErrInvalidRPE.Error())
+	}
+	if len(s.workoutSetRepo.sets) != 2 {
+		t.Fatalf("recorded sets = %d, want 2", len(s.workoutSetRepo.sets))
+	}
+	for _, set := range s.workoutSetRepo.sets {
+		switch set.SetNumber {
+		case 1:
+			if set.SetType != entity.SetTypeWarmUp {
+				t.Errorf("SetType = %v, want warmup", set.SetType)
+			}
+		case 2:
+			if set.SetType != entity.SetTypeWorking || set.RPE == nil || *set.RPE != 8.5 {
+				t.Errorf("set = %v @%v, want working @8.5", set.SetType, set.RPE)
+			}
+		}
+	}
+}
+
+func TestImportUsecase_ImportWorkouts_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		err     error
+		wantErr error
+	}{
+		{
+			name:    "異常系: 対応していないファイル形式",
+			format:  "unknown",
+			wantErr: ErrUnsupportedImportFormat,
+		},
+		{
+			name:    "異常系: ファイルを読み取れない",
+			format:  "test",
+			err:     errors.New("missing required column"),
+			wantErr: ErrInvalidImportFile,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newImportTestSetup()
+			s.parser.err = tt.err
+
+			_, err := s.usecase.ImportWorkouts(context.Background(), ImportWorkoutsInput{UserID: uuid.New(), Format: tt.format})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ImportWorkouts() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExerciseMatcher_Match(t *testing.T) {
+	bench, _ := entity.NewExercise("ベンチプレス", nil, nil)
+	incline, _ := entity.NewExercise("Incline Bench Press (Dumbbell)", nil, nil)
+	pullUp, _ := entity.NewExercise("Pull Up", nil, nil)
+	inclineBarbell, _ := entity.NewExercise("Incline Bench Press", nil, nil)
+	matcher := newExerciseMatcher([]*entity.Exercise{bench, incline, pullUp, inclineBarbell})
+
+	tests := []struct {
+		name  string
+		input string
+		want  *entity.Exercise
+	}{
+		{name: "正常系: 完全一致", input: "ベンチプレス", want: bench},
+		{name: "正常系: 英語名からシステム種目", input: "Bench Press (Barbell)", want: bench},
+		{name: "正常系: 大文字小文字・記号・複数形の違い", input: "pull-ups", want: pullUp},
+		{name: "正常系: 綴りの誤り（1文字）", input: "Incline Bench Press (Dumbell)", want: incline},
+		{name: "正常系: 2文字以上の綴りの誤りは対応付けない", input: "Incline Bench Pres (Dumbell)", want: nil},
+		{name: "正常系: 器具が異なる種目は対応付けない", input: "Incline Bench Press (Barbell)", want: nil},
+		{name: "正常系: InclineとDeclineは対応付けない", input: "Decline Bench Press", want: nil},
+		{name: "正常系: InclineとDeclineは器具付きでも対応付けない", input: "Decline Bench Press (Dumbbell)", want: nil},
+		{name: "正常系: 短い名前は曖昧一致しない", input: "Pull", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matcher.match(tt.input); got != tt.want {
+				t.Errorf("match(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// Ensure ImportUsecase implements ImportUsecaseInterface
+var _ ImportUsecaseInterface = (*ImportUsecase)(nil)
@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+	"github.com/ucchy108/whiskey/backend/domain/value"
+)
+
+var (
+	// ErrOIDCProviderNotFound は指定された外部IDプロバイダが設定されていない場合のエラー
+	ErrOIDCProviderNotFound = errors.New("OIDC provider not found")
+	// ErrInvalidOIDCState はコールバックのstateが存在しない・期限切れ・使用済み・別のプロバイダのものである場合のエラー
+	ErrInvalidOIDCState = errors.New("invalid or expired OIDC state")
+	// ErrOIDCAuthenticationFailed は認可コードの交換またはIDトークンの検証に失敗した場合のエラー
+	ErrOIDCAuthenticationFailed = errors.New("OIDC authentication failed")
+	// ErrOIDCEmailNotVerified は外部IDプロバイダでメールアドレスの所有が確認されていない場合のエラー
+	ErrOIDCEmailNotVerified = errors.New("email not verified by OIDC provider")
+)
+
+// OIDCStateTTL は外部IDプロバイダへリダイレクトしてからコールバックを受け付ける期間
+const OIDCStateTTL = 10 * time.Minute
+
+// OIDCUsecaseInterface はOIDCUsecaseのインターフェース。
+// テスト時のモック作成に使用する。
+type OIDCUsecaseInterface interface {
+	StartLogin(ctx context.Context, provider string, rememberMe bool) (*StartOIDCLoginOutput, error)
+	CompleteLogin(ctx context.Context, input CompleteOIDCLoginInput) (*LoginOutput, error)
+}
+
+// StartOIDCLoginOutput は外部IDプロバイダでのログイン開始の結果
+type StartOIDCLoginOutput struct {
+	// AuthorizationURL はユーザーをリダイレクトさせるプロバイダの認可エンドポイントのURL
+	AuthorizationURL string
+	// State はコールバックで照合するstateパラメータ。ログインCSRFを防ぐためブラウザにも保持させる
+	State string
+}
+
+// CompleteOIDCLoginInput は外部IDプロバイダからのコールバックの入力
+type CompleteOIDCLoginInput struct {
+	Provider string
+	State    string
+	Code     string
+	// Metadata はセッション一覧で端末を識別するためにセッションと共に保存するクライアント情報
+	Metadata repository.SessionMetadata
+}
+
+// OIDCUsecase は外部IDプロバイダ（OpenID Connect）によるログインのビジネスロジックを提供する。
+// 認可コードフロー（PKCE）で認証し、外部アカウントをユーザーに紐付けてセッションを作成する。
+type OIDCUsecase struct {
+	providers    map[string]repository.OIDCProvider
+	stateRepo    repository.OIDCStateRepository
+	userRepo     repository.UserRepository
+	identityRepo repository.UserIdentityRepository
+	userUsecase  UserUsecaseInterface
+	txManager    repository.TransactionManager
+}
+
+// NewOIDCUsecase はOIDCUsecaseの新しいインスタンスを生成する。
+// providersはプロバイダ名をキーとした外部IDプロバイダのマップ。
+// セッションの作成（二要素認証を含む）はuserUsecaseに委譲する。
+func NewOIDCUsecase(
+	providers map[string]repository.OIDCProvider,
+	stateRepo repository.OIDCStateRepository,
+	userRepo repository.UserRepository,
+	identityRepo repository.UserIdentityRepository,
+	userUsecase UserUsecaseInterface,
+	txManager repository.TransactionManager,
+) *OIDCUsecase {
+	return &OIDCUsecase{
+		providers:    providers,
+		stateRepo:    stateRepo,
+		userRepo:     userRepo,
+		identityRepo: identityRepo,
+		userUsecase:  userUsecase,
+		txManager:    txManager,
+	}
+}
+
+// StartLogin は外部IDプロバイダでのログインを開始し、リダイレクト先の認可URLを返す。
+// state・nonce・PKCEのcode_verifierを生成してOIDCStateTTLの間保存し、コールバック時に照合する。
+func (u *OIDCUsecase) StartLogin(ctx context.Context, providerName string, rememberMe bool) (*StartOIDCLoginOutput, error) {
+	provider, ok := u.providers[providerName]
+	if !ok {
+		return nil, ErrOIDCProviderNotFound
+	}
+
+	codeVerifier, err := value.NewPKCECodeVerifier()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate code verifier: %w", err)
+	}
+	nonce := uuid.New().String()
+
+	state, err := u.stateRepo.Create(ctx, repository.OIDCAuthState{
+		Provider:     providerName,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		RememberMe:   rememberMe,
+	}, OIDCStateTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OIDC state: %w", err)
+	}
+
+	return &StartOIDCLoginOutput{
+		AuthorizationURL: provider.AuthorizationURL(state, nonce, value.PKCECodeChallenge(codeVerifier)),
+		State:            state,
+	}, nil
+}
+
+// CompleteLogin は外部IDプロバイダからのコールバックを処理し、ログインを完了する。
+// stateは一度きりの使用で、認可コードの交換とIDトークンの検証に成功した場合のみ次のようにユーザーを決定する。
+//
+//   - 外部アカウントが紐付け済みの場合は、そのユーザーでログインする
+//   - 未紐付けで同じメールアドレスのユーザーが存在する場合は、そのユーザーに紐付ける
+//   - いずれでもない場合は、メール検証済みのユーザーを新規作成して紐付ける
+//
+// 紐付け・新規作成は、プロバイダがメールアドレスの所有を確認している場合（email_verified）のみ行う。
+// 二要素認証が有効なユーザーの場合はLoginと同様にセッションの代わりにチャレンジを返す。
+func (u *OIDCUsecase) CompleteLogin(ctx context.Context, input CompleteOIDCLoginInput) (*LoginOutput, error) {
+	provider, ok := u.providers[input.Provider]
+	if !ok {
+		return nil, ErrOIDCProviderNotFound
+	}
+
+	if input.State == "" {
+		return nil, ErrInvalidOIDCState
+	}
+
+	authState, err := u.stateRepo.Consume(ctx, input.State)
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume OIDC state: %w", err)
+	}
+	if authState == nil || authState.Provider != input.Provider {
+		return nil, ErrInvalidOIDCState
+	}
+
+	if input.Code == "" {
+		return nil, ErrOIDCAuthenticationFailed
+	}
+
+	identity, err := provider.Exchange(ctx, input.Code, authState.CodeVerifier, authState.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCAuthenticationFailed, err)
+	}
+
+	var user *entity.User
+	err = u.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = u.resolveUser(ctx, input.Provider, identity)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return u.userUsecase.StartSession(ctx, user, authState.RememberMe, input.Metadata)
+}
+
+// resolveUser は外部アカウントに対応するユーザーを返す。
+// 未紐付けの場合は既存ユーザーへの紐付け、またはユーザーの新規作成を行う。
+func (u *OIDCUsecase) resolveUser(ctx context.Context, providerName string, identity *repository.OIDCIdentity) (*entity.User, error) {
+	linked, err := u.identityRepo.FindByProviderSubject(ctx, providerName, identity.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user identity: %w", err)
+	}
+	if linked != nil {
+		user, err := u.userRepo.FindByID(ctx, linked.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find user: %w", err)
+		}
+		if user == nil {
+			return nil, ErrUserNotFound
+		}
+		return user, nil
+	}
+
+	if !identity.EmailVerified {
+		return nil, ErrOIDCEmailNotVerified
+	}
+	email, err := value.NewEmail(identity.Email)
+	if err != nil {
+		return nil, ErrOIDCEmailNotVerified
+	}
+
+	user, err := u.userRepo.FindByEmail(ctx, email.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if user != nil {
+		// 未検証のまま残っているアカウントは第三者が事前に登録したものである可能性があるため、
+		// プロバイダで所有が確認されたことをもって検証済みにし、設定済みのパスワードを無効化する
+		if !user.EmailVerified {
+			user.ClaimByVerifiedIdentity()
+			if err := u.userRepo.Update(ctx, user); err != nil {
+				return nil, fmt.Errorf("failed to update user: %w", err)
+			}
+		}
+	} else {
+		user, err = entity.NewOIDCUser(email.String())
+		if err != nil {
+			return nil, err
+		}
+		if err := u.userRepo.Create(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to create user: %w", err)
+		}
+	}
+
+	link, err := entity.NewUserIdentity(user.ID, providerName, identity.Subject, email.String())
+	if err != nil {
+		return nil, err
+	}
+	if err := u.identityRepo.Create(ctx, link); err != nil {
+		return nil, fmt.Errorf("failed to create user identity: %w", err)
+	}
+
+	return user, nil
+}
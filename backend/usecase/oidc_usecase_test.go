@@ -0,0 +1,395 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+	"github.com/ucchy108/whiskey/backend/domain/service"
+	"github.com/ucchy108/whiskey/backend/domain/value"
+)
+
+const testOIDCProvider = "google"
+
+// mockOIDCProvider はOIDCProviderのモック
+type mockOIDCProvider struct {
+	identity *repository.OIDCIdentity
+	err      error
+	// 最後にExchangeに渡された値
+	lastCode         string
+	lastCodeVerifier string
+	lastNonce        string
+}
+
+func (m *mockOIDCProvider) AuthorizationURL(state, nonce, codeChallenge string) string {
+	query := url.Values{}
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	return "https://idp.example.com/authorize?" + query.Encode()
+}
+
+func (m *mockOIDCProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*repository.OIDCIdentity, error) {
+	m.lastCode = code
+	m.lastCodeVerifier = codeVerifier
+	m.lastNonce = nonce
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.identity, nil
+}
+
+// Ensure mockOIDCProvider implements repository.OIDCProvider
+var _ repository.OIDCProvider = (*mockOIDCProvider)(nil)
+
+// mockOIDCStateRepository はOIDCStateRepositoryのモック
+type mockOIDCStateRepository struct {
+	states map[string]repository.OIDCAuthState
+}
+
+func newMockOIDCStateRepository() *mockOIDCStateRepository {
+	return &mockOIDCStateRepository{
+		states: make(map[string]repository.OIDCAuthState),
+	}
+}
+
+func (m *mockOIDCStateRepository) Create(ctx context.Context, authState repository.OIDCAuthState, ttl time.Duration) (string, error) {
+	state := uuid.New().String()
+	m.states[state] = authState
+	return state, nil
+}
+
+func (m *mockOIDCStateRepository) Consume(ctx context.Context, state string) (*repository.OIDCAuthState, error) {
+	authState, ok := m.states[state]
+	if !ok {
+		return nil, nil
+	}
+	delete(m.states, state)
+	return &authState, nil
+}
+
+// Ensure mockOIDCStateRepository implements repository.OIDCStateRepository
+var _ repository.OIDCStateRepository = (*mockOIDCStateRepository)(nil)
+
+// mockUserIdentityRepository はUserIdentityRepositoryのモック
+type mockUserIdentityRepository struct {
+	identities []*entity.UserIdentity
+}
+
+func (m *mockUserIdentityRepository) Create(ctx context.Context, identity *entity.UserIdentity) error {
+	m.identities = append(m.identities, identity)
+	return nil
+}
+
+func (m *mockUserIdentityRepository) FindByProviderSubject(ctx context.Context, provider, subject string) (*entity.UserIdentity, error) {
+	for _, identity := range m.identities {
+		if identity.Provider == provider && identity.Subject == subject {
+			return identity, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockUserIdentityRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.UserIdentity, error) {
+	var result []*entity.UserIdentity
+	for _, identity := range m.identities {
+		if identity.UserID == userID {
+			result = append(result, identity)
+		}
+	}
+	return result, nil
+}
+
+// Ensure mockUserIdentityRepository implements repository.UserIdentityRepository
+var _ repository.UserIdentityRepository = (*mockUserIdentityRepository)(nil)
+
+// passthroughTransactionManager はfnをそのまま実行するTransactionManagerのモック
+type passthroughTransactionManager struct{}
+
+func (passthroughTransactionManager) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// テスト用のセットアップヘルパー。
+// セッションの作成（二要素認証を含む）を検証するため、実際のUserUsecaseに委譲する。
+type oidcTestSetup struct {
+	userRepo      *mockUserRepository
+	sessionRepo   *mockSessionRepository
+	challengeRepo *mockChallengeRepository
+	stateRepo     *mockOIDCStateRepository
+	identityRepo  *mockUserIdentityRepository
+	provider      *mockOIDCProvider
+	usecase       *OIDCUsecase
+}
+
+func newOIDCTestSetup() *oidcTestSetup {
+	userRepo := newMockUserRepository()
+	sessionRepo := newMockSessionRepository()
+	challengeRepo := newMockChallengeRepository()
+	userUsecase := NewUserUsecase(userRepo, service.NewUserService(userRepo), sessionRepo, challengeRepo, newMockEmailSender(), newFakeRateLimiter(), testSessionPolicy)
+
+	s := &oidcTestSetup{
+		userRepo:      userRepo,
+		sessionRepo:   sessionRepo,
+		challengeRepo: challengeRepo,
+		stateRepo:     newMockOIDCStateRepository(),
+		identityRepo:  &mockUserIdentityRepository{},
+		provider: &mockOIDCProvider{
+			identity: &repository.OIDCIdentity{Subject: "subject-123", Email: "oidc@example.com", EmailVerified: true},
+		},
+	}
+	providers := map[string]repository.OIDCProvider{testOIDCProvider: s.provider}
+	s.usecase = NewOIDCUsecase(providers, s.stateRepo, userRepo, s.identityRepo, userUsecase, passthroughTransactionManager{})
+	return s
+}
+
+// startLogin はログインを開始し、コールバックで使用するstateを返す
+func (s *oidcTestSetup) startLogin(t *testing.T, rememberMe bool) string {
+	t.Helper()
+
+	out, err := s.usecase.StartLogin(context.Background(), testOIDCProvider, rememberMe)
+	if err != nil {
+		t.Fatalf("StartLogin() unexpected error = %v", err)
+	}
+	return out.State
+}
+
+func TestOIDCUsecase_StartLogin(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		wantErr  error
+	}{
+		{
+			name:     "正常系: 認可URLとstateを返す",
+			provider: testOIDCProvider,
+			wantErr:  nil,
+		},
+		{
+			name:     "異常系: 設定されていないプロバイダ",
+			provider: "unknown",
+			wantErr:  ErrOIDCProviderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newOIDCTestSetup()
+
+			out, err := s.usecase.StartLogin(context.Background(), tt.provider, true)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("StartLogin() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			authState, ok := s.stateRepo.states[out.State]
+			if !ok {
+				t.Fatalf("state %q was not stored", out.State)
+			}
+			if authState.Provider != tt.provider || !authState.RememberMe {
+				t.Errorf("stored state = %+v, want provider %q with remember me", authState, tt.provider)
+			}
+
+			authURL, err := url.Parse(out.AuthorizationURL)
+			if err != nil {
+				t.Fatalf("AuthorizationURL is not a valid URL: %v", err)
+			}
+			query := authURL.Query()
+			if query.Get("state") != out.State {
+				t.Errorf("state = %v, want %v", query.Get("state"), out.State)
+			}
+			if query.Get("nonce") != authState.Nonce {
+				t.Errorf("nonce = %v, want %v", query.Get("nonce"), authState.Nonce)
+			}
+			// code_verifierそのものは送らず、S256のcode_challengeのみを送る
+			if query.Get("code_challenge") != value.PKCECodeChallenge(authState.CodeVerifier) {
+				t.Errorf("code_challenge = %v, want S256 of the stored code verifier", query.Get("code_challenge"))
+			}
+		})
+	}
+}
+
+func TestOIDCUsecase_CompleteLogin(t *testing.T) {
+	tests := []struct {
+		name string
+		// setupは入力を返し、checkは成功時の結果を検証する
+		setup   func(t *testing.T, s *oidcTestSetup) CompleteOIDCLoginInput
+		check   func(t *testing.T, s *oidcTestSetup, out *LoginOutput)
+		wantErr error
+	}{
+		{
+			name: "正常系: 未登録のメールアドレスの場合は検証済みのユーザーを作成する",
+			setup: func(t *testing.T, s *oidcTestSetup) CompleteOIDCLoginInput {
+				return CompleteOIDCLoginInput{Provider: testOIDCProvider, State: s.startLogin(t, false), Code: "code"}
+			},
+			check: func(t *testing.T, s *oidcTestSetup, out *LoginOutput) {
+				user := s.userRepo.users["oidc@example.com"]
+				if user == nil {
+					t.Fatal("user was not created")
+				}
+				if !user.EmailVerified || user.HasPassword() {
+					t.Errorf("created user EmailVerified = %v, HasPassword = %v, want verified without password", user.EmailVerified, user.HasPassword())
+				}
+				if out.User.ID != user.ID || out.SessionID == "" {
+					t.Errorf("Login output = %+v, want session for the created user", out)
+				}
+				if out.SessionTTL != testSessionPolicy.TTL {
+					t.Errorf("SessionTTL = %v, want %v", out.SessionTTL, testSessionPolicy.TTL)
+				}
+			},
+		},
+		{
+			name: "正常系: 同じメールアドレスの検証済みユーザーに紐付ける",
+			setup: func(t *testing.T, s *oidcTestSetup) CompleteOIDCLoginInput {
+				s.userRepo.addUser("oidc@example.com", "password123")
+				return CompleteOIDCLoginInput{Provider: testOIDCProvider, State: s.startLogin(t, true), Code: "code"}
+			},
+			check: func(t *testing.T, s *oidcTestSetup, out *LoginOutput) {
+				user := s.userRepo.users["oidc@example.com"]
+				if out.User.ID != user.ID {
+					t.Errorf("logged in as %v, want existing user %v", out.User.ID, user.ID)
+				}
+				// 検証済みのユーザーのパスワードはそのまま使える
+				if err := user.VerifyPassword("password123"); err != nil {
+					t.Errorf("VerifyPassword() error = %v, want password kept", err)
+				}
+				if out.SessionTTL != testSessionPolicy.RememberMeTTL {
+					t.Errorf("SessionTTL = %v, want %v", out.SessionTTL, testSessionPolicy.RememberMeTTL)
+				}
+			},
+		},
+		{
+			name: "正常系: 未検証のユーザーは検証済みにしてパスワードを無効化する",
+			setup: func(t *testing.T, s *oidcTestSetup) CompleteOIDCLoginInput {
+				user, _ := entity.NewUser("oidc@example.com", "attacker-password")
+				s.userRepo.users["oidc@example.com"] = user
+				return CompleteOIDCLoginInput{Provider: testOIDCProvider, State: s.startLogin(t, false), Code: "code"}
+			},
+			check: func(t *testing.T, s *oidcTestSetup, out *LoginOutput) {
+				user := s.userRepo.users["oidc@example.com"]
+				if !user.EmailVerified {
+					t.Error("EmailVerified should be true after OIDC login")
+				}
+				if user.VerifyPassword("attacker-password") == nil {
+					t.Error("password set before verification should be invalidated")
+				}
+			},
+		},
+		{
+			name: "正常系: 紐付け済みの外部アカウントはメールアドレスが変わっても同じユーザーでログインする",
+			setup: func(t *testing.T, s *oidcTestSetup) CompleteOIDCLoginInput {
+				user := s.userRepo.addUser("original@example.com", "password123")
+				link, _ := entity.NewUserIdentity(user.ID, testOIDCProvider, "subject-123", "original@example.com")
+				s.identityRepo.identities = append(s.identityRepo.identities, link)
+				return CompleteOIDCLoginInput{Provider: testOIDCProvider, State: s.startLogin(t, false), Code: "code"}
+			},
+			check: func(t *testing.T, s *oidcTestSetup, out *LoginOutput) {
+				if out.User.Email.String() != "original@example.com" {
+					t.Errorf("logged in as %v, want linked user", out.User.Email.String())
+				}
+				if _, exists := s.userRepo.users["oidc@example.com"]; exists {
+					t.Error("no user should be created for a linked identity")
+				}
+				if len(s.identityRepo.identities) != 1 {
+					t.Errorf("len(identities) = %d, want 1", len(s.identityRepo.identities))
+				}
+			},
+		},
+		{
+			name: "正常系: 二要素認証が有効なユーザーにはチャレンジを返す",
+			setup: func(t *testing.T, s *oidcTestSetup) CompleteOIDCLoginInput {
+				user := s.userRepo.addUser("oidc@example.com", "password123")
+				user.TwoFactorEnabled = true
+				return CompleteOIDCLoginInput{Provider: testOIDCProvider, State: s.startLogin(t, true), Code: "code"}
+			},
+			check: func(t *testing.T, s *oidcTestSetup, out *LoginOutput) {
+				if !out.TwoFactorRequired || out.SessionID != "" {
+					t.Errorf("Login output = %+v, want a pending two-factor challenge", out)
+				}
+				challenge := s.challengeRepo.challenges[out.ChallengeToken]
+				if challenge == nil || !challenge.RememberMe {
+					t.Errorf("challenge = %+v, want challenge carrying remember me", challenge)
+				}
+				if len(s.sessionRepo.sessions) != 0 {
+					t.Error("no session should be created before two-factor verification")
+				}
+			},
+		},
+		{
+			name: "異常系: 存在しないstate",
+			setup: func(t *testing.T, s *oidcTestSetup) CompleteOIDCLoginInput {
+				return CompleteOIDCLoginInput{Provider: testOIDCProvider, State: uuid.New().String(), Code: "code"}
+			},
+			wantErr: ErrInvalidOIDCState,
+		},
+		{
+			name: "異常系: 別のプロバイダで発行されたstate",
+			setup: func(t *testing.T, s *oidcTestSetup) CompleteOIDCLoginInput {
+				state := s.startLogin(t, false)
+				s.usecase.providers["other"] = s.provider
+				return CompleteOIDCLoginInput{Provider: "other", State: state, Code: "code"}
+			},
+			wantErr: ErrInvalidOIDCState,
+		},
+		{
+			name: "異常系: 設定されていないプロバイダ",
+			setup: func(t *testing.T, s *oidcTestSetup) CompleteOIDCLoginInput {
+				return CompleteOIDCLoginInput{Provider: "unknown", State: s.startLogin(t, false), Code: "code"}
+			},
+			wantErr: ErrOIDCProviderNotFound,
+		},
+		{
+			name: "異常系: 認可コードの交換に失敗",
+			setup: func(t *testing.T, s *oidcTestSetup) CompleteOIDCLoginInput {
+				s.provider.err = errors.New("invalid ID token")
+				return CompleteOIDCLoginInput{Provider: testOIDCProvider, State: s.startLogin(t, false), Code: "code"}
+			},
+			wantErr: ErrOIDCAuthenticationFailed,
+		},
+		{
+			name: "異常系: プロバイダでメールアドレスが確認されていない",
+			setup: func(t *testing.T, s *oidcTestSetup) CompleteOIDCLoginInput {
+				s.userRepo.addUser("oidc@example.com", "password123")
+				s.provider.identity.EmailVerified = false
+				return CompleteOIDCLoginInput{Provider: testOIDCProvider, State: s.startLogin(t, false), Code: "code"}
+			},
+			wantErr: ErrOIDCEmailNotVerified,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newOIDCTestSetup()
+			input := tt.setup(t, s)
+
+			out, err := s.usecase.CompleteLogin(context.Background(), input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CompleteLogin() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				if len(s.identityRepo.identities) != 0 {
+					t.Error("no identity should be linked on failure")
+				}
+				return
+			}
+
+			if s.provider.lastCode != input.Code || s.provider.lastCodeVerifier == "" || s.provider.lastNonce == "" {
+				t.Errorf("Exchange() called with code = %q, verifier = %q, nonce = %q", s.provider.lastCode, s.provider.lastCodeVerifier, s.provider.lastNonce)
+			}
+			tt.check(t, s, out)
+
+			// stateは一度しか使用できない
+			if _, err := s.usecase.CompleteLogin(context.Background(), input); !errors.Is(err, ErrInvalidOIDCState) {
+				t.Errorf("CompleteLogin() with reused state error = %v, want %v", err, ErrInvalidOIDCState)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+// PersonalRecordUsecaseInterface はPersonalRecordUsecaseのインターフェース。
+// テスト時のモック作成に使用する。
+type PersonalRecordUsecaseInterface interface {
+	GetPersonalRecords(ctx context.Context, userID uuid.UUID) ([]*entity.PersonalRecord, error)
+	GetExercisePersonalRecords(ctx context.Context, userID, exerciseID uuid.UUID) ([]*entity.PersonalRecord, error)
+}
+
+// PersonalRecordUsecase は自己ベスト（PR）履歴に関するビジネスロジックを提供する。
+// 自己ベストの判定・保存はワークアウト記録時にWorkoutUsecaseが行い、本ユースケースは履歴の取得を担当する。
+type PersonalRecordUsecase struct {
+	personalRecordRepo repository.PersonalRecordRepository
+	exerciseRepo       repository.ExerciseRepository
+}
+
+// NewPersonalRecordUsecase はPersonalRecordUsecaseの新しいインスタンスを生成する。
+//
+// パラメータ:
+//   - personalRecordRepo: 自己ベスト更新イベントの永続化を担当するリポジトリ
+//   - exerciseRepo: エクササイズの存在確認に使用するリポジトリ
+//
+// 戻り値:
+//   - *PersonalRecordUsecase: 生成されたPersonalRecordUsecaseインスタンス
+func NewPersonalRecordUsecase(personalRecordRepo repository.PersonalRecordRepository, exerciseRepo repository.ExerciseRepository) *PersonalRecordUsecase {
+	return &PersonalRecordUsecase{
+		personalRecordRepo: personalRecordRepo,
+		exerciseRepo:       exerciseRepo,
+	}
+}
+
+// GetPersonalRecords はユーザーの全種目の自己ベスト履歴を新しい順に取得する。
+//
+// パラメータ:
+//   - ctx: リクエストのコンテキスト
+//   - userID: ユーザーID
+//
+// 戻り値:
+//   - []*entity.PersonalRecord: 自己ベスト更新イベントのリスト
+//   - error: リポジトリエラー
+func (u *PersonalRecordUsecase) GetPersonalRecords(ctx context.Context, userID uuid.UUID) ([]*entity.PersonalRecord, error) {
+	return u.personalRecordRepo.FindByUserID(ctx, userID)
+}
+
+// GetExercisePersonalRecords は指定された種目の自己ベスト履歴を新しい順に取得する。
+// 他ユーザーのカスタム種目は存在しないものとして扱う。
+//
+// パラメータ:
+//   - ctx: リクエストのコンテキスト
+//   - userID: ユーザーID
+//   - exerciseID: エクササイズID
+//
+// 戻り値:
+//   - []*entity.PersonalRecord: 自己ベスト更新イベントのリスト
+//   - error: 以下のエラーが返される可能性がある
+//     - ErrExerciseNotFound: 指定されたエクササイズが存在しない
+//     - その他のリポジトリエラー
+func (u *PersonalRecordUsecase) GetExercisePersonalRecords(ctx context.Context, userID, exerciseID uuid.UUID) ([]*entity.PersonalRecord, error) {
+	exercise, err := u.exerciseRepo.FindByID(ctx, exerciseID)
+	if err != nil || exercise == nil || !exercise.IsVisibleTo(userID) {
+		return nil, ErrExerciseNotFound
+	}
+
+	return u.personalRecordRepo.FindByUserIDAndExerciseID(ctx, userID, exerciseID)
+}
@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+// mockPersonalRecordRepository はPersonalRecordRepositoryのモック実装
+type mockPersonalRecordRepository struct {
+	records []*entity.PersonalRecord
+	err     error
+}
+
+func newMockPersonalRecordRepository() *mockPersonalRecordRepository {
+	return &mockPersonalRecordRepository{}
+}
+
+func (m *mockPersonalRecordRepository) Create(ctx context.Context, record *entity.PersonalRecord) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.records = append(m.records, record)
+	return nil
+}
+
+func (m *mockPersonalRecordRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.PersonalRecord, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var result []*entity.PersonalRecord
+	for _, record := range m.records {
+		if record.UserID == userID {
+			result = append(result, record)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockPersonalRecordRepository) FindByUserIDAndExerciseID(ctx context.Context, userID, exerciseID uuid.UUID) ([]*entity.PersonalRecord, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var result []*entity.PersonalRecord
+	for _, record := range m.records {
+		if record.UserID == userID && record.ExerciseID == exerciseID {
+			result = append(result, record)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockPersonalRecordRepository) DeleteByUserIDAndExerciseID(ctx context.Context, userID, exerciseID uuid.UUID) error {
+	if m.err != nil {
+		return m.err
+	}
+	var remaining []*entity.PersonalRecord
+	for _, record := range m.records {
+		if record.UserID != userID || record.ExerciseID != exerciseID {
+			remaining = append(remaining, record)
+		}
+	}
+	m.records = remaining
+	return nil
+}
+
+// テストヘルパー: 自己ベスト更新イベントを追加
+func (m *mockPersonalRecordRepository) addRecord(userID, exerciseID uuid.UUID, recordType entity.PersonalRecordType, value float64) *entity.PersonalRecord {
+	record := entity.NewPersonalRecord(userID, exerciseID, uuid.New(), nil, recordType, nil, value, nil, time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC))
+	m.records = append(m.records, record)
+	return record
+}
+
+// Ensure mockPersonalRecordRepository implements repository.PersonalRecordRepository
+var _ repository.PersonalRecordRepository = (*mockPersonalRecordRepository)(nil)
+
+func TestPersonalRecordUsecase_GetPersonalRecords(t *testing.T) {
+	userID := uuid.New()
+	otherUserID := uuid.New()
+
+	tests := []struct {
+		name      string
+		setup     func(*mockPersonalRecordRepository)
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name: "正常系: 自身の自己ベストのみ取得",
+			setup: func(m *mockPersonalRecordRepository) {
+				m.addRecord(userID, uuid.New(), entity.PersonalRecordTypeEstimated1RM, 100.0)
+				m.addRecord(userID, uuid.New(), entity.PersonalRecordTypeSessionVolume, 1500.0)
+				m.addRecord(otherUserID, uuid.New(), entity.PersonalRecordTypeEstimated1RM, 120.0)
+			},
+			wantCount: 2,
+		},
+		{
+			name:      "正常系: 自己ベストが存在しない",
+			setup:     func(m *mockPersonalRecordRepository) {},
+			wantCount: 0,
+		},
+		{
+			name: "異常系: リポジトリエラー",
+			setup: func(m *mockPersonalRecordRepository) {
+				m.err = errors.New("db error")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recordRepo := newMockPersonalRecordRepository()
+			tt.setup(recordRepo)
+			u := NewPersonalRecordUsecase(recordRepo, newMockExerciseRepository())
+
+			records, err := u.GetPersonalRecords(context.Background(), userID)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("GetPersonalRecords() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetPersonalRecords() unexpected error = %v", err)
+			}
+			if len(records) != tt.wantCount {
+				t.Errorf("GetPersonalRecords() count = %d, want %d", len(records), tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestPersonalRecordUsecase_GetExercisePersonalRecords(t *testing.T) {
+	chestPart := entity.BodyPartChest
+	userID := uuid.New()
+	otherUserID := uuid.New()
+
+	tests := []struct {
+		name      string
+		setup     func(*mockExerciseRepository, *mockPersonalRecordRepository) uuid.UUID
+		wantCount int
+		wantErr   bool
+		checkErr  func(error) bool
+	}{
+		{
+			name: "正常系: システム種目の自己ベストを取得",
+			setup: func(e *mockExerciseRepository, r *mockPersonalRecordRepository) uuid.UUID {
+				exercise := e.addExercise("ベンチプレス", nil, &chestPart)
+				r.addRecord(userID, exercise.ID, entity.PersonalRecordTypeEstimated1RM, 100.0)
+				r.addRecord(userID, exercise.ID, entity.PersonalRecordTypeRepMax, 90.0)
+				r.addRecord(userID, uuid.New(), entity.PersonalRecordTypeEstimated1RM, 150.0)
+				r.addRecord(otherUserID, exercise.ID, entity.PersonalRecordTypeEstimated1RM, 120.0)
+				return exercise.ID
+			},
+			wantCount: 2,
+		},
+		{
+			name: "正常系: 自身のカスタム種目の自己ベストを取得",
+			setup: func(e *mockExerciseRepository, r *mockPersonalRecordRepository) uuid.UUID {
+				exercise := e.addUserExercise(userID, "マイベンチ", nil, &chestPart)
+				r.addRecord(userID, exercise.ID, entity.PersonalRecordTypeSessionVolume, 1500.0)
+				return exercise.ID
+			},
+			wantCount: 1,
+		},
+		{
+			name: "異常系: エクササイズが存在しない",
+			setup: func(e *mockExerciseRepository, r *mockPersonalRecordRepository) uuid.UUID {
+				return uuid.New()
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrExerciseNotFound)
+			},
+		},
+		{
+			name: "異常系: 他ユーザーのカスタム種目は取得できない",
+			setup: func(e *mockExerciseRepository, r *mockPersonalRecordRepository) uuid.UUID {
+				exercise := e.addUserExercise(otherUserID, "他人の種目", nil, nil)
+				return exercise.ID
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrExerciseNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exerciseRepo := newMockExerciseRepository()
+			recordRepo := newMockPersonalRecordRepository()
+			exerciseID := tt.setup(exerciseRepo, recordRepo)
+			u := NewPersonalRecordUsecase(recordRepo, exerciseRepo)
+
+			records, err := u.GetExercisePersonalRecords(context.Background(), userID, exerciseID)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("GetExercisePersonalRecords() error = nil, want error")
+					return
+				}
+				if tt.checkErr != nil && !tt.checkErr(err) {
+					t.Errorf("GetExercisePersonalRecords() error = %v, want specific error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetExercisePersonalRecords() unexpected error = %v", err)
+			}
+			if len(records) != tt.wantCount {
+				t.Errorf("GetExercisePersonalRecords() count = %d, want %d", len(records), tt.wantCount)
+			}
+		})
+	}
+}
@@ -26,12 +26,55 @@ var allowedImageTypes = map[string]bool{
 	"image/png":  true,
 }
 
+// CreateProfileInput はプロフィール作成の入力データを表す。
+// nilのフィールドはデフォルト値とする。
+type CreateProfileInput struct {
+	UserID uuid.UUID
+	// DisplayName は表示名（1〜100文字）
+	DisplayName string
+	// Age は年齢（0〜150）
+	Age *int32
+	// Weight は体重kg（0より大きい値）
+	Weight *float64
+	// Height は身長cm（1〜300）
+	Height *float64
+	// OneRMFormula は推定1RMの計算式（省略時はEpley式）
+	OneRMFormula *entity.OneRMFormula
+	// Timezone はIANAタイムゾーン名（省略時はUTC）
+	Timezone *string
+	// WeeklyWorkoutGoal は週あたりの目標トレーニング日数（省略時は3、1〜7）
+	WeeklyWorkoutGoal *int32
+	// RestDayTolerance は日単位の連続記録を途切れさせずに休める連続日数（省略時は1、0〜6）
+	RestDayTolerance *int32
+	// DailyScoreStrategy はデイリースコアの計算方法（省略時は絶対値）
+	DailyScoreStrategy *entity.DailyScoreStrategyType
+}
+
+// UpdateProfileInput はプロフィール更新の入力データを表す。
+// nilのフィールドは更新しない。
+type UpdateProfileInput struct {
+	UserID      uuid.UUID
+	DisplayName *string
+	Age         *int32
+	// Weight を変更した場合、体重を使用するデイリースコアの計算方法では保存済みのスコアを非同期で再計算する
+	Weight *float64
+	Height *float64
+	// OneRMFormula を変更した場合は保存済みの全セットの推定1RMと自己ベストを同じトランザクションで再計算する
+	OneRMFormula      *entity.OneRMFormula
+	Timezone          *string
+	WeeklyWorkoutGoal *int32
+	RestDayTolerance  *int32
+	// DailyScoreStrategy を変更した場合は保存済みのスコアを非同期で再計算する
+	DailyScoreStrategy *entity.DailyScoreStrategyType
+}
+
 // ProfileUsecaseInterface はProfileUsecaseのインターフェース。
 // テスト時のモック作成に使用する。
 type ProfileUsecaseInterface interface {
-	CreateProfile(ctx context.Context, userID uuid.UUID, displayName string, age *int32, weight *float64, height *float64) (*entity.Profile, error)
+	CreateProfile(ctx context.Context, input CreateProfileInput) (*entity.Profile, error)
 	GetProfile(ctx context.Context, userID uuid.UUID) (*entity.Profile, error)
-	UpdateProfile(ctx context.Context, userID uuid.UUID, displayName *string, age *int32, weight *float64, height *float64) (*entity.Profile, error)
+	GetLocation(ctx context.Context, userID uuid.UUID) (*time.Location, error)
+	UpdateProfile(ctx context.Context, input UpdateProfileInput) (*entity.Profile, error)
 	GetAvatarUploadURL(ctx context.Context, userID uuid.UUID, contentType string) (string, string, error)
 	GetAvatarURL(ctx context.Context, userID uuid.UUID) (string, error)
 	DeleteAvatar(ctx context.Context, userID uuid.UUID) error
@@ -39,15 +82,23 @@ type ProfileUsecaseInterface interface {
 
 // ProfileUsecase はプロフィールに関するビジネスロジックを提供する。
 type ProfileUsecase struct {
-	profileRepo    repository.ProfileRepository
-	objectStorage  repository.ObjectStorageRepository
+	profileRepo        repository.ProfileRepository
+	workoutRepo        repository.WorkoutRepository
+	workoutSetRepo     repository.WorkoutSetRepository
+	personalRecordRepo repository.PersonalRecordRepository
+	objectStorage      repository.ObjectStorageRepository
+	txManager          repository.TransactionManager
 }
 
 // NewProfileUsecase はProfileUsecaseの新しいインスタンスを生成する。
-func NewProfileUsecase(profileRepo repository.ProfileRepository, objectStorage repository.ObjectStorageRepository) *ProfileUsecase {
+func NewProfileUsecase(profileRepo repository.ProfileRepository, workoutRepo repository.WorkoutRepository, workoutSetRepo repository.WorkoutSetRepository, personalRecordRepo repository.PersonalRecordRepository, objectStorage repository.ObjectStorageRepository, txManager repository.TransactionManager) *ProfileUsecase {
 	return &ProfileUsecase{
-		profileRepo:   profileRepo,
-		objectStorage: objectStorage,
+		profileRepo:        profileRepo,
+		workoutRepo:        workoutRepo,
+		workoutSetRepo:     workoutSetRepo,
+		personalRecordRepo: personalRecordRepo,
+		objectStorage:      objectStorage,
+		txManager:          txManager,
 	}
 }
 
@@ -56,24 +107,25 @@ func NewProfileUsecase(profileRepo repository.ProfileRepository, objectStorage r
 //
 // パラメータ:
 //   - ctx: リクエストのコンテキスト
-//   - userID: プロフィールを作成するユーザーのID
-//   - displayName: 表示名（1〜100文字）
-//   - age: 年齢（省略可、0〜150）
-//   - weight: 体重kg（省略可、0より大きい値）
-//   - height: 身長cm（省略可、1〜300）
+//   - input: 作成するプロフィールの入力データ
 //
 // 戻り値:
 //   - *entity.Profile: 作成されたプロフィールエンティティ
 //   - error: 以下のエラーが返される可能性がある
-//     - ErrProfileAlreadyExists: 既にプロフィールが存在
-//     - entity.ErrInvalidDisplayName: 表示名が不正
-//     - entity.ErrInvalidAge: 年齢が不正
-//     - entity.ErrInvalidWeight: 体重が不正
-//     - entity.ErrInvalidHeight: 身長が不正
-//     - その他のリポジトリエラー
-func (u *ProfileUsecase) CreateProfile(ctx context.Context, userID uuid.UUID, displayName string, age *int32, weight *float64, height *float64) (*entity.Profile, error) {
+//   - ErrProfileAlreadyExists: 既にプロフィールが存在
+//   - entity.ErrInvalidDisplayName: 表示名が不正
+//   - entity.ErrInvalidAge: 年齢が不正
+//   - entity.ErrInvalidWeight: 体重が不正
+//   - entity.ErrInvalidHeight: 身長が不正
+//   - entity.ErrInvalidOneRMFormula: 1RM計算式が不正
+//   - entity.ErrInvalidTimezone: タイムゾーンが不正
+//   - entity.ErrInvalidWeeklyWorkoutGoal: 週あたりの目標トレーニング日数が不正
+//   - entity.ErrInvalidRestDayTolerance: 休息日数が不正
+//   - entity.ErrInvalidDailyScoreStrategy: デイリースコアの計算方法が不正
+//   - その他のリポジトリエラー
+func (u *ProfileUsecase) CreateProfile(ctx context.Context, input CreateProfileInput) (*entity.Profile, error) {
 	// プロフィールの重複チェック
-	exists, err := u.profileRepo.ExistsByUserID(ctx, userID)
+	exists, err := u.profileRepo.ExistsByUserID(ctx, input.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -82,26 +134,56 @@ func (u *ProfileUsecase) CreateProfile(ctx context.Context, userID uuid.UUID, di
 	}
 
 	// エンティティ作成（バリデーション含む）
-	profile, err := entity.NewProfile(userID, displayName)
+	profile, err := entity.NewProfile(input.UserID, input.DisplayName)
 	if err != nil {
 		return nil, err
 	}
 
 	// オプションフィールドの設定
-	if age != nil {
-		if err := profile.UpdateAge(age); err != nil {
+	if input.Age != nil {
+		if err := profile.UpdateAge(input.Age); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.Weight != nil {
+		if err := profile.UpdateWeight(input.Weight); err != nil {
 			return nil, err
 		}
 	}
 
-	if weight != nil {
-		if err := profile.UpdateWeight(weight); err != nil {
+	if input.Height != nil {
+		if err := profile.UpdateHeight(input.Height); err != nil {
 			return nil, err
 		}
 	}
 
-	if height != nil {
-		if err := profile.UpdateHeight(height); err != nil {
+	if input.OneRMFormula != nil {
+		if err := profile.UpdateOneRMFormula(*input.OneRMFormula); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.Timezone != nil {
+		if err := profile.UpdateTimezone(*input.Timezone); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.WeeklyWorkoutGoal != nil {
+		if err := profile.UpdateWeeklyWorkoutGoal(*input.WeeklyWorkoutGoal); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.RestDayTolerance != nil {
+		if err := profile.UpdateRestDayTolerance(*input.RestDayTolerance); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.DailyScoreStrategy != nil {
+		if err := profile.UpdateDailyScoreStrategy(*input.DailyScoreStrategy); err != nil {
 			return nil, err
 		}
 	}
@@ -123,80 +205,197 @@ func (u *ProfileUsecase) CreateProfile(ctx context.Context, userID uuid.UUID, di
 // 戻り値:
 //   - *entity.Profile: 取得したプロフィールエンティティ
 //   - error: 以下のエラーが返される可能性がある
-//     - ErrProfileNotFound: 指定されたユーザーIDのプロフィールが存在しない
-//     - その他のリポジトリエラー
+//   - ErrProfileNotFound: 指定されたユーザーIDのプロフィールが存在しない
+//   - その他のリポジトリエラー
 func (u *ProfileUsecase) GetProfile(ctx context.Context, userID uuid.UUID) (*entity.Profile, error) {
 	profile, err := u.profileRepo.FindByUserID(ctx, userID)
 	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
 		return nil, ErrProfileNotFound
 	}
 
 	return profile, nil
 }
 
+// GetLocation はユーザーのタイムゾーンを取得する。
+// 日付文字列の解釈に使用し、プロフィールが存在しない場合はUTCを返す。
+// プロフィールを取得できない場合は、誤った日付で解釈しないようエラーを返す。
+//
+// パラメータ:
+//   - ctx: リクエストのコンテキスト
+//   - userID: ユーザーID
+//
+// 戻り値:
+//   - *time.Location: ユーザーのタイムゾーン
+//   - error: リポジトリエラー
+func (u *ProfileUsecase) GetLocation(ctx context.Context, userID uuid.UUID) (*time.Location, error) {
+	profile, err := u.profileRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
+		return time.UTC, nil
+	}
+	return profile.Location(), nil
+}
+
 // UpdateProfile は既存のプロフィールを更新する。
 // nilのフィールドは更新しない。
 //
 // パラメータ:
 //   - ctx: リクエストのコンテキスト
-//   - userID: 更新するプロフィールのユーザーID
-//   - displayName: 新しい表示名（nilの場合は変更なし）
-//   - age: 新しい年齢（nilの場合は変更なし）
-//   - weight: 新しい体重（nilの場合は変更なし）
-//   - height: 新しい身長（nilの場合は変更なし）
+//   - input: 更新内容の入力データ
 //
 // 戻り値:
 //   - *entity.Profile: 更新されたプロフィールエンティティ
 //   - error: 以下のエラーが返される可能性がある
-//     - ErrProfileNotFound: 指定されたユーザーIDのプロフィールが存在しない
-//     - entity.ErrInvalidDisplayName: 表示名が不正
-//     - entity.ErrInvalidAge: 年齢が不正
-//     - entity.ErrInvalidWeight: 体重が不正
-//     - entity.ErrInvalidHeight: 身長が不正
-//     - その他のリポジトリエラー
-func (u *ProfileUsecase) UpdateProfile(ctx context.Context, userID uuid.UUID, displayName *string, age *int32, weight *float64, height *float64) (*entity.Profile, error) {
+//   - ErrProfileNotFound: 指定されたユーザーIDのプロフィールが存在しない
+//   - entity.ErrInvalidDisplayName: 表示名が不正
+//   - entity.ErrInvalidAge: 年齢が不正
+//   - entity.ErrInvalidWeight: 体重が不正
+//   - entity.ErrInvalidHeight: 身長が不正
+//   - entity.ErrInvalidOneRMFormula: 1RM計算式が不正
+//   - entity.ErrInvalidTimezone: タイムゾーンが不正
+//   - entity.ErrInvalidWeeklyWorkoutGoal: 週あたりの目標トレーニング日数が不正
+//   - entity.ErrInvalidRestDayTolerance: 休息日数が不正
+//   - entity.ErrInvalidDailyScoreStrategy: デイリースコアの計算方法が不正
+//   - その他のリポジトリエラー
+func (u *ProfileUsecase) UpdateProfile(ctx context.Context, input UpdateProfileInput) (*entity.Profile, error) {
 	// プロフィール取得
-	profile, err := u.profileRepo.FindByUserID(ctx, userID)
+	profile, err := u.profileRepo.FindByUserID(ctx, input.UserID)
 	if err != nil {
+		return nil, err
+	}
+	if profile == nil {
 		return nil, ErrProfileNotFound
 	}
+	previousFormula := profile.OneRMFormula
 
 	// 表示名の更新
-	if displayName != nil {
-		if err := profile.UpdateDisplayName(*displayName); err != nil {
+	if input.DisplayName != nil {
+		if err := profile.UpdateDisplayName(*input.DisplayName); err != nil {
 			return nil, err
 		}
 	}
 
 	// 年齢の更新
-	if age != nil {
-		if err := profile.UpdateAge(age); err != nil {
+	if input.Age != nil {
+		if err := profile.UpdateAge(input.Age); err != nil {
 			return nil, err
 		}
 	}
 
 	// 体重の更新
-	if weight != nil {
-		if err := profile.UpdateWeight(weight); err != nil {
+	if input.Weight != nil {
+		if err := profile.UpdateWeight(input.Weight); err != nil {
 			return nil, err
 		}
 	}
 
 	// 身長の更新
-	if height != nil {
-		if err := profile.UpdateHeight(height); err != nil {
+	if input.Height != nil {
+		if err := profile.UpdateHeight(input.Height); err != nil {
 			return nil, err
 		}
 	}
 
-	// 永続化
-	if err := u.profileRepo.Update(ctx, profile); err != nil {
+	// 1RM計算式の更新
+	if input.OneRMFormula != nil {
+		if err := profile.UpdateOneRMFormula(*input.OneRMFormula); err != nil {
+			return nil, err
+		}
+	}
+
+	// タイムゾーンの更新
+	if input.Timezone != nil {
+		if err := profile.UpdateTimezone(*input.Timezone); err != nil {
+			return nil, err
+		}
+	}
+
+	// 週あたりの目標トレーニング日数の更新
+	if input.WeeklyWorkoutGoal != nil {
+		if err := profile.UpdateWeeklyWorkoutGoal(*input.WeeklyWorkoutGoal); err != nil {
+			return nil, err
+		}
+	}
+
+	// 連続記録を途切れさせずに休める連続日数の更新
+	if input.RestDayTolerance != nil {
+		if err := profile.UpdateRestDayTolerance(*input.RestDayTolerance); err != nil {
+			return nil, err
+		}
+	}
+
+	// デイリースコアの計算方法の更新（変更時は保存済みのスコアが再計算待ちとなる）
+	if input.DailyScoreStrategy != nil {
+		if err := profile.UpdateDailyScoreStrategy(*input.DailyScoreStrategy); err != nil {
+			return nil, err
+		}
+	}
+
+	// 計算式が変わらない場合はプロフィールのみ永続化
+	if profile.OneRMFormula == previousFormula {
+		if err := u.profileRepo.Update(ctx, profile); err != nil {
+			return nil, err
+		}
+		return profile, nil
+	}
+
+	// 計算式が変わった場合は、保存済みの推定1RMと自己ベストが新旧の計算式で混在しないよう
+	// プロフィールの更新と同じトランザクションで全セットの推定1RMと各種目の自己ベストを再計算する
+	err = u.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		if err := u.profileRepo.Update(ctx, profile); err != nil {
+			return err
+		}
+		return u.recalculateEstimated1RMs(ctx, input.UserID, profile.OneRMFormula)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	return profile, nil
 }
 
+// recalculateEstimated1RMs はユーザーの全セットの推定1RMを指定された計算式で再計算して保存し、
+// セットのある種目ごとに自己ベストを同じ計算式で再構築する。
+// トランザクション内で呼び出されることを前提とする。
+func (u *ProfileUsecase) recalculateEstimated1RMs(ctx context.Context, userID uuid.UUID, formula entity.OneRMFormula) error {
+	sets, err := u.workoutSetRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	var exerciseIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, set := range sets {
+		set.RecalculateEstimated1RM(formula)
+		if !seen[set.ExerciseID] {
+			seen[set.ExerciseID] = true
+			exerciseIDs = append(exerciseIDs, set.ExerciseID)
+		}
+	}
+
+	if err := u.workoutSetRepo.UpdateEstimated1RMs(ctx, sets); err != nil {
+		return err
+	}
+
+	// 推定1RMの自己ベストは計算式に依存するため、種目ごとに時系列順の全セットから判定し直す
+	for _, exerciseID := range exerciseIDs {
+		exerciseSets, err := u.workoutSetRepo.FindByUserIDAndExerciseID(ctx, userID, exerciseID)
+		if err != nil {
+			return err
+		}
+		if _, err := rebuildPersonalRecords(ctx, u.workoutRepo, u.personalRecordRepo, userID, exerciseID, exerciseSets, formula); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // avatarPrefix はユーザーのアバターのS3プレフィックスを返す。
 func avatarPrefix(userID uuid.UUID) string {
 	return fmt.Sprintf("whiskey/users/%s/avatar/", userID.String())
@@ -42,6 +42,7 @@ func (m *mockProfileRepository) FindByID(ctx context.Context, id uuid.UUID) (*en
 	return profile, nil
 }
 
+// FindByUserID は実装と同様、プロフィールが存在しない場合は(nil, nil)を返す
 func (m *mockProfileRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*entity.Profile, error) {
 	if m.err != nil {
 		return nil, m.err
@@ -51,7 +52,7 @@ func (m *mockProfileRepository) FindByUserID(ctx context.Context, userID uuid.UU
 			return profile, nil
 		}
 	}
-	return nil, errors.New("profile not found")
+	return nil, nil
 }
 
 func (m *mockProfileRepository) Update(ctx context.Context, profile *entity.Profile) error {
@@ -82,6 +83,31 @@ func (m *mockProfileRepository) ExistsByUserID(ctx context.Context, userID uuid.
 	return false, nil
 }
 
+func (m *mockProfileRepository) FindPendingDailyScoreBackfill(ctx context.Context) ([]*entity.Profile, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var result []*entity.Profile
+	for _, profile := range m.profiles {
+		if profile.DailyScoreBackfillPending {
+			result = append(result, profile)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockProfileRepository) CompleteDailyScoreBackfill(ctx context.Context, userID uuid.UUID, strategy entity.DailyScoreStrategyType) error {
+	if m.err != nil {
+		return m.err
+	}
+	for _, profile := range m.profiles {
+		if profile.UserID == userID && profile.DailyScoreStrategy == strategy {
+			profile.DailyScoreBackfillPending = false
+		}
+	}
+	return nil
+}
+
 // テストヘルパー: リポジトリにプロフィールを追加
 func (m *mockProfileRepository) addProfile(userID uuid.UUID, displayName string) *entity.Profile {
 	profile, _ := entity.NewProfile(userID, displayName)
@@ -147,7 +173,7 @@ var _ repository.ObjectStorageRepository = (*mockObjectStorage)(nil)
 
 // テストヘルパー: ProfileUsecaseを生成
 func newProfileUsecaseForTest(mockRepo *mockProfileRepository) *ProfileUsecase {
-	return NewProfileUsecase(mockRepo, newMockObjectStorage())
+	return NewProfileUsecase(mockRepo, newMockWorkoutRepository(), newMockWorkoutSetRepository(), newMockPersonalRecordRepository(), newMockObjectStorage(), passthroughTransactionManager{})
 }
 
 func TestProfileUsecase_CreateProfile(t *testing.T) {
@@ -156,43 +182,39 @@ func TestProfileUsecase_CreateProfile(t *testing.T) {
 	height := 175.0
 
 	tests := []struct {
-		name        string
-		userID      uuid.UUID
-		displayName string
-		age         *int32
-		weight      *float64
-		height      *float64
-		setup       func(*mockProfileRepository)
-		wantErr     bool
-		checkErr    func(error) bool
+		name     string
+		input    CreateProfileInput
+		setup    func(*mockProfileRepository)
+		wantErr  bool
+		checkErr func(error) bool
 	}{
 		{
-			name:        "正常系: プロフィール作成成功（全フィールド）",
-			userID:      uuid.New(),
-			displayName: "テストユーザー",
-			age:         &age,
-			weight:      &weight,
-			height:      &height,
-			setup:       func(m *mockProfileRepository) {},
-			wantErr:     false,
+			name: "正常系: プロフィール作成成功（全フィールド）",
+			input: CreateProfileInput{
+				UserID:      uuid.New(),
+				DisplayName: "テストユーザー",
+				Age:         &age,
+				Weight:      &weight,
+				Height:      &height,
+			},
+			setup:   func(m *mockProfileRepository) {},
+			wantErr: false,
 		},
 		{
-			name:        "正常系: プロフィール作成成功（必須フィールドのみ）",
-			userID:      uuid.New(),
-			displayName: "テストユーザー",
-			age:         nil,
-			weight:      nil,
-			height:      nil,
-			setup:       func(m *mockProfileRepository) {},
-			wantErr:     false,
+			name: "正常系: プロフィール作成成功（必須フィールドのみ）",
+			input: CreateProfileInput{
+				UserID:      uuid.New(),
+				DisplayName: "テストユーザー",
+			},
+			setup:   func(m *mockProfileRepository) {},
+			wantErr: false,
 		},
 		{
-			name:        "異常系: プロフィールが既に存在",
-			userID:      uuid.New(),
-			displayName: "テストユーザー",
-			age:         nil,
-			weight:      nil,
-			height:      nil,
+			name: "異常系: プロフィールが既に存在",
+			input: CreateProfileInput{
+				UserID:      uuid.New(),
+				DisplayName: "テストユーザー",
+			},
 			setup: func(m *mockProfileRepository) {
 				// setupでuserIDを使えないため、テスト内で設定
 			},
@@ -202,57 +224,126 @@ func TestProfileUsecase_CreateProfile(t *testing.T) {
 			},
 		},
 		{
-			name:        "異常系: 表示名が空文字",
-			userID:      uuid.New(),
-			displayName: "",
-			age:         nil,
-			weight:      nil,
-			height:      nil,
-			setup:       func(m *mockProfileRepository) {},
-			wantErr:     true,
+			name: "異常系: 表示名が空文字",
+			input: CreateProfileInput{
+				UserID:      uuid.New(),
+				DisplayName: "",
+			},
+			setup:   func(m *mockProfileRepository) {},
+			wantErr: true,
 			checkErr: func(err error) bool {
 				return errors.Is(err, entity.ErrInvalidDisplayName)
 			},
 		},
 		{
-			name:        "異常系: 無効な年齢",
-			userID:      uuid.New(),
-			displayName: "テストユーザー",
-			age:         int32Ptr(-1),
-			weight:      nil,
-			height:      nil,
-			setup:       func(m *mockProfileRepository) {},
-			wantErr:     true,
+			name: "異常系: 無効な年齢",
+			input: CreateProfileInput{
+				UserID:      uuid.New(),
+				DisplayName: "テストユーザー",
+				Age:         int32Ptr(-1),
+			},
+			setup:   func(m *mockProfileRepository) {},
+			wantErr: true,
 			checkErr: func(err error) bool {
 				return errors.Is(err, entity.ErrInvalidAge)
 			},
 		},
 		{
-			name:        "異常系: 無効な体重",
-			userID:      uuid.New(),
-			displayName: "テストユーザー",
-			age:         nil,
-			weight:      float64Ptr(0),
-			height:      nil,
-			setup:       func(m *mockProfileRepository) {},
-			wantErr:     true,
+			name: "異常系: 無効な体重",
+			input: CreateProfileInput{
+				UserID:      uuid.New(),
+				DisplayName: "テストユーザー",
+				Weight:      float64Ptr(0),
+			},
+			setup:   func(m *mockProfileRepository) {},
+			wantErr: true,
 			checkErr: func(err error) bool {
 				return errors.Is(err, entity.ErrInvalidWeight)
 			},
 		},
 		{
-			name:        "異常系: 無効な身長",
-			userID:      uuid.New(),
-			displayName: "テストユーザー",
-			age:         nil,
-			weight:      nil,
-			height:      float64Ptr(0),
-			setup:       func(m *mockProfileRepository) {},
-			wantErr:     true,
+			name: "異常系: 無効な身長",
+			input: CreateProfileInput{
+				UserID:      uuid.New(),
+				DisplayName: "テストユーザー",
+				Height:      float64Ptr(0),
+			},
+			setup:   func(m *mockProfileRepository) {},
+			wantErr: true,
 			checkErr: func(err error) bool {
 				return errors.Is(err, entity.ErrInvalidHeight)
 			},
 		},
+		{
+			name: "正常系: 1RM計算式を指定して作成",
+			input: CreateProfileInput{
+				UserID:       uuid.New(),
+				DisplayName:  "テストユーザー",
+				OneRMFormula: oneRMFormulaPtr(entity.OneRMFormulaBrzycki),
+			},
+			setup:   func(m *mockProfileRepository) {},
+			wantErr: false,
+		},
+		{
+			name: "異常系: 無効な1RM計算式",
+			input: CreateProfileInput{
+				UserID:       uuid.New(),
+				DisplayName:  "テストユーザー",
+				OneRMFormula: oneRMFormulaPtr(entity.OneRMFormula("unknown")),
+			},
+			setup:   func(m *mockProfileRepository) {},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, entity.ErrInvalidOneRMFormula)
+			},
+		},
+		{
+			name: "正常系: タイムゾーンを指定して作成",
+			input: CreateProfileInput{
+				UserID:      uuid.New(),
+				DisplayName: "テストユーザー",
+				Timezone:    strPtr("Asia/Tokyo"),
+			},
+			setup:   func(m *mockProfileRepository) {},
+			wantErr: false,
+		},
+		{
+			name: "異常系: 無効なタイムゾーン",
+			input: CreateProfileInput{
+				UserID:      uuid.New(),
+				DisplayName: "テストユーザー",
+				Timezone:    strPtr("Mars/Olympus"),
+			},
+			setup:   func(m *mockProfileRepository) {},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, entity.ErrInvalidTimezone)
+			},
+		},
+		{
+			name: "正常系: 週の目標と休息日数を指定して作成",
+			input: CreateProfileInput{
+				UserID:            uuid.New(),
+				DisplayName:       "テストユーザー",
+				WeeklyWorkoutGoal: int32Ptr(5),
+				RestDayTolerance:  int32Ptr(0),
+			},
+			setup:   func(m *mockProfileRepository) {},
+			wantErr: false,
+		},
+		{
+			name: "異常系: 週の目標が範囲外",
+			input: CreateProfileInput{
+				UserID:            uuid.New(),
+				DisplayName:       "テストユーザー",
+				WeeklyWorkoutGoal: int32Ptr(0),
+			},
+			setup:   func(m *mockProfileRepository) {},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, entity.ErrInvalidWeeklyWorkoutGoal)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -262,12 +353,12 @@ func TestProfileUsecase_CreateProfile(t *testing.T) {
 
 			// 「プロフィールが既に存在」ケースの特別対応
 			if tt.name == "異常系: プロフィールが既に存在" {
-				mockRepo.addProfile(tt.userID, "既存ユーザー")
+				mockRepo.addProfile(tt.input.UserID, "既存ユーザー")
 			}
 
 			usecase := newProfileUsecaseForTest(mockRepo)
 
-			profile, err := usecase.CreateProfile(context.Background(), tt.userID, tt.displayName, tt.age, tt.weight, tt.height)
+			profile, err := usecase.CreateProfile(context.Background(), tt.input)
 
 			if tt.wantErr {
 				if err == nil {
@@ -290,12 +381,39 @@ func TestProfileUsecase_CreateProfile(t *testing.T) {
 				return
 			}
 
-			if profile.UserID != tt.userID {
-				t.Errorf("profile.UserID = %v, want %v", profile.UserID, tt.userID)
+			if profile.UserID != tt.input.UserID {
+				t.Errorf("profile.UserID = %v, want %v", profile.UserID, tt.input.UserID)
 			}
 
-			if profile.DisplayName != tt.displayName {
-				t.Errorf("profile.DisplayName = %v, want %v", profile.DisplayName, tt.displayName)
+			if profile.DisplayName != tt.input.DisplayName {
+				t.Errorf("profile.DisplayName = %v, want %v", profile.DisplayName, tt.input.DisplayName)
+			}
+
+			wantFormula := entity.DefaultOneRMFormula
+			if tt.input.OneRMFormula != nil {
+				wantFormula = *tt.input.OneRMFormula
+			}
+			if profile.OneRMFormula != wantFormula {
+				t.Errorf("profile.OneRMFormula = %v, want %v", profile.OneRMFormula, wantFormula)
+			}
+
+			wantTimezone := entity.DefaultTimezone
+			if tt.input.Timezone != nil {
+				wantTimezone = *tt.input.Timezone
+			}
+			if profile.Timezone != wantTimezone {
+				t.Errorf("profile.Timezone = %v, want %v", profile.Timezone, wantTimezone)
+			}
+
+			wantGoal, wantRestDays := entity.DefaultWeeklyWorkoutGoal, entity.DefaultRestDayTolerance
+			if tt.input.WeeklyWorkoutGoal != nil {
+				wantGoal = *tt.input.WeeklyWorkoutGoal
+			}
+			if tt.input.RestDayTolerance != nil {
+				wantRestDays = *tt.input.RestDayTolerance
+			}
+			if profile.WeeklyWorkoutGoal != wantGoal || profile.RestDayTolerance != wantRestDays {
+				t.Errorf("profile.WeeklyWorkoutGoal, RestDayTolerance = %v, %v, want %v, %v", profile.WeeklyWorkoutGoal, profile.RestDayTolerance, wantGoal, wantRestDays)
 			}
 
 			// リポジトリに保存されているか確認
@@ -305,8 +423,8 @@ func TestProfileUsecase_CreateProfile(t *testing.T) {
 				return
 			}
 
-			if saved.DisplayName != tt.displayName {
-				t.Errorf("saved profile displayName = %v, want %v", saved.DisplayName, tt.displayName)
+			if saved.DisplayName != tt.input.DisplayName {
+				t.Errorf("saved profile displayName = %v, want %v", saved.DisplayName, tt.input.DisplayName)
 			}
 		})
 	}
@@ -383,21 +501,17 @@ func TestProfileUsecase_UpdateProfile(t *testing.T) {
 	height := 180.0
 
 	tests := []struct {
-		name        string
-		displayName *string
-		age         *int32
-		weight      *float64
-		height      *float64
-		setup       func(*mockProfileRepository) uuid.UUID
-		wantErr     bool
-		checkErr    func(error) bool
+		name     string
+		input    UpdateProfileInput
+		setup    func(*mockProfileRepository) uuid.UUID
+		wantErr  bool
+		checkErr func(error) bool
 	}{
 		{
-			name:        "正常系: 表示名を更新",
-			displayName: strPtr("新しい名前"),
-			age:         nil,
-			weight:      nil,
-			height:      nil,
+			name: "正常系: 表示名を更新",
+			input: UpdateProfileInput{
+				DisplayName: strPtr("新しい名前"),
+			},
 			setup: func(m *mockProfileRepository) uuid.UUID {
 				userID := uuid.New()
 				m.addProfile(userID, "古い名前")
@@ -406,11 +520,13 @@ func TestProfileUsecase_UpdateProfile(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:        "正常系: 全フィールド更新",
-			displayName: strPtr("更新ユーザー"),
-			age:         &age,
-			weight:      &weight,
-			height:      &height,
+			name: "正常系: 全フィールド更新",
+			input: UpdateProfileInput{
+				DisplayName: strPtr("更新ユーザー"),
+				Age:         &age,
+				Weight:      &weight,
+				Height:      &height,
+			},
 			setup: func(m *mockProfileRepository) uuid.UUID {
 				userID := uuid.New()
 				m.addProfile(userID, "テストユーザー")
@@ -419,11 +535,10 @@ func TestProfileUsecase_UpdateProfile(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:        "正常系: オプションフィールドのみ更新",
-			displayName: nil,
-			age:         &age,
-			weight:      nil,
-			height:      nil,
+			name: "正常系: オプションフィールドのみ更新",
+			input: UpdateProfileInput{
+				Age: &age,
+			},
 			setup: func(m *mockProfileRepository) uuid.UUID {
 				userID := uuid.New()
 				m.addProfile(userID, "テストユーザー")
@@ -432,11 +547,10 @@ func TestProfileUsecase_UpdateProfile(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:        "異常系: プロフィールが存在しない",
-			displayName: strPtr("テスト"),
-			age:         nil,
-			weight:      nil,
-			height:      nil,
+			name: "異常系: プロフィールが存在しない",
+			input: UpdateProfileInput{
+				DisplayName: strPtr("テスト"),
+			},
 			setup: func(m *mockProfileRepository) uuid.UUID {
 				return uuid.New()
 			},
@@ -446,11 +560,10 @@ func TestProfileUsecase_UpdateProfile(t *testing.T) {
 			},
 		},
 		{
-			name:        "異常系: 表示名が空文字",
-			displayName: strPtr(""),
-			age:         nil,
-			weight:      nil,
-			height:      nil,
+			name: "異常系: 表示名が空文字",
+			input: UpdateProfileInput{
+				DisplayName: strPtr(""),
+			},
 			setup: func(m *mockProfileRepository) uuid.UUID {
 				userID := uuid.New()
 				m.addProfile(userID, "テストユーザー")
@@ -462,11 +575,10 @@ func TestProfileUsecase_UpdateProfile(t *testing.T) {
 			},
 		},
 		{
-			name:        "異常系: 無効な年齢",
-			displayName: nil,
-			age:         int32Ptr(200),
-			weight:      nil,
-			height:      nil,
+			name: "異常系: 無効な年齢",
+			input: UpdateProfileInput{
+				Age: int32Ptr(200),
+			},
 			setup: func(m *mockProfileRepository) uuid.UUID {
 				userID := uuid.New()
 				m.addProfile(userID, "テストユーザー")
@@ -478,11 +590,10 @@ func TestProfileUsecase_UpdateProfile(t *testing.T) {
 			},
 		},
 		{
-			name:        "異常系: 無効な体重",
-			displayName: nil,
-			age:         nil,
-			weight:      float64Ptr(-1),
-			height:      nil,
+			name: "異常系: 無効な体重",
+			input: UpdateProfileInput{
+				Weight: float64Ptr(-1),
+			},
 			setup: func(m *mockProfileRepository) uuid.UUID {
 				userID := uuid.New()
 				m.addProfile(userID, "テストユーザー")
@@ -494,11 +605,10 @@ func TestProfileUsecase_UpdateProfile(t *testing.T) {
 			},
 		},
 		{
-			name:        "異常系: 無効な身長",
-			displayName: nil,
-			age:         nil,
-			weight:      nil,
-			height:      float64Ptr(500),
+			name: "異常系: 無効な身長",
+			input: UpdateProfileInput{
+				Height: float64Ptr(500),
+			},
 			setup: func(m *mockProfileRepository) uuid.UUID {
 				userID := uuid.New()
 				m.addProfile(userID, "テストユーザー")
@@ -509,6 +619,115 @@ func TestProfileUsecase_UpdateProfile(t *testing.T) {
 				return errors.Is(err, entity.ErrInvalidHeight)
 			},
 		},
+		{
+			name: "正常系: 1RM計算式を更新",
+			input: UpdateProfileInput{
+				OneRMFormula: oneRMFormulaPtr(entity.OneRMFormulaMayhew),
+			},
+			setup: func(m *mockProfileRepository) uuid.UUID {
+				userID := uuid.New()
+				m.addProfile(userID, "テストユーザー")
+				return userID
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系: 無効な1RM計算式",
+			input: UpdateProfileInput{
+				OneRMFormula: oneRMFormulaPtr(entity.OneRMFormula("unknown")),
+			},
+			setup: func(m *mockProfileRepository) uuid.UUID {
+				userID := uuid.New()
+				m.addProfile(userID, "テストユーザー")
+				return userID
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, entity.ErrInvalidOneRMFormula)
+			},
+		},
+		{
+			name: "正常系: タイムゾーンを更新",
+			input: UpdateProfileInput{
+				Timezone: strPtr("America/New_York"),
+			},
+			setup: func(m *mockProfileRepository) uuid.UUID {
+				userID := uuid.New()
+				m.addProfile(userID, "テストユーザー")
+				return userID
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系: 無効なタイムゾーン",
+			input: UpdateProfileInput{
+				Timezone: strPtr("Local"),
+			},
+			setup: func(m *mockProfileRepository) uuid.UUID {
+				userID := uuid.New()
+				m.addProfile(userID, "テストユーザー")
+				return userID
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, entity.ErrInvalidTimezone)
+			},
+		},
+		{
+			name: "正常系: 週の目標と休息日数を更新",
+			input: UpdateProfileInput{
+				WeeklyWorkoutGoal: int32Ptr(6),
+				RestDayTolerance:  int32Ptr(2),
+			},
+			setup: func(m *mockProfileRepository) uuid.UUID {
+				userID := uuid.New()
+				m.addProfile(userID, "テストユーザー")
+				return userID
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系: 休息日数が範囲外",
+			input: UpdateProfileInput{
+				RestDayTolerance: int32Ptr(-1),
+			},
+			setup: func(m *mockProfileRepository) uuid.UUID {
+				userID := uuid.New()
+				m.addProfile(userID, "テストユーザー")
+				return userID
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, entity.ErrInvalidRestDayTolerance)
+			},
+		},
+		{
+			name: "正常系: デイリースコアの計算方法を変更すると再計算待ちになる",
+			input: UpdateProfileInput{
+				DailyScoreStrategy: dailyScoreStrategyPtr(entity.DailyScoreStrategyBodyweightRelative),
+			},
+			setup: func(m *mockProfileRepository) uuid.UUID {
+				userID := uuid.New()
+				m.addProfile(userID, "テストユーザー")
+				return userID
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系: 無効なデイリースコアの計算方法",
+			input: UpdateProfileInput{
+				DailyScoreStrategy: dailyScoreStrategyPtr("unknown"),
+			},
+			setup: func(m *mockProfileRepository) uuid.UUID {
+				userID := uuid.New()
+				m.addProfile(userID, "テストユーザー")
+				return userID
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, entity.ErrInvalidDailyScoreStrategy)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -518,7 +737,9 @@ func TestProfileUsecase_UpdateProfile(t *testing.T) {
 
 			usecase := newProfileUsecaseForTest(mockRepo)
 
-			profile, err := usecase.UpdateProfile(context.Background(), userID, tt.displayName, tt.age, tt.weight, tt.height)
+			input := tt.input
+			input.UserID = userID
+			profile, err := usecase.UpdateProfile(context.Background(), input)
 
 			if tt.wantErr {
 				if err == nil {
@@ -541,20 +762,177 @@ func TestProfileUsecase_UpdateProfile(t *testing.T) {
 				return
 			}
 
-			if tt.displayName != nil && profile.DisplayName != *tt.displayName {
-				t.Errorf("profile.DisplayName = %v, want %v", profile.DisplayName, *tt.displayName)
+			if tt.input.DisplayName != nil && profile.DisplayName != *tt.input.DisplayName {
+				t.Errorf("profile.DisplayName = %v, want %v", profile.DisplayName, *tt.input.DisplayName)
+			}
+
+			if tt.input.Age != nil && (profile.Age == nil || *profile.Age != *tt.input.Age) {
+				t.Errorf("profile.Age = %v, want %v", profile.Age, *tt.input.Age)
+			}
+
+			if tt.input.Weight != nil && (profile.Weight == nil || *profile.Weight != *tt.input.Weight) {
+				t.Errorf("profile.Weight = %v, want %v", profile.Weight, *tt.input.Weight)
+			}
+
+			if tt.input.Height != nil && (profile.Height == nil || *profile.Height != *tt.input.Height) {
+				t.Errorf("profile.Height = %v, want %v", profile.Height, *tt.input.Height)
+			}
+
+			if tt.input.OneRMFormula != nil && profile.OneRMFormula != *tt.input.OneRMFormula {
+				t.Errorf("profile.OneRMFormula = %v, want %v", profile.OneRMFormula, *tt.input.OneRMFormula)
+			}
+
+			if tt.input.Timezone != nil && profile.Timezone != *tt.input.Timezone {
+				t.Errorf("profile.Timezone = %v, want %v", profile.Timezone, *tt.input.Timezone)
+			}
+
+			if tt.input.WeeklyWorkoutGoal != nil && profile.WeeklyWorkoutGoal != *tt.input.WeeklyWorkoutGoal {
+				t.Errorf("profile.WeeklyWorkoutGoal = %v, want %v", profile.WeeklyWorkoutGoal, *tt.input.WeeklyWorkoutGoal)
+			}
+
+			if tt.input.RestDayTolerance != nil && profile.RestDayTolerance != *tt.input.RestDayTolerance {
+				t.Errorf("profile.RestDayTolerance = %v, want %v", profile.RestDayTolerance, *tt.input.RestDayTolerance)
+			}
+
+			if tt.input.DailyScoreStrategy != nil && (profile.DailyScoreStrategy != *tt.input.DailyScoreStrategy || !profile.DailyScoreBackfillPending) {
+				t.Errorf("profile.DailyScoreStrategy, DailyScoreBackfillPending = %v, %v, want %v, true", profile.DailyScoreStrategy, profile.DailyScoreBackfillPending, *tt.input.DailyScoreStrategy)
+			}
+		})
+	}
+}
+
+func TestProfileUsecase_UpdateProfile_RecalculatesEstimated1RM(t *testing.T) {
+	tests := []struct {
+		name    string
+		formula entity.OneRMFormula
+		// wantRecalculated は保存済みの推定1RMが新しい計算式で再計算されることを期待するか
+		wantRecalculated bool
+	}{
+		{
+			name:             "正常系: 計算式を変更すると自分の全セットの推定1RMと自己ベストを再計算する",
+			formula:          entity.OneRMFormulaBrzycki,
+			wantRecalculated: true,
+		},
+		{
+			name:             "正常系: 計算式が変わらない場合は再計算しない",
+			formula:          entity.OneRMFormulaEpley,
+			wantRecalculated: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profileRepo := newMockProfileRepository()
+			workoutRepo := newMockWorkoutRepository()
+			setRepo := newMockWorkoutSetRepository()
+			setRepo.workoutRepo = workoutRepo
+			prRepo := newMockPersonalRecordRepository()
+			uc := NewProfileUsecase(profileRepo, workoutRepo, setRepo, prRepo, newMockObjectStorage(), passthroughTransactionManager{})
+
+			userID := uuid.New()
+			exerciseID := uuid.New()
+			profileRepo.addProfile(userID, "テストユーザー")
+			workout := workoutRepo.addWorkout(userID, time.Now())
+			set := setRepo.addWorkoutSet(workout.ID, exerciseID, 1, 5, 100)
+			otherWorkout := workoutRepo.addWorkout(uuid.New(), time.Now())
+			otherSet := setRepo.addWorkoutSet(otherWorkout.ID, uuid.New(), 1, 5, 100)
+			epley := entity.CalculateEstimated1RMWithFormula(100, 5, entity.OneRMFormulaEpley)
+			prRepo.addRecord(userID, exerciseID, entity.PersonalRecordTypeEstimated1RM, epley)
+
+			formula := tt.formula
+			if _, err := uc.UpdateProfile(context.Background(), UpdateProfileInput{UserID: userID, OneRMFormula: &formula}); err != nil {
+				t.Fatalf("UpdateProfile() unexpected error = %v", err)
 			}
 
-			if tt.age != nil && (profile.Age == nil || *profile.Age != *tt.age) {
-				t.Errorf("profile.Age = %v, want %v", profile.Age, *tt.age)
+			want := epley
+			if tt.wantRecalculated {
+				want = entity.CalculateEstimated1RMWithFormula(100, 5, tt.formula)
+			}
+			if set.Estimated1RM != want {
+				t.Errorf("set.Estimated1RM = %v, want %v", set.Estimated1RM, want)
+			}
+			if otherSet.Estimated1RM != epley {
+				t.Errorf("他ユーザーのset.Estimated1RM = %v, want %v", otherSet.Estimated1RM, epley)
 			}
 
-			if tt.weight != nil && (profile.Weight == nil || *profile.Weight != *tt.weight) {
-				t.Errorf("profile.Weight = %v, want %v", profile.Weight, *tt.weight)
+			var records []*entity.PersonalRecord
+			for _, record := range prRepo.records {
+				if record.RecordType == entity.PersonalRecordTypeEstimated1RM {
+					records = append(records, record)
+				}
+			}
+			if len(records) != 1 {
+				t.Fatalf("推定1RMの自己ベスト件数 = %d, want 1", len(records))
+			}
+			if records[0].Value != want {
+				t.Errorf("推定1RMの自己ベスト = %v, want %v", records[0].Value, want)
 			}
+		})
+	}
+}
 
-			if tt.height != nil && (profile.Height == nil || *profile.Height != *tt.height) {
-				t.Errorf("profile.Height = %v, want %v", profile.Height, *tt.height)
+func TestProfileUsecase_GetLocation(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(*mockProfileRepository) uuid.UUID
+		wantName string
+		wantErr  bool
+	}{
+		{
+			name: "正常系: プロフィールのタイムゾーンを返す",
+			setup: func(m *mockProfileRepository) uuid.UUID {
+				userID := uuid.New()
+				p := m.addProfile(userID, "テストユーザー")
+				p.Timezone = "Asia/Tokyo"
+				return userID
+			},
+			wantName: "Asia/Tokyo",
+		},
+		{
+			name: "正常系: デフォルトのタイムゾーンはUTC",
+			setup: func(m *mockProfileRepository) uuid.UUID {
+				userID := uuid.New()
+				m.addProfile(userID, "テストユーザー")
+				return userID
+			},
+			wantName: "UTC",
+		},
+		{
+			name: "正常系: プロフィールが存在しない場合はUTC",
+			setup: func(m *mockProfileRepository) uuid.UUID {
+				return uuid.New()
+			},
+			wantName: "UTC",
+		},
+		{
+			name: "異常系: プロフィールを取得できない場合はUTCにせずエラーを返す",
+			setup: func(m *mockProfileRepository) uuid.UUID {
+				m.err = errors.New("db error")
+				return uuid.New()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := newMockProfileRepository()
+			userID := tt.setup(mockRepo)
+
+			usecase := newProfileUsecaseForTest(mockRepo)
+
+			loc, err := usecase.GetLocation(context.Background(), userID)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("GetLocation() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetLocation() unexpected error = %v", err)
+			}
+			if loc.String() != tt.wantName {
+				t.Errorf("GetLocation() = %v, want %v", loc, tt.wantName)
 			}
 		})
 	}
@@ -587,7 +965,7 @@ func TestProfileUsecase_GetAvatarUploadURL(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := newMockProfileRepository()
 			mockStorage := newMockObjectStorage()
-			uc := NewProfileUsecase(mockRepo, mockStorage)
+			uc := NewProfileUsecase(mockRepo, newMockWorkoutRepository(), newMockWorkoutSetRepository(), newMockPersonalRecordRepository(), mockStorage, passthroughTransactionManager{})
 
 			userID := uuid.New()
 			url, key, err := uc.GetAvatarUploadURL(context.Background(), userID, tt.contentType)
@@ -618,7 +996,7 @@ func TestProfileUsecase_GetAvatarUploadURL_DeletesExisting(t *testing.T) {
 	t.Run("正常系: 既存アバターを削除してからURL発行", func(t *testing.T) {
 		mockRepo := newMockProfileRepository()
 		mockStorage := newMockObjectStorage()
-		uc := NewProfileUsecase(mockRepo, mockStorage)
+		uc := NewProfileUsecase(mockRepo, newMockWorkoutRepository(), newMockWorkoutSetRepository(), newMockPersonalRecordRepository(), mockStorage, passthroughTransactionManager{})
 
 		userID := uuid.New()
 
@@ -647,7 +1025,7 @@ func TestProfileUsecase_GetAvatarURL(t *testing.T) {
 	t.Run("正常系: アバターURLを取得", func(t *testing.T) {
 		mockRepo := newMockProfileRepository()
 		mockStorage := newMockObjectStorage()
-		uc := NewProfileUsecase(mockRepo, mockStorage)
+		uc := NewProfileUsecase(mockRepo, newMockWorkoutRepository(), newMockWorkoutSetRepository(), newMockPersonalRecordRepository(), mockStorage, passthroughTransactionManager{})
 
 		userID := uuid.New()
 		key := "whiskey/users/" + userID.String() + "/avatar/test.jpg"
@@ -666,7 +1044,7 @@ func TestProfileUsecase_GetAvatarURL(t *testing.T) {
 	t.Run("正常系: アバターが存在しない場合は空文字列", func(t *testing.T) {
 		mockRepo := newMockProfileRepository()
 		mockStorage := newMockObjectStorage()
-		uc := NewProfileUsecase(mockRepo, mockStorage)
+		uc := NewProfileUsecase(mockRepo, newMockWorkoutRepository(), newMockWorkoutSetRepository(), newMockPersonalRecordRepository(), mockStorage, passthroughTransactionManager{})
 
 		userID := uuid.New()
 
@@ -685,7 +1063,7 @@ func TestProfileUsecase_DeleteAvatar(t *testing.T) {
 	t.Run("正常系: アバターを削除", func(t *testing.T) {
 		mockRepo := newMockProfileRepository()
 		mockStorage := newMockObjectStorage()
-		uc := NewProfileUsecase(mockRepo, mockStorage)
+		uc := NewProfileUsecase(mockRepo, newMockWorkoutRepository(), newMockWorkoutSetRepository(), newMockPersonalRecordRepository(), mockStorage, passthroughTransactionManager{})
 
 		userID := uuid.New()
 		key := "whiskey/users/" + userID.String() + "/avatar/test.jpg"
@@ -706,7 +1084,7 @@ func TestProfileUsecase_DeleteAvatar(t *testing.T) {
 	t.Run("正常系: アバターが存在しない場合もエラーなし", func(t *testing.T) {
 		mockRepo := newMockProfileRepository()
 		mockStorage := newMockObjectStorage()
-		uc := NewProfileUsecase(mockRepo, mockStorage)
+		uc := NewProfileUsecase(mockRepo, newMockWorkoutRepository(), newMockWorkoutSetRepository(), newMockPersonalRecordRepository(), mockStorage, passthroughTransactionManager{})
 
 		userID := uuid.New()
 
@@ -725,3 +1103,11 @@ func int32Ptr(v int32) *int32 {
 func float64Ptr(v float64) *float64 {
 	return &v
 }
+
+func oneRMFormulaPtr(v entity.OneRMFormula) *entity.OneRMFormula {
+	return &v
+}
+
+func dailyScoreStrategyPtr(v entity.DailyScoreStrategyType) *entity.DailyScoreStrategyType {
+	return &v
+}
@@ -0,0 +1,315 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+var (
+	// ErrProgramNotFound はプログラムが見つからない場合のエラー
+	ErrProgramNotFound = errors.New("program not found")
+	// ErrProgramAccessDenied はプログラムへのアクセスが拒否された場合のエラー
+	ErrProgramAccessDenied = errors.New("access denied to this program")
+	// ErrProgramCompleted はプログラムの全日程を終えている場合のエラー
+	ErrProgramCompleted = errors.New("program is already completed")
+)
+
+// ProgramSetInput はプログラムの1セット分の処方の入力データを表す。
+type ProgramSetInput struct {
+	ExerciseID      uuid.UUID
+	Reps            int32
+	PercentageOf1RM float64
+}
+
+// ProgramDayInput はプログラムの1日分の入力データを表す。
+type ProgramDayInput struct {
+	Week int32
+	Day  int32
+	Sets []ProgramSetInput
+}
+
+// CreateProgramInput はプログラム作成の入力データを表す。
+type CreateProgramInput struct {
+	Name        string
+	Description *string
+	Days        []ProgramDayInput
+}
+
+// PrescribedSet はユーザーの推定1RMから計算した1セット分の処方を表す。
+// 推定1RMの記録がない種目はWeightがnilとなる。
+type PrescribedSet struct {
+	ExerciseID      uuid.UUID
+	SetNumber       int32
+	Reps            int32
+	PercentageOf1RM float64
+	Estimated1RM    float64
+	Weight          *float64
+}
+
+// ProgramDayOutput はプログラムの現在の日と、その日の処方重量を表す。
+type ProgramDayOutput struct {
+	Program  *entity.Program
+	DayIndex int32
+	Week     int32
+	Day      int32
+	Sets     []PrescribedSet
+}
+
+// ProgramUsecaseInterface はProgramUsecaseのインターフェース。
+// テスト時のモック作成に使用する。
+type ProgramUsecaseInterface interface {
+	CreateProgram(ctx context.Context, userID uuid.UUID, input CreateProgramInput) (*entity.Program, error)
+	GetProgram(ctx context.Context, userID, programID uuid.UUID) (*entity.Program, error)
+	ListPrograms(ctx context.Context, userID uuid.UUID) ([]*entity.Program, error)
+	DeleteProgram(ctx context.Context, userID, programID uuid.UUID) error
+	GetCurrentProgramDay(ctx context.Context, userID, programID uuid.UUID) (*ProgramDayOutput, error)
+}
+
+// ProgramUsecase は複数週にわたるトレーニングプログラムに関するビジネスロジックを提供する。
+// プログラムの作成、取得、一覧、削除と、現在の日の処方重量の計算のユースケースを実装する。
+// プログラムの進捗（現在の日）はワークアウト記録時にWorkoutUsecaseが進める。
+type ProgramUsecase struct {
+	programRepo    repository.ProgramRepository
+	exerciseRepo   repository.ExerciseRepository
+	workoutSetRepo repository.WorkoutSetRepository
+	txManager      repository.TransactionManager
+}
+
+// NewProgramUsecase はProgramUsecaseの新しいインスタンスを生成する。
+//
+// パラメータ:
+//   - programRepo: プログラムデータの永続化を担当するリポジトリ
+//   - exerciseRepo: エクササイズの存在確認に使用するリポジトリ
+//   - workoutSetRepo: 処方重量の基準となる最大推定1RMを取得するためのリポジトリ
+//   - txManager: プログラムと日程・セットの書き込みを1トランザクションにまとめるトランザクションマネージャー
+//
+// 戻り値:
+//   - *ProgramUsecase: 生成されたProgramUsecaseインスタンス
+func NewProgramUsecase(
+	programRepo repository.ProgramRepository,
+	exerciseRepo repository.ExerciseRepository,
+	workoutSetRepo repository.WorkoutSetRepository,
+	txManager repository.TransactionManager,
+) *ProgramUsecase {
+	return &ProgramUsecase{
+		programRepo:    programRepo,
+		exerciseRepo:   exerciseRepo,
+		workoutSetRepo: workoutSetRepo,
+		txManager:      txManager,
+	}
+}
+
+// CreateProgram は新しいプログラムを作成する。
+// 種目の存在確認とバリデーションを実施し、プログラムと日程・セットを1トランザクションで永続化する。
+//
+// パラメータ:
+//   - ctx: リクエストのコンテキスト
+//   - userID: プログラムを所有するユーザーのID
+//   - input: プログラム作成の入力データ
+//
+// 戻り値:
+//   - *entity.Program: 作成されたプログラムエンティティ
+//   - error: 以下のエラーが返される可能性がある
+//     - ErrExerciseNotFound: 指定されたエクササイズが存在しない
+//     - entity.ErrInvalidProgramName: プログラム名が不正
+//     - entity.ErrEmptyProgramDays: 日程が空
+//     - entity.ErrEmptyProgramDaySets: セットが空の日がある
+//     - entity.ErrInvalidProgramWeek: 週が不正
+//     - entity.ErrInvalidProgramDay: 日が不正
+//     - entity.ErrProgramDaysOutOfOrder: 日程が週・日の昇順でない
+//     - entity.ErrInvalidPrescribedReps: レップ数が不正
+//     - entity.ErrInvalidPercentageOf1RM: 推定1RMに対する割合が不正
+//     - その他のリポジトリエラー
+func (u *ProgramUsecase) CreateProgram(ctx context.Context, userID uuid.UUID, input CreateProgramInput) (*entity.Program, error) {
+	days, err := u.toProgramDays(ctx, userID, input.Days)
+	if err != nil {
+		return nil, err
+	}
+
+	program, err := entity.NewProgram(userID, input.Name, input.Description, days)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		return u.programRepo.Create(ctx, program)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return program, nil
+}
+
+// GetProgram はプログラムの詳細を取得する。
+//
+// パラメータ:
+//   - ctx: リクエストのコンテキスト
+//   - userID: リクエスト元のユーザーID
+//   - programID: 取得するプログラムのID
+//
+// 戻り値:
+//   - *entity.Program: 取得したプログラムエンティティ
+//   - error: 以下のエラーが返される可能性がある
+//     - ErrProgramNotFound: プログラムが存在しない
+//     - ErrProgramAccessDenied: アクセス権がない
+//     - その他のリポジトリエラー
+func (u *ProgramUsecase) GetProgram(ctx context.Context, userID, programID uuid.UUID) (*entity.Program, error) {
+	return u.getProgramWithOwnershipCheck(ctx, userID, programID)
+}
+
+// ListPrograms はユーザーのプログラム一覧を作成日時の新しい順に取得する。
+//
+// パラメータ:
+//   - ctx: リクエストのコンテキスト
+//   - userID: ユーザーID
+//
+// 戻り値:
+//   - []*entity.Program: プログラムのリスト
+//   - error: リポジトリエラー
+func (u *ProgramUsecase) ListPrograms(ctx context.Context, userID uuid.UUID) ([]*entity.Program, error) {
+	return u.programRepo.FindByUserID(ctx, userID)
+}
+
+// DeleteProgram はプログラムを削除する。
+// プログラムに沿って記録したワークアウトは削除されない。
+//
+// パラメータ:
+//   - ctx: リクエストのコンテキスト
+//   - userID: リクエスト元のユーザーID
+//   - programID: 削除するプログラムのID
+//
+// 戻り値:
+//   - error: 以下のエラーが返される可能性がある
+//     - ErrProgramNotFound: プログラムが存在しない
+//     - ErrProgramAccessDenied: アクセス権がない
+//     - その他のリポジトリエラー
+func (u *ProgramUsecase) DeleteProgram(ctx context.Context, userID, programID uuid.UUID) error {
+	if _, err := u.getProgramWithOwnershipCheck(ctx, userID, programID); err != nil {
+		return err
+	}
+
+	return u.programRepo.Delete(ctx, programID)
+}
+
+// GetCurrentProgramDay はプログラムの現在の日を取得し、各セットの処方重量を計算する。
+// 処方重量は種目ごとのユーザーの全期間最大推定1RMに割合を掛け、2.5kg刻みに丸めたものとする。
+// 推定1RMの記録がない種目は重量を計算しない。
+//
+// パラメータ:
+//   - ctx: リクエストのコンテキスト
+//   - userID: リクエスト元のユーザーID
+//   - programID: 対象のプログラムのID
+//
+// 戻り値:
+//   - *ProgramDayOutput: 現在の日と処方重量
+//   - error: 以下のエラーが返される可能性がある
+//     - ErrProgramNotFound: プログラムが存在しない
+//     - ErrProgramAccessDenied: アクセス権がない
+//     - ErrProgramCompleted: 全日程を終えている
+//     - その他のリポジトリエラー
+func (u *ProgramUsecase) GetCurrentProgramDay(ctx context.Context, userID, programID uuid.UUID) (*ProgramDayOutput, error) {
+	program, err := u.getProgramWithOwnershipCheck(ctx, userID, programID)
+	if err != nil {
+		return nil, err
+	}
+
+	day := program.CurrentDay()
+	if day == nil {
+		return nil, ErrProgramCompleted
+	}
+
+	estimated1RMs := make(map[uuid.UUID]float64)
+	for _, exerciseID := range day.ExerciseIDs() {
+		e1RM, err := u.workoutSetRepo.GetMaxEstimated1RMByExerciseAndUser(ctx, userID, exerciseID)
+		if err != nil {
+			return nil, err
+		}
+		estimated1RMs[exerciseID] = e1RM
+	}
+
+	return &ProgramDayOutput{
+		Program:  program,
+		DayIndex: program.CurrentDayIndex,
+		Week:     day.Week,
+		Day:      day.Day,
+		Sets:     toPrescribedSets(day, estimated1RMs),
+	}, nil
+}
+
+// getProgramWithOwnershipCheck はプログラムを取得し、オーナーシップを確認する。
+func (u *ProgramUsecase) getProgramWithOwnershipCheck(ctx context.Context, userID, programID uuid.UUID) (*entity.Program, error) {
+	program, err := u.programRepo.FindByID(ctx, programID)
+	if err != nil || program == nil {
+		return nil, ErrProgramNotFound
+	}
+
+	if !program.IsOwnedBy(userID) {
+		return nil, ErrProgramAccessDenied
+	}
+
+	return program, nil
+}
+
+// toProgramDays は入力データを検証し、ProgramDayのスライスに変換する。
+// 他ユーザーのカスタム種目は存在しないものとして扱う。
+func (u *ProgramUsecase) toProgramDays(ctx context.Context, userID uuid.UUID, inputs []ProgramDayInput) ([]entity.ProgramDay, error) {
+	visible := make(map[uuid.UUID]bool)
+	days := make([]entity.ProgramDay, 0, len(inputs))
+	for _, dayInput := range inputs {
+		sets := make([]entity.ProgramSet, 0, len(dayInput.Sets))
+		for _, in := range dayInput.Sets {
+			if !visible[in.ExerciseID] {
+				exercise, err := u.exerciseRepo.FindByID(ctx, in.ExerciseID)
+				if err != nil || exercise == nil || !exercise.IsVisibleTo(userID) {
+					return nil, ErrExerciseNotFound
+				}
+				visible[in.ExerciseID] = true
+			}
+
+			set, err := entity.NewProgramSet(in.ExerciseID, in.Reps, in.PercentageOf1RM)
+			if err != nil {
+				return nil, err
+			}
+			sets = append(sets, set)
+		}
+
+		day, err := entity.NewProgramDay(dayInput.Week, dayInput.Day, sets)
+		if err != nil {
+			return nil, err
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// toPrescribedSets はその日の処方と種目ごとの推定1RMから、処方重量付きのセットを組み立てる。
+// セット番号は種目ごとに1から振る。
+func toPrescribedSets(day *entity.ProgramDay, estimated1RMs map[uuid.UUID]float64) []PrescribedSet {
+	sets := make([]PrescribedSet, 0, len(day.Sets))
+	setNumbers := make(map[uuid.UUID]int32)
+	for _, s := range day.Sets {
+		setNumbers[s.ExerciseID]++
+		e1RM := estimated1RMs[s.ExerciseID]
+
+		var weight *float64
+		if e1RM > 0 {
+			w := s.PrescribedWeight(e1RM)
+			weight = &w
+		}
+
+		sets = append(sets, PrescribedSet{
+			ExerciseID:      s.ExerciseID,
+			SetNumber:       setNumbers[s.ExerciseID],
+			Reps:            s.Reps,
+			PercentageOf1RM: s.PercentageOf1RM,
+			Estimated1RM:    e1RM,
+			Weight:          weight,
+		})
+	}
+	return sets
+}
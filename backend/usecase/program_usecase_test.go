@@ -0,0 +1,621 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+// mockProgramRepository はProgramRepositoryのモック実装
+type mockProgramRepository struct {
+	programs map[uuid.UUID]*entity.Program
+	links    map[uuid.UUID]*entity.ProgramWorkout
+	err      error
+}
+
+func newMockProgramRepository() *mockProgramRepository {
+	return &mockProgramRepository{
+		programs: make(map[uuid.UUID]*entity.Program),
+		links:    make(map[uuid.UUID]*entity.ProgramWorkout),
+	}
+}
+
+func (m *mockProgramRepository) Create(ctx context.Context, program *entity.Program) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.programs[program.ID] = program
+	return nil
+}
+
+func (m *mockProgramRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Program, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	program, ok := m.programs[id]
+	if !ok {
+		return nil, errors.New("program not found")
+	}
+	return program, nil
+}
+
+func (m *mockProgramRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Program, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var result []*entity.Program
+	for _, program := range m.programs {
+		if program.UserID == userID {
+			result = append(result, program)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockProgramRepository) UpdateProgress(ctx context.Context, program *entity.Program) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.programs[program.ID] = program
+	return nil
+}
+
+func (m *mockProgramRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if m.err != nil {
+		return m.err
+	}
+	delete(m.programs, id)
+	return nil
+}
+
+func (m *mockProgramRepository) LinkWorkout(ctx context.Context, link *entity.ProgramWorkout) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.links[link.WorkoutID] = link
+	return nil
+}
+
+func (m *mockProgramRepository) FindWorkoutLink(ctx context.Context, workoutID uuid.UUID) (*entity.ProgramWorkout, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.links[workoutID], nil
+}
+
+// テストヘルパー: リポジトリにプログラムを追加
+func (m *mockProgramRepository) addProgram(userID uuid.UUID, name string, days ...entity.ProgramDay) *entity.Program {
+	program, _ := entity.NewProgram(userID, name, nil, days)
+	m.programs[program.ID] = program
+	return program
+}
+
+// Ensure mockProgramRepository implements repository.ProgramRepository
+var _ repository.ProgramRepository = (*mockProgramRepository)(nil)
+
+// テスト用のセットアップヘルパー。
+// プログラムの進捗はワークアウト記録で進むため、WorkoutUsecaseと同じモックリポジトリを共有する。
+type programTestSetup struct {
+	*workoutTestSetup
+	usecase *ProgramUsecase
+}
+
+func newProgramTestSetup() *programTestSetup {
+	ws := newWorkoutTestSetup()
+	return &programTestSetup{
+		workoutTestSetup: ws,
+		usecase:          NewProgramUsecase(ws.programRepo, ws.exerciseRepo, ws.workoutSetRepo, ws.txManager),
+	}
+}
+
+func TestProgramUsecase_CreateProgram(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		setup   func(*programTestSetup) CreateProgramInput
+		wantErr error
+	}{
+		{
+			name: "正常系: プログラム作成成功",
+			setup: func(s *programTestSetup) CreateProgramInput {
+				squat := s.exerciseRepo.addExercise("スクワット", nil, nil)
+				bench := s.exerciseRepo.addUserExercise(userID, "ベンチプレス", nil, nil)
+				return CreateProgramInput{
+					Name: "5/3/1",
+					Days: []ProgramDayInput{
+						{Week: 1, Day: 1, Sets: []ProgramSetInput{
+							{ExerciseID: squat.ID, Reps: 5, PercentageOf1RM: 65},
+							{ExerciseID: squat.ID, Reps: 5, PercentageOf1RM: 75},
+						}},
+						{Week: 1, Day: 2, Sets: []ProgramSetInput{
+							{ExerciseID: bench.ID, Reps: 5, PercentageOf1RM: 65},
+						}},
+					},
+				}
+			},
+		},
+		{
+			name: "異常系: 存在しないエクササイズ",
+			setup: func(s *programTestSetup) CreateProgramInput {
+				return CreateProgramInput{
+					Name: "5/3/1",
+					Days: []ProgramDayInput{{Week: 1, Day: 1, Sets: []ProgramSetInput{{ExerciseID: uuid.New(), Reps: 5, PercentageOf1RM: 65}}}},
+				}
+			},
+			wantErr: ErrExerciseNotFound,
+		},
+		{
+			name: "異常系: 他ユーザーのカスタム種目",
+			setup: func(s *programTestSetup) CreateProgramInput {
+				other := s.exerciseRepo.addUserExercise(uuid.New(), "他人の種目", nil, nil)
+				return CreateProgramInput{
+					Name: "5/3/1",
+					Days: []ProgramDayInput{{Week: 1, Day: 1, Sets: []ProgramSetInput{{ExerciseID: other.ID, Reps: 5, PercentageOf1RM: 65}}}},
+				}
+			},
+			wantErr: ErrExerciseNotFound,
+		},
+		{
+			name: "異常系: 日程が空",
+			setup: func(s *programTestSetup) CreateProgramInput {
+				return CreateProgramInput{Name: "5/3/1"}
+			},
+			wantErr: entity.ErrEmptyProgramDays,
+		},
+		{
+			name: "異常系: 割合が不正",
+			setup: func(s *programTestSetup) CreateProgramInput {
+				squat := s.exerciseRepo.addExercise("スクワット", nil, nil)
+				return CreateProgramInput{
+					Name: "5/3/1",
+					Days: []ProgramDayInput{{Week: 1, Day: 1, Sets: []ProgramSetInput{{ExerciseID: squat.ID, Reps: 5, PercentageOf1RM: 0}}}},
+				}
+			},
+			wantErr: entity.ErrInvalidPercentageOf1RM,
+		},
+		{
+			name: "異常系: 日程が昇順でない",
+			setup: func(s *programTestSetup) CreateProgramInput {
+				squat := s.exerciseRepo.addExercise("スクワット", nil, nil)
+				sets := []ProgramSetInput{{ExerciseID: squat.ID, Reps: 5, PercentageOf1RM: 65}}
+				return CreateProgramInput{
+					Name: "5/3/1",
+					Days: []ProgramDayInput{{Week: 2, Day: 1, Sets: sets}, {Week: 1, Day: 1, Sets: sets}},
+				}
+			},
+			wantErr: entity.ErrProgramDaysOutOfOrder,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newProgramTestSetup()
+			input := tt.setup(s)
+
+			program, err := s.usecase.CreateProgram(context.Background(), userID, input)
+
+			if tt.wantErr != nil {
+				if err != tt.wantErr {
+					t.Errorf("CreateProgram() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(s.programRepo.programs) != 0 {
+					t.Errorf("programs = %d, want 0", len(s.programRepo.programs))
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("CreateProgram() unexpected error = %v", err)
+			}
+			if program.UserID != userID || program.Name != input.Name || len(program.Days) != len(input.Days) {
+				t.Errorf("CreateProgram() = %+v, unexpected fields", program)
+			}
+			if s.txManager.commits != 1 {
+				t.Errorf("commits = %d, want 1", s.txManager.commits)
+			}
+		})
+	}
+}
+
+func TestProgramUsecase_GetProgram(t *testing.T) {
+	userID := uuid.New()
+	day := entity.ProgramDay{Week: 1, Day: 1, Sets: []entity.ProgramSet{{ExerciseID: uuid.New(), Reps: 5, PercentageOf1RM: 65}}}
+
+	tests := []struct {
+		name    string
+		setup   func(*programTestSetup) uuid.UUID
+		wantErr error
+	}{
+		{
+			name: "正常系: プログラム取得成功",
+			setup: func(s *programTestSetup) uuid.UUID {
+				return s.programRepo.addProgram(userID, "5/3/1", day).ID
+			},
+		},
+		{
+			name: "異常系: 存在しないプログラム",
+			setup: func(s *programTestSetup) uuid.UUID {
+				return uuid.New()
+			},
+			wantErr: ErrProgramNotFound,
+		},
+		{
+			name: "異常系: 他ユーザーのプログラム",
+			setup: func(s *programTestSetup) uuid.UUID {
+				return s.programRepo.addProgram(uuid.New(), "5/3/1", day).ID
+			},
+			wantErr: ErrProgramAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newProgramTestSetup()
+			programID := tt.setup(s)
+
+			program, err := s.usecase.GetProgram(context.Background(), userID, programID)
+
+			if tt.wantErr != nil {
+				if err != tt.wantErr {
+					t.Errorf("GetProgram() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("GetProgram() unexpected error = %v", err)
+			}
+			if program.ID != programID {
+				t.Errorf("GetProgram() ID = %v, want %v", program.ID, programID)
+			}
+		})
+	}
+}
+
+func TestProgramUsecase_DeleteProgram(t *testing.T) {
+	userID := uuid.New()
+	day := entity.ProgramDay{Week: 1, Day: 1, Sets: []entity.ProgramSet{{ExerciseID: uuid.New(), Reps: 5, PercentageOf1RM: 65}}}
+
+	t.Run("正常系: プログラム削除成功", func(t *testing.T) {
+		s := newProgramTestSetup()
+		program := s.programRepo.addProgram(userID, "5/3/1", day)
+
+		if err := s.usecase.DeleteProgram(context.Background(), userID, program.ID); err != nil {
+			t.Fatalf("DeleteProgram() unexpected error = %v", err)
+		}
+		if _, ok := s.programRepo.programs[program.ID]; ok {
+			t.Error("program should be deleted")
+		}
+	})
+
+	t.Run("異常系: 他ユーザーのプログラム", func(t *testing.T) {
+		s := newProgramTestSetup()
+		program := s.programRepo.addProgram(uuid.New(), "5/3/1", day)
+
+		if err := s.usecase.DeleteProgram(context.Background(), userID, program.ID); err != ErrProgramAccessDenied {
+			t.Errorf("DeleteProgram() error = %v, want %v", err, ErrProgramAccessDenied)
+		}
+		if _, ok := s.programRepo.programs[program.ID]; !ok {
+			t.Error("program should not be deleted")
+		}
+	})
+}
+
+func TestProgramUsecase_GetCurrentProgramDay(t *testing.T) {
+	userID := uuid.New()
+
+	t.Run("正常系: 推定1RMから処方重量を計算", func(t *testing.T) {
+		s := newProgramTestSetup()
+		squat := s.exerciseRepo.addExercise("スクワット", nil, nil)
+		bench := s.exerciseRepo.addExercise("ベンチプレス", nil, nil)
+		program := s.programRepo.addProgram(userID, "5/3/1",
+			entity.ProgramDay{Week: 1, Day: 1, Sets: []entity.ProgramSet{
+				{ExerciseID: squat.ID, Reps: 5, PercentageOf1RM: 65},
+				{ExerciseID: squat.ID, Reps: 5, PercentageOf1RM: 85},
+				{ExerciseID: bench.ID, Reps: 5, PercentageOf1RM: 65},
+			}},
+		)
+
+		// スクワットの推定1RMは 105kg × 1レップ = 105kg（処方重量は2.5kg刻みに丸める）
+		workout := s.workoutRepo.addWorkout(userID, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+		s.workoutSetRepo.addWorkoutSet(workout.ID, squat.ID, 1, 1, 105.0)
+
+		output, err := s.usecase.GetCurrentProgramDay(context.Background(), userID, program.ID)
+		if err != nil {
+			t.Fatalf("GetCurrentProgramDay() unexpected error = %v", err)
+		}
+		if output.DayIndex != 0 || output.Week != 1 || output.Day != 1 {
+			t.Errorf("GetCurrentProgramDay() = day %d (week %d day %d), want day 0 (week 1 day 1)", output.DayIndex, output.Week, output.Day)
+		}
+		if len(output.Sets) != 3 {
+			t.Fatalf("Sets length = %d, want 3", len(output.Sets))
+		}
+
+		e1RM := 105.0
+		light, heavy := 67.5, 90.0
+		wantSets := []struct {
+			exerciseID uuid.UUID
+			setNumber  int32
+			weight     *float64
+		}{
+			{squat.ID, 1, &light},
+			{squat.ID, 2, &heavy},
+			{bench.ID, 1, nil},
+		}
+		for i, want := range wantSets {
+			got := output.Sets[i]
+			if got.ExerciseID != want.exerciseID || got.SetNumber != want.setNumber {
+				t.Errorf("Sets[%d] = {%v %d}, want {%v %d}", i, got.ExerciseID, got.SetNumber, want.exerciseID, want.setNumber)
+			}
+			if want.weight == nil {
+				if got.Weight != nil || got.Estimated1RM != 0 {
+					t.Errorf("Sets[%d] weight = %v, e1RM = %v, want nil and 0 without history", i, got.Weight, got.Estimated1RM)
+				}
+				continue
+			}
+			if got.Weight == nil || *got.Weight != *want.weight {
+				t.Errorf("Sets[%d].Weight = %v, want %v", i, got.Weight, *want.weight)
+			}
+			if got.Estimated1RM != e1RM {
+				t.Errorf("Sets[%d].Estimated1RM = %v, want %v", i, got.Estimated1RM, e1RM)
+			}
+		}
+	})
+
+	t.Run("異常系: 全日程を終えている", func(t *testing.T) {
+		s := newProgramTestSetup()
+		squat := s.exerciseRepo.addExercise("スクワット", nil, nil)
+		program := s.programRepo.addProgram(userID, "Linear",
+			entity.ProgramDay{Week: 1, Day: 1, Sets: []entity.ProgramSet{{ExerciseID: squat.ID, Reps: 5, PercentageOf1RM: 70}}},
+		)
+		program.CurrentDayIndex = 1
+
+		if _, err := s.usecase.GetCurrentProgramDay(context.Background(), userID, program.ID); err != ErrProgramCompleted {
+			t.Errorf("GetCurrentProgramDay() error = %v, want %v", err, ErrProgramCompleted)
+		}
+	})
+
+	t.Run("異常系: 他ユーザーのプログラム", func(t *testing.T) {
+		s := newProgramTestSetup()
+		squat := s.exerciseRepo.addExercise("スクワット", nil, nil)
+		program := s.programRepo.addProgram(uuid.New(), "Linear",
+			entity.ProgramDay{Week: 1, Day: 1, Sets: []entity.ProgramSet{{ExerciseID: squat.ID, Reps: 5, PercentageOf1RM: 70}}},
+		)
+
+		if _, err := s.usecase.GetCurrentProgramDay(context.Background(), userID, program.ID); err != ErrProgramAccessDenied {
+			t.Errorf("GetCurrentProgramDay() error = %v, want %v", err, ErrProgramAccessDenied)
+		}
+	})
+}
+
+func TestWorkoutUsecase_RecordWorkout_AdvancesProgram(t *testing.T) {
+	userID := uuid.New()
+	testDate := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
+
+	s := newProgramTestSetup()
+	squat := s.exerciseRepo.addExercise("スクワット", nil, nil)
+	bench := s.exerciseRepo.addExercise("ベンチプレス", nil, nil)
+	program := s.programRepo.addProgram(userID, "Linear",
+		entity.ProgramDay{Week: 1, Day: 1, Sets: []entity.ProgramSet{
+			{ExerciseID: squat.ID, Reps: 5, PercentageOf1RM: 75},
+			{ExerciseID: bench.ID, Reps: 5, PercentageOf1RM: 75},
+		}},
+		entity.ProgramDay{Week: 1, Day: 2, Sets: []entity.ProgramSet{
+			{ExerciseID: squat.ID, Reps: 5, PercentageOf1RM: 80},
+		}},
+	)
+	// 現在の日の種目が同じでも、紐付けていないプログラムは進まない
+	unlinked := s.programRepo.addProgram(userID, "Squat Only",
+		entity.ProgramDay{Week: 1, Day: 1, Sets: []entity.ProgramSet{{ExerciseID: squat.ID, Reps: 5, PercentageOf1RM: 75}}},
+	)
+
+	// 現在の日の一部の種目のみでは進まない
+	_, err := s.workoutTestSetup.usecase.RecordWorkout(context.Background(), RecordWorkoutInput{
+		UserID:    userID,
+		Date:      testDate,
+		Sets:      []SetInput{{ExerciseID: squat.ID, SetNumber: 1, Reps: 5, Weight: 80.0}},
+		ProgramID: &program.ID,
+	})
+	if err != nil {
+		t.Fatalf("RecordWorkout() unexpected error = %v", err)
+	}
+	if program.CurrentDayIndex != 0 {
+		t.Errorf("CurrentDayIndex after partial workout = %d, want 0", program.CurrentDayIndex)
+	}
+
+	// 現在の日の全種目を記録すると次の日へ進む
+	_, err = s.workoutTestSetup.usecase.RecordWorkout(context.Background(), RecordWorkoutInput{
+		UserID: userID,
+		Date:   testDate.AddDate(0, 0, 1),
+		Sets: []SetInput{
+			{ExerciseID: squat.ID, SetNumber: 1, Reps: 5, Weight: 80.0},
+			{ExerciseID: bench.ID, SetNumber: 1, Reps: 5, Weight: 60.0},
+		},
+		ProgramID: &program.ID,
+	})
+	if err != nil {
+		t.Fatalf("RecordWorkout() unexpected error = %v", err)
+	}
+	if program.CurrentDayIndex != 1 {
+		t.Errorf("CurrentDayIndex after matching workout = %d, want 1", program.CurrentDayIndex)
+	}
+	if unlinked.CurrentDayIndex != 0 {
+		t.Errorf("unlinked program CurrentDayIndex = %d, want 0", unlinked.CurrentDayIndex)
+	}
+
+	// プログラムを指定しないワークアウトではどのプログラムも進まない
+	_, err = s.workoutTestSetup.usecase.RecordWorkout(context.Background(), RecordWorkoutInput{
+		UserID: userID,
+		Date:   testDate.AddDate(0, 0, 2),
+		Sets:   []SetInput{{ExerciseID: squat.ID, SetNumber: 1, Reps: 5, Weight: 85.0}},
+	})
+	if err != nil {
+		t.Fatalf("RecordWorkout() unexpected error = %v", err)
+	}
+	if program.CurrentDayIndex != 1 || unlinked.CurrentDayIndex != 0 {
+		t.Errorf("CurrentDayIndex after unlinked workout = %d, %d, want 1, 0", program.CurrentDayIndex, unlinked.CurrentDayIndex)
+	}
+}
+
+func TestWorkoutUsecase_RecordWorkout_ProgramErrors(t *testing.T) {
+	userID := uuid.New()
+	testDate := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		setup   func(s *programTestSetup, squat *entity.Exercise) uuid.UUID
+		wantErr error
+	}{
+		{
+			name: "異常系: 存在しないプログラム",
+			setup: func(s *programTestSetup, squat *entity.Exercise) uuid.UUID {
+				return uuid.New()
+			},
+			wantErr: ErrProgramNotFound,
+		},
+		{
+			name: "異常系: 他ユーザーのプログラム",
+			setup: func(s *programTestSetup, squat *entity.Exercise) uuid.UUID {
+				return s.programRepo.addProgram(uuid.New(), "Other",
+					entity.ProgramDay{Week: 1, Day: 1, Sets: []entity.ProgramSet{{ExerciseID: squat.ID, Reps: 5, PercentageOf1RM: 75}}},
+				).ID
+			},
+			wantErr: ErrProgramAccessDenied,
+		},
+		{
+			name: "異常系: 全日程を終えたプログラム",
+			setup: func(s *programTestSetup, squat *entity.Exercise) uuid.UUID {
+				program := s.programRepo.addProgram(userID, "Done",
+					entity.ProgramDay{Week: 1, Day: 1, Sets: []entity.ProgramSet{{ExerciseID: squat.ID, Reps: 5, PercentageOf1RM: 75}}},
+				)
+				program.CurrentDayIndex = 1
+				return program.ID
+			},
+			wantErr: ErrProgramCompleted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newProgramTestSetup()
+			squat := s.exerciseRepo.addExercise("スクワット", nil, nil)
+			programID := tt.setup(s, squat)
+
+			_, err := s.workoutTestSetup.usecase.RecordWorkout(context.Background(), RecordWorkoutInput{
+				UserID:    userID,
+				Date:      testDate,
+				Sets:      []SetInput{{ExerciseID: squat.ID, SetNumber: 1, Reps: 5, Weight: 80.0}},
+				ProgramID: &programID,
+			})
+			if err != tt.wantErr {
+				t.Fatalf("RecordWorkout() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(s.workoutRepo.workouts) != 0 {
+				t.Errorf("workouts = %d, want 0", len(s.workoutRepo.workouts))
+			}
+		})
+	}
+}
+
+func TestWorkoutUsecase_SetChanges_AdvanceLinkedProgram(t *testing.T) {
+	userID := uuid.New()
+	testDate := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
+	warmUp := entity.SetTypeWarmUp
+	working := entity.SetTypeWorking
+
+	t.Run("正常系: セット追加でその日を満たすと進み、再度の追加では進まない", func(t *testing.T) {
+		s := newProgramTestSetup()
+		squat := s.exerciseRepo.addExercise("スクワット", nil, nil)
+		bench := s.exerciseRepo.addExercise("ベンチプレス", nil, nil)
+		program := s.programRepo.addProgram(userID, "Linear",
+			entity.ProgramDay{Week: 1, Day: 1, Sets: []entity.ProgramSet{
+				{ExerciseID: squat.ID, Reps: 5, PercentageOf1RM: 75},
+				{ExerciseID: bench.ID, Reps: 5, PercentageOf1RM: 75},
+			}},
+			entity.ProgramDay{Week: 1, Day: 2, Sets: []entity.ProgramSet{
+				{ExerciseID: squat.ID, Reps: 5, PercentageOf1RM: 80},
+				{ExerciseID: bench.ID, Reps: 5, PercentageOf1RM: 80},
+			}},
+		)
+
+		output, err := s.workoutTestSetup.usecase.RecordWorkout(context.Background(), RecordWorkoutInput{
+			UserID:    userID,
+			Date:      testDate,
+			Sets:      []SetInput{{ExerciseID: squat.ID, SetNumber: 1, Reps: 5, Weight: 80.0}},
+			ProgramID: &program.ID,
+		})
+		if err != nil {
+			t.Fatalf("RecordWorkout() unexpected error = %v", err)
+		}
+		if program.CurrentDayIndex != 0 {
+			t.Fatalf("CurrentDayIndex after partial workout = %d, want 0", program.CurrentDayIndex)
+		}
+
+		_, err = s.workoutTestSetup.usecase.AddWorkoutSets(context.Background(), userID, output.Workout.ID, []SetInput{
+			{ExerciseID: bench.ID, SetNumber: 1, Reps: 5, Weight: 60.0},
+		})
+		if err != nil {
+			t.Fatalf("AddWorkoutSets() unexpected error = %v", err)
+		}
+		if program.CurrentDayIndex != 1 {
+			t.Errorf("CurrentDayIndex after AddWorkoutSets = %d, want 1", program.CurrentDayIndex)
+		}
+
+		// 2日目の種目を満たすセットを同じワークアウトに追加しても、1日目として記録したワークアウトなので進まない
+		_, err = s.workoutTestSetup.usecase.AddWorkoutSets(context.Background(), userID, output.Workout.ID, []SetInput{
+			{ExerciseID: squat.ID, SetNumber: 2, Reps: 5, Weight: 85.0},
+			{ExerciseID: bench.ID, SetNumber: 2, Reps: 5, Weight: 65.0},
+		})
+		if err != nil {
+			t.Fatalf("AddWorkoutSets() unexpected error = %v", err)
+		}
+		if program.CurrentDayIndex != 1 {
+			t.Errorf("CurrentDayIndex after second AddWorkoutSets = %d, want 1", program.CurrentDayIndex)
+		}
+	})
+
+	t.Run("正常系: ウォームアップから本セットへの更新でその日を満たすと進む", func(t *testing.T) {
+		s := newProgramTestSetup()
+		squat := s.exerciseRepo.addExercise("スクワット", nil, nil)
+		bench := s.exerciseRepo.addExercise("ベンチプレス", nil, nil)
+		program := s.programRepo.addProgram(userID, "Linear",
+			entity.ProgramDay{Week: 1, Day: 1, Sets: []entity.ProgramSet{
+				{ExerciseID: squat.ID, Reps: 5, PercentageOf1RM: 75},
+				{ExerciseID: bench.ID, Reps: 5, PercentageOf1RM: 75},
+			}},
+		)
+
+		// ウォームアップセットのみの種目はその日を満たさない
+		output, err := s.workoutTestSetup.usecase.RecordWorkout(context.Background(), RecordWorkoutInput{
+			UserID: userID,
+			Date:   testDate,
+			Sets: []SetInput{
+				{ExerciseID: squat.ID, SetNumber: 1, Reps: 5, Weight: 80.0},
+				{ExerciseID: bench.ID, SetNumber: 1, Reps: 5, Weight: 60.0, SetType: &warmUp},
+			},
+			ProgramID: &program.ID,
+		})
+		if err != nil {
+			t.Fatalf("RecordWorkout() unexpected error = %v", err)
+		}
+		if program.CurrentDayIndex != 0 {
+			t.Fatalf("CurrentDayIndex with warm-up only exercise = %d, want 0", program.CurrentDayIndex)
+		}
+
+		_, err = s.workoutTestSetup.usecase.UpdateWorkoutSet(context.Background(), userID, output.Sets[1].ID, UpdateWorkoutSetInput{
+			SetType: &working,
+		})
+		if err != nil {
+			t.Fatalf("UpdateWorkoutSet() unexpected error = %v", err)
+		}
+		if program.CurrentDayIndex != 1 {
+			t.Errorf("CurrentDayIndex after UpdateWorkoutSet = %d, want 1", program.CurrentDayIndex)
+		}
+	})
+}
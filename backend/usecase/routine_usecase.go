@@ -0,0 +1,315 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+var (
+	// ErrRoutineNotFound はルーティンが見つからない場合のエラー
+	ErrRoutineNotFound = errors.New("routine not found")
+	// ErrRoutineAccessDenied はルーティンへのアクセスが拒否された場合のエラー
+	ErrRoutineAccessDenied = errors.New("access denied to this routine")
+)
+
+// RoutineExerciseInput はルーティンに含める種目の目標の入力データを表す。
+type RoutineExerciseInput struct {
+	ExerciseID   uuid.UUID
+	TargetSets   int32
+	TargetReps   int32
+	TargetWeight float64
+}
+
+// CreateRoutineInput はルーティン作成の入力データを表す。
+type CreateRoutineInput struct {
+	Name        string
+	Description *string
+	Exercises   []RoutineExerciseInput
+}
+
+// UpdateRoutineInput はルーティン更新の入力データを表す。
+// nilのフィールドは更新しない。Exercisesを指定した場合は種目一覧全体を置き換える。
+type UpdateRoutineInput struct {
+	Name        *string
+	Description *string
+	Exercises   []RoutineExerciseInput
+}
+
+// RoutineUsecaseInterface はRoutineUsecaseのインターフェース。
+// テスト時のモック作成に使用する。
+type RoutineUsecaseInterface interface {
+	CreateRoutine(ctx context.Context, userID uuid.UUID, input CreateRoutineInput) (*entity.Routine, error)
+	GetRoutine(ctx context.Context, userID, routineID uuid.UUID) (*entity.Routine, error)
+	ListRoutines(ctx context.Context, userID uuid.UUID) ([]*entity.Routine, error)
+	UpdateRoutine(ctx context.Context, userID, routineID uuid.UUID, input UpdateRoutineInput) (*entity.Routine, error)
+	DeleteRoutine(ctx context.Context, userID, routineID uuid.UUID) error
+	StartRoutine(ctx context.Context, userID, routineID uuid.UUID, date time.Time, memo *string) (*RecordWorkoutOutput, error)
+}
+
+// RoutineUsecase はルーティン（ワークアウトのテンプレート）に関するビジネスロジックを提供する。
+// ルーティンの作成、取得、一覧、更新、削除と、ルーティンからのワークアウト開始のユースケースを実装する。
+type RoutineUsecase struct {
+	routineRepo    repository.RoutineRepository
+	exerciseRepo   repository.ExerciseRepository
+	workoutUsecase WorkoutUsecaseInterface
+	txManager      repository.TransactionManager
+}
+
+// NewRoutineUsecase はRoutineUsecaseの新しいインスタンスを生成する。
+//
+// パラメータ:
+//   - routineRepo: ルーティンデータの永続化を担当するリポジトリ
+//   - exerciseRepo: エクササイズの存在確認に使用するリポジトリ
+//   - workoutUsecase: ルーティンからワークアウトを記録するためのユースケース
+//   - txManager: ルーティンと種目の書き込みを1トランザクションにまとめるトランザクションマネージャー
+//
+// 戻り値:
+//   - *RoutineUsecase: 生成されたRoutineUsecaseインスタンス
+func NewRoutineUsecase(
+	routineRepo repository.RoutineRepository,
+	exerciseRepo repository.ExerciseRepository,
+	workoutUsecase WorkoutUsecaseInterface,
+	txManager repository.TransactionManager,
+) *RoutineUsecase {
+	return &RoutineUsecase{
+		routineRepo:    routineRepo,
+		exerciseRepo:   exerciseRepo,
+		workoutUsecase: workoutUsecase,
+		txManager:      txManager,
+	}
+}
+
+// CreateRoutine は新しいルーティンを作成する。
+// 種目の存在確認とバリデーションを実施し、ルーティンと種目を1トランザクションで永続化する。
+//
+// パラメータ:
+//   - ctx: リクエストのコンテキスト
+//   - userID: ルーティンを所有するユーザーのID
+//   - input: ルーティン作成の入力データ
+//
+// 戻り値:
+//   - *entity.Routine: 作成されたルーティンエンティティ
+//   - error: 以下のエラーが返される可能性がある
+//     - ErrExerciseNotFound: 指定されたエクササイズが存在しない
+//     - entity.ErrInvalidRoutineName: ルーティン名が不正
+//     - entity.ErrEmptyRoutineExercises: 種目が空
+//     - entity.ErrInvalidTargetSets: 目標セット数が不正
+//     - entity.ErrInvalidTargetReps: 目標レップ数が不正
+//     - entity.ErrInvalidTargetWeight: 目標重量が不正
+//     - その他のリポジトリエラー
+func (u *RoutineUsecase) CreateRoutine(ctx context.Context, userID uuid.UUID, input CreateRoutineInput) (*entity.Routine, error) {
+	exercises, err := u.toRoutineExercises(ctx, userID, input.Exercises)
+	if err != nil {
+		return nil, err
+	}
+
+	routine, err := entity.NewRoutine(userID, input.Name, input.Description, exercises)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		return u.routineRepo.Create(ctx, routine)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return routine, nil
+}
+
+// GetRoutine はルーティンの詳細を取得する。
+//
+// パラメータ:
+//   - ctx: リクエストのコンテキスト
+//   - userID: リクエスト元のユーザーID
+//   - routineID: 取得するルーティンのID
+//
+// 戻り値:
+//   - *entity.Routine: 取得したルーティンエンティティ
+//   - error: 以下のエラーが返される可能性がある
+//     - ErrRoutineNotFound: ルーティンが存在しない
+//     - ErrRoutineAccessDenied: アクセス権がない
+//     - その他のリポジトリエラー
+func (u *RoutineUsecase) GetRoutine(ctx context.Context, userID, routineID uuid.UUID) (*entity.Routine, error) {
+	return u.getRoutineWithOwnershipCheck(ctx, userID, routineID)
+}
+
+// ListRoutines はユーザーのルーティン一覧を取得する。
+//
+// パラメータ:
+//   - ctx: リクエストのコンテキスト
+//   - userID: ユーザーID
+//
+// 戻り値:
+//   - []*entity.Routine: ルーティンのリスト
+//   - error: リポジトリエラー
+func (u *RoutineUsecase) ListRoutines(ctx context.Context, userID uuid.UUID) ([]*entity.Routine, error) {
+	return u.routineRepo.FindByUserID(ctx, userID)
+}
+
+// UpdateRoutine はルーティンを更新する。
+// nilのフィールドは更新しない。種目を指定した場合は種目一覧全体を置き換える。
+//
+// パラメータ:
+//   - ctx: リクエストのコンテキスト
+//   - userID: リクエスト元のユーザーID
+//   - routineID: 更新するルーティンのID
+//   - input: ルーティン更新の入力データ
+//
+// 戻り値:
+//   - *entity.Routine: 更新されたルーティンエンティティ
+//   - error: 以下のエラーが返される可能性がある
+//     - ErrRoutineNotFound: ルーティンが存在しない
+//     - ErrRoutineAccessDenied: アクセス権がない
+//     - ErrExerciseNotFound: 指定されたエクササイズが存在しない
+//     - entity.ErrInvalidRoutineName: ルーティン名が不正
+//     - entity.ErrEmptyRoutineExercises: 種目が空
+//     - entity.ErrInvalidTargetSets: 目標セット数が不正
+//     - entity.ErrInvalidTargetReps: 目標レップ数が不正
+//     - entity.ErrInvalidTargetWeight: 目標重量が不正
+//     - その他のリポジトリエラー
+func (u *RoutineUsecase) UpdateRoutine(ctx context.Context, userID, routineID uuid.UUID, input UpdateRoutineInput) (*entity.Routine, error) {
+	routine, err := u.getRoutineWithOwnershipCheck(ctx, userID, routineID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		if err := routine.UpdateName(*input.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.Description != nil {
+		routine.UpdateDescription(input.Description)
+	}
+
+	if input.Exercises != nil {
+		exercises, err := u.toRoutineExercises(ctx, userID, input.Exercises)
+		if err != nil {
+			return nil, err
+		}
+		if err := routine.ReplaceExercises(exercises); err != nil {
+			return nil, err
+		}
+	}
+
+	err = u.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		return u.routineRepo.Update(ctx, routine)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return routine, nil
+}
+
+// DeleteRoutine はルーティンを削除する。
+// ルーティンから開始した既存のワークアウトは削除されない。
+//
+// パラメータ:
+//   - ctx: リクエストのコンテキスト
+//   - userID: リクエスト元のユーザーID
+//   - routineID: 削除するルーティンのID
+//
+// 戻り値:
+//   - error: 以下のエラーが返される可能性がある
+//     - ErrRoutineNotFound: ルーティンが存在しない
+//     - ErrRoutineAccessDenied: アクセス権がない
+//     - その他のリポジトリエラー
+func (u *RoutineUsecase) DeleteRoutine(ctx context.Context, userID, routineID uuid.UUID) error {
+	if _, err := u.getRoutineWithOwnershipCheck(ctx, userID, routineID); err != nil {
+		return err
+	}
+
+	return u.routineRepo.Delete(ctx, routineID)
+}
+
+// StartRoutine はルーティンの目標からセットを組み立て、指定日のワークアウトとして記録する。
+// 各種目について目標セット数分のセットを目標レップ数・重量で作成し、
+// 記録処理はWorkoutUsecase.RecordWorkoutに委譲する（デイリースコア・自己ベストも同様に計算される）。
+//
+// パラメータ:
+//   - ctx: リクエストのコンテキスト
+//   - userID: リクエスト元のユーザーID
+//   - routineID: 開始するルーティンのID
+//   - date: ワークアウトの日付
+//   - memo: ワークアウトのメモ（省略可）
+//
+// 戻り値:
+//   - *RecordWorkoutOutput: 作成されたワークアウト、セット、更新された自己ベスト
+//   - error: 以下のエラーが返される可能性がある
+//     - ErrRoutineNotFound: ルーティンが存在しない
+//     - ErrRoutineAccessDenied: アクセス権がない
+//     - RecordWorkoutが返すエラー（ErrExerciseNotFound など）
+func (u *RoutineUsecase) StartRoutine(ctx context.Context, userID, routineID uuid.UUID, date time.Time, memo *string) (*RecordWorkoutOutput, error) {
+	routine, err := u.getRoutineWithOwnershipCheck(ctx, userID, routineID)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.workoutUsecase.RecordWorkout(ctx, RecordWorkoutInput{
+		UserID: userID,
+		Date:   date,
+		Memo:   memo,
+		Sets:   toRoutineSetInputs(routine),
+	})
+}
+
+// getRoutineWithOwnershipCheck はルーティンを取得し、オーナーシップを確認する。
+func (u *RoutineUsecase) getRoutineWithOwnershipCheck(ctx context.Context, userID, routineID uuid.UUID) (*entity.Routine, error) {
+	routine, err := u.routineRepo.FindByID(ctx, routineID)
+	if err != nil || routine == nil {
+		return nil, ErrRoutineNotFound
+	}
+
+	if !routine.IsOwnedBy(userID) {
+		return nil, ErrRoutineAccessDenied
+	}
+
+	return routine, nil
+}
+
+// toRoutineExercises は入力データを検証し、RoutineExerciseのスライスに変換する。
+// 他ユーザーのカスタム種目は存在しないものとして扱う。
+func (u *RoutineUsecase) toRoutineExercises(ctx context.Context, userID uuid.UUID, inputs []RoutineExerciseInput) ([]entity.RoutineExercise, error) {
+	exercises := make([]entity.RoutineExercise, 0, len(inputs))
+	for _, in := range inputs {
+		exercise, err := u.exerciseRepo.FindByID(ctx, in.ExerciseID)
+		if err != nil || exercise == nil || !exercise.IsVisibleTo(userID) {
+			return nil, ErrExerciseNotFound
+		}
+
+		re, err := entity.NewRoutineExercise(in.ExerciseID, in.TargetSets, in.TargetReps, in.TargetWeight)
+		if err != nil {
+			return nil, err
+		}
+		exercises = append(exercises, re)
+	}
+	return exercises, nil
+}
+
+// toRoutineSetInputs はルーティンの目標からワークアウト記録用のセット入力を組み立てる。
+// セット番号は種目ごとに1から振り、同じ種目がルーティン内に複数回現れる場合は続き番号とする。
+func toRoutineSetInputs(routine *entity.Routine) []SetInput {
+	var sets []SetInput
+	setNumbers := make(map[uuid.UUID]int32)
+	for _, re := range routine.Exercises {
+		for i := int32(0); i < re.TargetSets; i++ {
+			setNumbers[re.ExerciseID]++
+			sets = append(sets, SetInput{
+				ExerciseID: re.ExerciseID,
+				SetNumber:  setNumbers[re.ExerciseID],
+				Reps:       re.TargetReps,
+				Weight:     re.TargetWeight,
+			})
+		}
+	}
+	return sets
+}
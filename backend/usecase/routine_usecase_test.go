@@ -0,0 +1,521 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+)
+
+// mockRoutineRepository はRoutineRepositoryのモック実装
+type mockRoutineRepository struct {
+	routines map[uuid.UUID]*entity.Routine
+	err      error
+}
+
+func newMockRoutineRepository() *mockRoutineRepository {
+	return &mockRoutineRepository{
+		routines: make(map[uuid.UUID]*entity.Routine),
+	}
+}
+
+func (m *mockRoutineRepository) Create(ctx context.Context, routine *entity.Routine) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.routines[routine.ID] = routine
+	return nil
+}
+
+func (m *mockRoutineRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Routine, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	routine, ok := m.routines[id]
+	if !ok {
+		return nil, errors.New("routine not found")
+	}
+	return routine, nil
+}
+
+func (m *mockRoutineRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Routine, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var result []*entity.Routine
+	for _, routine := range m.routines {
+		if routine.UserID == userID {
+			result = append(result, routine)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockRoutineRepository) Update(ctx context.Context, routine *entity.Routine) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.routines[routine.ID] = routine
+	return nil
+}
+
+func (m *mockRoutineRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if m.err != nil {
+		return m.err
+	}
+	delete(m.routines, id)
+	return nil
+}
+
+// テストヘルパー: リポジトリにルーティンを追加
+func (m *mockRoutineRepository) addRoutine(userID uuid.UUID, name string, exercises ...entity.RoutineExercise) *entity.Routine {
+	routine, _ := entity.NewRoutine(userID, name, nil, exercises)
+	m.routines[routine.ID] = routine
+	return routine
+}
+
+// Ensure mockRoutineRepository implements repository.RoutineRepository
+var _ repository.RoutineRepository = (*mockRoutineRepository)(nil)
+
+// テスト用のセットアップヘルパー。
+// StartRoutineの検証のため、ワークアウト記録は実際のWorkoutUsecaseに委譲する。
+type routineTestSetup struct {
+	*workoutTestSetup
+	routineRepo *mockRoutineRepository
+	usecase     *RoutineUsecase
+}
+
+func newRoutineTestSetup() *routineTestSetup {
+	ws := newWorkoutTestSetup()
+	routineRepo := newMockRoutineRepository()
+	return &routineTestSetup{
+		workoutTestSetup: ws,
+		routineRepo:      routineRepo,
+		usecase:          NewRoutineUsecase(routineRepo, ws.exerciseRepo, ws.usecase, ws.txManager),
+	}
+}
+
+func TestRoutineUsecase_CreateRoutine(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		setup   func(*routineTestSetup) CreateRoutineInput
+		wantErr error
+	}{
+		{
+			name: "正常系: ルーティン作成成功",
+			setup: func(s *routineTestSetup) CreateRoutineInput {
+				bench := s.exerciseRepo.addExercise("ベンチプレス", nil, nil)
+				dips := s.exerciseRepo.addUserExercise(userID, "ディップス", nil, nil)
+				return CreateRoutineInput{
+					Name:        "Push",
+					Description: strPtr("胸・三頭"),
+					Exercises: []RoutineExerciseInput{
+						{ExerciseID: bench.ID, TargetSets: 5, TargetReps: 5, TargetWeight: 80.0},
+						{ExerciseID: dips.ID, TargetSets: 3, TargetReps: 12, TargetWeight: 0},
+					},
+				}
+			},
+		},
+		{
+			name: "異常系: 存在しないエクササイズ",
+			setup: func(s *routineTestSetup) CreateRoutineInput {
+				return CreateRoutineInput{
+					Name:      "Push",
+					Exercises: []RoutineExerciseInput{{ExerciseID: uuid.New(), TargetSets: 3, TargetReps: 10}},
+				}
+			},
+			wantErr: ErrExerciseNotFound,
+		},
+		{
+			name: "異常系: 他ユーザーのカスタム種目",
+			setup: func(s *routineTestSetup) CreateRoutineInput {
+				other := s.exerciseRepo.addUserExercise(uuid.New(), "他人の種目", nil, nil)
+				return CreateRoutineInput{
+					Name:      "Push",
+					Exercises: []RoutineExerciseInput{{ExerciseID: other.ID, TargetSets: 3, TargetReps: 10}},
+				}
+			},
+			wantErr: ErrExerciseNotFound,
+		},
+		{
+			name: "異常系: 種目が空",
+			setup: func(s *routineTestSetup) CreateRoutineInput {
+				return CreateRoutineInput{Name: "Push"}
+			},
+			wantErr: entity.ErrEmptyRoutineExercises,
+		},
+		{
+			name: "異常系: 目標セット数が不正",
+			setup: func(s *routineTestSetup) CreateRoutineInput {
+				bench := s.exerciseRepo.addExercise("ベンチプレス", nil, nil)
+				return CreateRoutineInput{
+					Name:      "Push",
+					Exercises: []RoutineExerciseInput{{ExerciseID: bench.ID, TargetSets: 0, TargetReps: 10}},
+				}
+			},
+			wantErr: entity.ErrInvalidTargetSets,
+		},
+		{
+			name: "異常系: ルーティン名が空",
+			setup: func(s *routineTestSetup) CreateRoutineInput {
+				bench := s.exerciseRepo.addExercise("ベンチプレス", nil, nil)
+				return CreateRoutineInput{
+					Name:      "",
+					Exercises: []RoutineExerciseInput{{ExerciseID: bench.ID, TargetSets: 3, TargetReps: 10}},
+				}
+			},
+			wantErr: entity.ErrInvalidRoutineName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newRoutineTestSetup()
+			input := tt.setup(s)
+
+			routine, err := s.usecase.CreateRoutine(context.Background(), userID, input)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("CreateRoutine() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(s.routineRepo.routines) != 0 {
+					t.Errorf("CreateRoutine() saved %d routines on error, want 0", len(s.routineRepo.routines))
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("CreateRoutine() unexpected error = %v", err)
+			}
+			if routine.UserID != userID {
+				t.Errorf("UserID = %v, want %v", routine.UserID, userID)
+			}
+			if len(routine.Exercises) != len(input.Exercises) {
+				t.Errorf("Exercises length = %d, want %d", len(routine.Exercises), len(input.Exercises))
+			}
+			if _, ok := s.routineRepo.routines[routine.ID]; !ok {
+				t.Error("CreateRoutine() did not save the routine")
+			}
+			if s.txManager.commits != 1 {
+				t.Errorf("commits = %d, want 1", s.txManager.commits)
+			}
+		})
+	}
+}
+
+func TestRoutineUsecase_GetRoutine(t *testing.T) {
+	userID := uuid.New()
+	exercise := entity.RoutineExercise{ExerciseID: uuid.New(), TargetSets: 3, TargetReps: 10, TargetWeight: 50.0}
+
+	tests := []struct {
+		name    string
+		setup   func(*routineTestSetup) uuid.UUID
+		wantErr error
+	}{
+		{
+			name: "正常系: 取得成功",
+			setup: func(s *routineTestSetup) uuid.UUID {
+				return s.routineRepo.addRoutine(userID, "Push", exercise).ID
+			},
+		},
+		{
+			name: "異常系: 存在しないルーティン",
+			setup: func(s *routineTestSetup) uuid.UUID {
+				return uuid.New()
+			},
+			wantErr: ErrRoutineNotFound,
+		},
+		{
+			name: "異常系: 他ユーザーのルーティン",
+			setup: func(s *routineTestSetup) uuid.UUID {
+				return s.routineRepo.addRoutine(uuid.New(), "Push", exercise).ID
+			},
+			wantErr: ErrRoutineAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newRoutineTestSetup()
+			routineID := tt.setup(s)
+
+			routine, err := s.usecase.GetRoutine(context.Background(), userID, routineID)
+
+			if tt.wantErr != nil {
+				if err != tt.wantErr {
+					t.Errorf("GetRoutine() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetRoutine() unexpected error = %v", err)
+			}
+			if routine.ID != routineID {
+				t.Errorf("ID = %v, want %v", routine.ID, routineID)
+			}
+		})
+	}
+}
+
+func TestRoutineUsecase_ListRoutines(t *testing.T) {
+	userID := uuid.New()
+	exercise := entity.RoutineExercise{ExerciseID: uuid.New(), TargetSets: 3, TargetReps: 10, TargetWeight: 50.0}
+
+	s := newRoutineTestSetup()
+	s.routineRepo.addRoutine(userID, "Push", exercise)
+	s.routineRepo.addRoutine(userID, "Pull", exercise)
+	s.routineRepo.addRoutine(uuid.New(), "Legs", exercise)
+
+	routines, err := s.usecase.ListRoutines(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("ListRoutines() unexpected error = %v", err)
+	}
+	if len(routines) != 2 {
+		t.Errorf("ListRoutines() count = %d, want 2", len(routines))
+	}
+}
+
+func TestRoutineUsecase_UpdateRoutine(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		setup   func(*routineTestSetup) (uuid.UUID, UpdateRoutineInput)
+		check   func(*testing.T, *entity.Routine)
+		wantErr error
+	}{
+		{
+			name: "正常系: 名前のみ更新（種目は変更なし）",
+			setup: func(s *routineTestSetup) (uuid.UUID, UpdateRoutineInput) {
+				bench := s.exerciseRepo.addExercise("ベンチプレス", nil, nil)
+				routine := s.routineRepo.addRoutine(userID, "Push", entity.RoutineExercise{ExerciseID: bench.ID, TargetSets: 3, TargetReps: 10, TargetWeight: 60.0})
+				return routine.ID, UpdateRoutineInput{Name: strPtr("Push A")}
+			},
+			check: func(t *testing.T, r *entity.Routine) {
+				if r.Name != "Push A" {
+					t.Errorf("Name = %v, want Push A", r.Name)
+				}
+				if len(r.Exercises) != 1 {
+					t.Errorf("Exercises length = %d, want 1", len(r.Exercises))
+				}
+			},
+		},
+		{
+			name: "正常系: 種目を置き換え",
+			setup: func(s *routineTestSetup) (uuid.UUID, UpdateRoutineInput) {
+				bench := s.exerciseRepo.addExercise("ベンチプレス", nil, nil)
+				press := s.exerciseRepo.addExercise("ショルダープレス", nil, nil)
+				routine := s.routineRepo.addRoutine(userID, "Push", entity.RoutineExercise{ExerciseID: bench.ID, TargetSets: 3, TargetReps: 10, TargetWeight: 60.0})
+				return routine.ID, UpdateRoutineInput{Exercises: []RoutineExerciseInput{
+					{ExerciseID: press.ID, TargetSets: 4, TargetReps: 8, TargetWeight: 40.0},
+					{ExerciseID: bench.ID, TargetSets: 5, TargetReps: 5, TargetWeight: 80.0},
+				}}
+			},
+			check: func(t *testing.T, r *entity.Routine) {
+				if len(r.Exercises) != 2 || r.Exercises[1].TargetWeight != 80.0 {
+					t.Errorf("Exercises = %+v, want press then bench", r.Exercises)
+				}
+			},
+		},
+		{
+			name: "異常系: 他ユーザーのルーティン",
+			setup: func(s *routineTestSetup) (uuid.UUID, UpdateRoutineInput) {
+				routine := s.routineRepo.addRoutine(uuid.New(), "Push", entity.RoutineExercise{ExerciseID: uuid.New(), TargetSets: 3, TargetReps: 10})
+				return routine.ID, UpdateRoutineInput{Name: strPtr("Mine")}
+			},
+			wantErr: ErrRoutineAccessDenied,
+		},
+		{
+			name: "異常系: 存在しないエクササイズに置き換え",
+			setup: func(s *routineTestSetup) (uuid.UUID, UpdateRoutineInput) {
+				routine := s.routineRepo.addRoutine(userID, "Push", entity.RoutineExercise{ExerciseID: uuid.New(), TargetSets: 3, TargetReps: 10})
+				return routine.ID, UpdateRoutineInput{Exercises: []RoutineExerciseInput{{ExerciseID: uuid.New(), TargetSets: 3, TargetReps: 10}}}
+			},
+			wantErr: ErrExerciseNotFound,
+		},
+		{
+			name: "異常系: 種目を空に置き換え",
+			setup: func(s *routineTestSetup) (uuid.UUID, UpdateRoutineInput) {
+				routine := s.routineRepo.addRoutine(userID, "Push", entity.RoutineExercise{ExerciseID: uuid.New(), TargetSets: 3, TargetReps: 10})
+				return routine.ID, UpdateRoutineInput{Exercises: []RoutineExerciseInput{}}
+			},
+			wantErr: entity.ErrEmptyRoutineExercises,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newRoutineTestSetup()
+			routineID, input := tt.setup(s)
+
+			routine, err := s.usecase.UpdateRoutine(context.Background(), userID, routineID, input)
+
+			if tt.wantErr != nil {
+				if err != tt.wantErr {
+					t.Errorf("UpdateRoutine() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateRoutine() unexpected error = %v", err)
+			}
+			tt.check(t, routine)
+		})
+	}
+}
+
+func TestRoutineUsecase_DeleteRoutine(t *testing.T) {
+	userID := uuid.New()
+	exercise := entity.RoutineExercise{ExerciseID: uuid.New(), TargetSets: 3, TargetReps: 10}
+
+	tests := []struct {
+		name    string
+		setup   func(*routineTestSetup) uuid.UUID
+		wantErr error
+	}{
+		{
+			name: "正常系: 削除成功",
+			setup: func(s *routineTestSetup) uuid.UUID {
+				return s.routineRepo.addRoutine(userID, "Push", exercise).ID
+			},
+		},
+		{
+			name: "異常系: 存在しないルーティン",
+			setup: func(s *routineTestSetup) uuid.UUID {
+				return uuid.New()
+			},
+			wantErr: ErrRoutineNotFound,
+		},
+		{
+			name: "異常系: 他ユーザーのルーティン",
+			setup: func(s *routineTestSetup) uuid.UUID {
+				return s.routineRepo.addRoutine(uuid.New(), "Push", exercise).ID
+			},
+			wantErr: ErrRoutineAccessDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newRoutineTestSetup()
+			routineID := tt.setup(s)
+
+			err := s.usecase.DeleteRoutine(context.Background(), userID, routineID)
+
+			if err != tt.wantErr {
+				t.Errorf("DeleteRoutine() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil {
+				if _, ok := s.routineRepo.routines[routineID]; ok {
+					t.Error("DeleteRoutine() did not delete the routine")
+				}
+			}
+		})
+	}
+}
+
+func TestRoutineUsecase_StartRoutine(t *testing.T) {
+	userID := uuid.New()
+	testDate := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常系: ルーティンの目標からワークアウトを記録", func(t *testing.T) {
+		s := newRoutineTestSetup()
+		bench := s.exerciseRepo.addExercise("ベンチプレス", nil, nil)
+		dips := s.exerciseRepo.addExercise("ディップス", nil, nil)
+		routine := s.routineRepo.addRoutine(userID, "Push",
+			entity.RoutineExercise{ExerciseID: bench.ID, TargetSets: 3, TargetReps: 5, TargetWeight: 80.0},
+			entity.RoutineExercise{ExerciseID: dips.ID, TargetSets: 2, TargetReps: 12, TargetWeight: 0},
+			entity.RoutineExercise{ExerciseID: bench.ID, TargetSets: 1, TargetReps: 10, TargetWeight: 60.0},
+		)
+
+		output, err := s.usecase.StartRoutine(context.Background(), userID, routine.ID, testDate, strPtr("Push day"))
+		if err != nil {
+			t.Fatalf("StartRoutine() unexpected error = %v", err)
+		}
+
+		if output.Workout.UserID != userID || !output.Workout.Date.Equal(testDate) {
+			t.Errorf("Workout = %+v, unexpected user or date", output.Workout)
+		}
+		if output.Workout.Memo == nil || *output.Workout.Memo != "Push day" {
+			t.Errorf("Workout.Memo = %v, want Push day", output.Workout.Memo)
+		}
+		if len(output.Sets) != 6 {
+			t.Fatalf("Sets length = %d, want 6", len(output.Sets))
+		}
+
+		// 同じ種目が複数回現れる場合はセット番号が続き番号になる
+		wantSets := []struct {
+			exerciseID uuid.UUID
+			setNumber  int32
+			reps       int32
+			weight     float64
+		}{
+			{bench.ID, 1, 5, 80.0},
+			{bench.ID, 2, 5, 80.0},
+			{bench.ID, 3, 5, 80.0},
+			{dips.ID, 1, 12, 0},
+			{dips.ID, 2, 12, 0},
+			{bench.ID, 4, 10, 60.0},
+		}
+		for i, want := range wantSets {
+			got := output.Sets[i]
+			if got.ExerciseID != want.exerciseID || got.SetNumber != want.setNumber || got.Reps != want.reps || got.Weight != want.weight {
+				t.Errorf("Sets[%d] = {%v %d %d %v}, want %+v", i, got.ExerciseID, got.SetNumber, got.Reps, got.Weight, want)
+			}
+		}
+
+		if output.Workout.DailyScore == 0 {
+			t.Error("DailyScore should be calculated")
+		}
+		if len(output.PersonalRecords) == 0 {
+			t.Error("PersonalRecords should be detected for first-time sets")
+		}
+	})
+
+	t.Run("異常系: 他ユーザーのルーティン", func(t *testing.T) {
+		s := newRoutineTestSetup()
+		bench := s.exerciseRepo.addExercise("ベンチプレス", nil, nil)
+		routine := s.routineRepo.addRoutine(uuid.New(), "Push", entity.RoutineExercise{ExerciseID: bench.ID, TargetSets: 3, TargetReps: 5, TargetWeight: 80.0})
+
+		_, err := s.usecase.StartRoutine(context.Background(), userID, routine.ID, testDate, nil)
+		if err != ErrRoutineAccessDenied {
+			t.Errorf("StartRoutine() error = %v, want %v", err, ErrRoutineAccessDenied)
+		}
+		if len(s.workoutRepo.workouts) != 0 {
+			t.Errorf("workouts = %d, want 0", len(s.workoutRepo.workouts))
+		}
+	})
+
+	t.Run("正常系: 同日に2回目のセッションとして記録", func(t *testing.T) {
+		s := newRoutineTestSetup()
+		bench := s.exerciseRepo.addExercise("ベンチプレス", nil, nil)
+		routine := s.routineRepo.addRoutine(userID, "Push", entity.RoutineExercise{ExerciseID: bench.ID, TargetSets: 3, TargetReps: 5, TargetWeight: 80.0})
+
+		if _, err := s.usecase.StartRoutine(context.Background(), userID, routine.ID, testDate, nil); err != nil {
+			t.Fatalf("StartRoutine() unexpected error = %v", err)
+		}
+		if _, err := s.usecase.StartRoutine(context.Background(), userID, routine.ID, testDate, nil); err != nil {
+			t.Fatalf("StartRoutine() second session unexpected error = %v", err)
+		}
+		if len(s.workoutRepo.workouts) != 2 {
+			t.Errorf("workouts = %d, want 2", len(s.workoutRepo.workouts))
+		}
+	})
+
+	t.Run("異常系: ルーティンの種目が削除済み", func(t *testing.T) {
+		s := newRoutineTestSetup()
+		routine := s.routineRepo.addRoutine(userID, "Push", entity.RoutineExercise{ExerciseID: uuid.New(), TargetSets: 3, TargetReps: 5, TargetWeight: 80.0})
+
+		_, err := s.usecase.StartRoutine(context.Background(), userID, routine.ID, testDate, nil)
+		if err != ErrExerciseNotFound {
+			t.Errorf("StartRoutine() error = %v, want %v", err, ErrExerciseNotFound)
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/entity"
+	"github.com/ucchy108/whiskey/backend/domain/repository"
+	"github.com/ucchy108/whiskey/backend/domain/service"
+)
+
+// StreakSummary はワークアウトの連続記録と今週の目標の進捗を表す（ドメインサービスの型のエイリアス）。
+type StreakSummary = service.StreakSummary
+
+// StreakStats は連続記録の計算結果と、計算に使用した設定をまとめたもの。
+type StreakStats struct {
+	StreakSummary
+	// RestDayTolerance は連続記録を途切れさせずに休める連続日数
+	RestDayTolerance int32
+}
+
+// StatsUsecaseInterface はStatsUsecaseのインターフェース。
+// テスト時のモック作成に使用する。
+type StatsUsecaseInterface interface {
+	GetStreaks(ctx context.Context, userID uuid.UUID) (*StreakStats, error)
+}
+
+// StatsUsecase はワークアウトの継続状況（連続記録・週の目標）に関するビジネスロジックを提供する。
+type StatsUsecase struct {
+	profileRepo   repository.ProfileRepository
+	streakService *service.StreakService
+	// now は現在時刻を返す関数（テストで時刻を固定するために差し替える）
+	now func() time.Time
+}
+
+// NewStatsUsecase はStatsUsecaseの新しいインスタンスを生成する。
+//
+// パラメータ:
+//   - profileRepo: 週の目標・休息日数・タイムゾーンの取得を担当するリポジトリ
+//   - streakService: 連続記録を計算するドメインサービス
+//
+// 戻り値:
+//   - *StatsUsecase: 生成されたStatsUsecaseインスタンス
+func NewStatsUsecase(profileRepo repository.ProfileRepository, streakService *service.StreakService) *StatsUsecase {
+	return &StatsUsecase{
+		profileRepo:   profileRepo,
+		streakService: streakService,
+		now:           time.Now,
+	}
+}
+
+// GetStreaks はユーザーの日単位・週単位の連続記録と今週の目標の進捗を取得する。
+// 週の目標・休息日数・「今日」の判定にはプロフィールの設定を使用し、
+// プロフィールが未作成の場合はデフォルト値とUTCを使用する。
+//
+// パラメータ:
+//   - ctx: リクエストのコンテキスト
+//   - userID: ユーザーID
+//
+// 戻り値:
+//   - *StreakStats: 連続記録と今週の目標の進捗
+//   - error: リポジトリエラー
+func (u *StatsUsecase) GetStreaks(ctx context.Context, userID uuid.UUID) (*StreakStats, error) {
+	weeklyGoal := entity.DefaultWeeklyWorkoutGoal
+	restDayTolerance := entity.DefaultRestDayTolerance
+	loc := time.UTC
+	profile, err := u.profileRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if profile != nil {
+		weeklyGoal = profile.WeeklyWorkoutGoal
+		restDayTolerance = profile.RestDayTolerance
+		loc = profile.Location()
+	}
+
+	today := entity.CalendarDate(u.now().In(loc))
+	summary, err := u.streakService.CalculateStreaks(ctx, userID, today, weeklyGoal, restDayTolerance)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StreakStats{
+		StreakSummary:    *summary,
+		RestDayTolerance: restDayTolerance,
+	}, nil
+}
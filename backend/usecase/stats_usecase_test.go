@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ucchy108/whiskey/backend/domain/service"
+)
+
+func TestStatsUsecase_GetStreaks(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2026, 2, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name         string
+		setup        func(profileRepo *mockProfileRepository, workoutRepo *mockWorkoutRepository, userID uuid.UUID)
+		now          time.Time
+		wantErr      bool
+		wantCurrent  int
+		wantGoal     int
+		wantDays     int
+		wantAchieved bool
+		wantRestDays int32
+	}{
+		{
+			name: "正常系: プロフィールの目標・休息日数・タイムゾーンを使用",
+			setup: func(profileRepo *mockProfileRepository, workoutRepo *mockWorkoutRepository, userID uuid.UUID) {
+				profile := profileRepo.addProfile(userID, "テストユーザー")
+				profile.UpdateTimezone("Asia/Tokyo")
+				profile.UpdateWeeklyWorkoutGoal(2)
+				profile.UpdateRestDayTolerance(0)
+				workoutRepo.addWorkout(userID, day(9))
+				workoutRepo.addWorkout(userID, day(10))
+				workoutRepo.addWorkout(userID, day(11))
+			},
+			// UTCでは2/10だが、東京では2/11
+			now:          time.Date(2026, 2, 10, 20, 0, 0, 0, time.UTC),
+			wantCurrent:  3,
+			wantGoal:     2,
+			wantDays:     3,
+			wantAchieved: true,
+			wantRestDays: 0,
+		},
+		{
+			name: "正常系: プロフィール未作成の場合はデフォルト値を使用",
+			setup: func(profileRepo *mockProfileRepository, workoutRepo *mockWorkoutRepository, userID uuid.UUID) {
+				workoutRepo.addWorkout(userID, day(8))
+				workoutRepo.addWorkout(userID, day(10))
+			},
+			now:          time.Date(2026, 2, 11, 12, 0, 0, 0, time.UTC),
+			wantCurrent:  2,
+			wantGoal:     3,
+			wantDays:     1,
+			wantAchieved: false,
+			wantRestDays: 1,
+		},
+		{
+			name: "異常系: プロフィールを取得できない場合はデフォルト値にせずエラーを返す",
+			setup: func(profileRepo *mockProfileRepository, workoutRepo *mockWorkoutRepository, userID uuid.UUID) {
+				profileRepo.err = errors.New("database error")
+				workoutRepo.addWorkout(userID, day(10))
+			},
+			now:     time.Date(2026, 2, 11, 12, 0, 0, 0, time.UTC),
+			wantErr: true,
+		},
+		{
+			name: "異常系: リポジトリエラー",
+			setup: func(profileRepo *mockProfileRepository, workoutRepo *mockWorkoutRepository, userID uuid.UUID) {
+				workoutRepo.err = errors.New("database error")
+			},
+			now:     time.Date(2026, 2, 11, 12, 0, 0, 0, time.UTC),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID := uuid.New()
+			profileRepo := newMockProfileRepository()
+			workoutRepo := newMockWorkoutRepository()
+			tt.setup(profileRepo, workoutRepo, userID)
+
+			uc := NewStatsUsecase(profileRepo, service.NewStreakService(workoutRepo))
+			uc.now = func() time.Time { return tt.now }
+
+			got, err := uc.GetStreaks(context.Background(), userID)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("GetStreaks() expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetStreaks() unexpected error = %v", err)
+			}
+
+			if got.CurrentDailyStreak != tt.wantCurrent {
+				t.Errorf("CurrentDailyStreak = %d, want %d", got.CurrentDailyStreak, tt.wantCurrent)
+			}
+			if got.ThisWeek.Goal != tt.wantGoal || got.ThisWeek.WorkoutDays != tt.wantDays || got.ThisWeek.Achieved != tt.wantAchieved {
+				t.Errorf("ThisWeek = %+v, want goal %d, workout days %d, achieved %v", got.ThisWeek, tt.wantGoal, tt.wantDays, tt.wantAchieved)
+			}
+			if got.RestDayTolerance != tt.wantRestDays {
+				t.Errorf("RestDayTolerance = %d, want %d", got.RestDayTolerance, tt.wantRestDays)
+			}
+		})
+	}
+}
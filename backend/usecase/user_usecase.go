@@ -22,27 +22,138 @@ var (
 	ErrEmailNotVerified = errors.New("email not verified")
 	// ErrInvalidVerificationToken は検証トークンが不正な場合のエラー
 	ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
+	// ErrInvalidPasswordResetToken はパスワードリセットトークンが不正・期限切れ・使用済みの場合のエラー
+	ErrInvalidPasswordResetToken = errors.New("invalid or expired password reset token")
+	// ErrInvalidEmailChangeToken はメールアドレス変更トークンが不正・期限切れ・使用済みの場合のエラー
+	ErrInvalidEmailChangeToken = errors.New("invalid or expired email change token")
+	// ErrSessionNotFound はセッションが存在しない、または他のユーザーのセッションの場合のエラー
+	ErrSessionNotFound = errors.New("session not found")
+	// ErrAccountLocked はログイン失敗が続いたためアカウントが一時的にロックされている場合のエラー
+	ErrAccountLocked = errors.New("account temporarily locked")
+	// ErrTooManyRequests は短時間に同じ操作が繰り返されたためレート制限された場合のエラー
+	ErrTooManyRequests = errors.New("too many requests")
+	// ErrInvalidTwoFactorChallenge は二要素認証のチャレンジが存在しない・期限切れ・試行回数超過の場合のエラー
+	ErrInvalidTwoFactorChallenge = errors.New("invalid or expired two-factor challenge")
 )
 
+const (
+	// MaxFailedLoginAttempts はアカウントを一時的にロックするまでに許容するログイン失敗回数
+	MaxFailedLoginAttempts = 5
+	// FailedLoginWindow はログイン失敗回数を数える期間。ロックは最も古い失敗がこの期間を過ぎると解除される。
+	FailedLoginWindow = 15 * time.Minute
+	// MaxAccountEmails は1つのメールアドレス宛に送信できる確認・リセットメールの上限
+	MaxAccountEmails = 3
+	// AccountEmailWindow は確認・リセットメールの送信回数を数える期間
+	AccountEmailWindow = 1 * time.Hour
+	// TwoFactorChallengeTTL はパスワード認証後に二要素認証のコードを入力できる期間
+	TwoFactorChallengeTTL = 5 * time.Minute
+	// MaxTwoFactorAttempts は1つのチャレンジに対して許容するコードの入力回数。超えるとチャレンジは破棄される。
+	MaxTwoFactorAttempts = 5
+	// TOTPIssuer は認証アプリに表示されるサービス名
+	TOTPIssuer = "Whiskey"
+)
+
+// RetryAfterError はレート制限・アカウントロックにより拒否されたことを表すエラー。
+// 再試行が可能になるまでの時間を保持し、errors.Isで元のエラー（ErrAccountLocked等）と比較できる。
+type RetryAfterError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+// Error はエラーメッセージを返す
+func (e *RetryAfterError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap は元のエラーを返す
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
 // UserUsecaseInterface はUserUsecaseのインターフェース。
 // テスト時のモック作成に使用する。
 type UserUsecaseInterface interface {
 	Register(ctx context.Context, email, password string) (*entity.User, error)
-	Login(ctx context.Context, email, password string) (*entity.User, string, error)
+	Login(ctx context.Context, input LoginInput) (*LoginOutput, error)
+	StartSession(ctx context.Context, user *entity.User, rememberMe bool, metadata repository.SessionMetadata) (*LoginOutput, error)
 	Logout(ctx context.Context, sessionID string) error
 	GetUser(ctx context.Context, userID uuid.UUID) (*entity.User, error)
-	ChangePassword(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) error
+	ChangePassword(ctx context.Context, userID uuid.UUID, currentSessionID, currentPassword, newPassword string) error
+	RequestEmailChange(ctx context.Context, userID uuid.UUID, currentPassword, newEmail string) error
+	ConfirmEmailChange(ctx context.Context, token string) error
 	VerifyEmail(ctx context.Context, token string) error
 	ResendVerificationEmail(ctx context.Context, email string) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	ListSessions(ctx context.Context, userID uuid.UUID) ([]*repository.Session, error)
+	RevokeSession(ctx context.Context, userID uuid.UUID, sessionID string) error
+	VerifyTwoFactor(ctx context.Context, input VerifyTwoFactorInput) (*LoginOutput, error)
+	EnrollTwoFactor(ctx context.Context, userID uuid.UUID) (*TwoFactorEnrollment, error)
+	ConfirmTwoFactor(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	DisableTwoFactor(ctx context.Context, userID uuid.UUID, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+}
+
+// SessionPolicy はログイン時に作成するセッションの有効期限の設定。
+type SessionPolicy struct {
+	// TTL は無操作のまま失効するまでの期間。アクセスがあるたびにこの期間だけ延長される。
+	TTL time.Duration
+	// RememberMeTTL は「ログインしたままにする」を選択した場合のTTL。
+	RememberMeTTL time.Duration
+	// MaxLifetime はアクセスの有無に関わらず、ログインからセッションが失効するまでの最大期間。
+	MaxLifetime time.Duration
+}
+
+// LoginInput はログインの入力
+type LoginInput struct {
+	Email      string
+	Password   string
+	RememberMe bool
+	// Metadata はセッション一覧で端末を識別するためにセッションと共に保存するクライアント情報
+	Metadata repository.SessionMetadata
+}
+
+// LoginOutput はログインの結果。
+// 二要素認証が有効なユーザーの場合はセッションを作成せず、TwoFactorRequiredとChallengeTokenのみを返す。
+type LoginOutput struct {
+	User      *entity.User
+	SessionID string
+	// SessionTTL はセッションの現時点での有効期間（クッキーのMax-Ageに使用する）
+	SessionTTL time.Duration
+	// TwoFactorRequired は二要素認証のコード入力が必要かどうか
+	TwoFactorRequired bool
+	// ChallengeToken はVerifyTwoFactorに渡す保留中のログインの識別子
+	ChallengeToken string
+}
+
+// VerifyTwoFactorInput は二要素認証のコード検証の入力
+type VerifyTwoFactorInput struct {
+	ChallengeToken string
+	// Code は認証アプリのワンタイムコード、またはリカバリーコード
+	Code string
+	// Metadata はセッション一覧で端末を識別するためにセッションと共に保存するクライアント情報
+	Metadata repository.SessionMetadata
+}
+
+// TwoFactorEnrollment は二要素認証の登録開始時に認証アプリへ登録する情報
+type TwoFactorEnrollment struct {
+	// Secret は手入力用のBase32の秘密鍵
+	Secret string
+	// ProvisioningURI はQRコードとして読み取らせるotpauth URI
+	ProvisioningURI string
 }
 
 // UserUsecase はユーザーに関するビジネスロジックを提供する。
 type UserUsecase struct {
-	userRepo    repository.UserRepository
-	userService *service.UserService
-	sessionRepo repository.SessionRepository
-	emailSender repository.EmailSender
-	sessionTTL  time.Duration
+	userRepo      repository.UserRepository
+	userService   *service.UserService
+	sessionRepo   repository.SessionRepository
+	challengeRepo repository.TwoFactorChallengeRepository
+	emailSender   repository.EmailSender
+	rateLimiter   repository.RateLimiter
+	sessionPolicy SessionPolicy
+	// now は現在時刻を返す関数（テストで時刻を固定するために差し替える）
+	now func() time.Time
 }
 
 // NewUserUsecase はUserUsecaseの新しいインスタンスを生成する。
@@ -50,15 +161,20 @@ func NewUserUsecase(
 	userRepo repository.UserRepository,
 	userService *service.UserService,
 	sessionRepo repository.SessionRepository,
+	challengeRepo repository.TwoFactorChallengeRepository,
 	emailSender repository.EmailSender,
-	sessionTTL time.Duration,
+	rateLimiter repository.RateLimiter,
+	sessionPolicy SessionPolicy,
 ) *UserUsecase {
 	return &UserUsecase{
-		userRepo:    userRepo,
-		userService: userService,
-		sessionRepo: sessionRepo,
-		emailSender: emailSender,
-		sessionTTL:  sessionTTL,
+		userRepo:      userRepo,
+		userService:   userService,
+		sessionRepo:   sessionRepo,
+		challengeRepo: challengeRepo,
+		emailSender:   emailSender,
+		rateLimiter:   rateLimiter,
+		sessionPolicy: sessionPolicy,
+		now:           time.Now,
 	}
 }
 
@@ -94,36 +210,281 @@ func (u *UserUsecase) Register(ctx context.Context, email, password string) (*en
 }
 
 // Login はログイン処理を行う。メール未検証の場合はエラーを返す。
-func (u *UserUsecase) Login(ctx context.Context, email, password string) (*entity.User, string, error) {
-	emailVO, err := value.NewEmail(email)
+// RememberMeが指定された場合はSessionPolicy.RememberMeTTLでセッションを作成する。
+// いずれの場合もセッションはSessionPolicy.MaxLifetimeを超えて延長されない。
+//
+// FailedLoginWindow内にMaxFailedLoginAttempts回ログインに失敗したメールアドレスは一時的にロックされ、
+// 正しいパスワードでもErrAccountLocked（RetryAfterError）を返す。
+// アカウントの存在を推測されないよう、存在しないメールアドレスも同様に失敗回数を数える。
+//
+// 二要素認証が有効なユーザーの場合はセッションを作成せず、TwoFactorChallengeTTLで失効するチャレンジを返す。
+// セッションはVerifyTwoFactorでコードの検証に成功した時点で作成され、失敗回数もその時点でリセットされる。
+func (u *UserUsecase) Login(ctx context.Context, input LoginInput) (*LoginOutput, error) {
+	emailVO, err := value.NewEmail(input.Email)
 	if err != nil {
-		return nil, "", ErrInvalidCredentials
+		return nil, ErrInvalidCredentials
 	}
 
-	user, err := u.userRepo.FindByEmail(ctx, emailVO.String())
+	// 判定と記録を1回で行い、同時に試行されても上限を超えて照合させない。成功した場合は下でリセットする。
+	failureKey := failedLoginKey(emailVO.String())
+	attempts, err := u.rateLimiter.Allow(ctx, failureKey, MaxFailedLoginAttempts, FailedLoginWindow)
 	if err != nil {
-		return nil, "", ErrInvalidCredentials
+		return nil, fmt.Errorf("failed to check login attempts: %w", err)
+	}
+	if !attempts.Allowed {
+		return nil, &RetryAfterError{Err: ErrAccountLocked, RetryAfter: attempts.RetryAfter}
 	}
 
-	if user == nil {
-		return nil, "", ErrInvalidCredentials
+	user, err := u.userRepo.FindByEmail(ctx, emailVO.String())
+	if err != nil || user == nil || user.VerifyPassword(input.Password) != nil {
+		return nil, ErrInvalidCredentials
 	}
 
-	if err := user.VerifyPassword(password); err != nil {
-		return nil, "", ErrInvalidCredentials
+	// 二要素認証が有効な場合、失敗回数はコードの検証に成功するまでリセットしない
+	if !user.TwoFactorEnabled {
+		if err := u.rateLimiter.Reset(ctx, failureKey); err != nil {
+			return nil, fmt.Errorf("failed to reset login attempts: %w", err)
+		}
 	}
 
 	// メール検証チェック
 	if !user.EmailVerified {
-		return nil, "", ErrEmailNotVerified
+		return nil, ErrEmailNotVerified
+	}
+
+	return u.StartSession(ctx, user, input.RememberMe, input.Metadata)
+}
+
+// StartSession は認証済みのユーザーのログインを開始する。
+// パスワード以外の方法（外部IDプロバイダ等）で認証した場合もこのメソッドでセッションを作成し、
+// 二要素認証が有効なユーザーにはLoginと同様にセッションの代わりにチャレンジを返す。
+func (u *UserUsecase) StartSession(ctx context.Context, user *entity.User, rememberMe bool, metadata repository.SessionMetadata) (*LoginOutput, error) {
+	if user.TwoFactorEnabled {
+		challengeID, err := u.challengeRepo.Create(ctx, user.ID, rememberMe, TwoFactorChallengeTTL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create two-factor challenge: %w", err)
+		}
+
+		return &LoginOutput{
+			User:              user,
+			TwoFactorRequired: true,
+			ChallengeToken:    challengeID,
+		}, nil
+	}
+
+	return u.createSession(ctx, user, rememberMe, metadata)
+}
+
+// VerifyTwoFactor は二要素認証のコードを検証し、Loginで保留されたログインを完了してセッションを作成する。
+// コードには認証アプリのワンタイムコードとリカバリーコードのいずれも使用できる。
+// チャレンジは一度きりの使用で、MaxTwoFactorAttempts回を超えて試行された場合は破棄され、
+// ErrInvalidTwoFactorChallengeを返す（再度パスワードからログインし直す必要がある）。
+// チャレンジを作り直して試行回数の制限を回避されないよう、コードの試行はアカウントのログイン失敗回数としても数える。
+func (u *UserUsecase) VerifyTwoFactor(ctx context.Context, input VerifyTwoFactorInput) (*LoginOutput, error) {
+	if input.ChallengeToken == "" {
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+
+	challenge, err := u.challengeRepo.FindByID(ctx, input.ChallengeToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get two-factor challenge: %w", err)
+	}
+	if challenge == nil {
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+
+	attempts, err := u.rateLimiter.Allow(ctx, "two_factor:"+challenge.ID, MaxTwoFactorAttempts, TwoFactorChallengeTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check two-factor attempts: %w", err)
+	}
+	if !attempts.Allowed {
+		if err := u.challengeRepo.Delete(ctx, challenge.ID); err != nil {
+			return nil, fmt.Errorf("failed to delete two-factor challenge: %w", err)
+		}
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+
+	user, err := u.userRepo.FindByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrInvalidTwoFactorChallenge
+	}
+
+	if err := u.limitTwoFactorAttempt(ctx, user, func() error {
+		return user.VerifyTwoFactor(input.Code, u.now())
+	}); err != nil {
+		if errors.Is(err, entity.ErrTwoFactorNotEnabled) {
+			return nil, ErrInvalidTwoFactorChallenge
+		}
+		return nil, err
+	}
+
+	// 使用済みのタイムステップ・リカバリーコードを記録する
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	if err := u.challengeRepo.Delete(ctx, challenge.ID); err != nil {
+		return nil, fmt.Errorf("failed to delete two-factor challenge: %w", err)
+	}
+
+	return u.createSession(ctx, user, challenge.RememberMe, input.Metadata)
+}
+
+// EnrollTwoFactor は二要素認証の登録を開始し、認証アプリに登録する秘密鍵を返す。
+// ConfirmTwoFactorでコードが確認されるまで二要素認証は有効にならない。
+func (u *UserUsecase) EnrollTwoFactor(ctx context.Context, userID uuid.UUID) (*TwoFactorEnrollment, error) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	secret, err := user.BeginTwoFactorEnrollment()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return &TwoFactorEnrollment{
+		Secret:          secret.String(),
+		ProvisioningURI: secret.ProvisioningURI(TOTPIssuer, user.Email.String()),
+	}, nil
+}
+
+// ConfirmTwoFactor は認証アプリが生成したコードで登録を確認し、二要素認証を有効にする。
+// 戻り値のリカバリーコードは平文で返すのはこの一度きりで、以降はハッシュのみが保存される。
+func (u *UserUsecase) ConfirmTwoFactor(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	recoveryCodes, err := user.ConfirmTwoFactor(code, u.now())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return recoveryCodes, nil
+}
+
+// DisableTwoFactor は現在の二要素認証のコードを確認した上で、二要素認証を無効にする。
+// コードにはTOTPのコードとリカバリーコードのいずれも使用でき、VerifyTwoFactorと同様にログイン失敗回数として数える。
+func (u *UserUsecase) DisableTwoFactor(ctx context.Context, userID uuid.UUID, code string) error {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	if err := u.limitTwoFactorAttempt(ctx, user, func() error {
+		return user.DisableTwoFactor(code, u.now())
+	}); err != nil {
+		return err
+	}
+
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return nil
+}
+
+// RegenerateRecoveryCodes は現在の二要素認証のコードを確認した上で、リカバリーコードを発行し直す。
+// 未使用のリカバリーコードは全て無効になる。戻り値のリカバリーコードは平文で返すのはこの一度きり。
+func (u *UserUsecase) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	var recoveryCodes []string
+	if err := u.limitTwoFactorAttempt(ctx, user, func() error {
+		codes, err := user.RegenerateRecoveryCodes(code, u.now())
+		recoveryCodes = codes
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return recoveryCodes, nil
+}
+
+// limitTwoFactorAttempt は二要素認証のコードの試行をアカウントのログイン失敗回数として記録した上でverifyを実行する。
+// 失敗回数が上限に達している場合はverifyを実行せずにErrAccountLocked（RetryAfterError）を返し、
+// verifyが成功した場合は失敗回数をリセットする。
+func (u *UserUsecase) limitTwoFactorAttempt(ctx context.Context, user *entity.User, verify func() error) error {
+	failureKey := failedLoginKey(user.Email.String())
+	attempts, err := u.rateLimiter.Allow(ctx, failureKey, MaxFailedLoginAttempts, FailedLoginWindow)
+	if err != nil {
+		return fmt.Errorf("failed to check login attempts: %w", err)
+	}
+	if !attempts.Allowed {
+		return &RetryAfterError{Err: ErrAccountLocked, RetryAfter: attempts.RetryAfter}
+	}
+
+	if err := verify(); err != nil {
+		return err
 	}
 
-	sessionID, err := u.sessionRepo.Create(ctx, user.ID, u.sessionTTL)
+	if err := u.rateLimiter.Reset(ctx, failureKey); err != nil {
+		return fmt.Errorf("failed to reset login attempts: %w", err)
+	}
+	return nil
+}
+
+// createSession はSessionPolicyに従ってユーザーのセッションを作成する
+func (u *UserUsecase) createSession(ctx context.Context, user *entity.User, rememberMe bool, metadata repository.SessionMetadata) (*LoginOutput, error) {
+	ttl := u.sessionPolicy.TTL
+	if rememberMe {
+		ttl = u.sessionPolicy.RememberMeTTL
+	}
+	if ttl > u.sessionPolicy.MaxLifetime {
+		ttl = u.sessionPolicy.MaxLifetime
+	}
+	absoluteExpiresAt := u.now().Add(u.sessionPolicy.MaxLifetime)
+
+	// 削除の猶予期間中に本人がログインした場合は、アカウントの削除を取り消す
+	if user.DeletionScheduledAt != nil {
+		user.CancelDeletion()
+		if err := u.userRepo.Update(ctx, user); err != nil {
+			return nil, fmt.Errorf("failed to cancel account deletion: %w", err)
+		}
+	}
+
+	sessionID, err := u.sessionRepo.Create(ctx, user.ID, ttl, absoluteExpiresAt, metadata)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to create session: %w", err)
+		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
 
-	return user, sessionID, nil
+	return &LoginOutput{
+		User:       user,
+		SessionID:  sessionID,
+		SessionTTL: ttl,
+	}, nil
 }
 
 // VerifyEmail はメール検証を完了する。
@@ -156,12 +517,18 @@ func (u *UserUsecase) VerifyEmail(ctx context.Context, token string) error {
 
 // ResendVerificationEmail は確認メールを再送する。
 // セキュリティ上、メールアドレスの存在有無に関わらず同じレスポンスを返す。
+// 同じメールアドレス宛の送信がAccountEmailWindow内にMaxAccountEmails回を超える場合は
+// ErrTooManyRequests（RetryAfterError）を返す。
 func (u *UserUsecase) ResendVerificationEmail(ctx context.Context, email string) error {
 	emailVO, err := value.NewEmail(email)
 	if err != nil {
 		return nil // メール形式が不正でもエラーを返さない
 	}
 
+	if err := u.allowAccountEmail(ctx, emailVO); err != nil {
+		return err
+	}
+
 	user, err := u.userRepo.FindByEmail(ctx, emailVO.String())
 	if err != nil || user == nil {
 		return nil // ユーザーが存在しなくてもエラーを返さない
@@ -188,6 +555,73 @@ func (u *UserUsecase) ResendVerificationEmail(ctx context.Context, email string)
 	return nil
 }
 
+// RequestPasswordReset はパスワードリセットトークンを発行し、リセットメールを送信する。
+// セキュリティ上、メールアドレスの存在有無やメール送信の成否に関わらず同じレスポンスを返す。
+// 再発行すると以前のトークンは無効になる。
+// 確認メールの再送と同様に、メールアドレスごとの送信回数を制限する。
+func (u *UserUsecase) RequestPasswordReset(ctx context.Context, email string) error {
+	emailVO, err := value.NewEmail(email)
+	if err != nil {
+		return nil // メール形式が不正でもエラーを返さない
+	}
+
+	if err := u.allowAccountEmail(ctx, emailVO); err != nil {
+		return err
+	}
+
+	user, err := u.userRepo.FindByEmail(ctx, emailVO.String())
+	if err != nil || user == nil {
+		return nil // ユーザーが存在しなくてもエラーを返さない
+	}
+
+	token, err := user.IssuePasswordResetToken()
+	if err != nil {
+		return fmt.Errorf("failed to issue password reset token: %w", err)
+	}
+
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	// 送信失敗の有無でユーザーの存在が推測されないよう、エラーは返さない（ログは EmailSender 内で記録される）
+	_ = u.emailSender.SendPasswordResetEmail(ctx, emailVO.String(), token)
+
+	return nil
+}
+
+// ResetPassword はパスワードリセットトークンを検証し、新しいパスワードを設定する。
+// トークンの検証・消費とパスワードの更新は1つの条件付きUPDATEで行うため、
+// 同じトークンで同時にリクエストされても成功するのは1回だけとなる。
+// リセット後はユーザーの既存セッションを全て無効化する。
+func (u *UserUsecase) ResetPassword(ctx context.Context, token, newPassword string) error {
+	if token == "" {
+		return ErrInvalidPasswordResetToken
+	}
+
+	passwordVO, err := value.NewPassword(newPassword)
+	if err != nil {
+		return err
+	}
+	passwordHash, err := passwordVO.Hash()
+	if err != nil {
+		return err
+	}
+
+	user, err := u.userRepo.ResetPasswordByTokenHash(ctx, value.HashPasswordResetToken(token), passwordHash)
+	if err != nil {
+		return fmt.Errorf("failed to reset password: %w", err)
+	}
+	if user == nil {
+		return ErrInvalidPasswordResetToken
+	}
+
+	if err := u.sessionRepo.DeleteAllForUser(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to invalidate sessions: %w", err)
+	}
+
+	return nil
+}
+
 // GetUser はユーザー情報を取得する。
 func (u *UserUsecase) GetUser(ctx context.Context, userID uuid.UUID) (*entity.User, error) {
 	user, err := u.userRepo.FindByID(ctx, userID)
@@ -199,7 +633,8 @@ func (u *UserUsecase) GetUser(ctx context.Context, userID uuid.UUID) (*entity.Us
 }
 
 // ChangePassword はパスワード変更を行う。
-func (u *UserUsecase) ChangePassword(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) error {
+// 変更後は漏洩したセッションを使えなくするため、currentSessionID以外のセッションを全て無効化する。
+func (u *UserUsecase) ChangePassword(ctx context.Context, userID uuid.UUID, currentSessionID, currentPassword, newPassword string) error {
 	user, err := u.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		return ErrUserNotFound
@@ -217,6 +652,117 @@ func (u *UserUsecase) ChangePassword(ctx context.Context, userID uuid.UUID, curr
 		return err
 	}
 
+	if err := u.sessionRepo.DeleteOthers(ctx, user.ID, currentSessionID); err != nil {
+		return fmt.Errorf("failed to invalidate sessions: %w", err)
+	}
+
+	return nil
+}
+
+// RequestEmailChange はメールアドレスの変更を受け付け、変更後のメールアドレスに確認メールを送信する。
+// 本人確認のため現在のパスワードを要求し、変更後のメールアドレスが他のユーザーに使われていないことを確認する。
+// メールアドレスは確認されるまで変更せず、変更前のメールアドレスには変更を受け付けたことを通知する。
+// 確認メールの再送と同様に、変更後のメールアドレスごとの送信回数を制限する。
+func (u *UserUsecase) RequestEmailChange(ctx context.Context, userID uuid.UUID, currentPassword, newEmail string) error {
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil || user == nil {
+		return ErrUserNotFound
+	}
+
+	if err := user.VerifyPassword(currentPassword); err != nil {
+		return ErrInvalidCredentials
+	}
+
+	token, err := user.RequestEmailChange(newEmail)
+	if err != nil {
+		return err
+	}
+
+	if err := u.userService.CheckEmailUniqueness(ctx, *user.PendingEmail); err != nil {
+		return err
+	}
+
+	if err := u.allowAccountEmail(ctx, *user.PendingEmail); err != nil {
+		return err
+	}
+
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	if err := u.emailSender.SendEmailChangeVerificationEmail(ctx, user.PendingEmail.String(), token); err != nil {
+		return fmt.Errorf("failed to send email change verification email: %w", err)
+	}
+
+	// 通知は補助的なものであり、送信に失敗しても変更の受付は取り消さない（ログは EmailSender 内で記録される）
+	_ = u.emailSender.SendEmailChangeNotice(ctx, user.Email.String(), user.PendingEmail.String())
+
+	return nil
+}
+
+// ConfirmEmailChange はメールアドレス変更トークンを検証し、メールアドレスを変更後のものに切り替える。
+// トークンは一度きりの使用。受付後に同じメールアドレスが他のユーザーに登録された場合は
+// value.ErrEmailAlreadyExistsを返し、変更しない。
+func (u *UserUsecase) ConfirmEmailChange(ctx context.Context, token string) error {
+	if token == "" {
+		return ErrInvalidEmailChangeToken
+	}
+
+	user, err := u.userRepo.FindByEmailChangeTokenHash(ctx, value.HashEmailChangeToken(token))
+	if err != nil {
+		return fmt.Errorf("failed to find user by email change token: %w", err)
+	}
+	if user == nil || user.PendingEmail == nil {
+		return ErrInvalidEmailChangeToken
+	}
+
+	if err := u.userService.CheckEmailUniqueness(ctx, *user.PendingEmail); err != nil {
+		return err
+	}
+
+	if err := user.ConfirmEmailChange(); err != nil {
+		if errors.Is(err, entity.ErrEmailChangeNotRequested) || errors.Is(err, entity.ErrEmailChangeTokenExpired) {
+			return ErrInvalidEmailChangeToken
+		}
+		return err
+	}
+
+	// 重複の確認後、更新までの間に同じメールアドレスが登録された場合も一意制約で検出する
+	if err := u.userRepo.Update(ctx, user); err != nil {
+		if errors.Is(err, value.ErrEmailAlreadyExists) {
+			return value.ErrEmailAlreadyExists
+		}
+		return fmt.Errorf("failed to change email: %w", err)
+	}
+
+	return nil
+}
+
+// ListSessions はユーザーの有効なセッションを最終アクセス日時の新しい順に取得する。
+func (u *UserUsecase) ListSessions(ctx context.Context, userID uuid.UUID) ([]*repository.Session, error) {
+	sessions, err := u.sessionRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession は指定されたセッションを無効化する。
+// 他のユーザーのセッションは存在しないものとして扱い、ErrSessionNotFoundを返す。
+func (u *UserUsecase) RevokeSession(ctx context.Context, userID uuid.UUID, sessionID string) error {
+	session, err := u.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil || session.UserID != userID {
+		return ErrSessionNotFound
+	}
+
+	if err := u.sessionRepo.Delete(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+
 	return nil
 }
 
@@ -224,3 +770,21 @@ func (u *UserUsecase) ChangePassword(ctx context.Context, userID uuid.UUID, curr
 func (u *UserUsecase) Logout(ctx context.Context, sessionID string) error {
 	return u.sessionRepo.Delete(ctx, sessionID)
 }
+
+// allowAccountEmail はメールアドレス宛の確認・リセットメールの送信回数を記録し、上限を超えた場合はエラーを返す。
+// メールアドレスの存在有無に関わらず数えるため、制限の有無からアカウントの存在は推測できない。
+func (u *UserUsecase) allowAccountEmail(ctx context.Context, email value.Email) error {
+	result, err := u.rateLimiter.Allow(ctx, "account_email:"+email.String(), MaxAccountEmails, AccountEmailWindow)
+	if err != nil {
+		return fmt.Errorf("failed to check email rate limit: %w", err)
+	}
+	if !result.Allowed {
+		return &RetryAfterError{Err: ErrTooManyRequests, RetryAfter: result.RetryAfter}
+	}
+	return nil
+}
+
+// failedLoginKey はメールアドレスごとのログイン失敗回数を記録するレート制限のキーを返す
+func failedLoginKey(email string) string {
+	return "login_failures:" + email
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -49,11 +50,8 @@ func (m *mockUserRepository) FindByEmail(ctx context.Context, email string) (*en
 	if m.err != nil {
 		return nil, m.err
 	}
-	user, ok := m.users[email]
-	if !ok {
-		return nil, errors.New("user not found")
-	}
-	return user, nil
+	// 実装と同様に、存在しない場合はエラーではなくnilを返す
+	return m.users[email], nil
 }
 
 func (m *mockUserRepository) FindAll(ctx context.Context) ([]*entity.User, error) {
@@ -71,6 +69,12 @@ func (m *mockUserRepository) Update(ctx context.Context, user *entity.User) erro
 	if m.err != nil {
 		return m.err
 	}
+	// メールアドレスの変更に追従するため、同じIDの古いエントリを削除してから保存する
+	for email, existing := range m.users {
+		if existing.ID == user.ID {
+			delete(m.users, email)
+		}
+	}
 	m.users[user.Email.String()] = user
 	return nil
 }
@@ -108,24 +112,78 @@ func (m *mockUserRepository) FindByVerificationToken(ctx context.Context, token
 	return nil, nil
 }
 
+func (m *mockUserRepository) ResetPasswordByTokenHash(ctx context.Context, tokenHash string, passwordHash value.HashedPassword) (*entity.User, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, user := range m.users {
+		if user.PasswordResetToken != nil && user.PasswordResetToken.Hash() == tokenHash && !user.PasswordResetToken.IsExpired() {
+			user.PasswordHash = passwordHash
+			user.PasswordResetToken = nil
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockUserRepository) FindByEmailChangeTokenHash(ctx context.Context, tokenHash string) (*entity.User, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	for _, user := range m.users {
+		if user.EmailChangeToken != nil && user.EmailChangeToken.Hash() == tokenHash {
+			return user, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockUserRepository) FindDueForDeletion(ctx context.Context, before time.Time) ([]*entity.User, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var result []*entity.User
+	for _, user := range m.users {
+		if user.IsDeletionDue(before) {
+			result = append(result, user)
+		}
+	}
+	return result, nil
+}
+
+// testSessionPolicy はテストで使用するセッションの有効期限の設定
+var testSessionPolicy = SessionPolicy{
+	TTL:           24 * time.Hour,
+	RememberMeTTL: 14 * 24 * time.Hour,
+	MaxLifetime:   30 * 24 * time.Hour,
+}
+
 // mockSessionRepository はSessionRepositoryのモック実装
 type mockSessionRepository struct {
-	sessions map[string]uuid.UUID // sessionID -> userID
-	err      error
+	sessions  map[string]uuid.UUID // sessionID -> userID
+	metadata  map[string]repository.SessionMetadata
+	ttls      map[string]time.Duration
+	createdAt map[string]time.Time
+	err       error
 }
 
 func newMockSessionRepository() *mockSessionRepository {
 	return &mockSessionRepository{
-		sessions: make(map[string]uuid.UUID),
+		sessions:  make(map[string]uuid.UUID),
+		metadata:  make(map[string]repository.SessionMetadata),
+		ttls:      make(map[string]time.Duration),
+		createdAt: make(map[string]time.Time),
 	}
 }
 
-func (m *mockSessionRepository) Create(ctx context.Context, userID uuid.UUID, ttl time.Duration) (string, error) {
+func (m *mockSessionRepository) Create(ctx context.Context, userID uuid.UUID, ttl time.Duration, absoluteExpiresAt time.Time, metadata repository.SessionMetadata) (string, error) {
 	if m.err != nil {
 		return "", m.err
 	}
 	sessionID := uuid.New().String()
 	m.sessions[sessionID] = userID
+	m.metadata[sessionID] = metadata
+	m.ttls[sessionID] = ttl
 	return sessionID, nil
 }
 
@@ -140,6 +198,49 @@ func (m *mockSessionRepository) Get(ctx context.Context, sessionID string) (uuid
 	return userID, nil
 }
 
+func (m *mockSessionRepository) FindByID(ctx context.Context, sessionID string) (*repository.Session, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	userID, ok := m.sessions[sessionID]
+	if !ok {
+		return nil, nil
+	}
+	metadata := m.metadata[sessionID]
+	return &repository.Session{
+		ID:        sessionID,
+		UserID:    userID,
+		CreatedAt: m.createdAt[sessionID],
+		UserAgent: metadata.UserAgent,
+		IPAddress: metadata.IPAddress,
+		TTL:       m.ttls[sessionID],
+	}, nil
+}
+
+func (m *mockSessionRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*repository.Session, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	sessions := []*repository.Session{}
+	for sessionID, id := range m.sessions {
+		if id == userID {
+			session, _ := m.FindByID(ctx, sessionID)
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+func (m *mockSessionRepository) Touch(ctx context.Context, sessionID string, at time.Time) error {
+	if m.err != nil {
+		return m.err
+	}
+	if _, ok := m.sessions[sessionID]; !ok {
+		return errors.New("session not found")
+	}
+	return nil
+}
+
 func (m *mockSessionRepository) Delete(ctx context.Context, sessionID string) error {
 	if m.err != nil {
 		return m.err
@@ -148,6 +249,30 @@ func (m *mockSessionRepository) Delete(ctx context.Context, sessionID string) er
 	return nil
 }
 
+func (m *mockSessionRepository) DeleteAllForUser(ctx context.Context, userID uuid.UUID) error {
+	if m.err != nil {
+		return m.err
+	}
+	for sessionID, id := range m.sessions {
+		if id == userID {
+			delete(m.sessions, sessionID)
+		}
+	}
+	return nil
+}
+
+func (m *mockSessionRepository) DeleteOthers(ctx context.Context, userID uuid.UUID, keepSessionID string) error {
+	if m.err != nil {
+		return m.err
+	}
+	for sessionID, id := range m.sessions {
+		if id == userID && sessionID != keepSessionID {
+			delete(m.sessions, sessionID)
+		}
+	}
+	return nil
+}
+
 func (m *mockSessionRepository) Extend(ctx context.Context, sessionID string, ttl time.Duration) error {
 	if m.err != nil {
 		return m.err
@@ -158,10 +283,107 @@ func (m *mockSessionRepository) Extend(ctx context.Context, sessionID string, tt
 	return nil
 }
 
+// mockChallengeRepository はTwoFactorChallengeRepositoryのモック
+type mockChallengeRepository struct {
+	challenges map[string]*repository.TwoFactorChallenge
+}
+
+func newMockChallengeRepository() *mockChallengeRepository {
+	return &mockChallengeRepository{
+		challenges: make(map[string]*repository.TwoFactorChallenge),
+	}
+}
+
+func (m *mockChallengeRepository) Create(ctx context.Context, userID uuid.UUID, rememberMe bool, ttl time.Duration) (string, error) {
+	challengeID := uuid.New().String()
+	m.challenges[challengeID] = &repository.TwoFactorChallenge{
+		ID:         challengeID,
+		UserID:     userID,
+		RememberMe: rememberMe,
+	}
+	return challengeID, nil
+}
+
+func (m *mockChallengeRepository) FindByID(ctx context.Context, challengeID string) (*repository.TwoFactorChallenge, error) {
+	return m.challenges[challengeID], nil
+}
+
+func (m *mockChallengeRepository) Delete(ctx context.Context, challengeID string) error {
+	delete(m.challenges, challengeID)
+	return nil
+}
+
+// fakeClock はテストで時刻を進めるための時計
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+// fakeRateLimiter はRateLimiterのインメモリ実装（スライディングウィンドウ）
+type fakeRateLimiter struct {
+	clock    *fakeClock
+	attempts map[string][]time.Time
+	err      error
+}
+
+func newFakeRateLimiter() *fakeRateLimiter {
+	return &fakeRateLimiter{
+		clock:    &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		attempts: make(map[string][]time.Time),
+	}
+}
+
+func (f *fakeRateLimiter) check(key string, limit int, window time.Duration) repository.RateLimitResult {
+	now := f.clock.Now()
+	var recent []time.Time
+	for _, at := range f.attempts[key] {
+		if now.Sub(at) < window {
+			recent = append(recent, at)
+		}
+	}
+	f.attempts[key] = recent
+	if len(recent) < limit {
+		return repository.RateLimitResult{Allowed: true}
+	}
+	return repository.RateLimitResult{Allowed: false, RetryAfter: recent[0].Add(window).Sub(now)}
+}
+
+func (f *fakeRateLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (repository.RateLimitResult, error) {
+	if f.err != nil {
+		return repository.RateLimitResult{}, f.err
+	}
+	result := f.check(key, limit, window)
+	if result.Allowed {
+		f.attempts[key] = append(f.attempts[key], f.clock.Now())
+	}
+	return result, nil
+}
+
+func (f *fakeRateLimiter) Reset(ctx context.Context, key string) error {
+	if f.err != nil {
+		return f.err
+	}
+	delete(f.attempts, key)
+	return nil
+}
+
 // mockEmailSender はEmailSenderのモック実装
 type mockEmailSender struct {
 	sentEmails []string
-	err        error
+	// resetTokens は送信したパスワードリセットメールの宛先ごとの平文トークン
+	resetTokens map[string]string
+	// emailChangeTokens は送信したメールアドレス変更の確認メールの宛先ごとの平文トークン
+	emailChangeTokens map[string]string
+	// emailChangeNotices は送信したメールアドレス変更通知の宛先
+	emailChangeNotices []string
+	err                error
 }
 
 func newMockEmailSender() *mockEmailSender {
@@ -176,6 +398,36 @@ func (m *mockEmailSender) SendVerificationEmail(ctx context.Context, toEmail str
 	return nil
 }
 
+func (m *mockEmailSender) SendPasswordResetEmail(ctx context.Context, toEmail string, token string) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.resetTokens == nil {
+		m.resetTokens = make(map[string]string)
+	}
+	m.resetTokens[toEmail] = token
+	return nil
+}
+
+func (m *mockEmailSender) SendEmailChangeVerificationEmail(ctx context.Context, toEmail string, token string) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.emailChangeTokens == nil {
+		m.emailChangeTokens = make(map[string]string)
+	}
+	m.emailChangeTokens[toEmail] = token
+	return nil
+}
+
+func (m *mockEmailSender) SendEmailChangeNotice(ctx context.Context, toEmail string, newEmail string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.emailChangeNotices = append(m.emailChangeNotices, toEmail)
+	return nil
+}
+
 // テストヘルパー: リポジトリにユーザーを追加（メール検証済みの状態で追加）
 func (m *mockUserRepository) addUser(email, password string) *entity.User {
 	user, _ := entity.NewUser(email, password)
@@ -246,7 +498,7 @@ func TestUserUsecase_Register(t *testing.T) {
 			mockSessionRepo := newMockSessionRepository()
 			mockEmailSender := newMockEmailSender()
 			userService := service.NewUserService(mockRepo)
-			usecase := NewUserUsecase(mockRepo, userService, mockSessionRepo, mockEmailSender, 24*time.Hour)
+			usecase := NewUserUsecase(mockRepo, userService, mockSessionRepo, newMockChallengeRepository(), mockEmailSender, newFakeRateLimiter(), testSessionPolicy)
 
 			user, err := usecase.Register(context.Background(), tt.email, tt.password)
 
@@ -290,12 +542,14 @@ func TestUserUsecase_Login(t *testing.T) {
 	const validPassword = "password123"
 
 	tests := []struct {
-		name     string
-		email    string
-		password string
-		setup    func(*mockUserRepository)
-		wantErr  bool
-		checkErr func(error) bool
+		name       string
+		email      string
+		password   string
+		rememberMe bool
+		setup      func(*mockUserRepository)
+		wantTTL    time.Duration
+		wantErr    bool
+		checkErr   func(error) bool
 	}{
 		{
 			name:     "正常系: ログイン成功",
@@ -304,6 +558,18 @@ func TestUserUsecase_Login(t *testing.T) {
 			setup: func(m *mockUserRepository) {
 				m.addUser(validEmail, validPassword)
 			},
+			wantTTL: testSessionPolicy.TTL,
+			wantErr: false,
+		},
+		{
+			name:       "正常系: ログインしたままにする場合は長いTTL",
+			email:      validEmail,
+			password:   validPassword,
+			rememberMe: true,
+			setup: func(m *mockUserRepository) {
+				m.addUser(validEmail, validPassword)
+			},
+			wantTTL: testSessionPolicy.RememberMeTTL,
 			wantErr: false,
 		},
 		{
@@ -348,9 +614,15 @@ func TestUserUsecase_Login(t *testing.T) {
 			mockSessionRepo := newMockSessionRepository()
 			mockEmailSender := newMockEmailSender()
 			userService := service.NewUserService(mockRepo)
-			usecase := NewUserUsecase(mockRepo, userService, mockSessionRepo, mockEmailSender, 24*time.Hour)
+			usecase := NewUserUsecase(mockRepo, userService, mockSessionRepo, newMockChallengeRepository(), mockEmailSender, newFakeRateLimiter(), testSessionPolicy)
 
-			user, sessionID, err := usecase.Login(context.Background(), tt.email, tt.password)
+			metadata := repository.SessionMetadata{UserAgent: "Mozilla/5.0 (test)", IPAddress: "192.0.2.1"}
+			out, err := usecase.Login(context.Background(), LoginInput{
+				Email:      tt.email,
+				Password:   tt.password,
+				RememberMe: tt.rememberMe,
+				Metadata:   metadata,
+			})
 
 			if tt.wantErr {
 				if err == nil {
@@ -368,6 +640,7 @@ func TestUserUsecase_Login(t *testing.T) {
 				return
 			}
 
+			user, sessionID := out.User, out.SessionID
 			if user == nil {
 				t.Error("Login() user = nil, want user")
 				return
@@ -389,6 +662,19 @@ func TestUserUsecase_Login(t *testing.T) {
 				t.Errorf("stored userID = %v, want %v", storedUserID, user.ID)
 			}
 
+			// クライアント情報がセッションに保存されているか確認
+			if got := mockSessionRepo.metadata[sessionID]; got != metadata {
+				t.Errorf("session metadata = %+v, want %+v", got, metadata)
+			}
+
+			// TTLがログイン方法に応じて選択されているか確認
+			if out.SessionTTL != tt.wantTTL {
+				t.Errorf("SessionTTL = %v, want %v", out.SessionTTL, tt.wantTTL)
+			}
+			if got := mockSessionRepo.ttls[sessionID]; got != tt.wantTTL {
+				t.Errorf("stored session TTL = %v, want %v", got, tt.wantTTL)
+			}
+
 			if user.Email.String() != tt.email {
 				t.Errorf("user email = %v, want %v", user.Email.String(), tt.email)
 			}
@@ -396,30 +682,141 @@ func TestUserUsecase_Login(t *testing.T) {
 	}
 }
 
-func TestUserUsecase_GetUser(t *testing.T) {
+func TestUserUsecase_Login_TTLCappedByMaxLifetime(t *testing.T) {
+	mockRepo := newMockUserRepository()
+	mockRepo.addUser("user@example.com", "password123")
+	mockSessionRepo := newMockSessionRepository()
+	userService := service.NewUserService(mockRepo)
+	policy := SessionPolicy{
+		TTL:           24 * time.Hour,
+		RememberMeTTL: 90 * 24 * time.Hour,
+		MaxLifetime:   30 * 24 * time.Hour,
+	}
+	usecase := NewUserUsecase(mockRepo, userService, mockSessionRepo, newMockChallengeRepository(), newMockEmailSender(), newFakeRateLimiter(), policy)
+
+	out, err := usecase.Login(context.Background(), LoginInput{
+		Email:      "user@example.com",
+		Password:   "password123",
+		RememberMe: true,
+	})
+	if err != nil {
+		t.Fatalf("Login() unexpected error = %v", err)
+	}
+
+	if out.SessionTTL != policy.MaxLifetime {
+		t.Errorf("SessionTTL = %v, want %v", out.SessionTTL, policy.MaxLifetime)
+	}
+}
+
+func TestUserUsecase_Login_Lockout(t *testing.T) {
+	const email = "user@example.com"
+	const password = "password123"
+
+	setup := func() (*UserUsecase, *fakeRateLimiter) {
+		mockRepo := newMockUserRepository()
+		mockRepo.addUser(email, password)
+		limiter := newFakeRateLimiter()
+		userService := service.NewUserService(mockRepo)
+		return NewUserUsecase(mockRepo, userService, newMockSessionRepository(), newMockChallengeRepository(), newMockEmailSender(), limiter, testSessionPolicy), limiter
+	}
+
+	failLogins := func(t *testing.T, u *UserUsecase, loginEmail string, n int) {
+		t.Helper()
+		for i := 0; i < n; i++ {
+			_, err := u.Login(context.Background(), LoginInput{Email: loginEmail, Password: "wrongpassword"})
+			if !errors.Is(err, ErrInvalidCredentials) {
+				t.Fatalf("Login() attempt %d error = %v, want ErrInvalidCredentials", i+1, err)
+			}
+		}
+	}
+
+	t.Run("異常系: 失敗が上限に達すると正しいパスワードでもロックされる", func(t *testing.T) {
+		u, limiter := setup()
+		failLogins(t, u, email, MaxFailedLoginAttempts)
+
+		limiter.clock.Advance(1 * time.Minute)
+		_, err := u.Login(context.Background(), LoginInput{Email: email, Password: password})
+		if !errors.Is(err, ErrAccountLocked) {
+			t.Fatalf("Login() error = %v, want ErrAccountLocked", err)
+		}
+		var retryErr *RetryAfterError
+		if !errors.As(err, &retryErr) {
+			t.Fatalf("Login() error = %T, want *RetryAfterError", err)
+		}
+		if want := FailedLoginWindow - 1*time.Minute; retryErr.RetryAfter != want {
+			t.Errorf("RetryAfter = %v, want %v", retryErr.RetryAfter, want)
+		}
+	})
+
+	t.Run("正常系: ウィンドウを過ぎるとロックが解除される", func(t *testing.T) {
+		u, limiter := setup()
+		failLogins(t, u, email, MaxFailedLoginAttempts)
+
+		limiter.clock.Advance(FailedLoginWindow)
+		if _, err := u.Login(context.Background(), LoginInput{Email: email, Password: password}); err != nil {
+			t.Fatalf("Login() after lockout window error = %v", err)
+		}
+	})
+
+	t.Run("正常系: ログイン成功で失敗回数がリセットされる", func(t *testing.T) {
+		u, _ := setup()
+		failLogins(t, u, email, MaxFailedLoginAttempts-1)
+
+		if _, err := u.Login(context.Background(), LoginInput{Email: email, Password: password}); err != nil {
+			t.Fatalf("Login() error = %v", err)
+		}
+
+		// リセット後は再び上限回数まで失敗できる
+		failLogins(t, u, email, MaxFailedLoginAttempts-1)
+		if _, err := u.Login(context.Background(), LoginInput{Email: email, Password: password}); err != nil {
+			t.Fatalf("Login() after reset error = %v", err)
+		}
+	})
+
+	t.Run("異常系: 存在しないメールアドレスも同様にロックされる", func(t *testing.T) {
+		u, _ := setup()
+		failLogins(t, u, "nonexistent@example.com", MaxFailedLoginAttempts)
+
+		_, err := u.Login(context.Background(), LoginInput{Email: "nonexistent@example.com", Password: password})
+		if !errors.Is(err, ErrAccountLocked) {
+			t.Errorf("Login() error = %v, want ErrAccountLocked", err)
+		}
+	})
+
+	t.Run("正常系: 他のアカウントのロックは影響しない", func(t *testing.T) {
+		u, _ := setup()
+		failLogins(t, u, "other@example.com", MaxFailedLoginAttempts)
+
+		if _, err := u.Login(context.Background(), LoginInput{Email: email, Password: password}); err != nil {
+			t.Errorf("Login() error = %v", err)
+		}
+	})
+
+	t.Run("異常系: レート制限の確認に失敗", func(t *testing.T) {
+		u, limiter := setup()
+		limiter.err = errors.New("redis error")
+
+		if _, err := u.Login(context.Background(), LoginInput{Email: email, Password: password}); err == nil {
+			t.Error("Login() error = nil, want error")
+		}
+	})
+}
+
+func TestUserUsecase_AccountEmailRateLimit(t *testing.T) {
 	tests := []struct {
-		name     string
-		userID   uuid.UUID
-		setup    func(*mockUserRepository) uuid.UUID
-		wantErr  bool
-		checkErr func(error) bool
+		name string
+		send func(u *UserUsecase, email string) error
 	}{
 		{
-			name: "正常系: ユーザー取得成功",
-			setup: func(m *mockUserRepository) uuid.UUID {
-				user := m.addUser("user@example.com", "password123")
-				return user.ID
+			name: "異常系: 確認メールの再送が上限を超える",
+			send: func(u *UserUsecase, email string) error {
+				return u.ResendVerificationEmail(context.Background(), email)
 			},
-			wantErr: false,
 		},
 		{
-			name: "異常系: ユーザーが存在しない",
-			setup: func(m *mockUserRepository) uuid.UUID {
-				return uuid.New() // 存在しないID
-			},
-			wantErr: true,
-			checkErr: func(err error) bool {
-				return errors.Is(err, ErrUserNotFound)
+			name: "異常系: パスワードリセットメールが上限を超える",
+			send: func(u *UserUsecase, email string) error {
+				return u.RequestPasswordReset(context.Background(), email)
 			},
 		},
 	}
@@ -427,73 +824,508 @@ func TestUserUsecase_GetUser(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := newMockUserRepository()
-			userID := tt.setup(mockRepo)
-
-			mockSessionRepo := newMockSessionRepository()
-			mockEmailSender := newMockEmailSender()
+			limiter := newFakeRateLimiter()
 			userService := service.NewUserService(mockRepo)
-			usecase := NewUserUsecase(mockRepo, userService, mockSessionRepo, mockEmailSender, 24*time.Hour)
+			u := NewUserUsecase(mockRepo, userService, newMockSessionRepository(), newMockChallengeRepository(), newMockEmailSender(), limiter, testSessionPolicy)
 
-			user, err := usecase.GetUser(context.Background(), userID)
-
-			if tt.wantErr {
-				if err == nil {
-					t.Error("GetUser() error = nil, want error")
-					return
-				}
-				if tt.checkErr != nil && !tt.checkErr(err) {
-					t.Errorf("GetUser() error = %v, want specific error", err)
+			// 存在しないメールアドレスでも回数は数えられる（存在有無を推測させない）
+			const email = "nonexistent@example.com"
+			for i := 0; i < MaxAccountEmails; i++ {
+				if err := tt.send(u, email); err != nil {
+					t.Fatalf("attempt %d error = %v", i+1, err)
 				}
-				return
 			}
 
-			if err != nil {
-				t.Errorf("GetUser() unexpected error = %v", err)
-				return
+			err := tt.send(u, email)
+			if !errors.Is(err, ErrTooManyRequests) {
+				t.Fatalf("error = %v, want ErrTooManyRequests", err)
+			}
+			var retryErr *RetryAfterError
+			if !errors.As(err, &retryErr) || retryErr.RetryAfter != AccountEmailWindow {
+				t.Errorf("error = %v, want RetryAfterError with RetryAfter %v", err, AccountEmailWindow)
 			}
 
-			if user == nil {
-				t.Error("GetUser() user = nil, want user")
-				return
+			// 他のメールアドレスは制限されない
+			if err := tt.send(u, "another@example.com"); err != nil {
+				t.Errorf("other email error = %v", err)
 			}
 
-			if user.ID != userID {
-				t.Errorf("user ID = %v, want %v", user.ID, userID)
+			// ウィンドウを過ぎると再び送信できる
+			limiter.clock.Advance(AccountEmailWindow)
+			if err := tt.send(u, email); err != nil {
+				t.Errorf("after window error = %v", err)
 			}
 		})
 	}
 }
 
-func TestUserUsecase_ChangePassword(t *testing.T) {
-	const validEmail = "user@example.com"
-	const currentPassword = "currentpass123"
-	const newPassword = "newpassword456"
+// setupTwoFactorUsecase は時刻を固定したUserUsecaseと、二要素認証を有効にしたユーザーを返す
+func setupTwoFactorUsecase(t *testing.T) (*UserUsecase, *mockSessionRepository, *mockChallengeRepository, *entity.User, []string) {
+	t.Helper()
 
-	tests := []struct {
-		name            string
-		currentPassword string
-		newPassword     string
-		setup           func(*mockUserRepository) uuid.UUID
-		wantErr         bool
-		checkErr        func(error) bool
-	}{
-		{
-			name:            "正常系: パスワード変更成功",
-			currentPassword: currentPassword,
-			newPassword:     newPassword,
-			setup: func(m *mockUserRepository) uuid.UUID {
-				user := m.addUser(validEmail, currentPassword)
-				return user.ID
-			},
-			wantErr: false,
-		},
-		{
-			name:            "異常系: ユーザーが存在しない",
-			currentPassword: currentPassword,
-			newPassword:     newPassword,
-			setup: func(m *mockUserRepository) uuid.UUID {
-				return uuid.New() // 存在しないID
-			},
+	mockRepo := newMockUserRepository()
+	mockSessionRepo := newMockSessionRepository()
+	mockChallengeRepo := newMockChallengeRepository()
+	limiter := newFakeRateLimiter()
+	userService := service.NewUserService(mockRepo)
+	u := NewUserUsecase(mockRepo, userService, mockSessionRepo, mockChallengeRepo, newMockEmailSender(), limiter, testSessionPolicy)
+	u.now = limiter.clock.Now
+
+	user := mockRepo.addUser("test@example.com", "password123")
+	ctx := context.Background()
+
+	enrollment, err := u.EnrollTwoFactor(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("EnrollTwoFactor() unexpected error = %v", err)
+	}
+	code, _ := value.ReconstructTOTPSecret(enrollment.Secret).Code(limiter.clock.Now())
+	recoveryCodes, err := u.ConfirmTwoFactor(ctx, user.ID, code)
+	if err != nil {
+		t.Fatalf("ConfirmTwoFactor() unexpected error = %v", err)
+	}
+
+	// 有効化時に使用したコードと重ならないよう時刻を進める
+	limiter.clock.Advance(time.Minute)
+	return u, mockSessionRepo, mockChallengeRepo, user, recoveryCodes
+}
+
+func TestUserUsecase_EnrollTwoFactor(t *testing.T) {
+	mockRepo := newMockUserRepository()
+	userService := service.NewUserService(mockRepo)
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+	u := NewUserUsecase(mockRepo, userService, newMockSessionRepository(), newMockChallengeRepository(), newMockEmailSender(), newFakeRateLimiter(), testSessionPolicy)
+	u.now = clock.Now
+	user := mockRepo.addUser("test@example.com", "password123")
+	ctx := context.Background()
+
+	enrollment, err := u.EnrollTwoFactor(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("EnrollTwoFactor() unexpected error = %v", err)
+	}
+	if !strings.HasPrefix(enrollment.ProvisioningURI, "otpauth://totp/Whiskey:test@example.com?") {
+		t.Errorf("ProvisioningURI = %v, want otpauth URI for the user", enrollment.ProvisioningURI)
+	}
+	if user.TwoFactorEnabled {
+		t.Error("TwoFactorEnabled should remain false until confirmed")
+	}
+
+	// 誤ったコードでは有効化されない
+	if _, err := u.ConfirmTwoFactor(ctx, user.ID, "000000"); !errors.Is(err, entity.ErrInvalidTwoFactorCode) {
+		t.Errorf("ConfirmTwoFactor() error = %v, want %v", err, entity.ErrInvalidTwoFactorCode)
+	}
+
+	code, _ := value.ReconstructTOTPSecret(enrollment.Secret).Code(clock.Now())
+	recoveryCodes, err := u.ConfirmTwoFactor(ctx, user.ID, code)
+	if err != nil {
+		t.Fatalf("ConfirmTwoFactor() unexpected error = %v", err)
+	}
+	if !user.TwoFactorEnabled {
+		t.Error("TwoFactorEnabled should be true after ConfirmTwoFactor")
+	}
+	if len(recoveryCodes) != value.RecoveryCodeCount {
+		t.Errorf("len(recoveryCodes) = %d, want %d", len(recoveryCodes), value.RecoveryCodeCount)
+	}
+
+	// 有効化後は登録し直せない
+	if _, err := u.EnrollTwoFactor(ctx, user.ID); !errors.Is(err, entity.ErrTwoFactorAlreadyEnabled) {
+		t.Errorf("EnrollTwoFactor() error = %v, want %v", err, entity.ErrTwoFactorAlreadyEnabled)
+	}
+}
+
+func TestUserUsecase_Login_TwoFactorRequired(t *testing.T) {
+	u, mockSessionRepo, mockChallengeRepo, user, _ := setupTwoFactorUsecase(t)
+
+	out, err := u.Login(context.Background(), LoginInput{
+		Email:      "test@example.com",
+		Password:   "password123",
+		RememberMe: true,
+	})
+	if err != nil {
+		t.Fatalf("Login() unexpected error = %v", err)
+	}
+
+	if !out.TwoFactorRequired || out.ChallengeToken == "" {
+		t.Errorf("Login() = %+v, want a pending two-factor challenge", out)
+	}
+	if out.SessionID != "" || len(mockSessionRepo.sessions) != 0 {
+		t.Error("Login() should not create a session before two-factor verification")
+	}
+
+	challenge := mockChallengeRepo.challenges[out.ChallengeToken]
+	if challenge == nil || challenge.UserID != user.ID || !challenge.RememberMe {
+		t.Errorf("challenge = %+v, want challenge for user %v with RememberMe", challenge, user.ID)
+	}
+}
+
+func TestUserUsecase_VerifyTwoFactor(t *testing.T) {
+	tests := []struct {
+		name string
+		// setup はチャレンジの作成後に呼ばれ、検証に使用するチャレンジトークンとコードを返す
+		setup         func(u *UserUsecase, challengeToken string, recoveryCodes []string) (string, string)
+		wantErr       error
+		wantRemaining int
+	}{
+		{
+			name: "正常系: 認証アプリのコード",
+			setup: func(u *UserUsecase, challengeToken string, _ []string) (string, string) {
+				user, _ := u.userRepo.FindByEmail(context.Background(), "test@example.com")
+				code, _ := user.TOTPSecret.Code(u.now())
+				return challengeToken, code
+			},
+			wantRemaining: value.RecoveryCodeCount,
+		},
+		{
+			name: "正常系: リカバリーコード",
+			setup: func(_ *UserUsecase, challengeToken string, recoveryCodes []string) (string, string) {
+				return challengeToken, recoveryCodes[0]
+			},
+			wantRemaining: value.RecoveryCodeCount - 1,
+		},
+		{
+			name: "異常系: コードが一致しない",
+			setup: func(_ *UserUsecase, challengeToken string, _ []string) (string, string) {
+				return challengeToken, "000000"
+			},
+			wantErr:       entity.ErrInvalidTwoFactorCode,
+			wantRemaining: value.RecoveryCodeCount,
+		},
+		{
+			name: "異常系: チャレンジが存在しない",
+			setup: func(_ *UserUsecase, _ string, recoveryCodes []string) (string, string) {
+				return uuid.New().String(), recoveryCodes[0]
+			},
+			wantErr:       ErrInvalidTwoFactorChallenge,
+			wantRemaining: value.RecoveryCodeCount,
+		},
+		{
+			name: "異常系: 試行回数を超えると正しいコードでも拒否",
+			setup: func(u *UserUsecase, challengeToken string, recoveryCodes []string) (string, string) {
+				for i := 0; i < MaxTwoFactorAttempts; i++ {
+					u.VerifyTwoFactor(context.Background(), VerifyTwoFactorInput{ChallengeToken: challengeToken, Code: "000000"})
+				}
+				return challengeToken, recoveryCodes[0]
+			},
+			wantErr:       ErrInvalidTwoFactorChallenge,
+			wantRemaining: value.RecoveryCodeCount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, mockSessionRepo, mockChallengeRepo, user, recoveryCodes := setupTwoFactorUsecase(t)
+			ctx := context.Background()
+
+			login, err := u.Login(ctx, LoginInput{Email: "test@example.com", Password: "password123"})
+			if err != nil {
+				t.Fatalf("Login() unexpected error = %v", err)
+			}
+			challengeToken, code := tt.setup(u, login.ChallengeToken, recoveryCodes)
+
+			out, err := u.VerifyTwoFactor(ctx, VerifyTwoFactorInput{
+				ChallengeToken: challengeToken,
+				Code:           code,
+				Metadata:       repository.SessionMetadata{UserAgent: "test-agent"},
+			})
+
+			if len(user.RecoveryCodeHashes) != tt.wantRemaining {
+				t.Errorf("len(RecoveryCodeHashes) = %d, want %d", len(user.RecoveryCodeHashes), tt.wantRemaining)
+			}
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("VerifyTwoFactor() error = %v, want %v", err, tt.wantErr)
+				}
+				if len(mockSessionRepo.sessions) != 0 {
+					t.Error("VerifyTwoFactor() should not create a session on failure")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("VerifyTwoFactor() unexpected error = %v", err)
+			}
+			if out.SessionID == "" || out.TwoFactorRequired {
+				t.Errorf("VerifyTwoFactor() = %+v, want a session", out)
+			}
+			if mockSessionRepo.sessions[out.SessionID] != user.ID {
+				t.Errorf("session user = %v, want %v", mockSessionRepo.sessions[out.SessionID], user.ID)
+			}
+			if out.SessionTTL != testSessionPolicy.TTL {
+				t.Errorf("SessionTTL = %v, want %v", out.SessionTTL, testSessionPolicy.TTL)
+			}
+			if _, ok := mockChallengeRepo.challenges[challengeToken]; ok {
+				t.Error("VerifyTwoFactor() should consume the challenge")
+			}
+
+			// チャレンジは再利用できない
+			if _, err := u.VerifyTwoFactor(ctx, VerifyTwoFactorInput{ChallengeToken: challengeToken, Code: code}); !errors.Is(err, ErrInvalidTwoFactorChallenge) {
+				t.Errorf("VerifyTwoFactor() with consumed challenge error = %v, want %v", err, ErrInvalidTwoFactorChallenge)
+			}
+		})
+	}
+}
+
+func TestUserUsecase_VerifyTwoFactor_Lockout(t *testing.T) {
+	const email = "test@example.com"
+	const password = "password123"
+	ctx := context.Background()
+
+	login := func(t *testing.T, u *UserUsecase) string {
+		t.Helper()
+		out, err := u.Login(ctx, LoginInput{Email: email, Password: password})
+		if err != nil {
+			t.Fatalf("Login() unexpected error = %v", err)
+		}
+		return out.ChallengeToken
+	}
+
+	t.Run("異常系: チャレンジを作り直してもコードの失敗回数は引き継がれる", func(t *testing.T) {
+		u, mockSessionRepo, _, user, _ := setupTwoFactorUsecase(t)
+
+		// ログイン（1回）と誤ったコード（1回）を繰り返し、アカウントの上限に近づける
+		for i := 0; i < (MaxFailedLoginAttempts-1)/2; i++ {
+			challengeToken := login(t, u)
+			if _, err := u.VerifyTwoFactor(ctx, VerifyTwoFactorInput{ChallengeToken: challengeToken, Code: "000000"}); !errors.Is(err, entity.ErrInvalidTwoFactorCode) {
+				t.Fatalf("VerifyTwoFactor() attempt %d error = %v, want %v", i+1, err, entity.ErrInvalidTwoFactorCode)
+			}
+		}
+
+		// 新しいチャレンジでも、上限に達した後は正しいコードを受け付けない
+		challengeToken := login(t, u)
+		code, _ := user.TOTPSecret.Code(u.now())
+		_, err := u.VerifyTwoFactor(ctx, VerifyTwoFactorInput{ChallengeToken: challengeToken, Code: code})
+		if !errors.Is(err, ErrAccountLocked) {
+			t.Fatalf("VerifyTwoFactor() error = %v, want ErrAccountLocked", err)
+		}
+		if len(mockSessionRepo.sessions) != 0 {
+			t.Error("VerifyTwoFactor() should not create a session while the account is locked")
+		}
+
+		if _, err := u.Login(ctx, LoginInput{Email: email, Password: password}); !errors.Is(err, ErrAccountLocked) {
+			t.Errorf("Login() error = %v, want ErrAccountLocked", err)
+		}
+	})
+
+	t.Run("異常系: パスワードが正しくても二要素認証の完了までは失敗回数がリセットされない", func(t *testing.T) {
+		u, _, _, _, _ := setupTwoFactorUsecase(t)
+
+		for i := 0; i < MaxFailedLoginAttempts-1; i++ {
+			if _, err := u.Login(ctx, LoginInput{Email: email, Password: "wrongpassword"}); !errors.Is(err, ErrInvalidCredentials) {
+				t.Fatalf("Login() attempt %d error = %v, want ErrInvalidCredentials", i+1, err)
+			}
+		}
+		login(t, u)
+
+		if _, err := u.Login(ctx, LoginInput{Email: email, Password: password}); !errors.Is(err, ErrAccountLocked) {
+			t.Errorf("Login() error = %v, want ErrAccountLocked", err)
+		}
+	})
+
+	t.Run("正常系: 二要素認証に成功すると失敗回数がリセットされる", func(t *testing.T) {
+		u, _, _, user, _ := setupTwoFactorUsecase(t)
+		limiter := u.rateLimiter.(*fakeRateLimiter)
+
+		challengeToken := login(t, u)
+		u.VerifyTwoFactor(ctx, VerifyTwoFactorInput{ChallengeToken: challengeToken, Code: "000000"})
+		code, _ := user.TOTPSecret.Code(u.now())
+		if _, err := u.VerifyTwoFactor(ctx, VerifyTwoFactorInput{ChallengeToken: challengeToken, Code: code}); err != nil {
+			t.Fatalf("VerifyTwoFactor() unexpected error = %v", err)
+		}
+
+		if n := len(limiter.attempts[failedLoginKey(email)]); n != 0 {
+			t.Errorf("recorded login attempts = %d, want 0", n)
+		}
+	})
+}
+
+func TestUserUsecase_DisableTwoFactor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 現在のコードで無効化するとパスワードだけでログインできる", func(t *testing.T) {
+		u, _, _, user, _ := setupTwoFactorUsecase(t)
+		code, _ := user.TOTPSecret.Code(u.now())
+
+		if err := u.DisableTwoFactor(ctx, user.ID, code); err != nil {
+			t.Fatalf("DisableTwoFactor() unexpected error = %v", err)
+		}
+
+		out, err := u.Login(ctx, LoginInput{Email: "test@example.com", Password: "password123"})
+		if err != nil {
+			t.Fatalf("Login() unexpected error = %v", err)
+		}
+		if out.TwoFactorRequired || out.SessionID == "" {
+			t.Errorf("Login() = %+v, want a session without two-factor challenge", out)
+		}
+	})
+
+	t.Run("異常系: コードが一致しない", func(t *testing.T) {
+		u, _, _, user, _ := setupTwoFactorUsecase(t)
+
+		if err := u.DisableTwoFactor(ctx, user.ID, "000000"); !errors.Is(err, entity.ErrInvalidTwoFactorCode) {
+			t.Errorf("DisableTwoFactor() error = %v, want %v", err, entity.ErrInvalidTwoFactorCode)
+		}
+		if !user.TwoFactorEnabled {
+			t.Error("TwoFactorEnabled should remain true when DisableTwoFactor fails")
+		}
+	})
+
+	t.Run("異常系: 失敗が上限に達すると正しいコードでもロックされる", func(t *testing.T) {
+		u, _, _, user, recoveryCodes := setupTwoFactorUsecase(t)
+		for i := 0; i < MaxFailedLoginAttempts; i++ {
+			u.DisableTwoFactor(ctx, user.ID, "000000")
+		}
+
+		if err := u.DisableTwoFactor(ctx, user.ID, recoveryCodes[0]); !errors.Is(err, ErrAccountLocked) {
+			t.Errorf("DisableTwoFactor() error = %v, want ErrAccountLocked", err)
+		}
+	})
+}
+
+func TestUserUsecase_RegenerateRecoveryCodes(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 発行し直すと以前のリカバリーコードではログインできない", func(t *testing.T) {
+		u, _, _, user, oldCodes := setupTwoFactorUsecase(t)
+		code, _ := user.TOTPSecret.Code(u.now())
+
+		newCodes, err := u.RegenerateRecoveryCodes(ctx, user.ID, code)
+		if err != nil {
+			t.Fatalf("RegenerateRecoveryCodes() unexpected error = %v", err)
+		}
+		if len(newCodes) != value.RecoveryCodeCount {
+			t.Errorf("len(recoveryCodes) = %d, want %d", len(newCodes), value.RecoveryCodeCount)
+		}
+
+		login, err := u.Login(ctx, LoginInput{Email: "test@example.com", Password: "password123"})
+		if err != nil {
+			t.Fatalf("Login() unexpected error = %v", err)
+		}
+		if _, err := u.VerifyTwoFactor(ctx, VerifyTwoFactorInput{ChallengeToken: login.ChallengeToken, Code: oldCodes[0]}); !errors.Is(err, entity.ErrInvalidTwoFactorCode) {
+			t.Errorf("VerifyTwoFactor() with old recovery code error = %v, want %v", err, entity.ErrInvalidTwoFactorCode)
+		}
+		if _, err := u.VerifyTwoFactor(ctx, VerifyTwoFactorInput{ChallengeToken: login.ChallengeToken, Code: newCodes[0]}); err != nil {
+			t.Errorf("VerifyTwoFactor() with new recovery code error = %v", err)
+		}
+	})
+
+	t.Run("異常系: コードが一致しない", func(t *testing.T) {
+		u, _, _, user, _ := setupTwoFactorUsecase(t)
+
+		if _, err := u.RegenerateRecoveryCodes(ctx, user.ID, "000000"); !errors.Is(err, entity.ErrInvalidTwoFactorCode) {
+			t.Errorf("RegenerateRecoveryCodes() error = %v, want %v", err, entity.ErrInvalidTwoFactorCode)
+		}
+	})
+
+	t.Run("異常系: 二要素認証が有効でない", func(t *testing.T) {
+		mockRepo := newMockUserRepository()
+		userService := service.NewUserService(mockRepo)
+		u := NewUserUsecase(mockRepo, userService, newMockSessionRepository(), newMockChallengeRepository(), newMockEmailSender(), newFakeRateLimiter(), testSessionPolicy)
+		user := mockRepo.addUser("test@example.com", "password123")
+
+		if _, err := u.RegenerateRecoveryCodes(ctx, user.ID, "123456"); !errors.Is(err, entity.ErrTwoFactorNotEnabled) {
+			t.Errorf("RegenerateRecoveryCodes() error = %v, want %v", err, entity.ErrTwoFactorNotEnabled)
+		}
+	})
+}
+
+func TestUserUsecase_GetUser(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   uuid.UUID
+		setup    func(*mockUserRepository) uuid.UUID
+		wantErr  bool
+		checkErr func(error) bool
+	}{
+		{
+			name: "正常系: ユーザー取得成功",
+			setup: func(m *mockUserRepository) uuid.UUID {
+				user := m.addUser("user@example.com", "password123")
+				return user.ID
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系: ユーザーが存在しない",
+			setup: func(m *mockUserRepository) uuid.UUID {
+				return uuid.New() // 存在しないID
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrUserNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := newMockUserRepository()
+			userID := tt.setup(mockRepo)
+
+			mockSessionRepo := newMockSessionRepository()
+			mockEmailSender := newMockEmailSender()
+			userService := service.NewUserService(mockRepo)
+			usecase := NewUserUsecase(mockRepo, userService, mockSessionRepo, newMockChallengeRepository(), mockEmailSender, newFakeRateLimiter(), testSessionPolicy)
+
+			user, err := usecase.GetUser(context.Background(), userID)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("GetUser() error = nil, want error")
+					return
+				}
+				if tt.checkErr != nil && !tt.checkErr(err) {
+					t.Errorf("GetUser() error = %v, want specific error", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("GetUser() unexpected error = %v", err)
+				return
+			}
+
+			if user == nil {
+				t.Error("GetUser() user = nil, want user")
+				return
+			}
+
+			if user.ID != userID {
+				t.Errorf("user ID = %v, want %v", user.ID, userID)
+			}
+		})
+	}
+}
+
+func TestUserUsecase_ChangePassword(t *testing.T) {
+	const validEmail = "user@example.com"
+	const currentPassword = "currentpass123"
+	const newPassword = "newpassword456"
+
+	tests := []struct {
+		name            string
+		currentPassword string
+		newPassword     string
+		setup           func(*mockUserRepository) uuid.UUID
+		wantErr         bool
+		checkErr        func(error) bool
+	}{
+		{
+			name:            "正常系: パスワード変更成功",
+			currentPassword: currentPassword,
+			newPassword:     newPassword,
+			setup: func(m *mockUserRepository) uuid.UUID {
+				user := m.addUser(validEmail, currentPassword)
+				return user.ID
+			},
+			wantErr: false,
+		},
+		{
+			name:            "異常系: ユーザーが存在しない",
+			currentPassword: currentPassword,
+			newPassword:     newPassword,
+			setup: func(m *mockUserRepository) uuid.UUID {
+				return uuid.New() // 存在しないID
+			},
 			wantErr: true,
 			checkErr: func(err error) bool {
 				return errors.Is(err, ErrUserNotFound)
@@ -533,11 +1365,13 @@ func TestUserUsecase_ChangePassword(t *testing.T) {
 			userID := tt.setup(mockRepo)
 
 			mockSessionRepo := newMockSessionRepository()
+			mockSessionRepo.sessions["current-session"] = userID
+			mockSessionRepo.sessions["other-device"] = userID
 			mockEmailSender := newMockEmailSender()
 			userService := service.NewUserService(mockRepo)
-			usecase := NewUserUsecase(mockRepo, userService, mockSessionRepo, mockEmailSender, 24*time.Hour)
+			usecase := NewUserUsecase(mockRepo, userService, mockSessionRepo, newMockChallengeRepository(), mockEmailSender, newFakeRateLimiter(), testSessionPolicy)
 
-			err := usecase.ChangePassword(context.Background(), userID, tt.currentPassword, tt.newPassword)
+			err := usecase.ChangePassword(context.Background(), userID, "current-session", tt.currentPassword, tt.newPassword)
 
 			if tt.wantErr {
 				if err == nil {
@@ -547,6 +1381,9 @@ func TestUserUsecase_ChangePassword(t *testing.T) {
 				if tt.checkErr != nil && !tt.checkErr(err) {
 					t.Errorf("ChangePassword() error = %v, want specific error", err)
 				}
+				if len(mockSessionRepo.sessions) != 2 {
+					t.Errorf("sessions = %d, want 2 (should not be invalidated on failure)", len(mockSessionRepo.sessions))
+				}
 				return
 			}
 
@@ -571,32 +1408,47 @@ func TestUserUsecase_ChangePassword(t *testing.T) {
 			if err := user.VerifyPassword(tt.currentPassword); err == nil {
 				t.Error("VerifyPassword() succeeded with old password, should fail")
 			}
+
+			// 現在のセッションのみ残り、他のセッションは無効化されているか確認
+			if _, ok := mockSessionRepo.sessions["current-session"]; !ok {
+				t.Error("current session should remain after password change")
+			}
+			if _, ok := mockSessionRepo.sessions["other-device"]; ok {
+				t.Error("other sessions should be invalidated after password change")
+			}
 		})
 	}
 }
 
-func TestUserUsecase_Logout(t *testing.T) {
+func TestUserUsecase_ListSessions(t *testing.T) {
+	userID := uuid.New()
+
 	tests := []struct {
 		name      string
-		setup     func(*mockSessionRepository) string
+		setup     func(*mockSessionRepository)
+		wantCount int
 		wantErr   bool
-		checkErr  func(error) bool
 	}{
 		{
-			name: "正常系: ログアウト成功",
-			setup: func(m *mockSessionRepository) string {
-				sessionID := uuid.New().String()
-				m.sessions[sessionID] = uuid.New()
-				return sessionID
+			name: "正常系: 自分のセッションのみ取得",
+			setup: func(m *mockSessionRepository) {
+				m.sessions["session-1"] = userID
+				m.sessions["session-2"] = userID
+				m.sessions["other-session"] = uuid.New()
 			},
-			wantErr: false,
+			wantCount: 2,
 		},
 		{
-			name: "正常系: 存在しないセッション",
-			setup: func(m *mockSessionRepository) string {
-				return uuid.New().String() // 存在しないセッションID
+			name:      "正常系: セッションなし",
+			setup:     func(m *mockSessionRepository) {},
+			wantCount: 0,
+		},
+		{
+			name: "異常系: リポジトリエラー",
+			setup: func(m *mockSessionRepository) {
+				m.err = errors.New("redis error")
 			},
-			wantErr: false, // 削除は冪等
+			wantErr: true,
 		},
 	}
 
@@ -604,18 +1456,154 @@ func TestUserUsecase_Logout(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := newMockUserRepository()
 			mockSessionRepo := newMockSessionRepository()
-			sessionID := tt.setup(mockSessionRepo)
-
-			mockEmailSender := newMockEmailSender()
+			tt.setup(mockSessionRepo)
 			userService := service.NewUserService(mockRepo)
-			usecase := NewUserUsecase(mockRepo, userService, mockSessionRepo, mockEmailSender, 24*time.Hour)
+			usecase := NewUserUsecase(mockRepo, userService, mockSessionRepo, newMockChallengeRepository(), newMockEmailSender(), newFakeRateLimiter(), testSessionPolicy)
 
-			err := usecase.Logout(context.Background(), sessionID)
+			sessions, err := usecase.ListSessions(context.Background(), userID)
 
 			if tt.wantErr {
 				if err == nil {
-					t.Error("Logout() error = nil, want error")
-					return
+					t.Error("ListSessions() error = nil, want error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("ListSessions() unexpected error = %v", err)
+				return
+			}
+
+			if len(sessions) != tt.wantCount {
+				t.Errorf("ListSessions() count = %d, want %d", len(sessions), tt.wantCount)
+			}
+			for _, session := range sessions {
+				if session.UserID != userID {
+					t.Errorf("ListSessions() returned session of user %v, want %v", session.UserID, userID)
+				}
+			}
+		})
+	}
+}
+
+func TestUserUsecase_RevokeSession(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name      string
+		sessionID string
+		setup     func(*mockSessionRepository)
+		wantErr   bool
+		checkErr  func(error) bool
+	}{
+		{
+			name:      "正常系: 自分のセッションを無効化",
+			sessionID: "session-1",
+			setup: func(m *mockSessionRepository) {
+				m.sessions["session-1"] = userID
+			},
+			wantErr: false,
+		},
+		{
+			name:      "異常系: セッションが存在しない",
+			sessionID: "nonexistent",
+			setup:     func(m *mockSessionRepository) {},
+			wantErr:   true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrSessionNotFound)
+			},
+		},
+		{
+			name:      "異常系: 他のユーザーのセッション",
+			sessionID: "other-session",
+			setup: func(m *mockSessionRepository) {
+				m.sessions["other-session"] = uuid.New()
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrSessionNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := newMockUserRepository()
+			mockSessionRepo := newMockSessionRepository()
+			tt.setup(mockSessionRepo)
+			userService := service.NewUserService(mockRepo)
+			usecase := NewUserUsecase(mockRepo, userService, mockSessionRepo, newMockChallengeRepository(), newMockEmailSender(), newFakeRateLimiter(), testSessionPolicy)
+
+			err := usecase.RevokeSession(context.Background(), userID, tt.sessionID)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("RevokeSession() error = nil, want error")
+					return
+				}
+				if tt.checkErr != nil && !tt.checkErr(err) {
+					t.Errorf("RevokeSession() error = %v, want specific error", err)
+				}
+				// 他のユーザーのセッションは削除されない
+				if _, ok := mockSessionRepo.sessions["other-session"]; !ok && tt.sessionID == "other-session" {
+					t.Error("other user's session should not be deleted")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("RevokeSession() unexpected error = %v", err)
+				return
+			}
+
+			if _, ok := mockSessionRepo.sessions[tt.sessionID]; ok {
+				t.Error("session should be deleted")
+			}
+		})
+	}
+}
+
+func TestUserUsecase_Logout(t *testing.T) {
+	tests := []struct {
+		name      string
+		setup     func(*mockSessionRepository) string
+		wantErr   bool
+		checkErr  func(error) bool
+	}{
+		{
+			name: "正常系: ログアウト成功",
+			setup: func(m *mockSessionRepository) string {
+				sessionID := uuid.New().String()
+				m.sessions[sessionID] = uuid.New()
+				return sessionID
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系: 存在しないセッション",
+			setup: func(m *mockSessionRepository) string {
+				return uuid.New().String() // 存在しないセッションID
+			},
+			wantErr: false, // 削除は冪等
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := newMockUserRepository()
+			mockSessionRepo := newMockSessionRepository()
+			sessionID := tt.setup(mockSessionRepo)
+
+			mockEmailSender := newMockEmailSender()
+			userService := service.NewUserService(mockRepo)
+			usecase := NewUserUsecase(mockRepo, userService, mockSessionRepo, newMockChallengeRepository(), mockEmailSender, newFakeRateLimiter(), testSessionPolicy)
+
+			err := usecase.Logout(context.Background(), sessionID)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Logout() error = nil, want error")
+					return
 				}
 				if tt.checkErr != nil && !tt.checkErr(err) {
 					t.Errorf("Logout() error = %v, want specific error", err)
@@ -636,3 +1624,504 @@ func TestUserUsecase_Logout(t *testing.T) {
 		})
 	}
 }
+
+func TestUserUsecase_RequestPasswordReset(t *testing.T) {
+	const validEmail = "user@example.com"
+
+	tests := []struct {
+		name       string
+		email      string
+		setup      func(*mockUserRepository, *mockEmailSender)
+		wantErr    bool
+		wantSent   bool
+		wantIssued bool
+	}{
+		{
+			name:  "正常系: トークンを発行してリセットメールを送信",
+			email: validEmail,
+			setup: func(m *mockUserRepository, e *mockEmailSender) {
+				m.addUser(validEmail, "password123")
+			},
+			wantSent:   true,
+			wantIssued: true,
+		},
+		{
+			name:  "正常系: 存在しないメールアドレスでもエラーを返さない",
+			email: "unknown@example.com",
+			setup: func(m *mockUserRepository, e *mockEmailSender) {},
+		},
+		{
+			name:  "正常系: 不正なメール形式でもエラーを返さない",
+			email: "invalid-email",
+			setup: func(m *mockUserRepository, e *mockEmailSender) {},
+		},
+		{
+			name:  "正常系: メール送信に失敗してもエラーを返さない",
+			email: validEmail,
+			setup: func(m *mockUserRepository, e *mockEmailSender) {
+				m.addUser(validEmail, "password123")
+				e.err = errors.New("smtp error")
+			},
+			wantIssued: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := newMockUserRepository()
+			mockSessionRepo := newMockSessionRepository()
+			mockEmailSender := newMockEmailSender()
+			tt.setup(mockRepo, mockEmailSender)
+
+			userService := service.NewUserService(mockRepo)
+			usecase := NewUserUsecase(mockRepo, userService, mockSessionRepo, newMockChallengeRepository(), mockEmailSender, newFakeRateLimiter(), testSessionPolicy)
+
+			err := usecase.RequestPasswordReset(context.Background(), tt.email)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("RequestPasswordReset() error = nil, want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("RequestPasswordReset() unexpected error = %v", err)
+			}
+
+			token, sent := mockEmailSender.resetTokens[tt.email]
+			if sent != tt.wantSent {
+				t.Errorf("reset email sent = %v, want %v", sent, tt.wantSent)
+			}
+
+			user, ok := mockRepo.users[tt.email]
+			if !tt.wantIssued {
+				if ok && user.PasswordResetToken != nil {
+					t.Error("PasswordResetToken should not be issued")
+				}
+				return
+			}
+			if !ok || user.PasswordResetToken == nil {
+				t.Fatal("PasswordResetToken should be issued")
+			}
+			if sent && user.PasswordResetToken.Hash() != value.HashPasswordResetToken(token) {
+				t.Error("stored hash does not match the emailed token")
+			}
+			if sent && user.PasswordResetToken.Hash() == token {
+				t.Error("raw token should not be stored")
+			}
+		})
+	}
+}
+
+func TestUserUsecase_ResetPassword(t *testing.T) {
+	const validEmail = "user@example.com"
+	const currentPassword = "currentpass123"
+	const newPassword = "newpassword456"
+
+	tests := []struct {
+		name        string
+		newPassword string
+		setup       func(*mockUserRepository) string
+		wantErr     bool
+		checkErr    func(error) bool
+	}{
+		{
+			name:        "正常系: パスワードをリセット",
+			newPassword: newPassword,
+			setup: func(m *mockUserRepository) string {
+				user := m.addUser(validEmail, currentPassword)
+				token, _ := user.IssuePasswordResetToken()
+				return token
+			},
+			wantErr: false,
+		},
+		{
+			name:        "異常系: 空のトークン",
+			newPassword: newPassword,
+			setup: func(m *mockUserRepository) string {
+				m.addUser(validEmail, currentPassword)
+				return ""
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrInvalidPasswordResetToken)
+			},
+		},
+		{
+			name:        "異常系: 存在しないトークン",
+			newPassword: newPassword,
+			setup: func(m *mockUserRepository) string {
+				user := m.addUser(validEmail, currentPassword)
+				_, _ = user.IssuePasswordResetToken()
+				return "unknown-token"
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrInvalidPasswordResetToken)
+			},
+		},
+		{
+			name:        "異常系: 期限切れのトークン",
+			newPassword: newPassword,
+			setup: func(m *mockUserRepository) string {
+				user := m.addUser(validEmail, currentPassword)
+				token, _ := user.IssuePasswordResetToken()
+				user.PasswordResetToken = value.ReconstructPasswordResetToken(user.PasswordResetToken.Hash(), time.Now().Add(-time.Minute))
+				return token
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrInvalidPasswordResetToken)
+			},
+		},
+		{
+			name:        "異常系: 再発行で無効になったトークン",
+			newPassword: newPassword,
+			setup: func(m *mockUserRepository) string {
+				user := m.addUser(validEmail, currentPassword)
+				oldToken, _ := user.IssuePasswordResetToken()
+				_, _ = user.IssuePasswordResetToken()
+				return oldToken
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrInvalidPasswordResetToken)
+			},
+		},
+		{
+			name:        "異常系: 新しいパスワードが短すぎる",
+			newPassword: "short",
+			setup: func(m *mockUserRepository) string {
+				user := m.addUser(validEmail, currentPassword)
+				token, _ := user.IssuePasswordResetToken()
+				return token
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, value.ErrPasswordTooShort)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := newMockUserRepository()
+			token := tt.setup(mockRepo)
+			user := mockRepo.users[validEmail]
+
+			mockSessionRepo := newMockSessionRepository()
+			otherUserID := uuid.New()
+			mockSessionRepo.sessions["session-1"] = user.ID
+			mockSessionRepo.sessions["session-2"] = user.ID
+			mockSessionRepo.sessions["other-session"] = otherUserID
+
+			mockEmailSender := newMockEmailSender()
+			userService := service.NewUserService(mockRepo)
+			usecase := NewUserUsecase(mockRepo, userService, mockSessionRepo, newMockChallengeRepository(), mockEmailSender, newFakeRateLimiter(), testSessionPolicy)
+
+			err := usecase.ResetPassword(context.Background(), token, tt.newPassword)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("ResetPassword() error = nil, want error")
+					return
+				}
+				if tt.checkErr != nil && !tt.checkErr(err) {
+					t.Errorf("ResetPassword() error = %v, want specific error", err)
+				}
+				if len(mockSessionRepo.sessions) != 3 {
+					t.Errorf("sessions = %d, want 3 (should not be invalidated on failure)", len(mockSessionRepo.sessions))
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ResetPassword() unexpected error = %v", err)
+			}
+
+			if err := user.VerifyPassword(tt.newPassword); err != nil {
+				t.Error("VerifyPassword() failed with new password")
+			}
+			if user.PasswordResetToken != nil {
+				t.Error("PasswordResetToken should be cleared after reset")
+			}
+
+			// 既存セッションは全て無効化され、他のユーザーのセッションは残る
+			if _, ok := mockSessionRepo.sessions["session-1"]; ok {
+				t.Error("session-1 should be invalidated")
+			}
+			if _, ok := mockSessionRepo.sessions["session-2"]; ok {
+				t.Error("session-2 should be invalidated")
+			}
+			if _, ok := mockSessionRepo.sessions["other-session"]; !ok {
+				t.Error("other user's session should remain")
+			}
+
+			// 同じトークンは二度使えない
+			if err := usecase.ResetPassword(context.Background(), token, "anotherpass789"); !errors.Is(err, ErrInvalidPasswordResetToken) {
+				t.Errorf("second ResetPassword() error = %v, want ErrInvalidPasswordResetToken", err)
+			}
+		})
+	}
+}
+
+func TestUserUsecase_RequestEmailChange(t *testing.T) {
+	const currentEmail = "user@example.com"
+	const newEmail = "new@example.com"
+	const password = "password123"
+
+	tests := []struct {
+		name            string
+		currentPassword string
+		newEmail        string
+		setup           func(*mockUserRepository, *mockEmailSender)
+		wantErr         bool
+		checkErr        func(error) bool
+	}{
+		{
+			name:            "正常系: 変更を受け付けて確認メールと通知を送信",
+			currentPassword: password,
+			newEmail:        newEmail,
+			setup:           func(m *mockUserRepository, e *mockEmailSender) {},
+		},
+		{
+			name:            "異常系: 現在のパスワードが不正",
+			currentPassword: "wrongpassword",
+			newEmail:        newEmail,
+			setup:           func(m *mockUserRepository, e *mockEmailSender) {},
+			wantErr:         true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrInvalidCredentials)
+			},
+		},
+		{
+			name:            "異常系: 現在と同じメールアドレス",
+			currentPassword: password,
+			newEmail:        currentEmail,
+			setup:           func(m *mockUserRepository, e *mockEmailSender) {},
+			wantErr:         true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, entity.ErrSameEmail)
+			},
+		},
+		{
+			name:            "異常系: 不正なメール形式",
+			currentPassword: password,
+			newEmail:        "invalid-email",
+			setup:           func(m *mockUserRepository, e *mockEmailSender) {},
+			wantErr:         true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, value.ErrInvalidEmail)
+			},
+		},
+		{
+			name:            "異常系: 他のユーザーが使用中のメールアドレス",
+			currentPassword: password,
+			newEmail:        newEmail,
+			setup: func(m *mockUserRepository, e *mockEmailSender) {
+				m.addUser(newEmail, "otherpassword123")
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, value.ErrEmailAlreadyExists)
+			},
+		},
+		{
+			name:            "異常系: 確認メールの送信に失敗",
+			currentPassword: password,
+			newEmail:        newEmail,
+			setup: func(m *mockUserRepository, e *mockEmailSender) {
+				e.err = errors.New("smtp error")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := newMockUserRepository()
+			mockEmailSender := newMockEmailSender()
+			user := mockRepo.addUser(currentEmail, password)
+			tt.setup(mockRepo, mockEmailSender)
+
+			userService := service.NewUserService(mockRepo)
+			usecase := NewUserUsecase(mockRepo, userService, newMockSessionRepository(), newMockChallengeRepository(), mockEmailSender, newFakeRateLimiter(), testSessionPolicy)
+
+			err := usecase.RequestEmailChange(context.Background(), user.ID, tt.currentPassword, tt.newEmail)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("RequestEmailChange() error = nil, want error")
+					return
+				}
+				if tt.checkErr != nil && !tt.checkErr(err) {
+					t.Errorf("RequestEmailChange() error = %v, want specific error", err)
+				}
+				if user.Email.String() != currentEmail {
+					t.Errorf("Email = %v, want %v (should not change)", user.Email.String(), currentEmail)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("RequestEmailChange() unexpected error = %v", err)
+			}
+
+			// 確認されるまでメールアドレスは変更されない
+			if user.Email.String() != currentEmail {
+				t.Errorf("Email = %v, want %v", user.Email.String(), currentEmail)
+			}
+			if user.PendingEmail == nil || user.PendingEmail.String() != newEmail {
+				t.Errorf("PendingEmail = %v, want %v", user.PendingEmail, newEmail)
+			}
+
+			token, sent := mockEmailSender.emailChangeTokens[newEmail]
+			if !sent {
+				t.Fatal("verification email should be sent to the new address")
+			}
+			if user.EmailChangeToken == nil || user.EmailChangeToken.Hash() != value.HashEmailChangeToken(token) {
+				t.Error("stored hash does not match the emailed token")
+			}
+			if len(mockEmailSender.emailChangeNotices) != 1 || mockEmailSender.emailChangeNotices[0] != currentEmail {
+				t.Errorf("notices = %v, want [%v]", mockEmailSender.emailChangeNotices, currentEmail)
+			}
+		})
+	}
+}
+
+func TestUserUsecase_ConfirmEmailChange(t *testing.T) {
+	const currentEmail = "user@example.com"
+	const newEmail = "new@example.com"
+	const password = "password123"
+
+	tests := []struct {
+		name     string
+		setup    func(*mockUserRepository, *entity.User) string
+		wantErr  bool
+		checkErr func(error) bool
+	}{
+		{
+			name: "正常系: メールアドレスを変更",
+			setup: func(m *mockUserRepository, user *entity.User) string {
+				token, _ := user.RequestEmailChange(newEmail)
+				return token
+			},
+		},
+		{
+			name: "異常系: 空のトークン",
+			setup: func(m *mockUserRepository, user *entity.User) string {
+				_, _ = user.RequestEmailChange(newEmail)
+				return ""
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrInvalidEmailChangeToken)
+			},
+		},
+		{
+			name: "異常系: 存在しないトークン",
+			setup: func(m *mockUserRepository, user *entity.User) string {
+				_, _ = user.RequestEmailChange(newEmail)
+				return "unknown-token"
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrInvalidEmailChangeToken)
+			},
+		},
+		{
+			name: "異常系: 期限切れのトークン",
+			setup: func(m *mockUserRepository, user *entity.User) string {
+				token, _ := user.RequestEmailChange(newEmail)
+				user.EmailChangeToken = value.ReconstructEmailChangeToken(user.EmailChangeToken.Hash(), time.Now().Add(-time.Minute))
+				return token
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrInvalidEmailChangeToken)
+			},
+		},
+		{
+			name: "異常系: 受付後に他のユーザーが同じメールアドレスを登録",
+			setup: func(m *mockUserRepository, user *entity.User) string {
+				token, _ := user.RequestEmailChange(newEmail)
+				m.addUser(newEmail, "otherpassword123")
+				return token
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, value.ErrEmailAlreadyExists)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := newMockUserRepository()
+			user := mockRepo.addUser(currentEmail, password)
+			token := tt.setup(mockRepo, user)
+
+			userService := service.NewUserService(mockRepo)
+			usecase := NewUserUsecase(mockRepo, userService, newMockSessionRepository(), newMockChallengeRepository(), newMockEmailSender(), newFakeRateLimiter(), testSessionPolicy)
+
+			err := usecase.ConfirmEmailChange(context.Background(), token)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("ConfirmEmailChange() error = nil, want error")
+					return
+				}
+				if tt.checkErr != nil && !tt.checkErr(err) {
+					t.Errorf("ConfirmEmailChange() error = %v, want specific error", err)
+				}
+				if user.Email.String() != currentEmail {
+					t.Errorf("Email = %v, want %v (should not change)", user.Email.String(), currentEmail)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ConfirmEmailChange() unexpected error = %v", err)
+			}
+
+			if user.Email.String() != newEmail {
+				t.Errorf("Email = %v, want %v", user.Email.String(), newEmail)
+			}
+			if user.PendingEmail != nil || user.EmailChangeToken != nil {
+				t.Error("pending email change should be cleared after confirmation")
+			}
+			if _, ok := mockRepo.users[newEmail]; !ok {
+				t.Error("user should be stored under the new email")
+			}
+
+			// トークンは一度きりの使用
+			if err := usecase.ConfirmEmailChange(context.Background(), token); !errors.Is(err, ErrInvalidEmailChangeToken) {
+				t.Errorf("second ConfirmEmailChange() error = %v, want ErrInvalidEmailChangeToken", err)
+			}
+		})
+	}
+}
+
+// duplicateEmailUserRepository は重複の確認後に同じメールアドレスが登録された場合を再現するため、
+// Update で一意制約違反に相当するエラーを返すUserRepositoryのモック
+type duplicateEmailUserRepository struct {
+	*mockUserRepository
+}
+
+func (m *duplicateEmailUserRepository) Update(ctx context.Context, user *entity.User) error {
+	return value.ErrEmailAlreadyExists
+}
+
+func TestUserUsecase_ConfirmEmailChange_DuplicateOnUpdate(t *testing.T) {
+	mockRepo := newMockUserRepository()
+	user := mockRepo.addUser("user@example.com", "password123")
+	token, _ := user.RequestEmailChange("new@example.com")
+
+	repo := &duplicateEmailUserRepository{mockUserRepository: mockRepo}
+	usecase := NewUserUsecase(repo, service.NewUserService(repo), newMockSessionRepository(), newMockChallengeRepository(), newMockEmailSender(), newFakeRateLimiter(), testSessionPolicy)
+
+	err := usecase.ConfirmEmailChange(context.Background(), token)
+	if err != value.ErrEmailAlreadyExists {
+		t.Errorf("ConfirmEmailChange() error = %v, want %v", err, value.ErrEmailAlreadyExists)
+	}
+}
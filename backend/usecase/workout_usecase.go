@@ -2,7 +2,12 @@ package usecase
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"iter"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,9 +25,25 @@ var (
 	ErrEmptyWorkoutSets = errors.New("workout must have at least one set")
 	// ErrWorkoutSetNotFound はワークアウトセットが見つからない場合のエラー
 	ErrWorkoutSetNotFound = errors.New("workout set not found")
+	// ErrInvalidWorkoutCursor はページネーションのカーソルが不正な場合のエラー
+	ErrInvalidWorkoutCursor = errors.New("invalid cursor")
+	// ErrInvalidWorkoutSort はワークアウト一覧の並び順が不正な場合のエラー
+	ErrInvalidWorkoutSort = errors.New("invalid sort order: must be date_desc or date_asc")
+	// ErrInvalidWorkoutPageLimit はワークアウト一覧の取得件数が不正な場合のエラー
+	ErrInvalidWorkoutPageLimit = errors.New("limit must be between 1 and 100")
+	// ErrInvalidMinScore はデイリースコアの下限が不正な場合のエラー
+	ErrInvalidMinScore = errors.New("min score must be between 0 and 100")
+)
+
+const (
+	// DefaultWorkoutPageLimit はワークアウト一覧の1ページあたりのデフォルト件数
+	DefaultWorkoutPageLimit int32 = 20
+	// MaxWorkoutPageLimit はワークアウト一覧の1ページあたりの最大件数
+	MaxWorkoutPageLimit int32 = 100
 )
 
 // SetInput はワークアウトセットの入力データを表す。
+// SetTypeがnilの場合は通常のセットとして記録する。RPEとRIRはどちらか一方のみ指定できる。
 type SetInput struct {
 	ExerciseID      uuid.UUID
 	SetNumber       int32
@@ -30,20 +51,74 @@ type SetInput struct {
 	Weight          float64
 	DurationSeconds *int32
 	Notes           *string
+	SetType         *entity.SetType
+	RPE             *float64
+	RIR             *int32
+}
+
+// UpdateWorkoutSetInput はワークアウトセット更新の入力データを表す。
+// nilのフィールドは更新しない。RPEまたはRIRを指定した場合、指定しなかった方は消去する。
+type UpdateWorkoutSetInput struct {
+	Reps            *int32
+	Weight          *float64
+	DurationSeconds *int32
+	Notes           *string
+	SetType         *entity.SetType
+	RPE             *float64
+	RIR             *int32
 }
 
 // RecordWorkoutInput はワークアウト記録の入力データを表す。
+// StartedAtがnilの場合はDateをセッションの開始時刻とする。
+// EndedAtがnilの場合は終了時刻なしとして記録する。
 type RecordWorkoutInput struct {
-	UserID uuid.UUID
-	Date   time.Time
-	Memo   *string
-	Sets   []SetInput
+	UserID    uuid.UUID
+	Date      time.Time
+	StartedAt *time.Time
+	EndedAt   *time.Time
+	Memo      *string
+	Sets      []SetInput
+	// ProgramID を指定した場合、ワークアウトをそのプログラムの現在の日として記録する
+	ProgramID *uuid.UUID
 }
 
 // RecordWorkoutOutput はワークアウト記録の出力データを表す。
 type RecordWorkoutOutput struct {
+	Workout         *entity.Workout
+	Sets            []*entity.WorkoutSet
+	PersonalRecords []*entity.PersonalRecord
+}
+
+// RecordImportedWorkoutsInput はインポートしたワークアウトの一括記録の入力データを表す。
+// Workouts の ProgramID は使用しない（インポートしたワークアウトはプログラムに紐付けない）。
+type RecordImportedWorkoutsInput struct {
+	UserID uuid.UUID
+	// Exercises はインポートのために新しく作成するカスタム種目
+	Exercises []*entity.Exercise
+	Workouts  []RecordWorkoutInput
+	// DryRun がtrueの場合は何も保存せず、重複の判定のみを行う
+	DryRun bool
+}
+
+// RecordImportedWorkoutsOutput はインポートしたワークアウトの一括記録の出力データを表す。
+type RecordImportedWorkoutsOutput struct {
+	// Workouts は入力と同じ順の記録結果
+	Workouts []ImportedWorkoutResult
+}
+
+// ImportedWorkoutResult はインポートしたワークアウト1件分の記録結果
+type ImportedWorkoutResult struct {
+	// Workout は記録したワークアウト。ドライランまたは重複の場合はnil
 	Workout *entity.Workout
-	Sets    []*entity.WorkoutSet
+	// Duplicate は同じワークアウトが既に記録されているため記録しなかったかどうか
+	Duplicate bool
+}
+
+// AddWorkoutSetsOutput はセット追加の出力データを表す。
+// PersonalRecords には追加したセットで更新された自己ベストが含まれる。
+type AddWorkoutSetsOutput struct {
+	Sets            []*entity.WorkoutSet
+	PersonalRecords []*entity.PersonalRecord
 }
 
 // WorkoutDetailOutput はワークアウト詳細の出力データを表す。
@@ -52,36 +127,102 @@ type WorkoutDetailOutput struct {
 	Sets    []*entity.WorkoutSet
 }
 
-// ContributionDataPoint はコントリビューションデータの1ポイントを表す。
+// ContributionDataPoint はコントリビューションデータの1ポイント（1日分）を表す。
+// DailyScoreはその日の全セッションのデイリースコアの合計（上限100）。
 type ContributionDataPoint struct {
-	Date       time.Time
-	DailyScore int32
+	Date         time.Time
+	DailyScore   int32
+	SessionCount int32
+}
+
+// WorkoutFilter はワークアウト一覧の絞り込み条件を表す（リポジトリ層の型のエイリアス）。
+type WorkoutFilter = repository.WorkoutFilter
+
+// WorkoutSortOrder はワークアウト一覧の並び順を表す（リポジトリ層の型のエイリアス）。
+type WorkoutSortOrder = repository.WorkoutSortOrder
+
+// GetUserWorkoutsInput はワークアウト一覧取得の入力データを表す。
+// Sortが空の場合は新しい順、Limitがnilの場合はDefaultWorkoutPageLimit件を取得する。
+// Cursorには前ページのNextCursorを指定する（nilの場合は先頭ページ）。
+// WithDetailsがtrueの場合、各ワークアウトのセット・種目・サマリーも取得する。
+type GetUserWorkoutsInput struct {
+	UserID      uuid.UUID
+	Filter      WorkoutFilter
+	Sort        WorkoutSortOrder
+	Cursor      *string
+	Limit       *int32
+	WithDetails bool
+}
+
+// GetUserWorkoutsOutput はワークアウト一覧取得の出力データを表す。
+// NextCursorは次のページが存在しない場合nilとなる。
+// DetailsはワークアウトIDをキーとし、WithDetailsがfalseの場合はnilとなる。
+type GetUserWorkoutsOutput struct {
+	Workouts   []*entity.Workout
+	NextCursor *string
+	Details    map[uuid.UUID]*WorkoutListDetail
+}
+
+// WorkoutExerciseSummary はワークアウト内で実施された種目を表す。
+type WorkoutExerciseSummary struct {
+	ID       uuid.UUID
+	Name     string
+	BodyPart *entity.BodyPart
+}
+
+// WorkoutSummary はワークアウト1件分の集計値を表す。
+// TotalVolumeはウォームアップのセットを含めない。
+// BodyPartsは身体部位が設定された種目のみを対象とし、名前順で重複なく並ぶ。
+type WorkoutSummary struct {
+	TotalVolume   float64
+	SetCount      int32
+	ExerciseCount int32
+	BodyParts     []entity.BodyPart
+}
+
+// WorkoutListDetail はワークアウト一覧で展開表示するセット・種目・サマリーを表す。
+// Exercisesは最初に実施されたセットの順で重複なく並ぶ。
+type WorkoutListDetail struct {
+	Sets      []*entity.WorkoutSet
+	Exercises []WorkoutExerciseSummary
+	Summary   WorkoutSummary
 }
 
 // WeightProgressionPoint は日別の最大推定1RMを表す（レスポンス用エイリアス）。
 type WeightProgressionPoint = repository.WeightProgressionPoint
 
+// WorkoutExportRow はエクスポートするワークアウトとセット1件の組を表す（レスポンス用エイリアス）。
+type WorkoutExportRow = repository.WorkoutExportRow
+
 // WorkoutUsecaseInterface はWorkoutUsecaseのインターフェース。
 // テスト時のモック作成に使用する。
 type WorkoutUsecaseInterface interface {
 	RecordWorkout(ctx context.Context, input RecordWorkoutInput) (*RecordWorkoutOutput, error)
+	RecordImportedWorkouts(ctx context.Context, input RecordImportedWorkoutsInput) (*RecordImportedWorkoutsOutput, error)
 	GetWorkout(ctx context.Context, userID, workoutID uuid.UUID) (*WorkoutDetailOutput, error)
-	GetUserWorkouts(ctx context.Context, userID uuid.UUID, startDate, endDate *time.Time) ([]*entity.Workout, error)
+	GetUserWorkouts(ctx context.Context, input GetUserWorkoutsInput) (*GetUserWorkoutsOutput, error)
 	UpdateWorkoutMemo(ctx context.Context, userID, workoutID uuid.UUID, memo *string) (*entity.Workout, error)
-	AddWorkoutSets(ctx context.Context, userID, workoutID uuid.UUID, sets []SetInput) ([]*entity.WorkoutSet, error)
+	AddWorkoutSets(ctx context.Context, userID, workoutID uuid.UUID, sets []SetInput) (*AddWorkoutSetsOutput, error)
+	UpdateWorkoutSet(ctx context.Context, userID, workoutSetID uuid.UUID, input UpdateWorkoutSetInput) (*entity.WorkoutSet, error)
 	DeleteWorkoutSet(ctx context.Context, userID uuid.UUID, workoutSetID uuid.UUID) error
 	DeleteWorkout(ctx context.Context, userID, workoutID uuid.UUID) error
 	GetContributionData(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]ContributionDataPoint, error)
-	GetWeightProgression(ctx context.Context, userID, exerciseID uuid.UUID) ([]WeightProgressionPoint, error)
+	GetWeightProgression(ctx context.Context, userID, exerciseID uuid.UUID, formula *entity.OneRMFormula) ([]WeightProgressionPoint, error)
+	ExportWorkouts(ctx context.Context, userID uuid.UUID, startDate, endDate *time.Time) iter.Seq2[WorkoutExportRow, error]
 }
 
 // WorkoutUsecase はワークアウトに関するビジネスロジックを提供する。
 // ワークアウトの記録、取得、更新、削除、コントリビューションデータ取得のユースケースを実装する。
 type WorkoutUsecase struct {
-	workoutRepo    repository.WorkoutRepository
-	workoutSetRepo repository.WorkoutSetRepository
-	exerciseRepo   repository.ExerciseRepository
-	workoutService *service.WorkoutService
+	workoutRepo        repository.WorkoutRepository
+	workoutSetRepo     repository.WorkoutSetRepository
+	exerciseRepo       repository.ExerciseRepository
+	profileRepo        repository.ProfileRepository
+	personalRecordRepo repository.PersonalRecordRepository
+	programRepo        repository.ProgramRepository
+	workoutService     *service.WorkoutService
+	dailyScoreService  *service.DailyScoreService
+	txManager          repository.TransactionManager
 }
 
 // NewWorkoutUsecase はWorkoutUsecaseの新しいインスタンスを生成する。
@@ -90,7 +231,12 @@ type WorkoutUsecase struct {
 //   - workoutRepo: ワークアウトデータの永続化を担当するリポジトリ
 //   - workoutSetRepo: ワークアウトセットデータの永続化を担当するリポジトリ
 //   - exerciseRepo: エクササイズデータの永続化を担当するリポジトリ
-//   - workoutService: ワークアウトの日付ユニーク性チェックなどのドメインサービス
+//   - profileRepo: ユーザーが選択した1RM計算式を参照するためのプロフィールリポジトリ
+//   - personalRecordRepo: 自己ベスト更新イベントの永続化を担当するリポジトリ
+//   - programRepo: ワークアウト記録時にプログラムの進捗を進めるためのプログラムリポジトリ
+//   - workoutService: 日付ごとのデイリースコア集計などのドメインサービス
+//   - dailyScoreService: ユーザーが選択した計算方法でデイリースコアを計算するドメインサービス
+//   - txManager: ワークアウトとセットの書き込みを1トランザクションにまとめるトランザクションマネージャー
 //
 // 戻り値:
 //   - *WorkoutUsecase: 生成されたWorkoutUsecaseインスタンス
@@ -98,96 +244,249 @@ func NewWorkoutUsecase(
 	workoutRepo repository.WorkoutRepository,
 	workoutSetRepo repository.WorkoutSetRepository,
 	exerciseRepo repository.ExerciseRepository,
+	profileRepo repository.ProfileRepository,
+	personalRecordRepo repository.PersonalRecordRepository,
+	programRepo repository.ProgramRepository,
 	workoutService *service.WorkoutService,
+	dailyScoreService *service.DailyScoreService,
+	txManager repository.TransactionManager,
 ) *WorkoutUsecase {
 	return &WorkoutUsecase{
-		workoutRepo:    workoutRepo,
-		workoutSetRepo: workoutSetRepo,
-		exerciseRepo:   exerciseRepo,
-		workoutService: workoutService,
+		workoutRepo:        workoutRepo,
+		workoutSetRepo:     workoutSetRepo,
+		exerciseRepo:       exerciseRepo,
+		profileRepo:        profileRepo,
+		personalRecordRepo: personalRecordRepo,
+		programRepo:        programRepo,
+		workoutService:     workoutService,
+		dailyScoreService:  dailyScoreService,
+		txManager:          txManager,
 	}
 }
 
-// RecordWorkout は新しいワークアウトを記録する。
-// 日付重複チェック、セット空チェック、エクササイズ存在確認を実施し、
+// RecordWorkout は新しいワークアウト（セッション）を記録する。
+// 同じ日に複数のセッションを記録できる。
+// セット空チェック、セッション時刻の検証、エクササイズ存在確認を実施し、
 // ワークアウトとセットを永続化した後、デイリースコアを計算・更新する。
+// 平均負荷量との比でスコアを計算する場合は、記録したワークアウトを直近の平均負荷量に含む後続のワークアウトのスコアも再計算する。
+// 各セットの推定1RMはユーザーがプロフィールで選択した公式で計算する。
+// 記録したセット（ウォームアップセットを除く）が種目ごとの自己ベスト（推定1RM、nRM、セッションボリューム）を更新した場合は、
+// 自己ベスト更新イベントとして保存し、出力に含める。
+// ProgramID が指定された場合はワークアウトをそのプログラムの現在の日として紐付け、
+// 記録した種目（ウォームアップセットを除く）がその日の全種目を含めばプログラムを次の日へ進める。
+// 紐付けていないプログラムは進めない。
+// ワークアウト・セット・デイリースコア・自己ベスト・プログラム進捗の書き込みは1トランザクションで行い、
+// 途中で失敗した場合は何も保存されない。
 //
 // パラメータ:
 //   - ctx: リクエストのコンテキスト
 //   - input: ワークアウト記録の入力データ
 //
 // 戻り値:
-//   - *RecordWorkoutOutput: 作成されたワークアウトとセット
+//   - *RecordWorkoutOutput: 作成されたワークアウト、セット、更新された自己ベスト
 //   - error: 以下のエラーが返される可能性がある
-//     - service.ErrDuplicateWorkoutDate: 同日に既にワークアウトが存在
 //     - ErrEmptyWorkoutSets: セットが空
+//     - entity.ErrInvalidWorkoutEndTime: 終了時刻が開始時刻より前
 //     - ErrExerciseNotFound: 指定されたエクササイズが存在しない
+//     - ErrProgramNotFound: 指定されたプログラムが存在しない
+//     - ErrProgramAccessDenied: 指定されたプログラムへのアクセス権がない
+//     - ErrProgramCompleted: 指定されたプログラムは全日程を終えている
 //     - entity.ErrInvalidSetNumber: セット番号が不正
 //     - entity.ErrInvalidReps: レップ数が不正
 //     - entity.ErrInvalidExerciseWeight: 重量が不正
+//     - entity.ErrInvalidSetType, entity.ErrInvalidRPE, entity.ErrInvalidRIR, entity.ErrRPEAndRIRBothSet: セットの種類・RPE・RIRが不正
 //     - その他のリポジトリエラー
 func (u *WorkoutUsecase) RecordWorkout(ctx context.Context, input RecordWorkoutInput) (*RecordWorkoutOutput, error) {
-	// 日付重複チェック（ドメインサービスに委譲）
-	if err := u.workoutService.CheckDateUniqueness(ctx, input.UserID, input.Date); err != nil {
-		return nil, err
-	}
-
 	// セット空チェック
 	if len(input.Sets) == 0 {
 		return nil, ErrEmptyWorkoutSets
 	}
 
+	// ワークアウト作成
+	workout, err := newWorkoutFromInput(input)
+	if err != nil {
+		return nil, err
+	}
+
 	// 全エクササイズIDの存在確認
-	for _, setInput := range input.Sets {
-		_, err := u.exerciseRepo.FindByID(ctx, setInput.ExerciseID)
-		if err != nil {
-			return nil, ErrExerciseNotFound
+	if err := u.checkExercisesExist(ctx, input.UserID, input.Sets); err != nil {
+		return nil, err
+	}
+
+	// 紐付けるプログラムのオーナーシップ・進捗確認
+	var program *entity.Program
+	if input.ProgramID != nil {
+		found, err := u.programRepo.FindByID(ctx, *input.ProgramID)
+		if err != nil || found == nil {
+			return nil, ErrProgramNotFound
 		}
+		if !found.IsOwnedBy(input.UserID) {
+			return nil, ErrProgramAccessDenied
+		}
+		if found.IsCompleted() {
+			return nil, ErrProgramCompleted
+		}
+		program = found
 	}
 
-	// ワークアウト作成
-	workout := entity.NewWorkout(input.UserID, input.Date)
-	if input.Memo != nil {
-		workout.UpdateMemo(input.Memo)
+	formula, err := u.oneRMFormulaFor(ctx, input.UserID)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := u.workoutRepo.Create(ctx, workout); err != nil {
+	var sets []*entity.WorkoutSet
+	var records []*entity.PersonalRecord
+	err = u.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		if err := u.workoutRepo.Create(ctx, workout); err != nil {
+			return err
+		}
+
+		if program != nil {
+			if err := u.programRepo.LinkWorkout(ctx, &entity.ProgramWorkout{
+				WorkoutID: workout.ID,
+				ProgramID: program.ID,
+				DayIndex:  program.CurrentDayIndex,
+			}); err != nil {
+				return err
+			}
+		}
+
+		created, err := u.createSets(ctx, workout.ID, input.Sets, formula)
+		if err != nil {
+			return err
+		}
+		sets = created
+
+		// デイリースコア計算・更新
+		if err := u.recalculateDailyScore(ctx, workout, sets); err != nil {
+			return err
+		}
+		if err := u.recalculateFollowingDailyScores(ctx, input.UserID, workout.Date, workout.Date); err != nil {
+			return err
+		}
+
+		// 自己ベスト判定・保存
+		records, err = u.recordPersonalRecords(ctx, workout, sets, formula)
+		if err != nil {
+			return err
+		}
+
+		// 紐付けたプログラムの進捗更新
+		return u.advanceLinkedProgram(ctx, workout.ID, sets)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	// セット作成
-	sets := make([]*entity.WorkoutSet, 0, len(input.Sets))
-	for _, setInput := range input.Sets {
-		workoutSet, err := entity.NewWorkoutSet(workout.ID, setInput.ExerciseID, setInput.SetNumber, setInput.Reps, setInput.Weight)
+	return &RecordWorkoutOutput{
+		Workout:         workout,
+		Sets:            sets,
+		PersonalRecords: records,
+	}, nil
+}
+
+// RecordImportedWorkouts は他のアプリからインポートしたワークアウトを一括で記録する。
+// 同じ日付・開始日時で同じセット（種目・セット番号・レップ数・重量）のワークアウトが既に記録されている場合や、
+// 入力内で重複している場合は記録せず、重複として返す（同じファイルを再度インポートしても二重に記録しない）。
+// バックアップの復元のため、RecordWorkoutと異なりセットのないワークアウトも記録する。
+// インポートしたワークアウトはプログラムに紐付けず、プログラムの進捗も進めない。
+// 記録後、インポートしたワークアウトと、それを直近の平均負荷量に含む後続のワークアウトのデイリースコアを再計算し、
+// 記録した種目の自己ベストを全履歴から時系列で再構築する。
+// カスタム種目の作成を含む全ての書き込みは1トランザクションで行い、途中で失敗した場合は何も保存されない。
+//
+// パラメータ:
+//   - ctx: リクエストのコンテキスト
+//   - input: 作成するカスタム種目と記録するワークアウト
+//
+// 戻り値:
+//   - *RecordImportedWorkoutsOutput: 入力と同じ順の記録結果
+//   - error: 以下のエラーが返される可能性がある
+//     - entity.ErrInvalidWorkoutEndTime: 終了時刻が開始時刻より前のワークアウトがある
+//     - セットの値の検証エラー（RecordWorkoutと同じ）
+//     - その他のリポジトリエラー
+func (u *WorkoutUsecase) RecordImportedWorkouts(ctx context.Context, input RecordImportedWorkoutsInput) (*RecordImportedWorkoutsOutput, error) {
+	workouts := make([]*entity.Workout, len(input.Workouts))
+	for i, workoutInput := range input.Workouts {
+		workout, err := newWorkoutFromInput(workoutInput)
+		if err != nil {
+			return nil, err
+		}
+		workouts[i] = workout
+	}
+
+	output := &RecordImportedWorkoutsOutput{Workouts: make([]ImportedWorkoutResult, len(workouts))}
+
+	if input.DryRun {
+		history, err := u.loadWorkoutHistory(ctx, input.UserID)
 		if err != nil {
 			return nil, err
 		}
+		for i, duplicate := range history.markDuplicates(workouts, input.Workouts) {
+			output.Workouts[i].Duplicate = duplicate
+		}
+		return output, nil
+	}
 
-		if setInput.DurationSeconds != nil {
-			if err := workoutSet.UpdateDuration(setInput.DurationSeconds); err != nil {
-				return nil, err
+	formula, err := u.oneRMFormulaFor(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		for _, exercise := range input.Exercises {
+			if err := u.exerciseRepo.Create(ctx, exercise); err != nil {
+				return err
 			}
 		}
-		if setInput.Notes != nil {
-			workoutSet.UpdateNotes(setInput.Notes)
+
+		history, err := u.loadWorkoutHistory(ctx, input.UserID)
+		if err != nil {
+			return err
 		}
+		duplicates := history.markDuplicates(workouts, input.Workouts)
 
-		if err := u.workoutSetRepo.Create(ctx, workoutSet); err != nil {
-			return nil, err
+		var recorded []*entity.Workout
+		var exerciseIDs []uuid.UUID
+		seen := make(map[uuid.UUID]bool)
+		for i, workout := range workouts {
+			if duplicates[i] {
+				output.Workouts[i].Duplicate = true
+				continue
+			}
+
+			if err := u.workoutRepo.Create(ctx, workout); err != nil {
+				return err
+			}
+			sets, err := u.createSets(ctx, workout.ID, input.Workouts[i].Sets, formula)
+			if err != nil {
+				return err
+			}
+			history.setsByWorkout[workout.ID] = sets
+			for _, set := range sets {
+				if !seen[set.ExerciseID] {
+					seen[set.ExerciseID] = true
+					exerciseIDs = append(exerciseIDs, set.ExerciseID)
+				}
+			}
+
+			recorded = append(recorded, workout)
+			output.Workouts[i].Workout = workout
+		}
+		if len(recorded) == 0 {
+			return nil
 		}
 
-		sets = append(sets, workoutSet)
-	}
+		if err := u.recalculateImportedDailyScores(ctx, input.UserID, history.setsByWorkout, recorded); err != nil {
+			return err
+		}
 
-	// デイリースコア計算・更新
-	if err := u.recalculateDailyScore(ctx, workout, sets); err != nil {
+		return u.refreshPersonalRecords(ctx, input.UserID, exerciseIDs, formula)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return &RecordWorkoutOutput{
-		Workout: workout,
-		Sets:    sets,
-	}, nil
+	return output, nil
 }
 
 // GetWorkout はワークアウトの詳細を取得する。
@@ -221,23 +520,85 @@ func (u *WorkoutUsecase) GetWorkout(ctx context.Context, userID, workoutID uuid.
 	}, nil
 }
 
-// GetUserWorkouts はユーザーのワークアウト一覧を取得する。
-// 日付範囲が指定された場合はフィルタリングして返す。
+// GetUserWorkouts はユーザーのワークアウト一覧をキーセットページネーションで取得する。
+// 絞り込み・並び替えはリポジトリ層でSQLとして実行し、1件多く取得して次ページの有無を判定する。
+// WithDetailsが指定された場合、ページ内の全ワークアウトのセットを1クエリでまとめて取得する。
 //
 // パラメータ:
 //   - ctx: リクエストのコンテキスト
-//   - userID: ユーザーID
-//   - startDate: 開始日（nilの場合はフィルタリングなし）
-//   - endDate: 終了日（nilの場合はフィルタリングなし）
+//   - input: 絞り込み条件・並び順・カーソル・取得件数
 //
 // 戻り値:
-//   - []*entity.Workout: ワークアウトのリスト
-//   - error: リポジトリエラー
-func (u *WorkoutUsecase) GetUserWorkouts(ctx context.Context, userID uuid.UUID, startDate, endDate *time.Time) ([]*entity.Workout, error) {
-	if startDate != nil && endDate != nil {
-		return u.workoutRepo.FindByUserIDAndDateRange(ctx, userID, *startDate, *endDate)
+//   - *GetUserWorkoutsOutput: ワークアウトのリストと次ページのカーソル
+//   - error: 以下のエラーが返される可能性がある
+//     - ErrInvalidWorkoutCursor: カーソルが不正
+//     - ErrInvalidWorkoutSort: 並び順が不正
+//     - ErrInvalidWorkoutPageLimit: 取得件数が1〜100の範囲外
+//     - ErrInvalidMinScore: デイリースコアの下限が0〜100の範囲外
+//     - entity.ErrInvalidBodyPart: 身体部位が不正
+//     - その他のリポジトリエラー
+func (u *WorkoutUsecase) GetUserWorkouts(ctx context.Context, input GetUserWorkoutsInput) (*GetUserWorkoutsOutput, error) {
+	order := input.Sort
+	if order == "" {
+		order = repository.WorkoutSortDateDesc
+	}
+	if order != repository.WorkoutSortDateDesc && order != repository.WorkoutSortDateAsc {
+		return nil, ErrInvalidWorkoutSort
+	}
+
+	limit := DefaultWorkoutPageLimit
+	if input.Limit != nil {
+		if *input.Limit < 1 || *input.Limit > MaxWorkoutPageLimit {
+			return nil, ErrInvalidWorkoutPageLimit
+		}
+		limit = *input.Limit
+	}
+
+	if input.Filter.MinScore != nil && (*input.Filter.MinScore < 0 || *input.Filter.MinScore > entity.MaxDailyScore) {
+		return nil, ErrInvalidMinScore
+	}
+	if input.Filter.BodyPart != nil {
+		if err := entity.ValidateBodyPart(*input.Filter.BodyPart); err != nil {
+			return nil, err
+		}
+	}
+
+	var after *repository.WorkoutCursor
+	if input.Cursor != nil {
+		cursor, err := decodeWorkoutCursor(*input.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		after = cursor
+	}
+
+	workouts, err := u.workoutRepo.FindPage(ctx, repository.WorkoutPageQuery{
+		UserID: input.UserID,
+		Filter: input.Filter,
+		Sort:   order,
+		After:  after,
+		Limit:  limit + 1,
+	})
+	if err != nil {
+		return nil, err
 	}
-	return u.workoutRepo.FindByUserID(ctx, userID)
+
+	output := &GetUserWorkoutsOutput{Workouts: workouts}
+	if int32(len(workouts)) > limit {
+		output.Workouts = workouts[:limit]
+		next := encodeWorkoutCursor(output.Workouts[limit-1])
+		output.NextCursor = &next
+	}
+
+	if input.WithDetails {
+		details, err := u.getWorkoutListDetails(ctx, output.Workouts)
+		if err != nil {
+			return nil, err
+		}
+		output.Details = details
+	}
+
+	return output, nil
 }
 
 // UpdateWorkoutMemo はワークアウトのメモを更新する。
@@ -271,7 +632,12 @@ func (u *WorkoutUsecase) UpdateWorkoutMemo(ctx context.Context, userID, workoutI
 }
 
 // AddWorkoutSets は既存のワークアウトにセットを追加する。
-// オーナーシップチェック、エクササイズ存在確認を実施し、セットを追加した後、デイリースコアを再計算する。
+// オーナーシップチェック、エクササイズ存在確認を実施し、セットを追加した後、デイリースコアを再計算する
+// （平均負荷量との比で計算する場合は後続のワークアウトのスコアも再計算する）。
+// 各セットの推定1RMはユーザーがプロフィールで選択した公式で計算する。
+// 追加したセットが自己ベストを更新した場合は、自己ベスト更新イベントとして保存する。
+// ワークアウトがプログラムに紐付いている場合は、全セットでその日を満たせばプログラムを次の日へ進める。
+// セットの追加、デイリースコアの更新、自己ベストの保存、プログラム進捗の更新は1トランザクションで行う。
 //
 // パラメータ:
 //   - ctx: リクエストのコンテキスト
@@ -280,7 +646,7 @@ func (u *WorkoutUsecase) UpdateWorkoutMemo(ctx context.Context, userID, workoutI
 //   - sets: 追加するセットの入力データ
 //
 // 戻り値:
-//   - []*entity.WorkoutSet: 追加されたセット
+//   - *AddWorkoutSetsOutput: 追加されたセットと更新された自己ベスト
 //   - error: 以下のエラーが返される可能性がある
 //     - ErrWorkoutNotFound: ワークアウトが存在しない
 //     - ErrWorkoutAccessDenied: アクセス権がない
@@ -288,61 +654,190 @@ func (u *WorkoutUsecase) UpdateWorkoutMemo(ctx context.Context, userID, workoutI
 //     - entity.ErrInvalidSetNumber: セット番号が不正
 //     - entity.ErrInvalidReps: レップ数が不正
 //     - entity.ErrInvalidExerciseWeight: 重量が不正
+//     - entity.ErrInvalidSetType, entity.ErrInvalidRPE, entity.ErrInvalidRIR, entity.ErrRPEAndRIRBothSet: セットの種類・RPE・RIRが不正
 //     - その他のリポジトリエラー
-func (u *WorkoutUsecase) AddWorkoutSets(ctx context.Context, userID, workoutID uuid.UUID, sets []SetInput) ([]*entity.WorkoutSet, error) {
+func (u *WorkoutUsecase) AddWorkoutSets(ctx context.Context, userID, workoutID uuid.UUID, sets []SetInput) (*AddWorkoutSetsOutput, error) {
 	workout, err := u.getWorkoutWithOwnershipCheck(ctx, userID, workoutID)
 	if err != nil {
 		return nil, err
 	}
 
 	// 全エクササイズIDの存在確認
-	for _, setInput := range sets {
-		_, err := u.exerciseRepo.FindByID(ctx, setInput.ExerciseID)
+	if err := u.checkExercisesExist(ctx, userID, sets); err != nil {
+		return nil, err
+	}
+
+	formula, err := u.oneRMFormulaFor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdSets []*entity.WorkoutSet
+	var records []*entity.PersonalRecord
+	err = u.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		created, err := u.createSets(ctx, workoutID, sets, formula)
 		if err != nil {
-			return nil, ErrExerciseNotFound
+			return err
 		}
-	}
+		createdSets = created
 
-	// セット作成
-	createdSets := make([]*entity.WorkoutSet, 0, len(sets))
-	for _, setInput := range sets {
-		workoutSet, err := entity.NewWorkoutSet(workoutID, setInput.ExerciseID, setInput.SetNumber, setInput.Reps, setInput.Weight)
+		// 全セットを取得してデイリースコアを再計算
+		allSets, err := u.workoutSetRepo.FindByWorkoutID(ctx, workoutID)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		if setInput.DurationSeconds != nil {
-			if err := workoutSet.UpdateDuration(setInput.DurationSeconds); err != nil {
-				return nil, err
-			}
+		if err := u.recalculateDailyScore(ctx, workout, allSets); err != nil {
+			return err
 		}
-		if setInput.Notes != nil {
-			workoutSet.UpdateNotes(setInput.Notes)
+		if err := u.recalculateFollowingDailyScores(ctx, userID, workout.Date, workout.Date); err != nil {
+			return err
 		}
 
-		if err := u.workoutSetRepo.Create(ctx, workoutSet); err != nil {
-			return nil, err
+		// 自己ベスト判定・保存
+		records, err = u.recordPersonalRecords(ctx, workout, createdSets, formula)
+		if err != nil {
+			return err
 		}
 
-		createdSets = append(createdSets, workoutSet)
+		// 紐付けたプログラムの進捗更新
+		return u.advanceLinkedProgram(ctx, workout.ID, allSets)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// 全セットを取得してデイリースコアを再計算
-	allSets, err := u.workoutSetRepo.FindByWorkoutID(ctx, workoutID)
+	return &AddWorkoutSetsOutput{
+		Sets:            createdSets,
+		PersonalRecords: records,
+	}, nil
+}
+
+// UpdateWorkoutSet はワークアウトセットを部分更新する。
+// セットの存在確認、ワークアウトのオーナーシップチェックを実施し、
+// レップ数・重量が変更された場合は、ユーザーが選択した公式で推定1RMを再計算する。
+// レップ数・重量・セットの種類が変更された場合は、その種目の自己ベストを再構築する。
+// デイリースコアを再計算し、平均負荷量との比で計算する場合は後続のワークアウトのスコアも再計算する。
+// ワークアウトがプログラムに紐付いている場合は、全セットでその日を満たせばプログラムを次の日へ進める。
+// セットの更新、デイリースコアの再計算、自己ベストの再構築、プログラム進捗の更新は1トランザクションで行う。
+//
+// パラメータ:
+//   - ctx: リクエストのコンテキスト
+//   - userID: リクエスト元のユーザーID
+//   - workoutSetID: 更新するワークアウトセットのID
+//   - input: 更新内容（nilのフィールドは変更なし）
+//
+// 戻り値:
+//   - *entity.WorkoutSet: 更新されたワークアウトセット
+//   - error: 以下のエラーが返される可能性がある
+//     - ErrWorkoutSetNotFound: ワークアウトセットが存在しない
+//     - ErrWorkoutNotFound: ワークアウトが存在しない
+//     - ErrWorkoutAccessDenied: アクセス権がない
+//     - entity.ErrInvalidReps: レップ数が不正
+//     - entity.ErrInvalidExerciseWeight: 重量が不正
+//     - entity.ErrInvalidDuration: 継続時間が不正
+//     - entity.ErrInvalidSetType: セットの種類が不正
+//     - entity.ErrInvalidRPE, entity.ErrInvalidRIR: RPE・RIRが不正
+//     - entity.ErrRPEAndRIRBothSet: RPEとRIRが両方指定された
+//     - その他のリポジトリエラー
+func (u *WorkoutUsecase) UpdateWorkoutSet(ctx context.Context, userID, workoutSetID uuid.UUID, input UpdateWorkoutSetInput) (*entity.WorkoutSet, error) {
+	// セット取得
+	workoutSet, err := u.workoutSetRepo.FindByID(ctx, workoutSetID)
+	if err != nil || workoutSet == nil {
+		return nil, ErrWorkoutSetNotFound
+	}
+
+	// ワークアウトのオーナーシップチェック
+	workout, err := u.getWorkoutWithOwnershipCheck(ctx, userID, workoutSet.WorkoutID)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := u.recalculateDailyScore(ctx, workout, allSets); err != nil {
+	formula, err := u.oneRMFormulaFor(ctx, userID)
+	if err != nil {
 		return nil, err
 	}
 
-	return createdSets, nil
+	// レップ数・重量の更新（推定1RMを再計算）
+	if input.Reps != nil || input.Weight != nil {
+		reps := workoutSet.Reps
+		if input.Reps != nil {
+			reps = *input.Reps
+		}
+		weight := workoutSet.Weight
+		if input.Weight != nil {
+			weight = *input.Weight
+		}
+		if err := workoutSet.UpdateRepsAndWeightWithFormula(reps, weight, formula); err != nil {
+			return nil, err
+		}
+	}
+
+	// 継続時間の更新
+	if input.DurationSeconds != nil {
+		if err := workoutSet.UpdateDuration(input.DurationSeconds); err != nil {
+			return nil, err
+		}
+	}
+
+	// メモの更新
+	if input.Notes != nil {
+		workoutSet.UpdateNotes(input.Notes)
+	}
+
+	// セットの種類の更新
+	if input.SetType != nil {
+		if err := workoutSet.UpdateSetType(*input.SetType); err != nil {
+			return nil, err
+		}
+	}
+
+	// RPE・RIRの更新
+	if input.RPE != nil || input.RIR != nil {
+		if err := workoutSet.UpdateEffort(input.RPE, input.RIR); err != nil {
+			return nil, err
+		}
+	}
+
+	err = u.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		if err := u.workoutSetRepo.Update(ctx, workoutSet); err != nil {
+			return err
+		}
+
+		// 全セットでデイリースコアを再計算
+		allSets, err := u.workoutSetRepo.FindByWorkoutID(ctx, workout.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := u.recalculateDailyScore(ctx, workout, allSets); err != nil {
+			return err
+		}
+		if err := u.recalculateFollowingDailyScores(ctx, userID, workout.Date, workout.Date); err != nil {
+			return err
+		}
+
+		// 自己ベストに影響する項目が変わった場合は種目の自己ベストを再構築
+		if input.Reps != nil || input.Weight != nil || input.SetType != nil {
+			if err := u.refreshPersonalRecords(ctx, userID, []uuid.UUID{workoutSet.ExerciseID}, formula); err != nil {
+				return err
+			}
+		}
+
+		// 紐付けたプログラムの進捗更新（ウォームアップから本セットへの変更で日を満たす場合がある）
+		return u.advanceLinkedProgram(ctx, workout.ID, allSets)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return workoutSet, nil
 }
 
 // DeleteWorkoutSet はワークアウトセットを削除する。
 // セットの存在確認、ワークアウトのオーナーシップチェックを実施し、
-// セットを削除した後、デイリースコアを再計算する。
+// セットを削除した後、デイリースコアを再計算し（平均負荷量との比で計算する場合は後続のワークアウトも）、その種目の自己ベストを再構築する。
+// セットの削除、デイリースコアの更新、自己ベストの再構築は1トランザクションで行う。
 //
 // パラメータ:
 //   - ctx: リクエストのコンテキスト
@@ -368,22 +863,38 @@ func (u *WorkoutUsecase) DeleteWorkoutSet(ctx context.Context, userID uuid.UUID,
 		return err
 	}
 
-	// セット削除
-	if err := u.workoutSetRepo.Delete(ctx, workoutSetID); err != nil {
-		return err
-	}
-
-	// 残りのセットでデイリースコアを再計算
-	remainingSets, err := u.workoutSetRepo.FindByWorkoutID(ctx, workout.ID)
+	formula, err := u.oneRMFormulaFor(ctx, userID)
 	if err != nil {
 		return err
 	}
 
-	return u.recalculateDailyScore(ctx, workout, remainingSets)
+	return u.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		// セット削除
+		if err := u.workoutSetRepo.Delete(ctx, workoutSetID); err != nil {
+			return err
+		}
+
+		// 残りのセットでデイリースコアを再計算
+		remainingSets, err := u.workoutSetRepo.FindByWorkoutID(ctx, workout.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := u.recalculateDailyScore(ctx, workout, remainingSets); err != nil {
+			return err
+		}
+		if err := u.recalculateFollowingDailyScores(ctx, userID, workout.Date, workout.Date); err != nil {
+			return err
+		}
+
+		return u.refreshPersonalRecords(ctx, userID, []uuid.UUID{workoutSet.ExerciseID}, formula)
+	})
 }
 
 // DeleteWorkout はワークアウトとそのセットを削除する。
-// オーナーシップチェックを実施し、関連するセットとワークアウトを削除する。
+// オーナーシップチェックを実施し、関連するセットとワークアウトを1トランザクションで削除する。
+// 削除したセットの種目については、残りのセットから自己ベストを再構築する。
+// 平均負荷量との比でスコアを計算する場合は、削除したワークアウトを直近の平均負荷量に含んでいた後続のワークアウトのスコアを再計算する。
 //
 // パラメータ:
 //   - ctx: リクエストのコンテキスト
@@ -396,21 +907,52 @@ func (u *WorkoutUsecase) DeleteWorkoutSet(ctx context.Context, userID uuid.UUID,
 //     - ErrWorkoutAccessDenied: アクセス権がない
 //     - その他のリポジトリエラー
 func (u *WorkoutUsecase) DeleteWorkout(ctx context.Context, userID, workoutID uuid.UUID) error {
-	_, err := u.getWorkoutWithOwnershipCheck(ctx, userID, workoutID)
+	workout, err := u.getWorkoutWithOwnershipCheck(ctx, userID, workoutID)
 	if err != nil {
 		return err
 	}
 
-	// 関連セットを先に削除
-	if err := u.workoutSetRepo.DeleteByWorkoutID(ctx, workoutID); err != nil {
+	formula, err := u.oneRMFormulaFor(ctx, userID)
+	if err != nil {
 		return err
 	}
 
-	return u.workoutRepo.Delete(ctx, workoutID)
+	return u.txManager.RunInTx(ctx, func(ctx context.Context) error {
+		// 自己ベストの再構築対象となる種目を削除前に控える
+		sets, err := u.workoutSetRepo.FindByWorkoutID(ctx, workoutID)
+		if err != nil {
+			return err
+		}
+		var exerciseIDs []uuid.UUID
+		seen := make(map[uuid.UUID]bool)
+		for _, set := range sets {
+			if !seen[set.ExerciseID] {
+				seen[set.ExerciseID] = true
+				exerciseIDs = append(exerciseIDs, set.ExerciseID)
+			}
+		}
+
+		// 関連セットを先に削除
+		if err := u.workoutSetRepo.DeleteByWorkoutID(ctx, workoutID); err != nil {
+			return err
+		}
+
+		if err := u.workoutRepo.Delete(ctx, workoutID); err != nil {
+			return err
+		}
+
+		// 削除したワークアウトを直近の平均負荷量に含んでいた後続のワークアウトのスコアを再計算
+		if err := u.recalculateFollowingDailyScores(ctx, userID, workout.Date, workout.Date); err != nil {
+			return err
+		}
+
+		return u.refreshPersonalRecords(ctx, userID, exerciseIDs, formula)
+	})
 }
 
 // GetContributionData はコントリビューションデータを取得する。
-// 指定された日付範囲内のワークアウトデータをコントリビューションデータポイントに変換する。
+// 指定された日付範囲内のワークアウトを日付ごとに集計し、コントリビューションデータポイントに変換する。
+// 同じ日に複数のセッションがある場合はデイリースコアを合算する（上限100）。
 //
 // パラメータ:
 //   - ctx: リクエストのコンテキスト
@@ -422,16 +964,17 @@ func (u *WorkoutUsecase) DeleteWorkout(ctx context.Context, userID, workoutID uu
 //   - []ContributionDataPoint: コントリビューションデータのリスト
 //   - error: リポジトリエラー
 func (u *WorkoutUsecase) GetContributionData(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]ContributionDataPoint, error) {
-	workouts, err := u.workoutRepo.FindByUserIDAndDateRange(ctx, userID, startDate, endDate)
+	dailyScores, err := u.workoutService.AggregateDailyScores(ctx, userID, startDate, endDate)
 	if err != nil {
 		return nil, err
 	}
 
-	dataPoints := make([]ContributionDataPoint, 0, len(workouts))
-	for _, workout := range workouts {
+	dataPoints := make([]ContributionDataPoint, 0, len(dailyScores))
+	for _, score := range dailyScores {
 		dataPoints = append(dataPoints, ContributionDataPoint{
-			Date:       workout.Date,
-			DailyScore: workout.DailyScore,
+			Date:         score.Date,
+			DailyScore:   score.DailyScore,
+			SessionCount: score.SessionCount,
 		})
 	}
 
@@ -452,32 +995,544 @@ func (u *WorkoutUsecase) getWorkoutWithOwnershipCheck(ctx context.Context, userI
 	return workout, nil
 }
 
+// ExportWorkouts はユーザーのワークアウトとセットを、エクスポート用に日付の古い順で1行ずつ返す。
+// 全件をメモリに読み込まずにリポジトリから順に読み取るため、期間が長くても一定のメモリで書き出せる。
+//
+// パラメータ:
+//   - ctx: リクエストのコンテキスト
+//   - userID: ユーザーID
+//   - startDate: 開始日（nilの場合は限定しない）
+//   - endDate: 終了日（nilの場合は限定しない）
+//
+// 戻り値:
+//   - iter.Seq2[WorkoutExportRow, error]: ワークアウトとセットの組。セットのないワークアウトはSetがnilの1行となる。
+//     リポジトリエラーが発生した場合は、そのエラーを返して反復を終了する
+func (u *WorkoutUsecase) ExportWorkouts(ctx context.Context, userID uuid.UUID, startDate, endDate *time.Time) iter.Seq2[WorkoutExportRow, error] {
+	return u.workoutRepo.StreamForExport(ctx, userID, startDate, endDate)
+}
+
 // GetWeightProgression は種目の重量推移データを取得する。
-// 日別の最大推定1RMを返す。
+// 日別の最大推定1RMを、保存済みの値ではなく生の重量・レップ数から指定された公式で計算して返す。
 //
 // パラメータ:
 //   - ctx: リクエストのコンテキスト
 //   - userID: ユーザーID
 //   - exerciseID: エクササイズID
+//   - formula: 推定1RMの計算式（nilの場合はプロフィールで選択した公式）
 //
 // 戻り値:
 //   - []WeightProgressionPoint: 日別の推定1RMデータポイント
-//   - error: リポジトリエラー
-func (u *WorkoutUsecase) GetWeightProgression(ctx context.Context, userID, exerciseID uuid.UUID) ([]WeightProgressionPoint, error) {
-	return u.workoutSetRepo.GetWeightProgression(ctx, userID, exerciseID)
+//   - error: 以下のエラーが返される可能性がある
+//     - entity.ErrInvalidOneRMFormula: 計算式が不正
+//     - その他のリポジトリエラー
+func (u *WorkoutUsecase) GetWeightProgression(ctx context.Context, userID, exerciseID uuid.UUID, formula *entity.OneRMFormula) ([]WeightProgressionPoint, error) {
+	var f entity.OneRMFormula
+	if formula != nil {
+		if !formula.IsValid() {
+			return nil, entity.ErrInvalidOneRMFormula
+		}
+		f = *formula
+	} else {
+		userFormula, err := u.oneRMFormulaFor(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		f = userFormula
+	}
+
+	return u.workoutSetRepo.GetWeightProgression(ctx, userID, exerciseID, f)
+}
+
+// oneRMFormulaFor はユーザーがプロフィールで選択した1RM計算式を返す。
+// プロフィールが未作成の場合はデフォルトの公式（Epley式）を返す。
+// プロフィールの取得に失敗した場合は、異なる計算式で保存されないようエラーを返す。
+func (u *WorkoutUsecase) oneRMFormulaFor(ctx context.Context, userID uuid.UUID) (entity.OneRMFormula, error) {
+	profile, err := u.profileRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if profile == nil || !profile.OneRMFormula.IsValid() {
+		return entity.DefaultOneRMFormula, nil
+	}
+	return profile.OneRMFormula, nil
+}
+
+// checkExercisesExist は入力された全セットのエクササイズが存在し、ユーザーが使用可能であることを確認する。
+// 他ユーザーのカスタム種目は存在しないものとして扱う。
+func (u *WorkoutUsecase) checkExercisesExist(ctx context.Context, userID uuid.UUID, sets []SetInput) error {
+	for _, setInput := range sets {
+		exercise, err := u.exerciseRepo.FindByID(ctx, setInput.ExerciseID)
+		if err != nil || exercise == nil || !exercise.IsVisibleTo(userID) {
+			return ErrExerciseNotFound
+		}
+	}
+	return nil
+}
+
+// createSets は入力データからセットを生成し、永続化する。
+// 推定1RMは指定された公式で計算する。
+// トランザクション内で呼び出されることを前提とし、途中で失敗した場合はエラーを返す。
+func (u *WorkoutUsecase) createSets(ctx context.Context, workoutID uuid.UUID, inputs []SetInput, formula entity.OneRMFormula) ([]*entity.WorkoutSet, error) {
+	sets := make([]*entity.WorkoutSet, 0, len(inputs))
+	for _, setInput := range inputs {
+		workoutSet, err := entity.NewWorkoutSetWithFormula(workoutID, setInput.ExerciseID, setInput.SetNumber, setInput.Reps, setInput.Weight, formula)
+		if err != nil {
+			return nil, err
+		}
+
+		if setInput.DurationSeconds != nil {
+			if err := workoutSet.UpdateDuration(setInput.DurationSeconds); err != nil {
+				return nil, err
+			}
+		}
+		if setInput.Notes != nil {
+			workoutSet.UpdateNotes(setInput.Notes)
+		}
+		if setInput.SetType != nil {
+			if err := workoutSet.UpdateSetType(*setInput.SetType); err != nil {
+				return nil, err
+			}
+		}
+		if err := workoutSet.UpdateEffort(setInput.RPE, setInput.RIR); err != nil {
+			return nil, err
+		}
+
+		if err := u.workoutSetRepo.Create(ctx, workoutSet); err != nil {
+			return nil, err
+		}
+
+		sets = append(sets, workoutSet)
+	}
+	return sets, nil
+}
+
+// recordPersonalRecords は新しく記録されたセットについて種目ごとに自己ベストを判定し、更新されたものを保存する。
+// 判定の基準となる履歴は、同じユーザー・種目のセットのうち、このワークアウトより前のワークアウトのセットと
+// このワークアウトの既存セットとする。過去日付の記録などでより後のワークアウトにセットがある場合は、
+// 後続の自己ベスト更新イベントも変わりうるため、その種目の自己ベストを時系列で再構築する。
+// 戻り値は新しいセットによって更新された自己ベストのみを含む。
+// トランザクション内で呼び出されることを前提とする。
+func (u *WorkoutUsecase) recordPersonalRecords(ctx context.Context, workout *entity.Workout, newSets []*entity.WorkoutSet, formula entity.OneRMFormula) ([]*entity.PersonalRecord, error) {
+	// 入力順を保ったまま種目ごとにセットをまとめる
+	var exerciseIDs []uuid.UUID
+	setsByExercise := make(map[uuid.UUID][]*entity.WorkoutSet)
+	newSetIDs := make(map[uuid.UUID]bool, len(newSets))
+	for _, set := range newSets {
+		if _, ok := setsByExercise[set.ExerciseID]; !ok {
+			exerciseIDs = append(exerciseIDs, set.ExerciseID)
+		}
+		setsByExercise[set.ExerciseID] = append(setsByExercise[set.ExerciseID], set)
+		newSetIDs[set.ID] = true
+	}
+
+	records := make([]*entity.PersonalRecord, 0)
+	for _, exerciseID := range exerciseIDs {
+		// 時系列順に並んだ全セットから、このワークアウトより前のセットを履歴とする
+		allSets, err := u.workoutSetRepo.FindByUserIDAndExerciseID(ctx, workout.UserID, exerciseID)
+		if err != nil {
+			return nil, err
+		}
+
+		history := make([]*entity.WorkoutSet, 0, len(allSets))
+		reached, hasLater := false, false
+		for _, set := range allSets {
+			if set.WorkoutID == workout.ID {
+				reached = true
+			} else if reached {
+				hasLater = true
+				break
+			}
+			if !newSetIDs[set.ID] {
+				history = append(history, set)
+			}
+		}
+
+		if hasLater {
+			rebuilt, err := rebuildPersonalRecords(ctx, u.workoutRepo, u.personalRecordRepo, workout.UserID, exerciseID, allSets, formula)
+			if err != nil {
+				return nil, err
+			}
+			for _, record := range rebuilt {
+				if record.WorkoutID != workout.ID {
+					continue
+				}
+				if record.WorkoutSetID == nil || newSetIDs[*record.WorkoutSetID] {
+					records = append(records, record)
+				}
+			}
+			continue
+		}
+
+		for _, record := range entity.DetectPersonalRecords(workout, exerciseID, history, setsByExercise[exerciseID], formula) {
+			if err := u.personalRecordRepo.Create(ctx, record); err != nil {
+				return nil, err
+			}
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
+// refreshPersonalRecords はセットの更新・削除後に、指定された種目の自己ベストを現在のセットから再構築する。
+// トランザクション内で呼び出されることを前提とする。
+func (u *WorkoutUsecase) refreshPersonalRecords(ctx context.Context, userID uuid.UUID, exerciseIDs []uuid.UUID, formula entity.OneRMFormula) error {
+	for _, exerciseID := range exerciseIDs {
+		allSets, err := u.workoutSetRepo.FindByUserIDAndExerciseID(ctx, userID, exerciseID)
+		if err != nil {
+			return err
+		}
+		if _, err := rebuildPersonalRecords(ctx, u.workoutRepo, u.personalRecordRepo, userID, exerciseID, allSets, formula); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rebuildPersonalRecords は種目の既存の自己ベスト更新イベントを削除し、時系列順に並んだ全セットから判定し直して保存する。
+// 保存した自己ベスト更新イベントを返す。トランザクション内で呼び出されることを前提とする。
+// 1RM計算式の変更時にProfileUsecaseからも使用するため、リポジトリを引数で受け取る。
+func rebuildPersonalRecords(ctx context.Context, workoutRepo repository.WorkoutRepository, personalRecordRepo repository.PersonalRecordRepository, userID, exerciseID uuid.UUID, allSets []*entity.WorkoutSet, formula entity.OneRMFormula) ([]*entity.PersonalRecord, error) {
+	if err := personalRecordRepo.DeleteByUserIDAndExerciseID(ctx, userID, exerciseID); err != nil {
+		return nil, err
+	}
+	if len(allSets) == 0 {
+		return nil, nil
+	}
+
+	userWorkouts, err := workoutRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	workoutByID := make(map[uuid.UUID]*entity.Workout, len(userWorkouts))
+	for _, w := range userWorkouts {
+		workoutByID[w.ID] = w
+	}
+
+	// セットの並び順を保ったままワークアウトごとにまとめる
+	var workouts []*entity.Workout
+	setsByWorkout := make(map[uuid.UUID][]*entity.WorkoutSet)
+	for _, set := range allSets {
+		if _, ok := setsByWorkout[set.WorkoutID]; !ok {
+			w, ok := workoutByID[set.WorkoutID]
+			if !ok {
+				return nil, ErrWorkoutNotFound
+			}
+			workouts = append(workouts, w)
+		}
+		setsByWorkout[set.WorkoutID] = append(setsByWorkout[set.WorkoutID], set)
+	}
+
+	records := entity.RebuildPersonalRecords(exerciseID, workouts, setsByWorkout, formula)
+	for _, record := range records {
+		if err := personalRecordRepo.Create(ctx, record); err != nil {
+			return nil, err
+		}
+	}
+
+	return records, nil
+}
+
+// advanceLinkedProgram はワークアウトが紐付いたプログラムの日を、ワークアウトの全セットの種目
+// （ウォームアップセットを除く）が満たす場合に次の日へ進める。
+// 紐付けた日が既に終わっている場合は進めないため、セットの追加・更新のたびに呼び出してよい。
+// トランザクション内で呼び出されることを前提とする。
+func (u *WorkoutUsecase) advanceLinkedProgram(ctx context.Context, workoutID uuid.UUID, sets []*entity.WorkoutSet) error {
+	link, err := u.programRepo.FindWorkoutLink(ctx, workoutID)
+	if err != nil {
+		return err
+	}
+	if link == nil {
+		return nil
+	}
+
+	program, err := u.programRepo.FindByID(ctx, link.ProgramID)
+	if err != nil {
+		return err
+	}
+	if program == nil {
+		return nil
+	}
+
+	exerciseIDs := make([]uuid.UUID, 0, len(sets))
+	for _, set := range sets {
+		if !set.IsWarmUp() {
+			exerciseIDs = append(exerciseIDs, set.ExerciseID)
+		}
+	}
+
+	if !program.AdvanceIfSatisfied(link.DayIndex, exerciseIDs) {
+		return nil
+	}
+	return u.programRepo.UpdateProgress(ctx, program)
 }
 
 // recalculateDailyScore はセットからデイリースコアを再計算し、ワークアウトを更新する。
+// スコアはユーザーがプロフィールで選択した計算方法で計算し、
+// プロフィールが未作成の場合はデフォルトの計算方法を使用する。
 func (u *WorkoutUsecase) recalculateDailyScore(ctx context.Context, workout *entity.Workout, sets []*entity.WorkoutSet) error {
-	totalVolume := 0.0
-	for _, set := range sets {
-		totalVolume += set.CalculateVolume()
+	profile, err := u.profileRepo.FindByUserID(ctx, workout.UserID)
+	if err != nil {
+		return err
 	}
 
-	score := workout.CalculateDailyScore(totalVolume)
+	score, err := u.dailyScoreService.CalculateDailyScore(ctx, workout, sets, profile)
+	if err != nil {
+		return err
+	}
 	if err := workout.UpdateDailyScore(score); err != nil {
 		return err
 	}
 
 	return u.workoutRepo.Update(ctx, workout)
 }
+
+// recalculateFollowingDailyScores は after の翌日から until の entity.RollingAverageWeeks 週間後までの
+// ワークアウトのデイリースコアを再計算する。
+// 平均負荷量との比で計算する場合、それらのワークアウトのスコアは after〜until の日のワークアウトの負荷量を
+// 直近の平均負荷量に含むため、ワークアウトやセットを記録・変更・削除した後に呼び出す。
+// その他の計算方法ではスコアが他のワークアウトに依存しないため何もしない。
+// トランザクション内で呼び出されることを前提とする。
+func (u *WorkoutUsecase) recalculateFollowingDailyScores(ctx context.Context, userID uuid.UUID, after, until time.Time) error {
+	profile, err := u.profileRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if profile == nil || profile.DailyScoreStrategy != entity.DailyScoreStrategyRollingAverage {
+		return nil
+	}
+
+	workouts, err := u.workoutRepo.FindByUserIDAndDateRange(ctx, userID, after.AddDate(0, 0, 1), until.AddDate(0, 0, 7*entity.RollingAverageWeeks))
+	if err != nil {
+		return err
+	}
+	for _, workout := range workouts {
+		sets, err := u.workoutSetRepo.FindByWorkoutID(ctx, workout.ID)
+		if err != nil {
+			return err
+		}
+
+		score, err := u.dailyScoreService.CalculateDailyScore(ctx, workout, sets, profile)
+		if err != nil {
+			return err
+		}
+		if score == workout.DailyScore {
+			continue
+		}
+		if err := workout.UpdateDailyScore(score); err != nil {
+			return err
+		}
+		if err := u.workoutRepo.Update(ctx, workout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newWorkoutFromInput は記録の入力データからワークアウトを生成し、セッション時刻とメモを設定する。
+func newWorkoutFromInput(input RecordWorkoutInput) (*entity.Workout, error) {
+	workout := entity.NewWorkout(input.UserID, input.Date)
+	if input.StartedAt != nil || input.EndedAt != nil {
+		startedAt := input.Date
+		if input.StartedAt != nil {
+			startedAt = *input.StartedAt
+		}
+		if err := workout.UpdateSessionTime(startedAt, input.EndedAt); err != nil {
+			return nil, err
+		}
+	}
+	if input.Memo != nil {
+		workout.UpdateMemo(input.Memo)
+	}
+	return workout, nil
+}
+
+// workoutHistory はユーザーの記録済みのワークアウトとセット（インポート時の重複判定に使用する）
+type workoutHistory struct {
+	setsByWorkout map[uuid.UUID][]*entity.WorkoutSet
+	signatures    map[string]bool
+}
+
+// loadWorkoutHistory はユーザーの全ワークアウトとセットを読み込む。
+func (u *WorkoutUsecase) loadWorkoutHistory(ctx context.Context, userID uuid.UUID) (*workoutHistory, error) {
+	workouts, err := u.workoutRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	sets, err := u.workoutSetRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &workoutHistory{
+		setsByWorkout: make(map[uuid.UUID][]*entity.WorkoutSet, len(workouts)),
+		signatures:    make(map[string]bool, len(workouts)),
+	}
+	for _, set := range sets {
+		history.setsByWorkout[set.WorkoutID] = append(history.setsByWorkout[set.WorkoutID], set)
+	}
+	for _, workout := range workouts {
+		keys := make([]string, 0, len(history.setsByWorkout[workout.ID]))
+		for _, set := range history.setsByWorkout[workout.ID] {
+			keys = append(keys, workoutSetKey(set.ExerciseID, set.SetNumber, set.Reps, set.Weight))
+		}
+		history.signatures[workoutSignature(workout, keys)] = true
+	}
+	return history, nil
+}
+
+// markDuplicates は記録済みのワークアウト、または入力内のより前のワークアウトと重複するワークアウトを判定する。
+// 戻り値はworkoutsと同じ順で、重複する場合はtrueとなる。
+func (h *workoutHistory) markDuplicates(workouts []*entity.Workout, inputs []RecordWorkoutInput) []bool {
+	duplicates := make([]bool, len(workouts))
+	for i, workout := range workouts {
+		keys := make([]string, 0, len(inputs[i].Sets))
+		for _, set := range inputs[i].Sets {
+			keys = append(keys, workoutSetKey(set.ExerciseID, set.SetNumber, set.Reps, set.Weight))
+		}
+		signature := workoutSignature(workout, keys)
+		if h.signatures[signature] {
+			duplicates[i] = true
+			continue
+		}
+		h.signatures[signature] = true
+	}
+	return duplicates
+}
+
+// workoutSetKey は重複判定に使用するセット1件分のキーを返す。
+func workoutSetKey(exerciseID uuid.UUID, setNumber, reps int32, weight float64) string {
+	return fmt.Sprintf("%s/%d/%d/%.2f", exerciseID, setNumber, reps, weight)
+}
+
+// workoutSignature は重複判定に使用する、日付・開始日時・セットからなるワークアウトのキーを返す。
+// セットの記録順は区別しない。
+func workoutSignature(workout *entity.Workout, setKeys []string) string {
+	sort.Strings(setKeys)
+	return fmt.Sprintf("%s|%d|%s", workout.Date.Format(time.DateOnly), workout.StartedAt.Unix(), strings.Join(setKeys, ","))
+}
+
+// recalculateImportedDailyScores はインポートしたワークアウトのデイリースコアを日付順に計算し、
+// その負荷量を直近の平均負荷量に含む後続のワークアウトのスコアを再計算する。
+// トランザクション内で呼び出されることを前提とする。
+func (u *WorkoutUsecase) recalculateImportedDailyScores(ctx context.Context, userID uuid.UUID, setsByWorkout map[uuid.UUID][]*entity.WorkoutSet, recorded []*entity.Workout) error {
+	sorted := append([]*entity.Workout(nil), recorded...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].Date.Equal(sorted[j].Date) {
+			return sorted[i].Date.Before(sorted[j].Date)
+		}
+		return sorted[i].StartedAt.Before(sorted[j].StartedAt)
+	})
+
+	for _, workout := range sorted {
+		if err := u.recalculateDailyScore(ctx, workout, setsByWorkout[workout.ID]); err != nil {
+			return err
+		}
+	}
+
+	return u.recalculateFollowingDailyScores(ctx, userID, sorted[0].Date, sorted[len(sorted)-1].Date)
+}
+
+// getWorkoutListDetails はワークアウトごとのセット・種目・サマリーを組み立てる。
+// セットが存在しないワークアウトにも空の詳細を割り当てる。
+func (u *WorkoutUsecase) getWorkoutListDetails(ctx context.Context, workouts []*entity.Workout) (map[uuid.UUID]*WorkoutListDetail, error) {
+	details := make(map[uuid.UUID]*WorkoutListDetail, len(workouts))
+	if len(workouts) == 0 {
+		return details, nil
+	}
+
+	workoutIDs := make([]uuid.UUID, len(workouts))
+	for i, workout := range workouts {
+		workoutIDs[i] = workout.ID
+		details[workout.ID] = &WorkoutListDetail{
+			Sets:      []*entity.WorkoutSet{},
+			Exercises: []WorkoutExerciseSummary{},
+			Summary:   WorkoutSummary{BodyParts: []entity.BodyPart{}},
+		}
+	}
+
+	setDetails, err := u.workoutSetRepo.FindDetailsByWorkoutIDs(ctx, workoutIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	seenExercises := make(map[uuid.UUID]map[uuid.UUID]bool, len(workouts))
+	seenBodyParts := make(map[uuid.UUID]map[entity.BodyPart]bool, len(workouts))
+	for _, sd := range setDetails {
+		detail, ok := details[sd.Set.WorkoutID]
+		if !ok {
+			continue
+		}
+
+		detail.Sets = append(detail.Sets, sd.Set)
+		if !sd.Set.IsWarmUp() {
+			detail.Summary.TotalVolume += sd.Set.CalculateVolume()
+		}
+		detail.Summary.SetCount++
+
+		if seenExercises[sd.Set.WorkoutID] == nil {
+			seenExercises[sd.Set.WorkoutID] = make(map[uuid.UUID]bool)
+			seenBodyParts[sd.Set.WorkoutID] = make(map[entity.BodyPart]bool)
+		}
+		if !seenExercises[sd.Set.WorkoutID][sd.Set.ExerciseID] {
+			seenExercises[sd.Set.WorkoutID][sd.Set.ExerciseID] = true
+			detail.Exercises = append(detail.Exercises, WorkoutExerciseSummary{
+				ID:       sd.Set.ExerciseID,
+				Name:     sd.ExerciseName,
+				BodyPart: sd.BodyPart,
+			})
+			detail.Summary.ExerciseCount++
+		}
+		if sd.BodyPart != nil && !seenBodyParts[sd.Set.WorkoutID][*sd.BodyPart] {
+			seenBodyParts[sd.Set.WorkoutID][*sd.BodyPart] = true
+			detail.Summary.BodyParts = append(detail.Summary.BodyParts, *sd.BodyPart)
+		}
+	}
+
+	for _, detail := range details {
+		sort.Slice(detail.Summary.BodyParts, func(i, j int) bool {
+			return detail.Summary.BodyParts[i] < detail.Summary.BodyParts[j]
+		})
+	}
+
+	return details, nil
+}
+
+// encodeWorkoutCursor はワークアウトの並び替えキーを不透明なカーソル文字列に変換する。
+func encodeWorkoutCursor(workout *entity.Workout) string {
+	raw := strings.Join([]string{
+		workout.Date.Format(time.DateOnly),
+		workout.StartedAt.UTC().Format(time.RFC3339Nano),
+		workout.ID.String(),
+	}, "|")
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeWorkoutCursor はカーソル文字列をワークアウトの並び替えキーに変換する。
+// 形式が不正な場合はErrInvalidWorkoutCursorを返す。
+func decodeWorkoutCursor(cursor string) (*repository.WorkoutCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidWorkoutCursor
+	}
+
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 {
+		return nil, ErrInvalidWorkoutCursor
+	}
+
+	date, err := time.Parse(time.DateOnly, parts[0])
+	if err != nil {
+		return nil, ErrInvalidWorkoutCursor
+	}
+	startedAt, err := time.Parse(time.RFC3339Nano, parts[1])
+	if err != nil {
+		return nil, ErrInvalidWorkoutCursor
+	}
+	id, err := uuid.Parse(parts[2])
+	if err != nil {
+		return nil, ErrInvalidWorkoutCursor
+	}
+
+	return &repository.WorkoutCursor{Date: date, StartedAt: startedAt, ID: id}, nil
+}
@@ -3,6 +3,9 @@ package usecase
 import (
 	"context"
 	"errors"
+	"iter"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,6 +19,8 @@ import (
 type mockWorkoutRepository struct {
 	workouts map[uuid.UUID]*entity.Workout
 	err      error
+	// exportRows はStreamForExportが返す行（ユーザーIDと期間で絞り込む）
+	exportRows []repository.WorkoutExportRow
 }
 
 func newMockWorkoutRepository() *mockWorkoutRepository {
@@ -56,6 +61,58 @@ func (m *mockWorkoutRepository) FindByUserID(ctx context.Context, userID uuid.UU
 	return result, nil
 }
 
+// FindPage は日付範囲・スコア・メモの条件とカーソルを評価してページを返す
+// 種目・身体部位の条件はセットを参照するためモックでは評価しない
+func (m *mockWorkoutRepository) FindPage(ctx context.Context, query repository.WorkoutPageQuery) ([]*entity.Workout, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	f := query.Filter
+	var result []*entity.Workout
+	for _, workout := range m.workouts {
+		if workout.UserID != query.UserID ||
+			(f.StartDate != nil && workout.Date.Before(*f.StartDate)) ||
+			(f.EndDate != nil && workout.Date.After(*f.EndDate)) ||
+			(f.MinScore != nil && workout.DailyScore < *f.MinScore) ||
+			(f.MemoQuery != nil && (workout.Memo == nil || !strings.Contains(strings.ToLower(*workout.Memo), strings.ToLower(*f.MemoQuery)))) {
+			continue
+		}
+		result = append(result, workout)
+	}
+
+	less := func(a *entity.Workout, date, startedAt time.Time, id uuid.UUID) bool {
+		if !a.Date.Equal(date) {
+			return a.Date.Before(date)
+		}
+		if !a.StartedAt.Equal(startedAt) {
+			return a.StartedAt.Before(startedAt)
+		}
+		return a.ID.String() < id.String()
+	}
+	asc := query.Sort == repository.WorkoutSortDateAsc
+	sort.Slice(result, func(i, j int) bool {
+		b := result[j]
+		return less(result[i], b.Date, b.StartedAt, b.ID) == asc
+	})
+
+	if c := query.After; c != nil {
+		page := result[:0]
+		for _, workout := range result {
+			isBefore := less(workout, c.Date, c.StartedAt, c.ID)
+			isCursor := workout.ID == c.ID
+			if !isCursor && isBefore != asc {
+				page = append(page, workout)
+			}
+		}
+		result = page
+	}
+
+	if int32(len(result)) > query.Limit {
+		result = result[:query.Limit]
+	}
+	return result, nil
+}
+
 func (m *mockWorkoutRepository) FindByUserIDAndDateRange(ctx context.Context, userID uuid.UUID, startDate, endDate time.Time) ([]*entity.Workout, error) {
 	if m.err != nil {
 		return nil, m.err
@@ -71,16 +128,52 @@ func (m *mockWorkoutRepository) FindByUserIDAndDateRange(ctx context.Context, us
 	return result, nil
 }
 
-func (m *mockWorkoutRepository) FindByUserIDAndDate(ctx context.Context, userID uuid.UUID, date time.Time) (*entity.Workout, error) {
+func (m *mockWorkoutRepository) FindByUserIDAndDate(ctx context.Context, userID uuid.UUID, date time.Time) ([]*entity.Workout, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
+	var result []*entity.Workout
 	for _, workout := range m.workouts {
 		if workout.UserID == userID && workout.Date.Equal(date) {
-			return workout, nil
+			result = append(result, workout)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockWorkoutRepository) FindDatesByUserID(ctx context.Context, userID uuid.UUID) ([]time.Time, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	seen := make(map[time.Time]bool)
+	var result []time.Time
+	for _, workout := range m.workouts {
+		if workout.UserID == userID && !seen[workout.Date] {
+			seen[workout.Date] = true
+			result = append(result, workout.Date)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+	return result, nil
+}
+
+func (m *mockWorkoutRepository) StreamForExport(ctx context.Context, userID uuid.UUID, startDate, endDate *time.Time) iter.Seq2[repository.WorkoutExportRow, error] {
+	return func(yield func(repository.WorkoutExportRow, error) bool) {
+		if m.err != nil {
+			yield(repository.WorkoutExportRow{}, m.err)
+			return
+		}
+		for _, row := range m.exportRows {
+			if row.Workout.UserID != userID ||
+				(startDate != nil && row.Workout.Date.Before(*startDate)) ||
+				(endDate != nil && row.Workout.Date.After(*endDate)) {
+				continue
+			}
+			if !yield(row, nil) {
+				return
+			}
 		}
 	}
-	return nil, errors.New("workout not found")
 }
 
 func (m *mockWorkoutRepository) Update(ctx context.Context, workout *entity.Workout) error {
@@ -99,18 +192,6 @@ func (m *mockWorkoutRepository) Delete(ctx context.Context, id uuid.UUID) error
 	return nil
 }
 
-func (m *mockWorkoutRepository) ExistsByUserIDAndDate(ctx context.Context, userID uuid.UUID, date time.Time) (bool, error) {
-	if m.err != nil {
-		return false, m.err
-	}
-	for _, workout := range m.workouts {
-		if workout.UserID == userID && workout.Date.Equal(date) {
-			return true, nil
-		}
-	}
-	return false, nil
-}
-
 // テストヘルパー: ワークアウトを追加
 func (m *mockWorkoutRepository) addWorkout(userID uuid.UUID, date time.Time) *entity.Workout {
 	workout := entity.NewWorkout(userID, date)
@@ -125,6 +206,16 @@ var _ repository.WorkoutRepository = (*mockWorkoutRepository)(nil)
 type mockWorkoutSetRepository struct {
 	sets map[uuid.UUID]*entity.WorkoutSet
 	err  error
+	// failCreateAt が1以上の場合、その回数目のCreate呼び出しでcreateErrを返す
+	failCreateAt int
+	createErr    error
+	createCalls  int
+	// progressionFormula はGetWeightProgressionに渡された計算式を記録する
+	progressionFormula entity.OneRMFormula
+	// workoutRepo はFindByUserIDAndExerciseIDでセットの所有ユーザーを判定するために参照する
+	workoutRepo *mockWorkoutRepository
+	// exerciseRepo はFindDetailsByWorkoutIDsで種目名・身体部位を解決するために参照する
+	exerciseRepo *mockExerciseRepository
 }
 
 func newMockWorkoutSetRepository() *mockWorkoutSetRepository {
@@ -137,6 +228,10 @@ func (m *mockWorkoutSetRepository) Create(ctx context.Context, workoutSet *entit
 	if m.err != nil {
 		return m.err
 	}
+	m.createCalls++
+	if m.failCreateAt > 0 && m.createCalls == m.failCreateAt {
+		return m.createErr
+	}
 	m.sets[workoutSet.ID] = workoutSet
 	return nil
 }
@@ -178,6 +273,52 @@ func (m *mockWorkoutSetRepository) FindByWorkoutIDAndExerciseID(ctx context.Cont
 	return result, nil
 }
 
+func (m *mockWorkoutSetRepository) FindDetailsByWorkoutIDs(ctx context.Context, workoutIDs []uuid.UUID) ([]repository.WorkoutSetDetail, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	targets := make(map[uuid.UUID]bool, len(workoutIDs))
+	for _, id := range workoutIDs {
+		targets[id] = true
+	}
+	result := []repository.WorkoutSetDetail{}
+	for _, set := range m.sets {
+		if !targets[set.WorkoutID] {
+			continue
+		}
+		detail := repository.WorkoutSetDetail{Set: set}
+		if exercise, ok := m.exerciseRepo.exercises[set.ExerciseID]; ok {
+			detail.ExerciseName = exercise.Name
+			detail.BodyPart = exercise.BodyPart
+		}
+		result = append(result, detail)
+	}
+	// 種目ごとの最初のセットの作成日時（ワークアウト内で種目を最初に記録した順）
+	type key struct{ workoutID, exerciseID uuid.UUID }
+	firstCreated := make(map[key]time.Time)
+	for _, d := range result {
+		k := key{d.Set.WorkoutID, d.Set.ExerciseID}
+		if t, ok := firstCreated[k]; !ok || d.Set.CreatedAt.Before(t) {
+			firstCreated[k] = d.Set.CreatedAt
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i].Set, result[j].Set
+		if a.WorkoutID != b.WorkoutID {
+			return a.WorkoutID.String() < b.WorkoutID.String()
+		}
+		fa, fb := firstCreated[key{a.WorkoutID, a.ExerciseID}], firstCreated[key{b.WorkoutID, b.ExerciseID}]
+		if !fa.Equal(fb) {
+			return fa.Before(fb)
+		}
+		if a.ExerciseID != b.ExerciseID {
+			return a.ExerciseID.String() < b.ExerciseID.String()
+		}
+		return a.SetNumber < b.SetNumber
+	})
+	return result, nil
+}
+
 func (m *mockWorkoutSetRepository) FindByExerciseID(ctx context.Context, exerciseID uuid.UUID) ([]*entity.WorkoutSet, error) {
 	if m.err != nil {
 		return nil, m.err
@@ -191,6 +332,52 @@ func (m *mockWorkoutSetRepository) FindByExerciseID(ctx context.Context, exercis
 	return result, nil
 }
 
+func (m *mockWorkoutSetRepository) FindByUserIDAndExerciseID(ctx context.Context, userID, exerciseID uuid.UUID) ([]*entity.WorkoutSet, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var result []*entity.WorkoutSet
+	for _, set := range m.sets {
+		if set.ExerciseID != exerciseID {
+			continue
+		}
+		if workout, ok := m.workoutRepo.workouts[set.WorkoutID]; ok && workout.UserID == userID {
+			result = append(result, set)
+		}
+	}
+	// 実装と同様にワークアウトの日付・開始時刻・作成日時、セット番号の順に並べる
+	sort.Slice(result, func(i, j int) bool {
+		a, b := m.workoutRepo.workouts[result[i].WorkoutID], m.workoutRepo.workouts[result[j].WorkoutID]
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		if !a.StartedAt.Equal(b.StartedAt) {
+			return a.StartedAt.Before(b.StartedAt)
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		if a.ID != b.ID {
+			return a.ID.String() < b.ID.String()
+		}
+		return result[i].SetNumber < result[j].SetNumber
+	})
+	return result, nil
+}
+
+func (m *mockWorkoutSetRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.WorkoutSet, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var result []*entity.WorkoutSet
+	for _, set := range m.sets {
+		if workout, ok := m.workoutRepo.workouts[set.WorkoutID]; ok && workout.UserID == userID {
+			result = append(result, set)
+		}
+	}
+	return result, nil
+}
+
 func (m *mockWorkoutSetRepository) Update(ctx context.Context, workoutSet *entity.WorkoutSet) error {
 	if m.err != nil {
 		return m.err
@@ -199,6 +386,18 @@ func (m *mockWorkoutSetRepository) Update(ctx context.Context, workoutSet *entit
 	return nil
 }
 
+func (m *mockWorkoutSetRepository) UpdateEstimated1RMs(ctx context.Context, workoutSets []*entity.WorkoutSet) error {
+	if m.err != nil {
+		return m.err
+	}
+	for _, workoutSet := range workoutSets {
+		if set, ok := m.sets[workoutSet.ID]; ok {
+			set.Estimated1RM = workoutSet.Estimated1RM
+		}
+	}
+	return nil
+}
+
 func (m *mockWorkoutSetRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	if m.err != nil {
 		return m.err
@@ -220,16 +419,24 @@ func (m *mockWorkoutSetRepository) DeleteByWorkoutID(ctx context.Context, workou
 }
 
 func (m *mockWorkoutSetRepository) GetMaxEstimated1RMByExerciseAndUser(ctx context.Context, userID, exerciseID uuid.UUID) (float64, error) {
-	if m.err != nil {
-		return 0, m.err
+	sets, err := m.FindByUserIDAndExerciseID(ctx, userID, exerciseID)
+	if err != nil {
+		return 0, err
+	}
+	max := 0.0
+	for _, set := range sets {
+		if set.Estimated1RM > max {
+			max = set.Estimated1RM
+		}
 	}
-	return 0, nil
+	return max, nil
 }
 
-func (m *mockWorkoutSetRepository) GetWeightProgression(ctx context.Context, userID, exerciseID uuid.UUID) ([]repository.WeightProgressionPoint, error) {
+func (m *mockWorkoutSetRepository) GetWeightProgression(ctx context.Context, userID, exerciseID uuid.UUID, formula entity.OneRMFormula) ([]repository.WeightProgressionPoint, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
+	m.progressionFormula = formula
 	return []repository.WeightProgressionPoint{}, nil
 }
 
@@ -243,24 +450,105 @@ func (m *mockWorkoutSetRepository) addWorkoutSet(workoutID, exerciseID uuid.UUID
 // Ensure mockWorkoutSetRepository implements repository.WorkoutSetRepository
 var _ repository.WorkoutSetRepository = (*mockWorkoutSetRepository)(nil)
 
+// mockTransactionManager はTransactionManagerのモック実装。
+// 開始時にワークアウト・セット・自己ベストのモックリポジトリの状態を退避し、
+// fnがエラーを返した場合は退避した状態に戻すことでロールバックを再現する。
+type mockTransactionManager struct {
+	workoutRepo        *mockWorkoutRepository
+	workoutSetRepo     *mockWorkoutSetRepository
+	personalRecordRepo *mockPersonalRecordRepository
+	// exerciseRepo を設定した場合は種目も退避・復元する
+	exerciseRepo *mockExerciseRepository
+	commits      int
+	rollbacks    int
+}
+
+func newMockTransactionManager(workoutRepo *mockWorkoutRepository, workoutSetRepo *mockWorkoutSetRepository, personalRecordRepo *mockPersonalRecordRepository) *mockTransactionManager {
+	return &mockTransactionManager{
+		workoutRepo:        workoutRepo,
+		workoutSetRepo:     workoutSetRepo,
+		personalRecordRepo: personalRecordRepo,
+	}
+}
+
+func (m *mockTransactionManager) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	workouts := make(map[uuid.UUID]entity.Workout, len(m.workoutRepo.workouts))
+	for id, w := range m.workoutRepo.workouts {
+		workouts[id] = *w
+	}
+	sets := make(map[uuid.UUID]entity.WorkoutSet, len(m.workoutSetRepo.sets))
+	for id, s := range m.workoutSetRepo.sets {
+		sets[id] = *s
+	}
+	records := append([]*entity.PersonalRecord(nil), m.personalRecordRepo.records...)
+	var exercises map[uuid.UUID]*entity.Exercise
+	if m.exerciseRepo != nil {
+		exercises = make(map[uuid.UUID]*entity.Exercise, len(m.exerciseRepo.exercises))
+		for id, e := range m.exerciseRepo.exercises {
+			exercises[id] = e
+		}
+	}
+
+	if err := fn(ctx); err != nil {
+		m.workoutRepo.workouts = make(map[uuid.UUID]*entity.Workout, len(workouts))
+		for id, w := range workouts {
+			w := w
+			m.workoutRepo.workouts[id] = &w
+		}
+		m.workoutSetRepo.sets = make(map[uuid.UUID]*entity.WorkoutSet, len(sets))
+		for id, s := range sets {
+			s := s
+			m.workoutSetRepo.sets[id] = &s
+		}
+		m.personalRecordRepo.records = records
+		if m.exerciseRepo != nil {
+			m.exerciseRepo.exercises = exercises
+		}
+		m.rollbacks++
+		return err
+	}
+
+	m.commits++
+	return nil
+}
+
+// Ensure mockTransactionManager implements repository.TransactionManager
+var _ repository.TransactionManager = (*mockTransactionManager)(nil)
+
 // テスト用のセットアップヘルパー
 type workoutTestSetup struct {
-	workoutRepo    *mockWorkoutRepository
-	workoutSetRepo *mockWorkoutSetRepository
-	exerciseRepo   *mockExerciseRepository
-	usecase        *WorkoutUsecase
+	workoutRepo        *mockWorkoutRepository
+	workoutSetRepo     *mockWorkoutSetRepository
+	exerciseRepo       *mockExerciseRepository
+	profileRepo        *mockProfileRepository
+	personalRecordRepo *mockPersonalRecordRepository
+	programRepo        *mockProgramRepository
+	txManager          *mockTransactionManager
+	usecase            *WorkoutUsecase
 }
 
 func newWorkoutTestSetup() *workoutTestSetup {
 	workoutRepo := newMockWorkoutRepository()
 	workoutSetRepo := newMockWorkoutSetRepository()
+	workoutSetRepo.workoutRepo = workoutRepo
 	exerciseRepo := newMockExerciseRepository()
+	workoutSetRepo.exerciseRepo = exerciseRepo
+	profileRepo := newMockProfileRepository()
+	personalRecordRepo := newMockPersonalRecordRepository()
+	programRepo := newMockProgramRepository()
 	workoutService := service.NewWorkoutService(workoutRepo)
+	dailyScoreService := service.NewDailyScoreService(workoutRepo, workoutSetRepo)
+	txManager := newMockTransactionManager(workoutRepo, workoutSetRepo, personalRecordRepo)
+	txManager.exerciseRepo = exerciseRepo
 	return &workoutTestSetup{
-		workoutRepo:    workoutRepo,
-		workoutSetRepo: workoutSetRepo,
-		exerciseRepo:   exerciseRepo,
-		usecase:        NewWorkoutUsecase(workoutRepo, workoutSetRepo, exerciseRepo, workoutService),
+		workoutRepo:        workoutRepo,
+		workoutSetRepo:     workoutSetRepo,
+		exerciseRepo:       exerciseRepo,
+		profileRepo:        profileRepo,
+		personalRecordRepo: personalRecordRepo,
+		programRepo:        programRepo,
+		txManager:          txManager,
+		usecase:            NewWorkoutUsecase(workoutRepo, workoutSetRepo, exerciseRepo, profileRepo, personalRecordRepo, programRepo, workoutService, dailyScoreService, txManager),
 	}
 }
 
@@ -314,11 +602,10 @@ func TestWorkoutUsecase_RecordWorkout(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "異常系: 日付が重複",
+			name: "正常系: 自身のカスタム種目で記録成功",
 			setup: func(s *workoutTestSetup) RecordWorkoutInput {
 				userID := uuid.New()
-				exercise := s.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
-				s.workoutRepo.addWorkout(userID, testDate)
+				exercise := s.exerciseRepo.addUserExercise(userID, "マイベンチ", nil, &chestPart)
 				return RecordWorkoutInput{
 					UserID: userID,
 					Date:   testDate,
@@ -327,9 +614,63 @@ func TestWorkoutUsecase_RecordWorkout(t *testing.T) {
 					},
 				}
 			},
+			wantErr: false,
+		},
+		{
+			name: "正常系: 同日に2回目のセッションを記録",
+			setup: func(s *workoutTestSetup) RecordWorkoutInput {
+				userID := uuid.New()
+				exercise := s.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+				s.workoutRepo.addWorkout(userID, testDate)
+				startedAt := testDate.Add(18 * time.Hour)
+				return RecordWorkoutInput{
+					UserID:    userID,
+					Date:      testDate,
+					StartedAt: &startedAt,
+					Sets: []SetInput{
+						{ExerciseID: exercise.ID, SetNumber: 1, Reps: 10, Weight: 60.0},
+					},
+				}
+			},
+			wantErr: false,
+		},
+		{
+			name: "正常系: 開始時刻と終了時刻を指定",
+			setup: func(s *workoutTestSetup) RecordWorkoutInput {
+				exercise := s.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+				startedAt := testDate.Add(7 * time.Hour)
+				endedAt := startedAt.Add(time.Hour)
+				return RecordWorkoutInput{
+					UserID:    uuid.New(),
+					Date:      testDate,
+					StartedAt: &startedAt,
+					EndedAt:   &endedAt,
+					Sets: []SetInput{
+						{ExerciseID: exercise.ID, SetNumber: 1, Reps: 10, Weight: 60.0},
+					},
+				}
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系: 終了時刻が開始時刻より前",
+			setup: func(s *workoutTestSetup) RecordWorkoutInput {
+				exercise := s.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+				startedAt := testDate.Add(7 * time.Hour)
+				endedAt := startedAt.Add(-time.Minute)
+				return RecordWorkoutInput{
+					UserID:    uuid.New(),
+					Date:      testDate,
+					StartedAt: &startedAt,
+					EndedAt:   &endedAt,
+					Sets: []SetInput{
+						{ExerciseID: exercise.ID, SetNumber: 1, Reps: 10, Weight: 60.0},
+					},
+				}
+			},
 			wantErr: true,
 			checkErr: func(err error) bool {
-				return errors.Is(err, service.ErrDuplicateWorkoutDate)
+				return errors.Is(err, entity.ErrInvalidWorkoutEndTime)
 			},
 		},
 		{
@@ -346,6 +687,23 @@ func TestWorkoutUsecase_RecordWorkout(t *testing.T) {
 				return errors.Is(err, ErrEmptyWorkoutSets)
 			},
 		},
+		{
+			name: "異常系: 他ユーザーのカスタム種目",
+			setup: func(s *workoutTestSetup) RecordWorkoutInput {
+				exercise := s.exerciseRepo.addUserExercise(uuid.New(), "他人の種目", nil, nil)
+				return RecordWorkoutInput{
+					UserID: uuid.New(),
+					Date:   testDate,
+					Sets: []SetInput{
+						{ExerciseID: exercise.ID, SetNumber: 1, Reps: 10, Weight: 60.0},
+					},
+				}
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrExerciseNotFound)
+			},
+		},
 		{
 			name: "異常系: 存在しないエクササイズID",
 			setup: func(s *workoutTestSetup) RecordWorkoutInput {
@@ -467,6 +825,18 @@ func TestWorkoutUsecase_RecordWorkout(t *testing.T) {
 					t.Errorf("RecordWorkout() memo = %v, want %v", output.Workout.Memo, *input.Memo)
 				}
 			}
+
+			// セッション時刻が設定されているか確認（開始時刻の指定がない場合は日付）
+			wantStartedAt := input.Date
+			if input.StartedAt != nil {
+				wantStartedAt = *input.StartedAt
+			}
+			if !output.Workout.StartedAt.Equal(wantStartedAt) {
+				t.Errorf("RecordWorkout() started_at = %v, want %v", output.Workout.StartedAt, wantStartedAt)
+			}
+			if (output.Workout.EndedAt == nil) != (input.EndedAt == nil) {
+				t.Errorf("RecordWorkout() ended_at = %v, want %v", output.Workout.EndedAt, input.EndedAt)
+			}
 		})
 	}
 }
@@ -569,16 +939,17 @@ func TestWorkoutUsecase_GetUserWorkouts(t *testing.T) {
 
 	tests := []struct {
 		name      string
-		startDate *time.Time
-		endDate   *time.Time
+		input     func(userID uuid.UUID) GetUserWorkoutsInput
 		setup     func(*workoutTestSetup) uuid.UUID
-		wantCount int
-		wantErr   bool
+		wantDates []time.Time
+		wantNext  bool
+		wantErr   error
 	}{
 		{
-			name:      "正常系: 全件取得",
-			startDate: nil,
-			endDate:   nil,
+			name: "正常系: 全件取得（新しい順）",
+			input: func(userID uuid.UUID) GetUserWorkoutsInput {
+				return GetUserWorkoutsInput{UserID: userID}
+			},
 			setup: func(s *workoutTestSetup) uuid.UUID {
 				userID := uuid.New()
 				s.workoutRepo.addWorkout(userID, date1)
@@ -586,13 +957,29 @@ func TestWorkoutUsecase_GetUserWorkouts(t *testing.T) {
 				s.workoutRepo.addWorkout(userID, date3)
 				return userID
 			},
-			wantCount: 3,
-			wantErr:   false,
+			wantDates: []time.Time{date3, date2, date1},
+			wantNext:  false,
+		},
+		{
+			name: "正常系: 古い順で取得",
+			input: func(userID uuid.UUID) GetUserWorkoutsInput {
+				return GetUserWorkoutsInput{UserID: userID, Sort: repository.WorkoutSortDateAsc}
+			},
+			setup: func(s *workoutTestSetup) uuid.UUID {
+				userID := uuid.New()
+				s.workoutRepo.addWorkout(userID, date2)
+				s.workoutRepo.addWorkout(userID, date3)
+				s.workoutRepo.addWorkout(userID, date1)
+				return userID
+			},
+			wantDates: []time.Time{date1, date2, date3},
+			wantNext:  false,
 		},
 		{
-			name:      "正常系: 日付範囲でフィルタリング",
-			startDate: &date1,
-			endDate:   &date2,
+			name: "正常系: 件数を超える場合は次ページのカーソルを返す",
+			input: func(userID uuid.UUID) GetUserWorkoutsInput {
+				return GetUserWorkoutsInput{UserID: userID, Limit: int32Ptr(2)}
+			},
 			setup: func(s *workoutTestSetup) uuid.UUID {
 				userID := uuid.New()
 				s.workoutRepo.addWorkout(userID, date1)
@@ -600,18 +987,111 @@ func TestWorkoutUsecase_GetUserWorkouts(t *testing.T) {
 				s.workoutRepo.addWorkout(userID, date3)
 				return userID
 			},
-			wantCount: 2,
-			wantErr:   false,
+			wantDates: []time.Time{date3, date2},
+			wantNext:  true,
+		},
+		{
+			name: "正常系: 件数ちょうどの場合は次ページなし",
+			input: func(userID uuid.UUID) GetUserWorkoutsInput {
+				return GetUserWorkoutsInput{UserID: userID, Limit: int32Ptr(2)}
+			},
+			setup: func(s *workoutTestSetup) uuid.UUID {
+				userID := uuid.New()
+				s.workoutRepo.addWorkout(userID, date1)
+				s.workoutRepo.addWorkout(userID, date2)
+				return userID
+			},
+			wantDates: []time.Time{date2, date1},
+			wantNext:  false,
+		},
+		{
+			name: "正常系: 日付範囲とスコアとメモで絞り込み",
+			input: func(userID uuid.UUID) GetUserWorkoutsInput {
+				return GetUserWorkoutsInput{
+					UserID: userID,
+					Filter: WorkoutFilter{
+						StartDate: &date1,
+						EndDate:   &date2,
+						MinScore:  int32Ptr(30),
+						MemoQuery: strPtr("bench"),
+					},
+				}
+			},
+			setup: func(s *workoutTestSetup) uuid.UUID {
+				userID := uuid.New()
+				w1 := s.workoutRepo.addWorkout(userID, date1)
+				w1.DailyScore = 50
+				w1.UpdateMemo(strPtr("Heavy BENCH day"))
+				w2 := s.workoutRepo.addWorkout(userID, date2)
+				w2.DailyScore = 10
+				w2.UpdateMemo(strPtr("bench"))
+				w3 := s.workoutRepo.addWorkout(userID, date3)
+				w3.DailyScore = 80
+				w3.UpdateMemo(strPtr("bench"))
+				return userID
+			},
+			wantDates: []time.Time{date1},
+			wantNext:  false,
 		},
 		{
-			name:      "正常系: 空結果",
-			startDate: nil,
-			endDate:   nil,
+			name: "正常系: 空結果",
+			input: func(userID uuid.UUID) GetUserWorkoutsInput {
+				return GetUserWorkoutsInput{UserID: userID}
+			},
 			setup: func(s *workoutTestSetup) uuid.UUID {
 				return uuid.New()
 			},
-			wantCount: 0,
-			wantErr:   false,
+			wantDates: []time.Time{},
+			wantNext:  false,
+		},
+		{
+			name: "異常系: 不正な並び順",
+			input: func(userID uuid.UUID) GetUserWorkoutsInput {
+				return GetUserWorkoutsInput{UserID: userID, Sort: "score_desc"}
+			},
+			setup:   func(s *workoutTestSetup) uuid.UUID { return uuid.New() },
+			wantErr: ErrInvalidWorkoutSort,
+		},
+		{
+			name: "異常系: 取得件数が0",
+			input: func(userID uuid.UUID) GetUserWorkoutsInput {
+				return GetUserWorkoutsInput{UserID: userID, Limit: int32Ptr(0)}
+			},
+			setup:   func(s *workoutTestSetup) uuid.UUID { return uuid.New() },
+			wantErr: ErrInvalidWorkoutPageLimit,
+		},
+		{
+			name: "異常系: 取得件数が上限超過",
+			input: func(userID uuid.UUID) GetUserWorkoutsInput {
+				return GetUserWorkoutsInput{UserID: userID, Limit: int32Ptr(MaxWorkoutPageLimit + 1)}
+			},
+			setup:   func(s *workoutTestSetup) uuid.UUID { return uuid.New() },
+			wantErr: ErrInvalidWorkoutPageLimit,
+		},
+		{
+			name: "異常系: 不正なカーソル",
+			input: func(userID uuid.UUID) GetUserWorkoutsInput {
+				return GetUserWorkoutsInput{UserID: userID, Cursor: strPtr("not-a-cursor")}
+			},
+			setup:   func(s *workoutTestSetup) uuid.UUID { return uuid.New() },
+			wantErr: ErrInvalidWorkoutCursor,
+		},
+		{
+			name: "異常系: スコアの下限が範囲外",
+			input: func(userID uuid.UUID) GetUserWorkoutsInput {
+				return GetUserWorkoutsInput{UserID: userID, Filter: WorkoutFilter{MinScore: int32Ptr(101)}}
+			},
+			setup:   func(s *workoutTestSetup) uuid.UUID { return uuid.New() },
+			wantErr: ErrInvalidMinScore,
+		},
+		{
+			name: "異常系: 不正な身体部位",
+			input: func(userID uuid.UUID) GetUserWorkoutsInput {
+				bodyPart := entity.BodyPart("tail")
+				return GetUserWorkoutsInput{UserID: userID, Filter: WorkoutFilter{BodyPart: &bodyPart}}
+			},
+			setup:   func(s *workoutTestSetup) uuid.UUID { return uuid.New() },
+			wantErr: entity.ErrInvalidBodyPart,
 		},
 	}
 
@@ -620,11 +1100,11 @@ func TestWorkoutUsecase_GetUserWorkouts(t *testing.T) {
 			setup := newWorkoutTestSetup()
 			userID := tt.setup(setup)
 
-			workouts, err := setup.usecase.GetUserWorkouts(context.Background(), userID, tt.startDate, tt.endDate)
+			output, err := setup.usecase.GetUserWorkouts(context.Background(), tt.input(userID))
 
-			if tt.wantErr {
-				if err == nil {
-					t.Error("GetUserWorkouts() error = nil, want error")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetUserWorkouts() error = %v, want %v", err, tt.wantErr)
 				}
 				return
 			}
@@ -634,23 +1114,214 @@ func TestWorkoutUsecase_GetUserWorkouts(t *testing.T) {
 				return
 			}
 
-			if len(workouts) != tt.wantCount {
-				t.Errorf("GetUserWorkouts() count = %v, want %v", len(workouts), tt.wantCount)
+			if len(output.Workouts) != len(tt.wantDates) {
+				t.Fatalf("GetUserWorkouts() count = %v, want %v", len(output.Workouts), len(tt.wantDates))
+			}
+			for i, want := range tt.wantDates {
+				if !output.Workouts[i].Date.Equal(want) {
+					t.Errorf("GetUserWorkouts() workouts[%d].Date = %v, want %v", i, output.Workouts[i].Date, want)
+				}
+			}
+
+			if (output.NextCursor != nil) != tt.wantNext {
+				t.Errorf("GetUserWorkouts() NextCursor = %v, want present = %v", output.NextCursor, tt.wantNext)
 			}
 		})
 	}
 }
 
-func TestWorkoutUsecase_UpdateWorkoutMemo(t *testing.T) {
-	testDate := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
+func TestWorkoutUsecase_GetUserWorkouts_Pagination(t *testing.T) {
+	setup := newWorkoutTestSetup()
+	userID := uuid.New()
+	date := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	// 同日の複数セッションを含む5件を用意し、2件ずつ辿って全件を重複なく取得できることを確認する
+	want := make(map[uuid.UUID]bool)
+	for i := 0; i < 5; i++ {
+		w := setup.workoutRepo.addWorkout(userID, date.AddDate(0, 0, i/2))
+		w.StartedAt = date.AddDate(0, 0, i/2).Add(time.Duration(i) * time.Hour)
+		want[w.ID] = true
+	}
 
-	tests := []struct {
-		name     string
-		memo     *string
-		setup    func(*workoutTestSetup) (uuid.UUID, uuid.UUID)
-		wantErr  bool
-		checkErr func(error) bool
-	}{
+	seen := make(map[uuid.UUID]bool)
+	var cursor *string
+	var prev *entity.Workout
+	for page := 0; page < 10; page++ {
+		output, err := setup.usecase.GetUserWorkouts(context.Background(), GetUserWorkoutsInput{
+			UserID: userID,
+			Cursor: cursor,
+			Limit:  int32Ptr(2),
+		})
+		if err != nil {
+			t.Fatalf("GetUserWorkouts() unexpected error = %v", err)
+		}
+		for _, w := range output.Workouts {
+			if seen[w.ID] {
+				t.Errorf("workout %v returned twice", w.ID)
+			}
+			if prev != nil && w.StartedAt.After(prev.StartedAt) {
+				t.Errorf("workouts not in descending order: %v after %v", w.StartedAt, prev.StartedAt)
+			}
+			seen[w.ID] = true
+			prev = w
+		}
+		if output.NextCursor == nil {
+			break
+		}
+		cursor = output.NextCursor
+	}
+
+	if len(seen) != len(want) {
+		t.Errorf("paged through %d workouts, want %d", len(seen), len(want))
+	}
+}
+
+func TestWorkoutUsecase_GetUserWorkouts_WithDetails(t *testing.T) {
+	chestPart := entity.BodyPartChest
+	armsPart := entity.BodyPartArms
+	date := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		setup func(*workoutTestSetup) GetUserWorkoutsInput
+		check func(*testing.T, *workoutTestSetup, *GetUserWorkoutsOutput)
+	}{
+		{
+			name: "正常系: セット・種目・サマリーをワークアウトごとに集計",
+			setup: func(s *workoutTestSetup) GetUserWorkoutsInput {
+				userID := uuid.New()
+				bench := s.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+				curl := s.exerciseRepo.addExercise("アームカール", nil, &armsPart)
+				plank := s.exerciseRepo.addExercise("プランク", nil, nil)
+				w := s.workoutRepo.addWorkout(userID, date)
+				s.workoutSetRepo.addWorkoutSet(w.ID, bench.ID, 1, 10, 60)
+				s.workoutSetRepo.addWorkoutSet(w.ID, bench.ID, 2, 8, 70)
+				s.workoutSetRepo.addWorkoutSet(w.ID, curl.ID, 1, 12, 10)
+				s.workoutSetRepo.addWorkoutSet(w.ID, plank.ID, 1, 1, 0)
+				// ウォームアップのセットは総ボリュームに含めない
+				warmUp := s.workoutSetRepo.addWorkoutSet(w.ID, bench.ID, 3, 10, 20)
+				warmUp.UpdateSetType(entity.SetTypeWarmUp)
+				return GetUserWorkoutsInput{UserID: userID, WithDetails: true}
+			},
+			check: func(t *testing.T, s *workoutTestSetup, output *GetUserWorkoutsOutput) {
+				if len(output.Workouts) != 1 {
+					t.Fatalf("len(Workouts) = %d, want 1", len(output.Workouts))
+				}
+				detail, ok := output.Details[output.Workouts[0].ID]
+				if !ok {
+					t.Fatalf("Details missing workout %v", output.Workouts[0].ID)
+				}
+				if len(detail.Sets) != 5 {
+					t.Errorf("len(Sets) = %d, want 5", len(detail.Sets))
+				}
+				if len(detail.Exercises) != 3 {
+					t.Errorf("len(Exercises) = %d, want 3", len(detail.Exercises))
+				}
+				for _, e := range detail.Exercises {
+					if e.Name == "" {
+						t.Errorf("exercise %v has empty name", e.ID)
+					}
+				}
+				summary := detail.Summary
+				if summary.TotalVolume != 60*10+70*8+10*12 {
+					t.Errorf("TotalVolume = %v, want %v", summary.TotalVolume, 60*10+70*8+10*12)
+				}
+				if summary.SetCount != 5 {
+					t.Errorf("SetCount = %d, want 5", summary.SetCount)
+				}
+				if summary.ExerciseCount != 3 {
+					t.Errorf("ExerciseCount = %d, want 3", summary.ExerciseCount)
+				}
+				wantParts := []entity.BodyPart{entity.BodyPartArms, entity.BodyPartChest}
+				if len(summary.BodyParts) != len(wantParts) {
+					t.Fatalf("BodyParts = %v, want %v", summary.BodyParts, wantParts)
+				}
+				for i := range wantParts {
+					if summary.BodyParts[i] != wantParts[i] {
+						t.Errorf("BodyParts = %v, want %v", summary.BodyParts, wantParts)
+						break
+					}
+				}
+			},
+		},
+		{
+			name: "正常系: セットのないワークアウトは空の詳細を返す",
+			setup: func(s *workoutTestSetup) GetUserWorkoutsInput {
+				userID := uuid.New()
+				s.workoutRepo.addWorkout(userID, date)
+				return GetUserWorkoutsInput{UserID: userID, WithDetails: true}
+			},
+			check: func(t *testing.T, s *workoutTestSetup, output *GetUserWorkoutsOutput) {
+				detail, ok := output.Details[output.Workouts[0].ID]
+				if !ok {
+					t.Fatalf("Details missing workout %v", output.Workouts[0].ID)
+				}
+				if len(detail.Sets) != 0 || len(detail.Exercises) != 0 || detail.Summary.SetCount != 0 {
+					t.Errorf("detail = %+v, want empty", detail)
+				}
+				if detail.Summary.BodyParts == nil {
+					t.Error("BodyParts should be an empty slice, not nil")
+				}
+			},
+		},
+		{
+			name: "正常系: ページ外のワークアウトのセットは含まない",
+			setup: func(s *workoutTestSetup) GetUserWorkoutsInput {
+				userID := uuid.New()
+				bench := s.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+				older := s.workoutRepo.addWorkout(userID, date)
+				s.workoutSetRepo.addWorkoutSet(older.ID, bench.ID, 1, 10, 60)
+				s.workoutRepo.addWorkout(userID, date.AddDate(0, 0, 1))
+				return GetUserWorkoutsInput{UserID: userID, Limit: int32Ptr(1), WithDetails: true}
+			},
+			check: func(t *testing.T, s *workoutTestSetup, output *GetUserWorkoutsOutput) {
+				if len(output.Details) != 1 {
+					t.Fatalf("len(Details) = %d, want 1", len(output.Details))
+				}
+				if detail := output.Details[output.Workouts[0].ID]; len(detail.Sets) != 0 {
+					t.Errorf("len(Sets) = %d, want 0", len(detail.Sets))
+				}
+			},
+		},
+		{
+			name: "正常系: WithDetailsがfalseの場合は詳細を取得しない",
+			setup: func(s *workoutTestSetup) GetUserWorkoutsInput {
+				userID := uuid.New()
+				s.workoutRepo.addWorkout(userID, date)
+				return GetUserWorkoutsInput{UserID: userID}
+			},
+			check: func(t *testing.T, s *workoutTestSetup, output *GetUserWorkoutsOutput) {
+				if output.Details != nil {
+					t.Errorf("Details = %v, want nil", output.Details)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup := newWorkoutTestSetup()
+			input := tt.setup(setup)
+
+			output, err := setup.usecase.GetUserWorkouts(context.Background(), input)
+			if err != nil {
+				t.Fatalf("GetUserWorkouts() unexpected error = %v", err)
+			}
+			tt.check(t, setup, output)
+		})
+	}
+}
+
+func TestWorkoutUsecase_UpdateWorkoutMemo(t *testing.T) {
+	testDate := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		memo     *string
+		setup    func(*workoutTestSetup) (uuid.UUID, uuid.UUID)
+		wantErr  bool
+		checkErr func(error) bool
+	}{
 		{
 			name: "正常系: メモを更新",
 			memo: strPtr("良いトレーニングだった"),
@@ -797,7 +1468,7 @@ func TestWorkoutUsecase_AddWorkoutSets(t *testing.T) {
 			setup := newWorkoutTestSetup()
 			userID, workoutID, sets := tt.setup(setup)
 
-			createdSets, err := setup.usecase.AddWorkoutSets(context.Background(), userID, workoutID, sets)
+			output, err := setup.usecase.AddWorkoutSets(context.Background(), userID, workoutID, sets)
 
 			if tt.wantErr {
 				if err == nil {
@@ -815,8 +1486,8 @@ func TestWorkoutUsecase_AddWorkoutSets(t *testing.T) {
 				return
 			}
 
-			if len(createdSets) != len(sets) {
-				t.Errorf("AddWorkoutSets() created sets count = %v, want %v", len(createdSets), len(sets))
+			if len(output.Sets) != len(sets) {
+				t.Errorf("AddWorkoutSets() created sets count = %v, want %v", len(output.Sets), len(sets))
 			}
 
 			// デイリースコアが再計算されているか確認
@@ -828,6 +1499,171 @@ func TestWorkoutUsecase_AddWorkoutSets(t *testing.T) {
 	}
 }
 
+func TestWorkoutUsecase_UpdateWorkoutSet(t *testing.T) {
+	chestPart := entity.BodyPartChest
+	testDate := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		input      UpdateWorkoutSetInput
+		setup      func(*workoutTestSetup) (uuid.UUID, uuid.UUID)
+		wantReps   int32
+		wantWeight float64
+		wantErr    bool
+		checkErr   func(error) bool
+	}{
+		{
+			name:  "正常系: 重量のみ更新（レップ数は維持）",
+			input: UpdateWorkoutSetInput{Weight: float64Ptr(100.0)},
+			setup: func(s *workoutTestSetup) (uuid.UUID, uuid.UUID) {
+				userID := uuid.New()
+				workout := s.workoutRepo.addWorkout(userID, testDate)
+				exercise := s.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+				set := s.workoutSetRepo.addWorkoutSet(workout.ID, exercise.ID, 1, 10, 60.0)
+				return userID, set.ID
+			},
+			wantReps:   10,
+			wantWeight: 100.0,
+			wantErr:    false,
+		},
+		{
+			name:  "正常系: レップ数のみ更新（重量は維持）",
+			input: UpdateWorkoutSetInput{Reps: int32Ptr(5)},
+			setup: func(s *workoutTestSetup) (uuid.UUID, uuid.UUID) {
+				userID := uuid.New()
+				workout := s.workoutRepo.addWorkout(userID, testDate)
+				exercise := s.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+				set := s.workoutSetRepo.addWorkoutSet(workout.ID, exercise.ID, 1, 10, 60.0)
+				return userID, set.ID
+			},
+			wantReps:   5,
+			wantWeight: 60.0,
+			wantErr:    false,
+		},
+		{
+			name:  "正常系: メモと継続時間のみ更新",
+			input: UpdateWorkoutSetInput{DurationSeconds: int32Ptr(90), Notes: strPtr("フォーム修正")},
+			setup: func(s *workoutTestSetup) (uuid.UUID, uuid.UUID) {
+				userID := uuid.New()
+				workout := s.workoutRepo.addWorkout(userID, testDate)
+				exercise := s.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+				set := s.workoutSetRepo.addWorkoutSet(workout.ID, exercise.ID, 1, 10, 60.0)
+				return userID, set.ID
+			},
+			wantReps:   10,
+			wantWeight: 60.0,
+			wantErr:    false,
+		},
+		{
+			name:  "異常系: セットが存在しない",
+			input: UpdateWorkoutSetInput{Weight: float64Ptr(100.0)},
+			setup: func(s *workoutTestSetup) (uuid.UUID, uuid.UUID) {
+				return uuid.New(), uuid.New()
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrWorkoutSetNotFound)
+			},
+		},
+		{
+			name:  "異常系: アクセス権がない",
+			input: UpdateWorkoutSetInput{Weight: float64Ptr(100.0)},
+			setup: func(s *workoutTestSetup) (uuid.UUID, uuid.UUID) {
+				workout := s.workoutRepo.addWorkout(uuid.New(), testDate)
+				exercise := s.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+				set := s.workoutSetRepo.addWorkoutSet(workout.ID, exercise.ID, 1, 10, 60.0)
+				return uuid.New(), set.ID
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, ErrWorkoutAccessDenied)
+			},
+		},
+		{
+			name:  "異常系: 不正なレップ数",
+			input: UpdateWorkoutSetInput{Reps: int32Ptr(0)},
+			setup: func(s *workoutTestSetup) (uuid.UUID, uuid.UUID) {
+				userID := uuid.New()
+				workout := s.workoutRepo.addWorkout(userID, testDate)
+				exercise := s.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+				set := s.workoutSetRepo.addWorkoutSet(workout.ID, exercise.ID, 1, 10, 60.0)
+				return userID, set.ID
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, entity.ErrInvalidReps)
+			},
+		},
+		{
+			name:  "異常系: 不正な継続時間",
+			input: UpdateWorkoutSetInput{DurationSeconds: int32Ptr(-1)},
+			setup: func(s *workoutTestSetup) (uuid.UUID, uuid.UUID) {
+				userID := uuid.New()
+				workout := s.workoutRepo.addWorkout(userID, testDate)
+				exercise := s.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+				set := s.workoutSetRepo.addWorkoutSet(workout.ID, exercise.ID, 1, 10, 60.0)
+				return userID, set.ID
+			},
+			wantErr: true,
+			checkErr: func(err error) bool {
+				return errors.Is(err, entity.ErrInvalidDuration)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup := newWorkoutTestSetup()
+			userID, workoutSetID := tt.setup(setup)
+
+			set, err := setup.usecase.UpdateWorkoutSet(context.Background(), userID, workoutSetID, tt.input)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Error("UpdateWorkoutSet() error = nil, want error")
+					return
+				}
+				if tt.checkErr != nil && !tt.checkErr(err) {
+					t.Errorf("UpdateWorkoutSet() error = %v, want specific error", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("UpdateWorkoutSet() unexpected error = %v", err)
+				return
+			}
+
+			if set.Reps != tt.wantReps {
+				t.Errorf("set.Reps = %v, want %v", set.Reps, tt.wantReps)
+			}
+			if set.Weight != tt.wantWeight {
+				t.Errorf("set.Weight = %v, want %v", set.Weight, tt.wantWeight)
+			}
+
+			// 推定1RMが再計算されているか確認
+			want1RM := entity.CalculateEstimated1RM(tt.wantWeight, tt.wantReps)
+			if set.Estimated1RM != want1RM {
+				t.Errorf("set.Estimated1RM = %v, want %v", set.Estimated1RM, want1RM)
+			}
+
+			if tt.input.DurationSeconds != nil && (set.DurationSeconds == nil || *set.DurationSeconds != *tt.input.DurationSeconds) {
+				t.Errorf("set.DurationSeconds = %v, want %v", set.DurationSeconds, *tt.input.DurationSeconds)
+			}
+			if tt.input.Notes != nil && (set.Notes == nil || *set.Notes != *tt.input.Notes) {
+				t.Errorf("set.Notes = %v, want %v", set.Notes, *tt.input.Notes)
+			}
+
+			// デイリースコアが更新後のボリュームで再計算されているか確認
+			workout, _ := setup.workoutRepo.FindByID(context.Background(), set.WorkoutID)
+			wantScore := entity.NewDailyScoreStrategy(entity.DailyScoreStrategyAbsolute).CalculateDailyScore(entity.DailyScoreInput{Sets: []*entity.WorkoutSet{set}})
+			if workout.DailyScore != wantScore {
+				t.Errorf("workout.DailyScore = %v, want %v", workout.DailyScore, wantScore)
+			}
+		})
+	}
+}
+
 func TestWorkoutUsecase_DeleteWorkoutSet(t *testing.T) {
 	chestPart := entity.BodyPartChest
 	testDate := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
@@ -1001,6 +1837,7 @@ func TestWorkoutUsecase_GetContributionData(t *testing.T) {
 		name      string
 		setup     func(*workoutTestSetup) uuid.UUID
 		wantCount int
+		want      map[string]ContributionDataPoint
 		wantErr   bool
 	}{
 		{
@@ -1015,6 +1852,25 @@ func TestWorkoutUsecase_GetContributionData(t *testing.T) {
 			wantCount: 3,
 			wantErr:   false,
 		},
+		{
+			name: "正常系: 同日の複数セッションを合算（上限100）",
+			setup: func(s *workoutTestSetup) uuid.UUID {
+				userID := uuid.New()
+				day1 := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+				day2 := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+				s.workoutRepo.addWorkout(userID, day1).DailyScore = 40
+				s.workoutRepo.addWorkout(userID, day1).DailyScore = 35
+				s.workoutRepo.addWorkout(userID, day2).DailyScore = 70
+				s.workoutRepo.addWorkout(userID, day2).DailyScore = 60
+				return userID
+			},
+			wantCount: 2,
+			want: map[string]ContributionDataPoint{
+				"2026-02-01": {DailyScore: 75, SessionCount: 2},
+				"2026-02-02": {DailyScore: 100, SessionCount: 2},
+			},
+			wantErr: false,
+		},
 		{
 			name: "正常系: 空結果",
 			setup: func(s *workoutTestSetup) uuid.UUID {
@@ -1047,36 +1903,919 @@ func TestWorkoutUsecase_GetContributionData(t *testing.T) {
 			if len(dataPoints) != tt.wantCount {
 				t.Errorf("GetContributionData() count = %v, want %v", len(dataPoints), tt.wantCount)
 			}
+
+			for _, dp := range dataPoints {
+				want, ok := tt.want[dp.Date.Format(time.DateOnly)]
+				if !ok {
+					continue
+				}
+				if dp.DailyScore != want.DailyScore || dp.SessionCount != want.SessionCount {
+					t.Errorf("GetContributionData() %s = (score %d, sessions %d), want (score %d, sessions %d)",
+						dp.Date.Format(time.DateOnly), dp.DailyScore, dp.SessionCount, want.DailyScore, want.SessionCount)
+				}
+			}
 		})
 	}
 }
 
-func TestWorkoutUsecase_RecordWorkout_DailyScoreCalculation(t *testing.T) {
-	chestPart := entity.BodyPartChest
-	testDate := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
+func TestWorkoutUsecase_GetWeightProgression(t *testing.T) {
+	tests := []struct {
+		name        string
+		formula     *entity.OneRMFormula
+		setup       func(s *workoutTestSetup, userID uuid.UUID)
+		wantFormula entity.OneRMFormula
+		wantErr     error
+	}{
+		{
+			name:        "正常系: 公式の指定なし・プロフィール未作成の場合はEpley式",
+			setup:       func(s *workoutTestSetup, userID uuid.UUID) {},
+			wantFormula: entity.OneRMFormulaEpley,
+		},
+		{
+			name: "正常系: 公式の指定なしの場合はプロフィールの公式",
+			setup: func(s *workoutTestSetup, userID uuid.UUID) {
+				profile := s.profileRepo.addProfile(userID, "テストユーザー")
+				profile.OneRMFormula = entity.OneRMFormulaWathan
+			},
+			wantFormula: entity.OneRMFormulaWathan,
+		},
+		{
+			name:    "正常系: 指定した公式がプロフィールより優先される",
+			formula: oneRMFormulaPtr(entity.OneRMFormulaLombardi),
+			setup: func(s *workoutTestSetup, userID uuid.UUID) {
+				profile := s.profileRepo.addProfile(userID, "テストユーザー")
+				profile.OneRMFormula = entity.OneRMFormulaWathan
+			},
+			wantFormula: entity.OneRMFormulaLombardi,
+		},
+		{
+			name:    "異常系: 無効な公式",
+			formula: oneRMFormulaPtr(entity.OneRMFormula("unknown")),
+			setup:   func(s *workoutTestSetup, userID uuid.UUID) {},
+			wantErr: entity.ErrInvalidOneRMFormula,
+		},
+	}
 
-	setup := newWorkoutTestSetup()
-	exercise := setup.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup := newWorkoutTestSetup()
+			userID := uuid.New()
+			tt.setup(setup, userID)
 
-	input := RecordWorkoutInput{
-		UserID: uuid.New(),
-		Date:   testDate,
-		Sets: []SetInput{
-			{ExerciseID: exercise.ID, SetNumber: 1, Reps: 10, Weight: 60.0},
-			{ExerciseID: exercise.ID, SetNumber: 2, Reps: 8, Weight: 65.0},
-			{ExerciseID: exercise.ID, SetNumber: 3, Reps: 6, Weight: 70.0},
-		},
+			_, err := setup.usecase.GetWeightProgression(context.Background(), userID, uuid.New(), tt.formula)
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("GetWeightProgression() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("GetWeightProgression() unexpected error = %v", err)
+			}
+
+			if setup.workoutSetRepo.progressionFormula != tt.wantFormula {
+				t.Errorf("GetWeightProgression() formula = %v, want %v", setup.workoutSetRepo.progressionFormula, tt.wantFormula)
+			}
+		})
 	}
+}
 
-	output, err := setup.usecase.RecordWorkout(context.Background(), input)
-	if err != nil {
-		t.Fatalf("RecordWorkout() unexpected error = %v", err)
+func TestWorkoutUsecase_ExportWorkouts(t *testing.T) {
+	userID := uuid.New()
+	jan10 := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	jan20 := time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		startDate *time.Time
+		endDate   *time.Time
+		repoErr   error
+		wantRows  int
+		wantErr   bool
+	}{
+		{
+			name:     "正常系: 期間の指定なしの場合は全件",
+			wantRows: 3,
+		},
+		{
+			name:      "正常系: 期間で絞り込む",
+			startDate: &jan20,
+			wantRows:  1,
+		},
+		{
+			name:    "異常系: リポジトリエラー",
+			repoErr: errors.New("database error"),
+			wantErr: true,
+		},
 	}
 
-	// totalVolume = 10*60 + 8*65 + 6*70 = 600 + 520 + 420 = 1540
-	// score = 100 * √(1540/20000) ≈ 27.75 → 28
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup := newWorkoutTestSetup()
+			first := entity.NewWorkout(userID, jan10)
+			second := entity.NewWorkout(userID, jan20)
+			set1, _ := entity.NewWorkoutSet(first.ID, uuid.New(), 1, 10, 60)
+			set2, _ := entity.NewWorkoutSet(first.ID, set1.ExerciseID, 2, 8, 65)
+			setup.workoutRepo.exportRows = []repository.WorkoutExportRow{
+				{Workout: first, Set: set1, ExerciseName: "ベンチプレス"},
+				{Workout: first, Set: set2, ExerciseName: "ベンチプレス"},
+				{Workout: second},
+				{Workout: entity.NewWorkout(uuid.New(), jan10)},
+			}
+			setup.workoutRepo.err = tt.repoErr
+
+			var rows []WorkoutExportRow
+			var gotErr error
+			for row, err := range setup.usecase.ExportWorkouts(context.Background(), userID, tt.startDate, tt.endDate) {
+				if err != nil {
+					gotErr = err
+					break
+				}
+				rows = append(rows, row)
+			}
+
+			if tt.wantErr {
+				if gotErr == nil {
+					t.Error("ExportWorkouts() expected error")
+				}
+				return
+			}
+			if gotErr != nil {
+				t.Fatalf("ExportWorkouts() unexpected error = %v", gotErr)
+			}
+			if len(rows) != tt.wantRows {
+				t.Errorf("ExportWorkouts() returned %d rows, want %d", len(rows), tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestWorkoutUsecase_RecordWorkout_DailyScoreCalculation(t *testing.T) {
+	chestPart := entity.BodyPartChest
+	testDate := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
+
+	setup := newWorkoutTestSetup()
+	exercise := setup.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+
+	input := RecordWorkoutInput{
+		UserID: uuid.New(),
+		Date:   testDate,
+		Sets: []SetInput{
+			{ExerciseID: exercise.ID, SetNumber: 1, Reps: 10, Weight: 60.0},
+			{ExerciseID: exercise.ID, SetNumber: 2, Reps: 8, Weight: 65.0},
+			{ExerciseID: exercise.ID, SetNumber: 3, Reps: 6, Weight: 70.0},
+		},
+	}
+
+	output, err := setup.usecase.RecordWorkout(context.Background(), input)
+	if err != nil {
+		t.Fatalf("RecordWorkout() unexpected error = %v", err)
+	}
+
+	// totalVolume = 10*60 + 8*65 + 6*70 = 600 + 520 + 420 = 1540
+	// score = 100 * √(1540/20000) ≈ 27.75 → 28
 	expectedScore := int32(28)
 	if output.Workout.DailyScore != expectedScore {
 		t.Errorf("DailyScore = %v, want %v", output.Workout.DailyScore, expectedScore)
 	}
 }
+
+func TestWorkoutUsecase_RecordWorkout_SetTypeAndEffort(t *testing.T) {
+	chestPart := entity.BodyPartChest
+	testDate := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
+	warmUp := entity.SetTypeWarmUp
+
+	t.Run("正常系: ウォームアップセットはデイリースコアに含めない", func(t *testing.T) {
+		setup := newWorkoutTestSetup()
+		userID := uuid.New()
+		exercise := setup.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+
+		output, err := setup.usecase.RecordWorkout(context.Background(), RecordWorkoutInput{
+			UserID: userID,
+			Date:   testDate,
+			Sets: []SetInput{
+				{ExerciseID: exercise.ID, SetNumber: 1, Reps: 10, Weight: 40.0, SetType: &warmUp},
+				{ExerciseID: exercise.ID, SetNumber: 2, Reps: 10, Weight: 100.0, RPE: float64Ptr(8.5)},
+				{ExerciseID: exercise.ID, SetNumber: 3, Reps: 10, Weight: 100.0, RIR: int32Ptr(1)},
+			},
+		})
+		if err != nil {
+			t.Fatalf("RecordWorkout() unexpected error = %v", err)
+		}
+
+		// totalVolume = 10*100 + 10*100 = 2000（ウォームアップの400は除外）
+		// score = 100 * √(2000/20000) ≈ 31.62 → 32
+		if output.Workout.DailyScore != 32 {
+			t.Errorf("DailyScore = %v, want 32", output.Workout.DailyScore)
+		}
+		if output.Sets[0].SetType != entity.SetTypeWarmUp || output.Sets[1].SetType != entity.SetTypeWorking {
+			t.Errorf("SetType = %v, %v, want warmup, working", output.Sets[0].SetType, output.Sets[1].SetType)
+		}
+		if output.Sets[1].RPE == nil || *output.Sets[1].RPE != 8.5 {
+			t.Errorf("RPE = %v, want 8.5", output.Sets[1].RPE)
+		}
+		if output.Sets[2].RIR == nil || *output.Sets[2].RIR != 1 {
+			t.Errorf("RIR = %v, want 1", output.Sets[2].RIR)
+		}
+
+		// RIRを指定するとRPEは消去される
+		updated, err := setup.usecase.UpdateWorkoutSet(context.Background(), userID, output.Sets[1].ID, UpdateWorkoutSetInput{
+			RIR: int32Ptr(2),
+		})
+		if err != nil {
+			t.Fatalf("UpdateWorkoutSet() unexpected error = %v", err)
+		}
+		if updated.RPE != nil || updated.RIR == nil || *updated.RIR != 2 {
+			t.Errorf("RPE = %v, RIR = %v, want nil, 2", updated.RPE, updated.RIR)
+		}
+		if updated.SetType != entity.SetTypeWorking {
+			t.Errorf("SetType = %v, want unchanged working", updated.SetType)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		set     SetInput
+		wantErr error
+	}{
+		{
+			name:    "異常系: 不正なRPE",
+			set:     SetInput{SetNumber: 1, Reps: 10, Weight: 60.0, RPE: float64Ptr(5)},
+			wantErr: entity.ErrInvalidRPE,
+		},
+		{
+			name:    "異常系: RPEとRIRの両方を指定",
+			set:     SetInput{SetNumber: 1, Reps: 10, Weight: 60.0, RPE: float64Ptr(8), RIR: int32Ptr(2)},
+			wantErr: entity.ErrRPEAndRIRBothSet,
+		},
+		{
+			name: "異常系: 不正なセットの種類",
+			set: func() SetInput {
+				setType := entity.SetType("cooldown")
+				return SetInput{SetNumber: 1, Reps: 10, Weight: 60.0, SetType: &setType}
+			}(),
+			wantErr: entity.ErrInvalidSetType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup := newWorkoutTestSetup()
+			exercise := setup.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+			tt.set.ExerciseID = exercise.ID
+
+			_, err := setup.usecase.RecordWorkout(context.Background(), RecordWorkoutInput{
+				UserID: uuid.New(),
+				Date:   testDate,
+				Sets:   []SetInput{tt.set},
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RecordWorkout() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(setup.workoutRepo.workouts) != 0 {
+				t.Errorf("workouts = %d, want 0 after rollback", len(setup.workoutRepo.workouts))
+			}
+		})
+	}
+}
+
+func TestWorkoutUsecase_RecordWorkout_OneRMFormula(t *testing.T) {
+	chestPart := entity.BodyPartChest
+	testDate := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		setup       func(s *workoutTestSetup, userID uuid.UUID)
+		wantFormula entity.OneRMFormula
+	}{
+		{
+			name:        "正常系: プロフィール未作成の場合はEpley式",
+			setup:       func(s *workoutTestSetup, userID uuid.UUID) {},
+			wantFormula: entity.OneRMFormulaEpley,
+		},
+		{
+			name: "正常系: プロフィールで選択した公式を使用",
+			setup: func(s *workoutTestSetup, userID uuid.UUID) {
+				profile := s.profileRepo.addProfile(userID, "テストユーザー")
+				profile.OneRMFormula = entity.OneRMFormulaBrzycki
+			},
+			wantFormula: entity.OneRMFormulaBrzycki,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup := newWorkoutTestSetup()
+			userID := uuid.New()
+			tt.setup(setup, userID)
+			exercise := setup.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+
+			output, err := setup.usecase.RecordWorkout(context.Background(), RecordWorkoutInput{
+				UserID: userID,
+				Date:   testDate,
+				Sets: []SetInput{
+					{ExerciseID: exercise.ID, SetNumber: 1, Reps: 5, Weight: 100.0},
+				},
+			})
+			if err != nil {
+				t.Fatalf("RecordWorkout() unexpected error = %v", err)
+			}
+
+			want := entity.CalculateEstimated1RMWithFormula(100.0, 5, tt.wantFormula)
+			if got := output.Sets[0].Estimated1RM; got != want {
+				t.Errorf("Estimated1RM = %v, want %v", got, want)
+			}
+
+			// セット更新時も同じ公式で再計算される
+			updated, err := setup.usecase.UpdateWorkoutSet(context.Background(), userID, output.Sets[0].ID, UpdateWorkoutSetInput{
+				Reps: int32Ptr(8),
+			})
+			if err != nil {
+				t.Fatalf("UpdateWorkoutSet() unexpected error = %v", err)
+			}
+
+			want = entity.CalculateEstimated1RMWithFormula(100.0, 8, tt.wantFormula)
+			if updated.Estimated1RM != want {
+				t.Errorf("updated Estimated1RM = %v, want %v", updated.Estimated1RM, want)
+			}
+		})
+	}
+}
+
+func TestWorkoutUsecase_RecordWorkout_ProfileLookupError(t *testing.T) {
+	t.Run("異常系: プロフィールの取得に失敗した場合はデフォルトの公式で保存せずエラーを返す", func(t *testing.T) {
+		setup := newWorkoutTestSetup()
+		exercise := setup.exerciseRepo.addExercise("ベンチプレス", nil, nil)
+		repoErr := errors.New("database unavailable")
+		setup.profileRepo.err = repoErr
+
+		_, err := setup.usecase.RecordWorkout(context.Background(), RecordWorkoutInput{
+			UserID: uuid.New(),
+			Date:   time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC),
+			Sets: []SetInput{
+				{ExerciseID: exercise.ID, SetNumber: 1, Reps: 5, Weight: 100.0},
+			},
+		})
+		if !errors.Is(err, repoErr) {
+			t.Errorf("RecordWorkout() error = %v, want %v", err, repoErr)
+		}
+		if len(setup.workoutSetRepo.sets) != 0 {
+			t.Errorf("保存されたセット数 = %d, want 0", len(setup.workoutSetRepo.sets))
+		}
+	})
+}
+
+func TestWorkoutUsecase_RecordWorkout_PersonalRecords(t *testing.T) {
+	chestPart := entity.BodyPartChest
+	firstDate := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
+	secondDate := firstDate.AddDate(0, 0, 1)
+
+	type recordKey struct {
+		recordType entity.PersonalRecordType
+		reps       int32
+	}
+
+	tests := []struct {
+		name    string
+		history []SetInput
+		sets    []SetInput
+		want    []recordKey
+	}{
+		{
+			name:    "正常系: 初回記録は全種類が自己ベスト",
+			history: nil,
+			sets: []SetInput{
+				{SetNumber: 1, Reps: 5, Weight: 100.0},
+				{SetNumber: 2, Reps: 3, Weight: 105.0},
+			},
+			want: []recordKey{
+				{entity.PersonalRecordTypeEstimated1RM, 0},
+				{entity.PersonalRecordTypeRepMax, 1},
+				{entity.PersonalRecordTypeRepMax, 3},
+				{entity.PersonalRecordTypeRepMax, 5},
+				{entity.PersonalRecordTypeSessionVolume, 0},
+			},
+		},
+		{
+			name: "正常系: 過去と同じ記録は自己ベストにならない",
+			history: []SetInput{
+				{SetNumber: 1, Reps: 5, Weight: 100.0},
+				{SetNumber: 2, Reps: 3, Weight: 105.0},
+			},
+			sets: []SetInput{
+				{SetNumber: 1, Reps: 5, Weight: 100.0},
+			},
+			want: nil,
+		},
+		{
+			name: "正常系: 初めての10レップで10RMのみ更新",
+			history: []SetInput{
+				{SetNumber: 1, Reps: 5, Weight: 100.0},
+				{SetNumber: 2, Reps: 3, Weight: 105.0},
+			},
+			sets: []SetInput{
+				{SetNumber: 1, Reps: 10, Weight: 80.0},
+			},
+			want: []recordKey{
+				{entity.PersonalRecordTypeRepMax, 10},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup := newWorkoutTestSetup()
+			userID := uuid.New()
+			exercise := setup.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+			withExercise := func(inputs []SetInput) []SetInput {
+				for i := range inputs {
+					inputs[i].ExerciseID = exercise.ID
+				}
+				return inputs
+			}
+
+			if len(tt.history) > 0 {
+				if _, err := setup.usecase.RecordWorkout(context.Background(), RecordWorkoutInput{
+					UserID: userID,
+					Date:   firstDate,
+					Sets:   withExercise(tt.history),
+				}); err != nil {
+					t.Fatalf("RecordWorkout() history unexpected error = %v", err)
+				}
+			}
+			persistedBefore := len(setup.personalRecordRepo.records)
+
+			output, err := setup.usecase.RecordWorkout(context.Background(), RecordWorkoutInput{
+				UserID: userID,
+				Date:   secondDate,
+				Sets:   withExercise(tt.sets),
+			})
+			if err != nil {
+				t.Fatalf("RecordWorkout() unexpected error = %v", err)
+			}
+
+			if len(output.PersonalRecords) != len(tt.want) {
+				t.Fatalf("PersonalRecords count = %d, want %d", len(output.PersonalRecords), len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := output.PersonalRecords[i]
+				var reps int32
+				if got.Reps != nil {
+					reps = *got.Reps
+				}
+				if got.RecordType != want.recordType || reps != want.reps {
+					t.Errorf("PersonalRecords[%d] = (%v, %d), want (%v, %d)", i, got.RecordType, reps, want.recordType, want.reps)
+				}
+				if got.WorkoutID != output.Workout.ID || got.UserID != userID || got.ExerciseID != exercise.ID {
+					t.Errorf("PersonalRecords[%d] has wrong workout/user/exercise", i)
+				}
+			}
+
+			if persisted := len(setup.personalRecordRepo.records) - persistedBefore; persisted != len(tt.want) {
+				t.Errorf("persisted records = %d, want %d", persisted, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestWorkoutUsecase_RecordWorkout_BackdatedPersonalRecords(t *testing.T) {
+	chestPart := entity.BodyPartChest
+	laterDate := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	earlierDate := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	setup := newWorkoutTestSetup()
+	userID := uuid.New()
+	exercise := setup.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+
+	later, err := setup.usecase.RecordWorkout(context.Background(), RecordWorkoutInput{
+		UserID: userID,
+		Date:   laterDate,
+		Sets:   []SetInput{{ExerciseID: exercise.ID, SetNumber: 1, Reps: 5, Weight: 100.0}},
+	})
+	if err != nil {
+		t.Fatalf("RecordWorkout() later unexpected error = %v", err)
+	}
+
+	// 後の日付の記録より軽くても、それより前の日付の記録としては初回の自己ベストになる
+	earlier, err := setup.usecase.RecordWorkout(context.Background(), RecordWorkoutInput{
+		UserID: userID,
+		Date:   earlierDate,
+		Sets:   []SetInput{{ExerciseID: exercise.ID, SetNumber: 1, Reps: 5, Weight: 80.0}},
+	})
+	if err != nil {
+		t.Fatalf("RecordWorkout() earlier unexpected error = %v", err)
+	}
+
+	// 推定1RM + nRM(1, 3, 5) + セッションボリューム
+	if len(earlier.PersonalRecords) != 5 {
+		t.Fatalf("earlier PersonalRecords count = %d, want 5", len(earlier.PersonalRecords))
+	}
+	for _, record := range earlier.PersonalRecords {
+		if record.WorkoutID != earlier.Workout.ID || record.PreviousValue != nil {
+			t.Errorf("earlier record = (%v, previous %v), want first record of earlier workout", record.RecordType, record.PreviousValue)
+		}
+	}
+
+	// 後の日付の自己ベストは、前の日付の記録を更新前の値として再構築される
+	if len(setup.personalRecordRepo.records) != 10 {
+		t.Fatalf("persisted records = %d, want 10", len(setup.personalRecordRepo.records))
+	}
+	want := entity.CalculateEstimated1RMWithFormula(80.0, 5, entity.DefaultOneRMFormula)
+	found := false
+	for _, record := range setup.personalRecordRepo.records {
+		if record.WorkoutID == later.Workout.ID && record.RecordType == entity.PersonalRecordTypeEstimated1RM {
+			found = true
+			if record.PreviousValue == nil || *record.PreviousValue != want {
+				t.Errorf("later estimated_1rm previous = %v, want %v", record.PreviousValue, want)
+			}
+		}
+	}
+	if !found {
+		t.Error("later estimated_1rm record not found")
+	}
+}
+
+func TestWorkoutUsecase_UpdateAndDeleteWorkoutSet_RebuildPersonalRecords(t *testing.T) {
+	chestPart := entity.BodyPartChest
+	firstDate := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	secondDate := firstDate.AddDate(0, 0, 1)
+
+	tests := []struct {
+		name string
+		// act は2回目のワークアウトを記録した後に行う操作
+		act func(s *workoutTestSetup, userID uuid.UUID, first, second *RecordWorkoutOutput) error
+		// wantWorkout は再構築後の自己ベストがすべて属するワークアウトを返す
+		wantWorkout func(first, second *RecordWorkoutOutput) uuid.UUID
+		wantCount   int
+	}{
+		{
+			name: "正常系: 自己ベストのセットの重量を下げると自己ベストではなくなる",
+			act: func(s *workoutTestSetup, userID uuid.UUID, first, second *RecordWorkoutOutput) error {
+				_, err := s.usecase.UpdateWorkoutSet(context.Background(), userID, second.Sets[0].ID, UpdateWorkoutSetInput{
+					Weight: float64Ptr(90.0),
+				})
+				return err
+			},
+			wantWorkout: func(first, second *RecordWorkoutOutput) uuid.UUID { return first.Workout.ID },
+			wantCount:   5,
+		},
+		{
+			name: "正常系: 過去のセットを削除すると以降の自己ベストが初回記録として再構築される",
+			act: func(s *workoutTestSetup, userID uuid.UUID, first, second *RecordWorkoutOutput) error {
+				return s.usecase.DeleteWorkoutSet(context.Background(), userID, first.Sets[0].ID)
+			},
+			wantWorkout: func(first, second *RecordWorkoutOutput) uuid.UUID { return second.Workout.ID },
+			wantCount:   5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup := newWorkoutTestSetup()
+			userID := uuid.New()
+			exercise := setup.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+
+			first, err := setup.usecase.RecordWorkout(context.Background(), RecordWorkoutInput{
+				UserID: userID,
+				Date:   firstDate,
+				Sets:   []SetInput{{ExerciseID: exercise.ID, SetNumber: 1, Reps: 5, Weight: 100.0}},
+			})
+			if err != nil {
+				t.Fatalf("RecordWorkout() first unexpected error = %v", err)
+			}
+			second, err := setup.usecase.RecordWorkout(context.Background(), RecordWorkoutInput{
+				UserID: userID,
+				Date:   secondDate,
+				Sets:   []SetInput{{ExerciseID: exercise.ID, SetNumber: 1, Reps: 5, Weight: 110.0}},
+			})
+			if err != nil {
+				t.Fatalf("RecordWorkout() second unexpected error = %v", err)
+			}
+
+			if err := tt.act(setup, userID, first, second); err != nil {
+				t.Fatalf("unexpected error = %v", err)
+			}
+
+			records := setup.personalRecordRepo.records
+			if len(records) != tt.wantCount {
+				t.Fatalf("persisted records = %d, want %d", len(records), tt.wantCount)
+			}
+			wantWorkoutID := tt.wantWorkout(first, second)
+			for _, record := range records {
+				if record.WorkoutID != wantWorkoutID || record.PreviousValue != nil {
+					t.Errorf("record = (%v, workout %v, previous %v), want first record of workout %v", record.RecordType, record.WorkoutID, record.PreviousValue, wantWorkoutID)
+				}
+			}
+		})
+	}
+}
+
+func TestWorkoutUsecase_RecalculatesFollowingDailyScores(t *testing.T) {
+	chestPart := entity.BodyPartChest
+	firstDate := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	laterDate := firstDate.AddDate(0, 0, 7)
+
+	tests := []struct {
+		name string
+		// act は1回目（1,000kg）と後続のワークアウト（500kg、スコア25）を記録した後に行う操作
+		act       func(s *workoutTestSetup, userID, exerciseID uuid.UUID, first *RecordWorkoutOutput) error
+		wantScore int32
+	}{
+		{
+			name: "正常系: 過去の日付でワークアウトを記録すると後続のスコアを再計算",
+			act: func(s *workoutTestSetup, userID, exerciseID uuid.UUID, first *RecordWorkoutOutput) error {
+				_, err := s.usecase.RecordWorkout(context.Background(), RecordWorkoutInput{
+					UserID: userID,
+					Date:   firstDate.AddDate(0, 0, 3),
+					Sets:   []SetInput{{ExerciseID: exerciseID, SetNumber: 1, Reps: 5, Weight: 400.0}},
+				})
+				return err
+			},
+			wantScore: 17, // 50 × 500 / ((1000 + 2000) / 2)
+		},
+		{
+			name: "正常系: 過去のワークアウトにセットを追加すると後続のスコアを再計算",
+			act: func(s *workoutTestSetup, userID, exerciseID uuid.UUID, first *RecordWorkoutOutput) error {
+				_, err := s.usecase.AddWorkoutSets(context.Background(), userID, first.Workout.ID, []SetInput{
+					{ExerciseID: exerciseID, SetNumber: 3, Reps: 5, Weight: 200.0},
+				})
+				return err
+			},
+			wantScore: 13, // 50 × 500 / 2000
+		},
+		{
+			name: "正常系: 過去のセットを更新すると後続のスコアを再計算",
+			act: func(s *workoutTestSetup, userID, exerciseID uuid.UUID, first *RecordWorkoutOutput) error {
+				_, err := s.usecase.UpdateWorkoutSet(context.Background(), userID, first.Sets[0].ID, UpdateWorkoutSetInput{
+					Weight: float64Ptr(500.0),
+				})
+				return err
+			},
+			wantScore: 8, // 50 × 500 / 3000
+		},
+		{
+			name: "正常系: 過去のセットを削除すると後続のスコアを再計算",
+			act: func(s *workoutTestSetup, userID, exerciseID uuid.UUID, first *RecordWorkoutOutput) error {
+				return s.usecase.DeleteWorkoutSet(context.Background(), userID, first.Sets[0].ID)
+			},
+			wantScore: 50, // 50 × 500 / 500
+		},
+		{
+			name: "正常系: 過去のワークアウトを削除すると後続のスコアを再計算",
+			act: func(s *workoutTestSetup, userID, exerciseID uuid.UUID, first *RecordWorkoutOutput) error {
+				return s.usecase.DeleteWorkout(context.Background(), userID, first.Workout.ID)
+			},
+			wantScore: 16, // 直近の記録がないため 100 × √(500 / 20000)
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup := newWorkoutTestSetup()
+			userID := uuid.New()
+			exercise := setup.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+			profile := setup.profileRepo.addProfile(userID, "テスト")
+			profile.DailyScoreStrategy = entity.DailyScoreStrategyRollingAverage
+
+			first, err := setup.usecase.RecordWorkout(context.Background(), RecordWorkoutInput{
+				UserID: userID,
+				Date:   firstDate,
+				Sets: []SetInput{
+					{ExerciseID: exercise.ID, SetNumber: 1, Reps: 5, Weight: 100.0},
+					{ExerciseID: exercise.ID, SetNumber: 2, Reps: 5, Weight: 100.0},
+				},
+			})
+			if err != nil {
+				t.Fatalf("RecordWorkout() first unexpected error = %v", err)
+			}
+			later, err := setup.usecase.RecordWorkout(context.Background(), RecordWorkoutInput{
+				UserID: userID,
+				Date:   laterDate,
+				Sets:   []SetInput{{ExerciseID: exercise.ID, SetNumber: 1, Reps: 5, Weight: 100.0}},
+			})
+			if err != nil {
+				t.Fatalf("RecordWorkout() later unexpected error = %v", err)
+			}
+			if later.Workout.DailyScore != 25 {
+				t.Fatalf("later DailyScore = %d, want 25", later.Workout.DailyScore)
+			}
+
+			if err := tt.act(setup, userID, exercise.ID, first); err != nil {
+				t.Fatalf("unexpected error = %v", err)
+			}
+
+			got, _ := setup.workoutRepo.FindByID(context.Background(), later.Workout.ID)
+			if got.DailyScore != tt.wantScore {
+				t.Errorf("later DailyScore = %d, want %d", got.DailyScore, tt.wantScore)
+			}
+		})
+	}
+}
+
+func TestWorkoutUsecase_AddWorkoutSets_PersonalRecords(t *testing.T) {
+	chestPart := entity.BodyPartChest
+	testDate := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
+
+	setup := newWorkoutTestSetup()
+	userID := uuid.New()
+	exercise := setup.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+	workout := setup.workoutRepo.addWorkout(userID, testDate)
+	setup.workoutSetRepo.addWorkoutSet(workout.ID, exercise.ID, 1, 10, 60.0)
+
+	output, err := setup.usecase.AddWorkoutSets(context.Background(), userID, workout.ID, []SetInput{
+		{ExerciseID: exercise.ID, SetNumber: 2, Reps: 10, Weight: 70.0},
+	})
+	if err != nil {
+		t.Fatalf("AddWorkoutSets() unexpected error = %v", err)
+	}
+
+	// 推定1RM + nRM(1, 3, 5, 10) + セッションボリューム
+	if len(setup.personalRecordRepo.records) != 6 {
+		t.Fatalf("persisted records = %d, want 6", len(setup.personalRecordRepo.records))
+	}
+	// 更新された自己ベストがレスポンスとして返される
+	if len(output.PersonalRecords) != 6 {
+		t.Errorf("returned records = %d, want 6", len(output.PersonalRecords))
+	}
+
+	volume := setup.personalRecordRepo.records[5]
+	if volume.RecordType != entity.PersonalRecordTypeSessionVolume {
+		t.Fatalf("records[5].RecordType = %v, want %v", volume.RecordType, entity.PersonalRecordTypeSessionVolume)
+	}
+	// 同じワークアウトの既存セットも含めたボリューム: 10*60 + 10*70 = 1300
+	if volume.Value != 1300.0 {
+		t.Errorf("session volume = %v, want 1300", volume.Value)
+	}
+	if volume.PreviousValue == nil || *volume.PreviousValue != 600.0 {
+		t.Errorf("session volume previous = %v, want 600", volume.PreviousValue)
+	}
+}
+
+func TestWorkoutUsecase_RecordWorkout_RollbackOnPersonalRecordFailure(t *testing.T) {
+	chestPart := entity.BodyPartChest
+	testDate := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
+	dbErr := errors.New("connection reset")
+
+	setup := newWorkoutTestSetup()
+	exercise := setup.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+	setup.personalRecordRepo.err = dbErr
+
+	_, err := setup.usecase.RecordWorkout(context.Background(), RecordWorkoutInput{
+		UserID: uuid.New(),
+		Date:   testDate,
+		Sets: []SetInput{
+			{ExerciseID: exercise.ID, SetNumber: 1, Reps: 5, Weight: 100.0},
+		},
+	})
+
+	if !errors.Is(err, dbErr) {
+		t.Fatalf("RecordWorkout() error = %v, want %v", err, dbErr)
+	}
+	if setup.txManager.rollbacks != 1 {
+		t.Errorf("rollbacks = %d, want 1", setup.txManager.rollbacks)
+	}
+	if len(setup.workoutRepo.workouts) != 0 {
+		t.Errorf("workouts count = %d, want 0 after rollback", len(setup.workoutRepo.workouts))
+	}
+	if len(setup.workoutSetRepo.sets) != 0 {
+		t.Errorf("sets count = %d, want 0 after rollback", len(setup.workoutSetRepo.sets))
+	}
+}
+
+func TestWorkoutUsecase_RecordWorkout_RollbackOnPartialFailure(t *testing.T) {
+	chestPart := entity.BodyPartChest
+	testDate := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
+	dbErr := errors.New("connection reset")
+
+	tests := []struct {
+		name     string
+		setup    func(*workoutTestSetup, uuid.UUID) []SetInput
+		checkErr func(error) bool
+	}{
+		{
+			name: "異常系: 3セット目のバリデーションエラーで全体がロールバック",
+			setup: func(s *workoutTestSetup, exerciseID uuid.UUID) []SetInput {
+				return []SetInput{
+					{ExerciseID: exerciseID, SetNumber: 1, Reps: 10, Weight: 60.0},
+					{ExerciseID: exerciseID, SetNumber: 2, Reps: 8, Weight: 65.0},
+					{ExerciseID: exerciseID, SetNumber: 3, Reps: 0, Weight: 70.0},
+				}
+			},
+			checkErr: func(err error) bool {
+				return errors.Is(err, entity.ErrInvalidReps)
+			},
+		},
+		{
+			name: "異常系: 3セット目の保存時にDBエラーで全体がロールバック",
+			setup: func(s *workoutTestSetup, exerciseID uuid.UUID) []SetInput {
+				s.workoutSetRepo.failCreateAt = 3
+				s.workoutSetRepo.createErr = dbErr
+				return []SetInput{
+					{ExerciseID: exerciseID, SetNumber: 1, Reps: 10, Weight: 60.0},
+					{ExerciseID: exerciseID, SetNumber: 2, Reps: 8, Weight: 65.0},
+					{ExerciseID: exerciseID, SetNumber: 3, Reps: 6, Weight: 70.0},
+				}
+			},
+			checkErr: func(err error) bool {
+				return errors.Is(err, dbErr)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup := newWorkoutTestSetup()
+			exercise := setup.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+			userID := uuid.New()
+
+			_, err := setup.usecase.RecordWorkout(context.Background(), RecordWorkoutInput{
+				UserID: userID,
+				Date:   testDate,
+				Sets:   tt.setup(setup, exercise.ID),
+			})
+
+			if err == nil || !tt.checkErr(err) {
+				t.Fatalf("RecordWorkout() error = %v, want specific error", err)
+			}
+			if setup.txManager.rollbacks != 1 {
+				t.Errorf("rollbacks = %d, want 1", setup.txManager.rollbacks)
+			}
+			if len(setup.workoutRepo.workouts) != 0 {
+				t.Errorf("workouts count = %d, want 0 after rollback", len(setup.workoutRepo.workouts))
+			}
+			if len(setup.workoutSetRepo.sets) != 0 {
+				t.Errorf("sets count = %d, want 0 after rollback", len(setup.workoutSetRepo.sets))
+			}
+		})
+	}
+}
+
+func TestWorkoutUsecase_AddWorkoutSets_RollbackOnPartialFailure(t *testing.T) {
+	chestPart := entity.BodyPartChest
+	testDate := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
+
+	setup := newWorkoutTestSetup()
+	userID := uuid.New()
+	exercise := setup.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+	workout := setup.workoutRepo.addWorkout(userID, testDate)
+	existing := setup.workoutSetRepo.addWorkoutSet(workout.ID, exercise.ID, 1, 10, 60.0)
+	workout.DailyScore = entity.NewDailyScoreStrategy(entity.DailyScoreStrategyAbsolute).CalculateDailyScore(entity.DailyScoreInput{Sets: []*entity.WorkoutSet{existing}})
+	originalScore := workout.DailyScore
+
+	_, err := setup.usecase.AddWorkoutSets(context.Background(), userID, workout.ID, []SetInput{
+		{ExerciseID: exercise.ID, SetNumber: 2, Reps: 10, Weight: 100.0},
+		{ExerciseID: exercise.ID, SetNumber: 3, Reps: 10, Weight: -1.0},
+	})
+
+	if !errors.Is(err, entity.ErrInvalidExerciseWeight) {
+		t.Fatalf("AddWorkoutSets() error = %v, want %v", err, entity.ErrInvalidExerciseWeight)
+	}
+	if len(setup.workoutSetRepo.sets) != 1 {
+		t.Errorf("sets count = %d, want 1 (only the pre-existing set)", len(setup.workoutSetRepo.sets))
+	}
+	if _, ok := setup.workoutSetRepo.sets[existing.ID]; !ok {
+		t.Error("pre-existing set was removed")
+	}
+	if got := setup.workoutRepo.workouts[workout.ID].DailyScore; got != originalScore {
+		t.Errorf("daily score = %d, want unchanged %d", got, originalScore)
+	}
+}
+
+func TestWorkoutUsecase_DeleteWorkout_RollbackOnFailure(t *testing.T) {
+	chestPart := entity.BodyPartChest
+	testDate := time.Date(2026, 2, 7, 0, 0, 0, 0, time.UTC)
+
+	setup := newWorkoutTestSetup()
+	userID := uuid.New()
+	exercise := setup.exerciseRepo.addExercise("ベンチプレス", nil, &chestPart)
+	workout := setup.workoutRepo.addWorkout(userID, testDate)
+	setup.workoutSetRepo.addWorkoutSet(workout.ID, exercise.ID, 1, 10, 60.0)
+	setup.workoutSetRepo.addWorkoutSet(workout.ID, exercise.ID, 2, 10, 60.0)
+
+	// セット削除後のワークアウト削除で失敗させる
+	failingRepo := &failingDeleteWorkoutRepository{mockWorkoutRepository: setup.workoutRepo, err: errors.New("connection reset")}
+	setup.usecase.workoutRepo = failingRepo
+
+	err := setup.usecase.DeleteWorkout(context.Background(), userID, workout.ID)
+	if err == nil {
+		t.Fatal("DeleteWorkout() error = nil, want error")
+	}
+	if len(setup.workoutSetRepo.sets) != 2 {
+		t.Errorf("sets count = %d, want 2 after rollback", len(setup.workoutSetRepo.sets))
+	}
+	if _, ok := setup.workoutRepo.workouts[workout.ID]; !ok {
+		t.Error("workout was removed despite rollback")
+	}
+}
+
+// failingDeleteWorkoutRepository はDeleteだけ失敗するWorkoutRepositoryのモック
+type failingDeleteWorkoutRepository struct {
+	*mockWorkoutRepository
+	err error
+}
+
+func (m *failingDeleteWorkoutRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return m.err
+}
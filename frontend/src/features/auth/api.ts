@@ -26,4 +26,10 @@ export const authApi = {
       method: 'POST',
       body: JSON.stringify({ email }),
     }),
+
+  resetPassword: (token: string, newPassword: string) =>
+    request<void>('/api/auth/reset-password', {
+      method: 'POST',
+      body: JSON.stringify({ token, new_password: newPassword }),
+    }),
 };
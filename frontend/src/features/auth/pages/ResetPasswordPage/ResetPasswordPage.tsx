@@ -0,0 +1,193 @@
+import { useState } from 'react';
+import { useSearchParams, useNavigate } from 'react-router';
+import { useForm } from 'react-hook-form';
+import { zodResolver } from '@hookform/resolvers/zod';
+import Box from '@mui/material/Box';
+import Typography from '@mui/material/Typography';
+import Button from '@mui/material/Button';
+import CircularProgress from '@mui/material/CircularProgress';
+import CheckCircleOutlineIcon from '@mui/icons-material/CheckCircleOutline';
+import ErrorOutlineIcon from '@mui/icons-material/ErrorOutline';
+import { ApiRequestError } from '@/shared/api';
+import { PasswordField } from '@/shared/components';
+import { useSnackbar } from '@/shared/hooks';
+import { authApi } from '../../api';
+import { BrandPanel } from '../../components/BrandPanel';
+import {
+  resetPasswordSchema,
+  type ResetPasswordFormValues,
+} from '../../schemas';
+
+type ResetState = 'form' | 'success' | 'error';
+
+export function ResetPasswordPage() {
+  const [searchParams] = useSearchParams();
+  const navigate = useNavigate();
+  const { showError } = useSnackbar();
+  const token = searchParams.get('token');
+  const [state, setState] = useState<ResetState>(token ? 'form' : 'error');
+  const [isLoading, setIsLoading] = useState(false);
+
+  const {
+    register,
+    handleSubmit,
+    formState: { errors },
+  } = useForm<ResetPasswordFormValues>({
+    resolver: zodResolver(resetPasswordSchema),
+  });
+
+  const onValid = async (data: ResetPasswordFormValues) => {
+    if (!token) return;
+    setIsLoading(true);
+    try {
+      await authApi.resetPassword(token, data.password);
+      setState('success');
+    } catch (e) {
+      if (e instanceof ApiRequestError && e.status === 400) {
+        // トークン不正とパスワードのバリデーションエラーはどちらも 400 で返る
+        if (e.message.includes('reset token')) {
+          setState('error');
+        } else {
+          showError('入力内容に誤りがあります');
+        }
+      } else {
+        showError('パスワードの再設定に失敗しました。しばらく経ってからお試しください');
+      }
+    } finally {
+      setIsLoading(false);
+    }
+  };
+
+  const handleLoginClick = () => {
+    navigate('/login');
+  };
+
+  return (
+    <Box
+      sx={{
+        display: 'flex',
+        height: '100vh',
+      }}
+    >
+      <Box sx={{ flex: 1, display: { xs: 'none', md: 'block' } }}>
+        <BrandPanel />
+      </Box>
+
+      <Box
+        sx={{
+          flex: 1,
+          display: 'flex',
+          alignItems: 'center',
+          justifyContent: 'center',
+          bgcolor: 'background.paper',
+          p: '60px 80px',
+        }}
+      >
+        {state === 'form' && (
+          <Box
+            component="form"
+            onSubmit={handleSubmit(onValid)}
+            noValidate
+            sx={{
+              display: 'flex',
+              flexDirection: 'column',
+              gap: 4,
+              width: 400,
+              maxWidth: '100%',
+            }}
+          >
+            <Box sx={{ display: 'flex', flexDirection: 'column', gap: 1 }}>
+              <Typography
+                variant="h4"
+                component="h1"
+                sx={{ fontSize: 28, fontWeight: 700 }}
+              >
+                パスワード再設定
+              </Typography>
+              <Typography sx={{ fontSize: 15, color: 'text.secondary' }}>
+                新しいパスワードを入力してください
+              </Typography>
+            </Box>
+
+            <Box sx={{ display: 'flex', flexDirection: 'column', gap: 2.5 }}>
+              <PasswordField
+                id="password"
+                label="新しいパスワード"
+                placeholder="8文字以上で入力"
+                disabled={isLoading}
+                error={!!errors.password}
+                helperText={errors.password?.message}
+                {...register('password')}
+              />
+
+              <PasswordField
+                id="passwordConfirm"
+                label="新しいパスワード（確認）"
+                placeholder="パスワードを再入力"
+                disabled={isLoading}
+                error={!!errors.passwordConfirm}
+                helperText={errors.passwordConfirm?.message}
+                {...register('passwordConfirm')}
+              />
+            </Box>
+
+            <Button
+              type="submit"
+              variant="contained"
+              fullWidth
+              disabled={isLoading}
+              sx={{ height: 48, fontSize: 16 }}
+            >
+              {isLoading ? (
+                <CircularProgress size={20} color="inherit" />
+              ) : (
+                'パスワードを再設定'
+              )}
+            </Button>
+          </Box>
+        )}
+
+        {state !== 'form' && (
+          <Box
+            sx={{
+              display: 'flex',
+              flexDirection: 'column',
+              alignItems: 'center',
+              gap: 3,
+              maxWidth: 400,
+              width: '100%',
+            }}
+          >
+            {state === 'success' && (
+              <>
+                <CheckCircleOutlineIcon sx={{ fontSize: 64, color: 'success.main' }} />
+                <Typography variant="h5" component="h1" fontWeight="bold">
+                  パスワード再設定完了
+                </Typography>
+                <Typography variant="body1" color="text.secondary" textAlign="center">
+                  新しいパスワードでログインしてください。
+                </Typography>
+              </>
+            )}
+
+            {state === 'error' && (
+              <>
+                <ErrorOutlineIcon sx={{ fontSize: 64, color: 'error.main' }} />
+                <Typography variant="h5" component="h1" fontWeight="bold">
+                  再設定に失敗しました
+                </Typography>
+                <Typography variant="body1" color="text.secondary" textAlign="center">
+                  リンクが無効または期限切れです。再度パスワードリセットを申請してください。
+                </Typography>
+              </>
+            )}
+
+            <Button variant="contained" onClick={handleLoginClick} fullWidth>
+              ログインページへ
+            </Button>
+          </Box>
+        )}
+      </Box>
+    </Box>
+  );
+}
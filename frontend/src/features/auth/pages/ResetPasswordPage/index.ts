@@ -0,0 +1 @@
+export { ResetPasswordPage } from './ResetPasswordPage';
@@ -32,3 +32,20 @@ export const registerSchema = z
   });
 
 export type RegisterFormValues = z.infer<typeof registerSchema>;
+
+export const resetPasswordSchema = z
+  .object({
+    password: z
+      .string()
+      .min(1, 'パスワードを入力してください')
+      .min(8, 'パスワードは8文字以上で入力してください'),
+    passwordConfirm: z
+      .string()
+      .min(1, 'パスワード（確認）を入力してください'),
+  })
+  .refine((data) => data.password === data.passwordConfirm, {
+    message: 'パスワードが一致しません',
+    path: ['passwordConfirm'],
+  });
+
+export type ResetPasswordFormValues = z.infer<typeof resetPasswordSchema>;
@@ -1,6 +1,7 @@
 export interface LoginRequest {
   email: string;
   password: string;
+  remember_me?: boolean;
 }
 
 export interface RegisterRequest {
@@ -16,3 +17,14 @@ export interface User {
 export interface ApiError {
   error: string;
 }
+
+export interface TwoFactorChallenge {
+  two_factor_required: true;
+  challenge_token: string;
+  expires_in: number;
+}
+
+export interface VerifyTwoFactorRequest {
+  challenge_token: string;
+  code: string;
+}
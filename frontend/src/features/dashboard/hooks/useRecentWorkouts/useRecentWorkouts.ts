@@ -12,11 +12,9 @@ export function useRecentWorkouts(limit = 5) {
     setError(null);
 
     try {
-      const result = await workoutApi.list();
-      const sorted = [...result].sort(
-        (a, b) => new Date(b.date).getTime() - new Date(a.date).getTime(),
-      );
-      setData(sorted.slice(0, limit));
+      // 一覧APIは日付の新しい順で返すため、先頭ページを limit 件だけ取得すればよい
+      const result = await workoutApi.list({ limit });
+      setData(result.workouts);
     } catch (e) {
       setError(
         e instanceof Error ? e.message : 'データの取得に失敗しました',
@@ -29,7 +29,7 @@ export const Empty = meta.story({
             HttpResponse.json([]),
           ),
           http.get('/api/workouts', () =>
-            HttpResponse.json([]),
+            HttpResponse.json({ workouts: [], next_cursor: null }),
           ),
         ],
         exercise: [
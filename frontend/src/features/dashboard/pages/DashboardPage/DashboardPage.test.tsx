@@ -91,7 +91,9 @@ describe('DashboardPage', () => {
 
   it('ワークアウトが空の場合はメッセージが表示される', async () => {
     server.use(
-      http.get('/api/workouts', () => HttpResponse.json([])),
+      http.get('/api/workouts', () =>
+        HttpResponse.json({ workouts: [], next_cursor: null }),
+      ),
     );
 
     renderPage();
@@ -1,8 +1,10 @@
 import { request } from '@/shared/api';
 import type {
   Workout,
+  WorkoutListResponse,
+  WorkoutListParams,
   WorkoutDetail,
-  WorkoutSet,
+  AddSetsResponse,
   RecordWorkoutRequest,
   SetInput,
   ContributionData,
@@ -16,12 +18,23 @@ export const workoutApi = {
       body: JSON.stringify(data),
     }),
 
-  list: (startDate?: string, endDate?: string) => {
+  list: ({
+    startDate,
+    endDate,
+    cursor,
+    limit,
+    expand,
+  }: WorkoutListParams = {}) => {
     const params = new URLSearchParams();
     if (startDate) params.set('start_date', startDate);
     if (endDate) params.set('end_date', endDate);
+    if (cursor) params.set('cursor', cursor);
+    if (limit) params.set('limit', String(limit));
+    if (expand?.length) params.set('expand', expand.join(','));
     const query = params.toString();
-    return request<Workout[]>(`/api/workouts${query ? `?${query}` : ''}`);
+    return request<WorkoutListResponse>(
+      `/api/workouts${query ? `?${query}` : ''}`,
+    );
   },
 
   get: (id: string) => request<WorkoutDetail>(`/api/workouts/${id}`),
@@ -33,7 +46,7 @@ export const workoutApi = {
     }),
 
   addSets: (id: string, sets: SetInput[]) =>
-    request<WorkoutSet[]>(`/api/workouts/${id}/sets`, {
+    request<AddSetsResponse>(`/api/workouts/${id}/sets`, {
       method: 'POST',
       body: JSON.stringify({ sets }),
     }),
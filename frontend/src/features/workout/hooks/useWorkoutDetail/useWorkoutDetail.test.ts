@@ -38,7 +38,7 @@ describe('useWorkoutDetail', () => {
     mockDelete.mockClear().mockResolvedValue(undefined);
     mockDeleteSet.mockClear().mockResolvedValue(undefined);
     mockUpdateMemo.mockClear().mockResolvedValue({});
-    mockAddSets.mockClear().mockResolvedValue([]);
+    mockAddSets.mockClear().mockResolvedValue({ sets: [] });
   });
 
   it('id を渡すとデータを取得する', async () => {
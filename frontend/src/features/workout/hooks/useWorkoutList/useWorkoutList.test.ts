@@ -25,23 +25,24 @@ const mockWorkouts = [
   { id: 'w2', user_id: 'u1', date: '2026-02-08T00:00:00Z', daily_score: 4, memo: 'レッグデー', created_at: '', updated_at: '' },
 ];
 
-const mockDetails: Record<string, unknown> = {
-  w1: {
-    workout: mockWorkouts[0],
+// expand=sets でセットが埋め込まれた一覧レスポンス
+const mockItems = [
+  {
+    ...mockWorkouts[0],
     sets: [{ id: 's1', workout_id: 'w1', exercise_id: 'e1', set_number: 1, reps: 10, weight: 80, estimated_1rm: 107, duration_seconds: null, notes: null, created_at: '' }],
   },
-  w2: {
-    workout: mockWorkouts[1],
+  {
+    ...mockWorkouts[1],
     sets: [{ id: 's2', workout_id: 'w2', exercise_id: 'e2', set_number: 1, reps: 8, weight: 100, estimated_1rm: 120, duration_seconds: null, notes: null, created_at: '' }],
   },
-};
+];
 
 describe('useWorkoutList', () => {
   beforeEach(() => {
-    mockList.mockClear().mockResolvedValue(mockWorkouts);
-    mockGet.mockClear().mockImplementation((id: string) =>
-      Promise.resolve(mockDetails[id]),
-    );
+    mockList
+      .mockClear()
+      .mockResolvedValue({ workouts: mockItems, next_cursor: null });
+    mockGet.mockClear();
   });
 
   it('データを取得して日付降順でソートする', async () => {
@@ -56,6 +57,18 @@ describe('useWorkoutList', () => {
     expect(result.current.exercises).toHaveLength(2);
   });
 
+  it('一覧に埋め込まれたセットを使い、ワークアウトごとの詳細取得はしない', async () => {
+    const { result } = renderHook(() => useWorkoutList());
+
+    await waitFor(() => {
+      expect(result.current.workouts).toHaveLength(2);
+    });
+
+    expect(mockList).toHaveBeenCalledWith({ expand: ['sets'] });
+    expect(mockGet).not.toHaveBeenCalled();
+    expect(result.current.workouts[1].sets[0].id).toBe('s1');
+  });
+
   it('exerciseFilter で絞り込める', async () => {
     const { result } = renderHook(() => useWorkoutList());
 
@@ -104,4 +117,28 @@ describe('useWorkoutList', () => {
     });
     expect(result.current.page).toBe(1);
   });
+
+  it('next_cursor がある場合は loadMore で次のページを追加する', async () => {
+    mockList
+      .mockResolvedValueOnce({ workouts: [mockItems[1]], next_cursor: 'c1' })
+      .mockResolvedValueOnce({ workouts: [mockItems[0]], next_cursor: null });
+
+    const { result } = renderHook(() => useWorkoutList());
+
+    await waitFor(() => {
+      expect(result.current.workouts).toHaveLength(1);
+    });
+    expect(result.current.hasMore).toBe(true);
+
+    await act(async () => {
+      await result.current.loadMore();
+    });
+
+    expect(mockList).toHaveBeenLastCalledWith({
+      cursor: 'c1',
+      expand: ['sets'],
+    });
+    expect(result.current.workouts).toHaveLength(2);
+    expect(result.current.hasMore).toBe(false);
+  });
 });
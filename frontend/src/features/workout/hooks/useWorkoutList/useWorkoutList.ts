@@ -2,33 +2,53 @@ import { useCallback, useEffect, useMemo, useState } from 'react';
 import { exerciseApi } from '@/features/exercise/api';
 import type { Exercise } from '@/features/exercise';
 import { workoutApi } from '../../api';
-import type { Workout, WorkoutDetail } from '../../types';
+import type { WorkoutDetail, WorkoutListItem } from '../../types';
 
 const ITEMS_PER_PAGE = 5;
 
+// 一覧APIの expand=sets で埋め込まれたセットを、詳細と同じ形に変換する
+function toWorkoutDetail(item: WorkoutListItem): WorkoutDetail {
+  return { workout: item, sets: item.sets ?? [] };
+}
+
 export function useWorkoutList() {
   const [workouts, setWorkouts] = useState<WorkoutDetail[]>([]);
   const [exercises, setExercises] = useState<Exercise[]>([]);
   const [searchQuery, setSearchQuery] = useState('');
   const [exerciseFilter, setExerciseFilter] = useState('all');
   const [page, setPage] = useState(1);
+  const [nextCursor, setNextCursor] = useState<string | null>(null);
+  const [loadingMore, setLoadingMore] = useState(false);
 
   const reload = useCallback(async () => {
-    const [workoutList, exerciseList] = await Promise.all([
-      workoutApi.list(),
+    const [result, exerciseList] = await Promise.all([
+      workoutApi.list({ expand: ['sets'] }),
       exerciseApi.list(),
     ]);
     setExercises(exerciseList);
-    const details = await Promise.all(
-      workoutList.map((w: Workout) => workoutApi.get(w.id)),
-    );
-    setWorkouts(details);
+    setWorkouts(result.workouts.map(toWorkoutDetail));
+    setNextCursor(result.next_cursor);
   }, []);
 
   useEffect(() => {
     reload();
   }, [reload]);
 
+  const loadMore = useCallback(async () => {
+    if (!nextCursor) return;
+    setLoadingMore(true);
+    try {
+      const result = await workoutApi.list({
+        cursor: nextCursor,
+        expand: ['sets'],
+      });
+      setWorkouts((prev) => [...prev, ...result.workouts.map(toWorkoutDetail)]);
+      setNextCursor(result.next_cursor);
+    } finally {
+      setLoadingMore(false);
+    }
+  }, [nextCursor]);
+
   const filtered = useMemo(() => {
     return workouts.filter((wd) => {
       if (exerciseFilter !== 'all') {
@@ -88,5 +108,8 @@ export function useWorkoutList() {
     changeSearchQuery,
     changeExerciseFilter,
     setPage,
+    hasMore: nextCursor !== null,
+    loadingMore,
+    loadMore,
   };
 }
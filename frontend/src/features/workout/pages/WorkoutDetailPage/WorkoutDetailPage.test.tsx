@@ -39,7 +39,7 @@ vi.mock('../../api', () => ({
     delete: (...args: unknown[]) => mockDelete(...args),
     deleteSet: vi.fn().mockResolvedValue(undefined),
     updateMemo: vi.fn().mockResolvedValue({}),
-    addSets: vi.fn().mockResolvedValue([]),
+    addSets: vi.fn().mockResolvedValue({ sets: [] }),
   },
 }));
 
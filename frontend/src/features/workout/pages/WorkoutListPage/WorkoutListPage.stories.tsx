@@ -25,7 +25,9 @@ export const Empty = meta.story({
     msw: {
       handlers: {
         workout: [
-          http.get('/api/workouts', () => HttpResponse.json([])),
+          http.get('/api/workouts', () =>
+            HttpResponse.json({ workouts: [], next_cursor: null }),
+          ),
         ],
       },
     },
@@ -39,7 +41,7 @@ export const Loading = meta.story({
         workout: [
           http.get('/api/workouts', async () => {
             await delay('infinite');
-            return HttpResponse.json([]);
+            return HttpResponse.json({ workouts: [], next_cursor: null });
           }),
         ],
         exercise: [
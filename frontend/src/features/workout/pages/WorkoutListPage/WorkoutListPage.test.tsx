@@ -19,14 +19,16 @@ vi.mock('react-router', async () => {
 
 vi.mock('../../api', () => ({
   workoutApi: {
-    list: vi.fn().mockResolvedValue([
-      { id: 'w1', user_id: 'u1', date: '2026-02-07T00:00:00Z', daily_score: 3, memo: null, created_at: '', updated_at: '' },
-    ]),
-    get: vi.fn().mockResolvedValue({
-      workout: { id: 'w1', user_id: 'u1', date: '2026-02-07T00:00:00Z', daily_score: 3, memo: null, created_at: '', updated_at: '' },
-      sets: [
-        { id: 's1', workout_id: 'w1', exercise_id: 'e1', set_number: 1, reps: 10, weight: 80, estimated_1rm: 107, duration_seconds: null, notes: null, created_at: '' },
+    list: vi.fn().mockResolvedValue({
+      workouts: [
+        {
+          id: 'w1', user_id: 'u1', date: '2026-02-07T00:00:00Z', daily_score: 3, memo: null, created_at: '', updated_at: '',
+          sets: [
+            { id: 's1', workout_id: 'w1', exercise_id: 'e1', set_number: 1, reps: 10, weight: 80, estimated_1rm: 107, duration_seconds: null, notes: null, created_at: '' },
+          ],
+        },
       ],
+      next_cursor: null,
     }),
   },
 }));
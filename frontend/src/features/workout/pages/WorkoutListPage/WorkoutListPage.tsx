@@ -20,6 +20,9 @@ export function WorkoutListPage() {
     changeSearchQuery,
     changeExerciseFilter,
     setPage,
+    hasMore,
+    loadingMore,
+    loadMore,
   } = useWorkoutList();
 
   return (
@@ -93,6 +96,17 @@ export function WorkoutListPage() {
             onPageChange={setPage}
           />
         )}
+
+        {hasMore && (
+          <Button
+            variant="outlined"
+            onClick={loadMore}
+            disabled={loadingMore}
+            sx={{ alignSelf: 'center', borderRadius: '12px' }}
+          >
+            さらに読み込む
+          </Button>
+        )}
       </Box>
     </Box>
   );
@@ -8,6 +8,38 @@ export interface Workout {
   updated_at: string;
 }
 
+export interface WorkoutListResponse {
+  workouts: WorkoutListItem[];
+  next_cursor: string | null;
+}
+
+export interface WorkoutListParams {
+  startDate?: string;
+  endDate?: string;
+  cursor?: string;
+  limit?: number;
+  expand?: ('sets' | 'exercises')[];
+}
+
+export interface WorkoutListItem extends Workout {
+  sets?: WorkoutSet[];
+  exercises?: WorkoutExerciseSummary[];
+  summary?: WorkoutSummary;
+}
+
+export interface WorkoutExerciseSummary {
+  id: string;
+  name: string;
+  body_part: string | null;
+}
+
+export interface WorkoutSummary {
+  total_volume: number;
+  set_count: number;
+  exercise_count: number;
+  body_parts: string[];
+}
+
 export interface WorkoutSet {
   id: string;
   workout_id: string;
@@ -26,6 +58,10 @@ export interface WorkoutDetail {
   sets: WorkoutSet[];
 }
 
+export interface AddSetsResponse {
+  sets: WorkoutSet[];
+}
+
 export interface SetInput {
   exercise_id: string;
   set_number: number;
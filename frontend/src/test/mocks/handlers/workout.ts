@@ -6,8 +6,15 @@ export const workoutHandlers = [
     return HttpResponse.json(mockContributions);
   }),
 
-  http.get('/api/workouts', () => {
-    return HttpResponse.json(mockWorkouts);
+  http.get('/api/workouts', ({ request }) => {
+    const expand = new URL(request.url).searchParams.get('expand') ?? '';
+    const workouts = expand.split(',').includes('sets')
+      ? mockWorkouts.map((w) => ({
+          ...w,
+          sets: mockWorkoutDetails[w.id]?.sets ?? [],
+        }))
+      : mockWorkouts;
+    return HttpResponse.json({ workouts, next_cursor: null });
   }),
 
   http.get('/api/workouts/:id', ({ params }) => {
@@ -63,7 +70,10 @@ export const workoutHandlers = [
       estimated_1rm: 100,
       created_at: new Date().toISOString(),
     }));
-    return HttpResponse.json(newSets, { status: 201 });
+    return HttpResponse.json(
+      { sets: newSets, personal_records: [] },
+      { status: 201 },
+    );
   }),
 
   http.delete('/api/workouts/:id', () => {